@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// accountDeletionConfirmation must be typed to delete an account
+const accountDeletionConfirmation = "DELETE"
+
+// DeleteAccountRequest represents the request payload for deleting the caller's account
+type DeleteAccountRequest struct {
+	Confirm        string `json:"confirm"`
+	DeleteIdentity bool   `json:"deleteIdentity"` // Also delete the user at the identity provider
+}
+
+// AccountDeletionReport counts what was deleted or anonymized when an account was deleted
+type AccountDeletionReport struct {
+	BoardsDeleted          int64 `json:"boardsDeleted"`
+	BoardsTransferred      int64 `json:"boardsTransferred"` // Workspace boards handed to another admin of their workspace
+	BoardsAnonymized       int64 `json:"boardsAnonymized"`  // Workspace boards kept without an owner, when no other admin was left
+	IdeasDeleted           int64 `json:"ideasDeleted"`
+	FeedbackDeleted        int64 `json:"feedbackDeleted"` // Votes, comments, suggestions and subscribers of deleted boards
+	MembershipsRemoved     int64 `json:"membershipsRemoved"`
+	AssignmentsCleared     int64 `json:"assignmentsCleared"`
+	TokensDeleted          int64 `json:"tokensDeleted"` // Personal access, board API and embed tokens
+	ServiceAccountsDeleted int64 `json:"serviceAccountsDeleted"`
+	TemplatesDeleted       int64 `json:"templatesDeleted"`
+	AuditEntriesAnonymized int64 `json:"auditEntriesAnonymized"`
+	ActivitiesAnonymized   int64 `json:"activitiesAnonymized"`
+	FilesDeleted           int64 `json:"filesDeleted"` // Logos, attachments and export files of deleted boards
+	IdentityDeleted        bool  `json:"identityDeleted"`
+}
+
+// boardContentCollections hold documents that belong to a single board and go with it
+var boardContentCollections = []string{
+	models.ReleasesCollection,
+	models.SubmissionsCollection,
+	models.CommentsCollection,
+	models.VotesCollection,
+	models.SubscribersCollection,
+	models.InvitationsCollection,
+	models.APITokensCollection,
+	models.EmbedTokensCollection,
+	models.NotificationPrefsCollection,
+	models.BoardWebhooksCollection,
+	models.TelegramConfigsCollection,
+	models.JiraConfigsCollection,
+	models.LinearConfigsCollection,
+	models.AlertRulesCollection,
+	models.AlertFiringsCollection,
+	models.WebhookDeliveriesCollection,
+	models.NotificationJobsCollection,
+	models.UserNotificationsCollection,
+	models.NotificationBatchesCollection,
+	models.ExportConfigsCollection,
+	models.ActivitiesCollection,
+	models.AttachmentsCollection,
+	models.ScoreHistoryCollection,
+}
+
+// findWorkspaceHeirs picks who takes over the workspace boards a user owns: the longest-standing other
+// admin of each workspace. It also returns the workspaces left without another admin, or whose admins
+// the identity provider cannot list.
+func findWorkspaceHeirs(ctx context.Context, userID string) (map[string]string, []string, error) {
+	var workspaceIDs []string
+	var err error
+	models.ForEachTenant(ctx, func(ctx context.Context) {
+		if err != nil {
+			return
+		}
+		var tenantWorkspaces []string
+		err = models.GetCollection(ctx, models.BoardsCollection).Distinct(ctx, "workspace_id",
+			bson.M{"user_id": userID, "workspace_id": bson.M{"$nin": bson.A{nil, ""}}}).Decode(&tenantWorkspaces)
+		workspaceIDs = append(workspaceIDs, tenantWorkspaces...)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	heirs := make(map[string]string, len(workspaceIDs))
+	var orphaned []string
+	for _, workspaceID := range workspaceIDs {
+		admins, err := middleware.WorkspaceAdmins(ctx, workspaceID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, admin := range admins {
+			if admin != userID {
+				heirs[workspaceID] = admin
+				break
+			}
+		}
+		if _, found := heirs[workspaceID]; !found {
+			orphaned = append(orphaned, workspaceID)
+		}
+	}
+	return heirs, orphaned, nil
+}
+
+// deleteUserData removes a user's data: personal boards and everything on them are deleted, workspace
+// boards go to the heirs picked by findWorkspaceHeirs, and records others rely on are kept with the
+// user's ID anonymized. Every step is idempotent, so a deletion that fails part way can simply be run
+// again. With tenancy on, the data of every workspace is visited.
+func deleteUserData(ctx context.Context, userID string, heirs map[string]string) (*AccountDeletionReport, error) {
+	report := &AccountDeletionReport{}
+	var err error
+	models.ForEachTenant(ctx, func(ctx context.Context) {
+		if err == nil {
+			err = deleteTenantUserData(ctx, userID, heirs, report)
+		}
+	})
+	return report, err
+}
+
+// deleteTenantUserData deletes the user's data in the tenant of ctx, adding to report
+func deleteTenantUserData(ctx context.Context, userID string, heirs map[string]string, report *AccountDeletionReport) error {
+	boards := models.GetCollection(ctx, models.BoardsCollection)
+	// Boards are deleted and updated by owner and member, not by ID
+	defer models.InvalidateBoards()
+
+	// Personal boards go with their owner
+	boardIDs, err := findOwnedBoardIDs(ctx, bson.M{"user_id": userID, "workspace_id": bson.M{"$in": bson.A{nil, ""}}})
+	if err != nil {
+		return err
+	}
+	if err := deleteBoards(ctx, boardIDs, report); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	// Workspace boards belong to the organization, so another of its admins takes them over
+	for workspaceID, heir := range heirs {
+		updated, err := boards.UpdateMany(ctx, bson.M{"user_id": userID, "workspace_id": workspaceID}, bson.M{
+			"$set":  bson.M{"user_id": heir, "updated_at": now},
+			"$pull": bson.M{"members": bson.M{"user_id": heir}},
+		})
+		if err != nil {
+			return err
+		}
+		report.BoardsTransferred += updated.ModifiedCount
+	}
+
+	// Only users deleted at the identity provider first can leave a workspace without another admin;
+	// its boards then stay without an owner
+	updated, err := boards.UpdateMany(ctx, bson.M{"user_id": userID}, bson.M{
+		"$set": bson.M{"user_id": models.DeletedUserID, "updated_at": now},
+	})
+	if err != nil {
+		return err
+	}
+	report.BoardsAnonymized += updated.ModifiedCount
+
+	updated, err = boards.UpdateMany(ctx, bson.M{"members.user_id": userID}, bson.M{
+		"$pull": bson.M{"members": bson.M{"user_id": userID}},
+		"$set":  bson.M{"updated_at": now},
+	})
+	if err != nil {
+		return err
+	}
+	report.MembershipsRemoved += updated.ModifiedCount
+
+	updated, err = models.GetCollection(ctx, models.IdeasCollection).UpdateMany(ctx, bson.M{"assignee_id": userID}, bson.M{
+		"$unset": bson.M{"assignee_id": ""},
+		"$set":   bson.M{"updated_at": now},
+	})
+	if err != nil {
+		return err
+	}
+	report.AssignmentsCleared += updated.ModifiedCount
+
+	// Records that only exist for the user
+	owned := bson.M{"user_id": userID}
+	for _, name := range []string{models.PersonalTokensCollection, models.APITokensCollection, models.EmbedTokensCollection} {
+		result, err := models.GetCollection(ctx, name).DeleteMany(ctx, owned)
+		if err != nil {
+			return err
+		}
+		report.TokensDeleted += result.DeletedCount
+	}
+	for _, name := range []string{models.ExportConfigsCollection, models.NotificationPrefsCollection, models.UserNotificationsCollection, models.PushSubscriptionsCollection, models.AlertRulesCollection, models.AlertFiringsCollection} {
+		if _, err := models.GetCollection(ctx, name).DeleteMany(ctx, owned); err != nil {
+			return err
+		}
+	}
+	result, err := models.GetCollection(ctx, models.ServiceAccountsCollection).DeleteMany(ctx, owned)
+	if err != nil {
+		return err
+	}
+	report.ServiceAccountsDeleted += result.DeletedCount
+
+	result, err = models.GetCollection(ctx, models.TemplatesCollection).DeleteMany(ctx, bson.M{"author_id": userID})
+	if err != nil {
+		return err
+	}
+	report.TemplatesDeleted += result.DeletedCount
+
+	// History others rely on is kept, but no longer points at the user
+	auditLog := models.GetCollection(ctx, models.AuditLogCollection)
+	updated, err = auditLog.UpdateMany(ctx, bson.M{"actor_id": userID}, bson.M{
+		"$set":   bson.M{"actor_id": models.DeletedUserID},
+		"$unset": bson.M{"ip": "", "user_agent": ""},
+	})
+	if err != nil {
+		return err
+	}
+	report.AuditEntriesAnonymized += updated.ModifiedCount
+
+	updated, err = auditLog.UpdateMany(ctx,
+		bson.M{"target_type": models.AuditTargetMember, "target_id": userID},
+		bson.M{"$set": bson.M{"target_id": models.DeletedUserID}})
+	if err != nil {
+		return err
+	}
+	report.AuditEntriesAnonymized += updated.ModifiedCount
+
+	updated, err = auditLog.UpdateMany(ctx, bson.M{"impersonator_id": userID}, bson.M{
+		"$set": bson.M{"impersonator_id": models.DeletedUserID},
+	})
+	if err != nil {
+		return err
+	}
+	report.AuditEntriesAnonymized += updated.ModifiedCount
+
+	updated, err = models.GetCollection(ctx, models.ActivitiesCollection).UpdateMany(ctx, bson.M{"actor_id": userID}, bson.M{
+		"$set": bson.M{"actor_id": models.DeletedUserID},
+	})
+	if err != nil {
+		return err
+	}
+	report.ActivitiesAnonymized += updated.ModifiedCount
+
+	invitations := models.GetCollection(ctx, models.InvitationsCollection)
+	for _, field := range []string{"invited_by", "accepted_by"} {
+		if _, err := invitations.UpdateMany(ctx, bson.M{field: userID}, bson.M{"$set": bson.M{field: models.DeletedUserID}}); err != nil {
+			return err
+		}
+	}
+	if _, err := boards.UpdateMany(ctx, bson.M{"members.added_by": userID}, bson.M{
+		"$set": bson.M{"members.$[added].added_by": models.DeletedUserID},
+	}, options.UpdateMany().SetArrayFilters([]interface{}{bson.M{"added.added_by": userID}})); err != nil {
+		return err
+	}
+	impersonations := models.GetCollection(ctx, models.ImpersonationsCollection)
+	for _, field := range []string{"admin_id", "user_id"} {
+		if _, err := impersonations.UpdateMany(ctx, bson.M{field: userID}, bson.M{"$set": bson.M{field: models.DeletedUserID}}); err != nil {
+			return err
+		}
+	}
+	if _, err := models.GetCollection(ctx, models.WorkspacesCollection).UpdateMany(ctx, bson.M{"created_by": userID}, bson.M{
+		"$set": bson.M{"created_by": models.DeletedUserID},
+	}); err != nil {
+		return err
+	}
+	for _, collection := range []string{models.BoardsCollection, models.IdeasCollection} {
+		for _, field := range []string{"created_by", "updated_by"} {
+			if _, err := models.GetCollection(ctx, collection).UpdateMany(ctx, bson.M{field: userID}, bson.M{"$set": bson.M{field: models.DeletedUserID}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := models.GetCollection(ctx, models.AttachmentsCollection).UpdateMany(ctx, bson.M{"created_by": userID},
+		bson.M{"$set": bson.M{"created_by": models.DeletedUserID}}); err != nil {
+		return err
+	}
+	if _, err := models.GetCollection(ctx, models.ScoreHistoryCollection).UpdateMany(ctx, bson.M{"changed_by": userID},
+		bson.M{"$set": bson.M{"changed_by": models.DeletedUserID}}); err != nil {
+		return err
+	}
+
+	if _, err := models.GetCollection(ctx, models.UsersCollection).DeleteOne(ctx, bson.M{"_id": userID}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// deleteBoards deletes boards and everything on them, adding what was deleted to report. Callers
+// invalidate the board cache.
+func deleteBoards(ctx context.Context, boardIDs []string, report *AccountDeletionReport) error {
+	if len(boardIDs) == 0 {
+		return nil
+	}
+	inBoards := bson.M{"board_id": bson.M{"$in": boardIDs}}
+
+	// Files go first so a storage failure leaves the boards in place for another attempt
+	for _, boardID := range boardIDs {
+		deleted, err := utils.GetStorage().DeletePrefix(ctx, models.BoardStoragePrefix(ctx, boardID))
+		report.FilesDeleted += int64(deleted)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := models.GetCollection(ctx, models.IdeasCollection).DeleteMany(ctx, inBoards)
+	if err != nil {
+		return err
+	}
+	report.IdeasDeleted += result.DeletedCount
+
+	for _, name := range boardContentCollections {
+		result, err := models.GetCollection(ctx, name).DeleteMany(ctx, inBoards)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case models.SubmissionsCollection, models.CommentsCollection, models.VotesCollection, models.SubscribersCollection:
+			report.FeedbackDeleted += result.DeletedCount
+		case models.APITokensCollection, models.EmbedTokensCollection:
+			report.TokensDeleted += result.DeletedCount
+		}
+	}
+
+	if _, err := models.GetCollection(ctx, models.ServiceAccountsCollection).UpdateMany(ctx,
+		bson.M{"scopes.board_id": bson.M{"$in": boardIDs}},
+		bson.M{"$pull": bson.M{"scopes": inBoards}}); err != nil {
+		return err
+	}
+
+	if _, err := models.GetCollection(ctx, models.PublicBoardsCollection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": boardIDs}}); err != nil {
+		return err
+	}
+
+	result, err = models.GetCollection(ctx, models.BoardsCollection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": boardIDs}})
+	if err != nil {
+		return err
+	}
+	report.BoardsDeleted += result.DeletedCount
+	return nil
+}
+
+// findOwnedBoardIDs returns the IDs of the boards matching filter
+func findOwnedBoardIDs(ctx context.Context, filter bson.M) ([]string, error) {
+	cursor, err := models.GetCollection(ctx, models.BoardsCollection).Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var owned []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &owned); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(owned))
+	for _, board := range owned {
+		ids = append(ids, board.ID)
+	}
+	return ids, nil
+}
+
+// DeleteAccount handles DELETE /api/user, erasing the caller's data on request. The user confirms by
+// typing DELETE, and may also have their identity provider account deleted.
+func DeleteAccount(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Confirm != accountDeletionConfirmation {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "CONFIRMATION_REQUIRED",
+				"message": "Send confirm: \"DELETE\" to delete your account",
+			},
+		})
+		return
+	}
+
+	// Keep deleting if the client goes away, so the account is not left half deleted
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), 60*time.Second)
+	defer cancel()
+
+	// Workspace boards need another admin to take them over before their owner can leave
+	heirs, orphaned, err := findWorkspaceHeirs(ctx, userID)
+	if err != nil {
+		log.Printf("[Handler] DeleteAccount failed - Workspace admin lookup error: %v, UserID: %s, IP: %s", err, userID, c.ClientIP())
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "WORKSPACE_ADMIN_LOOKUP_FAILED",
+				"message": "Failed to find who takes over your workspace boards; try again",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if len(orphaned) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "WORKSPACE_OWNERSHIP_REQUIRED",
+				"message": "Make another member an admin of your workspaces, or delete the boards you own in them, before deleting your account",
+				"details": "Workspaces without another admin: " + strings.Join(orphaned, ", "),
+			},
+		})
+		return
+	}
+
+	report, err := deleteUserData(ctx, userID, heirs)
+	if err != nil {
+		log.Printf("[Handler] DeleteAccount failed - Error: %v, Report: %+v, UserID: %s, IP: %s", err, *report, userID, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete account data; try again to finish the deletion",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if req.DeleteIdentity {
+		supported, err := middleware.DeleteIdentity(ctx, userID)
+		if err != nil {
+			log.Printf("[Handler] DeleteAccount failed - Provider error: %v, UserID: %s, IP: %s", err, userID, c.ClientIP())
+			c.JSON(http.StatusBadGateway, gin.H{
+				"error": gin.H{
+					"code":    "IDENTITY_DELETE_FAILED",
+					"message": "Your data was deleted but your sign-in account could not be; try again",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		report.IdentityDeleted = supported
+	}
+
+	log.Printf("[Handler] DeleteAccount success - Report: %+v, UserID: %s, IP: %s", *report, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// workspaceAdminsVerifier is an identity provider that only lists workspace admins
+type workspaceAdminsVerifier struct {
+	admins map[string][]string
+}
+
+func (workspaceAdminsVerifier) Name() string { return "test" }
+
+func (workspaceAdminsVerifier) Verify(context.Context, string) (*middleware.Identity, error) {
+	return nil, errors.New("tokens are not verified in these tests")
+}
+
+func (v workspaceAdminsVerifier) WorkspaceAdmins(_ context.Context, orgID string) ([]string, error) {
+	return v.admins[orgID], nil
+}
+
+// createWorkspaceBoard inserts a workspace board owned by userID with the given members
+func createWorkspaceBoard(t *testing.T, userID, workspaceID string, memberIDs ...string) string {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	board := models.Board{
+		ID:          utils.GenerateFullUUID(),
+		Name:        "Workspace board",
+		PublicLink:  utils.GenerateFullUUID(),
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, memberID := range memberIDs {
+		board.Members = append(board.Members, models.BoardMember{UserID: memberID, Role: models.RoleEditor, AddedBy: userID, AddedAt: now})
+	}
+	_, err := models.GetCollection(ctx, models.BoardsCollection).InsertOne(ctx, board)
+	require.NoError(t, err)
+	return board.ID
+}
+
+func TestDeleteAccountWorkspaceBoards(t *testing.T) {
+	connectTestDatabase(t)
+	ctx := context.Background()
+
+	userID := "user_" + utils.GenerateShortUUID()
+	heirID := "user_" + utils.GenerateShortUUID()
+	sharedWorkspace := "org_" + utils.GenerateShortUUID()
+	soloWorkspace := "org_" + utils.GenerateShortUUID()
+
+	// Handler tests authenticate through serveAs, so no test relies on the configured provider
+	middleware.SetTokenVerifier(workspaceAdminsVerifier{admins: map[string][]string{
+		sharedWorkspace: {userID, heirID},
+		soloWorkspace:   {userID},
+	}})
+
+	t.Run("Workspaces Without Another Admin Block Deletion", func(t *testing.T) {
+		createWorkspaceBoard(t, userID, soloWorkspace)
+		_, orphaned, err := findWorkspaceHeirs(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{soloWorkspace}, orphaned)
+
+		_, err = models.GetCollection(ctx, models.BoardsCollection).DeleteMany(ctx, bson.M{"workspace_id": soloWorkspace})
+		require.NoError(t, err)
+	})
+
+	t.Run("Boards Go To Another Admin", func(t *testing.T) {
+		boardID := createWorkspaceBoard(t, userID, sharedWorkspace, heirID)
+
+		heirs, orphaned, err := findWorkspaceHeirs(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, orphaned)
+		assert.Equal(t, map[string]string{sharedWorkspace: heirID}, heirs)
+
+		report, err := deleteUserData(ctx, userID, heirs)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), report.BoardsTransferred)
+		assert.Zero(t, report.BoardsAnonymized)
+
+		board, err := models.FindBoardByID(ctx, boardID)
+		require.NoError(t, err)
+		assert.Equal(t, heirID, board.UserID)
+		assert.Empty(t, board.Members, "the new owner is no longer listed as a member")
+	})
+}
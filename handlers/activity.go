@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// GetBoardActivityRequest represents query parameters for the activity feed
+type GetBoardActivityRequest struct {
+	Type     string `form:"type"`
+	IdeaID   string `form:"ideaId"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// GetBoardActivity handles GET /api/boards/:id/activity
+func GetBoardActivity(c *gin.Context) {
+	startTime := time.Now()
+
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	// Get board ID from URL parameter
+	boardID := c.Param("id")
+	if boardID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_BOARD_ID",
+				"message": "Board ID is required",
+			},
+		})
+		return
+	}
+
+	// Parse query parameters
+	var req GetBoardActivityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if req.Type != "" && !models.IsValidActivityType(req.Type) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_ACTIVITY_TYPE",
+				"message": "Invalid activity type: " + req.Type,
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 50
+	}
+
+	ctx := c.Request.Context()
+
+	// Build filter for activity entries
+	filter := bson.M{"board_id": boardID}
+	if req.Type != "" {
+		filter["type"] = req.Type
+	}
+	if req.IdeaID != "" {
+		filter["idea_id"] = req.IdeaID
+	}
+
+	// Newest first
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	activitiesCollection := models.GetCollection(ctx, models.ActivitiesCollection)
+	cursor, err := activitiesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch activity",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	activities := []models.Activity{}
+	if err := cursor.All(ctx, &activities); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode activity",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := activitiesCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count activity",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] GetBoardActivity success - BoardID: %s, UserID: %s, Entries: %d, Total: %d, Duration: %v, IP: %s",
+		boardID, userID, len(activities), totalCount, time.Since(startTime), c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"activities": activities,
+		"count":      len(activities),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
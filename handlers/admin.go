@@ -0,0 +1,475 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"disko-backend/jobs"
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AdminListRequest represents the query parameters of paginated admin listings
+type AdminListRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+	UserID   string `form:"userId"` // Boards: filter by owner; impersonations: by impersonated user
+	Name     string `form:"name"`   // Boards only: case-insensitive "contains" match
+}
+
+// AdminModerateBoardRequest represents a moderation action on any board
+type AdminModerateBoardRequest struct {
+	IsPublic *bool  `json:"isPublic,omitempty"`
+	Frozen   *bool  `json:"frozen,omitempty"`
+	Archived *bool  `json:"archived,omitempty"`
+	Reason   string `json:"reason,omitempty" binding:"max=500"`
+}
+
+// AdminBoardSummary is a board as listed to platform admins
+type AdminBoardSummary struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	UserID       string    `json:"userId"`
+	WorkspaceID  string    `json:"workspaceId,omitempty"`
+	IsPublic     bool      `json:"isPublic"`
+	Archived     bool      `json:"archived"`
+	Frozen       bool      `json:"frozen"`
+	MembersCount int       `json:"membersCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// AdminUserSummary is a board owner with their board counts
+type AdminUserSummary struct {
+	UserID       string    `bson:"_id" json:"userId"`
+	Boards       int       `bson:"boards" json:"boards"`
+	PublicBoards int       `bson:"public_boards" json:"publicBoards"`
+	LastActiveAt time.Time `bson:"last_active_at" json:"lastActiveAt"`
+}
+
+// PlatformStats holds platform-wide totals
+type PlatformStats struct {
+	Users          int   `json:"users"` // Distinct board owners
+	Boards         int64 `json:"boards"`
+	PublicBoards   int64 `json:"publicBoards"`
+	Ideas          int64 `json:"ideas"`
+	Feedback       int64 `json:"feedback"`
+	Comments       int64 `json:"comments"`
+	Submissions    int64 `json:"submissions"`
+	Subscribers    int64 `json:"subscribers"`
+	Workspaces     int64 `json:"workspaces"`
+	BoardsLastWeek int64 `json:"boardsLastWeek"`
+}
+
+// bindAdminList parses and clamps the pagination of an admin listing, writing an error response if invalid
+func bindAdminList(c *gin.Context) (*AdminListRequest, bool) {
+	var req AdminListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 50
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+	return &req, true
+}
+
+// AdminListBoards handles GET /api/admin/boards
+func AdminListBoards(c *gin.Context) {
+	req, ok := bindAdminList(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{}
+	if req.UserID != "" {
+		filter["user_id"] = req.UserID
+	}
+	if req.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(req.Name), "$options": "i"}
+	}
+
+	collection := models.GetCollection(ctx, models.BoardsCollection)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var boards []models.Board
+	if err := cursor.All(ctx, &boards); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	summaries := []AdminBoardSummary{}
+	for _, board := range boards {
+		summaries = append(summaries, AdminBoardSummary{
+			ID:           board.ID,
+			Name:         board.Name,
+			UserID:       board.UserID,
+			WorkspaceID:  board.WorkspaceID,
+			IsPublic:     board.IsPublic,
+			Archived:     board.Archived,
+			Frozen:       board.Frozen,
+			MembersCount: len(board.Members),
+			CreatedAt:    board.CreatedAt,
+			UpdatedAt:    board.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"boards":     summaries,
+		"count":      len(summaries),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// AdminListUsers handles GET /api/admin/users, listing every board owner with their board counts
+func AdminListUsers(c *gin.Context) {
+	req, ok := bindAdminList(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	cursor, err := models.GetCollection(ctx, models.BoardsCollection).Aggregate(ctx, []bson.M{
+		{"$group": bson.M{
+			"_id":            "$user_id",
+			"boards":         bson.M{"$sum": 1},
+			"public_boards":  bson.M{"$sum": bson.M{"$cond": bson.A{"$is_public", 1, 0}}},
+			"last_active_at": bson.M{"$max": "$updated_at"},
+		}},
+		{"$sort": bson.D{{Key: "last_active_at", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$facet": bson.M{
+			"users": []bson.M{
+				{"$skip": (req.Page - 1) * req.PageSize},
+				{"$limit": req.PageSize},
+			},
+			"total": []bson.M{{"$count": "count"}},
+		}},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to aggregate users",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Users []AdminUserSummary `bson:"users"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to decode users",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	totalCount := 0
+	if len(result.Total) > 0 {
+		totalCount = result.Total[0].Count
+	}
+	if result.Users == nil {
+		result.Users = []AdminUserSummary{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":      result.Users,
+		"count":      len(result.Users),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (totalCount + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// AdminGetStats handles GET /api/admin/stats
+func AdminGetStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var stats PlatformStats
+	counts := []struct {
+		collection string
+		filter     bson.M
+		target     *int64
+	}{
+		{models.BoardsCollection, bson.M{}, &stats.Boards},
+		{models.BoardsCollection, bson.M{"is_public": true}, &stats.PublicBoards},
+		{models.BoardsCollection, bson.M{"created_at": bson.M{"$gte": time.Now().UTC().AddDate(0, 0, -7)}}, &stats.BoardsLastWeek},
+		{models.IdeasCollection, bson.M{}, &stats.Ideas},
+		{models.VotesCollection, bson.M{}, &stats.Feedback},
+		{models.CommentsCollection, bson.M{}, &stats.Comments},
+		{models.SubmissionsCollection, bson.M{}, &stats.Submissions},
+		{models.SubscribersCollection, bson.M{}, &stats.Subscribers},
+		{models.WorkspacesCollection, bson.M{}, &stats.Workspaces},
+	}
+	for _, count := range counts {
+		total, err := models.GetCollection(ctx, count.collection).CountDocuments(ctx, count.filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to count " + count.collection,
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		*count.target = total
+	}
+
+	var owners []string
+	if err := models.GetCollection(ctx, models.BoardsCollection).Distinct(ctx, "user_id", bson.M{}).Decode(&owners); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count users",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	stats.Users = len(owners)
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// AdminGetRealtimeMetrics handles GET /api/admin/realtime, reporting this instance's WebSocket and SSE load
+func AdminGetRealtimeMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.GetRealtimeMetrics())
+}
+
+// AdminListJobs handles GET /api/admin/jobs, reporting the scheduled jobs' runs on this instance
+// and their last run on any instance
+func AdminListJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	stats, err := jobs.Stats(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch jobs",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"instanceId": jobs.InstanceID(),
+		"jobs":       stats,
+	})
+}
+
+// AdminModerateBoard handles PUT /api/admin/boards/:id, letting admins unpublish, freeze or archive any board
+func AdminModerateBoard(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req AdminModerateBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	updateDoc := bson.M{}
+	if req.IsPublic != nil {
+		updateDoc["is_public"] = *req.IsPublic
+	}
+	if req.Frozen != nil {
+		updateDoc["frozen"] = *req.Frozen
+	}
+	if req.Archived != nil {
+		updateDoc["archived"] = *req.Archived
+	}
+	if len(updateDoc) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "No moderation action given",
+				"details": "provide isPublic, frozen or archived",
+			},
+		})
+		return
+	}
+	fields := updatedFields(updateDoc)
+	updateDoc["updated_at"] = time.Now().UTC()
+	updateDoc["updated_by"] = adminID
+
+	ctx := c.Request.Context()
+
+	before := findAuditBoard(ctx, boardID)
+	board, err := models.UpdateBoardAndReturn(ctx, bson.M{"_id": boardID}, models.BumpVersion(bson.M{"$set": updateDoc}))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to moderate board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// An unpublished board stops being served before the response
+	if req.IsPublic != nil {
+		if err := utils.DeletePublicBoard(ctx, boardID); err != nil {
+			log.Printf("[Handler] AdminModerateBoard - Failed to delete public read model: %v, BoardID: %s", err, boardID)
+		}
+	}
+
+	go utils.RecordActivity(ctx, boardID, "", adminID, models.ActivityBoardUpdated, map[string]interface{}{
+		"fields":     fields,
+		"moderation": true,
+		"reason":     req.Reason,
+	})
+	recordAudit(c, adminID, models.AuditBoardModerated, boardID, models.AuditTargetBoard, boardID, before, board)
+	if req.IsPublic != nil {
+		utils.RecheckBoardAccess(boardID)
+	}
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventBoardUpdated, "", map[string]interface{}{
+		"fields": fields,
+	})
+
+	log.Printf("[Handler] AdminModerateBoard success - BoardID: %s, Fields: %v, Reason: %s, AdminID: %s, IP: %s",
+		boardID, fields, req.Reason, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, AdminBoardSummary{
+		ID:           board.ID,
+		Name:         board.Name,
+		UserID:       board.UserID,
+		WorkspaceID:  board.WorkspaceID,
+		IsPublic:     board.IsPublic,
+		Archived:     board.Archived,
+		Frozen:       board.Frozen,
+		MembersCount: len(board.Members),
+		CreatedAt:    board.CreatedAt,
+		UpdatedAt:    board.UpdatedAt,
+	})
+}
+
+// AdminDeleteComment handles DELETE /api/admin/comments/:id, removing abusive comments on any board
+func AdminDeleteComment(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+	commentID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	var comment models.Comment
+	err := models.GetCollection(ctx, models.CommentsCollection).FindOneAndDelete(ctx, bson.M{"_id": commentID}).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "COMMENT_NOT_FOUND",
+					"message": "Comment not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete comment",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AdminDeleteComment success - CommentID: %s, IdeaID: %s, BoardID: %s, AdminID: %s, IP: %s",
+		commentID, comment.IdeaID, comment.BoardID, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted successfully",
+	})
+}
@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateAlertRuleRequest represents the request payload for creating an alert rule
+type CreateAlertRuleRequest struct {
+	Metric    string `json:"metric" binding:"required"`
+	Column    string `json:"column,omitempty"` // Required for column_size
+	Threshold int    `json:"threshold" binding:"required"`
+	Enabled   *bool  `json:"enabled,omitempty"`
+}
+
+// UpdateAlertRuleRequest represents the request payload for updating an alert rule
+type UpdateAlertRuleRequest struct {
+	Column    *string `json:"column,omitempty"`
+	Threshold *int    `json:"threshold,omitempty"`
+	Enabled   *bool   `json:"enabled,omitempty"`
+}
+
+// ListAlertRules handles GET /api/boards/:id/alerts, listing the caller's alert rules on the board
+func ListAlertRules(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	cursor, err := models.GetCollection(ctx, models.AlertRulesCollection).Find(ctx,
+		bson.M{"board_id": boardID, "user_id": userID},
+		options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch alert rules",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	rules := []models.AlertRule{}
+	if err := cursor.All(ctx, &rules); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode alert rules",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// CreateAlertRule handles POST /api/boards/:id/alerts. The rule only fires for thresholds crossed
+// after it is created.
+func CreateAlertRule(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req CreateAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	rule := models.AlertRule{
+		ID:        utils.GenerateFullUUID(),
+		BoardID:   boardID,
+		UserID:    userID,
+		Metric:    models.AlertMetric(req.Metric),
+		Threshold: req.Threshold,
+		Enabled:   true,
+	}
+	if rule.Metric == models.AlertColumnSize {
+		rule.Column = req.Column
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	// Validate alert rule
+	if validationErrors := models.ValidateAlertRule(&rule); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Alert rule validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.AlertRulesCollection)
+	count, err := collection.CountDocuments(ctx, bson.M{"board_id": boardID, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count alert rules",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if count >= models.MaxAlertRulesPerUser {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_ALERT_RULES",
+				"message": "You have too many alert rules on this board; remove one first",
+			},
+		})
+		return
+	}
+
+	if err := utils.ArmAlertRule(ctx, &rule); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to prepare alert rule",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := collection.InsertOne(ctx, rule); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create alert rule",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateAlertRule success - RuleID: %s, BoardID: %s, UserID: %s, Metric: %s, Threshold: %d, IP: %s",
+		rule.ID, boardID, userID, rule.Metric, rule.Threshold, c.ClientIP())
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateAlertRule handles PUT /api/boards/:id/alerts/:alertId. Changing the column or threshold
+// re-arms the rule against the board as it is now.
+func UpdateAlertRule(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+	ruleID := c.Param("alertId")
+
+	// Parse request body
+	var req UpdateAlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.AlertRulesCollection)
+	var rule models.AlertRule
+	err := collection.FindOne(ctx, bson.M{"_id": ruleID, "board_id": boardID, "user_id": userID}).Decode(&rule)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "ALERT_RULE_NOT_FOUND",
+					"message": "Alert rule not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch alert rule",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	rearm := false
+	if req.Column != nil && rule.Metric == models.AlertColumnSize && *req.Column != rule.Column {
+		rule.Column = *req.Column
+		rearm = true
+	}
+	if req.Threshold != nil && *req.Threshold != rule.Threshold {
+		rule.Threshold = *req.Threshold
+		rearm = true
+	}
+	if req.Enabled != nil {
+		// A rule switched back on should not report what happened while it was off
+		rearm = rearm || (*req.Enabled && !rule.Enabled)
+		rule.Enabled = *req.Enabled
+	}
+
+	// Validate alert rule
+	if validationErrors := models.ValidateAlertRule(&rule); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Alert rule validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if rearm {
+		if err := utils.ArmAlertRule(ctx, &rule); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to prepare alert rule",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": rule.ID}, bson.M{"$set": bson.M{
+		"column":     rule.Column,
+		"threshold":  rule.Threshold,
+		"enabled":    rule.Enabled,
+		"updated_at": rule.UpdatedAt,
+	}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update alert rule",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateAlertRule success - RuleID: %s, BoardID: %s, UserID: %s, Threshold: %d, Enabled: %t, IP: %s",
+		rule.ID, boardID, userID, rule.Threshold, rule.Enabled, c.ClientIP())
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteAlertRule handles DELETE /api/boards/:id/alerts/:alertId
+func DeleteAlertRule(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+	ruleID := c.Param("alertId")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.AlertRulesCollection).DeleteOne(ctx, bson.M{"_id": ruleID, "board_id": boardID, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete alert rule",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "ALERT_RULE_NOT_FOUND",
+				"message": "Alert rule not found",
+			},
+		})
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.AlertFiringsCollection).DeleteMany(ctx, bson.M{"rule_id": ruleID}); err != nil {
+		log.Printf("[Handler] DeleteAlertRule - Failed to delete firings of rule %s: %v", ruleID, err)
+	}
+
+	log.Printf("[Handler] DeleteAlertRule success - RuleID: %s, BoardID: %s, UserID: %s, IP: %s",
+		ruleID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert rule deleted successfully",
+	})
+}
+
+// checkColumnAlerts re-evaluates the board's column alert rules when an idea changed columns
+func checkColumnAlerts(ctx context.Context, existing, updated *models.Idea) {
+	if updated.Column != existing.Column {
+		go utils.EvaluateColumnAlerts(ctx, updated.BoardID)
+	}
+}
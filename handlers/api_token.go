@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateAPITokenRequest represents the request payload for minting a board API token
+type CreateAPITokenRequest struct {
+	Label string `json:"label,omitempty" binding:"max=100"`
+}
+
+// CreateAPITokenResponse returns a new token together with its secret, which is never shown again
+type CreateAPITokenResponse struct {
+	models.APIToken
+	Secret string `json:"secret"`
+}
+
+// GetAPIBoardIdeasRequest represents the query parameters for listing ideas with an API token
+type GetAPIBoardIdeasRequest struct {
+	Column   string `form:"column"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+	View     string `form:"view"` // compact
+}
+
+// CreateAPIToken handles POST /api/boards/:id/api-tokens
+func CreateAPIToken(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.APITokensCollection)
+
+	existing, err := collection.CountDocuments(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count API tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if existing >= models.MaxAPITokensPerBoard {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_API_TOKENS",
+				"message": fmt.Sprintf("Boards may have at most %d API tokens", models.MaxAPITokensPerBoard),
+			},
+		})
+		return
+	}
+
+	secret := utils.GenerateAPITokenSecret()
+	token := models.APIToken{
+		ID:        utils.GenerateFullUUID(),
+		BoardID:   boardID,
+		UserID:    userID,
+		Label:     req.Label,
+		TokenHash: models.HashAPIToken(secret),
+		Prefix:    secret[:models.APITokenPrefixLength],
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if _, err := collection.InsertOne(ctx, token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create API token",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateAPIToken success - TokenID: %s, BoardID: %s, UserID: %s, IP: %s",
+		token.ID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, CreateAPITokenResponse{APIToken: token, Secret: secret})
+}
+
+// ListAPITokens handles GET /api/boards/:id/api-tokens
+func ListAPITokens(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	// Tokens carry the owner's user ID, so this also scopes the list to boards they own
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.APITokensCollection).Find(ctx, bson.M{"board_id": boardID, "user_id": userID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch API tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []models.APIToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode API tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+		"count":  len(tokens),
+	})
+}
+
+// DeleteAPIToken handles DELETE /api/boards/:id/api-tokens/:tokenId
+func DeleteAPIToken(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+	tokenID := c.Param("tokenId")
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"_id": tokenID, "board_id": boardID, "user_id": userID}
+	result, err := models.GetCollection(ctx, models.APITokensCollection).DeleteOne(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete API token",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "API_TOKEN_NOT_FOUND",
+				"message": "API token not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteAPIToken success - TokenID: %s, BoardID: %s, UserID: %s, IP: %s", tokenID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API token revoked successfully",
+	})
+}
+
+// findAPITokenBoard loads the board of the authenticated API token, or the :id board of a service account
+// scoped to it, writing an error response if it is gone. The board must still belong to the credential's owner.
+func findAPITokenBoard(ctx context.Context, c *gin.Context) (*models.Board, bool) {
+	var filter bson.M
+	if token, err := middleware.GetAPIToken(c); err == nil {
+		filter = bson.M{"_id": token.BoardID, "user_id": token.UserID}
+	} else if account, err := middleware.GetServiceAccount(c); err == nil {
+		filter = bson.M{"_id": c.Param("id"), "user_id": account.UserID}
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get API credentials",
+			},
+		})
+		return nil, false
+	}
+
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, filter).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found",
+				},
+			})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return &board, true
+}
+
+// GetAPIBoard handles GET /api/v1/boards/:id (board API token)
+func GetAPIBoard(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	board, ok := findAPITokenBoard(ctx, c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":             board.ID,
+		"name":           board.Name,
+		"description":    board.Description,
+		"isPublic":       board.IsPublic,
+		"archived":       board.Archived,
+		"frozen":         board.Frozen,
+		"visibleColumns": board.VisibleColumns,
+		"visibleFields":  board.VisibleFields,
+		"createdAt":      board.CreatedAt,
+		"updatedAt":      board.UpdatedAt,
+	})
+}
+
+// GetAPIBoardStats handles GET /api/v1/boards/:id/stats (board API token)
+func GetAPIBoardStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	board, ok := findAPITokenBoard(ctx, c)
+	if !ok {
+		return
+	}
+
+	stats, err := models.GetBoardStats(ctx, board)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to compute board stats",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetAPIBoardIdeas handles GET /api/v1/boards/:id/ideas (board API token) and
+// GET /api/service/boards/:id/ideas (service account with ideas:read)
+func GetAPIBoardIdeas(c *gin.Context) {
+	// Parse query parameters
+	var req GetAPIBoardIdeasRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 50
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	if req.Column != "" && !models.IsValidColumn(req.Column) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_COLUMN",
+				"message": "Invalid column",
+			},
+		})
+		return
+	}
+
+	if rejectInvalidView(c, req.View) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	board, ok := findAPITokenBoard(ctx, c)
+	if !ok {
+		return
+	}
+
+	filter := bson.M{"board_id": board.ID}
+	if req.Column != "" {
+		filter["column"] = req.Column
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "column", Value: 1}, {Key: "position", Value: 1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+	if req.View == ideaViewCompact {
+		opts.SetProjection(compactIdeaProjection)
+	}
+
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	cursor, err := ideasCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var ideas []models.Idea
+	if err := cursor.All(ctx, &ideas); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := ideasCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	response := gin.H{
+		"count":      len(ideas),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	}
+	if req.View == ideaViewCompact {
+		compact := []CompactIdeaResponse{}
+		for _, idea := range ideas {
+			compact = append(compact, newCompactIdeaResponse(idea))
+		}
+		response["ideas"] = compact
+		response["view"] = ideaViewCompact
+	} else {
+		responses := []IdeaResponse{}
+		for _, idea := range ideas {
+			responses = append(responses, newIdeaResponse(idea))
+		}
+		response["ideas"] = responses
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// attachmentExtension matches the file extensions kept in storage keys
+var attachmentExtension = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// AttachmentResponse is an attachment with a signed link to download it
+type AttachmentResponse struct {
+	models.Attachment
+	Download *DownloadLink `json:"download,omitempty"`
+}
+
+// newAttachmentResponse signs a download link for an attachment, saved under its original name
+func newAttachmentResponse(attachment models.Attachment) AttachmentResponse {
+	return AttachmentResponse{Attachment: attachment, Download: signedDownload(attachment.Key, attachment.Filename)}
+}
+
+// cleanAttachmentName keeps the base name of an uploaded file, without control characters
+func cleanAttachmentName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 200 {
+		name = strings.ToValidUTF8(name[:200], "")
+	}
+	return name
+}
+
+// ListIdeaAttachments handles GET /api/ideas/:id/attachments for anyone who can view the board
+func ListIdeaAttachments(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	var idea models.Idea
+	err = models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	if err == nil {
+		_, err = middleware.FindAccessibleBoard(ctx, c, idea.BoardID, userID, models.RoleViewer)
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch idea",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	cursor, err := models.GetCollection(ctx, models.AttachmentsCollection).Find(ctx, bson.M{"idea_id": ideaID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch attachments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	var attachments []models.Attachment
+	if err := cursor.All(ctx, &attachments); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode attachments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	responses := make([]AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		responses = append(responses, newAttachmentResponse(attachment))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"attachments": responses,
+	})
+}
+
+// UploadIdeaAttachment handles POST /api/ideas/:id/attachments with the file as the file field of a
+// multipart form
+func UploadIdeaAttachment(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ideaID := c.Param("id")
+
+	body, filename, ok := readUpload(c, models.MaxAttachmentSize)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idea, _, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	collection := models.GetCollection(ctx, models.AttachmentsCollection)
+	count, err := collection.CountDocuments(ctx, bson.M{"idea_id": ideaID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count attachments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if count >= models.MaxIdeaAttachments {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_ATTACHMENTS",
+				"message": fmt.Sprintf("Ideas may carry at most %d attachments", models.MaxIdeaAttachments),
+			},
+		})
+		return
+	}
+
+	attachment := models.Attachment{
+		ID:          utils.GenerateFullUUID(),
+		BoardID:     idea.BoardID,
+		IdeaID:      ideaID,
+		Filename:    cleanAttachmentName(filename),
+		ContentType: http.DetectContentType(body),
+		Size:        int64(len(body)),
+		CreatedBy:   userID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	extension := strings.ToLower(path.Ext(attachment.Filename))
+	if !attachmentExtension.MatchString(extension) {
+		extension = ""
+	}
+	attachment.Key = models.IdeaStoragePrefix(ctx, idea.BoardID, ideaID) + attachment.ID + extension
+
+	if err := utils.GetStorage().Put(ctx, attachment.Key, body, attachment.ContentType); err != nil {
+		log.Printf("[Handler] UploadIdeaAttachment failed - IdeaID: %s, Error: %v, UserID: %s, IP: %s", ideaID, err, userID, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "STORAGE_ERROR",
+				"message": "Failed to store attachment",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := collection.InsertOne(ctx, attachment); err != nil {
+		utils.GetStorage().Delete(ctx, attachment.Key)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save attachment",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UploadIdeaAttachment success - IdeaID: %s, AttachmentID: %s, Type: %s, Size: %d, UserID: %s, IP: %s",
+		ideaID, attachment.ID, attachment.ContentType, attachment.Size, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, newAttachmentResponse(attachment))
+}
+
+// DeleteIdeaAttachment handles DELETE /api/ideas/:id/attachments/:attachmentId
+func DeleteIdeaAttachment(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ideaID := c.Param("id")
+	attachmentID := c.Param("attachmentId")
+
+	ctx := c.Request.Context()
+
+	if _, _, ok := findOwnedIdea(ctx, c, ideaID, userID); !ok {
+		return
+	}
+
+	var attachment models.Attachment
+	err = models.GetCollection(ctx, models.AttachmentsCollection).FindOneAndDelete(ctx, bson.M{"_id": attachmentID, "idea_id": ideaID}).Decode(&attachment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "ATTACHMENT_NOT_FOUND",
+					"message": "Attachment not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete attachment",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// The record is gone, so a failure only leaves an unreachable file behind
+	if err := utils.GetStorage().Delete(ctx, attachment.Key); err != nil {
+		log.Printf("[Handler] DeleteIdeaAttachment - File cleanup error: %v, AttachmentID: %s", err, attachmentID)
+	}
+
+	log.Printf("[Handler] DeleteIdeaAttachment success - IdeaID: %s, AttachmentID: %s, UserID: %s, IP: %s",
+		ideaID, attachmentID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachment deleted successfully",
+	})
+}
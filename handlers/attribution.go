@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// maxAttributionDays bounds the look-back window of feedback source breakdowns
+const maxAttributionDays = 365
+
+// GetFeedbackSourcesRequest represents the query parameters for feedback source breakdowns
+type GetFeedbackSourcesRequest struct {
+	IdeaID string `form:"ideaId"`
+	Days   int    `form:"days"` // Only count feedback from the last N days; 0 counts everything
+}
+
+// AttributionBucket is the feedback count for one source, referrer or device class
+type AttributionBucket struct {
+	Value string `bson:"_id" json:"value"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// FeedbackSourcesResponse breaks a board's visitor feedback down by where it came from.
+// Feedback recorded without attribution is counted under an empty value.
+type FeedbackSourcesResponse struct {
+	Sources   []AttributionBucket `bson:"sources" json:"sources"`
+	Referrers []AttributionBucket `bson:"referrers" json:"referrers"`
+	Devices   []AttributionBucket `bson:"devices" json:"devices"`
+	Types     []AttributionBucket `bson:"types" json:"types"`
+	Total     int                 `bson:"-" json:"total"`
+}
+
+// attributionGroup counts votes per value of a field, most frequent first
+func attributionGroup(field string) []bson.M {
+	return []bson.M{
+		{"$group": bson.M{"_id": bson.M{"$ifNull": bson.A{"$" + field, ""}}, "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+}
+
+// GetFeedbackSources handles GET /api/boards/:id/feedback-sources
+func GetFeedbackSources(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse query parameters
+	var req GetFeedbackSourcesRequest
+	if err := c.ShouldBindQuery(&req); err != nil || req.Days < 0 || req.Days > maxAttributionDays {
+		details := "days must be between 0 and 365"
+		if err != nil {
+			details = err.Error()
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": details,
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	match := bson.M{"board_id": boardID}
+	if req.IdeaID != "" {
+		match["idea_id"] = req.IdeaID
+	}
+	if req.Days > 0 {
+		match["created_at"] = bson.M{"$gte": time.Now().UTC().AddDate(0, 0, -req.Days)}
+	}
+
+	// Break down every dimension in one pass over the board's votes
+	cursor, err := models.GetCollection(ctx, models.VotesCollection).Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"sources":   attributionGroup("source"),
+			"referrers": attributionGroup("referrer"),
+			"devices":   attributionGroup("device"),
+			"types":     attributionGroup("type"),
+		}},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to aggregate feedback sources",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var breakdown FeedbackSourcesResponse
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&breakdown); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to decode feedback sources",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+	for _, bucket := range breakdown.Types {
+		breakdown.Total += bucket.Count
+	}
+	for _, buckets := range []*[]AttributionBucket{&breakdown.Sources, &breakdown.Referrers, &breakdown.Devices, &breakdown.Types} {
+		if *buckets == nil {
+			*buckets = []AttributionBucket{}
+		}
+	}
+
+	log.Printf("[Handler] GetFeedbackSources success - BoardID: %s, IdeaID: %s, Days: %d, Total: %d, UserID: %s, IP: %s",
+		boardID, req.IdeaID, req.Days, breakdown.Total, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, breakdown)
+}
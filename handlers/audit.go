@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// GetAuditLogRequest represents query parameters for the audit log
+type GetAuditLogRequest struct {
+	BoardID  string    `form:"boardId"` // Admin listing only; board audit trails use the :id route
+	Action   string    `form:"action"`
+	ActorID  string    `form:"actorId"`
+	TargetID string    `form:"targetId"`
+	Since    time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until    time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page     int       `form:"page"`
+	PageSize int       `form:"pageSize"`
+}
+
+// actor is who makes a change, with what board permissions and the audit log need to know about them.
+// REST handlers take it from the request, WebSocket operations from the connection.
+type actor struct {
+	userID         string
+	orgID          string // Active organization, giving access to its workspace's boards
+	orgRole        string
+	ip             string
+	userAgent      string
+	impersonatorID string // Admin acting as the user, if any
+}
+
+// requestActor returns the authenticated caller of a request
+func requestActor(c *gin.Context) actor {
+	userID, _ := c.Get("userID")
+	orgID, orgRole := middleware.GetOrganization(c)
+	who := actor{
+		orgID:     orgID,
+		orgRole:   orgRole,
+		ip:        c.ClientIP(),
+		userAgent: c.GetHeader("User-Agent"),
+	}
+	who.userID, _ = userID.(string)
+	if impersonation := middleware.GetImpersonation(c); impersonation != nil {
+		who.impersonatorID = impersonation.AdminID
+	}
+	return who
+}
+
+// recordAudit stores an audit entry for a mutation in the background, diffing the object before and
+// after it. Pass nil as before for creations and as after for deletions.
+func recordAudit(c *gin.Context, actorID string, action models.AuditAction, boardID string, targetType models.AuditTargetType, targetID string, before, after interface{}) {
+	who := requestActor(c)
+	who.userID = actorID
+	recordActorAudit(c.Request.Context(), who, action, boardID, targetType, targetID, before, after)
+}
+
+// recordActorAudit is recordAudit for changes made outside a request's handler
+func recordActorAudit(ctx context.Context, who actor, action models.AuditAction, boardID string, targetType models.AuditTargetType, targetID string, before, after interface{}) {
+	entry := models.AuditEntry{
+		BoardID:        boardID,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		Action:         action,
+		ActorID:        who.userID,
+		IP:             who.ip,
+		UserAgent:      who.userAgent,
+		Changes:        utils.AuditDiff(before, after),
+		ImpersonatorID: who.impersonatorID,
+	}
+	go utils.RecordAudit(ctx, entry)
+}
+
+// findAuditBoard loads a board as it is before a mutation, returning nil if it cannot be read
+func findAuditBoard(ctx context.Context, boardID string) *models.Board {
+	var board models.Board
+	if err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Decode(&board); err != nil {
+		return nil
+	}
+	return &board
+}
+
+// listAuditLog writes a page of audit entries matching the request, newest first
+func listAuditLog(c *gin.Context, filter bson.M) {
+	// Parse query parameters
+	var req GetAuditLogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Action != "" && !models.IsValidAuditAction(req.Action) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_AUDIT_ACTION",
+				"message": "Invalid audit action: " + req.Action,
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 50
+	}
+
+	if _, scoped := filter["board_id"]; !scoped && req.BoardID != "" {
+		filter["board_id"] = req.BoardID
+	}
+	if req.Action != "" {
+		filter["action"] = req.Action
+	}
+	if req.ActorID != "" {
+		filter["actor_id"] = req.ActorID
+	}
+	if req.TargetID != "" {
+		filter["target_id"] = req.TargetID
+	}
+	if !req.Since.IsZero() || !req.Until.IsZero() {
+		createdAt := bson.M{}
+		if !req.Since.IsZero() {
+			createdAt["$gte"] = req.Since.UTC()
+		}
+		if !req.Until.IsZero() {
+			createdAt["$lt"] = req.Until.UTC()
+		}
+		filter["created_at"] = createdAt
+	}
+
+	ctx := c.Request.Context()
+
+	// Newest first
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	auditCollection := models.GetCollection(ctx, models.AuditLogCollection)
+	cursor, err := auditCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch audit log",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode audit log",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := auditCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count audit log",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	actorIDs := []string{}
+	for _, entry := range entries {
+		actorIDs = append(actorIDs, entry.ActorID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries":       entries,
+		"users":         findUserProfiles(ctx, actorIDs),
+		"count":         len(entries),
+		"totalCount":    totalCount,
+		"page":          req.Page,
+		"pageSize":      req.PageSize,
+		"totalPages":    (int(totalCount) + req.PageSize - 1) / req.PageSize,
+		"retentionDays": models.AuditRetentionDays(),
+	})
+}
+
+// GetBoardAuditLog handles GET /api/boards/:id/audit (owner only)
+func GetBoardAuditLog(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	log.Printf("[Handler] GetBoardAuditLog called - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	listAuditLog(c, bson.M{"board_id": boardID})
+}
+
+// AdminGetAuditLog handles GET /api/admin/audit, listing audit entries across all boards,
+// including boards that have since been deleted
+func AdminGetAuditLog(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+
+	log.Printf("[Handler] AdminGetAuditLog called - AdminID: %s, IP: %s", adminID, c.ClientIP())
+
+	listAuditLog(c, bson.M{})
+}
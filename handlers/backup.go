@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BackupRequest represents query parameters for downloading a backup
+type BackupRequest struct {
+	Format      string `form:"format"`      // json (default) or bson
+	Collections string `form:"collections"` // Comma-separated; all collections when empty
+	Gzip        bool   `form:"gzip"`
+}
+
+// RestoreRequest represents query parameters for restoring a backup sent as the request body
+type RestoreRequest struct {
+	Format string `form:"format"` // json (default) or bson; gzip-compressed bodies are detected
+	Mode   string `form:"mode"`   // merge (default) keeps documents missing from the backup, replace deletes them
+}
+
+// AdminDownloadBackup handles GET /api/admin/backup, streaming every document of the database so
+// self-hosted instances can be restored with AdminRestoreBackup
+func AdminDownloadBackup(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+
+	var req BackupRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Format == "" {
+		req.Format = utils.BackupFormatJSON
+	}
+	if !utils.IsValidBackupFormat(req.Format) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_FORMAT",
+				"message": "format must be json or bson",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collections, err := utils.BackupCollections(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to list collections",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Collections != "" {
+		known := map[string]bool{}
+		for _, collection := range collections {
+			known[collection] = true
+		}
+		collections = nil
+		for _, collection := range strings.Split(req.Collections, ",") {
+			collection = strings.TrimSpace(collection)
+			if collection == "" {
+				continue
+			}
+			if !known[collection] {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": gin.H{
+						"code":    "UNKNOWN_COLLECTION",
+						"message": "Unknown collection: " + collection,
+					},
+				})
+				return
+			}
+			collections = append(collections, collection)
+		}
+	}
+
+	filename := "disko-backup-" + time.Now().UTC().Format("20060102T150405Z") + ".jsonl"
+	contentType := "application/x-ndjson"
+	if req.Format == utils.BackupFormatBSON {
+		filename = strings.TrimSuffix(filename, ".jsonl") + ".bson"
+		contentType = "application/octet-stream"
+	}
+	var body io.Writer = c.Writer
+	var gz *gzip.Writer
+	if req.Gzip {
+		filename += ".gz"
+		contentType = "application/gzip"
+		gz = gzip.NewWriter(c.Writer)
+		body = gz
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	// The status is sent with the first bytes, so a failure can only cut the download short
+	report, err := utils.WriteBackup(ctx, body, req.Format, collections)
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		log.Printf("[Handler] AdminDownloadBackup failed - Format: %s, Collections: %d, Error: %v, AdminID: %s, IP: %s",
+			req.Format, len(collections), err, adminID, c.ClientIP())
+		c.Abort()
+		return
+	}
+
+	log.Printf("[Handler] AdminDownloadBackup success - Format: %s, Collections: %d, Duration: %v, AdminID: %s, IP: %s",
+		req.Format, len(report.Collections), report.Duration, adminID, c.ClientIP())
+}
+
+// AdminRestoreBackup handles POST /api/admin/restore, writing the documents of a backup made by
+// AdminDownloadBackup or the scheduled backup job back to the database
+func AdminRestoreBackup(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+
+	var req RestoreRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Format == "" {
+		req.Format = utils.BackupFormatJSON
+	}
+	if !utils.IsValidBackupFormat(req.Format) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_FORMAT",
+				"message": "format must be json or bson",
+			},
+		})
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = "merge"
+	}
+	if req.Mode != "merge" && req.Mode != "replace" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_MODE",
+				"message": "mode must be merge or replace",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	report, err := utils.RestoreBackup(ctx, c.Request.Body, req.Format, req.Mode == "replace")
+	if err != nil {
+		log.Printf("[Handler] AdminRestoreBackup failed - Format: %s, Mode: %s, Error: %v, AdminID: %s, IP: %s",
+			req.Format, req.Mode, err, adminID, c.ClientIP())
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": gin.H{
+				"code":    "RESTORE_FAILED",
+				"message": "The restore stopped; documents before the failure were restored",
+				"details": err.Error(),
+			},
+			"report": report,
+		})
+		return
+	}
+
+	log.Printf("[Handler] AdminRestoreBackup success - Format: %s, Mode: %s, Collections: %d, Skipped: %d, Errors: %d, Duration: %v, AdminID: %s, IP: %s",
+		req.Format, req.Mode, len(report.Collections), report.Skipped, len(report.Errors), report.Duration, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, report)
+}
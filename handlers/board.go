@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"disko-backend/middleware"
@@ -21,32 +25,78 @@ type CreateBoardRequest struct {
 	Description    string   `json:"description,omitempty" binding:"max=500"`
 	VisibleColumns []string `json:"visibleColumns,omitempty"`
 	VisibleFields  []string `json:"visibleFields,omitempty"`
+	WorkspaceID    string   `json:"workspaceId,omitempty"` // Must be the workspace of the active organization
 }
 
 // UpdateBoardRequest represents the request payload for updating a board
 type UpdateBoardRequest struct {
-	Name           string   `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
-	Description    string   `json:"description,omitempty" binding:"max=500"`
-	VisibleColumns []string `json:"visibleColumns,omitempty"`
-	VisibleFields  []string `json:"visibleFields,omitempty"`
-	IsPublic       *bool    `json:"isPublic,omitempty"`
+	Name              string                    `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Description       string                    `json:"description,omitempty" binding:"max=500"`
+	VisibleColumns    []string                  `json:"visibleColumns,omitempty"`
+	VisibleFields     []string                  `json:"visibleFields,omitempty"`
+	IsPublic          *bool                     `json:"isPublic,omitempty"`
+	PublicRiceScore   *bool                     `json:"publicRiceScore,omitempty"`
+	Archived          *bool                     `json:"archived,omitempty"`
+	Frozen            *bool                     `json:"frozen,omitempty"`
+	StrictPrivacy     *bool                     `json:"strictPrivacy,omitempty"`
+	AcceptsIdeas      *bool                     `json:"acceptsIdeas,omitempty"`
+	ModerateComments  *bool                     `json:"moderateComments,omitempty"`
+	CaptchaProvider   *string                   `json:"captchaProvider,omitempty"`   // Empty string disables CAPTCHA
+	FeedbackRateLimit *models.FeedbackRateLimit `json:"feedbackRateLimit,omitempty"` // All-zero policy restores the server defaults
+	Reactions         *[]string                 `json:"reactions,omitempty"`         // Empty list restores the defaults
+	VoteOptions       *[]string                 `json:"voteOptions,omitempty"`       // Empty list disables vote options
+	WorkspaceID       *string                   `json:"workspaceId,omitempty"`       // Empty string moves the board out of its workspace
+	Version           *int64                    `json:"version,omitempty"`           // Rejects the edit if the board changed since; If-Match works too
+}
+
+// DeleteBoardRequest represents the request payload confirming a board deletion
+type DeleteBoardRequest struct {
+	ConfirmName string `json:"confirmName"` // Must match the board name
+}
+
+// GetBoardsRequest represents query parameters for listing boards
+type GetBoardsRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+	SortBy   string `form:"sortBy"`  // name, updatedAt, ideasCount
+	SortDir  string `form:"sortDir"` // asc, desc
+	IsPublic *bool  `form:"isPublic"`
+	Archived *bool  `form:"archived"`
+	Name     string `form:"name"` // Case-insensitive "contains" match
 }
 
 // BoardResponse represents the response format for board operations
 type BoardResponse struct {
-	ID             string    `json:"id"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description,omitempty"`
-	PublicLink     string    `json:"publicLink"`
-	IsPublic       bool      `json:"isPublic"`
-	UserID         string    `json:"userId"`
-	IsAdmin        bool      `json:"isAdmin"`
-	VisibleColumns []string  `json:"visibleColumns"`
-	VisibleFields  []string  `json:"visibleFields"`
-	IdeasCount     int       `json:"ideasCount"`
-	ReactionsCount int       `json:"reactionsCount"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
+	ID                string                    `json:"id"`
+	Name              string                    `json:"name"`
+	Description       string                    `json:"description,omitempty"`
+	PublicLink        string                    `json:"publicLink"`
+	IsPublic          bool                      `json:"isPublic"`
+	UserID            string                    `json:"userId"`
+	IsAdmin           bool                      `json:"isAdmin"`
+	Role              models.MemberRole         `json:"role,omitempty"` // The caller's role on the board
+	WorkspaceID       string                    `json:"workspaceId,omitempty"`
+	VisibleColumns    []string                  `json:"visibleColumns"`
+	VisibleFields     []string                  `json:"visibleFields"`
+	PublicRiceScore   bool                      `json:"publicRiceScore"`
+	Archived          bool                      `json:"archived"`
+	Frozen            bool                      `json:"frozen"`
+	StrictPrivacy     bool                      `json:"strictPrivacy"`
+	AcceptsIdeas      bool                      `json:"acceptsIdeas"`
+	ModerateComments  bool                      `json:"moderateComments"`
+	CaptchaProvider   string                    `json:"captchaProvider"`
+	FeedbackRateLimit *models.FeedbackRateLimit `json:"feedbackRateLimit"` // Null means the server defaults apply
+	Reactions         []string                  `json:"reactions"`
+	VoteOptions       []string                  `json:"voteOptions"`
+	IdeasCount        int                       `json:"ideasCount"`
+	ReactionsCount    int                       `json:"reactionsCount"`
+	Stats             *models.BoardStats        `json:"stats,omitempty"` // Only with ?include=stats
+	CreatedAt         time.Time                 `json:"createdAt"`
+	UpdatedAt         time.Time                 `json:"updatedAt"`
+	CreatedBy         string                    `json:"createdBy,omitempty"`
+	UpdatedBy         string                    `json:"updatedBy,omitempty"` // Who last changed the settings or members
+	Version           int64                     `json:"version"`             // Send back as version or If-Match when editing
+	LogoURL           string                    `json:"logoUrl,omitempty"`   // Signed link that expires; reload the board for a new one
 }
 
 // CreateBoard handles POST /api/boards
@@ -147,14 +197,20 @@ func CreateBoard(c *gin.Context) {
 		UserID:         userID,
 		VisibleColumns: visibleColumns,
 		VisibleFields:  visibleFields,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 
 	// Insert into MongoDB
-	collection := models.GetCollection(models.BoardsCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
+	collection := models.GetCollection(ctx, models.BoardsCollection)
+
+	if rejectBoardWorkspace(ctx, c, req.WorkspaceID, boardID) {
+		return
+	}
+	board.WorkspaceID = req.WorkspaceID
 
 	log.Printf("[Handler] CreateBoard - Collection insertion - Database: disko, Collection: boards, UserID: %s, BoardID: %s",
 		userID, boardID)
@@ -212,12 +268,14 @@ func CreateBoard(c *gin.Context) {
 		Status:         string(models.StatusActive),
 		ThumbsUp:       0,
 		EmojiReactions: []models.EmojiReaction{},
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 
 	// Insert default idea
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	_, err = ideasCollection.InsertOne(ctx, defaultIdea)
 	if err != nil {
 		log.Printf("[Handler] CreateBoard - Failed to create default idea: %v, BoardID: %s, UserID: %s", err, boardID, userID)
@@ -237,10 +295,15 @@ func CreateBoard(c *gin.Context) {
 		PublicLink:     board.PublicLink,
 		IsPublic:       board.IsPublic,
 		UserID:         board.UserID,
+		Role:           models.RoleOwner,
+		WorkspaceID:    board.WorkspaceID,
 		VisibleColumns: board.VisibleColumns,
 		VisibleFields:  board.VisibleFields,
 		CreatedAt:      board.CreatedAt,
 		UpdatedAt:      board.UpdatedAt,
+		CreatedBy:      board.CreatedBy,
+		UpdatedBy:      board.UpdatedBy,
+		Version:        board.Version,
 	}
 	responseDuration := time.Since(responseStartTime)
 
@@ -248,6 +311,8 @@ func CreateBoard(c *gin.Context) {
 	log.Printf("[Handler] CreateBoard completed successfully - BoardID: %s, Name: %s, Total duration: %v, Response duration: %v, UserID: %s, IP: %s",
 		board.ID, board.Name, totalDuration, responseDuration, userID, c.ClientIP())
 
+	recordAudit(c, userID, models.AuditBoardCreated, board.ID, models.AuditTargetBoard, board.ID, nil, board)
+
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -273,19 +338,112 @@ func GetBoards(c *gin.Context) {
 	log.Printf("[Handler] GetBoards started - UserID: %s, IP: %s, UserAgent: %s, Referer: %s",
 		userID, c.ClientIP(), userAgent, referer)
 
+	// Parse query parameters
+	var req GetBoardsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.SortBy == "" {
+		req.SortBy = "updatedAt"
+	}
+	if req.SortDir == "" {
+		req.SortDir = "desc"
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 50
+	}
+
 	// Query boards for the authenticated user
-	collection := models.GetCollection(models.BoardsCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
+	collection := models.GetCollection(ctx, models.BoardsCollection)
 
-	filter := bson.M{"user_id": userID}
+	orgID, _ := middleware.GetOrganization(c)
+	filter := models.AccessibleBoardsFilter(userID, orgID)
+	if req.IsPublic != nil {
+		filter["is_public"] = *req.IsPublic
+	}
+	// Archived boards are hidden unless explicitly requested
+	if req.Archived != nil && *req.Archived {
+		filter["archived"] = true
+	} else {
+		filter["archived"] = bson.M{"$ne": true}
+	}
+	if req.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(req.Name), "$options": "i"}
+	}
 	log.Printf("[Handler] GetBoards - Executing database query - Filter: %v, UserID: %s", filter, userID)
 
 	// Log collection details
 	log.Printf("[Handler] GetBoards - Collection lookup - Database: disko, Collection: boards, UserID: %s", userID)
 
+	// Get total count for pagination
+	totalCount, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Build sort stage
+	sortDir := 1
+	if req.SortDir == "desc" {
+		sortDir = -1
+	}
+
+	pipeline := []bson.M{{"$match": filter}}
+
+	var sortStage bson.D
+	switch req.SortBy {
+	case "name":
+		sortStage = bson.D{{Key: "name", Value: sortDir}}
+	case "ideasCount":
+		// Count ideas per board so the page can be sorted before it is sliced
+		pipeline = append(pipeline,
+			bson.M{"$lookup": bson.M{
+				"from": models.IdeasCollection,
+				"let":  bson.M{"boardId": "$_id"},
+				"pipeline": []bson.M{
+					{"$match": bson.M{"$expr": bson.M{"$eq": []string{"$board_id", "$$boardId"}}}},
+					{"$count": "count"},
+				},
+				"as": "ideas_count_result",
+			}},
+			bson.M{"$addFields": bson.M{
+				"ideas_count": bson.M{"$ifNull": []interface{}{bson.M{"$arrayElemAt": []interface{}{"$ideas_count_result.count", 0}}, 0}},
+			}},
+		)
+		sortStage = bson.D{{Key: "ideas_count", Value: sortDir}}
+	default:
+		sortStage = bson.D{{Key: "updated_at", Value: sortDir}}
+	}
+	// Tie-break on _id for stable pages
+	sortStage = append(sortStage, bson.E{Key: "_id", Value: 1})
+
+	pipeline = append(pipeline,
+		bson.M{"$sort": sortStage},
+		bson.M{"$skip": int64((req.Page - 1) * req.PageSize)},
+		bson.M{"$limit": int64(req.PageSize)},
+	)
+
 	dbStartTime := time.Now()
-	cursor, err := collection.Find(ctx, filter)
+	cursor, err := collection.Aggregate(ctx, pipeline)
 	dbDuration := time.Since(dbStartTime)
 
 	if err != nil {
@@ -343,7 +501,7 @@ func GetBoards(c *gin.Context) {
 	var responses []BoardResponse
 	for i, board := range boards {
 		// Count ideas for this board
-		ideasCollection := models.GetCollection(models.IdeasCollection)
+		ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 		ideasFilter := bson.M{"board_id": board.ID}
 		ideasCount, err := ideasCollection.CountDocuments(ctx, ideasFilter)
 		if err != nil {
@@ -390,18 +548,34 @@ func GetBoards(c *gin.Context) {
 		}
 
 		responses = append(responses, BoardResponse{
-			ID:             board.ID,
-			Name:           board.Name,
-			Description:    board.Description,
-			PublicLink:     board.PublicLink,
-			IsPublic:       board.IsPublic,
-			UserID:         board.UserID,
-			VisibleColumns: board.VisibleColumns,
-			VisibleFields:  board.VisibleFields,
-			IdeasCount:     int(ideasCount),
-			ReactionsCount: reactionsCount,
-			CreatedAt:      board.CreatedAt,
-			UpdatedAt:      board.UpdatedAt,
+			ID:                board.ID,
+			Name:              board.Name,
+			Description:       board.Description,
+			PublicLink:        board.PublicLink,
+			IsPublic:          board.IsPublic,
+			UserID:            board.UserID,
+			Role:              callerBoardRole(c, &board, userID),
+			WorkspaceID:       board.WorkspaceID,
+			VisibleColumns:    board.VisibleColumns,
+			VisibleFields:     board.VisibleFields,
+			PublicRiceScore:   board.PublicRiceScore,
+			Archived:          board.Archived,
+			Frozen:            board.Frozen,
+			StrictPrivacy:     board.StrictPrivacy,
+			AcceptsIdeas:      board.AcceptsIdeas,
+			ModerateComments:  board.ModerateComments,
+			CaptchaProvider:   board.CaptchaProvider,
+			FeedbackRateLimit: board.FeedbackRateLimit,
+			Reactions:         board.AllowedReactions(),
+			VoteOptions:       voteOptionsOrEmpty(board.VoteOptions),
+			IdeasCount:        int(ideasCount),
+			ReactionsCount:    reactionsCount,
+			CreatedAt:         board.CreatedAt,
+			UpdatedAt:         board.UpdatedAt,
+			CreatedBy:         board.CreatedBy,
+			UpdatedBy:         board.UpdatedBy,
+			Version:           board.Version,
+			LogoURL:           boardLogoURL(&board),
 		})
 		log.Printf("[Handler] GetBoards - Board %d: ID=%s, Name=%s, PublicLink=%s, IdeasCount=%d",
 			i+1, board.ID, board.Name, board.PublicLink, ideasCount)
@@ -412,9 +586,17 @@ func GetBoards(c *gin.Context) {
 	log.Printf("[Handler] GetBoards completed successfully - Collection lookup summary: Total boards: %d, UserID: %s, Total duration: %v, Response duration: %v, IP: %s",
 		len(responses), userID, totalDuration, responseDuration, c.ClientIP())
 
+	if responses == nil {
+		responses = []BoardResponse{}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"boards": responses,
-		"count":  len(responses),
+		"boards":     responses,
+		"count":      len(responses),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
 	})
 }
 
@@ -456,10 +638,15 @@ func UpdateBoard(c *gin.Context) {
 		})
 		return
 	}
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
+		return
+	}
 
 	// Build update document
 	updateDoc := bson.M{
 		"updated_at": time.Now().UTC(),
+		"updated_by": userID,
 	}
 
 	if req.Name != "" {
@@ -502,6 +689,92 @@ func UpdateBoard(c *gin.Context) {
 		updateDoc["visible_fields"] = req.VisibleFields
 	}
 
+	// Handle public RICE score exposure toggle
+	if req.PublicRiceScore != nil {
+		updateDoc["public_rice_score"] = *req.PublicRiceScore
+	}
+	if req.Archived != nil {
+		updateDoc["archived"] = *req.Archived
+	}
+	if req.Frozen != nil {
+		updateDoc["frozen"] = *req.Frozen
+	}
+	if req.StrictPrivacy != nil {
+		updateDoc["strict_privacy"] = *req.StrictPrivacy
+	}
+	if req.AcceptsIdeas != nil {
+		updateDoc["accepts_ideas"] = *req.AcceptsIdeas
+	}
+	if req.ModerateComments != nil {
+		updateDoc["moderate_comments"] = *req.ModerateComments
+	}
+
+	// Handle CAPTCHA provider; only providers configured on this server can be selected
+	if req.CaptchaProvider != nil {
+		provider := strings.TrimSpace(*req.CaptchaProvider)
+		if provider != "" && utils.CaptchaSiteKey(provider) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_CAPTCHA_PROVIDER",
+					"message": "Unknown or unconfigured CAPTCHA provider",
+					"details": "supported providers are hcaptcha, turnstile and recaptcha, each requiring its site key and secret on the server",
+				},
+			})
+			return
+		}
+		updateDoc["captcha_provider"] = provider
+	}
+
+	// Handle feedback rate limit policy
+	unsetDoc := bson.M{}
+	if req.FeedbackRateLimit != nil {
+		if *req.FeedbackRateLimit == (models.FeedbackRateLimit{}) {
+			unsetDoc["feedback_rate_limit"] = ""
+		} else {
+			if validationErrors := models.ValidateFeedbackRateLimit(req.FeedbackRateLimit); len(validationErrors) > 0 {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": gin.H{
+						"code":    "INVALID_RATE_LIMIT",
+						"message": "Invalid feedback rate limit",
+						"details": validationErrors.Error(),
+					},
+				})
+				return
+			}
+			updateDoc["feedback_rate_limit"] = *req.FeedbackRateLimit
+		}
+	}
+
+	// Handle custom reaction set
+	if req.Reactions != nil {
+		if validationErrors := models.ValidateReactions(*req.Reactions); len(validationErrors) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_REACTIONS",
+					"message": "Invalid reaction set",
+					"details": validationErrors.Error(),
+				},
+			})
+			return
+		}
+		updateDoc["reactions"] = *req.Reactions
+	}
+
+	// Handle vote options
+	if req.VoteOptions != nil {
+		if validationErrors := models.ValidateVoteOptions(*req.VoteOptions); len(validationErrors) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_VOTE_OPTIONS",
+					"message": "Invalid vote options",
+					"details": validationErrors.Error(),
+				},
+			})
+			return
+		}
+		updateDoc["vote_options"] = *req.VoteOptions
+	}
+
 	// Handle isPublic field
 	if req.IsPublic != nil {
 		updateDoc["is_public"] = *req.IsPublic
@@ -515,9 +788,20 @@ func UpdateBoard(c *gin.Context) {
 	}
 
 	// Update board in MongoDB
-	collection := models.GetCollection(models.BoardsCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
+
+	// Handle workspace placement; the owner may move a board into their active organization's workspace
+	if req.WorkspaceID != nil {
+		workspaceID := strings.TrimSpace(*req.WorkspaceID)
+		if workspaceID == "" {
+			unsetDoc["workspace_id"] = ""
+		} else {
+			if rejectBoardWorkspace(ctx, c, workspaceID, boardID) {
+				return
+			}
+			updateDoc["workspace_id"] = workspaceID
+		}
+	}
 
 	filter := bson.M{
 		"_id":     boardID,
@@ -527,11 +811,44 @@ func UpdateBoard(c *gin.Context) {
 	log.Printf("[Handler] UpdateBoard - Collection update - Database: disko, Collection: boards, BoardID: %s, UserID: %s, UpdateDoc: %v",
 		boardID, userID, updateDoc)
 
+	before := findAuditBoard(ctx, boardID)
+
+	// Refuse to overwrite settings the owner has not seen
+	owned := before != nil && before.UserID == userID
+	if version != nil && owned && *version != before.Version {
+		respondVersionConflict(c, *version, before.Version)
+		return
+	}
+	if version != nil {
+		filter["version"] = models.VersionCondition(*version)
+	}
+
 	updateStartTime := time.Now()
-	result, err := collection.UpdateOne(ctx, filter, bson.M{"$set": updateDoc})
+	update := bson.M{"$set": updateDoc}
+	if len(unsetDoc) > 0 {
+		update["$unset"] = unsetDoc
+	}
+	updatedBoard, err := models.UpdateBoardAndReturn(ctx, filter, models.BumpVersion(update))
 	updateDuration := time.Since(updateStartTime)
 
+	if err == mongo.ErrNoDocuments && version != nil && owned {
+		if current, found := currentVersion(ctx, models.BoardsCollection, boardID); found {
+			respondVersionConflict(c, *version, current)
+			return
+		}
+	}
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Printf("[Handler] UpdateBoard failed - Board not found in collection - BoardID: %s, UserID: %s", boardID, userID)
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or you don't have permission to update it",
+				},
+			})
+			return
+		}
+
 		log.Printf("[Handler] UpdateBoard failed - Collection update error: %v, BoardID: %s, UserID: %s, Duration: %v",
 			err, boardID, userID, updateDuration)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -544,57 +861,64 @@ func UpdateBoard(c *gin.Context) {
 		return
 	}
 
-	log.Printf("[Handler] UpdateBoard - Collection update successful - Matched: %d, Modified: %d, BoardID: %s, UserID: %s, Duration: %v",
-		result.MatchedCount, result.ModifiedCount, boardID, userID, updateDuration)
+	log.Printf("[Handler] UpdateBoard - Collection update successful - BoardID: %s, Name: %s, UserID: %s, Duration: %v",
+		updatedBoard.ID, updatedBoard.Name, userID, updateDuration)
 
-	if result.MatchedCount == 0 {
-		log.Printf("[Handler] UpdateBoard failed - Board not found in collection - BoardID: %s, UserID: %s", boardID, userID)
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": gin.H{
-				"code":    "BOARD_NOT_FOUND",
-				"message": "Board not found or you don't have permission to update it",
-			},
-		})
-		return
+	// A board made private or given a new link stops being served before the response
+	if req.IsPublic != nil {
+		if err := utils.DeletePublicBoard(ctx, boardID); err != nil {
+			log.Printf("[Handler] UpdateBoard - Failed to delete public read model: %v, BoardID: %s", err, boardID)
+		}
 	}
 
-	// Fetch and return updated board
-	log.Printf("[Handler] UpdateBoard - Fetching updated board from collection - BoardID: %s, UserID: %s", boardID, userID)
-
-	fetchStartTime := time.Now()
-	var updatedBoard models.Board
-	err = collection.FindOne(ctx, filter).Decode(&updatedBoard)
-	fetchDuration := time.Since(fetchStartTime)
-
-	if err != nil {
-		log.Printf("[Handler] UpdateBoard failed - Fetch updated board error: %v, BoardID: %s, UserID: %s, Duration: %v",
-			err, boardID, userID, fetchDuration)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch updated board",
-				"details": err.Error(),
-			},
+	// Record activity
+	go utils.RecordActivity(ctx, boardID, "", userID, models.ActivityBoardUpdated, map[string]interface{}{
+		"fields": updatedFields(updateDoc),
+	})
+	recordAudit(c, userID, models.AuditBoardUpdated, boardID, models.AuditTargetBoard, boardID, before, updatedBoard)
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventBoardUpdated, "", map[string]interface{}{
+		"fields": updatedFields(updateDoc),
+	})
+	if req.IsPublic != nil {
+		utils.RecheckBoardAccess(boardID) // Public visitors lose a board made private or given a new link
+	}
+	if updatedBoard.IsPublic && (before == nil || !before.IsPublic) {
+		go utils.EmitWebhookEvent(ctx, boardID, models.WebhookBoardPublished, gin.H{
+			"publicLink": updatedBoard.PublicLink,
+			"url":        fmt.Sprintf("%s/public/%s", os.Getenv("APP_URL"), updatedBoard.PublicLink),
 		})
-		return
 	}
 
-	log.Printf("[Handler] UpdateBoard - Updated board fetched from collection - BoardID: %s, Name: %s, UserID: %s, Duration: %v",
-		updatedBoard.ID, updatedBoard.Name, userID, fetchDuration)
-
 	// Return updated board
 	response := BoardResponse{
-		ID:             updatedBoard.ID,
-		Name:           updatedBoard.Name,
-		Description:    updatedBoard.Description,
-		PublicLink:     updatedBoard.PublicLink,
-		UserID:         updatedBoard.UserID,
-		VisibleColumns: updatedBoard.VisibleColumns,
-		VisibleFields:  updatedBoard.VisibleFields,
-		CreatedAt:      updatedBoard.CreatedAt,
-		UpdatedAt:      updatedBoard.UpdatedAt,
+		ID:                updatedBoard.ID,
+		Name:              updatedBoard.Name,
+		Description:       updatedBoard.Description,
+		PublicLink:        updatedBoard.PublicLink,
+		UserID:            updatedBoard.UserID,
+		Role:              models.RoleOwner,
+		WorkspaceID:       updatedBoard.WorkspaceID,
+		VisibleColumns:    updatedBoard.VisibleColumns,
+		VisibleFields:     updatedBoard.VisibleFields,
+		PublicRiceScore:   updatedBoard.PublicRiceScore,
+		Archived:          updatedBoard.Archived,
+		Frozen:            updatedBoard.Frozen,
+		StrictPrivacy:     updatedBoard.StrictPrivacy,
+		AcceptsIdeas:      updatedBoard.AcceptsIdeas,
+		ModerateComments:  updatedBoard.ModerateComments,
+		CaptchaProvider:   updatedBoard.CaptchaProvider,
+		FeedbackRateLimit: updatedBoard.FeedbackRateLimit,
+		Reactions:         updatedBoard.AllowedReactions(),
+		VoteOptions:       voteOptionsOrEmpty(updatedBoard.VoteOptions),
+		CreatedAt:         updatedBoard.CreatedAt,
+		UpdatedAt:         updatedBoard.UpdatedAt,
+		CreatedBy:         updatedBoard.CreatedBy,
+		UpdatedBy:         updatedBoard.UpdatedBy,
+		Version:           updatedBoard.Version,
+		LogoURL:           boardLogoURL(updatedBoard),
 	}
 
+	setVersionHeader(c, updatedBoard.Version)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -633,8 +957,19 @@ func DeleteBoard(c *gin.Context) {
 	log.Printf("[Handler] DeleteBoard started - BoardID: %s, UserID: %s, IP: %s, UserAgent: %s, Referer: %s",
 		boardID, userID, c.ClientIP(), userAgent, referer)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// The owner confirms by typing the board name, so a stray or forged request can't delete it
+	var req DeleteBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ConfirmName) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "CONFIRMATION_REQUIRED",
+				"message": "Type the board name in confirmName to delete the board",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
 
 	// Start a transaction for cascade deletion
 	sessionStartTime := time.Now()
@@ -658,10 +993,11 @@ func DeleteBoard(c *gin.Context) {
 		sessionDuration, boardID, userID)
 
 	// Execute transaction
+	var deletedBoard models.Board
 	transactionStartTime := time.Now()
 	err = mongo.WithSession(ctx, session, func(sc context.Context) error {
 		// First, verify the board exists and belongs to the user
-		boardsCollection := models.GetCollection(models.BoardsCollection)
+		boardsCollection := models.GetCollection(ctx, models.BoardsCollection)
 		boardFilter := bson.M{
 			"_id":     boardID,
 			"user_id": userID,
@@ -685,9 +1021,13 @@ func DeleteBoard(c *gin.Context) {
 
 		log.Printf("[Handler] DeleteBoard - Board verified - Name: %s, PublicLink: %s, BoardID: %s, UserID: %s",
 			board.Name, board.PublicLink, boardID, userID)
+		if strings.TrimSpace(req.ConfirmName) != strings.TrimSpace(board.Name) {
+			return &BoardConfirmationError{}
+		}
+		deletedBoard = board
 
 		// Delete all ideas associated with this board
-		ideasCollection := models.GetCollection(models.IdeasCollection)
+		ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 		ideasFilter := bson.M{"board_id": boardID}
 
 		log.Printf("[Handler] DeleteBoard - Collection deletion - Ideas collection: Database: disko, Collection: ideas, BoardID: %s, UserID: %s",
@@ -703,6 +1043,240 @@ func DeleteBoard(c *gin.Context) {
 		log.Printf("[Handler] DeleteBoard - Ideas collection deletion successful - Ideas deleted: %d, BoardID: %s, UserID: %s",
 			ideasResult.DeletedCount, boardID, userID)
 
+		// Delete the board's releases
+		releasesResult, err := models.GetCollection(ctx, models.ReleasesCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Releases deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Releases collection deletion successful - Releases deleted: %d, BoardID: %s, UserID: %s",
+			releasesResult.DeletedCount, boardID, userID)
+
+		// Delete the board's idea suggestions
+		submissionsResult, err := models.GetCollection(ctx, models.SubmissionsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Submissions deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Submissions collection deletion successful - Submissions deleted: %d, BoardID: %s, UserID: %s",
+			submissionsResult.DeletedCount, boardID, userID)
+
+		// Delete the board's comments
+		commentsResult, err := models.GetCollection(ctx, models.CommentsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Comments deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Comments collection deletion successful - Comments deleted: %d, BoardID: %s, UserID: %s",
+			commentsResult.DeletedCount, boardID, userID)
+
+		// Delete the board's visitor votes
+		votesResult, err := models.GetCollection(ctx, models.VotesCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Votes deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Votes collection deletion successful - Votes deleted: %d, BoardID: %s, UserID: %s",
+			votesResult.DeletedCount, boardID, userID)
+
+		// Delete the board's release announcement subscribers
+		subscribersResult, err := models.GetCollection(ctx, models.SubscribersCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Subscribers deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Subscribers collection deletion successful - Subscribers deleted: %d, BoardID: %s, UserID: %s",
+			subscribersResult.DeletedCount, boardID, userID)
+
+		// Delete the board's collaborator invitations
+		invitationsResult, err := models.GetCollection(ctx, models.InvitationsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Invitations deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Invitations collection deletion successful - Invitations deleted: %d, BoardID: %s, UserID: %s",
+			invitationsResult.DeletedCount, boardID, userID)
+
+		// Revoke the board's API tokens
+		apiTokensResult, err := models.GetCollection(ctx, models.APITokensCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - API tokens deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - API tokens collection deletion successful - Tokens deleted: %d, BoardID: %s, UserID: %s",
+			apiTokensResult.DeletedCount, boardID, userID)
+
+		// Delete the members' notification preferences for the board
+		preferencesResult, err := models.GetCollection(ctx, models.NotificationPrefsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Notification preferences deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Notification preferences deletion successful - Preferences deleted: %d, BoardID: %s, UserID: %s",
+			preferencesResult.DeletedCount, boardID, userID)
+
+		// Delete the board's outgoing webhooks
+		webhooksResult, err := models.GetCollection(ctx, models.BoardWebhooksCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Webhooks deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Webhooks deletion successful - Webhooks deleted: %d, BoardID: %s, UserID: %s",
+			webhooksResult.DeletedCount, boardID, userID)
+
+		// Delete the board's Telegram chat, with its bot token
+		telegramResult, err := models.GetCollection(ctx, models.TelegramConfigsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Telegram config deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Telegram config deletion successful - Configs deleted: %d, BoardID: %s, UserID: %s",
+			telegramResult.DeletedCount, boardID, userID)
+
+		// Delete the board's Jira connection, with its credentials
+		jiraResult, err := models.GetCollection(ctx, models.JiraConfigsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Jira config deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Jira config deletion successful - Configs deleted: %d, BoardID: %s, UserID: %s",
+			jiraResult.DeletedCount, boardID, userID)
+
+		// Delete the board's Linear connection, with its encrypted credentials
+		linearResult, err := models.GetCollection(ctx, models.LinearConfigsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Linear config deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Linear config deletion successful - Configs deleted: %d, BoardID: %s, UserID: %s",
+			linearResult.DeletedCount, boardID, userID)
+
+		// Delete the board's alert rules and what they fired for
+		alertRulesResult, err := models.GetCollection(ctx, models.AlertRulesCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Alert rules deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+		if _, err := models.GetCollection(ctx, models.AlertFiringsCollection).DeleteMany(sc, bson.M{"board_id": boardID}); err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Alert firings deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Alert rules deletion successful - Rules deleted: %d, BoardID: %s, UserID: %s",
+			alertRulesResult.DeletedCount, boardID, userID)
+
+		// Delete the webhooks' delivery log
+		deliveriesResult, err := models.GetCollection(ctx, models.WebhookDeliveriesCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Webhook deliveries deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Webhook deliveries deletion successful - Deliveries deleted: %d, BoardID: %s, UserID: %s",
+			deliveriesResult.DeletedCount, boardID, userID)
+
+		// Drop the board's queued notifications
+		jobsResult, err := models.GetCollection(ctx, models.NotificationJobsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Notification jobs deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Notification jobs deletion successful - Jobs deleted: %d, BoardID: %s, UserID: %s",
+			jobsResult.DeletedCount, boardID, userID)
+
+		// Delete the board's notifications from notification centers
+		userNotificationsResult, err := models.GetCollection(ctx, models.UserNotificationsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - User notifications deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - User notifications deletion successful - Notifications deleted: %d, BoardID: %s, UserID: %s",
+			userNotificationsResult.DeletedCount, boardID, userID)
+
+		// Delete the board's pending notification digests
+		notificationBatchesResult, err := models.GetCollection(ctx, models.NotificationBatchesCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Notification batches deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Notification batches deletion successful - Batches deleted: %d, BoardID: %s, UserID: %s",
+			notificationBatchesResult.DeletedCount, boardID, userID)
+
+		// Delete the board's attachment records; the files go once the transaction commits
+		attachmentsResult, err := models.GetCollection(ctx, models.AttachmentsCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Attachments deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Attachments deletion successful - Attachments deleted: %d, BoardID: %s, UserID: %s",
+			attachmentsResult.DeletedCount, boardID, userID)
+
+		// Delete the score history of the board's ideas
+		scoreHistoryResult, err := models.GetCollection(ctx, models.ScoreHistoryCollection).DeleteMany(sc, bson.M{"board_id": boardID})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Score history deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Score history deletion successful - Snapshots deleted: %d, BoardID: %s, UserID: %s",
+			scoreHistoryResult.DeletedCount, boardID, userID)
+
+		// Drop the board from service account scopes
+		serviceAccountsResult, err := models.GetCollection(ctx, models.ServiceAccountsCollection).UpdateMany(sc,
+			bson.M{"scopes.board_id": boardID},
+			bson.M{"$pull": bson.M{"scopes": bson.M{"board_id": boardID}}})
+		if err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Service account scopes update error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
+		log.Printf("[Handler] DeleteBoard - Service account scopes updated - Accounts updated: %d, BoardID: %s, UserID: %s",
+			serviceAccountsResult.ModifiedCount, boardID, userID)
+
+		// Stop serving the board publicly
+		if _, err := models.GetCollection(ctx, models.PublicBoardsCollection).DeleteOne(sc, bson.M{"_id": boardID}); err != nil {
+			log.Printf("[Handler] DeleteBoard failed - Public read model deletion error: %v, BoardID: %s, UserID: %s",
+				err, boardID, userID)
+			return err
+		}
+
 		// Delete the board itself
 		log.Printf("[Handler] DeleteBoard - Collection deletion - Boards collection: Database: disko, Collection: boards, BoardID: %s, UserID: %s",
 			boardID, userID)
@@ -734,6 +1308,15 @@ func DeleteBoard(c *gin.Context) {
 			})
 			return
 		}
+		if _, ok := err.(*BoardConfirmationError); ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "CONFIRMATION_MISMATCH",
+					"message": "The typed name does not match the board name",
+				},
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
@@ -744,11 +1327,21 @@ func DeleteBoard(c *gin.Context) {
 		})
 		return
 	}
+	models.InvalidateBoard(boardID)
+	utils.RecheckBoardAccess(boardID)
+
+	// The board is gone, so a failure only leaves unreachable files behind
+	if deleted, err := utils.GetStorage().DeletePrefix(ctx, models.BoardStoragePrefix(ctx, boardID)); err != nil {
+		log.Printf("[Handler] DeleteBoard - Files cleanup error: %v, Files deleted: %d, BoardID: %s", err, deleted, boardID)
+	}
 
 	totalDuration := time.Since(startTime)
 	log.Printf("[Handler] DeleteBoard completed successfully - BoardID: %s, UserID: %s, Transaction duration: %v, Total duration: %v, IP: %s",
 		boardID, userID, transactionDuration, totalDuration, c.ClientIP())
 
+	recordAudit(c, userID, models.AuditBoardDeleted, boardID, models.AuditTargetBoard, boardID, deletedBoard, nil)
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventBoardDeleted, "", nil)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Board deleted successfully",
 		"boardID": boardID,
@@ -762,6 +1355,13 @@ type PublicBoardResponse struct {
 	Description    string    `json:"description,omitempty"`
 	VisibleColumns []string  `json:"visibleColumns"`
 	VisibleFields  []string  `json:"visibleFields"`
+	ShowRiceScore  bool      `json:"showRiceScore"`
+	StrictPrivacy  bool      `json:"strictPrivacy"` // Frontend can skip the consent banner
+	AcceptsIdeas   bool      `json:"acceptsIdeas"`  // Frontend shows the suggestion form
+	Reactions      []string  `json:"reactions"`
+	VoteOptions    []string  `json:"voteOptions"`
+	Captcha        *Captcha  `json:"captcha,omitempty"` // Widget to render before public writes
+	LogoURL        string    `json:"logoUrl,omitempty"`
 	CreatedAt      time.Time `json:"createdAt"`
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
@@ -792,63 +1392,65 @@ func GetBoard(c *gin.Context) {
 	log.Printf("[Handler] GetBoard started - BoardID: %s, UserID: %s, IP: %s, UserAgent: %s, Referer: %s",
 		boardID, userID, c.ClientIP(), userAgent, referer)
 
-	// Get database connection
-	if models.DB == nil {
-		log.Printf("[Handler] GetBoard failed - Database connection failed, BoardID: %s, UserID: %s", boardID, userID)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Database connection failed",
-			},
-		})
+	board, ok := contextBoard(c)
+	if !ok {
 		return
 	}
 
-	// Find the board
-	collection := models.GetCollection(models.BoardsCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{"_id": boardID, "user_id": userID}
-	log.Printf("[Handler] GetBoard - Database query: Filter: %+v, BoardID: %s, UserID: %s", filter, boardID, userID)
-	log.Printf("[Handler] GetBoard - Database connection status: %t", models.DB != nil)
-	log.Printf("[Handler] GetBoard - Collection name: %s", models.BoardsCollection)
+	// Convert to response format
+	response := BoardResponse{
+		ID:                board.ID,
+		Name:              board.Name,
+		Description:       board.Description,
+		PublicLink:        board.PublicLink,
+		IsPublic:          board.IsPublic,
+		UserID:            board.UserID,
+		IsAdmin:           board.UserID == userID, // User is admin if they own the board
+		Role:              callerBoardRole(c, board, userID),
+		WorkspaceID:       board.WorkspaceID,
+		VisibleColumns:    board.VisibleColumns,
+		VisibleFields:     board.VisibleFields,
+		PublicRiceScore:   board.PublicRiceScore,
+		Archived:          board.Archived,
+		Frozen:            board.Frozen,
+		StrictPrivacy:     board.StrictPrivacy,
+		AcceptsIdeas:      board.AcceptsIdeas,
+		ModerateComments:  board.ModerateComments,
+		CaptchaProvider:   board.CaptchaProvider,
+		FeedbackRateLimit: board.FeedbackRateLimit,
+		Reactions:         board.AllowedReactions(),
+		VoteOptions:       voteOptionsOrEmpty(board.VoteOptions),
+		CreatedAt:         board.CreatedAt,
+		UpdatedAt:         board.UpdatedAt,
+		CreatedBy:         board.CreatedBy,
+		UpdatedBy:         board.UpdatedBy,
+		Version:           board.Version,
+		LogoURL:           boardLogoURL(board),
+	}
 
-	var board models.Board
-	if err := collection.FindOne(ctx, filter).Decode(&board); err != nil {
-		if err == mongo.ErrNoDocuments {
-			log.Printf("[Handler] GetBoard failed - Board not found or user does not own it: BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "BOARD_NOT_FOUND",
-					"message": "Board not found or you don't have permission to access it",
-				},
-			})
-		} else {
-			log.Printf("[Handler] GetBoard failed - Database error: BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
+	// Optional aggregated stats
+	for _, include := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(include) != "stats" {
+			continue
+		}
+		stats, err := models.GetBoardStats(ctx, board)
+		if err != nil {
+			log.Printf("[Handler] GetBoard failed - Stats aggregation error: BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": gin.H{
 					"code":    "DATABASE_ERROR",
-					"message": "Failed to retrieve board",
+					"message": "Failed to compute board stats",
+					"details": err.Error(),
 				},
 			})
+			return
 		}
-		return
-	}
-
-	// Convert to response format
-	response := BoardResponse{
-		ID:             board.ID,
-		Name:           board.Name,
-		Description:    board.Description,
-		PublicLink:     board.PublicLink,
-		IsPublic:       board.IsPublic,
-		UserID:         board.UserID,
-		IsAdmin:        board.UserID == userID, // User is admin if they own the board
-		VisibleColumns: board.VisibleColumns,
-		VisibleFields:  board.VisibleFields,
-		CreatedAt:      board.CreatedAt,
-		UpdatedAt:      board.UpdatedAt,
+		response.Stats = stats
+		response.IdeasCount = stats.TotalIdeas
+		response.ReactionsCount = stats.TotalFeedback
 	}
 
 	duration := time.Since(startTime)
@@ -882,18 +1484,11 @@ func GetPublicBoard(c *gin.Context) {
 	log.Printf("[Handler] GetPublicBoard started - PublicLink: %s, IP: %s, UserAgent: %s, Referer: %s",
 		publicLink, c.ClientIP(), userAgent, referer)
 
-	// Query board by public link
-	collection := models.GetCollection(models.BoardsCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	filter := bson.M{"public_link": publicLink, "is_public": true}
-	log.Printf("[Handler] GetPublicBoard - Collection lookup - Database: disko, Collection: boards, PublicLink: %s, Filter: %v",
-		publicLink, filter)
+	// Read the board from its public read model
+	ctx := c.Request.Context()
 
 	dbStartTime := time.Now()
-	var board models.Board
-	err := collection.FindOne(ctx, filter).Decode(&board)
+	view, err := utils.LoadPublicBoard(ctx, publicLink)
 	dbDuration := time.Since(dbStartTime)
 
 	if err != nil {
@@ -909,7 +1504,7 @@ func GetPublicBoard(c *gin.Context) {
 			return
 		}
 
-		log.Printf("[Handler] GetPublicBoard failed - Collection lookup error: %v, PublicLink: %s, Duration: %v, IP: %s",
+		log.Printf("[Handler] GetPublicBoard failed - Read model lookup error: %v, PublicLink: %s, Duration: %v, IP: %s",
 			err, publicLink, dbDuration, c.ClientIP())
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
@@ -920,10 +1515,22 @@ func GetPublicBoard(c *gin.Context) {
 		})
 		return
 	}
+	board := view.Board.AsBoard()
 
-	log.Printf("[Handler] GetPublicBoard - Collection lookup successful - Board found: ID=%s, Name=%s, PublicLink=%s, Duration: %v",
+	log.Printf("[Handler] GetPublicBoard - Read model lookup successful - Board found: ID=%s, Name=%s, PublicLink=%s, Duration: %v",
 		board.ID, board.Name, board.PublicLink, dbDuration)
 
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	// Widgets and bots revalidating an unchanged board get a 304 without the body
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	if utils.RespondNotModified(c, board.UpdatedAt, board.IPRules != nil, board.ID) {
+		log.Printf("[Handler] GetPublicBoard not modified - BoardID: %s, IP: %s", board.ID, utils.VisitorKey(c.ClientIP(), board.StrictPrivacy))
+		return
+	}
+
 	// Return public board data (without admin-only information)
 	responseStartTime := time.Now()
 	response := PublicBoardResponse{
@@ -932,6 +1539,13 @@ func GetPublicBoard(c *gin.Context) {
 		Description:    board.Description,
 		VisibleColumns: board.VisibleColumns,
 		VisibleFields:  board.VisibleFields,
+		ShowRiceScore:  board.ShowsRiceScorePublicly(),
+		StrictPrivacy:  board.StrictPrivacy,
+		AcceptsIdeas:   board.AcceptsIdeas,
+		Reactions:      board.AllowedReactions(),
+		VoteOptions:    voteOptionsOrEmpty(board.VoteOptions),
+		Captcha:        publicCaptcha(&board),
+		LogoURL:        publicBoardLogoURL(&board),
 		CreatedAt:      board.CreatedAt,
 		UpdatedAt:      board.UpdatedAt,
 	}
@@ -939,7 +1553,7 @@ func GetPublicBoard(c *gin.Context) {
 
 	totalDuration := time.Since(startTime)
 	log.Printf("[Handler] GetPublicBoard completed successfully - Collection lookup summary: BoardID: %s, Name: %s, Total duration: %v, Response duration: %v, IP: %s",
-		board.ID, board.Name, totalDuration, responseDuration, c.ClientIP())
+		board.ID, board.Name, totalDuration, responseDuration, utils.VisitorKey(c.ClientIP(), board.StrictPrivacy))
 
 	c.JSON(http.StatusOK, response)
 }
@@ -949,6 +1563,7 @@ func GetPublicReleasedIdeas(c *gin.Context) {
 	boardID := c.Param("id")
 	log.Printf("[API] GetReleasedIdeas (public) called - BoardID: %s, IP: %s, UserAgent: %s", boardID, c.ClientIP(), c.GetHeader("User-Agent"))
 	c.Header("X-Public-Access", "true")
+	c.Set("publicAccess", true)
 	GetReleasedIdeas(c)
 }
 
@@ -959,11 +1574,19 @@ func (e *BoardNotFoundError) Error() string {
 	return "board not found"
 }
 
+// BoardConfirmationError means the typed confirmation did not match the board name
+type BoardConfirmationError struct{}
+
+func (e *BoardConfirmationError) Error() string {
+	return "board name confirmation mismatch"
+}
+
 // InviteRequest represents the request payload for sending board invitations
 type InviteRequest struct {
 	Email   string `json:"emailTo" binding:"required,email"`
 	Subject string `json:"subject" binding:"required,min=1,max=200"`
 	Message string `json:"message,omitempty" binding:"max=1000"`
+	Role    string `json:"role,omitempty"` // editor or viewer invites a collaborator; empty shares the public board
 }
 
 // SendBoardInvite handles POST /api/boards/:id/invite
@@ -1016,36 +1639,16 @@ func SendBoardInvite(c *gin.Context) {
 		return
 	}
 
-	// Get board data to verify ownership and get board info
-	collection := models.GetCollection(models.BoardsCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
 
-	filter := bson.M{"_id": boardID, "user_id": userID}
-	var board models.Board
-	err = collection.FindOne(ctx, filter).Decode(&board)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			log.Printf("[Handler] SendBoardInvite failed - Board not found or not owned by user - BoardID: %s, UserID: %s, IP: %s",
-				boardID, userID, c.ClientIP())
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "BOARD_NOT_FOUND",
-					"message": "Board not found or you don't have permission to invite to this board",
-				},
-			})
-			return
-		}
+	ctx := c.Request.Context()
 
-		log.Printf("[Handler] SendBoardInvite failed - Database error: %v, BoardID: %s, UserID: %s, IP: %s",
-			err, boardID, userID, c.ClientIP())
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch board",
-				"details": err.Error(),
-			},
-		})
+	// Collaborator invitations make the invitee a member instead of pointing them to the public board
+	if req.Role != "" {
+		sendCollaboratorInvite(ctx, c, board, &req, userID)
 		return
 	}
 
@@ -1063,7 +1666,7 @@ func SendBoardInvite(c *gin.Context) {
 	}
 
 	// Send invitation email
-	err = utils.SendBoardInviteEmail(req.Email, req.Subject, req.Message, board, userID)
+	err = utils.SendBoardInviteEmail(ctx, req.Email, req.Subject, req.Message, *board, userID)
 	if err != nil {
 		log.Printf("[Handler] SendBoardInvite failed - Email error: %v, BoardID: %s, UserID: %s, Email: %s, IP: %s",
 			err, boardID, userID, req.Email, c.ClientIP())
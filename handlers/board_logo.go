@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// boardLogoURL returns a signed link to the board's logo for signed-in members, or "" without one
+func boardLogoURL(board *models.Board) string {
+	if link := signedDownload(board.LogoKey, ""); link != nil {
+		return link.URL
+	}
+	return ""
+}
+
+// publicBoardLogoURL returns the address of a public board's logo, or "" without one. The file
+// name changes with every upload, so the address can be cached for long.
+func publicBoardLogoURL(board *models.Board) string {
+	if board.LogoKey == "" {
+		return ""
+	}
+	return os.Getenv("APP_URL") + "/api/boards/" + board.PublicLink + "/logo/public?v=" + strings.TrimSuffix(path.Base(board.LogoKey), path.Ext(board.LogoKey))
+}
+
+// UploadBoardLogo handles PUT /api/boards/:id/logo with the image as the file field of a multipart
+// form. Replaces the previous logo.
+func UploadBoardLogo(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	body, _, ok := readUpload(c, models.MaxBoardLogoSize)
+	if !ok {
+		return
+	}
+	contentType := http.DetectContentType(body)
+	extension, allowed := models.BoardLogoContentTypes[contentType]
+	if !allowed {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_IMAGE",
+				"message": "Logos must be PNG, JPEG, GIF or WebP images",
+				"details": "detected " + contentType,
+			},
+		})
+		return
+	}
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	key := models.BoardStoragePrefix(ctx, boardID) + "logo-" + utils.GenerateShortUUID() + extension
+	if err := utils.GetStorage().Put(ctx, key, body, contentType); err != nil {
+		log.Printf("[Handler] UploadBoardLogo failed - BoardID: %s, Error: %v, UserID: %s, IP: %s", boardID, err, userID, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "STORAGE_ERROR",
+				"message": "Failed to store logo",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	update := bson.M{"$set": bson.M{"logo_key": key, "updated_at": time.Now().UTC(), "updated_by": userID}}
+	if _, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, bson.M{"_id": boardID}, models.BumpVersion(update)); err != nil {
+		utils.GetStorage().Delete(ctx, key)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update board logo",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(boardID)
+
+	// The previous file is unreachable now; a failure only leaves it behind
+	if board.LogoKey != "" {
+		if err := utils.GetStorage().Delete(ctx, board.LogoKey); err != nil {
+			log.Printf("[Handler] UploadBoardLogo - Previous logo cleanup error: %v, BoardID: %s", err, boardID)
+		}
+	}
+
+	log.Printf("[Handler] UploadBoardLogo success - BoardID: %s, Type: %s, Size: %d, UserID: %s, IP: %s",
+		boardID, contentType, len(body), userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditBoardUpdated, boardID, models.AuditTargetBoard, boardID,
+		gin.H{"logo": board.LogoKey != ""}, gin.H{"logo": true})
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventBoardUpdated, "", map[string]interface{}{
+		"fields": []string{"logo_key"},
+	})
+
+	board.LogoKey = key
+	c.JSON(http.StatusOK, gin.H{
+		"logoUrl":       boardLogoURL(board),
+		"publicLogoUrl": publicBoardLogoURL(board),
+	})
+}
+
+// DeleteBoardLogo handles DELETE /api/boards/:id/logo
+func DeleteBoardLogo(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+	if board.LogoKey == "" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "LOGO_NOT_FOUND",
+				"message": "This board has no logo",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	update := bson.M{"$unset": bson.M{"logo_key": ""}, "$set": bson.M{"updated_at": time.Now().UTC(), "updated_by": userID}}
+	if _, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, bson.M{"_id": boardID}, models.BumpVersion(update)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove board logo",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(boardID)
+
+	if err := utils.GetStorage().Delete(ctx, board.LogoKey); err != nil {
+		log.Printf("[Handler] DeleteBoardLogo - Logo cleanup error: %v, BoardID: %s", err, boardID)
+	}
+
+	log.Printf("[Handler] DeleteBoardLogo success - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditBoardUpdated, boardID, models.AuditTargetBoard, boardID,
+		gin.H{"logo": true}, gin.H{"logo": false})
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventBoardUpdated, "", map[string]interface{}{
+		"fields": []string{"logo_key"},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Board logo removed successfully",
+	})
+}
+
+// GetPublicBoardLogo handles GET /api/boards/:id/logo/public, where :id is the public link
+func GetPublicBoardLogo(c *gin.Context) {
+	publicLink := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	view, err := utils.LoadPublicBoard(ctx, publicLink)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	board := view.Board.AsBoard()
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+	if board.LogoKey == "" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "LOGO_NOT_FOUND",
+				"message": "This board has no logo",
+			},
+		})
+		return
+	}
+
+	// Boards with IP rules must not have their logo cached by shared caches
+	cacheControl := "public, max-age=86400"
+	if board.IPRules != nil {
+		cacheControl = "private, max-age=86400"
+	}
+	serveStoredFile(c, board.LogoKey, "", cacheControl)
+}
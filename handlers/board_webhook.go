@@ -0,0 +1,465 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateBoardWebhookRequest represents the request payload for registering an outgoing webhook
+type CreateBoardWebhookRequest struct {
+	URL     string                    `json:"url" binding:"required,max=2048"`
+	Events  []models.WebhookEventType `json:"events"` // Catalog event types; empty subscribes to every event
+	Enabled *bool                     `json:"enabled"`
+}
+
+// UpdateBoardWebhookRequest represents the request payload for changing an outgoing webhook;
+// omitted fields are kept
+type UpdateBoardWebhookRequest struct {
+	URL          *string                    `json:"url" binding:"omitempty,max=2048"`
+	Events       *[]models.WebhookEventType `json:"events"`
+	Enabled      *bool                      `json:"enabled"`
+	RotateSecret bool                       `json:"rotateSecret"`
+}
+
+// GetWebhookDeliveriesRequest represents query parameters for a webhook's delivery log
+type GetWebhookDeliveriesRequest struct {
+	Success  *bool `form:"success"` // Only successful or only failed attempts
+	Page     int   `form:"page"`
+	PageSize int   `form:"pageSize"`
+}
+
+// BoardWebhookSecretResponse returns a webhook together with its secret, after it was created or rotated
+type BoardWebhookSecretResponse struct {
+	models.BoardWebhook
+	Secret string `json:"secret"`
+}
+
+// validateWebhookURL checks a webhook URL is an https URL on the public internet, writing an error
+// response if invalid
+func validateWebhookURL(c *gin.Context, rawURL string) bool {
+	if err := utils.ValidateOutboundURL(c.Request.Context(), rawURL); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_WEBHOOK_URL",
+				"message": "Webhook URL must be an absolute https URL on the public internet",
+				"details": err.Error(),
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// validateWebhookEvents checks a webhook's events, writing an error response if invalid
+func validateWebhookEvents(c *gin.Context, events []models.WebhookEventType) bool {
+	if validationErrors := models.ValidateWebhookEvents(events); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_WEBHOOK_EVENTS",
+				"message": "Invalid webhook events",
+				"details": validationErrors.Error(),
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// uniqueEvents converts legacy event names to their catalog types and drops repeated events,
+// keeping their order
+func uniqueEvents(events []models.WebhookEventType) []models.WebhookEventType {
+	seen := make(map[models.WebhookEventType]bool)
+	unique := []models.WebhookEventType{}
+	for _, event := range events {
+		event = event.Canonical()
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return unique
+}
+
+// emitIdeaEvent sends an idea event to the board's webhooks in the background. extra adds fields
+// to the event's data next to the idea.
+func emitIdeaEvent(ctx context.Context, eventType models.WebhookEventType, idea *models.Idea, extra gin.H) {
+	data := gin.H{"idea": models.NewWebhookIdeaData(idea)}
+	for key, value := range extra {
+		data[key] = value
+	}
+	go utils.EmitWebhookEvent(ctx, idea.BoardID, eventType, data)
+}
+
+// ListWebhookEventTypes handles GET /api/webhooks/events (public endpoint), listing the event
+// types board webhooks can subscribe to
+func ListWebhookEventTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"events":     models.WebhookEventCatalog,
+		"count":      len(models.WebhookEventCatalog),
+		"apiVersion": models.WebhookAPIVersion,
+	})
+}
+
+// CreateBoardWebhook handles POST /api/boards/:id/webhooks
+func CreateBoardWebhook(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req CreateBoardWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	if !validateWebhookURL(c, req.URL) || !validateWebhookEvents(c, req.Events) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.BoardWebhooksCollection)
+
+	existing, err := collection.CountDocuments(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count webhooks",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if existing >= models.MaxWebhooksPerBoard {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_WEBHOOKS",
+				"message": fmt.Sprintf("Boards may have at most %d webhooks", models.MaxWebhooksPerBoard),
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	webhook := models.BoardWebhook{
+		ID:        utils.GenerateFullUUID(),
+		BoardID:   boardID,
+		UserID:    userID,
+		URL:       req.URL,
+		Secret:    utils.GenerateWebhookSecret(),
+		Events:    uniqueEvents(req.Events),
+		Enabled:   req.Enabled == nil || *req.Enabled,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if _, err := collection.InsertOne(ctx, webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create webhook",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateBoardWebhook success - WebhookID: %s, BoardID: %s, UserID: %s, IP: %s",
+		webhook.ID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, BoardWebhookSecretResponse{BoardWebhook: webhook, Secret: webhook.Secret})
+}
+
+// ListBoardWebhooks handles GET /api/boards/:id/webhooks
+func ListBoardWebhooks(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.BoardWebhooksCollection).Find(ctx, bson.M{"board_id": boardID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch webhooks",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []models.BoardWebhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode webhooks",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks": webhooks,
+		"count":    len(webhooks),
+	})
+}
+
+// UpdateBoardWebhook handles PUT /api/boards/:id/webhooks/:webhookId
+func UpdateBoardWebhook(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+	webhookID := c.Param("webhookId")
+
+	// Parse request body
+	var req UpdateBoardWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	updateDoc := bson.M{"updated_at": time.Now().UTC()}
+	if req.URL != nil {
+		*req.URL = strings.TrimSpace(*req.URL)
+		if !validateWebhookURL(c, *req.URL) {
+			return
+		}
+		updateDoc["url"] = *req.URL
+	}
+	if req.Events != nil {
+		if !validateWebhookEvents(c, *req.Events) {
+			return
+		}
+		updateDoc["events"] = uniqueEvents(*req.Events)
+	}
+	if req.Enabled != nil {
+		updateDoc["enabled"] = *req.Enabled
+	}
+	secret := ""
+	if req.RotateSecret {
+		secret = utils.GenerateWebhookSecret()
+		updateDoc["secret"] = secret
+	}
+
+	ctx := c.Request.Context()
+
+	var webhook models.BoardWebhook
+	err := models.GetCollection(ctx, models.BoardWebhooksCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": webhookID, "board_id": boardID},
+		bson.M{"$set": updateDoc},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "WEBHOOK_NOT_FOUND",
+					"message": "Webhook not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update webhook",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateBoardWebhook success - WebhookID: %s, BoardID: %s, SecretRotated: %t, UserID: %s, IP: %s",
+		webhookID, boardID, req.RotateSecret, userID, c.ClientIP())
+
+	if req.RotateSecret {
+		c.JSON(http.StatusOK, BoardWebhookSecretResponse{BoardWebhook: webhook, Secret: secret})
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteBoardWebhook handles DELETE /api/boards/:id/webhooks/:webhookId
+func DeleteBoardWebhook(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+	webhookID := c.Param("webhookId")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.BoardWebhooksCollection).DeleteOne(ctx, bson.M{"_id": webhookID, "board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete webhook",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "WEBHOOK_NOT_FOUND",
+				"message": "Webhook not found",
+			},
+		})
+		return
+	}
+
+	// The delivery log goes with the webhook
+	if _, err := models.GetCollection(ctx, models.WebhookDeliveriesCollection).DeleteMany(ctx, bson.M{"webhook_id": webhookID}); err != nil {
+		log.Printf("[Handler] DeleteBoardWebhook - Deliveries deletion error: %v, WebhookID: %s, BoardID: %s", err, webhookID, boardID)
+	}
+
+	log.Printf("[Handler] DeleteBoardWebhook success - WebhookID: %s, BoardID: %s, UserID: %s, IP: %s",
+		webhookID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// GetWebhookDeliveries handles GET /api/boards/:id/webhooks/:webhookId/deliveries, listing the
+// webhook's delivery attempts newest first
+func GetWebhookDeliveries(c *gin.Context) {
+	boardID := c.Param("id")
+	webhookID := c.Param("webhookId")
+
+	// Parse query parameters
+	var req GetWebhookDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 50
+	}
+
+	ctx := c.Request.Context()
+
+	err := models.GetCollection(ctx, models.BoardWebhooksCollection).FindOne(ctx, bson.M{"_id": webhookID, "board_id": boardID}).Err()
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "WEBHOOK_NOT_FOUND",
+					"message": "Webhook not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch webhook",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	filter := bson.M{"webhook_id": webhookID}
+	if req.Success != nil {
+		filter["success"] = *req.Success
+	}
+
+	// Newest first
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	deliveriesCollection := models.GetCollection(ctx, models.WebhookDeliveriesCollection)
+	cursor, err := deliveriesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch webhook deliveries",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []models.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode webhook deliveries",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := deliveriesCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count webhook deliveries",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// maxCalendarEvents caps how many ideas a roadmap calendar feed contains
+const maxCalendarEvents = 500
+
+// GetPublicRoadmapCalendar handles GET /api/public/:publicLink/roadmap.ics
+func GetPublicRoadmapCalendar(c *gin.Context) {
+	publicLink := c.Param("publicLink")
+
+	ctx := c.Request.Context()
+
+	// Verify board exists by public link and is public
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	// Target dates are only published when the board makes the field visible
+	showTargetDate, showDescription := false, false
+	for _, field := range board.VisibleFields {
+		switch field {
+		case string(models.FieldTargetDate):
+			showTargetDate = true
+		case string(models.FieldDescription):
+			showDescription = true
+		}
+	}
+	if !showTargetDate {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "CALENDAR_NOT_AVAILABLE",
+				"message": "This board does not publish target dates",
+			},
+		})
+		return
+	}
+
+	filter := bson.M{
+		"board_id":    board.ID,
+		"column":      bson.M{"$in": board.VisibleColumns},
+		"target_date": bson.M{"$ne": nil},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "target_date", Value: 1}}).
+		SetLimit(maxCalendarEvents)
+
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var ideas []models.Idea
+	if err := cursor.All(ctx, &ideas); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	publicURL := fmt.Sprintf("%s/public/%s", os.Getenv("APP_URL"), board.PublicLink)
+	events := make([]utils.ICalEvent, 0, len(ideas))
+	for _, idea := range ideas {
+		if idea.TargetDate == nil {
+			continue
+		}
+		event := utils.ICalEvent{
+			UID:          idea.ID + "@disko",
+			Summary:      idea.OneLiner,
+			URL:          publicURL,
+			Date:         *idea.TargetDate,
+			LastModified: idea.UpdatedAt,
+		}
+		if showDescription {
+			event.Description = idea.Description
+		}
+		events = append(events, event)
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	if board.IPRules != nil {
+		c.Header("Cache-Control", "private, max-age=900")
+	} else {
+		c.Header("Cache-Control", "public, max-age=900")
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, board.PublicLink))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(utils.BuildICalendar(board.Name+" roadmap", events)))
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Captcha describes the CAPTCHA widget public visitors must solve on a board
+type Captcha struct {
+	Provider string `json:"provider"`
+	SiteKey  string `json:"siteKey"`
+}
+
+// publicCaptcha returns the board's CAPTCHA widget settings, or nil when CAPTCHA is off or unusable
+func publicCaptcha(board *models.Board) *Captcha {
+	if board.CaptchaProvider == "" {
+		return nil
+	}
+	siteKey := utils.CaptchaSiteKey(board.CaptchaProvider)
+	if siteKey == "" {
+		return nil
+	}
+	return &Captcha{Provider: board.CaptchaProvider, SiteKey: siteKey}
+}
+
+// rejectIfCaptchaFails verifies the X-Captcha-Token header on boards with CAPTCHA enabled,
+// writing CAPTCHA_REQUIRED, CAPTCHA_FAILED or CAPTCHA_UNAVAILABLE and returning true if the request must stop.
+// A provider whose server credentials were removed is skipped so the board stays usable.
+func rejectIfCaptchaFails(ctx context.Context, c *gin.Context, board *models.Board) bool {
+	if publicCaptcha(board) == nil {
+		if board.CaptchaProvider != "" {
+			log.Printf("[Handler] CAPTCHA skipped - Provider %s is not configured, BoardID: %s", board.CaptchaProvider, board.ID)
+		}
+		return false
+	}
+
+	token := c.GetHeader(utils.CaptchaTokenHeader)
+	if token == "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "CAPTCHA_REQUIRED",
+				"message": "Please complete the CAPTCHA challenge",
+			},
+		})
+		return true
+	}
+
+	// Strict privacy boards don't share the visitor's address with the provider
+	remoteIP := ""
+	if !board.StrictPrivacy {
+		remoteIP = c.ClientIP()
+	}
+
+	valid, err := utils.VerifyCaptcha(ctx, board.CaptchaProvider, token, remoteIP)
+	if err != nil {
+		log.Printf("[Handler] CAPTCHA verification error: %v, Provider: %s, BoardID: %s", err, board.CaptchaProvider, board.ID)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "CAPTCHA_UNAVAILABLE",
+				"message": "CAPTCHA verification is temporarily unavailable. Please try again.",
+			},
+		})
+		return true
+	}
+	if !valid {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "CAPTCHA_FAILED",
+				"message": "CAPTCHA verification failed. Please try again.",
+			},
+		})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ChangelogIdea is a released idea as shown on the public changelog
+type ChangelogIdea struct {
+	ID              string    `json:"id"`
+	OneLiner        string    `json:"oneLiner"`
+	DescriptionHTML string    `json:"descriptionHtml,omitempty"` // Only when the description field is public
+	ValueStatement  string    `json:"valueStatement,omitempty"`  // Only when the value statement field is public
+	ReleasedAt      time.Time `json:"releasedAt"`
+}
+
+// ChangelogRelease is a release and its ideas on the public changelog
+type ChangelogRelease struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Date      time.Time       `json:"date"`
+	NotesHTML string          `json:"notesHtml,omitempty"`
+	Ideas     []ChangelogIdea `json:"ideas"`
+}
+
+// ChangelogMonth groups the releases and loose released ideas of one calendar month
+type ChangelogMonth struct {
+	Period   string             `json:"period"` // YYYY-MM
+	Title    string             `json:"title"`  // e.g. "October 2026"
+	Releases []ChangelogRelease `json:"releases"`
+	Ideas    []ChangelogIdea    `json:"ideas"` // Released ideas not attached to a release
+	Count    int                `json:"count"`
+}
+
+// GetPublicChangelog handles GET /api/public/:publicLink/changelog
+func GetPublicChangelog(c *gin.Context) {
+	publicLink := c.Param("publicLink")
+
+	ctx := c.Request.Context()
+
+	// Verify board exists by public link and is public
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+
+	// The changelog only exposes what the public board already shows
+	showReleases, showDescription, showValueStatement := false, false, false
+	for _, column := range board.VisibleColumns {
+		if column == string(models.ColumnRelease) {
+			showReleases = true
+		}
+	}
+	for _, field := range board.VisibleFields {
+		switch field {
+		case string(models.FieldDescription):
+			showDescription = true
+		case string(models.FieldValueStatement):
+			showValueStatement = true
+		}
+	}
+	if !showReleases {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "CHANGELOG_NOT_AVAILABLE",
+				"message": "This board does not publish its releases",
+			},
+		})
+		return
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(maxGroupedReleasedIdeas)
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, bson.M{
+		"board_id": board.ID,
+		"column":   string(models.ColumnRelease),
+	}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch released ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var ideas []models.Idea
+	if err := cursor.All(ctx, &ideas); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode released ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	releases, err := loadBoardReleases(ctx, board.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch releases",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	releasesByID := make(map[string]*models.Release, len(releases))
+	for i := range releases {
+		releasesByID[releases[i].ID] = &releases[i]
+	}
+
+	months := map[string]*ChangelogMonth{}
+	monthFor := func(date time.Time) *ChangelogMonth {
+		period := date.UTC().Format("2006-01")
+		month, ok := months[period]
+		if !ok {
+			month = &ChangelogMonth{
+				Period:   period,
+				Title:    date.UTC().Format("January 2006"),
+				Releases: []ChangelogRelease{},
+				Ideas:    []ChangelogIdea{},
+			}
+			months[period] = month
+		}
+		return month
+	}
+
+	// Ideas attached to a release take the release's date; others the time they were last moved
+	releaseIdeas := map[string][]ChangelogIdea{}
+	for _, idea := range ideas {
+		entry := ChangelogIdea{
+			ID:         idea.ID,
+			OneLiner:   idea.OneLiner,
+			ReleasedAt: idea.UpdatedAt,
+		}
+		if showDescription && idea.Description != "" {
+			entry.DescriptionHTML = utils.RenderMarkdown(idea.Description)
+		}
+		if showValueStatement {
+			entry.ValueStatement = idea.ValueStatement
+		}
+
+		if release, ok := releasesByID[idea.ReleaseID]; ok {
+			entry.ReleasedAt = release.Date
+			releaseIdeas[release.ID] = append(releaseIdeas[release.ID], entry)
+			continue
+		}
+		month := monthFor(entry.ReleasedAt)
+		month.Ideas = append(month.Ideas, entry)
+		month.Count++
+	}
+
+	// Releases are already newest first; those without released ideas are left out
+	for _, release := range releases {
+		entries := releaseIdeas[release.ID]
+		if len(entries) == 0 {
+			continue
+		}
+		month := monthFor(release.Date)
+		month.Releases = append(month.Releases, ChangelogRelease{
+			ID:        release.ID,
+			Name:      release.Name,
+			Date:      release.Date,
+			NotesHTML: utils.RenderMarkdown(release.Notes),
+			Ideas:     entries,
+		})
+		month.Count += len(entries)
+	}
+
+	changelog := make([]ChangelogMonth, 0, len(months))
+	for _, month := range months {
+		changelog = append(changelog, *month)
+	}
+	sort.Slice(changelog, func(i, j int) bool {
+		return changelog[i].Period > changelog[j].Period
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"board": gin.H{
+			"name":        board.Name,
+			"description": board.Description,
+		},
+		"months": changelog,
+		"count":  len(ideas),
+	})
+}
@@ -0,0 +1,484 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// maxPublicComments caps how many approved comments are returned for one idea
+const maxPublicComments = 200
+
+// AddCommentRequest represents the public payload for commenting on an idea
+type AddCommentRequest struct {
+	AuthorName string `json:"authorName,omitempty" binding:"max=50"`
+	Body       string `json:"body" binding:"required,min=1,max=1000"`
+}
+
+// GetCommentsRequest represents query parameters for the comment moderation queue
+type GetCommentsRequest struct {
+	Status   string `form:"status"` // pending (default), approved
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// PublicCommentResponse represents a comment as shown to public visitors
+type PublicCommentResponse struct {
+	ID         string    `json:"id"`
+	IdeaID     string    `json:"ideaId"`
+	AuthorName string    `json:"authorName,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// findPublicIdeaBoard loads an idea and its board, requiring the board to be public, writing an error response if not
+func findPublicIdeaBoard(ctx context.Context, c *gin.Context, ideaID string) (*models.Idea, *models.Board, bool) {
+	var idea models.Idea
+	err := models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	var board models.Board
+	if err == nil {
+		err = models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": idea.BoardID, "is_public": true}).Decode(&board)
+	}
+
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "IDEA_NOT_FOUND",
+				"message": "Idea not found",
+			},
+		})
+		return nil, nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch idea",
+				"details": err.Error(),
+			},
+		})
+		return nil, nil, false
+	}
+	if rejectBlockedVisitor(c, &board) {
+		return nil, nil, false
+	}
+	return &idea, &board, true
+}
+
+// AddComment handles POST /api/ideas/:id/comments
+func AddComment(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	// Parse request body
+	var req AddCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findPublicIdeaBoard(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	visitorKey := utils.VisitorKey(c.ClientIP(), board.StrictPrivacy)
+
+	// Rate limiting per visitor and idea
+	rateLimitKey := "comment_" + ideaID + "_" + visitorKey
+	rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_COMMENT_SECONDS", 30)
+	if isRateLimited(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "RATE_LIMITED",
+				"message": fmt.Sprintf("Please wait %d seconds before commenting again", rateLimitSeconds),
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	if rejectIfCaptchaFails(ctx, c, board) {
+		return
+	}
+
+	status := models.CommentApproved
+	if board.ModerateComments {
+		status = models.CommentPending
+	}
+
+	comment := models.Comment{
+		ID:         utils.GenerateCommentID(),
+		BoardID:    board.ID,
+		IdeaID:     idea.ID,
+		AuthorName: strings.TrimSpace(req.AuthorName),
+		Body:       strings.TrimSpace(req.Body),
+		VisitorKey: visitorKey,
+		Status:     string(status),
+	}
+
+	// Validate comment
+	if validationErrors := models.ValidateComment(&comment); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Comment validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if models.LooksLikeSpam(comment.Body) {
+		log.Printf("[Handler] AddComment rejected - Spam heuristics, IdeaID: %s, Visitor: %s", ideaID, visitorKey)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "SPAM_DETECTED",
+				"message": "Your comment looks like spam. Please remove links and try again.",
+			},
+		})
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.CommentsCollection).InsertOne(ctx, comment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save comment",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+
+	// Let the owner's open board know a comment arrived
+	utils.BroadcastCommentEvent(board.ID, idea.ID, comment.ID, comment.Status)
+
+	summary := fmt.Sprintf("New comment on \"%s\"", idea.OneLiner)
+	if comment.Status == string(models.CommentPending) {
+		summary = fmt.Sprintf("New comment awaiting approval on \"%s\"", idea.OneLiner)
+	}
+	go utils.SendBoardNotification(ctx, models.NotifyComment, board.ID, idea.ID, summary, "")
+	emitIdeaEvent(ctx, models.WebhookCommentCreated, idea, gin.H{"comment": comment})
+
+	log.Printf("[Handler] AddComment success - CommentID: %s, IdeaID: %s, BoardID: %s, Status: %s, Visitor: %s",
+		comment.ID, idea.ID, board.ID, comment.Status, visitorKey)
+
+	if status == models.CommentPending {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Thanks! Your comment will appear once the board owner approves it.",
+			"status":  comment.Status,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, PublicCommentResponse{
+		ID:         comment.ID,
+		IdeaID:     comment.IdeaID,
+		AuthorName: comment.AuthorName,
+		Body:       comment.Body,
+		CreatedAt:  comment.CreatedAt,
+	})
+}
+
+// GetPublicComments handles GET /api/ideas/:id/comments
+func GetPublicComments(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	_, board, ok := findPublicIdeaBoard(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(maxPublicComments)
+	filter := bson.M{"idea_id": ideaID, "status": string(models.CommentApproved)}
+	cursor, err := models.GetCollection(ctx, models.CommentsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch comments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var comments []models.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode comments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	responses := []PublicCommentResponse{}
+	for _, comment := range comments {
+		responses = append(responses, PublicCommentResponse{
+			ID:         comment.ID,
+			IdeaID:     comment.IdeaID,
+			AuthorName: comment.AuthorName,
+			Body:       comment.Body,
+			CreatedAt:  comment.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments": responses,
+		"count":    len(responses),
+	})
+}
+
+// GetBoardComments handles GET /api/boards/:id/comments
+func GetBoardComments(c *gin.Context) {
+	boardID := c.Param("id")
+
+	// Parse query parameters
+	var req GetCommentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Status == "" {
+		req.Status = string(models.CommentPending)
+	}
+	if !models.IsValidCommentStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_STATUS",
+				"message": "status must be pending or approved",
+			},
+		})
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 50
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"board_id": boardID, "status": req.Status}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	commentsCollection := models.GetCollection(ctx, models.CommentsCollection)
+	cursor, err := commentsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch comments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	comments := []models.Comment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode comments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := commentsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count comments",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments":   comments,
+		"count":      len(comments),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// ApproveComment handles POST /api/comments/:id/approve
+func ApproveComment(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	commentID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	comment, ok := findOwnedComment(ctx, c, commentID, userID)
+	if !ok {
+		return
+	}
+
+	if comment.Status != string(models.CommentApproved) {
+		now := time.Now().UTC()
+		_, err = models.GetCollection(ctx, models.CommentsCollection).UpdateOne(ctx, bson.M{"_id": commentID},
+			bson.M{"$set": bson.M{"status": string(models.CommentApproved), "updated_at": now}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to approve comment",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		comment.Status = string(models.CommentApproved)
+		comment.UpdatedAt = now
+
+		utils.BroadcastCommentEvent(comment.BoardID, comment.IdeaID, comment.ID, comment.Status)
+	}
+
+	log.Printf("[Handler] ApproveComment success - CommentID: %s, IdeaID: %s, BoardID: %s, UserID: %s, IP: %s",
+		commentID, comment.IdeaID, comment.BoardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment handles DELETE /api/comments/:id
+func DeleteComment(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	commentID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	comment, ok := findOwnedComment(ctx, c, commentID, userID)
+	if !ok {
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.CommentsCollection).DeleteOne(ctx, bson.M{"_id": commentID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete comment",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteComment success - CommentID: %s, IdeaID: %s, BoardID: %s, UserID: %s, IP: %s",
+		commentID, comment.IdeaID, comment.BoardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted successfully",
+	})
+}
+
+// findOwnedComment loads a comment and verifies the user owns its board, writing an error response if not
+func findOwnedComment(ctx context.Context, c *gin.Context, commentID, userID string) (*models.Comment, bool) {
+	var comment models.Comment
+	err := models.GetCollection(ctx, models.CommentsCollection).FindOne(ctx, bson.M{"_id": commentID}).Decode(&comment)
+	if err == nil {
+		var count int64
+		count, err = models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, boardAccessFilter(c, comment.BoardID, userID, models.RoleEditor))
+		if err == nil && count == 0 {
+			err = mongo.ErrNoDocuments
+		}
+	}
+
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "COMMENT_NOT_FOUND",
+				"message": "Comment not found or you don't have permission to moderate it",
+			},
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch comment",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return &comment, true
+}
@@ -51,37 +51,15 @@ func HandleContactPage(c *gin.Context) {
 	})
 }
 
-// Simple in-memory rate limiting for contact form
-var contactRateLimitStore = make(map[string]time.Time)
-
-// isContactRateLimited checks if an IP is rate limited for contact form
-func isContactRateLimited(ip string) bool {
-	if lastRequest, exists := contactRateLimitStore[ip]; exists {
-		// Rate limit: 1 contact form submission per hour per IP
-		if time.Since(lastRequest) < time.Hour {
-			return true
-		}
-	}
-	return false
-}
-
-// setContactRateLimit sets the rate limit for an IP
-func setContactRateLimit(ip string) {
-	contactRateLimitStore[ip] = time.Now()
-
-	// Clean up old entries after 2 hours
-	go func() {
-		time.Sleep(2 * time.Hour)
-		delete(contactRateLimitStore, ip)
-	}()
-}
+// contactRateLimitWindow allows one contact form submission per IP in the window
+const contactRateLimitWindow = time.Hour
 
 // HandleContactSubmit handles contact form submissions
 func HandleContactSubmit(c *gin.Context) {
 	clientIP := c.ClientIP()
 
 	// Check rate limiting
-	if isContactRateLimited(clientIP) {
+	if isRateLimited("contact_"+clientIP, contactRateLimitWindow) {
 		log.Printf("[Contact] Rate limited contact form submission from IP: %s", clientIP)
 		c.JSON(http.StatusTooManyRequests, ContactResponse{
 			Success: false,
@@ -110,7 +88,7 @@ func HandleContactSubmit(c *gin.Context) {
 	}
 
 	// Set rate limit before processing
-	setContactRateLimit(clientIP)
+	setRateLimit("contact_"+clientIP, contactRateLimitWindow)
 
 	// Send email notification
 	if err := sendContactEmail(req); err != nil {
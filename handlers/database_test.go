@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	testDatabaseOnce sync.Once
+	testDatabaseErr  error
+)
+
+// connectTestDatabase connects to the MongoDB server in MONGODB_TEST_URI, in a database of its own
+// that is dropped when the package's tests finish. Tests that need a database skip without it.
+func connectTestDatabase(t *testing.T) {
+	t.Helper()
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI is not set")
+	}
+
+	testDatabaseOnce.Do(func() {
+		os.Setenv("MONGODB_URI", uri)
+		os.Setenv("MONGODB_DATABASE", fmt.Sprintf("disko_test_%d", time.Now().UnixNano()))
+		testDatabaseErr = models.ConnectDatabase()
+	})
+	if testDatabaseErr != nil {
+		t.Fatalf("failed to connect to the test database: %v", testDatabaseErr)
+	}
+}
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	code := m.Run()
+	if models.DB != nil {
+		models.DB.DB.Drop(context.Background())
+		models.DisconnectDatabase()
+	}
+	os.Exit(code)
+}
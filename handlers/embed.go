@@ -0,0 +1,582 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateEmbedTokenRequest represents the request payload for creating an embed token
+type CreateEmbedTokenRequest struct {
+	Label          string   `json:"label,omitempty" binding:"max=100"`
+	AllowedOrigins []string `json:"allowedOrigins,omitempty"`
+}
+
+// GetEmbedFeedRequest represents query parameters for the embed feed
+type GetEmbedFeedRequest struct {
+	Limit int `form:"limit"`
+}
+
+// EmbedIdeaResponse represents a released idea in the embeddable widget feed
+type EmbedIdeaResponse struct {
+	ID             string                 `json:"id"`
+	OneLiner       string                 `json:"oneLiner"`
+	Description    string                 `json:"description,omitempty"`
+	ThumbsUp       int                    `json:"thumbsUp"`
+	EmojiReactions []models.EmojiReaction `json:"emojiReactions"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
+}
+
+// embedFeedCacheEntry holds a rendered feed along with the origins and client addresses allowed to read it
+type embedFeedCacheEntry struct {
+	payload        gin.H
+	allowedOrigins []string
+	ipRules        *models.BoardIPRules
+	expiresAt      time.Time
+}
+
+// embedFeedError describes why a feed could not be built
+type embedFeedError struct {
+	status  int
+	code    string
+	message string
+}
+
+var (
+	embedFeedCache   = make(map[string]embedFeedCacheEntry)
+	embedFeedCacheMu sync.RWMutex
+)
+
+// embedFeedCacheTTL returns how long rendered feeds are cached, in memory and by browsers/CDNs
+func embedFeedCacheTTL() time.Duration {
+	return time.Duration(getRateLimitSeconds("EMBED_CACHE_SECONDS", 60)) * time.Second
+}
+
+// invalidateEmbedFeedCache drops every cached feed rendered for a token
+func invalidateEmbedFeedCache(token string) {
+	embedFeedCacheMu.Lock()
+	defer embedFeedCacheMu.Unlock()
+	for key := range embedFeedCache {
+		if strings.HasPrefix(key, token+":") {
+			delete(embedFeedCache, key)
+		}
+	}
+}
+
+// normalizeOrigin reduces a URL to its scheme://host[:port] origin
+func normalizeOrigin(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid origin: %s", raw)
+	}
+	return parsed.Scheme + "://" + strings.ToLower(parsed.Host), nil
+}
+
+// setEmbedCORSHeaders allows the requesting origin to read the feed
+func setEmbedCORSHeaders(c *gin.Context, allowedOrigins []string) {
+	if len(allowedOrigins) == 0 {
+		c.Header("Access-Control-Allow-Origin", "*")
+	} else if origin := c.GetHeader("Origin"); origin != "" {
+		c.Header("Access-Control-Allow-Origin", origin)
+	}
+	c.Header("Access-Control-Allow-Methods", "GET, OPTIONS")
+	c.Header("Access-Control-Allow-Headers", "Content-Type")
+	c.Header("Vary", "Origin")
+}
+
+// CreateEmbedToken handles POST /api/boards/:id/embed-tokens
+func CreateEmbedToken(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req CreateEmbedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	allowedOrigins := []string{}
+	for _, raw := range req.AllowedOrigins {
+		origin, err := normalizeOrigin(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_ORIGIN",
+					"message": "Allowed origins must be absolute http(s) URLs",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		allowedOrigins = append(allowedOrigins, origin)
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.EmbedTokensCollection)
+
+	existing, err := collection.CountDocuments(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count embed tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if existing >= models.MaxEmbedTokensPerBoard {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_EMBED_TOKENS",
+				"message": fmt.Sprintf("Boards may have at most %d embed tokens", models.MaxEmbedTokensPerBoard),
+			},
+		})
+		return
+	}
+
+	token := models.EmbedToken{
+		ID:             utils.GenerateEmbedToken(),
+		BoardID:        boardID,
+		UserID:         userID,
+		Label:          req.Label,
+		AllowedOrigins: allowedOrigins,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if _, err := collection.InsertOne(ctx, token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create embed token",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateEmbedToken success - BoardID: %s, UserID: %s, Origins: %d, IP: %s",
+		boardID, userID, len(allowedOrigins), c.ClientIP())
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// ListEmbedTokens handles GET /api/boards/:id/embed-tokens
+func ListEmbedTokens(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	// Tokens carry the owner's user ID, so this also scopes the list to boards they own
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.EmbedTokensCollection).Find(ctx, bson.M{"board_id": boardID, "user_id": userID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch embed tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []models.EmbedToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode embed tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+		"count":  len(tokens),
+	})
+}
+
+// DeleteEmbedToken handles DELETE /api/boards/:id/embed-tokens/:tokenId
+func DeleteEmbedToken(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+	tokenID := c.Param("tokenId")
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"_id": tokenID, "board_id": boardID, "user_id": userID}
+	result, err := models.GetCollection(ctx, models.EmbedTokensCollection).DeleteOne(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete embed token",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "EMBED_TOKEN_NOT_FOUND",
+				"message": "Embed token not found",
+			},
+		})
+		return
+	}
+
+	// Revoked tokens must stop serving immediately
+	invalidateEmbedFeedCache(tokenID)
+
+	log.Printf("[Handler] DeleteEmbedToken success - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Embed token deleted successfully",
+	})
+}
+
+// EmbedFeedPreflight handles OPTIONS /embed/v1/:token/released and /embed/v1/:token/roadmap
+func EmbedFeedPreflight(c *gin.Context) {
+	setEmbedCORSHeaders(c, nil)
+	c.Header("Access-Control-Max-Age", "86400")
+	c.Status(http.StatusNoContent)
+}
+
+// GetEmbedFeed handles GET /embed/v1/:token/released (public, CORS-enabled)
+func GetEmbedFeed(c *gin.Context) {
+	serveEmbedPayload(c, "released", buildEmbedFeed)
+}
+
+// GetEmbedRoadmap handles GET /embed/v1/:token/roadmap (public, CORS-enabled)
+func GetEmbedRoadmap(c *gin.Context) {
+	serveEmbedPayload(c, "roadmap", buildEmbedRoadmap)
+}
+
+// serveEmbedPayload answers an embed request from the cache, building the payload on a miss,
+// and enforces the token's allowed origins
+func serveEmbedPayload(c *gin.Context, kind string, build func(ctx context.Context, tokenID string, limit int) (embedFeedCacheEntry, *embedFeedError)) {
+	token := c.Param("token")
+
+	var req GetEmbedFeedRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+
+	// Tokens of one tenant are never served on another tenant's host
+	ctx := c.Request.Context()
+	cacheKey := fmt.Sprintf("%s:%s:%d:%s", token, kind, req.Limit, models.TenantFromContext(ctx))
+	ttl := embedFeedCacheTTL()
+	origin := c.GetHeader("Origin")
+
+	embedFeedCacheMu.RLock()
+	entry, cached := embedFeedCache[cacheKey]
+	embedFeedCacheMu.RUnlock()
+
+	if !cached || time.Now().After(entry.expiresAt) {
+		var feedErr *embedFeedError
+		entry, feedErr = build(ctx, token, req.Limit)
+		if feedErr != nil {
+			setEmbedCORSHeaders(c, nil)
+			c.JSON(feedErr.status, gin.H{
+				"error": gin.H{
+					"code":    feedErr.code,
+					"message": feedErr.message,
+				},
+			})
+			return
+		}
+		entry.expiresAt = time.Now().Add(ttl)
+
+		embedFeedCacheMu.Lock()
+		embedFeedCache[cacheKey] = entry
+		embedFeedCacheMu.Unlock()
+	}
+
+	// Browsers enforce CORS, but restricted tokens also refuse other origins outright
+	if origin != "" && len(entry.allowedOrigins) > 0 {
+		token := models.EmbedToken{AllowedOrigins: entry.allowedOrigins}
+		if !token.AllowsOrigin(origin) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "ORIGIN_NOT_ALLOWED",
+					"message": "This embed token is not enabled for this site",
+				},
+			})
+			return
+		}
+	}
+
+	if !entry.ipRules.Permits(c.ClientIP()) {
+		setEmbedCORSHeaders(c, entry.allowedOrigins)
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "IP_BLOCKED",
+				"message": "This board is not available from your network",
+			},
+		})
+		return
+	}
+
+	setEmbedCORSHeaders(c, entry.allowedOrigins)
+	// Shared caches must not hand a restricted board's feed to other networks
+	visibility := "public"
+	if entry.ipRules != nil {
+		visibility = "private"
+	}
+	c.Header("Cache-Control", fmt.Sprintf("%s, max-age=%d", visibility, int(ttl.Seconds())))
+	c.JSON(http.StatusOK, entry.payload)
+}
+
+// buildEmbedFeed loads the token, its public board and the most recently shipped ideas
+func buildEmbedFeed(ctx context.Context, tokenID string, limit int) (embedFeedCacheEntry, *embedFeedError) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	token, board, feedErr := loadEmbedBoard(ctx, tokenID)
+	if feedErr != nil {
+		return embedFeedCacheEntry{}, feedErr
+	}
+
+	filter := bson.M{"board_id": board.ID, "column": string(models.ColumnRelease)}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("[Handler] GetEmbedFeed - Failed to fetch ideas: %v", err)
+		return embedFeedCacheEntry{}, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed feed"}
+	}
+	defer cursor.Close(ctx)
+
+	var ideas []models.Idea
+	if err := cursor.All(ctx, &ideas); err != nil {
+		log.Printf("[Handler] GetEmbedFeed - Failed to decode ideas: %v", err)
+		return embedFeedCacheEntry{}, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed feed"}
+	}
+
+	showDescription := embedShowsDescription(&board)
+	responses := []EmbedIdeaResponse{}
+	for _, idea := range ideas {
+		responses = append(responses, newEmbedIdeaResponse(idea, showDescription))
+	}
+
+	touchEmbedToken(ctx, token.ID)
+
+	return embedFeedCacheEntry{
+		payload: gin.H{
+			"board": gin.H{
+				"name":       board.Name,
+				"publicLink": board.PublicLink,
+			},
+			"ideas": responses,
+			"count": len(responses),
+		},
+		allowedOrigins: token.AllowedOrigins,
+		ipRules:        board.IPRules,
+	}, nil
+}
+
+// loadEmbedBoard loads an embed token and its board, which must still be public
+func loadEmbedBoard(ctx context.Context, tokenID string) (models.EmbedToken, models.Board, *embedFeedError) {
+	var token models.EmbedToken
+	err := models.GetCollection(ctx, models.EmbedTokensCollection).FindOne(ctx, bson.M{"_id": tokenID}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return token, models.Board{}, &embedFeedError{http.StatusNotFound, "EMBED_TOKEN_NOT_FOUND", "Embed token not found"}
+		}
+		log.Printf("[Handler] Embed - Failed to fetch token: %v", err)
+		return token, models.Board{}, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed feed"}
+	}
+
+	// The widget only works while the board itself is public
+	var board models.Board
+	err = models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": token.BoardID, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return token, board, &embedFeedError{http.StatusNotFound, "BOARD_NOT_FOUND", "Board is not publicly accessible"}
+		}
+		log.Printf("[Handler] Embed - Failed to fetch board: %v", err)
+		return token, board, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed feed"}
+	}
+	return token, board, nil
+}
+
+// touchEmbedToken records when a token last rendered a payload
+func touchEmbedToken(ctx context.Context, tokenID string) {
+	now := time.Now().UTC()
+	if _, err := models.GetCollection(ctx, models.EmbedTokensCollection).UpdateOne(ctx, bson.M{"_id": tokenID}, bson.M{"$set": bson.M{"last_used_at": now}}); err != nil {
+		log.Printf("[Handler] Embed - Failed to update token usage: %v", err)
+	}
+}
+
+// embedShowsDescription reports whether the board makes idea descriptions public
+func embedShowsDescription(board *models.Board) bool {
+	for _, field := range board.VisibleFields {
+		if field == string(models.FieldDescription) {
+			return true
+		}
+	}
+	return false
+}
+
+// newEmbedIdeaResponse builds the compact widget representation of an idea
+func newEmbedIdeaResponse(idea models.Idea, showDescription bool) EmbedIdeaResponse {
+	response := EmbedIdeaResponse{
+		ID:             idea.ID,
+		OneLiner:       idea.OneLiner,
+		ThumbsUp:       idea.ThumbsUp,
+		EmojiReactions: idea.EmojiReactions,
+		UpdatedAt:      idea.UpdatedAt,
+	}
+	if showDescription {
+		response.Description = idea.Description
+	}
+	return response
+}
+
+// EmbedRoadmapColumn is one visible board column in the roadmap widget
+type EmbedRoadmapColumn struct {
+	Column string              `json:"column"`
+	Ideas  []EmbedIdeaResponse `json:"ideas"`
+	Count  int                 `json:"count"` // Ideas in the column, which may exceed the returned ideas
+}
+
+// buildEmbedRoadmap loads the token, its public board and the top ideas of each visible column
+func buildEmbedRoadmap(ctx context.Context, tokenID string, limit int) (embedFeedCacheEntry, *embedFeedError) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	token, board, feedErr := loadEmbedBoard(ctx, tokenID)
+	if feedErr != nil {
+		return embedFeedCacheEntry{}, feedErr
+	}
+
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	showDescription := embedShowsDescription(&board)
+	columns := []EmbedRoadmapColumn{}
+	for _, column := range board.VisibleColumns {
+		filter := bson.M{"board_id": board.ID, "column": column}
+		opts := options.Find().
+			SetSort(bson.D{{Key: "position", Value: 1}}).
+			SetLimit(int64(limit))
+
+		cursor, err := ideasCollection.Find(ctx, filter, opts)
+		if err != nil {
+			log.Printf("[Handler] GetEmbedRoadmap - Failed to fetch ideas: %v", err)
+			return embedFeedCacheEntry{}, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed roadmap"}
+		}
+		var ideas []models.Idea
+		err = cursor.All(ctx, &ideas)
+		cursor.Close(ctx)
+		if err != nil {
+			log.Printf("[Handler] GetEmbedRoadmap - Failed to decode ideas: %v", err)
+			return embedFeedCacheEntry{}, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed roadmap"}
+		}
+
+		count, err := ideasCollection.CountDocuments(ctx, filter)
+		if err != nil {
+			log.Printf("[Handler] GetEmbedRoadmap - Failed to count ideas: %v", err)
+			return embedFeedCacheEntry{}, &embedFeedError{http.StatusInternalServerError, "DATABASE_ERROR", "Failed to load embed roadmap"}
+		}
+
+		entry := EmbedRoadmapColumn{Column: column, Ideas: []EmbedIdeaResponse{}, Count: int(count)}
+		for _, idea := range ideas {
+			entry.Ideas = append(entry.Ideas, newEmbedIdeaResponse(idea, showDescription))
+		}
+		columns = append(columns, entry)
+	}
+
+	touchEmbedToken(ctx, token.ID)
+
+	return embedFeedCacheEntry{
+		payload: gin.H{
+			"board": gin.H{
+				"name":       board.Name,
+				"publicLink": board.PublicLink,
+			},
+			"columns": columns,
+		},
+		allowedOrigins: token.AllowedOrigins,
+		ipRules:        board.IPRules,
+	}, nil
+}
+
+// ServeEmbedWidget handles GET /embed/widget.js
+func ServeEmbedWidget(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.File("./static/js/embed-widget.js")
+}
@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// defaultExportIntervalHours is used when a config does not specify a schedule
+const defaultExportIntervalHours = 24
+
+// ExportConfigRequest represents the request payload for configuring a board export.
+// Credentials may be omitted on update to keep the stored ones.
+type ExportConfigRequest struct {
+	Provider        string `json:"provider" binding:"required"`
+	Bucket          string `json:"bucket,omitempty"` // Required except for the storage provider
+	Prefix          string `json:"prefix,omitempty"`
+	Region          string `json:"region,omitempty"`
+	Endpoint        string `json:"endpoint,omitempty"`
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	Format          string `json:"format,omitempty"`
+	IntervalHours   int    `json:"intervalHours,omitempty"`
+	Enabled         *bool  `json:"enabled,omitempty"`
+}
+
+// GetExportConfig handles GET /api/boards/:id/export-config
+func GetExportConfig(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	var config models.ExportConfig
+	err := models.GetCollection(ctx, models.ExportConfigsCollection).FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "EXPORT_CONFIG_NOT_FOUND",
+					"message": "No export is configured for this board",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch export config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// UpsertExportConfig handles PUT /api/boards/:id/export-config
+func UpsertExportConfig(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req ExportConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Bucket credentials are stored encrypted
+	if req.Provider != string(models.ProviderStorage) && rejectWithoutEncryption(c) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Custom endpoints must be https URLs on the public internet, checked again on each upload
+	if req.Endpoint != "" {
+		if err := utils.ValidateOutboundURL(ctx, req.Endpoint); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_ENDPOINT",
+					"message": "Endpoint must be an absolute https URL on the public internet",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	collection := models.GetCollection(ctx, models.ExportConfigsCollection)
+
+	var config models.ExportConfig
+	err = collection.FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	isNew := err == mongo.ErrNoDocuments
+	if err != nil && !isNew {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch export config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	previousInterval := config.IntervalHours
+
+	if isNew {
+		config = models.ExportConfig{
+			ID:        utils.GenerateFullUUID(),
+			BoardID:   boardID,
+			UserID:    userID,
+			Enabled:   true,
+			NextRunAt: now, // First export runs on the next scheduler tick
+		}
+	}
+
+	config.Provider = req.Provider
+	config.Bucket = strings.TrimSpace(req.Bucket)
+	config.Prefix = strings.Trim(req.Prefix, "/")
+	config.Region = req.Region
+	config.Endpoint = req.Endpoint
+	if req.AccessKeyID != "" {
+		config.AccessKeyID = req.AccessKeyID
+	}
+	if req.SecretAccessKey != "" {
+		config.SecretAccessKey = req.SecretAccessKey
+	}
+	if config.Provider == string(models.ProviderStorage) {
+		config.AccessKeyID, config.SecretAccessKey = "", "" // The server's storage needs no credentials
+	}
+	config.Format = strings.ToLower(strings.TrimSpace(req.Format))
+	if config.Format == "" {
+		config.Format = string(models.FormatCSV)
+	}
+	config.IntervalHours = req.IntervalHours
+	if config.IntervalHours == 0 {
+		config.IntervalHours = defaultExportIntervalHours
+	}
+	if req.Enabled != nil {
+		config.Enabled = *req.Enabled
+	}
+
+	// Reschedule from the last run when the interval changes
+	if !isNew && previousInterval != config.IntervalHours {
+		base := now
+		if config.LastRunAt != nil {
+			base = *config.LastRunAt
+		}
+		config.NextRunAt = base.Add(time.Duration(config.IntervalHours) * time.Hour)
+	}
+
+	// Validate export config
+	if validationErrors := models.ValidateExportConfig(&config); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Export config validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	// Encrypt new credentials and any saved in plaintext before they were encrypted
+	for _, credential := range []*string{&config.AccessKeyID, &config.SecretAccessKey} {
+		if *credential == "" || utils.IsEncryptedSecret(*credential) {
+			continue
+		}
+		if *credential, err = utils.EncryptSecret(*credential); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "ENCRYPTION_FAILED",
+					"message": "Failed to encrypt bucket credentials",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	if isNew {
+		_, err = collection.InsertOne(ctx, config)
+	} else {
+		_, err = collection.ReplaceOne(ctx, bson.M{"_id": config.ID}, config)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save export config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpsertExportConfig success - BoardID: %s, UserID: %s, Provider: %s, Bucket: %s, Enabled: %t, IP: %s",
+		boardID, userID, config.Provider, config.Bucket, config.Enabled, c.ClientIP())
+
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, config)
+}
+
+// DeleteExportConfig handles DELETE /api/boards/:id/export-config
+func DeleteExportConfig(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.ExportConfigsCollection).DeleteOne(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete export config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "EXPORT_CONFIG_NOT_FOUND",
+				"message": "No export is configured for this board",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteExportConfig success - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Export config deleted successfully",
+	})
+}
+
+// RunExportNow handles POST /api/boards/:id/export-config/run
+func RunExportNow(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Uploads can take longer than a regular request
+	ctx := c.Request.Context()
+
+	var config models.ExportConfig
+	err = models.GetCollection(ctx, models.ExportConfigsCollection).FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "EXPORT_CONFIG_NOT_FOUND",
+					"message": "No export is configured for this board",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch export config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	keys, runErr := utils.RunBoardExport(ctx, config)
+	utils.RecordExportResult(ctx, config, keys, runErr)
+
+	if runErr != nil {
+		log.Printf("[Handler] RunExportNow failed - BoardID: %s, UserID: %s, Error: %v, IP: %s", boardID, userID, runErr, c.ClientIP())
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "EXPORT_FAILED",
+				"message": "Failed to upload export to cloud storage",
+				"details": runErr.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] RunExportNow success - BoardID: %s, UserID: %s, Files: %d, IP: %s", boardID, userID, len(keys), c.ClientIP())
+
+	response := gin.H{
+		"message": "Export completed successfully",
+		"files":   keys,
+	}
+	if config.Provider == string(models.ProviderStorage) {
+		response["downloads"] = exportDownloads(keys)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ExportDownload is a file of an export run kept in the server's storage
+type ExportDownload struct {
+	Key string `json:"key"`
+	*DownloadLink
+}
+
+// exportDownloads signs download links for export files
+func exportDownloads(keys []string) []ExportDownload {
+	downloads := make([]ExportDownload, 0, len(keys))
+	for _, key := range keys {
+		downloads = append(downloads, ExportDownload{Key: key, DownloadLink: signedDownload(key, path.Base(key))})
+	}
+	return downloads
+}
+
+// GetExportDownloads handles GET /api/boards/:id/export-config/downloads, returning download links
+// for the files of the last successful run of an export kept in the server's storage
+func GetExportDownloads(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	var config models.ExportConfig
+	err := models.GetCollection(ctx, models.ExportConfigsCollection).FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "EXPORT_CONFIG_NOT_FOUND",
+					"message": "No export is configured for this board",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch export config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if config.Provider != string(models.ProviderStorage) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "EXPORT_NOT_STORED",
+				"message": "This export uploads to your own bucket; download the files from there",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"lastRunAt": config.LastRunAt,
+		"downloads": exportDownloads(config.LastFiles),
+	})
+}
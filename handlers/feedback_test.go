@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackBoardSettingsFailsClosed(t *testing.T) {
+	connectTestDatabase(t)
+	userID := "user_test"
+
+	t.Run("Lookup Failure Refuses Feedback", func(t *testing.T) {
+		boardID, _ := createTestBoard(t, userID)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/ideas/idea_1/thumbsup", nil)
+
+		// A cancelled context makes the lookup fail like an unreachable database
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, ok := feedbackBoardSettings(ctx, c, boardID)
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "BOARD_SETTINGS_UNAVAILABLE")
+	})
+
+	t.Run("Missing Board", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/ideas/idea_1/thumbsup", nil)
+
+		_, ok := feedbackBoardSettings(context.Background(), c, "board_missing")
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Settings Loaded", func(t *testing.T) {
+		boardID, _ := createTestBoard(t, userID)
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/ideas/idea_1/thumbsup", nil)
+
+		board, ok := feedbackBoardSettings(context.Background(), c, boardID)
+		assert.True(t, ok)
+		assert.False(t, board.StrictPrivacy)
+	})
+}
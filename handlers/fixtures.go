@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// fixturesSeedRun marks boards loaded from fixtures, so DELETE /api/admin/seed removes them too
+const fixturesSeedRun = "fixtures"
+
+// Fixtures are boards with their ideas in a JSON file, loaded with fixed IDs so demo environments
+// and integration tests start from the same data every time
+type Fixtures struct {
+	Boards []FixtureBoard `json:"boards" binding:"required,min=1,max=100,dive"`
+}
+
+// FixtureBoard is a board of a fixtures file
+type FixtureBoard struct {
+	ID             string        `json:"id" binding:"required,max=64"`
+	Name           string        `json:"name" binding:"required,min=1,max=100"`
+	Description    string        `json:"description" binding:"max=500"`
+	PublicLink     string        `json:"publicLink" binding:"max=64"` // Defaults to the ID
+	IsPublic       bool          `json:"isPublic"`
+	UserID         string        `json:"userId"`         // Defaults to the admin loading the fixtures or FIXTURES_USER_ID
+	VisibleColumns []string      `json:"visibleColumns"` // Defaults to every column
+	VisibleFields  []string      `json:"visibleFields"`  // Defaults to the default fields
+	VoteOptions    []string      `json:"voteOptions" binding:"max=5"`
+	CreatedAt      *time.Time    `json:"createdAt"` // Defaults to the load time
+	Ideas          []FixtureIdea `json:"ideas" binding:"max=5000,dive"`
+}
+
+// FixtureIdea is an idea of a fixture board
+type FixtureIdea struct {
+	ID             string                 `json:"id" binding:"required,max=64"`
+	OneLiner       string                 `json:"oneLiner" binding:"required,min=1,max=200"`
+	Description    string                 `json:"description" binding:"max=1000"`
+	ValueStatement string                 `json:"valueStatement" binding:"max=500"`
+	RiceScore      models.RICEScore       `json:"riceScore"`
+	Column         string                 `json:"column"`   // Defaults to parking
+	Position       int                    `json:"position"` // Defaults to the next place in the column
+	InProgress     bool                   `json:"inProgress"`
+	Status         string                 `json:"status"` // Defaults to active
+	Tags           []string               `json:"tags"`
+	TargetDate     string                 `json:"targetDate"` // YYYY-MM-DD
+	ThumbsUp       int                    `json:"thumbsUp" binding:"min=0"`
+	EmojiReactions []models.EmojiReaction `json:"emojiReactions"`
+	Votes          map[string]int         `json:"votes"`
+	CreatedAt      *time.Time             `json:"createdAt"` // Defaults to the board's
+}
+
+// FixturesReport describes loaded fixtures
+type FixturesReport struct {
+	Boards          int   `json:"boards"`
+	Ideas           int   `json:"ideas"`
+	BoardsReplaced  int64 `json:"boardsReplaced"` // Boards with the same IDs deleted with everything on them
+	FeedbackDeleted int64 `json:"feedbackDeleted"`
+}
+
+// AdminLoadFixtures handles POST /api/admin/fixtures, replacing the boards of the fixtures in the
+// body. Only registered when SEED_DATA_ENABLED=true.
+func AdminLoadFixtures(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+
+	var fixtures Fixtures
+	if err := c.ShouldBindJSON(&fixtures); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid fixtures",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	report, err := LoadFixtures(c.Request.Context(), &fixtures, adminID)
+	if err != nil {
+		var invalid *invalidFixturesError
+		if errors.As(err, &invalid) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_FIXTURES",
+					"message": "Invalid fixtures",
+					"details": invalid.Error(),
+				},
+			})
+			return
+		}
+
+		log.Printf("[Handler] AdminLoadFixtures failed - Error: %v, AdminID: %s, IP: %s", err, adminID, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to load fixtures",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AdminLoadFixtures success - Boards: %d, Ideas: %d, Replaced: %d, AdminID: %s, IP: %s",
+		report.Boards, report.Ideas, report.BoardsReplaced, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, report)
+}
+
+// LoadFixturesFile loads the fixtures file at path on startup, for FIXTURES_FILE. Boards without a
+// userId are owned by FIXTURES_USER_ID.
+func LoadFixturesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := binding.Validator.ValidateStruct(&fixtures); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	report, err := LoadFixtures(ctx, &fixtures, os.Getenv("FIXTURES_USER_ID"))
+	if err != nil {
+		return err
+	}
+	log.Printf("[Fixtures] Loaded %s - Boards: %d, Ideas: %d, Replaced: %d", path, report.Boards, report.Ideas, report.BoardsReplaced)
+	return nil
+}
+
+// invalidFixturesError reports fixtures that cannot be loaded as they are, before anything is written
+type invalidFixturesError struct {
+	message string
+}
+
+func (e *invalidFixturesError) Error() string {
+	return e.message
+}
+
+// invalidFixtures returns an invalidFixturesError
+func invalidFixtures(format string, args ...interface{}) error {
+	return &invalidFixturesError{message: fmt.Sprintf(format, args...)}
+}
+
+// LoadFixtures deletes the boards with the IDs of the fixtures and everything on them, then creates
+// them again as the fixtures describe. Loading the same fixtures twice gives the same data, apart
+// from timestamps the fixtures leave out.
+func LoadFixtures(ctx context.Context, fixtures *Fixtures, defaultUserID string) (*FixturesReport, error) {
+	boards, ideas, err := buildFixtures(fixtures, defaultUserID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	boardIDs := make([]string, 0, len(boards))
+	publicLinks := make([]string, 0, len(boards))
+	boardDocs := make([]interface{}, 0, len(boards))
+	for _, board := range boards {
+		boardIDs = append(boardIDs, board.ID)
+		publicLinks = append(publicLinks, board.PublicLink)
+		boardDocs = append(boardDocs, board)
+	}
+	ideaDocs := make([]interface{}, 0, len(ideas))
+	for _, idea := range ideas {
+		ideaDocs = append(ideaDocs, idea)
+	}
+
+	// Public links are unique, so one used by a board outside the fixtures cannot be taken over
+	taken, err := models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, bson.M{
+		"public_link": bson.M{"$in": publicLinks},
+		"_id":         bson.M{"$nin": boardIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if taken > 0 {
+		return nil, invalidFixtures("a public link of the fixtures belongs to another board")
+	}
+
+	defer models.InvalidateBoards()
+	deleted := &AccountDeletionReport{}
+	if err := deleteBoards(ctx, boardIDs, deleted); err != nil {
+		return nil, fmt.Errorf("failed to delete previous boards: %w", err)
+	}
+
+	if _, err := models.GetCollection(ctx, models.BoardsCollection).InsertMany(ctx, boardDocs); err != nil {
+		return nil, fmt.Errorf("failed to create boards: %w", err)
+	}
+	for start := 0; start < len(ideaDocs); start += seedInsertBatch {
+		end := min(start+seedInsertBatch, len(ideaDocs))
+		if _, err := models.GetCollection(ctx, models.IdeasCollection).InsertMany(ctx, ideaDocs[start:end], options.InsertMany().SetOrdered(false)); err != nil {
+			return nil, fmt.Errorf("failed to create ideas: %w", err)
+		}
+	}
+
+	return &FixturesReport{
+		Boards:          len(boards),
+		Ideas:           len(ideas),
+		BoardsReplaced:  deleted.BoardsDeleted,
+		FeedbackDeleted: deleted.FeedbackDeleted,
+	}, nil
+}
+
+// buildFixtures validates fixtures and turns them into board and idea documents
+func buildFixtures(fixtures *Fixtures, defaultUserID string, now time.Time) ([]models.Board, []models.Idea, error) {
+	boards := make([]models.Board, 0, len(fixtures.Boards))
+	var ideas []models.Idea
+	boardIDs := map[string]bool{}
+	publicLinks := map[string]bool{}
+	ideaIDs := map[string]bool{}
+
+	for _, fixture := range fixtures.Boards {
+		board := models.Board{
+			ID:             fixture.ID,
+			Name:           fixture.Name,
+			Description:    fixture.Description,
+			PublicLink:     fixture.PublicLink,
+			IsPublic:       fixture.IsPublic,
+			UserID:         fixture.UserID,
+			VisibleColumns: fixture.VisibleColumns,
+			VisibleFields:  fixture.VisibleFields,
+			VoteOptions:    fixture.VoteOptions,
+			SeedRun:        fixturesSeedRun,
+			CreatedAt:      now,
+		}
+		if board.PublicLink == "" {
+			board.PublicLink = board.ID
+		}
+		if board.UserID == "" {
+			board.UserID = defaultUserID
+		}
+		if board.UserID == "" {
+			return nil, nil, invalidFixtures("board %s has no userId and no default owner is set", board.ID)
+		}
+		board.CreatedBy = board.UserID
+		board.UpdatedBy = board.UserID
+		if len(board.VisibleColumns) == 0 {
+			board.VisibleColumns = models.GetDefaultVisibleColumns()
+		}
+		if len(board.VisibleFields) == 0 {
+			board.VisibleFields = models.GetDefaultVisibleFields()
+		}
+		if fixture.CreatedAt != nil {
+			board.CreatedAt = fixture.CreatedAt.UTC()
+		}
+		board.UpdatedAt = board.CreatedAt
+
+		if boardIDs[board.ID] {
+			return nil, nil, invalidFixtures("board ID %s is used twice", board.ID)
+		}
+		if publicLinks[board.PublicLink] {
+			return nil, nil, invalidFixtures("public link %s is used twice", board.PublicLink)
+		}
+		boardIDs[board.ID] = true
+		publicLinks[board.PublicLink] = true
+		for _, column := range board.VisibleColumns {
+			if !models.IsValidColumn(column) {
+				return nil, nil, invalidFixtures("board %s: invalid column %s", board.ID, column)
+			}
+		}
+		for _, field := range board.VisibleFields {
+			if !models.IsValidField(field) {
+				return nil, nil, invalidFixtures("board %s: invalid field %s", board.ID, field)
+			}
+		}
+		boards = append(boards, board)
+
+		positions := map[string]int{}
+		for _, fixtureIdea := range fixture.Ideas {
+			idea, err := buildFixtureIdea(&board, &fixtureIdea, positions)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ideaIDs[idea.ID] {
+				return nil, nil, invalidFixtures("idea ID %s is used twice", idea.ID)
+			}
+			ideaIDs[idea.ID] = true
+			ideas = append(ideas, idea)
+		}
+	}
+	return boards, ideas, nil
+}
+
+// buildFixtureIdea validates an idea of a fixture board and turns it into an idea document. Ideas
+// without a position go after the last idea of their column so far.
+func buildFixtureIdea(board *models.Board, fixture *FixtureIdea, positions map[string]int) (models.Idea, error) {
+	idea := models.Idea{
+		ID:             fixture.ID,
+		BoardID:        board.ID,
+		OneLiner:       fixture.OneLiner,
+		Description:    fixture.Description,
+		ValueStatement: fixture.ValueStatement,
+		RiceScore:      fixture.RiceScore,
+		Column:         fixture.Column,
+		Position:       fixture.Position,
+		InProgress:     fixture.InProgress,
+		Status:         fixture.Status,
+		Tags:           models.NormalizeTags(fixture.Tags),
+		ThumbsUp:       fixture.ThumbsUp,
+		EmojiReactions: fixture.EmojiReactions,
+		Votes:          fixture.Votes,
+		CreatedBy:      board.UserID,
+		UpdatedBy:      board.UserID,
+		CreatedAt:      board.CreatedAt,
+	}
+	if idea.Column == "" {
+		idea.Column = string(models.ColumnParking)
+	}
+	if idea.Status == "" {
+		idea.Status = string(models.StatusActive)
+	}
+	if idea.EmojiReactions == nil {
+		idea.EmojiReactions = []models.EmojiReaction{}
+	}
+	if fixture.CreatedAt != nil {
+		idea.CreatedAt = fixture.CreatedAt.UTC()
+	}
+	idea.UpdatedAt = idea.CreatedAt
+
+	if !models.IsValidColumn(idea.Column) {
+		return idea, invalidFixtures("idea %s: invalid column %s", idea.ID, idea.Column)
+	}
+	if !models.IsValidStatus(idea.Status) {
+		return idea, invalidFixtures("idea %s: invalid status %s", idea.ID, idea.Status)
+	}
+	if !idea.RiceScore.IsValidRICEScore() {
+		return idea, invalidFixtures("idea %s: invalid RICE score (R: 0-10, I: 0-10, C: 0-10, E: 1/3/8/21)", idea.ID)
+	}
+	if !models.IsValidTags(idea.Tags) {
+		return idea, invalidFixtures("idea %s: at most %d tags of up to %d characters each", idea.ID, models.MaxIdeaTags, models.MaxTagLength)
+	}
+	targetDate, err := models.ParseTargetDate(fixture.TargetDate)
+	if err != nil {
+		return idea, invalidFixtures("idea %s: targetDate must be a date in YYYY-MM-DD format", idea.ID)
+	}
+	idea.TargetDate = targetDate
+
+	if idea.Position <= 0 {
+		idea.Position = positions[idea.Column] + 1
+	}
+	positions[idea.Column] = max(positions[idea.Column], idea.Position)
+	return idea, nil
+}
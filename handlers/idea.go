@@ -6,7 +6,10 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"disko-backend/middleware"
@@ -27,6 +30,9 @@ type CreateIdeaRequest struct {
 	RiceScore      models.RICEScore `json:"riceScore" binding:"omitempty"`
 	Column         string           `json:"column,omitempty"`
 	Position       int              `json:"position,omitempty"`
+	Tags           []string         `json:"tags,omitempty"`
+	AssigneeID     string           `json:"assigneeId,omitempty"`
+	TargetDate     string           `json:"targetDate,omitempty"` // YYYY-MM-DD
 }
 
 // UpdateIdeaRequest represents the request payload for updating an idea
@@ -38,6 +44,11 @@ type UpdateIdeaRequest struct {
 	Column         string            `json:"column,omitempty"`
 	InProgress     *bool             `json:"inProgress,omitempty"`
 	Status         string            `json:"status,omitempty"`
+	Tags           *[]string         `json:"tags,omitempty"`       // Empty list clears tags
+	AssigneeID     *string           `json:"assigneeId,omitempty"` // Empty string unassigns
+	ReleaseID      *string           `json:"releaseId,omitempty"`  // Empty string detaches from the release
+	TargetDate     *string           `json:"targetDate,omitempty"` // YYYY-MM-DD, empty string clears the date
+	Version        *int64            `json:"version,omitempty"`    // Rejects the edit if the idea changed since; If-Match works too
 }
 
 // UpdateIdeaPositionRequest represents the request payload for updating idea position
@@ -46,29 +57,163 @@ type UpdateIdeaPositionRequest struct {
 	Position int    `json:"position" binding:"min=0"`
 }
 
+// IdeaPositionUpdate is one idea's new column and position in a reorder
+type IdeaPositionUpdate struct {
+	ID       string `json:"id" binding:"required"`
+	Column   string `json:"column" binding:"required"`
+	Position int    `json:"position" binding:"min=0"`
+}
+
+// ReorderIdeasRequest represents the request payload for moving many ideas of a board at once
+type ReorderIdeasRequest struct {
+	Ideas []IdeaPositionUpdate `json:"ideas" binding:"required,min=1,max=500,dive"`
+}
+
 // UpdateIdeaStatusRequest represents the request payload for updating idea status
 type UpdateIdeaStatusRequest struct {
 	InProgress *bool  `json:"inProgress,omitempty"`
 	Status     string `json:"status,omitempty"`
 	Column     string `json:"column,omitempty"`
+	Version    *int64 `json:"version,omitempty"` // Rejects the edit if the idea changed since; If-Match works too
+}
+
+// IdeaPageRequest represents cursor pagination of a board's ideas. Without either parameter every idea is returned.
+type IdeaPageRequest struct {
+	AfterID string `form:"afterId"`                                 // Last idea of the previous page
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=500"` // Defaults to 100 when afterId is set
+	View    string `form:"view"`                                    // compact
+}
+
+// GetBoardIdeasRequest represents query parameters for listing a board's ideas
+type GetBoardIdeasRequest struct {
+	IdeaPageRequest
+	GroupBy string `form:"groupBy"` // tag, assignee, status
+}
+
+// Swimlane represents a group of ideas sharing a tag, assignee or status
+type Swimlane struct {
+	Key   string         `json:"key"` // Empty for untagged or unassigned ideas
+	Ideas []IdeaResponse `json:"ideas"`
+	Count int            `json:"count"`
 }
 
 // IdeaResponse represents the response format for idea operations
 type IdeaResponse struct {
+	ID             string                  `json:"id"`
+	BoardID        string                  `json:"boardId"`
+	OneLiner       string                  `json:"oneLiner"`
+	Description    string                  `json:"description"`
+	ValueStatement string                  `json:"valueStatement"`
+	RiceScore      models.RICEScore        `json:"riceScore"`
+	Column         string                  `json:"column"`
+	Position       int                     `json:"position"`
+	InProgress     bool                    `json:"inProgress"`
+	Status         string                  `json:"status"`
+	Tags           []string                `json:"tags"`
+	AssigneeID     string                  `json:"assigneeId,omitempty"`
+	ReleaseID      string                  `json:"releaseId,omitempty"`
+	ThumbsUp       int                     `json:"thumbsUp"`
+	EmojiReactions []models.EmojiReaction  `json:"emojiReactions"`
+	Votes          map[string]int          `json:"votes,omitempty"` // Counts per board vote option
+	Poll           *models.IdeaPoll        `json:"poll,omitempty"`
+	TargetDate     *time.Time              `json:"targetDate,omitempty"`
+	JiraIssue      *models.JiraIssueLink   `json:"jiraIssue,omitempty"`
+	LinearIssue    *models.LinearIssueLink `json:"linearIssue,omitempty"`
+	CreatedAt      time.Time               `json:"createdAt"`
+	UpdatedAt      time.Time               `json:"updatedAt"`
+	CreatedBy      string                  `json:"createdBy,omitempty"`
+	UpdatedBy      string                  `json:"updatedBy,omitempty"` // Who last edited the idea; feedback does not count
+	Version        int64                   `json:"version"`             // Send back as version or If-Match when editing
+}
+
+// ideaViewCompact is the view listing only what a board needs to render its cards
+const ideaViewCompact = "compact"
+
+// compactIdeaProjection loads only the fields of a CompactIdeaResponse
+var compactIdeaProjection = bson.M{
+	"_id":             1,
+	"one_liner":       1,
+	"column":          1,
+	"position":        1,
+	"in_progress":     1,
+	"thumbs_up":       1,
+	"emoji_reactions": 1,
+	"votes":           1,
+}
+
+// CompactIdeaResponse represents an idea in a view=compact listing
+type CompactIdeaResponse struct {
 	ID             string                 `json:"id"`
-	BoardID        string                 `json:"boardId"`
 	OneLiner       string                 `json:"oneLiner"`
-	Description    string                 `json:"description"`
-	ValueStatement string                 `json:"valueStatement"`
-	RiceScore      models.RICEScore       `json:"riceScore"`
 	Column         string                 `json:"column"`
 	Position       int                    `json:"position"`
 	InProgress     bool                   `json:"inProgress"`
-	Status         string                 `json:"status"`
 	ThumbsUp       int                    `json:"thumbsUp"`
 	EmojiReactions []models.EmojiReaction `json:"emojiReactions"`
-	CreatedAt      time.Time              `json:"createdAt"`
-	UpdatedAt      time.Time              `json:"updatedAt"`
+	Votes          map[string]int         `json:"votes,omitempty"` // Counts per board vote option
+}
+
+// newCompactIdeaResponse builds the compact response for an idea loaded with compactIdeaProjection
+func newCompactIdeaResponse(idea models.Idea) CompactIdeaResponse {
+	return CompactIdeaResponse{
+		ID:             idea.ID,
+		OneLiner:       idea.OneLiner,
+		Column:         idea.Column,
+		Position:       idea.Position,
+		InProgress:     idea.InProgress,
+		ThumbsUp:       idea.ThumbsUp,
+		EmojiReactions: idea.EmojiReactions,
+		Votes:          idea.Votes,
+	}
+}
+
+// rejectInvalidView writes INVALID_VIEW unless view is empty or compact
+func rejectInvalidView(c *gin.Context, view string) bool {
+	if view == "" || view == ideaViewCompact {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": gin.H{
+			"code":    "INVALID_VIEW",
+			"message": "view must be compact when set",
+		},
+	})
+	return true
+}
+
+// newIdeaResponse builds the admin response for an idea
+func newIdeaResponse(idea models.Idea) IdeaResponse {
+	response := IdeaResponse{
+		ID:             idea.ID,
+		BoardID:        idea.BoardID,
+		OneLiner:       idea.OneLiner,
+		Description:    idea.Description,
+		ValueStatement: idea.ValueStatement,
+		RiceScore:      idea.RiceScore,
+		Column:         idea.Column,
+		Position:       idea.Position,
+		InProgress:     idea.InProgress,
+		Status:         idea.Status,
+		Tags:           idea.Tags,
+		AssigneeID:     idea.AssigneeID,
+		ReleaseID:      idea.ReleaseID,
+		ThumbsUp:       idea.ThumbsUp,
+		EmojiReactions: idea.EmojiReactions,
+		Votes:          idea.Votes,
+		Poll:           idea.Poll,
+		TargetDate:     idea.TargetDate,
+		JiraIssue:      idea.JiraIssue,
+		LinearIssue:    idea.LinearIssue,
+		CreatedAt:      idea.CreatedAt,
+		UpdatedAt:      idea.UpdatedAt,
+		CreatedBy:      idea.CreatedBy,
+		UpdatedBy:      idea.UpdatedBy,
+		Version:        idea.Version,
+	}
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+	return response
 }
 
 // PublicIdeaResponse represents the response format for public idea access (filtered)
@@ -80,12 +225,60 @@ type PublicIdeaResponse struct {
 	Column         string                 `json:"column"`
 	Position       int                    `json:"position"`
 	InProgress     bool                   `json:"inProgress"`
+	RiceScore      *models.RICEScore      `json:"riceScore,omitempty"`      // Only when the board exposes RICE publicly
+	RiceScoreTotal *float64               `json:"riceScoreTotal,omitempty"` // Only when the board exposes RICE publicly
 	ThumbsUp       int                    `json:"thumbsUp"`
 	EmojiReactions []models.EmojiReaction `json:"emojiReactions"`
+	Votes          map[string]int         `json:"votes,omitempty"` // Counts per board vote option
+	Poll           *models.IdeaPoll       `json:"poll,omitempty"`
+	TargetDate     *time.Time             `json:"targetDate,omitempty"` // Only when the targetDate field is public
 	CreatedAt      time.Time              `json:"createdAt"`
 	UpdatedAt      time.Time              `json:"updatedAt"`
 }
 
+// withPublicRiceScore attaches the RICE score to a public response
+func (r *PublicIdeaResponse) withPublicRiceScore(score models.RICEScore) {
+	total := score.CalculateRICEScore()
+	r.RiceScore = &score
+	r.RiceScoreTotal = &total
+}
+
+// newPublicIdeaResponse converts an idea for public visitors, keeping only the board's visible fields
+func newPublicIdeaResponse(idea models.Idea, visibleFields map[string]bool, exposeRiceScore bool) PublicIdeaResponse {
+	response := PublicIdeaResponse{
+		ID:             idea.ID,
+		OneLiner:       idea.OneLiner, // Always visible
+		Column:         idea.Column,
+		Position:       idea.Position,
+		InProgress:     idea.InProgress,
+		ThumbsUp:       idea.ThumbsUp,
+		EmojiReactions: idea.EmojiReactions,
+		Votes:          idea.Votes,
+		Poll:           idea.Poll,
+		CreatedAt:      idea.CreatedAt,
+		UpdatedAt:      idea.UpdatedAt,
+	}
+
+	// Add optional fields based on visibility settings
+	if visibleFields[string(models.FieldDescription)] {
+		response.Description = idea.Description
+	}
+
+	if visibleFields[string(models.FieldValueStatement)] {
+		response.ValueStatement = idea.ValueStatement
+	}
+
+	if visibleFields[string(models.FieldTargetDate)] {
+		response.TargetDate = idea.TargetDate
+	}
+
+	// RICE scores are private unless the board opts into open roadmapping
+	if exposeRiceScore {
+		response.withPublicRiceScore(idea.RiceScore)
+	}
+	return response
+}
+
 // CreateIdea handles POST /api/boards/:id/ideas
 func CreateIdea(c *gin.Context) {
 	log.Printf("[Handler] CreateIdea started - Method: %s, Path: %s, IP: %s", c.Request.Method, c.Request.URL.Path, c.ClientIP())
@@ -131,53 +324,44 @@ func CreateIdea(c *gin.Context) {
 	log.Printf("[Handler] CreateIdea - JSON parsed successfully: OneLiner='%s', Description='%s', ValueStatement='%s', RiceScore=%+v",
 		req.OneLiner, req.Description, req.ValueStatement, req.RiceScore)
 
-	// Validate RICE score
-	log.Printf("[Handler] CreateIdea - Validating RICE score: %+v", req.RiceScore)
-	if !req.RiceScore.IsValidRICEScore() {
-		log.Printf("[Handler] CreateIdea - RICE score validation failed")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": gin.H{
-				"code":    "INVALID_RICE_SCORE",
-				"message": "Invalid RICE score values. R: 0-10, I: 0-10, C: 0-10, E: 1/3/8/21",
-			},
-		})
+	if rejectInvalidRiceScore(c, req.RiceScore) {
 		return
 	}
-	log.Printf("[Handler] CreateIdea - RICE score validation passed")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
-	// Verify board exists and belongs to user
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     boardID,
-		"user_id": userID,
+	board, ok := contextBoard(c)
+	if !ok {
+		return
 	}
 
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "BOARD_NOT_FOUND",
-					"message": "Board not found or you don't have permission to add ideas",
-				},
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to verify board",
-				"details": err.Error(),
-			},
-		})
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
 		return
 	}
 
+	insertIdea(ctx, c, board, &req, userID)
+}
+
+// rejectInvalidRiceScore writes INVALID_RICE_SCORE unless the score is within range
+func rejectInvalidRiceScore(c *gin.Context, score models.RICEScore) bool {
+	if score.IsValidRICEScore() {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": gin.H{
+			"code":    "INVALID_RICE_SCORE",
+			"message": "Invalid RICE score values. R: 0-10, I: 0-10, C: 0-10, E: 1/3/8/21",
+		},
+	})
+	return true
+}
+
+// insertIdea creates an idea on a board the actor may add ideas to and writes the created idea.
+// The actor is recorded in the board activity log.
+func insertIdea(ctx context.Context, c *gin.Context, board *models.Board, req *CreateIdeaRequest, actorID string) {
+	boardID := board.ID
+
 	// Set default column to parking if not specified
 	column := req.Column
 	if column == "" {
@@ -198,17 +382,9 @@ func CreateIdea(c *gin.Context) {
 	// Get next position in column if not specified
 	position := req.Position
 	if position == 0 {
-		ideasCollection := models.GetCollection(models.IdeasCollection)
-		positionFilter := bson.M{
-			"board_id": boardID,
-			"column":   column,
-		}
-
-		// Find the highest position in the column
-		opts := options.FindOne().SetSort(bson.D{{Key: "position", Value: -1}})
-		var lastIdea models.Idea
-		err = ideasCollection.FindOne(ctx, positionFilter, opts).Decode(&lastIdea)
-		if err != nil && err != mongo.ErrNoDocuments {
+		var err error
+		position, err = models.NextIdeaPosition(ctx, boardID, column)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": gin.H{
 					"code":    "DATABASE_ERROR",
@@ -218,12 +394,17 @@ func CreateIdea(c *gin.Context) {
 			})
 			return
 		}
+	}
 
-		if err == mongo.ErrNoDocuments {
-			position = 1 // First idea in column
-		} else {
-			position = lastIdea.Position + 1
-		}
+	targetDate, err := models.ParseTargetDate(req.TargetDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_TARGET_DATE",
+				"message": "targetDate must be a date in YYYY-MM-DD format",
+			},
+		})
+		return
 	}
 
 	// Generate unique idea ID with "I" prefix
@@ -242,8 +423,13 @@ func CreateIdea(c *gin.Context) {
 		Position:       position,
 		InProgress:     false,
 		Status:         string(models.StatusActive),
+		Tags:           models.NormalizeTags(req.Tags),
+		AssigneeID:     req.AssigneeID,
+		TargetDate:     targetDate,
 		ThumbsUp:       0,
 		EmojiReactions: []models.EmojiReaction{},
+		CreatedBy:      actorID,
+		UpdatedBy:      actorID,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
@@ -261,9 +447,8 @@ func CreateIdea(c *gin.Context) {
 	}
 
 	// Insert into MongoDB
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	_, err = ideasCollection.InsertOne(ctx, idea)
-	if err != nil {
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	if _, err := ideasCollection.InsertOne(ctx, idea); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
@@ -274,23 +459,19 @@ func CreateIdea(c *gin.Context) {
 		return
 	}
 
+	// Record activity
+	go utils.RecordActivity(ctx, boardID, idea.ID, actorID, models.ActivityIdeaCreated, map[string]interface{}{
+		"oneLiner": idea.OneLiner,
+		"column":   idea.Column,
+	})
+	recordAudit(c, actorID, models.AuditIdeaCreated, boardID, models.AuditTargetIdea, idea.ID, nil, idea)
+	go utils.RecordScoreSnapshot(ctx, &idea, actorID)
+
 	// Return created idea
-	response := IdeaResponse{
-		ID:             idea.ID,
-		BoardID:        idea.BoardID,
-		OneLiner:       idea.OneLiner,
-		Description:    idea.Description,
-		ValueStatement: idea.ValueStatement,
-		RiceScore:      idea.RiceScore,
-		Column:         idea.Column,
-		Position:       idea.Position,
-		InProgress:     idea.InProgress,
-		Status:         idea.Status,
-		ThumbsUp:       idea.ThumbsUp,
-		EmojiReactions: idea.EmojiReactions,
-		CreatedAt:      idea.CreatedAt,
-		UpdatedAt:      idea.UpdatedAt,
-	}
+	response := newIdeaResponse(idea)
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventIdeaCreated, idea.ID, response)
+	emitIdeaEvent(ctx, models.WebhookIdeaCreated, &idea, nil)
+	go utils.EvaluateColumnAlerts(ctx, boardID)
 
 	c.JSON(http.StatusCreated, response)
 }
@@ -335,64 +516,186 @@ func GetBoardIdeas(c *gin.Context) {
 
 	log.Printf("[Handler] GetBoardIdeas - Board ID validation passed - BoardID: %s, UserID: %s", boardID, userID)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Verify board exists and belongs to user
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     boardID,
-		"user_id": userID,
+	// Parse query parameters
+	var req GetBoardIdeasRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
 	}
 
-	log.Printf("[Handler] GetBoardIdeas - Starting board verification - Filter: %+v, BoardID: %s, UserID: %s", boardFilter, boardID, userID)
-	log.Printf("[Handler] GetBoardIdeas - Database collection: %s", models.BoardsCollection)
-
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			log.Printf("[Handler] GetBoardIdeas failed - Board not found - BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "BOARD_NOT_FOUND",
-					"message": "Board not found or you don't have permission to view ideas",
-				},
-			})
-			return
-		}
-
-		log.Printf("[Handler] GetBoardIdeas failed - Database error during board verification - BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
+	switch req.GroupBy {
+	case "", "tag", "assignee", "status":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
 			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to verify board",
-				"details": err.Error(),
+				"code":    "INVALID_GROUP_BY",
+				"message": "groupBy must be one of: tag, assignee, status",
+			},
+		})
+		return
+	}
+	if rejectInvalidView(c, req.View) {
+		return
+	}
+	if req.View == ideaViewCompact && req.GroupBy != "" {
+		// Swimlanes group by fields the compact view does not load
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_VIEW",
+				"message": "view=compact cannot be combined with groupBy",
 			},
 		})
 		return
 	}
 
-	log.Printf("[Handler] GetBoardIdeas - Board verification successful - BoardID: %s, UserID: %s, Board name: %s", boardID, userID, board.Name)
+	ctx := c.Request.Context()
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	log.Printf("[Handler] GetBoardIdeas - Board verified - BoardID: %s, UserID: %s, Board name: %s", boardID, userID, board.Name)
 
 	// Query ideas for the board
-	ideasCollection := models.GetCollection(models.IdeasCollection)
 	ideasFilter := bson.M{"board_id": boardID}
 
-	log.Printf("[Handler] GetBoardIdeas - Starting ideas query - Filter: %+v, BoardID: %s", ideasFilter, boardID)
-	log.Printf("[Handler] GetBoardIdeas - Database collection: %s", models.IdeasCollection)
+	log.Printf("[Handler] GetBoardIdeas - Starting ideas query - Filter: %+v, AfterID: %s, Limit: %d, BoardID: %s", ideasFilter, req.AfterID, req.Limit, boardID)
+
+	ideas, hasMore, ok := findIdeaPage(ctx, c, ideasFilter, req.IdeaPageRequest)
+	if !ok {
+		log.Printf("[Handler] GetBoardIdeas failed - Ideas query - BoardID: %s, UserID: %s", boardID, userID)
+		return
+	}
+
+	log.Printf("[Handler] GetBoardIdeas - Ideas decoded successfully - BoardID: %s, UserID: %s, Ideas count: %d", boardID, userID, len(ideas))
+
+	if req.View == ideaViewCompact {
+		compact := []CompactIdeaResponse{}
+		for _, idea := range ideas {
+			compact = append(compact, newCompactIdeaResponse(idea))
+		}
+		response := gin.H{
+			"ideas": compact,
+			"count": len(compact),
+			"view":  ideaViewCompact,
+		}
+		addIdeaPage(response, req.IdeaPageRequest, ideas, hasMore)
+
+		log.Printf("[Handler] GetBoardIdeas success - BoardID: %s, UserID: %s, Ideas count: %d, View: compact, Duration: %v, IP: %s",
+			boardID, userID, len(compact), time.Since(startTime), c.ClientIP())
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	// Convert to response format
+	var responses []IdeaResponse
+	for _, idea := range ideas {
+		responses = append(responses, newIdeaResponse(idea))
+	}
 
-	// Sort by column and position
-	opts := options.Find().SetSort(bson.D{
-		{Key: "column", Value: 1},
-		{Key: "position", Value: 1},
+	duration := time.Since(startTime)
+	log.Printf("[Handler] GetBoardIdeas success - BoardID: %s, UserID: %s, Ideas count: %d, Duration: %v, IP: %s, Response size: %d bytes",
+		boardID, userID, len(responses), duration, c.ClientIP(), len(responses)*100) // Approximate response size
+	log.Printf("[Handler] GetBoardIdeas - Response structure: %+v", gin.H{
+		"ideas": len(responses),
+		"count": len(responses),
 	})
 
-	log.Printf("[Handler] GetBoardIdeas - Query options: %+v", opts)
+	// Profiles of the assignees, so the board can show names and avatars
+	assigneeIDs := []string{}
+	for _, idea := range ideas {
+		assigneeIDs = append(assigneeIDs, idea.AssigneeID)
+	}
+	users := findUserProfiles(ctx, assigneeIDs)
+
+	// Pre-group into swimlanes so large boards don't need client-side bucketing
+	response := gin.H{
+		"count": len(responses),
+		"users": users,
+	}
+	if req.GroupBy != "" {
+		response["groupBy"] = req.GroupBy
+		response["swimlanes"] = groupIdeasIntoSwimlanes(responses, req.GroupBy)
+	} else {
+		response["ideas"] = responses
+	}
+	addIdeaPage(response, req.IdeaPageRequest, ideas, hasMore)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ideaPageSort is the stable board order idea listings page through
+var ideaPageSort = bson.D{
+	{Key: "column", Value: 1},
+	{Key: "position", Value: 1},
+	{Key: "_id", Value: 1},
+}
+
+// defaultIdeaPageLimit is the page size when only afterId is given
+const defaultIdeaPageLimit = 100
+
+// findIdeaPage loads the ideas matching a board's filter in board order, only those after req.AfterID
+// and at most req.Limit of them when paginating, reporting whether more follow. The cursor idea
+// must be on the board; one that has since moved resumes from its new place. The compact view
+// loads only the fields of a CompactIdeaResponse. It writes an error response and returns false
+// on failure.
+func findIdeaPage(ctx context.Context, c *gin.Context, filter bson.M, req IdeaPageRequest) ([]models.Idea, bool, bool) {
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	opts := options.Find().SetSort(ideaPageSort)
+	if req.View == ideaViewCompact {
+		opts.SetProjection(compactIdeaProjection)
+	}
+
+	limit := req.Limit
+	if req.AfterID != "" {
+		if limit == 0 {
+			limit = defaultIdeaPageLimit
+		}
+
+		var after models.Idea
+		err := ideasCollection.FindOne(ctx, bson.M{"_id": req.AfterID, "board_id": filter["board_id"]},
+			options.FindOne().SetProjection(bson.M{"column": 1, "position": 1})).Decode(&after)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": gin.H{
+						"code":    "INVALID_CURSOR",
+						"message": "afterId must be an idea on this board",
+					},
+				})
+				return nil, false, false
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch cursor idea",
+					"details": err.Error(),
+				},
+			})
+			return nil, false, false
+		}
+
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{"column": bson.M{"$gt": after.Column}},
+			bson.M{"column": after.Column, "position": bson.M{"$gt": after.Position}},
+			bson.M{"column": after.Column, "position": after.Position, "_id": bson.M{"$gt": after.ID}},
+		}}}}
+	}
+	if limit > 0 {
+		// One extra idea tells whether another page follows
+		opts.SetLimit(int64(limit + 1))
+	}
 
-	cursor, err := ideasCollection.Find(ctx, ideasFilter, opts)
+	cursor, err := ideasCollection.Find(ctx, filter, opts)
 	if err != nil {
-		log.Printf("[Handler] GetBoardIdeas failed - Database error during ideas query - BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
@@ -400,16 +703,12 @@ func GetBoardIdeas(c *gin.Context) {
 				"details": err.Error(),
 			},
 		})
-		return
+		return nil, false, false
 	}
 	defer cursor.Close(ctx)
 
-	log.Printf("[Handler] GetBoardIdeas - Ideas query successful - BoardID: %s, UserID: %s", boardID, userID)
-
-	// Decode results
 	var ideas []models.Idea
 	if err := cursor.All(ctx, &ideas); err != nil {
-		log.Printf("[Handler] GetBoardIdeas failed - Database error during ideas decoding - BoardID: %s, UserID: %s, Error: %v", boardID, userID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
@@ -417,44 +716,82 @@ func GetBoardIdeas(c *gin.Context) {
 				"details": err.Error(),
 			},
 		})
-		return
+		return nil, false, false
 	}
 
-	log.Printf("[Handler] GetBoardIdeas - Ideas decoded successfully - BoardID: %s, UserID: %s, Ideas count: %d", boardID, userID, len(ideas))
+	hasMore := limit > 0 && len(ideas) > limit
+	if hasMore {
+		ideas = ideas[:limit]
+	}
+	return ideas, hasMore, true
+}
 
-	// Convert to response format
-	var responses []IdeaResponse
-	for _, idea := range ideas {
-		responses = append(responses, IdeaResponse{
-			ID:             idea.ID,
-			BoardID:        idea.BoardID,
-			OneLiner:       idea.OneLiner,
-			Description:    idea.Description,
-			ValueStatement: idea.ValueStatement,
-			RiceScore:      idea.RiceScore,
-			Column:         idea.Column,
-			Position:       idea.Position,
-			InProgress:     idea.InProgress,
-			Status:         idea.Status,
-			ThumbsUp:       idea.ThumbsUp,
-			EmojiReactions: idea.EmojiReactions,
-			CreatedAt:      idea.CreatedAt,
-			UpdatedAt:      idea.UpdatedAt,
-		})
+// addIdeaPage adds hasMore, and the nextCursor to pass as afterId, to a paginated listing's response
+func addIdeaPage(response gin.H, req IdeaPageRequest, ideas []models.Idea, hasMore bool) {
+	if req.AfterID == "" && req.Limit == 0 {
+		return
 	}
+	response["hasMore"] = hasMore
+	if hasMore {
+		response["nextCursor"] = ideas[len(ideas)-1].ID
+	}
+}
 
-	duration := time.Since(startTime)
-	log.Printf("[Handler] GetBoardIdeas success - BoardID: %s, UserID: %s, Ideas count: %d, Duration: %v, IP: %s, Response size: %d bytes",
-		boardID, userID, len(responses), duration, c.ClientIP(), len(responses)*100) // Approximate response size
-	log.Printf("[Handler] GetBoardIdeas - Response structure: %+v", gin.H{
-		"ideas": len(responses),
-		"count": len(responses),
-	})
+// groupIdeasIntoSwimlanes buckets ideas by tag, assignee or status, keeping their board order.
+// Ideas with several tags appear in each tag's lane; untagged and unassigned ideas share a lane with an empty key, listed last.
+func groupIdeasIntoSwimlanes(ideas []IdeaResponse, groupBy string) []Swimlane {
+	lanes := make(map[string]*Swimlane)
+	add := func(key string, idea IdeaResponse) {
+		lane, ok := lanes[key]
+		if !ok {
+			lane = &Swimlane{Key: key, Ideas: []IdeaResponse{}}
+			lanes[key] = lane
+		}
+		lane.Ideas = append(lane.Ideas, idea)
+		lane.Count++
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"ideas": responses,
-		"count": len(responses),
-	})
+	for _, idea := range ideas {
+		switch groupBy {
+		case "tag":
+			if len(idea.Tags) == 0 {
+				add("", idea)
+			}
+			for _, tag := range idea.Tags {
+				add(tag, idea)
+			}
+		case "assignee":
+			add(idea.AssigneeID, idea)
+		case "status":
+			add(idea.Status, idea)
+		}
+	}
+
+	var keys []string
+	if groupBy == "status" {
+		// Status lanes follow the idea lifecycle rather than the alphabet
+		for _, status := range []models.IdeaStatus{models.StatusDraft, models.StatusActive, models.StatusDone, models.StatusArchived} {
+			if _, ok := lanes[string(status)]; ok {
+				keys = append(keys, string(status))
+			}
+		}
+	} else {
+		for key := range lanes {
+			if key != "" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		if _, ok := lanes[""]; ok {
+			keys = append(keys, "")
+		}
+	}
+
+	swimlanes := make([]Swimlane, 0, len(keys))
+	for _, key := range keys {
+		swimlanes = append(swimlanes, *lanes[key])
+	}
+	return swimlanes
 }
 
 // UpdateIdea handles PUT /api/ideas/:id
@@ -495,12 +832,15 @@ func UpdateIdea(c *gin.Context) {
 		})
 		return
 	}
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
+		return
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
 	// First, get the idea to verify it exists and get board info
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	var existingIdea models.Idea
 	err = ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&existingIdea)
 	if err != nil {
@@ -524,15 +864,8 @@ func UpdateIdea(c *gin.Context) {
 		return
 	}
 
-	// Verify user owns the board containing this idea
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     existingIdea.BoardID,
-		"user_id": userID,
-	}
-
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
+	// Verify the user may edit the board containing this idea
+	board, err := middleware.FindAccessibleBoard(ctx, c, existingIdea.BoardID, userID, models.RoleEditor)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusForbidden, gin.H{
@@ -554,9 +887,21 @@ func UpdateIdea(c *gin.Context) {
 		return
 	}
 
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	// Refuse to overwrite an edit the client has not seen
+	if version != nil && *version != existingIdea.Version {
+		respondVersionConflict(c, *version, existingIdea.Version)
+		return
+	}
+
 	// Build update document
 	updateDoc := bson.M{
 		"updated_at": time.Now().UTC(),
+		"updated_by": userID,
 	}
 
 	if req.OneLiner != "" {
@@ -603,6 +948,65 @@ func UpdateIdea(c *gin.Context) {
 		updateDoc["in_progress"] = *req.InProgress
 	}
 
+	if req.Tags != nil {
+		tags := models.NormalizeTags(*req.Tags)
+		if !models.IsValidTags(tags) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_TAGS",
+					"message": fmt.Sprintf("Ideas may have at most %d tags of up to %d characters each", models.MaxIdeaTags, models.MaxTagLength),
+				},
+			})
+			return
+		}
+		updateDoc["tags"] = tags
+	}
+
+	if req.AssigneeID != nil {
+		updateDoc["assignee_id"] = *req.AssigneeID
+	}
+
+	if req.ReleaseID != nil && *req.ReleaseID != "" {
+		// Ideas can only be attached to releases on their own board
+		count, err := models.GetCollection(ctx, models.ReleasesCollection).CountDocuments(ctx, bson.M{"_id": *req.ReleaseID, "board_id": existingIdea.BoardID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to verify release",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "RELEASE_NOT_FOUND",
+					"message": "Release not found on this board",
+				},
+			})
+			return
+		}
+	}
+	if req.ReleaseID != nil {
+		updateDoc["release_id"] = *req.ReleaseID
+	}
+
+	if req.TargetDate != nil {
+		targetDate, err := models.ParseTargetDate(*req.TargetDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_TARGET_DATE",
+					"message": "targetDate must be a date in YYYY-MM-DD format",
+				},
+			})
+			return
+		}
+		updateDoc["target_date"] = targetDate
+	}
+
 	if req.Status != "" {
 		// Validate status
 		if !models.IsValidStatus(req.Status) {
@@ -634,38 +1038,34 @@ func UpdateIdea(c *gin.Context) {
 		}
 	}
 
-	// Update idea in MongoDB
+	// Update idea in MongoDB and return the updated document, unless it was edited since it was loaded.
+	// Ideas changing column are moved to the end of their new column.
 	filter := bson.M{"_id": ideaID}
-	result, err := ideasCollection.UpdateOne(ctx, filter, bson.M{"$set": updateDoc})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to update idea",
-				"details": err.Error(),
-			},
-		})
-		return
+	if version != nil {
+		filter["version"] = models.VersionCondition(*version)
 	}
-
-	if result.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": gin.H{
-				"code":    "IDEA_NOT_FOUND",
-				"message": "Idea not found",
-			},
-		})
-		return
+	updatedIdea, err := saveIdeaUpdate(ctx, filter, &existingIdea, updateDoc)
+	if err == mongo.ErrNoDocuments && version != nil {
+		if current, found := currentVersion(ctx, models.IdeasCollection, ideaID); found {
+			respondVersionConflict(c, *version, current)
+			return
+		}
 	}
-
-	// Fetch and return updated idea
-	var updatedIdea models.Idea
-	err = ideasCollection.FindOne(ctx, filter).Decode(&updatedIdea)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch updated idea",
+				"message": "Failed to update idea",
 				"details": err.Error(),
 			},
 		})
@@ -673,23 +1073,35 @@ func UpdateIdea(c *gin.Context) {
 	}
 
 	// Return updated idea
-	response := IdeaResponse{
-		ID:             updatedIdea.ID,
-		BoardID:        updatedIdea.BoardID,
-		OneLiner:       updatedIdea.OneLiner,
-		Description:    updatedIdea.Description,
-		ValueStatement: updatedIdea.ValueStatement,
-		RiceScore:      updatedIdea.RiceScore,
-		Column:         updatedIdea.Column,
-		Position:       updatedIdea.Position,
-		InProgress:     updatedIdea.InProgress,
-		Status:         updatedIdea.Status,
-		ThumbsUp:       updatedIdea.ThumbsUp,
-		EmojiReactions: updatedIdea.EmojiReactions,
-		CreatedAt:      updatedIdea.CreatedAt,
-		UpdatedAt:      updatedIdea.UpdatedAt,
+	response := newIdeaResponse(*updatedIdea)
+
+	// Record activity (column changes are recorded as moves)
+	activityType := models.ActivityIdeaUpdated
+	if updatedIdea.Column != existingIdea.Column {
+		activityType = models.ActivityIdeaMoved
 	}
+	go utils.RecordActivity(ctx, updatedIdea.BoardID, ideaID, userID, activityType, map[string]interface{}{
+		"fromColumn": existingIdea.Column,
+		"toColumn":   updatedIdea.Column,
+		"fields":     updatedFields(updateDoc),
+	})
+	recordAudit(c, userID, models.AuditIdeaUpdated, updatedIdea.BoardID, models.AuditTargetIdea, ideaID, existingIdea, updatedIdea)
+	if updatedIdea.RiceScore != existingIdea.RiceScore {
+		go utils.RecordScoreSnapshot(ctx, updatedIdea, userID)
+	}
+	announceIfReleased(ctx, &existingIdea, updatedIdea)
+	notifyStatusChange(ctx, &existingIdea, updatedIdea, userID)
+	checkColumnAlerts(ctx, &existingIdea, updatedIdea)
+
+	// Edits may touch RICE scores and hidden fields, so only members receive the details
+	utils.BroadcastBoardEvent(ctx, updatedIdea.BoardID, utils.EventIdeaUpdated, ideaID, map[string]interface{}{
+		"ideaId": ideaID,
+		"fields": updatedFields(updateDoc),
+		"type":   "idea_changed",
+		"idea":   response,
+	})
 
+	setVersionHeader(c, updatedIdea.Version)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -719,11 +1131,10 @@ func DeleteIdea(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
 	// First, get the idea to verify it exists and get board info
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	var existingIdea models.Idea
 	err = ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&existingIdea)
 	if err != nil {
@@ -747,15 +1158,8 @@ func DeleteIdea(c *gin.Context) {
 		return
 	}
 
-	// Verify user owns the board containing this idea
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     existingIdea.BoardID,
-		"user_id": userID,
-	}
-
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
+	// Verify the user may edit the board containing this idea
+	board, err := middleware.FindAccessibleBoard(ctx, c, existingIdea.BoardID, userID, models.RoleEditor)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusForbidden, gin.H{
@@ -777,6 +1181,11 @@ func DeleteIdea(c *gin.Context) {
 		return
 	}
 
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
 	// Delete the idea
 	filter := bson.M{"_id": ideaID}
 	result, err := ideasCollection.DeleteOne(ctx, filter)
@@ -801,16 +1210,45 @@ func DeleteIdea(c *gin.Context) {
 		return
 	}
 
+	// Remove the idea's comments, votes, attachments and score history; a failure only leaves unreachable
+	// records behind
+	if _, err := models.GetCollection(ctx, models.CommentsCollection).DeleteMany(ctx, bson.M{"idea_id": ideaID}); err != nil {
+		log.Printf("[Handler] DeleteIdea - Comments cleanup error: %v, IdeaID: %s", err, ideaID)
+	}
+	if _, err := models.GetCollection(ctx, models.VotesCollection).DeleteMany(ctx, bson.M{"idea_id": ideaID}); err != nil {
+		log.Printf("[Handler] DeleteIdea - Votes cleanup error: %v, IdeaID: %s", err, ideaID)
+	}
+	if _, err := models.GetCollection(ctx, models.AttachmentsCollection).DeleteMany(ctx, bson.M{"idea_id": ideaID}); err != nil {
+		log.Printf("[Handler] DeleteIdea - Attachments cleanup error: %v, IdeaID: %s", err, ideaID)
+	}
+	if _, err := models.GetCollection(ctx, models.ScoreHistoryCollection).DeleteMany(ctx, bson.M{"idea_id": ideaID}); err != nil {
+		log.Printf("[Handler] DeleteIdea - Score history cleanup error: %v, IdeaID: %s", err, ideaID)
+	}
+	if _, err := utils.GetStorage().DeletePrefix(ctx, models.IdeaStoragePrefix(ctx, existingIdea.BoardID, ideaID)); err != nil {
+		log.Printf("[Handler] DeleteIdea - Attachment files cleanup error: %v, IdeaID: %s", err, ideaID)
+	}
+
+	// Record activity
+	go utils.RecordActivity(ctx, existingIdea.BoardID, ideaID, userID, models.ActivityIdeaDeleted, map[string]interface{}{
+		"oneLiner": existingIdea.OneLiner,
+		"column":   existingIdea.Column,
+	})
+	recordAudit(c, userID, models.AuditIdeaDeleted, existingIdea.BoardID, models.AuditTargetIdea, ideaID, existingIdea, nil)
+	utils.BroadcastBoardEvent(ctx, existingIdea.BoardID, utils.EventIdeaDeleted, ideaID, map[string]interface{}{
+		"column": existingIdea.Column,
+	})
+	go utils.EvaluateColumnAlerts(ctx, existingIdea.BoardID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Idea deleted successfully",
 	})
 }
 
-// UpdateIdeaPosition handles PUT /api/ideas/:id/position
+// UpdateIdeaPosition handles PUT /api/ideas/:id/position. The position is the idea's 1-based place
+// in the target column; both columns are renumbered in the same transaction.
 func UpdateIdeaPosition(c *gin.Context) {
 	// Get user ID from auth middleware
-	userID, err := middleware.GetUserID(c)
-	if err != nil {
+	if _, err := middleware.GetUserID(c); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "INTERNAL_ERROR",
@@ -845,159 +1283,230 @@ func UpdateIdeaPosition(c *gin.Context) {
 		return
 	}
 
-	// Validate column
-	if !models.IsValidColumn(req.Column) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": gin.H{
-				"code":    "INVALID_COLUMN",
-				"message": "Invalid column type: " + req.Column,
-			},
-		})
+	ctx := c.Request.Context()
+
+	updatedIdea, changeErr := moveIdea(ctx, requestActor(c), ideaID, req)
+	if changeErr != nil {
+		c.JSON(changeErr.status, changeErr.body())
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	c.JSON(http.StatusOK, newIdeaResponse(*updatedIdea))
+}
 
-	// First, get the idea to verify it exists and get board info
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	var existingIdea models.Idea
-	err = ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&existingIdea)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "IDEA_NOT_FOUND",
-					"message": "Idea not found",
-				},
-			})
-			return
-		}
+// saveIdeaUpdate sets the fields of updateDoc on the idea matching filter and returns the updated
+// idea. When updateDoc changes the idea's column, the idea goes to the end of its new column through
+// models.MoveIdea, so both columns stay numbered from 1.
+func saveIdeaUpdate(ctx context.Context, filter bson.M, existingIdea *models.Idea, updateDoc bson.M) (*models.Idea, error) {
+	if column, ok := updateDoc["column"].(string); ok && column != existingIdea.Column {
+		return models.MoveIdea(ctx, filter, column, models.EndOfColumn, updateDoc)
+	}
+	return models.UpdateIdeaAndReturn(ctx, filter, models.BumpVersion(bson.M{"$set": updateDoc}))
+}
 
+// ReorderIdeas handles PUT /api/boards/:id/ideas/reorder, persisting the new column and position of
+// every idea a drag and drop moved in a single bulk write. Ideas already in place are skipped.
+func ReorderIdeas(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch idea",
-				"details": err.Error(),
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
 			},
 		})
 		return
 	}
 
-	// Verify user owns the board containing this idea
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     existingIdea.BoardID,
-		"user_id": userID,
+	// Parse request body
+	var req ReorderIdeasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
 	}
 
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusForbidden, gin.H{
+	ideaIDs := make([]string, 0, len(req.Ideas))
+	seen := make(map[string]bool, len(req.Ideas))
+	for _, update := range req.Ideas {
+		if !models.IsValidColumn(update.Column) {
+			c.JSON(http.StatusBadRequest, gin.H{
 				"error": gin.H{
-					"code":    "PERMISSION_DENIED",
-					"message": "You don't have permission to update this idea",
+					"code":    "INVALID_COLUMN",
+					"message": "Invalid column type: " + update.Column,
 				},
 			})
 			return
 		}
+		if seen[update.ID] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "DUPLICATE_IDEA",
+					"message": "Each idea may only appear once: " + update.ID,
+				},
+			})
+			return
+		}
+		seen[update.ID] = true
+		ideaIDs = append(ideaIDs, update.ID)
+	}
 
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Load the ideas being moved, which must all be on this board
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	cursor, err := ideasCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ideaIDs}, "board_id": board.ID})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
-				"message": "Failed to verify board ownership",
+				"message": "Failed to fetch ideas",
 				"details": err.Error(),
 			},
 		})
 		return
 	}
-
-	// Update idea position and column
-	updateDoc := bson.M{
-		"column":     req.Column,
-		"position":   req.Position,
-		"updated_at": time.Now().UTC(),
-	}
-
-	// If moving back to parking, remove in-progress status
-	if req.Column == string(models.ColumnParking) {
-		updateDoc["in_progress"] = false
-	}
-
-	filter := bson.M{"_id": ideaID}
-	result, err := ideasCollection.UpdateOne(ctx, filter, bson.M{"$set": updateDoc})
-	if err != nil {
+	var existingIdeas []models.Idea
+	if err := cursor.All(ctx, &existingIdeas); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
-				"message": "Failed to update idea position",
+				"message": "Failed to decode ideas",
 				"details": err.Error(),
 			},
 		})
 		return
 	}
 
-	if result.MatchedCount == 0 {
+	existingByID := make(map[string]models.Idea, len(existingIdeas))
+	for _, idea := range existingIdeas {
+		existingByID[idea.ID] = idea
+	}
+	missing := []string{}
+	for _, ideaID := range ideaIDs {
+		if _, found := existingByID[ideaID]; !found {
+			missing = append(missing, ideaID)
+		}
+	}
+	if len(missing) > 0 {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": gin.H{
 				"code":    "IDEA_NOT_FOUND",
-				"message": "Idea not found",
+				"message": "Some ideas were not found on this board",
+				"details": strings.Join(missing, ", "),
 			},
 		})
 		return
 	}
 
-	// Fetch and return updated idea
-	var updatedIdea models.Idea
-	err = ideasCollection.FindOne(ctx, filter).Decode(&updatedIdea)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch updated idea",
-				"details": err.Error(),
-			},
+	// One update per idea that actually moves
+	now := time.Now().UTC()
+	writes := []mongo.WriteModel{}
+	moved := []IdeaPositionUpdate{}
+	for _, update := range req.Ideas {
+		existing := existingByID[update.ID]
+		if existing.Column == update.Column && existing.Position == update.Position {
+			continue
+		}
+
+		updateDoc := bson.M{
+			"column":     update.Column,
+			"position":   update.Position,
+			"updated_at": now,
+			"updated_by": userID,
+		}
+		// If moving back to parking, remove in-progress status
+		if update.Column == string(models.ColumnParking) {
+			updateDoc["in_progress"] = false
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": update.ID, "board_id": board.ID}).
+			SetUpdate(models.BumpVersion(bson.M{"$set": updateDoc})))
+		moved = append(moved, update)
+	}
+
+	if len(writes) > 0 {
+		if _, err := ideasCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			log.Printf("[Handler] ReorderIdeas failed - BulkWrite error: %v, BoardID: %s, Ideas: %d, UserID: %s", err, board.ID, len(writes), userID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to update idea positions",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	responses := []IdeaResponse{}
+	columnsChanged := false
+	for _, update := range moved {
+		existing := existingByID[update.ID]
+		updated := existing
+		updated.Column = update.Column
+		updated.Position = update.Position
+		updated.UpdatedAt = now
+		if update.Column == string(models.ColumnParking) {
+			updated.InProgress = false
+		}
+		responses = append(responses, newIdeaResponse(updated))
+
+		// Broadcast like single moves; public views only learn that the idea changed
+		utils.BroadcastBoardEvent(ctx, board.ID, utils.EventIdeaUpdated, update.ID, map[string]interface{}{
+			"ideaId":   update.ID,
+			"column":   update.Column,
+			"position": update.Position,
+			"type":     "position_update",
 		})
-		return
+
+		// Reordering within a column is not worth an activity or audit entry per card
+		if existing.Column == update.Column {
+			continue
+		}
+		columnsChanged = true
+		go utils.RecordActivity(ctx, board.ID, update.ID, userID, models.ActivityIdeaMoved, map[string]interface{}{
+			"fromColumn":   existing.Column,
+			"toColumn":     update.Column,
+			"fromPosition": existing.Position,
+			"toPosition":   update.Position,
+		})
+		recordAudit(c, userID, models.AuditIdeaMoved, board.ID, models.AuditTargetIdea, update.ID, existing, updated)
+		announceIfReleased(ctx, &existing, &updated)
+		notifyStatusChange(ctx, &existing, &updated, userID)
+	}
+	if columnsChanged {
+		go utils.EvaluateColumnAlerts(ctx, board.ID)
 	}
 
-	// Return updated idea
-	response := IdeaResponse{
-		ID:             updatedIdea.ID,
-		BoardID:        updatedIdea.BoardID,
-		OneLiner:       updatedIdea.OneLiner,
-		Description:    updatedIdea.Description,
-		ValueStatement: updatedIdea.ValueStatement,
-		RiceScore:      updatedIdea.RiceScore,
-		Column:         updatedIdea.Column,
-		Position:       updatedIdea.Position,
-		InProgress:     updatedIdea.InProgress,
-		Status:         updatedIdea.Status,
-		ThumbsUp:       updatedIdea.ThumbsUp,
-		EmojiReactions: updatedIdea.EmojiReactions,
-		CreatedAt:      updatedIdea.CreatedAt,
-		UpdatedAt:      updatedIdea.UpdatedAt,
-	}
-
-	// Broadcast idea position update to WebSocket clients
-	positionUpdate := map[string]interface{}{
-		"ideaId":   ideaID,
-		"column":   req.Column,
-		"position": req.Position,
-		"type":     "position_update",
-	}
-	utils.BroadcastIdeaUpdate(updatedIdea.BoardID, ideaID, positionUpdate)
+	log.Printf("[Handler] ReorderIdeas success - BoardID: %s, Ideas: %d, Moved: %d, UserID: %s, IP: %s",
+		board.ID, len(req.Ideas), len(moved), userID, c.ClientIP())
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, gin.H{
+		"ideas": responses,
+		"count": len(responses),
+	})
 }
 
 // UpdateIdeaStatus handles PUT /api/ideas/:id/status
 func UpdateIdeaStatus(c *gin.Context) {
 	// Get user ID from auth middleware
-	userID, err := middleware.GetUserID(c)
-	if err != nil {
+	if _, err := middleware.GetUserID(c); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "INTERNAL_ERROR",
@@ -1031,195 +1540,21 @@ func UpdateIdeaStatus(c *gin.Context) {
 		})
 		return
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// First, get the idea to verify it exists and get board info
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	var existingIdea models.Idea
-	err = ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&existingIdea)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "IDEA_NOT_FOUND",
-					"message": "Idea not found",
-				},
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch idea",
-				"details": err.Error(),
-			},
-		})
-		return
-	}
-
-	// Verify user owns the board containing this idea
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     existingIdea.BoardID,
-		"user_id": userID,
-	}
-
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": gin.H{
-					"code":    "PERMISSION_DENIED",
-					"message": "You don't have permission to update this idea",
-				},
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to verify board ownership",
-				"details": err.Error(),
-			},
-		})
-		return
-	}
-
-	// Build update document
-	updateDoc := bson.M{
-		"updated_at": time.Now().UTC(),
-	}
-
-	// Handle in-progress status update
-	if req.InProgress != nil {
-		updateDoc["in_progress"] = *req.InProgress
-	}
-
-	// Handle status update with automatic column transitions
-	if req.Status != "" {
-		// Validate status
-		if !models.IsValidStatus(req.Status) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": gin.H{
-					"code":    "INVALID_STATUS",
-					"message": "Invalid status: " + req.Status,
-				},
-			})
-			return
-		}
-
-		updateDoc["status"] = req.Status
-
-		// Automatic column transitions based on status
-		switch req.Status {
-		case string(models.StatusDone):
-			// When marked as done, move to release column and remove in-progress
-			updateDoc["column"] = string(models.ColumnRelease)
-			updateDoc["in_progress"] = false
-		case string(models.StatusArchived):
-			// When archived, move to wont-do column and remove in-progress
-			updateDoc["column"] = string(models.ColumnWontDo)
-			updateDoc["in_progress"] = false
-		case string(models.StatusActive):
-			// When reactivated, move back to parking if currently in release or wont-do
-			if existingIdea.Column == string(models.ColumnRelease) || existingIdea.Column == string(models.ColumnWontDo) {
-				updateDoc["column"] = string(models.ColumnParking)
-			}
-		}
-	}
-
-	// Handle explicit column update (overrides automatic transitions)
-	if req.Column != "" {
-		// Validate column
-		if !models.IsValidColumn(req.Column) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": gin.H{
-					"code":    "INVALID_COLUMN",
-					"message": "Invalid column type: " + req.Column,
-				},
-			})
-			return
-		}
-		updateDoc["column"] = req.Column
-
-		// If moving back to parking, remove in-progress status
-		if req.Column == string(models.ColumnParking) {
-			updateDoc["in_progress"] = false
-		}
-	}
-
-	// Update idea in MongoDB
-	filter := bson.M{"_id": ideaID}
-	result, err := ideasCollection.UpdateOne(ctx, filter, bson.M{"$set": updateDoc})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to update idea status",
-				"details": err.Error(),
-			},
-		})
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
 		return
 	}
 
-	if result.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": gin.H{
-				"code":    "IDEA_NOT_FOUND",
-				"message": "Idea not found",
-			},
-		})
-		return
-	}
+	ctx := c.Request.Context()
 
-	// Fetch and return updated idea
-	var updatedIdea models.Idea
-	err = ideasCollection.FindOne(ctx, filter).Decode(&updatedIdea)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch updated idea",
-				"details": err.Error(),
-			},
-		})
+	updatedIdea, changeErr := changeIdeaStatus(ctx, requestActor(c), ideaID, req, version)
+	if changeErr != nil {
+		c.JSON(changeErr.status, changeErr.body())
 		return
 	}
 
-	// Return updated idea
-	response := IdeaResponse{
-		ID:             updatedIdea.ID,
-		BoardID:        updatedIdea.BoardID,
-		OneLiner:       updatedIdea.OneLiner,
-		Description:    updatedIdea.Description,
-		ValueStatement: updatedIdea.ValueStatement,
-		RiceScore:      updatedIdea.RiceScore,
-		Column:         updatedIdea.Column,
-		Position:       updatedIdea.Position,
-		InProgress:     updatedIdea.InProgress,
-		Status:         updatedIdea.Status,
-		ThumbsUp:       updatedIdea.ThumbsUp,
-		EmojiReactions: updatedIdea.EmojiReactions,
-		CreatedAt:      updatedIdea.CreatedAt,
-		UpdatedAt:      updatedIdea.UpdatedAt,
-	}
-
-	// Broadcast idea status update to WebSocket clients
-	statusUpdate := map[string]interface{}{
-		"ideaId":     ideaID,
-		"inProgress": updatedIdea.InProgress,
-		"status":     updatedIdea.Status,
-		"column":     updatedIdea.Column,
-		"type":       "status_update",
-	}
-	utils.BroadcastIdeaUpdate(updatedIdea.BoardID, ideaID, statusUpdate)
-
-	c.JSON(http.StatusOK, response)
+	setVersionHeader(c, updatedIdea.Version)
+	c.JSON(http.StatusOK, newIdeaResponse(*updatedIdea))
 }
 
 // GetPublicBoardIdeas handles GET /api/boards/:id/ideas/public
@@ -1236,15 +1571,27 @@ func GetPublicBoardIdeas(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Parse query parameters
+	var req IdeaPageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectInvalidView(c, req.View) {
+		return
+	}
 
-	// First, find the board by public link and ensure it's public
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{"public_link": publicLink, "is_public": true}
+	ctx := c.Request.Context()
 
-	var board models.Board
-	err := boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
+	// Read the board and its visible ideas from the public read model
+	view, err := utils.LoadPublicBoard(ctx, publicLink)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -1265,47 +1612,61 @@ func GetPublicBoardIdeas(c *gin.Context) {
 		})
 		return
 	}
+	board := view.Board.AsBoard()
 
-	// Query ideas for the board
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	ideasFilter := bson.M{"board_id": board.ID}
-
-	// Sort by column and position
-	opts := options.Find().SetSort(bson.D{
-		{Key: "column", Value: 1},
-		{Key: "position", Value: 1},
-	})
-
-	cursor, err := ideasCollection.Find(ctx, ideasFilter, opts)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch ideas",
-				"details": err.Error(),
-			},
-		})
+	if rejectBlockedVisitor(c, &board) {
 		return
 	}
-	defer cursor.Close(ctx)
 
-	// Decode results
-	var ideas []models.Idea
-	if err := cursor.All(ctx, &ideas); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to decode ideas",
-				"details": err.Error(),
-			},
-		})
+	// Widgets and bots revalidating an unchanged board get a 304 without the body
+	lastModified := view.LastModified
+	if board.UpdatedAt.After(lastModified) {
+		lastModified = board.UpdatedAt
+	}
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	if utils.RespondNotModified(c, lastModified, board.IPRules != nil, board.ID, strconv.Itoa(len(view.Ideas)), c.Request.URL.RawQuery) {
 		return
 	}
 
-	// Filter ideas based on visible columns
-	visibleColumns := make(map[string]bool)
-	for _, column := range board.VisibleColumns {
-		visibleColumns[column] = true
+	// Page through the read model's ideas; a cursor idea it does not hold, such as one moved to a
+	// hidden column, is resolved against the ideas collection
+	ideas, hasMore, found := publicIdeaPage(view.Ideas, req)
+	if !found {
+		ideasFilter := bson.M{"board_id": board.ID, "column": bson.M{"$in": board.VisibleColumns}}
+		var ok bool
+		ideas, hasMore, ok = findIdeaPage(ctx, c, ideasFilter, req)
+		if !ok {
+			return
+		}
+	}
+
+	boardInfo := gin.H{
+		"id":             board.ID,
+		"name":           board.Name,
+		"description":    board.Description,
+		"visibleColumns": board.VisibleColumns,
+		"visibleFields":  board.VisibleFields,
+		"showRiceScore":  board.ShowsRiceScorePublicly(),
+		"strictPrivacy":  board.StrictPrivacy,
+		"reactions":      board.AllowedReactions(),
+		"voteOptions":    voteOptionsOrEmpty(board.VoteOptions),
+	}
+
+	// The compact view only holds fields every visitor may see
+	if req.View == ideaViewCompact {
+		compact := []CompactIdeaResponse{}
+		for _, idea := range ideas {
+			compact = append(compact, newCompactIdeaResponse(idea))
+		}
+		response := gin.H{
+			"ideas": compact,
+			"count": len(compact),
+			"view":  ideaViewCompact,
+			"board": boardInfo,
+		}
+		addIdeaPage(response, req, ideas, hasMore)
+		c.JSON(http.StatusOK, response)
+		return
 	}
 
 	// Filter visible fields
@@ -1314,61 +1675,60 @@ func GetPublicBoardIdeas(c *gin.Context) {
 		visibleFields[field] = true
 	}
 
+	exposeRiceScore := board.ShowsRiceScorePublicly()
+
 	// Convert to public response format with field filtering
 	var responses []PublicIdeaResponse
 	for _, idea := range ideas {
-		// Only include ideas in visible columns
-		if !visibleColumns[idea.Column] {
-			continue
-		}
+		responses = append(responses, newPublicIdeaResponse(idea, visibleFields, exposeRiceScore))
+	}
 
-		response := PublicIdeaResponse{
-			ID:             idea.ID,
-			OneLiner:       idea.OneLiner, // Always visible
-			Column:         idea.Column,
-			Position:       idea.Position,
-			InProgress:     idea.InProgress,
-			ThumbsUp:       idea.ThumbsUp,
-			EmojiReactions: idea.EmojiReactions,
-			CreatedAt:      idea.CreatedAt,
-			UpdatedAt:      idea.UpdatedAt,
-		}
+	response := gin.H{
+		"ideas": responses,
+		"count": len(responses),
+		"board": boardInfo,
+	}
+	addIdeaPage(response, req, ideas, hasMore)
+	c.JSON(http.StatusOK, response)
+}
 
-		// Add optional fields based on visibility settings
-		if visibleFields[string(models.FieldDescription)] {
-			response.Description = idea.Description
+// publicIdeaPage pages through ideas already in board order the way findIdeaPage does, returning
+// false if the cursor idea is not among them
+func publicIdeaPage(ideas []models.Idea, req IdeaPageRequest) ([]models.Idea, bool, bool) {
+	limit := req.Limit
+	if req.AfterID != "" {
+		if limit == 0 {
+			limit = defaultIdeaPageLimit
+		}
+		index := -1
+		for i := range ideas {
+			if ideas[i].ID == req.AfterID {
+				index = i
+				break
+			}
 		}
-
-		if visibleFields[string(models.FieldValueStatement)] {
-			response.ValueStatement = idea.ValueStatement
+		if index < 0 {
+			return nil, false, false
 		}
-
-		// Note: RICE scores are never included in public view for privacy
-
-		responses = append(responses, response)
+		ideas = ideas[index+1:]
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"ideas": responses,
-		"count": len(responses),
-		"board": gin.H{
-			"id":             board.ID,
-			"name":           board.Name,
-			"description":    board.Description,
-			"visibleColumns": board.VisibleColumns,
-			"visibleFields":  board.VisibleFields,
-		},
-	})
+	hasMore := limit > 0 && len(ideas) > limit
+	if hasMore {
+		ideas = ideas[:limit]
+	}
+	return ideas, hasMore, true
 }
 
-// ThumbsUpRequest represents the request for thumbs up feedback
+// ThumbsUpRequest represents the optional request body for thumbs up feedback
 type ThumbsUpRequest struct {
-	// No body needed - just IP-based rate limiting
+	Note string `json:"note,omitempty" binding:"max=280"` // Optional context, only visible to the board owner
 }
 
 // EmojiReactionRequest represents the request for emoji feedback
 type EmojiReactionRequest struct {
 	Emoji string `json:"emoji" binding:"required,min=1,max=10"`
+	Note  string `json:"note,omitempty" binding:"max=280"` // Optional context, only visible to the board owner
 }
 
 // AddThumbsUp handles POST /api/ideas/:id/thumbsup (public endpoint)
@@ -1385,27 +1745,25 @@ func AddThumbsUp(c *gin.Context) {
 		return
 	}
 
-	// Get client IP for rate limiting
-	clientIP := c.ClientIP()
-
-	// Rate limiting: check if this IP has made a request recently
-	rateLimitKey := "thumbsup_" + ideaID + "_" + clientIP
-	rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_THUMBSUP_SECONDS", 10)
-	if isRateLimited(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second) {
-		c.JSON(http.StatusTooManyRequests, gin.H{
-			"error": gin.H{
-				"code":    "RATE_LIMITED",
-				"message": fmt.Sprintf("Please wait %d seconds before giving another thumbs up", rateLimitSeconds),
-			},
-		})
-		return
+	// Body is optional
+	var req ThumbsUpRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "VALIDATION_ERROR",
+					"message": "Invalid request data",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
 	// Find the idea and verify it exists
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	var idea models.Idea
 	err := ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
 	if err != nil {
@@ -1429,47 +1787,103 @@ func AddThumbsUp(c *gin.Context) {
 		return
 	}
 
-	// Increment thumbs up count
-	updateDoc := bson.M{
-		"$inc": bson.M{"thumbs_up": 1},
-		"$set": bson.M{"updated_at": time.Now().UTC()},
+	// Get client IP for rate limiting (coarse network prefix only on strict privacy boards)
+	board, ok := feedbackBoardSettings(ctx, c, idea.BoardID)
+	if !ok || rejectBlockedVisitor(c, &board) {
+		return
 	}
+	clientIP := utils.VisitorKey(c.ClientIP(), board.StrictPrivacy)
 
-	result, err := ideasCollection.UpdateOne(ctx, bson.M{"_id": ideaID}, updateDoc)
+	// Rate limiting under the board's feedback policy (server default: one per idea per window)
+	policy := board.FeedbackPolicy(getRateLimitSeconds("RATE_LIMIT_THUMBSUP_SECONDS", 10))
+	rateLimitKey := feedbackRateLimitKey("thumbsup", policy, idea.BoardID, ideaID, clientIP)
+	if limited, wait := feedbackRateLimited(rateLimitKey, policy); limited {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "RATE_LIMITED",
+				"message": fmt.Sprintf("Please wait %d seconds before giving another thumbs up", wait),
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	if rejectIfCaptchaFails(ctx, c, &board) {
+		return
+	}
+
+	// Record the visitor's vote first; the unique index rejects repeat thumbs up
+	vote, err := recordVote(ctx, c, &idea, board.StrictPrivacy, models.VoteThumbsUp, "", req.Note)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "ALREADY_VOTED",
+					"message": "You have already given this idea a thumbs up",
+				},
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
-				"message": "Failed to update thumbs up count",
+				"message": "Failed to record vote",
 				"details": err.Error(),
 			},
 		})
 		return
 	}
 
-	if result.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{
+	// Increment thumbs up count
+	updateDoc := bson.M{
+		"$inc": bson.M{"thumbs_up": 1},
+		"$set": bson.M{"updated_at": time.Now().UTC()},
+	}
+
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID}, updateDoc)
+	if err != nil {
+		discardVote(ctx, vote)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
-				"code":    "IDEA_NOT_FOUND",
-				"message": "Idea not found",
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update thumbs up count",
+				"details": err.Error(),
 			},
 		})
 		return
 	}
 
 	// Set rate limit
-	setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+	recordFeedbackHit(rateLimitKey, policy)
 
 	// Send notification to admin (async)
-	go sendFeedbackNotification(idea.BoardID, ideaID, "thumbsup", clientIP)
+	go sendFeedbackNotification(ctx, idea.BoardID, ideaID, "thumbsup", clientIP)
+	emitIdeaEvent(ctx, models.WebhookFeedbackReceived, &idea, gin.H{"feedback": gin.H{"type": "thumbsup"}})
+	go utils.EvaluateIdeaAlerts(ctx, idea.BoardID, ideaID)
 
 	// Broadcast feedback animation to WebSocket clients
-	utils.BroadcastFeedbackAnimation(idea.BoardID, ideaID, "thumbsup", "")
+	utils.BroadcastFeedbackAnimation(ctx, idea.BoardID, ideaID, "thumbsup", "")
+
+	// Record activity
+	go utils.RecordActivity(ctx, idea.BoardID, ideaID, "", models.ActivityFeedback, map[string]interface{}{
+		"feedbackType": "thumbsup",
+	})
 
 	// Return success response
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Thumbs up added successfully",
-		"thumbsUp":  idea.ThumbsUp + 1,
+		"thumbsUp":  updatedIdea.ThumbsUp,
 		"timestamp": time.Now().UTC(),
 	})
 }
@@ -1501,46 +1915,77 @@ func AddEmojiReaction(c *gin.Context) {
 		return
 	}
 
-	// Get client IP for rate limiting
-	clientIP := c.ClientIP()
+	ctx := c.Request.Context()
 
-	// Rate limiting: check if this IP has made an emoji request recently
-	rateLimitKey := "emoji_" + ideaID + "_" + clientIP
-	rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_EMOJI_SECONDS", 5)
-	if isRateLimited(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second) {
-		c.JSON(http.StatusTooManyRequests, gin.H{
+	// Find the idea and verify it exists
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	var idea models.Idea
+	err := ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
-				"code":    "RATE_LIMITED",
-				"message": fmt.Sprintf("Please wait %d seconds before adding another emoji reaction", rateLimitSeconds),
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch idea",
+				"details": err.Error(),
 			},
 		})
 		return
 	}
 
-	// Basic emoji validation (prevent abuse)
-	if !isValidEmoji(req.Emoji) {
+	// Only reactions from the board's configured set are accepted
+	board, ok := feedbackBoardSettings(ctx, c, idea.BoardID)
+	if !ok || rejectBlockedVisitor(c, &board) {
+		return
+	}
+	if !board.AllowsReaction(req.Emoji) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": gin.H{
 				"code":    "INVALID_EMOJI",
-				"message": "Invalid emoji provided",
+				"message": "This reaction is not enabled for this board",
 			},
 		})
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Get client IP for rate limiting (coarse network prefix only on strict privacy boards)
+	clientIP := utils.VisitorKey(c.ClientIP(), board.StrictPrivacy)
 
-	// Find the idea and verify it exists
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	var idea models.Idea
-	err := ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	// Rate limiting under the board's feedback policy (server default: one per idea per window)
+	policy := board.FeedbackPolicy(getRateLimitSeconds("RATE_LIMIT_EMOJI_SECONDS", 5))
+	rateLimitKey := feedbackRateLimitKey("emoji", policy, idea.BoardID, ideaID, clientIP)
+	if limited, wait := feedbackRateLimited(rateLimitKey, policy); limited {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "RATE_LIMITED",
+				"message": fmt.Sprintf("Please wait %d seconds before adding another emoji reaction", wait),
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	if rejectIfCaptchaFails(ctx, c, &board) {
+		return
+	}
+
+	// Record the visitor's reaction first; the unique index rejects the same emoji twice
+	vote, err := recordVote(ctx, c, &idea, board.StrictPrivacy, models.VoteEmoji, req.Emoji, req.Note)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{
 				"error": gin.H{
-					"code":    "IDEA_NOT_FOUND",
-					"message": "Idea not found",
+					"code":    "ALREADY_REACTED",
+					"message": "You have already added this reaction",
 				},
 			})
 			return
@@ -1549,7 +1994,7 @@ func AddEmojiReaction(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
-				"message": "Failed to fetch idea",
+				"message": "Failed to record reaction",
 				"details": err.Error(),
 			},
 		})
@@ -1587,6 +2032,7 @@ func AddEmojiReaction(c *gin.Context) {
 
 	result, err := ideasCollection.UpdateOne(ctx, bson.M{"_id": ideaID}, updateDoc)
 	if err != nil {
+		discardVote(ctx, vote)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "DATABASE_ERROR",
@@ -1598,6 +2044,7 @@ func AddEmojiReaction(c *gin.Context) {
 	}
 
 	if result.MatchedCount == 0 {
+		discardVote(ctx, vote)
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": gin.H{
 				"code":    "IDEA_NOT_FOUND",
@@ -1608,13 +2055,21 @@ func AddEmojiReaction(c *gin.Context) {
 	}
 
 	// Set rate limit
-	setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+	recordFeedbackHit(rateLimitKey, policy)
 
 	// Send notification to admin (async)
-	go sendFeedbackNotification(idea.BoardID, ideaID, "emoji:"+req.Emoji, clientIP)
+	go sendFeedbackNotification(ctx, idea.BoardID, ideaID, "emoji:"+req.Emoji, clientIP)
+	emitIdeaEvent(ctx, models.WebhookFeedbackReceived, &idea, gin.H{"feedback": gin.H{"type": "emoji", "emoji": req.Emoji}})
+	go utils.EvaluateIdeaAlerts(ctx, idea.BoardID, ideaID)
 
 	// Broadcast feedback animation to WebSocket clients
-	utils.BroadcastFeedbackAnimation(idea.BoardID, ideaID, "emoji", req.Emoji)
+	utils.BroadcastFeedbackAnimation(ctx, idea.BoardID, ideaID, "emoji", req.Emoji)
+
+	// Record activity
+	go utils.RecordActivity(ctx, idea.BoardID, ideaID, "", models.ActivityFeedback, map[string]interface{}{
+		"feedbackType": "emoji",
+		"emoji":        req.Emoji,
+	})
 
 	// Return success response
 	c.JSON(http.StatusOK, gin.H{
@@ -1624,26 +2079,15 @@ func AddEmojiReaction(c *gin.Context) {
 	})
 }
 
-// Simple in-memory rate limiting (for production, use Redis)
-var rateLimitStore = make(map[string]time.Time)
-
+// isRateLimited reports whether key acted within the last duration
 func isRateLimited(key string, duration time.Duration) bool {
-	if lastRequest, exists := rateLimitStore[key]; exists {
-		if time.Since(lastRequest) < duration {
-			return true
-		}
-	}
-	return false
+	limited, _ := utils.RateLimited(key, 1, duration)
+	return limited
 }
 
+// setRateLimit starts key's cooldown of duration
 func setRateLimit(key string, duration time.Duration) {
-	rateLimitStore[key] = time.Now()
-
-	// Clean up old entries (simple cleanup)
-	go func() {
-		time.Sleep(duration * 2)
-		delete(rateLimitStore, key)
-	}()
+	utils.RecordRateLimitHit(key, duration)
 }
 
 // getRateLimitSeconds gets rate limit seconds from environment variable with fallback
@@ -1656,54 +2100,87 @@ func getRateLimitSeconds(envVar string, fallback int) int {
 	return fallback
 }
 
-// isValidEmoji performs basic emoji validation
-func isValidEmoji(emoji string) bool {
-	// Basic validation - check length and common emoji patterns
-	if len(emoji) == 0 || len(emoji) > 10 {
+// feedbackRateLimitKey builds the key a feedback action is counted under for the policy's scope
+func feedbackRateLimitKey(kind string, policy models.FeedbackRateLimit, boardID, ideaID, visitorKey string) string {
+	if policy.Scope == string(models.RateLimitScopeBoard) {
+		return kind + "_board_" + boardID + "_" + visitorKey
+	}
+	return kind + "_" + ideaID + "_" + visitorKey
+}
+
+// feedbackRateLimited reports whether key has used up its burst, and how many seconds until it may act again
+func feedbackRateLimited(key string, policy models.FeedbackRateLimit) (bool, int) {
+	return utils.RateLimited(key, policy.Burst, time.Duration(policy.WindowSeconds)*time.Second)
+}
+
+// recordFeedbackHit counts a successful feedback action against key
+func recordFeedbackHit(key string, policy models.FeedbackRateLimit) {
+	utils.RecordRateLimitHit(key, time.Duration(policy.WindowSeconds)*time.Second)
+}
+
+// rejectIfFrozen responds with FROZEN and returns true when the board is in read-only freeze mode
+func rejectIfFrozen(c *gin.Context, board *models.Board) bool {
+	if !board.Frozen {
 		return false
 	}
 
-	// Allow common emoji characters (this is a simplified check)
-	// In production, you'd want a more comprehensive emoji validation
-	validEmojis := []string{
-		// Frontend emoji picker emojis
-		"🚀", "💡", "🎯", "🔥", "👍", "❤️", "😊", "🎉", "⭐", "💪",
-
-		// Additional common emojis
-		"😀", "😃", "😄", "😁", "😆", "😅", "😂", "🤣", "😇",
-		"🙂", "🙃", "😉", "😌", "😍", "🥰", "😘", "😗", "😙", "😚",
-		"😋", "😛", "😝", "😜", "🤪", "🤨", "🧐", "🤓", "😎", "🤩",
-		"🥳", "😏", "😒", "😞", "😔", "😟", "😕", "🙁", "☹️", "😣",
-		"😖", "😫", "😩", "🥺", "😢", "😭", "😤", "😠", "😡", "🤬",
-		"🤯", "😳", "🥵", "🥶", "😱", "😨", "😰", "😥", "😓", "🤗",
-		"🤔", "🤭", "🤫", "🤥", "😶", "😐", "😑", "😬", "🙄", "😯",
-		"😦", "😧", "😮", "😲", "🥱", "😴", "🤤", "😪", "😵", "🤐",
-		"🥴", "🤢", "🤮", "🤧", "😷", "🤒", "🤕", "🤑", "🤠", "😈",
-		"👎", "👌", "✌️", "🤞", "🤟", "🤘", "🤙", "👈", "👉",
-		"👆", "🖕", "👇", "☝️", "👋", "🤚", "🖐️", "✋", "🖖", "👏",
-		"🙌", "🤲", "🤝", "🙏", "✍️", "🦾", "🦿", "🦵", "🦶",
-		"🧡", "💛", "💚", "💙", "💜", "🖤", "🤍", "🤎", "💔",
-		"❣️", "💕", "💞", "💓", "💗", "💖", "💘", "💝", "💟", "☮️",
-		"✝️", "☪️", "🕉️", "☸️", "✡️", "🔯", "🕎", "☯️", "☦️", "🛐",
-		"🌟", "💫", "✨", "🌠", "🌙", "☀️", "🌤️", "⛅", "🌦️",
-		"🌧️", "⛈️", "🌩️", "🌨️", "❄️", "☃️", "⛄", "🌬️", "💨", "🌪️",
-		"💥", "⚡", "🌈", "☔", "💧", "🌊", "🎊", "🎈",
-		"🎁", "🎀", "🏆", "🥇", "🥈", "🥉", "🏅", "🎖️", "🏵️", "🎗️",
-	}
-
-	for _, validEmoji := range validEmojis {
-		if emoji == validEmoji {
-			return true
-		}
+	frozen := frozenBoardError()
+	c.JSON(frozen.status, frozen.body())
+	return true
+}
+
+// frozenBoardError is the FROZEN error of a change to an idea on a frozen board
+func frozenBoardError() *ideaChangeError {
+	return &ideaChangeError{http.StatusLocked, "FROZEN", "This board is frozen. Unfreeze it to change ideas.", ""}
+}
+
+// feedbackBoardSettings loads the board settings that govern public feedback: privacy, CAPTCHA,
+// rate limits and IP rules. Feedback is refused while they cannot be loaded, so a database error
+// never lifts the board's abuse controls; the error response is written and false returned.
+func feedbackBoardSettings(ctx context.Context, c *gin.Context, boardID string) (models.Board, bool) {
+	var board models.Board
+	opts := options.FindOne().SetProjection(bson.M{"strict_privacy": 1, "reactions": 1, "vote_options": 1, "captcha_provider": 1, "feedback_rate_limit": 1, "ip_rules": 1})
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}, opts).Decode(&board)
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "BOARD_NOT_FOUND",
+				"message": "Board not found",
+			},
+		})
+		return board, false
+	}
+	if err != nil {
+		log.Printf("[Handler] Feedback refused - Failed to load board settings: %v, BoardID: %s, IP: %s", err, boardID, c.ClientIP())
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "BOARD_SETTINGS_UNAVAILABLE",
+				"message": "Feedback is unavailable right now; try again shortly",
+				"details": err.Error(),
+			},
+		})
+		return board, false
 	}
+	return board, true
+}
 
-	return false
+// updatedFields returns the names of the fields set by an update document, excluding the timestamp and editor
+func updatedFields(updateDoc bson.M) []string {
+	fields := []string{}
+	for field := range updateDoc {
+		if field == "updated_at" || field == "updated_by" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
 }
 
 // sendFeedbackNotification sends notifications to admin about feedback
-func sendFeedbackNotification(boardID, ideaID, feedbackType, clientIP string) {
+func sendFeedbackNotification(ctx context.Context, boardID, ideaID, feedbackType, clientIP string) {
 	// Use the notification service to send multi-channel notifications
-	utils.SendFeedbackNotification(boardID, ideaID, feedbackType, clientIP)
+	utils.SendFeedbackNotification(ctx, boardID, ideaID, feedbackType, clientIP)
 }
 
 // GetReleasedIdeasRequest represents query parameters for released ideas
@@ -1713,6 +2190,7 @@ type GetReleasedIdeasRequest struct {
 	SortDir  string `form:"sortDir"` // asc, desc
 	Page     int    `form:"page"`
 	PageSize int    `form:"pageSize"`
+	GroupBy  string `form:"groupBy"` // release
 }
 
 // GetReleasedIdeas handles GET /api/boards/:id/release
@@ -1742,6 +2220,16 @@ func GetReleasedIdeas(c *gin.Context) {
 		return
 	}
 
+	if req.GroupBy != "" && req.GroupBy != "release" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_GROUP_BY",
+				"message": "groupBy must be release",
+			},
+		})
+		return
+	}
+
 	// Set defaults
 	if req.SortBy == "" {
 		req.SortBy = "created_at"
@@ -1756,11 +2244,11 @@ func GetReleasedIdeas(c *gin.Context) {
 		req.PageSize = 50
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
 	// Check if this is a public request or admin request
-	isPublic := c.GetHeader("X-Public-Access") == "true"
+	isPublic := c.GetBool("publicAccess")
+	exposeRiceScore := false
 
 	if !isPublic {
 		// For admin requests, verify board ownership
@@ -1775,15 +2263,8 @@ func GetReleasedIdeas(c *gin.Context) {
 			return
 		}
 
-		// Verify board exists and belongs to user
-		boardsCollection := models.GetCollection(models.BoardsCollection)
-		boardFilter := bson.M{
-			"_id":     boardID,
-			"user_id": userID,
-		}
-
-		var board models.Board
-		err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
+		// Verify board exists and the user may view it
+		_, err = middleware.FindAccessibleBoard(ctx, c, boardID, userID, models.RoleViewer)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
 				c.JSON(http.StatusNotFound, gin.H{
@@ -1806,7 +2287,7 @@ func GetReleasedIdeas(c *gin.Context) {
 		}
 	} else {
 		// For public requests, verify board exists by public link and is public
-		boardsCollection := models.GetCollection(models.BoardsCollection)
+		boardsCollection := models.GetCollection(ctx, models.BoardsCollection)
 		boardFilter := bson.M{"public_link": boardID, "is_public": true}
 
 		var board models.Board
@@ -1832,8 +2313,14 @@ func GetReleasedIdeas(c *gin.Context) {
 			return
 		}
 
+		if rejectBlockedVisitor(c, &board) {
+			return
+		}
+
 		// Use the actual board ID for querying ideas
 		boardID = board.ID
+		exposeRiceScore = board.ShowsRiceScorePublicly()
+		utils.SetPrivacyHeaders(c, board.StrictPrivacy)
 	}
 
 	// Build filter for released ideas
@@ -1843,11 +2330,18 @@ func GetReleasedIdeas(c *gin.Context) {
 	}
 
 	// Add search filter if provided
+	textSearch := false
 	if req.Search != "" {
-		filter["$or"] = []bson.M{
-			{"one_liner": bson.M{"$regex": req.Search, "$options": "i"}},
-			{"description": bson.M{"$regex": req.Search, "$options": "i"}},
-			{"value_statement": bson.M{"$regex": req.Search, "$options": "i"}},
+		var err error
+		if filter, textSearch, err = ideaSearchFilter(ctx, filter, req.Search); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to search released ideas",
+					"details": err.Error(),
+				},
+			})
+			return
 		}
 	}
 
@@ -1857,25 +2351,62 @@ func GetReleasedIdeas(c *gin.Context) {
 		sortDir = -1
 	}
 
-	var sortField string
+	var sortOrder bson.D
 	switch req.SortBy {
 	case "name":
-		sortField = "one_liner"
+		sortOrder = bson.D{{Key: "one_liner", Value: sortDir}}
 	case "thumbs_up":
-		sortField = "thumbs_up"
+		sortOrder = bson.D{{Key: "thumbs_up", Value: sortDir}}
 	case "rice_score":
-		sortField = "rice_score.reach" // Sort by reach as primary RICE component
+		sortOrder = bson.D{{Key: "rice_score.reach", Value: sortDir}} // Sort by reach as primary RICE component
+	case "created_at":
+		sortOrder = bson.D{{Key: "created_at", Value: sortDir}}
 	default:
-		sortField = "created_at"
+		// Best matches first when searching, else by creation date
+		sortOrder = bson.D{{Key: "created_at", Value: sortDir}}
+		if textSearch {
+			sortOrder = bson.D{textScoreSort, {Key: "created_at", Value: -1}}
+		}
+	}
+
+	// Convert to the public (filtered) or full admin response format
+	toResponse := func(idea models.Idea) interface{} {
+		if !isPublic {
+			return newIdeaResponse(idea)
+		}
+		publicResponse := PublicIdeaResponse{
+			ID:             idea.ID,
+			OneLiner:       idea.OneLiner,
+			Description:    idea.Description,
+			ValueStatement: idea.ValueStatement,
+			Column:         idea.Column,
+			Position:       idea.Position,
+			InProgress:     idea.InProgress,
+			ThumbsUp:       idea.ThumbsUp,
+			EmojiReactions: idea.EmojiReactions,
+			Votes:          idea.Votes,
+			Poll:           idea.Poll,
+			CreatedAt:      idea.CreatedAt,
+			UpdatedAt:      idea.UpdatedAt,
+		}
+		if exposeRiceScore {
+			publicResponse.withPublicRiceScore(idea.RiceScore)
+		}
+		return publicResponse
+	}
+
+	if req.GroupBy == "release" {
+		respondReleasedIdeasByRelease(ctx, c, boardID, filter, sortOrder, toResponse)
+		return
 	}
 
 	opts := options.Find().
-		SetSort(bson.D{{Key: sortField, Value: sortDir}}).
+		SetSort(sortOrder).
 		SetSkip(int64((req.Page - 1) * req.PageSize)).
 		SetLimit(int64(req.PageSize))
 
 	// Query released ideas
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	cursor, err := ideasCollection.Find(ctx, filter, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -1918,40 +2449,7 @@ func GetReleasedIdeas(c *gin.Context) {
 	// Convert to response format
 	var responses []interface{}
 	for _, idea := range ideas {
-		if isPublic {
-			// Return public response format (filtered)
-			responses = append(responses, PublicIdeaResponse{
-				ID:             idea.ID,
-				OneLiner:       idea.OneLiner,
-				Description:    idea.Description,
-				ValueStatement: idea.ValueStatement,
-				Column:         idea.Column,
-				Position:       idea.Position,
-				InProgress:     idea.InProgress,
-				ThumbsUp:       idea.ThumbsUp,
-				EmojiReactions: idea.EmojiReactions,
-				CreatedAt:      idea.CreatedAt,
-				UpdatedAt:      idea.UpdatedAt,
-			})
-		} else {
-			// Return full admin response format
-			responses = append(responses, IdeaResponse{
-				ID:             idea.ID,
-				BoardID:        idea.BoardID,
-				OneLiner:       idea.OneLiner,
-				Description:    idea.Description,
-				ValueStatement: idea.ValueStatement,
-				RiceScore:      idea.RiceScore,
-				Column:         idea.Column,
-				Position:       idea.Position,
-				InProgress:     idea.InProgress,
-				Status:         idea.Status,
-				ThumbsUp:       idea.ThumbsUp,
-				EmojiReactions: idea.EmojiReactions,
-				CreatedAt:      idea.CreatedAt,
-				UpdatedAt:      idea.UpdatedAt,
-			})
-		}
+		responses = append(responses, toResponse(idea))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -1964,6 +2462,39 @@ func GetReleasedIdeas(c *gin.Context) {
 	})
 }
 
+// textScoreSort orders text search results by relevance, best first
+var textScoreSort = bson.E{Key: "score", Value: bson.M{"$meta": "textScore"}}
+
+// ideaSearchFilter narrows filter to ideas matching a search query. Whole words are matched with the
+// text index; when none match, it falls back to case-insensitive substring matching of the one-liner,
+// description and value statement, which finds partial words but cannot use an index. It reports
+// whether the text index was used, so results can be sorted by textScoreSort.
+func ideaSearchFilter(ctx context.Context, filter bson.M, query string) (bson.M, bool, error) {
+	textFilter := bson.M{"$text": bson.M{"$search": query}}
+	for key, value := range filter {
+		textFilter[key] = value
+	}
+
+	matches, err := models.GetCollection(ctx, models.IdeasCollection).CountDocuments(ctx, textFilter, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, false, err
+	}
+	if matches > 0 {
+		return textFilter, true, nil
+	}
+
+	pattern := regexp.QuoteMeta(query)
+	regexFilter := bson.M{"$or": []bson.M{
+		{"one_liner": bson.M{"$regex": pattern, "$options": "i"}},
+		{"description": bson.M{"$regex": pattern, "$options": "i"}},
+		{"value_statement": bson.M{"$regex": pattern, "$options": "i"}},
+	}}
+	for key, value := range filter {
+		regexFilter[key] = value
+	}
+	return regexFilter, false, nil
+}
+
 // SearchBoardIdeasRequest represents the request parameters for searching ideas
 type SearchBoardIdeasRequest struct {
 	Query      string `form:"q"`
@@ -1976,18 +2507,6 @@ type SearchBoardIdeasRequest struct {
 
 // SearchBoardIdeas handles GET /api/boards/:id/search
 func SearchBoardIdeas(c *gin.Context) {
-	// Get user ID from auth middleware
-	userID, err := middleware.GetUserID(c)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "INTERNAL_ERROR",
-				"message": "Failed to get user ID",
-			},
-		})
-		return
-	}
-
 	// Get board ID from URL parameter
 	boardID := c.Param("id")
 	if boardID == "" {
@@ -2013,38 +2532,7 @@ func SearchBoardIdeas(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Verify board exists and belongs to user
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardFilter := bson.M{
-		"_id":     boardID,
-		"user_id": userID,
-	}
-
-	var board models.Board
-	err = boardsCollection.FindOne(ctx, boardFilter).Decode(&board)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": gin.H{
-					"code":    "BOARD_NOT_FOUND",
-					"message": "Board not found or you don't have permission to search ideas",
-				},
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": gin.H{
-				"code":    "DATABASE_ERROR",
-				"message": "Failed to verify board",
-				"details": err.Error(),
-			},
-		})
-		return
-	}
+	ctx := c.Request.Context()
 
 	// Build aggregation pipeline
 	pipeline := []bson.M{}
@@ -2070,12 +2558,18 @@ func SearchBoardIdeas(c *gin.Context) {
 	}
 
 	// Add text search if query is provided
+	textSearch := false
 	if req.Query != "" {
-		// Use MongoDB regex search across multiple fields
-		matchStage["$or"] = []bson.M{
-			{"one_liner": bson.M{"$regex": req.Query, "$options": "i"}},
-			{"description": bson.M{"$regex": req.Query, "$options": "i"}},
-			{"value_statement": bson.M{"$regex": req.Query, "$options": "i"}},
+		var err error
+		if matchStage, textSearch, err = ideaSearchFilter(ctx, matchStage, req.Query); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to search ideas",
+					"details": err.Error(),
+				},
+			})
+			return
 		}
 	}
 
@@ -2106,33 +2600,34 @@ func SearchBoardIdeas(c *gin.Context) {
 	})
 
 	// Add sorting
-	sortStage := bson.M{}
 	sortDirection := 1 // ascending by default
 	if req.SortDir == "desc" {
 		sortDirection = -1
 	}
 
+	var sortStage bson.D
 	switch req.SortBy {
 	case "name":
-		sortStage["one_liner"] = sortDirection
+		sortStage = bson.D{{Key: "one_liner", Value: sortDirection}}
 	case "rice":
-		sortStage["calculated_rice_score"] = sortDirection
+		sortStage = bson.D{{Key: "calculated_rice_score", Value: sortDirection}}
 	case "status":
 		// Sort by in_progress first, then by status
-		sortStage["in_progress"] = -1 // in-progress items first
-		sortStage["status"] = sortDirection
+		sortStage = bson.D{{Key: "in_progress", Value: -1}, {Key: "status", Value: sortDirection}} // in-progress items first
 	case "created":
-		sortStage["created_at"] = sortDirection
+		sortStage = bson.D{{Key: "created_at", Value: sortDirection}}
 	default:
-		// Default sort: column, then position
-		sortStage["column"] = 1
-		sortStage["position"] = 1
+		// Default sort: best matches first when searching, then column and position
+		if textSearch {
+			sortStage = append(sortStage, textScoreSort)
+		}
+		sortStage = append(sortStage, bson.E{Key: "column", Value: 1}, bson.E{Key: "position", Value: 1})
 	}
 
 	pipeline = append(pipeline, bson.M{"$sort": sortStage})
 
 	// Execute aggregation
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	cursor, err := ideasCollection.Aggregate(ctx, pipeline)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -2162,22 +2657,7 @@ func SearchBoardIdeas(c *gin.Context) {
 	// Convert to response format
 	var responses []IdeaResponse
 	for _, idea := range ideas {
-		responses = append(responses, IdeaResponse{
-			ID:             idea.ID,
-			BoardID:        idea.BoardID,
-			OneLiner:       idea.OneLiner,
-			Description:    idea.Description,
-			ValueStatement: idea.ValueStatement,
-			RiceScore:      idea.RiceScore,
-			Column:         idea.Column,
-			Position:       idea.Position,
-			InProgress:     idea.InProgress,
-			Status:         idea.Status,
-			ThumbsUp:       idea.ThumbsUp,
-			EmojiReactions: idea.EmojiReactions,
-			CreatedAt:      idea.CreatedAt,
-			UpdatedAt:      idea.UpdatedAt,
-		})
+		responses = append(responses, newIdeaResponse(idea))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
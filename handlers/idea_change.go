@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ideaChangeError is a refused or failed idea change. REST handlers write it as their error response
+// and WebSocket operations return it as their result.
+type ideaChangeError struct {
+	status  int
+	code    string
+	message string
+	details string
+}
+
+// body renders the error like the API's error responses
+func (e *ideaChangeError) body() gin.H {
+	body := gin.H{
+		"code":    e.code,
+		"message": e.message,
+	}
+	if e.details != "" {
+		body["details"] = e.details
+	}
+	return gin.H{"error": body}
+}
+
+// databaseError is an ideaChangeError for a failed database call
+func databaseError(message string, err error) *ideaChangeError {
+	return &ideaChangeError{http.StatusInternalServerError, "DATABASE_ERROR", message, err.Error()}
+}
+
+// loadEditableIdea loads an idea the actor may edit, on a board that is not frozen
+func loadEditableIdea(ctx context.Context, who actor, ideaID string) (*models.Idea, *ideaChangeError) {
+	var idea models.Idea
+	err := models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &ideaChangeError{http.StatusNotFound, "IDEA_NOT_FOUND", "Idea not found", ""}
+		}
+		return nil, databaseError("Failed to fetch idea", err)
+	}
+
+	// Verify the actor may edit the board containing this idea
+	board, err := models.FindBoardByID(ctx, idea.BoardID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, databaseError("Failed to verify board ownership", err)
+	}
+	if err != nil || !middleware.OrganizationBoardRole(board, who.userID, who.orgID, who.orgRole).Allows(models.RoleEditor) {
+		return nil, &ideaChangeError{http.StatusForbidden, "PERMISSION_DENIED", "You don't have permission to update this idea", ""}
+	}
+
+	// Frozen boards are read-only for ideas
+	if board.Frozen {
+		return nil, frozenBoardError()
+	}
+	return &idea, nil
+}
+
+// moveIdea moves an idea to a 1-based position in a column, renumbering the columns it leaves and
+// joins in the same transaction, for PUT /api/ideas/:id/position and the move_idea WebSocket operation
+func moveIdea(ctx context.Context, who actor, ideaID string, req UpdateIdeaPositionRequest) (*models.Idea, *ideaChangeError) {
+	// Validate column
+	if !models.IsValidColumn(req.Column) {
+		return nil, &ideaChangeError{http.StatusBadRequest, "INVALID_COLUMN", "Invalid column type: " + req.Column, ""}
+	}
+
+	existingIdea, changeErr := loadEditableIdea(ctx, who, ideaID)
+	if changeErr != nil {
+		return nil, changeErr
+	}
+
+	// Move the idea and renumber the columns it leaves and joins together
+	updateDoc := bson.M{
+		"updated_at": time.Now().UTC(),
+		"updated_by": who.userID,
+	}
+
+	// If moving back to parking, remove in-progress status
+	if req.Column == string(models.ColumnParking) {
+		updateDoc["in_progress"] = false
+	}
+
+	updatedIdea, err := models.MoveIdea(ctx, bson.M{"_id": ideaID}, req.Column, req.Position, updateDoc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &ideaChangeError{http.StatusNotFound, "IDEA_NOT_FOUND", "Idea not found", ""}
+		}
+		return nil, databaseError("Failed to update idea position", err)
+	}
+
+	// Broadcast idea position update; public views only learn that the idea changed
+	positionUpdate := map[string]interface{}{
+		"ideaId":   ideaID,
+		"column":   req.Column,
+		"position": updatedIdea.Position,
+		"type":     "position_update",
+	}
+	utils.BroadcastBoardEvent(ctx, updatedIdea.BoardID, utils.EventIdeaUpdated, ideaID, positionUpdate)
+
+	// Record activity
+	go utils.RecordActivity(ctx, updatedIdea.BoardID, ideaID, who.userID, models.ActivityIdeaMoved, map[string]interface{}{
+		"fromColumn":   existingIdea.Column,
+		"toColumn":     req.Column,
+		"fromPosition": existingIdea.Position,
+		"toPosition":   updatedIdea.Position,
+	})
+	recordActorAudit(ctx, who, models.AuditIdeaMoved, updatedIdea.BoardID, models.AuditTargetIdea, ideaID, existingIdea, updatedIdea)
+	announceIfReleased(ctx, existingIdea, updatedIdea)
+	notifyStatusChange(ctx, existingIdea, updatedIdea, who.userID)
+	checkColumnAlerts(ctx, existingIdea, updatedIdea)
+
+	return updatedIdea, nil
+}
+
+// changeIdeaStatus sets an idea's status, in-progress flag or column, moving it to the column its new
+// status belongs in, for PUT /api/ideas/:id/status and the update_status WebSocket operation. With a
+// version, the change is refused if the idea was edited since.
+func changeIdeaStatus(ctx context.Context, who actor, ideaID string, req UpdateIdeaStatusRequest, version *int64) (*models.Idea, *ideaChangeError) {
+	existingIdea, changeErr := loadEditableIdea(ctx, who, ideaID)
+	if changeErr != nil {
+		return nil, changeErr
+	}
+
+	// Refuse to overwrite an edit the client has not seen
+	if version != nil && *version != existingIdea.Version {
+		return nil, versionConflictError(*version, existingIdea.Version)
+	}
+
+	// Build update document
+	updateDoc := bson.M{
+		"updated_at": time.Now().UTC(),
+		"updated_by": who.userID,
+	}
+
+	// Handle in-progress status update
+	if req.InProgress != nil {
+		updateDoc["in_progress"] = *req.InProgress
+	}
+
+	// Handle status update with automatic column transitions
+	if req.Status != "" {
+		// Validate status
+		if !models.IsValidStatus(req.Status) {
+			return nil, &ideaChangeError{http.StatusBadRequest, "INVALID_STATUS", "Invalid status: " + req.Status, ""}
+		}
+
+		updateDoc["status"] = req.Status
+
+		// Automatic column transitions based on status
+		switch req.Status {
+		case string(models.StatusDone):
+			// When marked as done, move to release column and remove in-progress
+			updateDoc["column"] = string(models.ColumnRelease)
+			updateDoc["in_progress"] = false
+		case string(models.StatusArchived):
+			// When archived, move to wont-do column and remove in-progress
+			updateDoc["column"] = string(models.ColumnWontDo)
+			updateDoc["in_progress"] = false
+		case string(models.StatusActive):
+			// When reactivated, move back to parking if currently in release or wont-do
+			if existingIdea.Column == string(models.ColumnRelease) || existingIdea.Column == string(models.ColumnWontDo) {
+				updateDoc["column"] = string(models.ColumnParking)
+			}
+		}
+	}
+
+	// Handle explicit column update (overrides automatic transitions)
+	if req.Column != "" {
+		// Validate column
+		if !models.IsValidColumn(req.Column) {
+			return nil, &ideaChangeError{http.StatusBadRequest, "INVALID_COLUMN", "Invalid column type: " + req.Column, ""}
+		}
+		updateDoc["column"] = req.Column
+
+		// If moving back to parking, remove in-progress status
+		if req.Column == string(models.ColumnParking) {
+			updateDoc["in_progress"] = false
+		}
+	}
+
+	// Update idea in MongoDB and return the updated document, unless it was edited since it was loaded.
+	// Ideas changing column are moved to the end of their new column.
+	filter := bson.M{"_id": ideaID}
+	if version != nil {
+		filter["version"] = models.VersionCondition(*version)
+	}
+	updatedIdea, err := saveIdeaUpdate(ctx, filter, existingIdea, updateDoc)
+	if err == mongo.ErrNoDocuments && version != nil {
+		if current, found := currentVersion(ctx, models.IdeasCollection, ideaID); found {
+			return nil, versionConflictError(*version, current)
+		}
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, &ideaChangeError{http.StatusNotFound, "IDEA_NOT_FOUND", "Idea not found", ""}
+		}
+		return nil, databaseError("Failed to update idea status", err)
+	}
+
+	// Broadcast idea status update; public views only learn that the idea changed
+	statusUpdate := map[string]interface{}{
+		"ideaId":     ideaID,
+		"inProgress": updatedIdea.InProgress,
+		"status":     updatedIdea.Status,
+		"column":     updatedIdea.Column,
+		"type":       "status_update",
+	}
+	utils.BroadcastBoardEvent(ctx, updatedIdea.BoardID, utils.EventIdeaUpdated, ideaID, statusUpdate)
+
+	// Record activity (column changes are recorded as moves)
+	statusActivityType := models.ActivityIdeaUpdated
+	if updatedIdea.Column != existingIdea.Column {
+		statusActivityType = models.ActivityIdeaMoved
+	}
+	go utils.RecordActivity(ctx, updatedIdea.BoardID, ideaID, who.userID, statusActivityType, map[string]interface{}{
+		"fromColumn": existingIdea.Column,
+		"toColumn":   updatedIdea.Column,
+		"status":     updatedIdea.Status,
+		"inProgress": updatedIdea.InProgress,
+	})
+	recordActorAudit(ctx, who, models.AuditIdeaStatusChanged, updatedIdea.BoardID, models.AuditTargetIdea, ideaID, existingIdea, updatedIdea)
+	announceIfReleased(ctx, existingIdea, updatedIdea)
+	notifyStatusChange(ctx, existingIdea, updatedIdea, who.userID)
+	checkColumnAlerts(ctx, existingIdea, updatedIdea)
+
+	return updatedIdea, nil
+}
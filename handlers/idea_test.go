@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// createTestBoard stores a board owned by userID with ideas in the given columns, numbered in order,
+// and returns the board and idea IDs
+func createTestBoard(t *testing.T, userID string, columns ...string) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	board := models.Board{
+		ID:         utils.GenerateFullUUID(),
+		Name:       "Test board",
+		PublicLink: utils.GenerateFullUUID(),
+		UserID:     userID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	_, err := models.GetCollection(ctx, models.BoardsCollection).InsertOne(ctx, board)
+	require.NoError(t, err)
+
+	positions := map[string]int{}
+	ideaIDs := []string{}
+	for _, column := range columns {
+		positions[column]++
+		idea := models.Idea{
+			ID:             utils.GenerateIdeaID(),
+			BoardID:        board.ID,
+			OneLiner:       "Idea",
+			RiceScore:      models.RICEScore{Reach: 5, Impact: 5, Confidence: 5, Effort: 3},
+			Column:         column,
+			Position:       positions[column],
+			Status:         string(models.StatusActive),
+			EmojiReactions: []models.EmojiReaction{},
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		_, err := models.GetCollection(ctx, models.IdeasCollection).InsertOne(ctx, idea)
+		require.NoError(t, err)
+		ideaIDs = append(ideaIDs, idea.ID)
+	}
+	return board.ID, ideaIDs
+}
+
+// columnOrder returns the positions of a board column's ideas, by idea ID in position order
+func columnOrder(t *testing.T, boardID, column string) ([]string, []int) {
+	t.Helper()
+	ctx := context.Background()
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, bson.M{"board_id": boardID, "column": column},
+		options.Find().SetSort(bson.D{{Key: "position", Value: 1}}))
+	require.NoError(t, err)
+	var ideas []models.Idea
+	require.NoError(t, cursor.All(ctx, &ideas))
+
+	ids := []string{}
+	positions := []int{}
+	for _, idea := range ideas {
+		ids = append(ids, idea.ID)
+		positions = append(positions, idea.Position)
+	}
+	return ids, positions
+}
+
+// serveAs sends a request to handler as the signed-in user
+func serveAs(userID, method, path, route string, handler gin.HandlerFunc, body string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		c.Set("userID", userID)
+	}, handler)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdateIdeaColumnRenumbersColumns(t *testing.T) {
+	connectTestDatabase(t)
+	userID := "user_" + utils.GenerateShortUUID()
+
+	t.Run("Column Change", func(t *testing.T) {
+		boardID, ideaIDs := createTestBoard(t, userID, "now", "now", "now", "next", "next")
+
+		w := serveAs(userID, http.MethodPut, "/api/ideas/"+ideaIDs[1], "/api/ideas/:id", UpdateIdea, `{"column": "next"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		ids, positions := columnOrder(t, boardID, "now")
+		assert.Equal(t, []string{ideaIDs[0], ideaIDs[2]}, ids)
+		assert.Equal(t, []int{1, 2}, positions)
+
+		ids, positions = columnOrder(t, boardID, "next")
+		assert.Equal(t, []string{ideaIDs[3], ideaIDs[4], ideaIDs[1]}, ids)
+		assert.Equal(t, []int{1, 2, 3}, positions)
+	})
+
+	t.Run("Status Change", func(t *testing.T) {
+		boardID, ideaIDs := createTestBoard(t, userID, "later", "later", "release")
+
+		w := serveAs(userID, http.MethodPut, "/api/ideas/"+ideaIDs[0], "/api/ideas/:id", UpdateIdea, `{"status": "done"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		ids, positions := columnOrder(t, boardID, "later")
+		assert.Equal(t, []string{ideaIDs[1]}, ids)
+		assert.Equal(t, []int{1}, positions)
+
+		ids, positions = columnOrder(t, boardID, "release")
+		assert.Equal(t, []string{ideaIDs[2], ideaIDs[0]}, ids)
+		assert.Equal(t, []int{1, 2}, positions)
+	})
+
+	t.Run("Status Endpoint Into Empty Column", func(t *testing.T) {
+		boardID, ideaIDs := createTestBoard(t, userID, "now", "now")
+
+		w := serveAs(userID, http.MethodPut, "/api/ideas/"+ideaIDs[0]+"/status", "/api/ideas/:id/status", UpdateIdeaStatus, `{"status": "archived"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		ids, positions := columnOrder(t, boardID, "now")
+		assert.Equal(t, []string{ideaIDs[1]}, ids)
+		assert.Equal(t, []int{1}, positions)
+
+		ids, positions = columnOrder(t, boardID, "wont-do")
+		assert.Equal(t, []string{ideaIDs[0]}, ids)
+		assert.Equal(t, []int{1}, positions)
+	})
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// StartImpersonationRequest represents the request payload for impersonating a user
+type StartImpersonationRequest struct {
+	UserID          string `json:"userId" binding:"required"`
+	Reason          string `json:"reason" binding:"required,min=10,max=500"` // e.g. the support ticket being debugged
+	DurationMinutes int    `json:"durationMinutes"`                          // Defaults to 30, at most 60
+}
+
+// AdminStartImpersonation handles POST /api/admin/impersonations. The returned ID is sent in the
+// X-Impersonation-ID header, next to the admin's own session token, to act as the user.
+func AdminStartImpersonation(c *gin.Context) {
+	// Get user ID from auth middleware
+	adminID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.DurationMinutes == 0 {
+		req.DurationMinutes = models.DefaultImpersonationMinutes
+	}
+	if req.DurationMinutes < 1 || req.DurationMinutes > models.MaxImpersonationMinutes {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": "durationMinutes must be between 1 and 60",
+			},
+		})
+		return
+	}
+
+	// Impersonation is for debugging customer boards, not for borrowing admin rights
+	if req.UserID == adminID || middleware.IsAdmin(req.UserID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_IMPERSONATION",
+				"message": "Admins cannot impersonate themselves or other admins",
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	impersonation := models.Impersonation{
+		ID:        utils.GenerateFullUUID(),
+		AdminID:   adminID,
+		UserID:    req.UserID,
+		Reason:    strings.TrimSpace(req.Reason),
+		IP:        c.ClientIP(),
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(req.DurationMinutes) * time.Minute),
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := models.GetCollection(ctx, models.ImpersonationsCollection).InsertOne(ctx, impersonation); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to start impersonation",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AdminStartImpersonation success - ImpersonationID: %s, AdminID: %s, UserID: %s, ExpiresAt: %s, Reason: %q, IP: %s",
+		impersonation.ID, adminID, impersonation.UserID, impersonation.ExpiresAt.Format(time.RFC3339), impersonation.Reason, c.ClientIP())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"impersonation": impersonation,
+		"header":        models.ImpersonationHeader,
+	})
+}
+
+// AdminEndImpersonation handles DELETE /api/admin/impersonations/:impersonationId, ending a session early
+func AdminEndImpersonation(c *gin.Context) {
+	// Get user ID from auth middleware
+	adminID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	impersonationID := c.Param("impersonationId")
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	result, err := models.GetCollection(ctx, models.ImpersonationsCollection).UpdateOne(ctx,
+		bson.M{"_id": impersonationID, "ended_at": bson.M{"$exists": false}, "expires_at": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{"ended_at": now}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to end impersonation",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "IMPERSONATION_NOT_FOUND",
+				"message": "No active impersonation with this ID",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AdminEndImpersonation success - ImpersonationID: %s, AdminID: %s, IP: %s", impersonationID, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Impersonation ended",
+	})
+}
+
+// AdminListImpersonations handles GET /api/admin/impersonations, newest first. userId filters by
+// the impersonated user.
+func AdminListImpersonations(c *gin.Context) {
+	req, ok := bindAdminList(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{}
+	if req.UserID != "" {
+		filter["user_id"] = req.UserID
+	}
+
+	collection := models.GetCollection(ctx, models.ImpersonationsCollection)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch impersonations",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	impersonations := []models.Impersonation{}
+	if err := cursor.All(ctx, &impersonations); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode impersonations",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count impersonations",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"impersonations": impersonations,
+		"count":          len(impersonations),
+		"totalCount":     totalCount,
+		"page":           req.Page,
+		"pageSize":       req.PageSize,
+		"totalPages":     (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Kinds of inbound feedback
+const (
+	InboundKindSubmission = "submission" // A new idea suggestion for the moderation queue
+	InboundKindFeedback   = "feedback"   // A thumbs up or reaction on an existing idea
+)
+
+// InboundFeedbackRequest represents feedback forwarded by an external tool such as Intercom, Zendesk or Typeform
+type InboundFeedbackRequest struct {
+	Kind       string `json:"kind" binding:"required,oneof=submission feedback"`
+	Source     string `json:"source" binding:"required,max=50"`       // Tool that forwarded it, e.g. intercom
+	ExternalID string `json:"externalId,omitempty" binding:"max=200"` // Conversation or response ID in the tool, for deduplication
+	CustomerID string `json:"customerId,omitempty" binding:"max=200"` // Customer in the tool, counted once per idea and reaction
+
+	// Submission fields
+	OneLiner       string `json:"oneLiner,omitempty" binding:"max=200"`
+	Description    string `json:"description,omitempty" binding:"max=1000"`
+	ValueStatement string `json:"valueStatement,omitempty" binding:"max=500"`
+	SubmitterName  string `json:"submitterName,omitempty" binding:"max=100"`
+	SubmitterEmail string `json:"submitterEmail,omitempty" binding:"omitempty,email"`
+
+	// Feedback fields
+	IdeaID string `json:"ideaId,omitempty"`
+	Emoji  string `json:"emoji,omitempty" binding:"max=10"` // Empty for a thumbs up
+	Note   string `json:"note,omitempty" binding:"max=280"`
+}
+
+// inboundVisitorKey identifies an external customer or record in the votes and submissions it creates
+func inboundVisitorKey(source, id string) string {
+	return "inbound:" + source + ":" + id
+}
+
+// IngestInboundFeedback handles POST /api/service/boards/:id/inbound
+func IngestInboundFeedback(c *gin.Context) {
+	account, err := middleware.GetServiceAccount(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get service account",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req InboundFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	req.Source = strings.ToLower(strings.TrimSpace(req.Source))
+	req.ExternalID = strings.TrimSpace(req.ExternalID)
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+
+	ctx := c.Request.Context()
+
+	board, ok := findAPITokenBoard(ctx, c)
+	if !ok {
+		return
+	}
+
+	log.Printf("[Handler] IngestInboundFeedback - AccountID: %s, BoardID: %s, Kind: %s, Source: %s, IP: %s",
+		account.ID, board.ID, req.Kind, req.Source, c.ClientIP())
+
+	if req.Kind == InboundKindSubmission {
+		ingestInboundSubmission(ctx, c, board, &req)
+		return
+	}
+	ingestInboundFeedback(ctx, c, board, &req)
+}
+
+// ingestInboundSubmission queues a forwarded idea suggestion for moderation like a public one.
+// A suggestion already forwarded with the same source and external ID is rejected.
+func ingestInboundSubmission(ctx context.Context, c *gin.Context, board *models.Board, req *InboundFeedbackRequest) {
+	if board.Frozen || board.Archived {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "SUBMISSIONS_DISABLED",
+				"message": "This board is not accepting idea suggestions",
+			},
+		})
+		return
+	}
+
+	submission := models.IdeaSubmission{
+		ID:             utils.GenerateSubmissionID(),
+		BoardID:        board.ID,
+		OneLiner:       strings.TrimSpace(req.OneLiner),
+		Description:    strings.TrimSpace(req.Description),
+		ValueStatement: strings.TrimSpace(req.ValueStatement),
+		SubmitterName:  strings.TrimSpace(req.SubmitterName),
+		SubmitterEmail: strings.TrimSpace(req.SubmitterEmail),
+		Source:         req.Source,
+		Status:         string(models.SubmissionPending),
+	}
+	if req.ExternalID != "" {
+		submission.VisitorKey = inboundVisitorKey(req.Source, req.ExternalID)
+	}
+	if board.StrictPrivacy {
+		// Strict privacy boards never store contact details
+		submission.SubmitterEmail = ""
+	}
+
+	// Validate submission
+	if validationErrors := models.ValidateSubmission(&submission); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Submission validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	// Reject records already forwarded, and exact duplicates already waiting in the queue
+	duplicateFilter := bson.M{
+		"board_id":  board.ID,
+		"status":    string(models.SubmissionPending),
+		"one_liner": bson.M{"$regex": "^" + regexp.QuoteMeta(submission.OneLiner) + "$", "$options": "i"},
+	}
+	if submission.VisitorKey != "" {
+		duplicateFilter = bson.M{"$or": bson.A{
+			duplicateFilter,
+			bson.M{"board_id": board.ID, "visitor_key": submission.VisitorKey},
+		}}
+	}
+	submissionsCollection := models.GetCollection(ctx, models.SubmissionsCollection)
+	duplicates, err := submissionsCollection.CountDocuments(ctx, duplicateFilter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to check for duplicate suggestions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if duplicates > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "DUPLICATE_SUBMISSION",
+				"message": "This idea has already been suggested and is awaiting review",
+			},
+		})
+		return
+	}
+
+	if _, err := submissionsCollection.InsertOne(ctx, submission); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save suggestion",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	go utils.SendBoardNotification(ctx, models.NotifyNewSubmission, board.ID, "",
+		fmt.Sprintf("New idea suggested via %s: \"%s\"", submission.Source, submission.OneLiner), "")
+	go utils.EmitWebhookEvent(ctx, board.ID, models.WebhookSubmissionCreated, gin.H{"submission": submission})
+
+	log.Printf("[Handler] IngestInboundFeedback success - SubmissionID: %s, BoardID: %s, Source: %s",
+		submission.ID, board.ID, submission.Source)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":    "Suggestion queued for moderation",
+		"submission": submission,
+	})
+}
+
+// ingestInboundFeedback records a forwarded thumbs up or reaction on one of the board's ideas.
+// Each customer, or each external record when there is no customer, counts once per idea and reaction.
+func ingestInboundFeedback(ctx context.Context, c *gin.Context, board *models.Board, req *InboundFeedbackRequest) {
+	if req.IdeaID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "ideaId is required for feedback",
+			},
+		})
+		return
+	}
+
+	var idea models.Idea
+	err := models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": req.IdeaID, "board_id": board.ID}).Decode(&idea)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch idea",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	voteType := models.VoteThumbsUp
+	if req.Emoji != "" {
+		voteType = models.VoteEmoji
+		if !board.AllowsReaction(req.Emoji) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_EMOJI",
+					"message": "This reaction is not enabled on the board",
+				},
+			})
+			return
+		}
+	}
+
+	customer := req.CustomerID
+	if customer == "" {
+		customer = req.ExternalID
+	}
+	if customer == "" {
+		customer = utils.GenerateFullUUID()
+	}
+
+	// Record the vote first; the unique index rejects feedback already forwarded for the customer
+	vote := models.Vote{
+		ID:          utils.GenerateFullUUID(),
+		BoardID:     idea.BoardID,
+		IdeaID:      idea.ID,
+		VisitorID:   inboundVisitorKey(req.Source, customer),
+		Type:        string(voteType),
+		Emoji:       req.Emoji,
+		Note:        strings.TrimSpace(req.Note),
+		CreatedAt:   time.Now().UTC(),
+		Attribution: models.Attribution{Source: req.Source},
+	}
+	if _, err := models.GetCollection(ctx, models.VotesCollection).InsertOne(ctx, vote); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "ALREADY_VOTED",
+					"message": "This customer's feedback on the idea has already been recorded",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to record vote",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Increment the thumbs up count, or the reaction's count, adding it when it is the first
+	updateDoc := bson.M{
+		"$set": bson.M{"updated_at": time.Now().UTC()},
+	}
+	if voteType == models.VoteThumbsUp {
+		updateDoc["$inc"] = bson.M{"thumbs_up": 1}
+	} else {
+		updateDoc["$push"] = bson.M{"emoji_reactions": models.EmojiReaction{Emoji: req.Emoji, Count: 1}}
+		for i, reaction := range idea.EmojiReactions {
+			if reaction.Emoji == req.Emoji {
+				delete(updateDoc, "$push")
+				updateDoc["$inc"] = bson.M{"emoji_reactions." + fmt.Sprintf("%d", i) + ".count": 1}
+				break
+			}
+		}
+	}
+
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": idea.ID}, updateDoc)
+	if err != nil {
+		discardVote(ctx, &vote)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update feedback count",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	feedbackType, feedback := "thumbsup", gin.H{"type": "thumbsup", "source": req.Source}
+	if voteType == models.VoteEmoji {
+		feedbackType, feedback = "emoji:"+req.Emoji, gin.H{"type": "emoji", "emoji": req.Emoji, "source": req.Source}
+	}
+	go sendFeedbackNotification(ctx, idea.BoardID, idea.ID, feedbackType, vote.VisitorID)
+	emitIdeaEvent(ctx, models.WebhookFeedbackReceived, &idea, gin.H{"feedback": feedback})
+	go utils.EvaluateIdeaAlerts(ctx, idea.BoardID, idea.ID)
+
+	// Broadcast feedback animation to WebSocket clients
+	utils.BroadcastFeedbackAnimation(ctx, idea.BoardID, idea.ID, string(voteType), req.Emoji)
+
+	go utils.RecordActivity(ctx, idea.BoardID, idea.ID, "", models.ActivityFeedback, map[string]interface{}{
+		"feedbackType": string(voteType),
+		"emoji":        req.Emoji,
+		"source":       req.Source,
+	})
+
+	log.Printf("[Handler] IngestInboundFeedback success - IdeaID: %s, BoardID: %s, Type: %s, Source: %s",
+		idea.ID, board.ID, voteType, req.Source)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Feedback recorded",
+		"thumbsUp":       updatedIdea.ThumbsUp,
+		"emojiReactions": updatedIdea.EmojiReactions,
+	})
+}
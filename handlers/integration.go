@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// applyIntegrationMove moves an idea when an issue tracker reports progress on its linked issue,
+// on behalf of actorID and with the side effects of the same change made on the board. Moved ideas
+// go to the end of the column, with both columns renumbered, and their status follows it as for
+// status updates. Ideas changed
+// since they were read, and ideas on frozen boards, are left alone. source names the issue in the
+// activity, as in "jira:DIS-12".
+func applyIntegrationMove(ctx context.Context, actorID string, existing *models.Idea, column string, inProgress bool, source string) {
+	if existing.Column == column && existing.InProgress == inProgress {
+		return
+	}
+
+	board, err := models.FindBoardByID(ctx, existing.BoardID)
+	if err != nil || board.Frozen {
+		return
+	}
+
+	now := time.Now().UTC()
+	set := bson.M{"in_progress": inProgress, "updated_at": now, "updated_by": actorID}
+	if column != existing.Column {
+		set["column"] = column
+
+		switch {
+		case column == string(models.ColumnRelease):
+			set["status"] = string(models.StatusDone)
+		case column == string(models.ColumnWontDo):
+			set["status"] = string(models.StatusArchived)
+		case existing.Status == string(models.StatusDone) || existing.Status == string(models.StatusArchived):
+			set["status"] = string(models.StatusActive)
+		}
+	}
+
+	updatedIdea, err := saveIdeaUpdate(ctx,
+		bson.M{"_id": existing.ID, "column": existing.Column, "in_progress": existing.InProgress}, existing, set)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("[Integration] Failed to move idea - IdeaID: %s, Source: %s, Error: %v", existing.ID, source, err)
+		}
+		return
+	}
+
+	utils.BroadcastBoardEvent(ctx, updatedIdea.BoardID, utils.EventIdeaUpdated, updatedIdea.ID, newIdeaResponse(*updatedIdea))
+
+	activityType, auditAction := models.ActivityIdeaUpdated, models.AuditIdeaStatusChanged
+	if updatedIdea.Column != existing.Column {
+		activityType, auditAction = models.ActivityIdeaMoved, models.AuditIdeaMoved
+	}
+	go utils.RecordActivity(ctx, updatedIdea.BoardID, updatedIdea.ID, actorID, activityType, map[string]interface{}{
+		"fromColumn": existing.Column,
+		"toColumn":   updatedIdea.Column,
+		"status":     updatedIdea.Status,
+		"inProgress": updatedIdea.InProgress,
+		"source":     source,
+	})
+	go utils.RecordAudit(ctx, models.AuditEntry{
+		BoardID:    updatedIdea.BoardID,
+		TargetType: models.AuditTargetIdea,
+		TargetID:   updatedIdea.ID,
+		Action:     auditAction,
+		ActorID:    actorID,
+		Changes:    utils.AuditDiff(existing, updatedIdea),
+	})
+	announceIfReleased(ctx, existing, updatedIdea)
+	notifyStatusChange(ctx, existing, updatedIdea, actorID)
+	checkColumnAlerts(ctx, existing, updatedIdea)
+
+	log.Printf("[Integration] Idea moved - IdeaID: %s, BoardID: %s, Column: %s, InProgress: %t, Source: %s",
+		updatedIdea.ID, updatedIdea.BoardID, updatedIdea.Column, updatedIdea.InProgress, source)
+}
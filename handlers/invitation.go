@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AcceptInvitationRequest represents the request payload for accepting a collaborator invitation
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// sendCollaboratorInvite stores a collaborator invitation for the owner's board and emails its accept link
+func sendCollaboratorInvite(ctx context.Context, c *gin.Context, board *models.Board, req *InviteRequest, userID string) {
+	if rejectInvalidMemberRole(c, req.Role) {
+		return
+	}
+
+	now := time.Now().UTC()
+	invitation := models.BoardInvitation{
+		ID:        utils.GenerateFullUUID(),
+		BoardID:   board.ID,
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:      models.MemberRole(req.Role),
+		Token:     utils.GenerateInvitationToken(),
+		Status:    string(models.InvitationPending),
+		InvitedBy: userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(models.InvitationTTL),
+	}
+
+	collection := models.GetCollection(ctx, models.InvitationsCollection)
+	if _, err := collection.InsertOne(ctx, invitation); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create invitation",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := utils.SendBoardInvitationEmail(invitation, *board, req.Subject, req.Message); err != nil {
+		// An invitation nobody received would only linger as a usable token
+		if _, delErr := collection.DeleteOne(ctx, bson.M{"_id": invitation.ID}); delErr != nil {
+			log.Printf("[Handler] SendBoardInvite - Invitation cleanup error: %v, InvitationID: %s", delErr, invitation.ID)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "EMAIL_ERROR",
+				"message": "Failed to send invitation email",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] SendBoardInvite collaborator invitation sent - InvitationID: %s, BoardID: %s, Role: %s, UserID: %s, IP: %s",
+		invitation.ID, board.ID, invitation.Role, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Invitation email sent successfully",
+		"invitation": invitation,
+	})
+}
+
+// GetBoardInvitations handles GET /api/boards/:id/invitations (owner only)
+func GetBoardInvitations(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.InvitationsCollection).Find(ctx, bson.M{"board_id": boardID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch invitations",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	invitations := []models.BoardInvitation{}
+	if err := cursor.All(ctx, &invitations); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode invitations",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+		"count":       len(invitations),
+	})
+}
+
+// RevokeBoardInvitation handles DELETE /api/boards/:id/invitations/:invitationId (owner only)
+func RevokeBoardInvitation(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+	invitationID := c.Param("invitationId")
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"_id": invitationID, "board_id": boardID}
+	result, err := models.GetCollection(ctx, models.InvitationsCollection).DeleteOne(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to revoke invitation",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "INVITATION_NOT_FOUND",
+				"message": "Invitation not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] RevokeBoardInvitation success - InvitationID: %s, BoardID: %s, UserID: %s, IP: %s",
+		invitationID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invitation revoked successfully",
+	})
+}
+
+// AcceptInvitation handles POST /api/invitations/accept. The signed-in user redeems the emailed
+// token once and becomes a member of the board with the invited role.
+func AcceptInvitation(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Claim the invitation atomically so a token can only be redeemed once
+	now := time.Now().UTC()
+	invitations := models.GetCollection(ctx, models.InvitationsCollection)
+	var invitation models.BoardInvitation
+	err = invitations.FindOneAndUpdate(ctx,
+		bson.M{"token": req.Token, "status": string(models.InvitationPending), "expires_at": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{"status": string(models.InvitationAccepted), "accepted_by": userID, "accepted_at": now}},
+	).Decode(&invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "INVITATION_NOT_FOUND",
+					"message": "This invitation is invalid, has expired or was already used",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to accept invitation",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// releaseInvitation puts the invitation back so it can be retried after a failure
+	releaseInvitation := func() {
+		_, err := invitations.UpdateOne(ctx, bson.M{"_id": invitation.ID}, bson.M{
+			"$set":   bson.M{"status": string(models.InvitationPending)},
+			"$unset": bson.M{"accepted_by": "", "accepted_at": ""},
+		})
+		if err != nil {
+			log.Printf("[Handler] AcceptInvitation - Invitation release error: %v, InvitationID: %s", err, invitation.ID)
+		}
+	}
+
+	boards := models.GetCollection(ctx, models.BoardsCollection)
+	var board models.Board
+	if err := boards.FindOne(ctx, bson.M{"_id": invitation.BoardID}).Decode(&board); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "The board of this invitation no longer exists",
+				},
+			})
+			return
+		}
+
+		releaseInvitation()
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Owners and existing members keep their role
+	if role := board.RoleOf(userID); role != "" {
+		c.JSON(http.StatusOK, gin.H{
+			"boardId":   board.ID,
+			"boardName": board.Name,
+			"role":      role,
+		})
+		return
+	}
+
+	member := models.BoardMember{
+		UserID:  userID,
+		Email:   invitation.Email,
+		Role:    invitation.Role,
+		AddedBy: invitation.InvitedBy,
+		AddedAt: now,
+	}
+	filter := bson.M{
+		"_id":             board.ID,
+		"members.user_id": bson.M{"$ne": userID},
+		fmt.Sprintf("members.%d", models.MaxBoardMembers-1): bson.M{"$exists": false},
+	}
+	result, err := boards.UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"members": member},
+		"$set":  bson.M{"updated_at": now, "updated_by": userID},
+	})
+	if err != nil {
+		releaseInvitation()
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to add member",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(board.ID)
+	if result.MatchedCount == 0 {
+		releaseInvitation()
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_MEMBERS",
+				"message": fmt.Sprintf("Boards may have at most %d members", models.MaxBoardMembers),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AcceptInvitation success - InvitationID: %s, BoardID: %s, Role: %s, UserID: %s, IP: %s",
+		invitation.ID, board.ID, member.Role, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"boardId":   board.ID,
+		"boardName": board.Name,
+		"role":      member.Role,
+	})
+}
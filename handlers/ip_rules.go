@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// rejectBlockedVisitor writes IP_BLOCKED unless the board's IP rules admit the client
+func rejectBlockedVisitor(c *gin.Context, board *models.Board) bool {
+	if board.IPRules.Permits(c.ClientIP()) {
+		return false
+	}
+	log.Printf("[Handler] Public access blocked by IP rules - BoardID: %s, Path: %s, IP: %s", board.ID, c.FullPath(), c.ClientIP())
+	c.JSON(http.StatusForbidden, gin.H{
+		"error": gin.H{
+			"code":    "IP_BLOCKED",
+			"message": "This board is not available from your network",
+		},
+	})
+	return true
+}
+
+// boardIPRulesOrEmpty returns a board's IP rules with empty lists when none are set
+func boardIPRulesOrEmpty(board *models.Board) models.BoardIPRules {
+	rules := models.BoardIPRules{Allow: []string{}, Deny: []string{}}
+	if board.IPRules != nil {
+		rules.Allow = append(rules.Allow, board.IPRules.Allow...)
+		rules.Deny = append(rules.Deny, board.IPRules.Deny...)
+	}
+	return rules
+}
+
+// GetBoardIPRules handles GET /api/boards/:id/ip-rules (owner only)
+func GetBoardIPRules(c *gin.Context) {
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	rules := boardIPRulesOrEmpty(board)
+	c.JSON(http.StatusOK, gin.H{
+		"allow":    rules.Allow,
+		"deny":     rules.Deny,
+		"clientIp": c.ClientIP(),
+	})
+}
+
+// UpdateBoardIPRules handles PUT /api/boards/:id/ip-rules (owner only). Both lists are replaced;
+// sending two empty lists removes the restrictions.
+func UpdateBoardIPRules(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req models.BoardIPRules
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if validationErrors := models.ValidateBoardIPRules(&req); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_IP_RULES",
+				"message": "Invalid IP rules",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+	before := boardIPRulesOrEmpty(board)
+
+	ctx := c.Request.Context()
+
+	update := bson.M{"$set": bson.M{"ip_rules": req, "updated_at": time.Now().UTC(), "updated_by": userID}}
+	if len(req.Allow) == 0 && len(req.Deny) == 0 {
+		update = bson.M{"$unset": bson.M{"ip_rules": ""}, "$set": bson.M{"updated_at": time.Now().UTC(), "updated_by": userID}}
+	}
+	if _, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, bson.M{"_id": boardID}, models.BumpVersion(update)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update IP rules",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(boardID)
+	utils.RecheckBoardAccess(boardID)
+
+	log.Printf("[Handler] UpdateBoardIPRules success - BoardID: %s, Allow: %d, Deny: %d, UserID: %s, IP: %s",
+		boardID, len(req.Allow), len(req.Deny), userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditBoardUpdated, boardID, models.AuditTargetBoard, boardID,
+		gin.H{"ipRules": before}, gin.H{"ipRules": req})
+	utils.BroadcastBoardEvent(ctx, boardID, utils.EventBoardUpdated, "", map[string]interface{}{
+		"fields": []string{"ip_rules"},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"allow":    req.Allow,
+		"deny":     req.Deny,
+		"clientIp": c.ClientIP(),
+	})
+}
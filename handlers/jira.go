@@ -0,0 +1,503 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// defaultJiraIssueType is used when a config does not name an issue type
+const defaultJiraIssueType = "Task"
+
+// JiraConfigRequest represents the request payload for connecting a board to a Jira project.
+// Credentials may be omitted on update to keep the stored ones.
+type JiraConfigRequest struct {
+	SiteURL      string `json:"siteUrl" binding:"required"`
+	AuthType     string `json:"authType" binding:"required"`
+	Email        string `json:"email,omitempty"`        // api_token
+	APIToken     string `json:"apiToken,omitempty"`     // api_token
+	CloudID      string `json:"cloudId,omitempty"`      // oauth
+	AccessToken  string `json:"accessToken,omitempty"`  // oauth
+	RefreshToken string `json:"refreshToken,omitempty"` // oauth
+	ProjectKey   string `json:"projectKey" binding:"required"`
+	IssueType    string `json:"issueType,omitempty"`
+	SyncStatus   *bool  `json:"syncStatus,omitempty"`
+}
+
+// findJiraConfig loads the board's Jira config, writing an error response if it is missing
+func findJiraConfig(ctx context.Context, c *gin.Context, boardID string) (*models.JiraConfig, bool) {
+	var config models.JiraConfig
+	err := models.GetCollection(ctx, models.JiraConfigsCollection).FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "JIRA_NOT_CONFIGURED",
+					"message": "No Jira project is configured for this board",
+				},
+			})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Jira config",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return &config, true
+}
+
+// recordJiraError stores why the last call to Jira failed on the config, or clears it after a success
+func recordJiraError(ctx context.Context, configID string, jiraErr error) {
+	message := ""
+	if jiraErr != nil {
+		message = jiraErr.Error()
+	}
+	if _, err := models.GetCollection(ctx, models.JiraConfigsCollection).UpdateOne(ctx, bson.M{"_id": configID}, bson.M{
+		"$set": bson.M{"last_error": message},
+	}); err != nil {
+		log.Printf("Failed to record Jira error for config %s: %v", configID, err)
+	}
+}
+
+// GetJiraConfig handles GET /api/boards/:id/jira
+func GetJiraConfig(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	config, ok := findJiraConfig(ctx, c, boardID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// UpsertJiraConfig handles PUT /api/boards/:id/jira. The credentials are checked against the
+// project before they are saved.
+func UpsertJiraConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req JiraConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Credentials are stored encrypted
+	if rejectWithoutEncryption(c) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.JiraConfigsCollection)
+
+	var config models.JiraConfig
+	err := collection.FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	isNew := err == mongo.ErrNoDocuments
+	if err != nil && !isNew {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Jira config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if isNew {
+		config = models.JiraConfig{
+			ID:      utils.GenerateFullUUID(),
+			BoardID: boardID,
+			UserID:  userID,
+		}
+	}
+
+	// Credentials of another auth type, or for another site, are not kept
+	authType := models.JiraAuthType(req.AuthType)
+	siteURL := strings.TrimRight(strings.TrimSpace(req.SiteURL), "/")
+	if authType != config.AuthType || siteURL != config.SiteURL {
+		config.Email, config.APIToken = "", ""
+		config.CloudID, config.AccessToken, config.RefreshToken, config.TokenExpiresAt = "", "", "", nil
+	}
+	config.SiteURL = siteURL
+	config.AuthType = authType
+
+	if email := strings.TrimSpace(req.Email); email != "" {
+		config.Email = email
+	}
+	if token := strings.TrimSpace(req.APIToken); token != "" {
+		config.APIToken = token
+	}
+	if cloudID := strings.TrimSpace(req.CloudID); cloudID != "" {
+		config.CloudID = cloudID
+	}
+	if token := strings.TrimSpace(req.AccessToken); token != "" {
+		config.AccessToken = token
+		// A new access token is used until it is refused or refreshed
+		config.TokenExpiresAt = nil
+	}
+	if token := strings.TrimSpace(req.RefreshToken); token != "" {
+		config.RefreshToken = token
+	}
+	config.ProjectKey = strings.ToUpper(strings.TrimSpace(req.ProjectKey))
+	config.IssueType = strings.TrimSpace(req.IssueType)
+	if config.IssueType == "" {
+		config.IssueType = defaultJiraIssueType
+	}
+	if req.SyncStatus != nil {
+		config.SyncStatus = *req.SyncStatus
+	}
+
+	// Validate Jira config
+	if validationErrors := models.ValidateJiraConfig(&config); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Jira config validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	// Check the credentials before saving them; refreshed OAuth tokens are stored with the config
+	checked := config
+	checked.ID = ""
+	if err := utils.CheckJiraProject(ctx, &checked); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "JIRA_CONNECTION_FAILED",
+				"message": "Jira did not accept the credentials or project",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	config.AccessToken, config.RefreshToken, config.TokenExpiresAt = checked.AccessToken, checked.RefreshToken, checked.TokenExpiresAt
+	config.LastError = ""
+
+	// Encrypt new credentials and any saved in plaintext before they were encrypted
+	for _, credential := range []*string{&config.APIToken, &config.AccessToken, &config.RefreshToken} {
+		if *credential == "" || utils.IsEncryptedSecret(*credential) {
+			continue
+		}
+		if *credential, err = utils.EncryptSecret(*credential); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "ENCRYPTION_FAILED",
+					"message": "Failed to encrypt Jira credentials",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	if isNew {
+		_, err = collection.InsertOne(ctx, config)
+	} else {
+		_, err = collection.ReplaceOne(ctx, bson.M{"_id": config.ID}, config)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save Jira config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpsertJiraConfig success - BoardID: %s, UserID: %s, Site: %s, Project: %s, AuthType: %s, SyncStatus: %t, IP: %s",
+		boardID, userID, config.SiteURL, config.ProjectKey, config.AuthType, config.SyncStatus, c.ClientIP())
+
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, config)
+}
+
+// DeleteJiraConfig handles DELETE /api/boards/:id/jira. Ideas keep the links to issues created before.
+func DeleteJiraConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.JiraConfigsCollection).DeleteOne(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete Jira config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "JIRA_NOT_CONFIGURED",
+				"message": "No Jira project is configured for this board",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteJiraConfig success - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Jira config deleted successfully",
+	})
+}
+
+// CreateIdeaJiraIssue handles POST /api/ideas/:id/jira, creating an issue in the board's Jira
+// project from the idea and linking it
+func CreateIdeaJiraIssue(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	if idea.JiraIssue != nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "JIRA_ISSUE_EXISTS",
+				"message": "This idea is already linked to a Jira issue",
+				"details": idea.JiraIssue.Key,
+			},
+		})
+		return
+	}
+
+	config, ok := findJiraConfig(ctx, c, idea.BoardID)
+	if !ok {
+		return
+	}
+
+	link, err := utils.CreateJiraIssue(ctx, config, idea, userID)
+	if err != nil {
+		recordJiraError(ctx, config.ID, err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "JIRA_REQUEST_FAILED",
+				"message": "Jira did not create the issue",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if config.LastError != "" {
+		recordJiraError(ctx, config.ID, nil)
+	}
+
+	// Only link the issue if nobody linked another one meanwhile
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx,
+		bson.M{"_id": ideaID, "jira_issue": bson.M{"$exists": false}},
+		models.BumpVersion(bson.M{"$set": bson.M{"jira_issue": link, "updated_at": time.Now().UTC(), "updated_by": userID}}))
+	if err != nil {
+		log.Printf("[Handler] CreateIdeaJiraIssue - Issue %s created but not linked: %v, IdeaID: %s", link.Key, err, ideaID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "JIRA_ISSUE_EXISTS",
+					"message": "This idea was linked to another Jira issue meanwhile",
+					"details": link.Key,
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to link Jira issue",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	response := newIdeaResponse(*updatedIdea)
+	utils.BroadcastBoardEvent(ctx, idea.BoardID, utils.EventIdeaUpdated, ideaID, response)
+
+	log.Printf("[Handler] CreateIdeaJiraIssue success - IdeaID: %s, BoardID: %s, Issue: %s, UserID: %s, IP: %s",
+		ideaID, idea.BoardID, link.Key, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditIdeaJiraLinked, idea.BoardID, models.AuditTargetIdea, ideaID, idea, updatedIdea)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UnlinkIdeaJiraIssue handles DELETE /api/ideas/:id/jira. The issue itself stays in Jira.
+func UnlinkIdeaJiraIssue(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	if idea.JiraIssue == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "JIRA_ISSUE_NOT_FOUND",
+				"message": "This idea is not linked to a Jira issue",
+			},
+		})
+		return
+	}
+
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID}, models.BumpVersion(bson.M{
+		"$unset": bson.M{"jira_issue": ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC(), "updated_by": userID},
+	}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to unlink Jira issue",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	response := newIdeaResponse(*updatedIdea)
+	utils.BroadcastBoardEvent(ctx, idea.BoardID, utils.EventIdeaUpdated, ideaID, response)
+
+	log.Printf("[Handler] UnlinkIdeaJiraIssue success - IdeaID: %s, BoardID: %s, Issue: %s, UserID: %s, IP: %s",
+		ideaID, idea.BoardID, idea.JiraIssue.Key, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditIdeaJiraUnlinked, idea.BoardID, models.AuditTargetIdea, ideaID, idea, updatedIdea)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SyncJiraStatuses syncs every board that has status sync turned on, releasing ideas whose issue is
+// done. It runs as a scheduled job.
+func SyncJiraStatuses(ctx context.Context) error {
+	cursor, err := models.GetCollection(ctx, models.JiraConfigsCollection).Find(ctx, bson.M{"sync_status": true})
+	if err != nil {
+		return fmt.Errorf("failed to load Jira configs: %w", err)
+	}
+
+	var configs []models.JiraConfig
+	if err := cursor.All(ctx, &configs); err != nil {
+		return fmt.Errorf("failed to decode Jira configs: %w", err)
+	}
+
+	for _, config := range configs {
+		syncJiraBoard(ctx, &config)
+	}
+	return nil
+}
+
+// syncJiraBoard refreshes the status of a board's linked issues that are not released yet,
+// releasing the ideas whose issue is done. Issues deleted in Jira are left alone.
+func syncJiraBoard(ctx context.Context, config *models.JiraConfig) {
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	cursor, err := ideasCollection.Find(ctx, bson.M{
+		"board_id":   config.BoardID,
+		"jira_issue": bson.M{"$exists": true},
+		"column":     bson.M{"$ne": string(models.ColumnRelease)},
+	})
+	if err != nil {
+		log.Printf("[Jira] Failed to load linked ideas - BoardID: %s, Error: %v", config.BoardID, err)
+		return
+	}
+
+	var ideas []models.Idea
+	if err := cursor.All(ctx, &ideas); err != nil {
+		log.Printf("[Jira] Failed to decode linked ideas - BoardID: %s, Error: %v", config.BoardID, err)
+		return
+	}
+
+	var syncErr error
+	for _, idea := range ideas {
+		status, err := utils.FetchJiraIssueStatus(ctx, config, idea.JiraIssue.IssueID)
+		if err != nil {
+			var jiraErr *utils.JiraError
+			if errors.As(err, &jiraErr) && jiraErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			syncErr = err
+			if errors.As(err, &jiraErr) && (jiraErr.StatusCode == http.StatusUnauthorized || jiraErr.StatusCode == http.StatusForbidden) {
+				break
+			}
+			continue
+		}
+
+		now := time.Now().UTC()
+		if _, err := ideasCollection.UpdateOne(ctx, bson.M{"_id": idea.ID}, bson.M{"$set": bson.M{
+			"jira_issue.status":    status.Name,
+			"jira_issue.synced_at": now,
+		}}); err != nil {
+			log.Printf("[Jira] Failed to update issue status - IdeaID: %s, Error: %v", idea.ID, err)
+		}
+
+		if status.Category == models.JiraStatusDone {
+			applyIntegrationMove(ctx, config.UserID, &idea, string(models.ColumnRelease), false, "jira:"+idea.JiraIssue.Key)
+		}
+	}
+
+	now := time.Now().UTC()
+	update := bson.M{"last_synced_at": now, "last_error": ""}
+	if syncErr != nil {
+		update["last_error"] = syncErr.Error()
+		log.Printf("[Jira] Status sync failed - BoardID: %s, Error: %v", config.BoardID, syncErr)
+	}
+	if _, err := models.GetCollection(ctx, models.JiraConfigsCollection).UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{"$set": update}); err != nil {
+		log.Printf("[Jira] Failed to record sync - ConfigID: %s, Error: %v", config.ID, err)
+	}
+}
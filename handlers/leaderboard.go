@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// GetLeaderboardRequest represents the query parameters for the idea leaderboard
+type GetLeaderboardRequest struct {
+	Metric string `form:"metric"` // thumbsup, emoji or score (default)
+	Window string `form:"window"` // 7d, 30d, 90d or all (default)
+	Limit  int    `form:"limit"`
+}
+
+// LeaderboardEntry is one ranked idea with the feedback counted in the window
+type LeaderboardEntry struct {
+	Rank     int         `json:"rank"`
+	Idea     interface{} `json:"idea"` // IdeaResponse for owners, PublicIdeaResponse for visitors
+	ThumbsUp int         `json:"thumbsUp"`
+	Emoji    int         `json:"emoji"`
+	Comments int         `json:"comments"`
+	Score    int         `json:"score"`
+}
+
+// leaderboardCounts holds the feedback counted for one idea
+type leaderboardCounts struct {
+	thumbsUp int
+	emoji    int
+	comments int
+}
+
+// value returns the count the leaderboard ranks by
+func (l leaderboardCounts) value(metric models.LeaderboardMetric) int {
+	switch metric {
+	case models.LeaderboardThumbsUp:
+		return l.thumbsUp
+	case models.LeaderboardEmoji:
+		return l.emoji
+	}
+	return models.EngagementScore(l.thumbsUp, l.emoji, l.comments)
+}
+
+// bindLeaderboardRequest parses and defaults the leaderboard query, writing VALIDATION_ERROR if invalid
+func bindLeaderboardRequest(c *gin.Context) (*GetLeaderboardRequest, bool) {
+	var req GetLeaderboardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+
+	// Set defaults
+	if req.Metric == "" {
+		req.Metric = string(models.LeaderboardScore)
+	}
+	if req.Window == "" {
+		req.Window = string(models.LeaderboardAllTime)
+	}
+	if req.Limit <= 0 {
+		req.Limit = models.DefaultLeaderboardLimit
+	}
+	if req.Limit > models.MaxLeaderboardLimit {
+		req.Limit = models.MaxLeaderboardLimit
+	}
+
+	if !models.IsValidLeaderboardMetric(req.Metric) || !models.IsValidLeaderboardWindow(req.Window) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": "metric must be thumbsup, emoji or score and window must be 7d, 30d, 90d or all",
+			},
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+// countWindowFeedback counts thumbs up, emoji reactions and approved comments per idea since a time.
+// A zero time counts all time, taking reactions from the idea counters.
+func countWindowFeedback(ctx context.Context, boardID string, ideas []models.Idea, since time.Time) (map[string]*leaderboardCounts, error) {
+	counts := make(map[string]*leaderboardCounts, len(ideas))
+	for _, idea := range ideas {
+		entry := &leaderboardCounts{}
+		if since.IsZero() {
+			entry.thumbsUp = idea.ThumbsUp
+			for _, reaction := range idea.EmojiReactions {
+				entry.emoji += reaction.Count
+			}
+		}
+		counts[idea.ID] = entry
+	}
+
+	type groupCount struct {
+		ID struct {
+			IdeaID string `bson:"idea_id"`
+			Type   string `bson:"type"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	aggregate := func(collection string, match bson.M) ([]groupCount, error) {
+		cursor, err := models.GetCollection(ctx, collection).Aggregate(ctx, []bson.M{
+			{"$match": match},
+			{"$group": bson.M{"_id": bson.M{"idea_id": "$idea_id", "type": "$type"}, "count": bson.M{"$sum": 1}}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer cursor.Close(ctx)
+
+		var groups []groupCount
+		err = cursor.All(ctx, &groups)
+		return groups, err
+	}
+
+	commentMatch := bson.M{"board_id": boardID, "status": string(models.CommentApproved)}
+	if !since.IsZero() {
+		commentMatch["created_at"] = bson.M{"$gte": since}
+	}
+	comments, err := aggregate(models.CommentsCollection, commentMatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range comments {
+		if entry, ok := counts[group.ID.IdeaID]; ok {
+			entry.comments += group.Count
+		}
+	}
+
+	if since.IsZero() {
+		return counts, nil
+	}
+
+	// Windowed reactions come from the per-visitor votes, which carry their own timestamps
+	votes, err := aggregate(models.VotesCollection, bson.M{
+		"board_id":   boardID,
+		"type":       bson.M{"$in": []string{string(models.VoteThumbsUp), string(models.VoteEmoji)}},
+		"created_at": bson.M{"$gte": since},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range votes {
+		entry, ok := counts[group.ID.IdeaID]
+		if !ok {
+			continue
+		}
+		if group.ID.Type == string(models.VoteThumbsUp) {
+			entry.thumbsUp += group.Count
+		} else {
+			entry.emoji += group.Count
+		}
+	}
+	return counts, nil
+}
+
+// buildLeaderboard ranks ideas by the requested metric, leaving out ideas with nothing counted.
+// Ties go to the older idea.
+func buildLeaderboard(ctx context.Context, board *models.Board, req *GetLeaderboardRequest, ideas []models.Idea, toResponse func(models.Idea) interface{}) ([]LeaderboardEntry, error) {
+	counts, err := countWindowFeedback(ctx, board.ID, ideas, models.LeaderboardWindow(req.Window).Since(time.Now().UTC()))
+	if err != nil {
+		return nil, err
+	}
+
+	metric := models.LeaderboardMetric(req.Metric)
+	ranked := []models.Idea{}
+	for _, idea := range ideas {
+		if counts[idea.ID].value(metric) > 0 {
+			ranked = append(ranked, idea)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		left, right := counts[ranked[i].ID].value(metric), counts[ranked[j].ID].value(metric)
+		if left != right {
+			return left > right
+		}
+		return ranked[i].CreatedAt.Before(ranked[j].CreatedAt)
+	})
+	if len(ranked) > req.Limit {
+		ranked = ranked[:req.Limit]
+	}
+
+	entries := []LeaderboardEntry{}
+	for i, idea := range ranked {
+		entry := counts[idea.ID]
+		entries = append(entries, LeaderboardEntry{
+			Rank:     i + 1,
+			Idea:     toResponse(idea),
+			ThumbsUp: entry.thumbsUp,
+			Emoji:    entry.emoji,
+			Comments: entry.comments,
+			Score:    models.EngagementScore(entry.thumbsUp, entry.emoji, entry.comments),
+		})
+	}
+	return entries, nil
+}
+
+// findLeaderboardIdeas loads a board's ideas, limited to the given columns when not nil
+func findLeaderboardIdeas(ctx context.Context, boardID string, columns []string) ([]models.Idea, error) {
+	filter := bson.M{"board_id": boardID}
+	if columns != nil {
+		filter["column"] = bson.M{"$in": columns}
+	}
+
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ideas []models.Idea
+	err = cursor.All(ctx, &ideas)
+	return ideas, err
+}
+
+// GetBoardLeaderboard handles GET /api/boards/:id/leaderboard
+func GetBoardLeaderboard(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	req, ok := bindLeaderboardRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	ideas, err := findLeaderboardIdeas(ctx, board.ID, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	entries, err := buildLeaderboard(ctx, board, req, ideas, func(idea models.Idea) interface{} {
+		return newIdeaResponse(idea)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count feedback",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] GetBoardLeaderboard success - BoardID: %s, Metric: %s, Window: %s, Count: %d, UserID: %s, IP: %s",
+		board.ID, req.Metric, req.Window, len(entries), userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"count":   len(entries),
+		"metric":  req.Metric,
+		"window":  req.Window,
+	})
+}
+
+// GetPublicBoardLeaderboard handles GET /api/boards/:id/leaderboard/public, where :id is the public link.
+// Only ideas in visible columns are ranked.
+func GetPublicBoardLeaderboard(c *gin.Context) {
+	publicLink := c.Param("id")
+
+	req, ok := bindLeaderboardRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	columns := board.VisibleColumns
+	if columns == nil {
+		columns = []string{}
+	}
+	ideas, err := findLeaderboardIdeas(ctx, board.ID, columns)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	visibleFields := make(map[string]bool)
+	for _, field := range board.VisibleFields {
+		visibleFields[field] = true
+	}
+	exposeRiceScore := board.ShowsRiceScorePublicly()
+
+	entries, err := buildLeaderboard(ctx, &board, req, ideas, func(idea models.Idea) interface{} {
+		return newPublicIdeaResponse(idea, visibleFields, exposeRiceScore)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count feedback",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"count":   len(entries),
+		"metric":  req.Metric,
+		"window":  req.Window,
+	})
+}
@@ -0,0 +1,606 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// LinearConfigRequest represents the request payload for connecting a board to a Linear team.
+// The API key and webhook secret may be omitted on update to keep the stored ones.
+type LinearConfigRequest struct {
+	APIKey        string            `json:"apiKey,omitempty"`
+	TeamKey       string            `json:"teamKey" binding:"required"`
+	WebhookSecret string            `json:"webhookSecret,omitempty"`
+	StateColumns  map[string]string `json:"stateColumns,omitempty"` // Omitted keeps the current mapping
+	SyncStatus    *bool             `json:"syncStatus,omitempty"`
+}
+
+// LinearWebhookEvent is the envelope of a Linear webhook
+type LinearWebhookEvent struct {
+	Action string `json:"action"` // create, update or remove
+	Type   string `json:"type"`   // Issue, Comment, ...
+	Data   struct {
+		ID         string `json:"id"`
+		Identifier string `json:"identifier"`
+		State      struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"state"`
+	} `json:"data"`
+}
+
+// linearWebhookURL is where a config's Linear webhook posts to
+func linearWebhookURL(configID string) string {
+	return fmt.Sprintf("%s/api/webhooks/linear/%s", os.Getenv("APP_URL"), configID)
+}
+
+// findLinearConfig loads the board's Linear config, writing an error response if it is missing
+func findLinearConfig(ctx context.Context, c *gin.Context, boardID string) (*models.LinearConfig, bool) {
+	var config models.LinearConfig
+	err := models.GetCollection(ctx, models.LinearConfigsCollection).FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "LINEAR_NOT_CONFIGURED",
+					"message": "No Linear team is configured for this board",
+				},
+			})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Linear config",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	config.WebhookURL = linearWebhookURL(config.ID)
+	return &config, true
+}
+
+// rejectWithoutEncryption writes ENCRYPTION_NOT_CONFIGURED when credentials cannot be stored or read
+func rejectWithoutEncryption(c *gin.Context) bool {
+	if utils.EncryptionEnabled() {
+		return false
+	}
+
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"error": gin.H{
+			"code":    "ENCRYPTION_NOT_CONFIGURED",
+			"message": "Integration credentials cannot be stored until ENCRYPTION_KEY is set",
+		},
+	})
+	return true
+}
+
+// GetLinearConfig handles GET /api/boards/:id/linear
+func GetLinearConfig(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	config, ok := findLinearConfig(ctx, c, boardID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// UpsertLinearConfig handles PUT /api/boards/:id/linear. The API key is checked by looking up the
+// team before it is saved.
+func UpsertLinearConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	if rejectWithoutEncryption(c) {
+		return
+	}
+
+	// Parse request body
+	var req LinearConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.LinearConfigsCollection)
+
+	var config models.LinearConfig
+	err := collection.FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	isNew := err == mongo.ErrNoDocuments
+	if err != nil && !isNew {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Linear config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if isNew {
+		config = models.LinearConfig{
+			ID:           utils.GenerateFullUUID(),
+			BoardID:      boardID,
+			UserID:       userID,
+			StateColumns: models.DefaultLinearStateColumns,
+			SyncStatus:   true,
+		}
+	}
+
+	// The API key is only decrypted to look up the team
+	apiKey := strings.TrimSpace(req.APIKey)
+	if apiKey == "" && config.APIKey != "" {
+		if apiKey, err = utils.DecryptSecret(config.APIKey); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DECRYPTION_FAILED",
+					"message": "The stored API key cannot be read; send it again",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+	if apiKey == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Linear config validation failed",
+				"details": "apiKey: API key is required",
+			},
+		})
+		return
+	}
+
+	team, err := utils.FindLinearTeam(ctx, apiKey, strings.ToUpper(strings.TrimSpace(req.TeamKey)))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "LINEAR_CONNECTION_FAILED",
+				"message": "Linear did not accept the API key or team",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	config.TeamID, config.TeamKey, config.TeamName = team.ID, team.Key, team.Name
+
+	if req.APIKey != "" {
+		if config.APIKey, err = utils.EncryptSecret(apiKey); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "ENCRYPTION_FAILED",
+					"message": "Failed to encrypt API key",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+	if secret := strings.TrimSpace(req.WebhookSecret); secret != "" {
+		if config.WebhookSecret, err = utils.EncryptSecret(secret); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "ENCRYPTION_FAILED",
+					"message": "Failed to encrypt webhook secret",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+	if req.StateColumns != nil {
+		config.StateColumns = req.StateColumns
+	}
+	if req.SyncStatus != nil {
+		config.SyncStatus = *req.SyncStatus
+	}
+	config.LastError = ""
+
+	// Validate Linear config
+	if validationErrors := models.ValidateLinearConfig(&config); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Linear config validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if isNew {
+		_, err = collection.InsertOne(ctx, config)
+	} else {
+		_, err = collection.ReplaceOne(ctx, bson.M{"_id": config.ID}, config)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save Linear config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpsertLinearConfig success - BoardID: %s, UserID: %s, Team: %s, SyncStatus: %t, IP: %s",
+		boardID, userID, config.TeamKey, config.SyncStatus, c.ClientIP())
+
+	config.WebhookURL = linearWebhookURL(config.ID)
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, config)
+}
+
+// DeleteLinearConfig handles DELETE /api/boards/:id/linear. Ideas keep the links to issues created before.
+func DeleteLinearConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.LinearConfigsCollection).DeleteOne(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete Linear config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "LINEAR_NOT_CONFIGURED",
+				"message": "No Linear team is configured for this board",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteLinearConfig success - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Linear config deleted successfully",
+	})
+}
+
+// CreateIdeaLinearIssue handles POST /api/ideas/:id/linear, creating an issue in the board's Linear
+// team from the idea and linking it
+func CreateIdeaLinearIssue(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	if idea.LinearIssue != nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "LINEAR_ISSUE_EXISTS",
+				"message": "This idea is already linked to a Linear issue",
+				"details": idea.LinearIssue.Identifier,
+			},
+		})
+		return
+	}
+
+	config, ok := findLinearConfig(ctx, c, idea.BoardID)
+	if !ok {
+		return
+	}
+	if rejectWithoutEncryption(c) {
+		return
+	}
+
+	apiKey, err := utils.DecryptSecret(config.APIKey)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DECRYPTION_FAILED",
+				"message": "The stored API key cannot be read; reconnect Linear",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	collection := models.GetCollection(ctx, models.LinearConfigsCollection)
+	link, err := utils.CreateLinearIssue(ctx, apiKey, config.TeamID, idea, userID)
+	if err != nil {
+		if _, dbErr := collection.UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{"$set": bson.M{"last_error": err.Error()}}); dbErr != nil {
+			log.Printf("Failed to record Linear error for config %s: %v", config.ID, dbErr)
+		}
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "LINEAR_REQUEST_FAILED",
+				"message": "Linear did not create the issue",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if config.LastError != "" {
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{"$set": bson.M{"last_error": ""}}); err != nil {
+			log.Printf("Failed to clear Linear error for config %s: %v", config.ID, err)
+		}
+	}
+
+	// Only link the issue if nobody linked another one meanwhile
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx,
+		bson.M{"_id": ideaID, "linear_issue": bson.M{"$exists": false}},
+		models.BumpVersion(bson.M{"$set": bson.M{"linear_issue": link, "updated_at": time.Now().UTC(), "updated_by": userID}}))
+	if err != nil {
+		log.Printf("[Handler] CreateIdeaLinearIssue - Issue %s created but not linked: %v, IdeaID: %s", link.Identifier, err, ideaID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "LINEAR_ISSUE_EXISTS",
+					"message": "This idea was linked to another Linear issue meanwhile",
+					"details": link.Identifier,
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to link Linear issue",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	response := newIdeaResponse(*updatedIdea)
+	utils.BroadcastBoardEvent(ctx, idea.BoardID, utils.EventIdeaUpdated, ideaID, response)
+
+	log.Printf("[Handler] CreateIdeaLinearIssue success - IdeaID: %s, BoardID: %s, Issue: %s, UserID: %s, IP: %s",
+		ideaID, idea.BoardID, link.Identifier, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditIdeaLinearLinked, idea.BoardID, models.AuditTargetIdea, ideaID, idea, updatedIdea)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UnlinkIdeaLinearIssue handles DELETE /api/ideas/:id/linear. The issue itself stays in Linear.
+func UnlinkIdeaLinearIssue(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	if idea.LinearIssue == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "LINEAR_ISSUE_NOT_FOUND",
+				"message": "This idea is not linked to a Linear issue",
+			},
+		})
+		return
+	}
+
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID}, models.BumpVersion(bson.M{
+		"$unset": bson.M{"linear_issue": ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC(), "updated_by": userID},
+	}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to unlink Linear issue",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	response := newIdeaResponse(*updatedIdea)
+	utils.BroadcastBoardEvent(ctx, idea.BoardID, utils.EventIdeaUpdated, ideaID, response)
+
+	log.Printf("[Handler] UnlinkIdeaLinearIssue success - IdeaID: %s, BoardID: %s, Issue: %s, UserID: %s, IP: %s",
+		ideaID, idea.BoardID, idea.LinearIssue.Identifier, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditIdeaLinearUnlinked, idea.BoardID, models.AuditTargetIdea, ideaID, idea, updatedIdea)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// HandleLinearWebhook handles POST /api/webhooks/linear/:configId. Events are verified against the
+// config's signing secret; issue updates refresh the linked idea's issue state and, with status sync,
+// move the idea to the column mapped to the state's type. Other events are acknowledged and ignored.
+func HandleLinearWebhook(c *gin.Context) {
+	configID := c.Param("configId")
+
+	ctx := c.Request.Context()
+
+	var config models.LinearConfig
+	err := models.GetCollection(ctx, models.LinearConfigsCollection).FindOne(ctx, bson.M{"_id": configID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "LINEAR_NOT_CONFIGURED",
+					"message": "No Linear team is configured for this webhook",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Linear config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if config.WebhookSecret == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "WEBHOOK_NOT_CONFIGURED",
+				"message": "No webhook signing secret is configured for this board",
+			},
+		})
+		return
+	}
+	secret, err := utils.DecryptSecret(config.WebhookSecret)
+	if err != nil {
+		log.Printf("[Handler] HandleLinearWebhook failed - Secret decryption error: %v, ConfigID: %s", err, configID)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "DECRYPTION_FAILED",
+				"message": "The webhook signing secret cannot be read",
+			},
+		})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Failed to read webhook payload",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := utils.VerifyLinearWebhook(secret, body, c.GetHeader("Linear-Signature"), time.Now()); err != nil {
+		log.Printf("[Handler] HandleLinearWebhook failed - Verification error: %v, ConfigID: %s, IP: %s", err, configID, c.ClientIP())
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_SIGNATURE",
+				"message": "Webhook signature verification failed",
+			},
+		})
+		return
+	}
+
+	var event LinearWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid webhook payload",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	if _, err := models.GetCollection(ctx, models.LinearConfigsCollection).UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{
+		"$set": bson.M{"last_event_at": now},
+	}); err != nil {
+		log.Printf("Failed to record Linear event for config %s: %v", config.ID, err)
+	}
+
+	if event.Type != "Issue" || event.Action != "update" || event.Data.State.Type == "" {
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
+	}
+
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	var idea models.Idea
+	err = ideasCollection.FindOne(ctx, bson.M{"board_id": config.BoardID, "linear_issue.issue_id": event.Data.ID}).Decode(&idea)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("[Handler] HandleLinearWebhook failed - Idea lookup error: %v, ConfigID: %s", err, configID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch linked idea",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
+	}
+
+	stateChanged := event.Data.State.Type != idea.LinearIssue.StateType
+	if _, err := ideasCollection.UpdateOne(ctx, bson.M{"_id": idea.ID}, bson.M{"$set": bson.M{
+		"linear_issue.state":      event.Data.State.Name,
+		"linear_issue.state_type": event.Data.State.Type,
+		"linear_issue.updated_at": now,
+	}}); err != nil {
+		log.Printf("[Handler] HandleLinearWebhook - Failed to update issue state: %v, IdeaID: %s", err, idea.ID)
+	}
+
+	if config.SyncStatus && stateChanged {
+		column := config.StateColumns[event.Data.State.Type]
+		if column == "" {
+			column = idea.Column
+		}
+		applyIntegrationMove(ctx, config.UserID, &idea, column, event.Data.State.Type == models.LinearStateStarted,
+			"linear:"+idea.LinearIssue.Identifier)
+	}
+
+	log.Printf("[Handler] HandleLinearWebhook issue updated - IdeaID: %s, Issue: %s, State: %s, ConfigID: %s",
+		idea.ID, idea.LinearIssue.Identifier, event.Data.State.Name, configID)
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AddMemberRequest represents the request payload for adding a board member
+type AddMemberRequest struct {
+	UserID string `json:"userId" binding:"required"` // Clerk user ID
+	Email  string `json:"email,omitempty" binding:"omitempty,email,max=254"`
+	Role   string `json:"role" binding:"required"`
+}
+
+// UpdateMemberRequest represents the request payload for changing a member's role
+type UpdateMemberRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// rejectInvalidMemberRole writes INVALID_ROLE unless the role can be given to a member
+func rejectInvalidMemberRole(c *gin.Context, role string) bool {
+	if models.IsValidMemberRole(role) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": gin.H{
+			"code":    "INVALID_ROLE",
+			"message": "Role must be editor or viewer",
+		},
+	})
+	return true
+}
+
+// GetBoardMembers handles GET /api/boards/:id/members
+func GetBoardMembers(c *gin.Context) {
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	members := board.Members
+	if members == nil {
+		members = []models.BoardMember{}
+	}
+
+	ctx := c.Request.Context()
+
+	userIDs := []string{board.UserID}
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"owner":   gin.H{"userId": board.UserID, "role": models.RoleOwner},
+		"members": members,
+		"count":   len(members),
+		"users":   findUserProfiles(ctx, userIDs),
+	})
+}
+
+// AddBoardMember handles POST /api/boards/:id/members (owner only)
+func AddBoardMember(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	req.UserID = strings.TrimSpace(req.UserID)
+	if rejectInvalidMemberRole(c, req.Role) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	if req.UserID == board.UserID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "ALREADY_OWNER",
+				"message": "The board owner cannot be added as a member",
+			},
+		})
+		return
+	}
+	if board.RoleOf(req.UserID) != "" {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "MEMBER_EXISTS",
+				"message": "This user is already a member of the board",
+			},
+		})
+		return
+	}
+	if len(board.Members) >= models.MaxBoardMembers {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_MEMBERS",
+				"message": fmt.Sprintf("Boards may have at most %d members", models.MaxBoardMembers),
+			},
+		})
+		return
+	}
+
+	member := models.BoardMember{
+		UserID:  req.UserID,
+		Email:   strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:    models.MemberRole(req.Role),
+		AddedBy: userID,
+		AddedAt: time.Now().UTC(),
+	}
+
+	// Guard against a concurrent add of the same user
+	filter := bson.M{"_id": boardID, "user_id": userID, "members.user_id": bson.M{"$ne": member.UserID}}
+	result, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"members": member},
+		"$set":  bson.M{"updated_at": member.AddedAt, "updated_by": userID},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to add member",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(boardID)
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "MEMBER_EXISTS",
+				"message": "This user is already a member of the board",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AddBoardMember success - BoardID: %s, MemberID: %s, Role: %s, UserID: %s, IP: %s",
+		boardID, member.UserID, member.Role, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditMemberAdded, boardID, models.AuditTargetMember, member.UserID, nil, member)
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateBoardMember handles PUT /api/boards/:id/members/:userId (owner only)
+func UpdateBoardMember(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+	memberID := c.Param("userId")
+
+	// Parse request body
+	var req UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if rejectInvalidMemberRole(c, req.Role) {
+		return
+	}
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+	previous := board.Member(memberID)
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"_id": boardID, "user_id": userID, "members.user_id": memberID}
+	result, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{"members.$.role": req.Role, "updated_at": time.Now().UTC(), "updated_by": userID},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update member",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(boardID)
+	utils.RecheckBoardAccess(boardID)
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "MEMBER_NOT_FOUND",
+				"message": "Member not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateBoardMember success - BoardID: %s, MemberID: %s, Role: %s, UserID: %s, IP: %s",
+		boardID, memberID, req.Role, userID, c.ClientIP())
+
+	if previous != nil {
+		updated := *previous
+		updated.Role = models.MemberRole(req.Role)
+		recordAudit(c, userID, models.AuditMemberUpdated, boardID, models.AuditTargetMember, memberID, previous, updated)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"userId": memberID,
+		"role":   req.Role,
+	})
+}
+
+// RemoveBoardMember handles DELETE /api/boards/:id/members/:userId. The owner may remove anyone;
+// members may remove themselves to leave a board.
+func RemoveBoardMember(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+	memberID := c.Param("userId")
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+	if memberID != userID && !middleware.BoardRole(c, board, userID).Allows(models.RoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "OWNER_REQUIRED",
+				"message": "Only the board owner can remove other members",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"_id": boardID, "members.user_id": memberID}
+	result, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, filter, bson.M{
+		"$pull": bson.M{"members": bson.M{"user_id": memberID}},
+		"$set":  bson.M{"updated_at": time.Now().UTC(), "updated_by": userID},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove member",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	models.InvalidateBoard(boardID)
+	utils.RecheckBoardAccess(boardID)
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "MEMBER_NOT_FOUND",
+				"message": "Member not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] RemoveBoardMember success - BoardID: %s, MemberID: %s, UserID: %s, IP: %s",
+		boardID, memberID, userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditMemberRemoved, boardID, models.AuditTargetMember, memberID, board.Member(memberID), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member removed successfully",
+	})
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// NotificationPreferenceRequest represents the request body for setting notification preferences
+type NotificationPreferenceRequest struct {
+	Channels map[models.NotificationEvent][]models.NotificationChannel `json:"channels"`
+}
+
+// NotificationPreferenceResponse is the caller's effective channels for every event on a board
+type NotificationPreferenceResponse struct {
+	BoardID   string                                                    `json:"boardId"`
+	Channels  map[models.NotificationEvent][]models.NotificationChannel `json:"channels"`
+	Custom    []models.NotificationEvent                                `json:"custom"` // Events the user configured; the others use the defaults
+	UpdatedAt *time.Time                                                `json:"updatedAt,omitempty"`
+}
+
+// newNotificationPreferenceResponse resolves a preference, nil when the user has none, into the
+// channels of every event
+func newNotificationPreferenceResponse(boardID string, preference *models.NotificationPreference) NotificationPreferenceResponse {
+	response := NotificationPreferenceResponse{
+		BoardID:  boardID,
+		Channels: make(map[models.NotificationEvent][]models.NotificationChannel),
+		Custom:   []models.NotificationEvent{},
+	}
+	for _, event := range models.NotificationEvents {
+		channels := preference.ChannelsFor(event)
+		if channels == nil {
+			channels = []models.NotificationChannel{}
+		}
+		response.Channels[event] = channels
+		if preference != nil {
+			if _, ok := preference.Channels[event]; ok {
+				response.Custom = append(response.Custom, event)
+			}
+		}
+	}
+	if preference != nil {
+		response.UpdatedAt = &preference.UpdatedAt
+	}
+	return response
+}
+
+// GetNotificationPreferences handles GET /api/boards/:id/notification-preferences, returning which
+// channels notify the caller of each event on the board
+func GetNotificationPreferences(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	var preference models.NotificationPreference
+	err := models.GetCollection(ctx, models.NotificationPrefsCollection).FindOne(ctx, bson.M{"user_id": userID, "board_id": boardID}).Decode(&preference)
+	if err != nil && err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch notification preferences",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusOK, newNotificationPreferenceResponse(boardID, nil))
+		return
+	}
+	c.JSON(http.StatusOK, newNotificationPreferenceResponse(boardID, &preference))
+}
+
+// UpdateNotificationPreferences handles PUT /api/boards/:id/notification-preferences. The listed
+// events replace the caller's previous choices; events left out use the defaults, and an empty
+// channel list turns an event off.
+func UpdateNotificationPreferences(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req NotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if validationErrors := models.ValidateNotificationChannels(req.Channels); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_NOTIFICATION_PREFERENCES",
+				"message": "Invalid notification preferences",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	// Store each channel once, and an explicit empty list for events turned off
+	channels := make(map[models.NotificationEvent][]models.NotificationChannel, len(req.Channels))
+	for event, selected := range req.Channels {
+		seen := make(map[models.NotificationChannel]bool)
+		channels[event] = []models.NotificationChannel{}
+		for _, channel := range selected {
+			if !seen[channel] {
+				seen[channel] = true
+				channels[event] = append(channels[event], channel)
+			}
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	var preference models.NotificationPreference
+	err := models.GetCollection(ctx, models.NotificationPrefsCollection).FindOneAndUpdate(ctx,
+		bson.M{"user_id": userID, "board_id": boardID},
+		bson.M{
+			"$set": bson.M{
+				"channels":   channels,
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{
+				"_id":        utils.GenerateFullUUID(),
+				"created_at": now,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&preference)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save notification preferences",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateNotificationPreferences success - BoardID: %s, UserID: %s, Events: %d, IP: %s",
+		boardID, userID, len(channels), c.ClientIP())
+
+	c.JSON(http.StatusOK, newNotificationPreferenceResponse(boardID, &preference))
+}
+
+// notifyStatusChange notifies the board's owner and members, except the actor, when an idea's
+// status or column changed, and sends idea.status_changed to the board's webhooks
+func notifyStatusChange(ctx context.Context, existing, updated *models.Idea, actorID string) {
+	var summary string
+	switch {
+	case updated.Column != existing.Column:
+		summary = fmt.Sprintf("\"%s\" moved from %s to %s", updated.OneLiner, existing.Column, updated.Column)
+	case updated.Status != existing.Status:
+		summary = fmt.Sprintf("\"%s\" is now %s", updated.OneLiner, updated.Status)
+	default:
+		return
+	}
+	go utils.SendBoardNotification(ctx, models.NotifyStatusChange, updated.BoardID, updated.ID, summary, actorID)
+	emitIdeaEvent(ctx, models.WebhookIdeaStatusChanged, updated, gin.H{"previous": gin.H{
+		"column":     existing.Column,
+		"status":     existing.Status,
+		"inProgress": existing.InProgress,
+	}})
+}
+
+// AdminListNotificationJobs handles GET /api/admin/notification-jobs, listing queued notification
+// deliveries newest first. Filter with status (pending, processing, done or dead) and boardId.
+func AdminListNotificationJobs(c *gin.Context) {
+	req, ok := bindAdminList(c)
+	if !ok {
+		return
+	}
+
+	filter := bson.M{}
+	if status := c.Query("status"); status != "" {
+		if !models.IsValidNotificationJobStatus(status) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_JOB_STATUS",
+					"message": "Invalid job status: " + status,
+				},
+			})
+			return
+		}
+		filter["status"] = status
+	}
+	if boardID := c.Query("boardId"); boardID != "" {
+		filter["board_id"] = boardID
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.NotificationJobsCollection)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch notification jobs",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []models.NotificationJob{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode notification jobs",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count notification jobs",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":       jobs,
+		"count":      len(jobs),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// AdminRetryNotificationJob handles POST /api/admin/notification-jobs/:jobId/retry, queuing a
+// dead-lettered job again with a fresh set of attempts
+func AdminRetryNotificationJob(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+	jobID := c.Param("jobId")
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	var job models.NotificationJob
+	err := models.GetCollection(ctx, models.NotificationJobsCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": jobID, "status": models.JobDead},
+		bson.M{"$set": bson.M{
+			"status":          models.JobPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+			"updated_at":      now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "JOB_NOT_FOUND",
+					"message": "No dead-lettered notification job with this ID",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to retry notification job",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	utils.WakeNotificationWorkers()
+
+	log.Printf("[Handler] AdminRetryNotificationJob success - JobID: %s, Kind: %s, BoardID: %s, AdminID: %s, IP: %s",
+		jobID, job.Kind, job.BoardID, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, job)
+}
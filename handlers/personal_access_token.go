@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreatePersonalAccessTokenRequest represents the request payload for minting a personal access token
+type CreatePersonalAccessTokenRequest struct {
+	Name          string `json:"name" binding:"required,min=1,max=100"`
+	ExpiresInDays int    `json:"expiresInDays,omitempty" binding:"min=0,max=365"` // 0 never expires
+}
+
+// CreatePersonalAccessTokenResponse returns a new token together with its secret, which is never shown again
+type CreatePersonalAccessTokenResponse struct {
+	models.PersonalAccessToken
+	Secret string `json:"secret"`
+}
+
+// rejectIfPersonalAccessToken writes SESSION_REQUIRED when the request was authenticated with a
+// personal access token, so a leaked token cannot mint or revoke others
+func rejectIfPersonalAccessToken(c *gin.Context) bool {
+	if !middleware.IsPersonalAccessTokenAuth(c) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{
+		"error": gin.H{
+			"code":    "SESSION_REQUIRED",
+			"message": "Personal access tokens can only be managed from a signed-in session",
+		},
+	})
+	return true
+}
+
+// CreatePersonalAccessToken handles POST /api/user/tokens
+func CreatePersonalAccessToken(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+	if rejectIfPersonalAccessToken(c) {
+		return
+	}
+
+	// Parse request body
+	var req CreatePersonalAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.PersonalTokensCollection)
+
+	existing, err := collection.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count personal access tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if existing >= models.MaxPersonalAccessTokens {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_TOKENS",
+				"message": fmt.Sprintf("You may have at most %d personal access tokens", models.MaxPersonalAccessTokens),
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	secret := utils.GeneratePersonalAccessToken()
+	token := models.PersonalAccessToken{
+		ID:        utils.GenerateFullUUID(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		TokenHash: models.HashAPIToken(secret),
+		Prefix:    secret[:len(models.PersonalAccessTokenPrefix)+models.APITokenPrefixLength],
+		TenantID:  models.TenantFromContext(ctx),
+		CreatedAt: now,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := now.AddDate(0, 0, req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if _, err := collection.InsertOne(ctx, token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create personal access token",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreatePersonalAccessToken success - TokenID: %s, ExpiresInDays: %d, UserID: %s, IP: %s",
+		token.ID, req.ExpiresInDays, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, CreatePersonalAccessTokenResponse{PersonalAccessToken: token, Secret: secret})
+}
+
+// ListPersonalAccessTokens handles GET /api/user/tokens
+func ListPersonalAccessTokens(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.PersonalTokensCollection).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch personal access tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []models.PersonalAccessToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode personal access tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+		"count":  len(tokens),
+	})
+}
+
+// RevokePersonalAccessToken handles DELETE /api/user/tokens/:tokenId
+func RevokePersonalAccessToken(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+	if rejectIfPersonalAccessToken(c) {
+		return
+	}
+
+	tokenID := c.Param("tokenId")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.PersonalTokensCollection).DeleteOne(ctx, bson.M{"_id": tokenID, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to revoke personal access token",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "TOKEN_NOT_FOUND",
+				"message": "Personal access token not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] RevokePersonalAccessToken success - TokenID: %s, UserID: %s, IP: %s", tokenID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Personal access token revoked successfully",
+	})
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// SetPollRequest represents the request payload for attaching a poll to an idea
+type SetPollRequest struct {
+	Question string   `json:"question" binding:"required,min=1,max=200"`
+	Options  []string `json:"options" binding:"required"`
+}
+
+// AnswerPollRequest represents the public payload for answering a poll
+type AnswerPollRequest struct {
+	OptionID string `json:"optionId" binding:"required"`
+}
+
+// findOwnedIdea loads an idea and the owner's board, writing IDEA_NOT_FOUND or PERMISSION_DENIED if not
+func findOwnedIdea(ctx context.Context, c *gin.Context, ideaID, userID string) (*models.Idea, *models.Board, bool) {
+	var idea models.Idea
+	err := models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return nil, nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch idea",
+				"details": err.Error(),
+			},
+		})
+		return nil, nil, false
+	}
+
+	board, err := middleware.FindAccessibleBoard(ctx, c, idea.BoardID, userID, models.RoleEditor)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "PERMISSION_DENIED",
+					"message": "You don't have permission to modify this idea",
+				},
+			})
+			return nil, nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to verify board ownership",
+				"details": err.Error(),
+			},
+		})
+		return nil, nil, false
+	}
+	return &idea, board, true
+}
+
+// clearPollAnswers removes the visitor answers of an idea's poll so a new poll starts from zero
+func clearPollAnswers(ctx context.Context, ideaID string) error {
+	_, err := models.GetCollection(ctx, models.VotesCollection).DeleteMany(ctx, bson.M{"idea_id": ideaID, "type": string(models.VotePoll)})
+	return err
+}
+
+// SetIdeaPoll handles PUT /api/ideas/:id/poll. Replacing a poll resets its answers.
+func SetIdeaPoll(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ideaID := c.Param("id")
+
+	// Parse request body
+	var req SetPollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	poll := models.IdeaPoll{
+		Question:  strings.TrimSpace(req.Question),
+		Options:   []models.PollOption{},
+		CreatedAt: time.Now().UTC(),
+	}
+	for _, label := range req.Options {
+		poll.Options = append(poll.Options, models.PollOption{
+			ID:    utils.GenerateShortUUID(),
+			Label: strings.TrimSpace(label),
+		})
+	}
+
+	// Validate poll
+	if validationErrors := models.ValidatePoll(&poll); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Poll validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	if err := clearPollAnswers(ctx, ideaID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to reset poll answers",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID}, models.BumpVersion(bson.M{
+		"$set": bson.M{"poll": poll, "updated_at": time.Now().UTC(), "updated_by": userID},
+	}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save poll",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"poll": updatedIdea.Poll})
+
+	log.Printf("[Handler] SetIdeaPoll success - IdeaID: %s, BoardID: %s, Options: %d, UserID: %s, IP: %s",
+		ideaID, idea.BoardID, len(poll.Options), userID, c.ClientIP())
+
+	recordAudit(c, userID, models.AuditIdeaPollUpdated, idea.BoardID, models.AuditTargetIdea, ideaID, idea, updatedIdea)
+
+	c.JSON(http.StatusOK, newIdeaResponse(*updatedIdea))
+}
+
+// DeleteIdeaPoll handles DELETE /api/ideas/:id/poll
+func DeleteIdeaPoll(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, board, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	if idea.Poll == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "POLL_NOT_FOUND",
+				"message": "This idea has no poll",
+			},
+		})
+		return
+	}
+
+	_, err = models.GetCollection(ctx, models.IdeasCollection).UpdateOne(ctx, bson.M{"_id": ideaID}, models.BumpVersion(bson.M{
+		"$unset": bson.M{"poll": ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC(), "updated_by": userID},
+	}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove poll",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := clearPollAnswers(ctx, ideaID); err != nil {
+		log.Printf("[Handler] DeleteIdeaPoll - Answers cleanup error: %v, IdeaID: %s", err, ideaID)
+	}
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"poll": nil})
+
+	log.Printf("[Handler] DeleteIdeaPoll success - IdeaID: %s, BoardID: %s, UserID: %s, IP: %s",
+		ideaID, idea.BoardID, userID, c.ClientIP())
+
+	withoutPoll := *idea
+	withoutPoll.Poll = nil
+	recordAudit(c, userID, models.AuditIdeaPollDeleted, idea.BoardID, models.AuditTargetIdea, ideaID, idea, withoutPoll)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Poll removed successfully",
+	})
+}
+
+// AnswerPoll handles POST /api/ideas/:id/poll/answer (public endpoint)
+func AnswerPoll(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	// Parse request body
+	var req AnswerPollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idea, ok := findFeedbackIdea(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	if idea.Poll == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "POLL_NOT_FOUND",
+				"message": "This idea has no poll",
+			},
+		})
+		return
+	}
+	if idea.Poll.OptionIndex(req.OptionID) < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_POLL_OPTION",
+				"message": "Unknown poll option",
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	board, ok := feedbackBoardSettings(ctx, c, idea.BoardID)
+	if !ok || rejectBlockedVisitor(c, &board) {
+		return
+	}
+	if rejectIfCaptchaFails(ctx, c, &board) {
+		return
+	}
+
+	// One answer per visitor; the unique votes index rejects repeats
+	vote := models.Vote{
+		ID:          utils.GenerateFullUUID(),
+		BoardID:     idea.BoardID,
+		IdeaID:      idea.ID,
+		VisitorID:   utils.VisitorID(c, board.StrictPrivacy),
+		Type:        string(models.VotePoll),
+		Option:      req.OptionID,
+		CreatedAt:   time.Now().UTC(),
+		Attribution: utils.FeedbackAttribution(c),
+	}
+	if _, err := models.GetCollection(ctx, models.VotesCollection).InsertOne(ctx, vote); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "ALREADY_VOTED",
+					"message": "You have already answered this poll",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to record answer",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Count the answer, guarding against the poll being replaced meanwhile
+	filter := bson.M{
+		"_id":             ideaID,
+		"poll.created_at": idea.Poll.CreatedAt,
+		"poll.options.id": req.OptionID,
+	}
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, filter, bson.M{
+		"$inc": bson.M{"poll.options.$.count": 1},
+	})
+	if err != nil {
+		discardVote(ctx, &vote)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "POLL_CHANGED",
+					"message": "This poll has changed. Please reload and answer again.",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to record answer",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"poll": updatedIdea.Poll})
+
+	// Record activity
+	go utils.RecordActivity(ctx, idea.BoardID, ideaID, "", models.ActivityFeedback, map[string]interface{}{
+		"feedbackType": "poll",
+		"optionId":     req.OptionID,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Answer recorded",
+		"poll":    updatedIdea.Poll,
+	})
+}
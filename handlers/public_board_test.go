@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestPublicBoardReadModel(t *testing.T) {
+	connectTestDatabase(t)
+	ctx := context.Background()
+
+	userID := "user_" + utils.GenerateShortUUID()
+	boardID, _ := createTestBoard(t, userID, "now")
+	board, err := models.FindBoardByID(ctx, boardID)
+	require.NoError(t, err)
+
+	_, err = models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, bson.M{"_id": boardID}, bson.M{"$set": bson.M{
+		"is_public":           true,
+		"visible_columns":     []string{"now"},
+		"members":             []models.BoardMember{{UserID: "user_member", Role: models.RoleEditor, AddedBy: userID, AddedAt: time.Now().UTC()}},
+		"feedback_rate_limit": models.FeedbackRateLimit{},
+	}})
+	require.NoError(t, err)
+	models.InvalidateBoard(boardID)
+
+	t.Run("Stores Only Public Settings", func(t *testing.T) {
+		view, err := utils.LoadPublicBoard(ctx, board.PublicLink)
+		require.NoError(t, err)
+		assert.Equal(t, boardID, view.Board.ID)
+		assert.Len(t, view.Ideas, 1)
+
+		var stored bson.M
+		require.NoError(t, models.GetCollection(ctx, models.PublicBoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Decode(&stored))
+		settings, ok := stored["board"].(bson.M)
+		require.True(t, ok)
+		for _, field := range []string{"user_id", "members", "workspace_id", "feedback_rate_limit", "moderate_comments"} {
+			assert.NotContains(t, settings, field)
+		}
+	})
+
+	t.Run("Board Made Private Is Not Served", func(t *testing.T) {
+		_, err := models.GetCollection(ctx, models.BoardsCollection).UpdateOne(ctx, bson.M{"_id": boardID}, bson.M{"$set": bson.M{"is_public": false}})
+		require.NoError(t, err)
+		models.InvalidateBoard(boardID)
+
+		// The read model is still fresh, but the live board decides
+		_, err = utils.LoadPublicBoard(ctx, board.PublicLink)
+		assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+		count, err := models.GetCollection(ctx, models.PublicBoardsCollection).CountDocuments(ctx, bson.M{"_id": boardID})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreatePushSubscriptionRequest represents the request payload for subscribing a browser to push
+// notifications; it is the JSON of the browser's PushSubscription
+type CreatePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" binding:"required,max=2048"`
+	Keys     struct {
+		P256dh string `json:"p256dh" binding:"required"`
+		Auth   string `json:"auth" binding:"required"`
+	} `json:"keys" binding:"required"`
+}
+
+// GetPushConfig handles GET /api/push/config, returning the VAPID public key browsers subscribe with
+func GetPushConfig(c *gin.Context) {
+	publicKey := utils.VAPIDPublicKey()
+	c.JSON(http.StatusOK, gin.H{
+		"enabled":   publicKey != "",
+		"publicKey": publicKey,
+	})
+}
+
+// CreatePushSubscription handles POST /api/push/subscriptions, subscribing the caller's browser.
+// A browser already subscribed, possibly by another user signed in on it before, is moved to the caller.
+func CreatePushSubscription(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	if utils.VAPIDPublicKey() == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "PUSH_NOT_CONFIGURED",
+				"message": "Push notifications are not configured",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req CreatePushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := utils.ValidateOutboundURL(c.Request.Context(), req.Endpoint); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_PUSH_ENDPOINT",
+				"message": "Push endpoint must be an absolute https URL on the public internet",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if err := utils.ValidatePushKeys(req.Keys.P256dh, req.Keys.Auth); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_PUSH_KEYS",
+				"message": "Invalid push subscription keys",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.PushSubscriptionsCollection)
+	count, err := collection.CountDocuments(ctx, bson.M{"user_id": userID, "endpoint": bson.M{"$ne": req.Endpoint}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count push subscriptions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if count >= models.MaxPushSubscriptionsPerUser {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_PUSH_SUBSCRIPTIONS",
+				"message": "Push notifications are enabled on too many browsers; remove one first",
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	var subscription models.PushSubscription
+	err = collection.FindOneAndUpdate(ctx,
+		bson.M{"endpoint": req.Endpoint},
+		bson.M{
+			"$set": bson.M{
+				"user_id":    userID,
+				"keys":       models.PushSubscriptionKeys{P256dh: req.Keys.P256dh, Auth: req.Keys.Auth},
+				"user_agent": c.GetHeader("User-Agent"),
+			},
+			"$setOnInsert": bson.M{
+				"_id":        utils.GenerateFullUUID(),
+				"created_at": now,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&subscription)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save push subscription",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreatePushSubscription success - SubscriptionID: %s, UserID: %s, IP: %s",
+		subscription.ID, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// ListPushSubscriptions handles GET /api/push/subscriptions, listing the browsers the caller
+// receives push notifications on
+func ListPushSubscriptions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	ctx := c.Request.Context()
+
+	subscriptions, err := models.FindPushSubscriptions(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch push subscriptions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if subscriptions == nil {
+		subscriptions = []models.PushSubscription{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscriptions": subscriptions,
+		"count":         len(subscriptions),
+	})
+}
+
+// DeletePushSubscription handles DELETE /api/push/subscriptions/:subscriptionId
+func DeletePushSubscription(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	subscriptionID := c.Param("subscriptionId")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.PushSubscriptionsCollection).DeleteOne(ctx, bson.M{"_id": subscriptionID, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete push subscription",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "PUSH_SUBSCRIPTION_NOT_FOUND",
+				"message": "Push subscription not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeletePushSubscription success - SubscriptionID: %s, UserID: %s, IP: %s",
+		subscriptionID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Push subscription deleted successfully",
+	})
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePushSubscriptionRejectsPrivateEndpoints(t *testing.T) {
+	t.Setenv("VAPID_PUBLIC_KEY", "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4")
+	t.Setenv("VAPID_PRIVATE_KEY", "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94")
+
+	for _, endpoint := range []string{
+		"https://127.0.0.1/push/abc",
+		"https://169.254.169.254/latest/meta-data/",
+		"https://[::1]:8443/push",
+		"https://localhost/push",
+		"http://93.184.216.34/push",
+	} {
+		body, err := json.Marshal(map[string]interface{}{
+			"endpoint": endpoint,
+			"keys": map[string]string{
+				"p256dh": "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+				"auth":   "BTBZMqHH6r4Tts7J_aSIgg",
+			},
+		})
+		require.NoError(t, err)
+
+		w := serveAs("user_1", http.MethodPost, "/api/push/subscriptions", "/api/push/subscriptions", CreatePushSubscription, string(body))
+		assert.Equal(t, http.StatusBadRequest, w.Code, endpoint)
+		assert.Contains(t, w.Body.String(), "INVALID_PUSH_ENDPOINT", endpoint)
+	}
+}
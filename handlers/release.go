@@ -0,0 +1,459 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CreateReleaseRequest represents the request payload for creating a release
+type CreateReleaseRequest struct {
+	Name  string     `json:"name" binding:"required,min=1,max=100"`
+	Date  *time.Time `json:"date,omitempty"` // RFC 3339, defaults to now
+	Notes string     `json:"notes,omitempty" binding:"max=5000"`
+}
+
+// UpdateReleaseRequest represents the request payload for updating a release
+type UpdateReleaseRequest struct {
+	Name  string     `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Date  *time.Time `json:"date,omitempty"`
+	Notes *string    `json:"notes,omitempty" binding:"omitempty,max=5000"`
+}
+
+// ReleaseResponse represents a release with the number of ideas attached to it
+type ReleaseResponse struct {
+	models.Release
+	IdeasCount int `json:"ideasCount"`
+}
+
+// findOwnedRelease loads a release and verifies the user owns its board, writing an error response if not
+func findOwnedRelease(ctx context.Context, c *gin.Context, releaseID, userID string) (*models.Release, bool) {
+	var release models.Release
+	err := models.GetCollection(ctx, models.ReleasesCollection).FindOne(ctx, bson.M{"_id": releaseID}).Decode(&release)
+	if err == nil {
+		var count int64
+		count, err = models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, boardAccessFilter(c, release.BoardID, userID, models.RoleEditor))
+		if err == nil && count > 0 {
+			return &release, true
+		}
+		if err == nil {
+			err = mongo.ErrNoDocuments
+		}
+	}
+
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "RELEASE_NOT_FOUND",
+				"message": "Release not found or you don't have permission to manage it",
+			},
+		})
+		return nil, false
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": gin.H{
+			"code":    "DATABASE_ERROR",
+			"message": "Failed to fetch release",
+			"details": err.Error(),
+		},
+	})
+	return nil, false
+}
+
+// CreateRelease handles POST /api/boards/:id/releases
+func CreateRelease(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req CreateReleaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	release := models.Release{
+		ID:      utils.GenerateReleaseID(),
+		BoardID: boardID,
+		Name:    req.Name,
+		Date:    time.Now().UTC(),
+		Notes:   req.Notes,
+	}
+	if req.Date != nil {
+		release.Date = req.Date.UTC()
+	}
+
+	// Validate release
+	if validationErrors := models.ValidateRelease(&release); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Release validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.ReleasesCollection).InsertOne(ctx, release); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create release",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateRelease success - ReleaseID: %s, BoardID: %s, UserID: %s, IP: %s",
+		release.ID, boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, ReleaseResponse{Release: release})
+}
+
+// ListReleases handles GET /api/boards/:id/releases
+func ListReleases(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	releases, err := loadBoardReleases(ctx, boardID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch releases",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Count attached ideas per release in one aggregation
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"board_id": boardID, "release_id": bson.M{"$nin": []interface{}{nil, ""}}}},
+		{"$group": bson.M{"_id": "$release_id", "count": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count release ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var counts []struct {
+		ReleaseID string `bson:"_id"`
+		Count     int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &counts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode release idea counts",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	ideasCount := make(map[string]int)
+	for _, entry := range counts {
+		ideasCount[entry.ReleaseID] = entry.Count
+	}
+
+	responses := []ReleaseResponse{}
+	for _, release := range releases {
+		responses = append(responses, ReleaseResponse{Release: release, IdeasCount: ideasCount[release.ID]})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"releases": responses,
+		"count":    len(responses),
+	})
+}
+
+// UpdateRelease handles PUT /api/releases/:id
+func UpdateRelease(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	releaseID := c.Param("id")
+
+	// Parse request body
+	var req UpdateReleaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	release, ok := findOwnedRelease(ctx, c, releaseID, userID)
+	if !ok {
+		return
+	}
+
+	if req.Name != "" {
+		release.Name = req.Name
+	}
+	if req.Date != nil {
+		release.Date = req.Date.UTC()
+	}
+	if req.Notes != nil {
+		release.Notes = *req.Notes
+	}
+
+	// Validate release
+	if validationErrors := models.ValidateRelease(release); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Release validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	update := bson.M{"$set": bson.M{
+		"name":       release.Name,
+		"date":       release.Date,
+		"notes":      release.Notes,
+		"updated_at": release.UpdatedAt,
+	}}
+	if _, err := models.GetCollection(ctx, models.ReleasesCollection).UpdateOne(ctx, bson.M{"_id": releaseID}, update); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update release",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateRelease success - ReleaseID: %s, BoardID: %s, UserID: %s, IP: %s",
+		releaseID, release.BoardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, ReleaseResponse{Release: *release})
+}
+
+// DeleteRelease handles DELETE /api/releases/:id
+func DeleteRelease(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	releaseID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	release, ok := findOwnedRelease(ctx, c, releaseID, userID)
+	if !ok {
+		return
+	}
+
+	// Detach ideas first so none point at a missing release
+	detached, err := models.GetCollection(ctx, models.IdeasCollection).UpdateMany(ctx,
+		bson.M{"board_id": release.BoardID, "release_id": releaseID},
+		bson.M{"$unset": bson.M{"release_id": ""}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to detach ideas from release",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.ReleasesCollection).DeleteOne(ctx, bson.M{"_id": releaseID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete release",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteRelease success - ReleaseID: %s, BoardID: %s, UserID: %s, DetachedIdeas: %d, IP: %s",
+		releaseID, release.BoardID, userID, detached.ModifiedCount, c.ClientIP())
+
+	if detached.ModifiedCount > 0 {
+		utils.BroadcastBoardEvent(ctx, release.BoardID, utils.EventIdeasUpdated, "", map[string]interface{}{
+			"releaseId": releaseID,
+			"count":     detached.ModifiedCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Release deleted successfully",
+		"detachedIdeas": detached.ModifiedCount,
+	})
+}
+
+// loadBoardReleases returns a board's releases, newest first
+func loadBoardReleases(ctx context.Context, boardID string) ([]models.Release, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.ReleasesCollection).Find(ctx, bson.M{"board_id": boardID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	releases := []models.Release{}
+	if err := cursor.All(ctx, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// maxGroupedReleasedIdeas caps how many released ideas are returned when grouping by release,
+// since grouped responses are not paginated
+const maxGroupedReleasedIdeas = 500
+
+// ReleaseGroup is one release and the released ideas attached to it.
+// Release is nil for the trailing group of ideas not attached to any release.
+type ReleaseGroup struct {
+	Release *models.Release `json:"release"`
+	Ideas   []interface{}   `json:"ideas"`
+	Count   int             `json:"count"`
+}
+
+// respondReleasedIdeasByRelease writes the released ideas matching filter grouped by release,
+// newest release first with unassigned ideas last
+func respondReleasedIdeasByRelease(ctx context.Context, c *gin.Context, boardID string, filter bson.M, sort bson.D, toResponse func(models.Idea) interface{}) {
+	opts := options.Find().SetSort(sort).SetLimit(maxGroupedReleasedIdeas)
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch released ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var ideas []models.Idea
+	if err := cursor.All(ctx, &ideas); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode released ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	releases, err := loadBoardReleases(ctx, boardID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch releases",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	groupIndex := make(map[string]int, len(releases))
+	groups := make([]ReleaseGroup, 0, len(releases)+1)
+	for i := range releases {
+		groupIndex[releases[i].ID] = len(groups)
+		groups = append(groups, ReleaseGroup{Release: &releases[i], Ideas: []interface{}{}})
+	}
+	unassigned := ReleaseGroup{Ideas: []interface{}{}}
+
+	for _, idea := range ideas {
+		if index, ok := groupIndex[idea.ReleaseID]; ok {
+			groups[index].Ideas = append(groups[index].Ideas, toResponse(idea))
+			groups[index].Count++
+			continue
+		}
+		unassigned.Ideas = append(unassigned.Ideas, toResponse(idea))
+		unassigned.Count++
+	}
+
+	// Drop releases without matching ideas so search results stay compact
+	visible := []ReleaseGroup{}
+	for _, group := range groups {
+		if group.Count > 0 {
+			visible = append(visible, group)
+		}
+	}
+	if unassigned.Count > 0 {
+		visible = append(visible, unassigned)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"groupBy":  "release",
+		"releases": visible,
+		"count":    len(ideas),
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// GetScoreHistoryRequest represents query parameters for an idea's score history
+type GetScoreHistoryRequest struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
+
+// GetIdeaScoreHistory handles GET /api/ideas/:id/score-history for editors of the idea's board
+func GetIdeaScoreHistory(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ideaID := c.Param("id")
+
+	// Parse query parameters
+	var req GetScoreHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 50
+	}
+
+	ctx := c.Request.Context()
+
+	idea, _, ok := findOwnedIdea(ctx, c, ideaID, userID)
+	if !ok {
+		return
+	}
+
+	// Oldest first, so the snapshots read as the score's timeline
+	filter := bson.M{"idea_id": ideaID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	historyCollection := models.GetCollection(ctx, models.ScoreHistoryCollection)
+	cursor, err := historyCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch score history",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	snapshots := []models.ScoreSnapshot{}
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode score history",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := historyCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count score history",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	actorIDs := []string{}
+	for _, snapshot := range snapshots {
+		if snapshot.ChangedBy != "" {
+			actorIDs = append(actorIDs, snapshot.ChangedBy)
+		}
+	}
+
+	log.Printf("[Handler] GetIdeaScoreHistory success - IdeaID: %s, Snapshots: %d, Total: %d, UserID: %s, IP: %s",
+		ideaID, len(snapshots), totalCount, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"ideaId":       ideaID,
+		"riceScore":    idea.RiceScore,
+		"currentTotal": idea.RiceScore.CalculateRICEScore(),
+		"snapshots":    snapshots,
+		"users":        findUserProfiles(ctx, actorIDs),
+		"count":        len(snapshots),
+		"totalCount":   totalCount,
+		"page":         req.Page,
+		"pageSize":     req.PageSize,
+		"totalPages":   (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// seedInsertBatch is how many ideas are inserted per InsertMany
+const seedInsertBatch = 1000
+
+// maxSeedLoadIdeas caps how many seeded ideas a load test spreads its feedback over
+const maxSeedLoadIdeas = 100000
+
+// SeedDataRequest represents the synthetic data to create. The same seed creates the same boards
+// and ideas, apart from their IDs and timestamps.
+type SeedDataRequest struct {
+	Boards        int   `json:"boards" binding:"required,min=1,max=100"`
+	IdeasPerBoard int   `json:"ideasPerBoard" binding:"min=0,max=5000"`
+	MaxFeedback   int   `json:"maxFeedback" binding:"min=0,max=10000"` // Upper bound of each idea's thumbs up, reactions and votes
+	Seed          int64 `json:"seed"`                                  // 0 uses 1
+}
+
+// SeedLoadRequest represents a load test: thumbs up on random seeded ideas at a steady rate, each
+// written and broadcast like a visitor's
+type SeedLoadRequest struct {
+	Rate    int   `json:"rate" binding:"required,min=1,max=1000"` // Feedback events per second
+	Seconds int   `json:"seconds" binding:"required,min=1,max=600"`
+	Seed    int64 `json:"seed"` // 0 uses 1
+}
+
+// SeedLoadReport describes the running or last load test
+type SeedLoadReport struct {
+	Running          bool       `json:"running"`
+	Rate             int        `json:"rate"`
+	Seconds          int        `json:"seconds"`
+	Ideas            int        `json:"ideas"`
+	Events           int64      `json:"events"`
+	Failures         int64      `json:"failures"`
+	AverageLatencyMs float64    `json:"averageLatencyMs"` // Of the feedback writes
+	MaxLatencyMs     float64    `json:"maxLatencyMs"`
+	StartedAt        time.Time  `json:"startedAt"`
+	FinishedAt       *time.Time `json:"finishedAt,omitempty"`
+}
+
+// seedLoad holds the running or last load test; only one runs at a time
+var (
+	seedLoadMu     sync.Mutex
+	seedLoadReport *SeedLoadReport
+)
+
+// seedIdea pairs a seeded idea with its board for load tests
+type seedIdea struct {
+	ID      string `bson:"_id"`
+	BoardID string `bson:"board_id"`
+}
+
+// Word lists the synthetic ideas are built from
+var (
+	seedVerbs      = []string{"Add", "Improve", "Redesign", "Speed up", "Simplify", "Automate", "Export", "Share", "Sync", "Localize"}
+	seedAdjectives = []string{"bulk", "dark-mode", "mobile", "offline", "shared", "scheduled", "custom", "weekly", "team", "public"}
+	seedNouns      = []string{"reports", "dashboards", "invoices", "onboarding", "search", "notifications", "calendar", "exports", "billing", "comments"}
+	seedTags       = []string{"ux", "performance", "mobile", "integrations", "billing", "api", "security"}
+	seedEfforts    = []int{1, 3, 8, 21}
+)
+
+// AdminSeedData handles POST /api/admin/seed, creating public boards owned by the admin with
+// synthetic ideas and feedback so listing, search and broadcast performance can be measured
+// repeatably. Only registered when SEED_DATA_ENABLED=true.
+func AdminSeedData(c *gin.Context) {
+	startTime := time.Now()
+	adminID, _ := middleware.GetUserID(c)
+
+	var req SeedDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Seed == 0 {
+		req.Seed = 1
+	}
+
+	ctx := c.Request.Context()
+	rng := rand.New(rand.NewSource(req.Seed))
+	seedRun := utils.GenerateShortUUID()
+	now := time.Now().UTC()
+
+	boards := make([]models.Board, 0, req.Boards)
+	boardDocs := make([]interface{}, 0, req.Boards)
+	for i := 0; i < req.Boards; i++ {
+		board := models.Board{
+			ID:             utils.GenerateBoardID(),
+			Name:           fmt.Sprintf("Seed board %d", i+1),
+			Description:    fmt.Sprintf("Synthetic data (seed %d, run %s)", req.Seed, seedRun),
+			PublicLink:     utils.GenerateShortUUID(),
+			IsPublic:       true,
+			UserID:         adminID,
+			VisibleColumns: models.GetDefaultVisibleColumns(),
+			VisibleFields:  models.GetDefaultVisibleFields(),
+			VoteOptions:    []string{"Must have", "Nice to have"},
+			SeedRun:        seedRun,
+			CreatedBy:      adminID,
+			UpdatedBy:      adminID,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		boards = append(boards, board)
+		boardDocs = append(boardDocs, board)
+	}
+	if _, err := models.GetCollection(ctx, models.BoardsCollection).InsertMany(ctx, boardDocs); err != nil {
+		log.Printf("[Handler] AdminSeedData failed - Board insert error: %v, AdminID: %s, IP: %s", err, adminID, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create seed boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ideasCreated := 0
+	batch := make([]interface{}, 0, seedInsertBatch)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := models.GetCollection(ctx, models.IdeasCollection).InsertMany(ctx, batch, options.InsertMany().SetOrdered(false))
+		ideasCreated += len(batch)
+		batch = batch[:0]
+		return err
+	}
+	for _, board := range boards {
+		positions := map[string]int{}
+		for i := 0; i < req.IdeasPerBoard; i++ {
+			idea := newSeedIdea(rng, &board, positions, req.MaxFeedback, now)
+			batch = append(batch, idea)
+			if len(batch) == seedInsertBatch {
+				if err := flush(); err != nil {
+					respondSeedIdeasError(c, err, adminID, seedRun)
+					return
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		respondSeedIdeasError(c, err, adminID, seedRun)
+		return
+	}
+
+	summaries := make([]gin.H, 0, len(boards))
+	for _, board := range boards {
+		summaries = append(summaries, gin.H{"id": board.ID, "publicLink": board.PublicLink})
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("[Handler] AdminSeedData success - SeedRun: %s, Boards: %d, Ideas: %d, Seed: %d, Duration: %v, AdminID: %s, IP: %s",
+		seedRun, len(boards), ideasCreated, req.Seed, duration, adminID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"seedRun":    seedRun,
+		"seed":       req.Seed,
+		"boards":     summaries,
+		"ideas":      ideasCreated,
+		"durationMs": duration.Milliseconds(),
+	})
+}
+
+// newSeedIdea builds a synthetic idea in a random column of the board, next in that column's order
+func newSeedIdea(rng *rand.Rand, board *models.Board, positions map[string]int, maxFeedback int, now time.Time) models.Idea {
+	column := board.VisibleColumns[rng.Intn(len(board.VisibleColumns))]
+	positions[column]++
+	createdAt := now.Add(-time.Duration(rng.Intn(90*24)) * time.Hour)
+	oneLiner := fmt.Sprintf("%s %s %s", seedVerbs[rng.Intn(len(seedVerbs))],
+		seedAdjectives[rng.Intn(len(seedAdjectives))], seedNouns[rng.Intn(len(seedNouns))])
+
+	idea := models.Idea{
+		ID:             utils.GenerateIdeaID(),
+		BoardID:        board.ID,
+		OneLiner:       oneLiner,
+		Description:    fmt.Sprintf("Customers asked for this %d times in the last quarter.", rng.Intn(50)+1),
+		ValueStatement: fmt.Sprintf("Saves teams about %d minutes a week.", rng.Intn(120)+5),
+		RiceScore: models.RICEScore{
+			Reach:      rng.Intn(11),
+			Impact:     rng.Intn(11),
+			Confidence: rng.Intn(11),
+			Effort:     seedEfforts[rng.Intn(len(seedEfforts))],
+		},
+		Column:         column,
+		Position:       positions[column],
+		InProgress:     column == string(models.ColumnNow) && rng.Intn(2) == 0,
+		Status:         string(models.StatusActive),
+		Tags:           []string{seedTags[rng.Intn(len(seedTags))]},
+		EmojiReactions: []models.EmojiReaction{},
+		CreatedBy:      board.UserID,
+		UpdatedBy:      board.UserID,
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt.Add(time.Duration(rng.Intn(24*60)) * time.Minute),
+	}
+
+	if maxFeedback > 0 {
+		idea.ThumbsUp = rng.Intn(maxFeedback + 1)
+		reactions := board.AllowedReactions()
+		for _, index := range rng.Perm(len(reactions))[:min(3, len(reactions))] {
+			idea.EmojiReactions = append(idea.EmojiReactions, models.EmojiReaction{
+				Emoji: reactions[index],
+				Count: rng.Intn(maxFeedback + 1),
+			})
+		}
+		idea.Votes = map[string]int{}
+		for _, option := range board.VoteOptions {
+			idea.Votes[option] = rng.Intn(maxFeedback + 1)
+		}
+	}
+	return idea
+}
+
+// respondSeedIdeasError reports a failed idea insert; the run's boards stay for AdminDeleteSeedData
+func respondSeedIdeasError(c *gin.Context, err error, adminID, seedRun string) {
+	log.Printf("[Handler] AdminSeedData failed - Idea insert error: %v, SeedRun: %s, AdminID: %s, IP: %s", err, seedRun, adminID, c.ClientIP())
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": gin.H{
+			"code":    "DATABASE_ERROR",
+			"message": "Failed to create seed ideas",
+			"details": err.Error(),
+		},
+	})
+}
+
+// AdminDeleteSeedData handles DELETE /api/admin/seed, deleting every seeded board and everything on it
+func AdminDeleteSeedData(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+	ctx := c.Request.Context()
+	defer models.InvalidateBoards()
+
+	boardIDs, err := findOwnedBoardIDs(ctx, bson.M{"seed_run": bson.M{"$exists": true}})
+	report := &AccountDeletionReport{}
+	if err == nil {
+		err = deleteBoards(ctx, boardIDs, report)
+	}
+	if err != nil {
+		log.Printf("[Handler] AdminDeleteSeedData failed - Error: %v, AdminID: %s, IP: %s", err, adminID, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete seed data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] AdminDeleteSeedData success - Boards: %d, Ideas: %d, AdminID: %s, IP: %s",
+		report.BoardsDeleted, report.IdeasDeleted, adminID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"boardsDeleted":   report.BoardsDeleted,
+		"ideasDeleted":    report.IdeasDeleted,
+		"feedbackDeleted": report.FeedbackDeleted,
+	})
+}
+
+// AdminStartSeedLoad handles POST /api/admin/seed/load, starting a load test on the seeded boards
+func AdminStartSeedLoad(c *gin.Context) {
+	adminID, _ := middleware.GetUserID(c)
+
+	var req SeedLoadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if req.Seed == 0 {
+		req.Seed = 1
+	}
+
+	ctx := c.Request.Context()
+	boardIDs, err := findOwnedBoardIDs(ctx, bson.M{"seed_run": bson.M{"$exists": true}})
+	var ideas []seedIdea
+	if err == nil && len(boardIDs) > 0 {
+		var cursor *mongo.Cursor
+		cursor, err = models.GetCollection(ctx, models.IdeasCollection).Find(ctx, bson.M{"board_id": bson.M{"$in": boardIDs}},
+			options.Find().SetProjection(bson.M{"_id": 1, "board_id": 1}).SetLimit(maxSeedLoadIdeas))
+		if err == nil {
+			err = cursor.All(ctx, &ideas)
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch seed ideas",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if len(ideas) == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "NO_SEED_DATA",
+				"message": "Create seed data with POST /api/admin/seed first",
+			},
+		})
+		return
+	}
+
+	seedLoadMu.Lock()
+	if seedLoadReport != nil && seedLoadReport.Running {
+		seedLoadMu.Unlock()
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "LOAD_TEST_RUNNING",
+				"message": "A load test is already running",
+			},
+		})
+		return
+	}
+	seedLoadReport = &SeedLoadReport{
+		Running:   true,
+		Rate:      req.Rate,
+		Seconds:   req.Seconds,
+		Ideas:     len(ideas),
+		StartedAt: time.Now().UTC(),
+	}
+	report := *seedLoadReport
+	seedLoadMu.Unlock()
+
+	go runSeedLoad(models.DetachTenant(ctx), req, ideas)
+
+	log.Printf("[Handler] AdminStartSeedLoad success - Rate: %d/s, Seconds: %d, Ideas: %d, AdminID: %s, IP: %s",
+		req.Rate, req.Seconds, len(ideas), adminID, c.ClientIP())
+	c.JSON(http.StatusAccepted, report)
+}
+
+// AdminGetSeedLoad handles GET /api/admin/seed/load, returning the running or last load test
+func AdminGetSeedLoad(c *gin.Context) {
+	seedLoadMu.Lock()
+	defer seedLoadMu.Unlock()
+	if seedLoadReport == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NO_LOAD_TEST",
+				"message": "No load test has run yet",
+			},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, seedLoadReport)
+}
+
+// runSeedLoad gives random ideas a thumbs up at the requested rate, broadcasting each like a
+// visitor's feedback, and records the write latencies. Ticks missed while writes are slow are
+// dropped, so fewer events than rate × seconds show the database could not keep up.
+func runSeedLoad(ctx context.Context, req SeedLoadRequest, ideas []seedIdea) {
+	rng := rand.New(rand.NewSource(req.Seed))
+	ticker := time.NewTicker(time.Second / time.Duration(req.Rate))
+	defer ticker.Stop()
+	deadline := time.After(time.Duration(req.Seconds) * time.Second)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+
+	var events, failures int64
+	var total, slowest time.Duration
+	record := func(finished bool) {
+		seedLoadMu.Lock()
+		defer seedLoadMu.Unlock()
+		seedLoadReport.Events = events
+		seedLoadReport.Failures = failures
+		if events > 0 {
+			seedLoadReport.AverageLatencyMs = float64(total.Microseconds()) / float64(events) / 1000
+		}
+		seedLoadReport.MaxLatencyMs = float64(slowest.Microseconds()) / 1000
+		if finished {
+			now := time.Now().UTC()
+			seedLoadReport.Running = false
+			seedLoadReport.FinishedAt = &now
+		}
+	}
+
+	for {
+		select {
+		case <-deadline:
+			record(true)
+			log.Printf("[Seed] Load test finished - Events: %d, Failures: %d, Rate: %d/s, Seconds: %d",
+				events, failures, req.Rate, req.Seconds)
+			return
+		case <-ticker.C:
+		}
+
+		idea := ideas[rng.Intn(len(ideas))]
+		writeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		started := time.Now()
+		_, err := ideasCollection.UpdateOne(writeCtx, bson.M{"_id": idea.ID}, bson.M{
+			"$inc": bson.M{"thumbs_up": 1},
+			"$set": bson.M{"updated_at": time.Now().UTC()},
+		})
+		latency := time.Since(started)
+		cancel()
+
+		events++
+		total += latency
+		if latency > slowest {
+			slowest = latency
+		}
+		if err != nil {
+			failures++
+		} else {
+			utils.BroadcastFeedbackAnimation(ctx, idea.BoardID, idea.ID, "thumbsup", "")
+		}
+		if events%int64(req.Rate) == 0 {
+			record(false)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ServiceAccountRequest represents the request payload for creating a service account
+type ServiceAccountRequest struct {
+	Name   string                       `json:"name" binding:"required,min=1,max=100"`
+	Scopes []models.ServiceAccountScope `json:"scopes" binding:"required"`
+}
+
+// UpdateServiceAccountRequest represents the request payload for changing a service account
+type UpdateServiceAccountRequest struct {
+	Name   string                        `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Scopes *[]models.ServiceAccountScope `json:"scopes,omitempty"`
+}
+
+// CreateServiceAccountResponse returns a new service account together with its secret, which is never shown again
+type CreateServiceAccountResponse struct {
+	models.ServiceAccount
+	Secret string `json:"secret"`
+}
+
+// rejectInvalidServiceScopes validates a scope list and checks that the user owns every scoped board,
+// writing the error response if not
+func rejectInvalidServiceScopes(ctx context.Context, c *gin.Context, scopes []models.ServiceAccountScope, userID string) bool {
+	if err := models.ValidateServiceAccountScopes(scopes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_SCOPES",
+				"message": "Invalid service account scopes",
+				"details": err.Error(),
+			},
+		})
+		return true
+	}
+
+	account := models.ServiceAccount{Scopes: scopes}
+	boardIDs := account.BoardIDs()
+	owned, err := models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, bson.M{"_id": bson.M{"$in": boardIDs}, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to verify boards",
+				"details": err.Error(),
+			},
+		})
+		return true
+	}
+	if owned != int64(len(boardIDs)) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "BOARD_NOT_FOUND",
+				"message": "Service accounts can only be scoped to boards you own",
+			},
+		})
+		return true
+	}
+	return false
+}
+
+// CreateServiceAccount handles POST /api/service-accounts
+func CreateServiceAccount(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+	if rejectIfPersonalAccessToken(c) {
+		return
+	}
+
+	// Parse request body
+	var req ServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if rejectInvalidServiceScopes(ctx, c, req.Scopes, userID) {
+		return
+	}
+
+	collection := models.GetCollection(ctx, models.ServiceAccountsCollection)
+
+	existing, err := collection.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count service accounts",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if existing >= models.MaxServiceAccounts {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_SERVICE_ACCOUNTS",
+				"message": fmt.Sprintf("You may have at most %d service accounts", models.MaxServiceAccounts),
+			},
+		})
+		return
+	}
+
+	secret := utils.GenerateServiceAccountSecret()
+	account := models.ServiceAccount{
+		ID:        utils.GenerateFullUUID(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		Scopes:    req.Scopes,
+		TokenHash: models.HashAPIToken(secret),
+		Prefix:    secret[:len(models.ServiceAccountTokenPrefix)+models.APITokenPrefixLength],
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if _, err := collection.InsertOne(ctx, account); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create service account",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateServiceAccount success - AccountID: %s, Boards: %d, UserID: %s, IP: %s",
+		account.ID, len(account.Scopes), userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, CreateServiceAccountResponse{ServiceAccount: account, Secret: secret})
+}
+
+// ListServiceAccounts handles GET /api/service-accounts
+func ListServiceAccounts(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.ServiceAccountsCollection).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch service accounts",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	accounts := []models.ServiceAccount{}
+	if err := cursor.All(ctx, &accounts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode service accounts",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"serviceAccounts": accounts,
+		"count":           len(accounts),
+	})
+}
+
+// UpdateServiceAccount handles PUT /api/service-accounts/:accountId, renaming it or replacing its scopes
+func UpdateServiceAccount(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+	if rejectIfPersonalAccessToken(c) {
+		return
+	}
+
+	accountID := c.Param("accountId")
+
+	// Parse request body
+	var req UpdateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	updateDoc := bson.M{}
+	if name := strings.TrimSpace(req.Name); name != "" {
+		updateDoc["name"] = name
+	}
+	if req.Scopes != nil {
+		if rejectInvalidServiceScopes(ctx, c, *req.Scopes, userID) {
+			return
+		}
+		updateDoc["scopes"] = *req.Scopes
+	}
+	if len(updateDoc) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Nothing to update",
+				"details": "provide name or scopes",
+			},
+		})
+		return
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var account models.ServiceAccount
+	err = models.GetCollection(ctx, models.ServiceAccountsCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": accountID, "user_id": userID}, bson.M{"$set": updateDoc}, opts).Decode(&account)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "SERVICE_ACCOUNT_NOT_FOUND",
+					"message": "Service account not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update service account",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateServiceAccount success - AccountID: %s, Boards: %d, UserID: %s, IP: %s",
+		account.ID, len(account.Scopes), userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, account)
+}
+
+// DeleteServiceAccount handles DELETE /api/service-accounts/:accountId
+func DeleteServiceAccount(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+	if rejectIfPersonalAccessToken(c) {
+		return
+	}
+
+	accountID := c.Param("accountId")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.ServiceAccountsCollection).DeleteOne(ctx, bson.M{"_id": accountID, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete service account",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "SERVICE_ACCOUNT_NOT_FOUND",
+				"message": "Service account not found",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteServiceAccount success - AccountID: %s, UserID: %s, IP: %s", accountID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Service account deleted successfully",
+	})
+}
+
+// ServiceCreateIdea handles POST /api/service/boards/:id/ideas (service account with ideas:create)
+func ServiceCreateIdea(c *gin.Context) {
+	account, err := middleware.GetServiceAccount(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get service account",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req CreateIdeaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if rejectInvalidRiceScore(c, req.RiceScore) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	board, ok := findAPITokenBoard(ctx, c)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	log.Printf("[Handler] ServiceCreateIdea - AccountID: %s, BoardID: %s, OneLiner: %s, IP: %s",
+		account.ID, board.ID, req.OneLiner, c.ClientIP())
+
+	insertIdea(ctx, c, board, &req, account.ActorID())
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"context"
 	"log"
 	"net/http"
 	"time"
@@ -44,8 +43,7 @@ func GetStats(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
 	// Initialize stats
 	stats := gin.H{
@@ -55,7 +53,7 @@ func GetStats(c *gin.Context) {
 	}
 
 	// Count boards for this user
-	boardsCollection := models.GetCollection(models.BoardsCollection)
+	boardsCollection := models.GetCollection(ctx, models.BoardsCollection)
 	boardsCount, err := boardsCollection.CountDocuments(ctx, bson.M{"user_id": userID})
 	if err != nil {
 		log.Printf("[Stats] Error counting boards for user %s: %v - IP: %s", userID, err, c.ClientIP())
@@ -65,7 +63,7 @@ func GetStats(c *gin.Context) {
 	}
 
 	// Count ideas for this user's boards
-	ideasCollection := models.GetCollection(models.IdeasCollection)
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 	ideasCount, err := ideasCollection.CountDocuments(ctx, bson.M{"user_id": userID})
 	if err != nil {
 		log.Printf("[Stats] Error counting ideas for user %s: %v - IP: %s", userID, err, c.ClientIP())
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inlineContentTypes are shown by browsers in place; everything else is downloaded, so uploaded HTML
+// or SVG never runs on the app's origin
+var inlineContentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+// DownloadLink is a signed URL for a stored file
+type DownloadLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// signedDownload returns a download link for key, saved as filename when set. Returns nil when the
+// link cannot be signed, so responses still list the file.
+func signedDownload(key, filename string) *DownloadLink {
+	if key == "" {
+		return nil
+	}
+	signed, expires, err := utils.SignedStorageURL(key, utils.StorageURLTTL(), filename)
+	if err != nil {
+		log.Printf("[Handler] Failed to sign download URL - Key: %s, Error: %v", key, err)
+		return nil
+	}
+	return &DownloadLink{URL: signed, ExpiresAt: expires}
+}
+
+// DownloadFile handles GET /api/files/*key, serving stored files to holders of a link signed by
+// utils.SignedStorageURL
+func DownloadFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	filename := c.Query("filename")
+	if !utils.VerifyStorageSignature(key, c.Query("expires"), filename, c.Query("signature")) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_SIGNATURE",
+				"message": "This download link is invalid or has expired",
+			},
+		})
+		return
+	}
+
+	// Links are signed per file and expire, so browsers may keep the file until then
+	cacheControl := "private, no-cache"
+	if expires, err := strconv.ParseInt(c.Query("expires"), 10, 64); err == nil {
+		cacheControl = fmt.Sprintf("private, max-age=%d", max(expires-time.Now().Unix(), 0))
+	}
+	serveStoredFile(c, key, filename, cacheControl)
+}
+
+// serveStoredFile streams a stored file, writing FILE_NOT_FOUND when it does not exist. Types that
+// are not safe to show in place are sent as downloads.
+func serveStoredFile(c *gin.Context, key, filename, cacheControl string) {
+	ctx := c.Request.Context()
+
+	reader, object, err := utils.GetStorage().Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, utils.ErrObjectNotFound) || errors.Is(err, utils.ErrInvalidStorageKey) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "FILE_NOT_FOUND",
+					"message": "File not found",
+				},
+			})
+			return
+		}
+
+		log.Printf("[Handler] serveStoredFile failed - Key: %s, Error: %v, IP: %s", key, err, c.ClientIP())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "STORAGE_ERROR",
+				"message": "Failed to read file",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer reader.Close()
+
+	disposition := "attachment"
+	contentType := strings.TrimSpace(strings.Split(object.ContentType, ";")[0])
+	if inlineContentTypes[contentType] {
+		disposition = "inline"
+	}
+
+	c.Header("Content-Type", object.ContentType)
+	c.Header("Content-Disposition", utils.ContentDisposition(disposition, filename))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "default-src 'none'; sandbox")
+	c.Header("Cache-Control", cacheControl)
+	if !object.ModifiedAt.IsZero() {
+		c.Header("Last-Modified", object.ModifiedAt.UTC().Format(http.TimeFormat))
+	}
+	if object.Size >= 0 {
+		c.Header("Content-Length", strconv.FormatInt(object.Size, 10))
+	}
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		log.Printf("[Handler] serveStoredFile interrupted - Key: %s, Error: %v, IP: %s", key, err, c.ClientIP())
+		c.Abort()
+	}
+}
+
+// readUpload reads the "file" field of a multipart upload of at most maxSize bytes, writing an error
+// response and returning false when it is missing or too large
+func readUpload(c *gin.Context, maxSize int64) ([]byte, string, bool) {
+	// Leave room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+64<<10)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondFileTooLarge(c, maxSize)
+			return nil, "", false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Send the file as the file field of a multipart form",
+				"details": err.Error(),
+			},
+		})
+		return nil, "", false
+	}
+	if header.Size > maxSize {
+		respondFileTooLarge(c, maxSize)
+		return nil, "", false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Failed to read the uploaded file",
+				"details": err.Error(),
+			},
+		})
+		return nil, "", false
+	}
+	defer file.Close()
+
+	body, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Failed to read the uploaded file",
+				"details": err.Error(),
+			},
+		})
+		return nil, "", false
+	}
+	if int64(len(body)) > maxSize {
+		respondFileTooLarge(c, maxSize)
+		return nil, "", false
+	}
+	if len(body) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "The uploaded file is empty",
+			},
+		})
+		return nil, "", false
+	}
+	return body, header.Filename, true
+}
+
+// respondFileTooLarge rejects an upload over the size limit
+func respondFileTooLarge(c *gin.Context, maxSize int64) {
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": gin.H{
+			"code":    "FILE_TOO_LARGE",
+			"message": fmt.Sprintf("Files may be at most %d KB", maxSize>>10),
+		},
+	})
+}
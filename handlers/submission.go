@@ -0,0 +1,669 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SubmitIdeaRequest represents the public payload for suggesting an idea
+type SubmitIdeaRequest struct {
+	OneLiner       string `json:"oneLiner" binding:"required,min=1,max=200"`
+	Description    string `json:"description,omitempty" binding:"max=1000"`
+	ValueStatement string `json:"valueStatement,omitempty" binding:"max=500"`
+	SubmitterName  string `json:"submitterName,omitempty" binding:"max=100"`
+	SubmitterEmail string `json:"submitterEmail,omitempty" binding:"omitempty,email"`
+	Website        string `json:"website,omitempty"` // Honeypot: hidden from humans, filled in by bots
+}
+
+// UpdateSubmissionRequest represents the owner's edits to a pending submission
+type UpdateSubmissionRequest struct {
+	OneLiner       string  `json:"oneLiner,omitempty" binding:"omitempty,min=1,max=200"`
+	Description    *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	ValueStatement *string `json:"valueStatement,omitempty" binding:"omitempty,max=500"`
+}
+
+// ApproveSubmissionRequest represents optional placement for the idea created on approval
+type ApproveSubmissionRequest struct {
+	Column string `json:"column,omitempty"` // Defaults to parking
+}
+
+// GetSubmissionsRequest represents query parameters for the moderation queue
+type GetSubmissionsRequest struct {
+	Status   string `form:"status"` // pending (default), approved, rejected
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// SubmitPublicIdea handles POST /api/boards/:id/submissions/public
+func SubmitPublicIdea(c *gin.Context) {
+	publicLink := c.Param("id")
+
+	// Parse request body
+	var req SubmitIdeaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify board exists by public link, is public and accepts suggestions
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	if !board.AcceptsIdeas || board.Frozen || board.Archived {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "SUBMISSIONS_DISABLED",
+				"message": "This board is not accepting idea suggestions",
+			},
+		})
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	visitorKey := utils.VisitorKey(c.ClientIP(), board.StrictPrivacy)
+
+	// Rate limiting per visitor and board
+	rateLimitKey := "submission_" + board.ID + "_" + visitorKey
+	rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_SUBMISSION_SECONDS", 60)
+	if isRateLimited(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "RATE_LIMITED",
+				"message": fmt.Sprintf("Please wait %d seconds before suggesting another idea", rateLimitSeconds),
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	if rejectIfCaptchaFails(ctx, c, &board) {
+		return
+	}
+
+	// Honeypot: accept silently so bots don't learn they were caught
+	if req.Website != "" {
+		setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+		log.Printf("[Handler] SubmitPublicIdea dropped - Honeypot filled, BoardID: %s, Visitor: %s", board.ID, visitorKey)
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Thanks! Your suggestion will appear once the board owner approves it.",
+		})
+		return
+	}
+
+	submission := models.IdeaSubmission{
+		ID:             utils.GenerateSubmissionID(),
+		BoardID:        board.ID,
+		OneLiner:       strings.TrimSpace(req.OneLiner),
+		Description:    strings.TrimSpace(req.Description),
+		ValueStatement: strings.TrimSpace(req.ValueStatement),
+		SubmitterName:  strings.TrimSpace(req.SubmitterName),
+		SubmitterEmail: strings.TrimSpace(req.SubmitterEmail),
+		VisitorKey:     visitorKey,
+		Status:         string(models.SubmissionPending),
+	}
+	if board.StrictPrivacy {
+		// Strict privacy boards never store contact details
+		submission.SubmitterEmail = ""
+	}
+
+	// Validate submission
+	if validationErrors := models.ValidateSubmission(&submission); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Submission validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if submission.LooksLikeSpam() {
+		log.Printf("[Handler] SubmitPublicIdea rejected - Spam heuristics, BoardID: %s, Visitor: %s", board.ID, visitorKey)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "SPAM_DETECTED",
+				"message": "Your suggestion looks like spam. Please remove links and try again.",
+			},
+		})
+		return
+	}
+
+	// Reject exact duplicates already waiting in the queue
+	submissionsCollection := models.GetCollection(ctx, models.SubmissionsCollection)
+	duplicates, err := submissionsCollection.CountDocuments(ctx, bson.M{
+		"board_id":  board.ID,
+		"status":    string(models.SubmissionPending),
+		"one_liner": bson.M{"$regex": "^" + regexp.QuoteMeta(submission.OneLiner) + "$", "$options": "i"},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to check for duplicate suggestions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if duplicates > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": gin.H{
+				"code":    "DUPLICATE_SUBMISSION",
+				"message": "This idea has already been suggested and is awaiting review",
+			},
+		})
+		return
+	}
+
+	if _, err := submissionsCollection.InsertOne(ctx, submission); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save suggestion",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+
+	go utils.SendBoardNotification(ctx, models.NotifyNewSubmission, board.ID, "",
+		fmt.Sprintf("New idea suggested: \"%s\"", submission.OneLiner), "")
+	go utils.EmitWebhookEvent(ctx, board.ID, models.WebhookSubmissionCreated, gin.H{"submission": submission})
+
+	log.Printf("[Handler] SubmitPublicIdea success - SubmissionID: %s, BoardID: %s, Visitor: %s",
+		submission.ID, board.ID, visitorKey)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Thanks! Your suggestion will appear once the board owner approves it.",
+	})
+}
+
+// GetSubmissions handles GET /api/boards/:id/submissions
+func GetSubmissions(c *gin.Context) {
+	boardID := c.Param("id")
+
+	// Parse query parameters
+	var req GetSubmissionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Status == "" {
+		req.Status = string(models.SubmissionPending)
+	}
+	if !models.IsValidSubmissionStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_STATUS",
+				"message": "status must be pending, approved or rejected",
+			},
+		})
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 50
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"board_id": boardID, "status": req.Status}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	submissionsCollection := models.GetCollection(ctx, models.SubmissionsCollection)
+	cursor, err := submissionsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch suggestions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	submissions := []models.IdeaSubmission{}
+	if err := cursor.All(ctx, &submissions); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode suggestions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := submissionsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count suggestions",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"submissions": submissions,
+		"count":       len(submissions),
+		"totalCount":  totalCount,
+		"page":        req.Page,
+		"pageSize":    req.PageSize,
+		"totalPages":  (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// UpdateSubmission handles PUT /api/submissions/:id
+func UpdateSubmission(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	submissionID := c.Param("id")
+
+	// Parse request body
+	var req UpdateSubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	submission, _, ok := findPendingSubmission(ctx, c, submissionID, userID)
+	if !ok {
+		return
+	}
+
+	if req.OneLiner != "" {
+		submission.OneLiner = req.OneLiner
+	}
+	if req.Description != nil {
+		submission.Description = *req.Description
+	}
+	if req.ValueStatement != nil {
+		submission.ValueStatement = *req.ValueStatement
+	}
+	submission.UpdatedAt = time.Now().UTC()
+
+	// Validate submission
+	if validationErrors := models.ValidateSubmission(submission); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Submission validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	update := bson.M{"$set": bson.M{
+		"one_liner":       submission.OneLiner,
+		"description":     submission.Description,
+		"value_statement": submission.ValueStatement,
+		"updated_at":      submission.UpdatedAt,
+	}}
+	filter := bson.M{"_id": submissionID, "status": string(models.SubmissionPending)}
+	result, err := models.GetCollection(ctx, models.SubmissionsCollection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update suggestion",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if result.MatchedCount == 0 {
+		respondSubmissionModerated(c)
+		return
+	}
+
+	log.Printf("[Handler] UpdateSubmission success - SubmissionID: %s, BoardID: %s, UserID: %s, IP: %s",
+		submissionID, submission.BoardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// ApproveSubmission handles POST /api/submissions/:id/approve
+func ApproveSubmission(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	submissionID := c.Param("id")
+
+	// Body is optional
+	var req ApproveSubmissionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "VALIDATION_ERROR",
+					"message": "Invalid request data",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	column := req.Column
+	if column == "" {
+		column = string(models.ColumnParking)
+	}
+	if !models.IsValidColumn(column) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_COLUMN",
+				"message": "Invalid column type: " + column,
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	submission, board, ok := findPendingSubmission(ctx, c, submissionID, userID)
+	if !ok {
+		return
+	}
+
+	// Frozen boards are read-only for ideas
+	if rejectIfFrozen(c, board) {
+		return
+	}
+
+	position, err := models.NextIdeaPosition(ctx, board.ID, column)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to determine position",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	idea := models.Idea{
+		ID:             utils.GenerateIdeaID(),
+		BoardID:        board.ID,
+		OneLiner:       submission.OneLiner,
+		Description:    submission.Description,
+		ValueStatement: submission.ValueStatement,
+		Column:         column,
+		Position:       position,
+		Status:         string(models.StatusActive),
+		EmojiReactions: []models.EmojiReaction{},
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	// Validate idea
+	if validationErrors := models.ValidateIdea(&idea); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Idea validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	if _, err := ideasCollection.InsertOne(ctx, idea); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create idea",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Mark approved only if nobody moderated it meanwhile; otherwise undo the idea
+	result, err := models.GetCollection(ctx, models.SubmissionsCollection).UpdateOne(ctx,
+		bson.M{"_id": submissionID, "status": string(models.SubmissionPending)},
+		bson.M{"$set": bson.M{
+			"status":       string(models.SubmissionApproved),
+			"idea_id":      idea.ID,
+			"moderated_at": now,
+			"updated_at":   now,
+		}})
+	if err != nil || result.MatchedCount == 0 {
+		if _, deleteErr := ideasCollection.DeleteOne(ctx, bson.M{"_id": idea.ID}); deleteErr != nil {
+			log.Printf("[Handler] ApproveSubmission failed - Orphan idea cleanup error: %v, IdeaID: %s", deleteErr, idea.ID)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to approve suggestion",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		respondSubmissionModerated(c)
+		return
+	}
+
+	// Record activity
+	go utils.RecordActivity(ctx, board.ID, idea.ID, userID, models.ActivityIdeaCreated, map[string]interface{}{
+		"oneLiner":     idea.OneLiner,
+		"column":       idea.Column,
+		"submissionId": submissionID,
+	})
+	recordAudit(c, userID, models.AuditIdeaCreated, board.ID, models.AuditTargetIdea, idea.ID, nil, idea)
+	go utils.RecordScoreSnapshot(ctx, &idea, userID)
+
+	log.Printf("[Handler] ApproveSubmission success - SubmissionID: %s, IdeaID: %s, BoardID: %s, UserID: %s, IP: %s",
+		submissionID, idea.ID, board.ID, userID, c.ClientIP())
+
+	response := newIdeaResponse(idea)
+	utils.BroadcastBoardEvent(ctx, board.ID, utils.EventIdeaCreated, idea.ID, response)
+	emitIdeaEvent(ctx, models.WebhookIdeaCreated, &idea, gin.H{"submissionId": submissionID})
+	go utils.EvaluateColumnAlerts(ctx, board.ID)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RejectSubmission handles POST /api/submissions/:id/reject
+func RejectSubmission(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	submissionID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	submission, _, ok := findPendingSubmission(ctx, c, submissionID, userID)
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	result, err := models.GetCollection(ctx, models.SubmissionsCollection).UpdateOne(ctx,
+		bson.M{"_id": submissionID, "status": string(models.SubmissionPending)},
+		bson.M{"$set": bson.M{
+			"status":       string(models.SubmissionRejected),
+			"moderated_at": now,
+			"updated_at":   now,
+		}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to reject suggestion",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if result.MatchedCount == 0 {
+		respondSubmissionModerated(c)
+		return
+	}
+
+	log.Printf("[Handler] RejectSubmission success - SubmissionID: %s, BoardID: %s, UserID: %s, IP: %s",
+		submissionID, submission.BoardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Suggestion rejected",
+	})
+}
+
+// findPendingSubmission loads a pending submission and the owner's board, writing an error response if not found
+func findPendingSubmission(ctx context.Context, c *gin.Context, submissionID, userID string) (*models.IdeaSubmission, *models.Board, bool) {
+	var submission models.IdeaSubmission
+	err := models.GetCollection(ctx, models.SubmissionsCollection).FindOne(ctx, bson.M{"_id": submissionID}).Decode(&submission)
+	var board models.Board
+	if err == nil {
+		err = models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, boardAccessFilter(c, submission.BoardID, userID, models.RoleEditor)).Decode(&board)
+	}
+
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "SUBMISSION_NOT_FOUND",
+				"message": "Suggestion not found or you don't have permission to moderate it",
+			},
+		})
+		return nil, nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch suggestion",
+				"details": err.Error(),
+			},
+		})
+		return nil, nil, false
+	}
+
+	if submission.Status != string(models.SubmissionPending) {
+		respondSubmissionModerated(c)
+		return nil, nil, false
+	}
+	return &submission, &board, true
+}
+
+// respondSubmissionModerated reports that a submission has already left the pending queue
+func respondSubmissionModerated(c *gin.Context) {
+	c.JSON(http.StatusConflict, gin.H{
+		"error": gin.H{
+			"code":    "SUBMISSION_ALREADY_MODERATED",
+			"message": "This suggestion has already been approved or rejected",
+		},
+	})
+}
@@ -0,0 +1,470 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SubscribeRequest represents the public payload for subscribing to a board's release announcements
+type SubscribeRequest struct {
+	Email   string `json:"email" binding:"required,email,max=254"`
+	Website string `json:"website,omitempty"` // Honeypot: hidden from humans, filled in by bots
+}
+
+// GetSubscribersRequest represents query parameters for listing a board's subscribers
+type GetSubscribersRequest struct {
+	Status   string `form:"status"` // Optional: pending or confirmed
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// subscribeAcceptedMessage is returned for every accepted subscription so the endpoint
+// doesn't reveal whether an address is already subscribed
+const subscribeAcceptedMessage = "Thanks! Check your inbox to confirm your subscription."
+
+// announceIfReleased emails the board's subscribers and sends idea.released to its webhooks when an
+// idea has just moved into the release column
+func announceIfReleased(ctx context.Context, existing, updated *models.Idea) {
+	if updated.Column == existing.Column || updated.Column != string(models.ColumnRelease) {
+		return
+	}
+	go utils.NotifySubscribersOfRelease(ctx, updated.BoardID, *updated)
+	emitIdeaEvent(ctx, models.WebhookIdeaReleased, updated, nil)
+}
+
+// subscriptionRedirect sends the visitor back to the public board with a status flag
+func subscriptionRedirect(ctx context.Context, c *gin.Context, boardID, flag string) {
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID, "is_public": true}).Decode(&board)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/?"+flag+"=1")
+		return
+	}
+	c.Redirect(http.StatusFound, "/public/"+board.PublicLink+"?"+flag+"=1")
+}
+
+// SubscribeToBoard handles POST /api/boards/:id/subscribe (public endpoint)
+func SubscribeToBoard(c *gin.Context) {
+	publicLink := c.Param("id")
+
+	// Parse request body
+	var req SubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Verify board exists by public link and is public
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+	visitorKey := utils.VisitorKey(c.ClientIP(), board.StrictPrivacy)
+
+	// Rate limiting per visitor and board
+	rateLimitKey := "subscribe_" + board.ID + "_" + visitorKey
+	rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_SUBSCRIBE_SECONDS", 60)
+	if isRateLimited(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "RATE_LIMITED",
+				"message": fmt.Sprintf("Please wait %d seconds before subscribing again", rateLimitSeconds),
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	if rejectIfCaptchaFails(ctx, c, &board) {
+		return
+	}
+	setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+
+	// Honeypot: accept silently so bots don't learn they were caught
+	if req.Website != "" {
+		log.Printf("[Handler] SubscribeToBoard dropped - Honeypot filled, BoardID: %s, Visitor: %s", board.ID, visitorKey)
+		c.JSON(http.StatusAccepted, gin.H{"message": subscribeAcceptedMessage})
+		return
+	}
+
+	subscriber := models.Subscriber{
+		ID:               utils.GenerateFullUUID(),
+		BoardID:          board.ID,
+		Email:            strings.ToLower(strings.TrimSpace(req.Email)),
+		Status:           string(models.SubscriberPending),
+		ConfirmToken:     utils.GenerateSubscriberToken(),
+		UnsubscribeToken: utils.GenerateSubscriberToken(),
+	}
+
+	// Validate subscriber
+	if validationErrors := models.ValidateSubscriber(&subscriber); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Subscription validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	// Insert the pending subscriber; an existing address keeps its record
+	subscribersCollection := models.GetCollection(ctx, models.SubscribersCollection)
+	if _, err := subscribersCollection.InsertOne(ctx, subscriber); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to save subscription",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+
+		var existing models.Subscriber
+		if err := subscribersCollection.FindOne(ctx, bson.M{"board_id": board.ID, "email": subscriber.Email}).Decode(&existing); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch subscription",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+
+		// Already confirmed: nothing to send, same answer as a new subscription
+		if existing.Status == string(models.SubscriberConfirmed) {
+			c.JSON(http.StatusAccepted, gin.H{"message": subscribeAcceptedMessage})
+			return
+		}
+		subscriber = existing
+	}
+
+	// Send the confirmation email (async)
+	go func(subscriber models.Subscriber, board models.Board) {
+		_ = utils.SendSubscriptionConfirmEmail(subscriber, board)
+	}(subscriber, board)
+
+	log.Printf("[Handler] SubscribeToBoard success - SubscriberID: %s, BoardID: %s, Visitor: %s",
+		subscriber.ID, board.ID, visitorKey)
+
+	c.JSON(http.StatusAccepted, gin.H{"message": subscribeAcceptedMessage})
+}
+
+// ConfirmSubscription handles GET /api/subscriptions/confirm?token= (link from the confirmation email)
+func ConfirmSubscription(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_TOKEN",
+				"message": "Confirmation token is required",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	var subscriber models.Subscriber
+	err := models.GetCollection(ctx, models.SubscribersCollection).FindOneAndUpdate(ctx,
+		bson.M{"confirm_token": token, "status": string(models.SubscriberPending)},
+		bson.M{
+			"$set":   bson.M{"status": string(models.SubscriberConfirmed), "confirmed_at": now, "updated_at": now},
+			"$unset": bson.M{"confirm_token": ""},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&subscriber)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_TOKEN",
+					"message": "This confirmation link is invalid or has already been used",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to confirm subscription",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] ConfirmSubscription success - SubscriberID: %s, BoardID: %s", subscriber.ID, subscriber.BoardID)
+
+	subscriptionRedirect(ctx, c, subscriber.BoardID, "subscribed")
+}
+
+// Unsubscribe handles GET /api/subscriptions/unsubscribe?token= (link included in every email)
+func Unsubscribe(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_TOKEN",
+				"message": "Unsubscribe token is required",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var subscriber models.Subscriber
+	err := models.GetCollection(ctx, models.SubscribersCollection).FindOneAndDelete(ctx, bson.M{"unsubscribe_token": token}).Decode(&subscriber)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_TOKEN",
+					"message": "This unsubscribe link is invalid or you are already unsubscribed",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to unsubscribe",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] Unsubscribe success - SubscriberID: %s, BoardID: %s", subscriber.ID, subscriber.BoardID)
+
+	subscriptionRedirect(ctx, c, subscriber.BoardID, "unsubscribed")
+}
+
+// GetSubscribers handles GET /api/boards/:id/subscribers
+func GetSubscribers(c *gin.Context) {
+	boardID := c.Param("id")
+
+	// Parse query parameters
+	var req GetSubscribersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if req.Status != "" && !models.IsValidSubscriberStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_STATUS",
+				"message": "status must be pending or confirmed",
+			},
+		})
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 50
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"board_id": boardID}
+	if req.Status != "" {
+		filter["status"] = req.Status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	subscribersCollection := models.GetCollection(ctx, models.SubscribersCollection)
+	cursor, err := subscribersCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch subscribers",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	subscribers := []models.Subscriber{}
+	if err := cursor.All(ctx, &subscribers); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode subscribers",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := subscribersCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count subscribers",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscribers": subscribers,
+		"count":       len(subscribers),
+		"totalCount":  totalCount,
+		"page":        req.Page,
+		"pageSize":    req.PageSize,
+		"totalPages":  (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// DeleteSubscriber handles DELETE /api/subscribers/:id
+func DeleteSubscriber(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	subscriberID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	subscribersCollection := models.GetCollection(ctx, models.SubscribersCollection)
+	var subscriber models.Subscriber
+	if err := subscribersCollection.FindOne(ctx, bson.M{"_id": subscriberID}).Decode(&subscriber); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "SUBSCRIBER_NOT_FOUND",
+					"message": "Subscriber not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch subscriber",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Verify the user may edit the subscriber's board
+	count, err := models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, boardAccessFilter(c, subscriber.BoardID, userID, models.RoleEditor))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to verify board ownership",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "PERMISSION_DENIED",
+				"message": "You don't have permission to remove this subscriber",
+			},
+		})
+		return
+	}
+
+	if _, err := subscribersCollection.DeleteOne(ctx, bson.M{"_id": subscriberID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove subscriber",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteSubscriber success - SubscriberID: %s, BoardID: %s, UserID: %s, IP: %s",
+		subscriberID, subscriber.BoardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Subscriber removed successfully",
+	})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// TelegramConfigRequest represents the request payload for connecting a board to a Telegram chat.
+// The bot token may be omitted on update to keep the stored one.
+type TelegramConfigRequest struct {
+	BotToken string                     `json:"botToken,omitempty"`
+	ChatID   string                     `json:"chatId" binding:"required"`
+	Events   []models.NotificationEvent `json:"events"` // Empty sends every event
+	Enabled  *bool                      `json:"enabled,omitempty"`
+}
+
+// findTelegramConfig loads the board's Telegram config, writing an error response if it is missing
+func findTelegramConfig(ctx context.Context, c *gin.Context, boardID string) (*models.TelegramConfig, bool) {
+	var config models.TelegramConfig
+	err := models.GetCollection(ctx, models.TelegramConfigsCollection).FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "TELEGRAM_NOT_CONFIGURED",
+					"message": "No Telegram chat is configured for this board",
+				},
+			})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Telegram config",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return &config, true
+}
+
+// GetTelegramConfig handles GET /api/boards/:id/telegram
+func GetTelegramConfig(c *gin.Context) {
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	config, ok := findTelegramConfig(ctx, c, boardID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// UpsertTelegramConfig handles PUT /api/boards/:id/telegram
+func UpsertTelegramConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	// Parse request body
+	var req TelegramConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.TelegramConfigsCollection)
+
+	var config models.TelegramConfig
+	err := collection.FindOne(ctx, bson.M{"board_id": boardID}).Decode(&config)
+	isNew := err == mongo.ErrNoDocuments
+	if err != nil && !isNew {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch Telegram config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if isNew {
+		config = models.TelegramConfig{
+			ID:      utils.GenerateFullUUID(),
+			BoardID: boardID,
+			UserID:  userID,
+			Enabled: true,
+		}
+	}
+
+	if token := strings.TrimSpace(req.BotToken); token != "" {
+		config.BotToken = token
+	}
+	config.ChatID = strings.TrimSpace(req.ChatID)
+	config.Events = uniqueNotificationEvents(req.Events)
+	if req.Enabled != nil {
+		config.Enabled = *req.Enabled
+	}
+	// Changed settings may fix whatever failed before
+	config.LastError = ""
+
+	// Validate Telegram config
+	if validationErrors := models.ValidateTelegramConfig(&config); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Telegram config validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if isNew {
+		_, err = collection.InsertOne(ctx, config)
+	} else {
+		_, err = collection.ReplaceOne(ctx, bson.M{"_id": config.ID}, config)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to save Telegram config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpsertTelegramConfig success - BoardID: %s, UserID: %s, ChatID: %s, Enabled: %t, IP: %s",
+		boardID, userID, config.ChatID, config.Enabled, c.ClientIP())
+
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, config)
+}
+
+// DeleteTelegramConfig handles DELETE /api/boards/:id/telegram
+func DeleteTelegramConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.TelegramConfigsCollection).DeleteOne(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete Telegram config",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "TELEGRAM_NOT_CONFIGURED",
+				"message": "No Telegram chat is configured for this board",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] DeleteTelegramConfig success - BoardID: %s, UserID: %s, IP: %s", boardID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Telegram config deleted successfully",
+	})
+}
+
+// TestTelegramConfig handles POST /api/boards/:id/telegram/test, sending a test message to the
+// board's chat so the owner can check the bot token and chat ID
+func TestTelegramConfig(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	boardID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	config, ok := findTelegramConfig(ctx, c, boardID)
+	if !ok {
+		return
+	}
+
+	var board models.Board
+	if err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Decode(&board); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	text := fmt.Sprintf("<b>%s</b>\nThis chat will receive the board's notifications.", html.EscapeString(board.Name))
+	if _, err := utils.SendTelegramMessage(config.BotToken, config.ChatID, text); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "TELEGRAM_SEND_FAILED",
+				"message": "Telegram did not accept the test message",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] TestTelegramConfig success - BoardID: %s, UserID: %s, ChatID: %s, IP: %s",
+		boardID, userID, config.ChatID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Test message sent",
+	})
+}
+
+// uniqueNotificationEvents drops repeated events, keeping their order
+func uniqueNotificationEvents(events []models.NotificationEvent) []models.NotificationEvent {
+	seen := make(map[models.NotificationEvent]bool)
+	unique := []models.NotificationEvent{}
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,573 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// templatePreviewSize is the number of ideas shown in gallery listings
+const templatePreviewSize = 5
+
+// PublishTemplateRequest represents the request payload for publishing a board as a template
+type PublishTemplateRequest struct {
+	Name         string `json:"name" binding:"required,min=1,max=100"`
+	Description  string `json:"description,omitempty" binding:"max=500"`
+	Category     string `json:"category" binding:"required"`
+	IncludeIdeas *bool  `json:"includeIdeas,omitempty"`
+}
+
+// InstallTemplateRequest represents the request payload for instantiating a template
+type InstallTemplateRequest struct {
+	Name string `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+}
+
+// ListTemplatesRequest represents query parameters for browsing the gallery
+type ListTemplatesRequest struct {
+	Category string `form:"category"`
+	SortBy   string `form:"sortBy"` // popular, recent
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// TemplateSummaryResponse represents a template in gallery listings
+type TemplateSummaryResponse struct {
+	ID             string                `json:"id"`
+	Name           string                `json:"name"`
+	Description    string                `json:"description,omitempty"`
+	Category       string                `json:"category"`
+	VisibleColumns []string              `json:"visibleColumns"`
+	IdeasCount     int                   `json:"ideasCount"`
+	Preview        []models.TemplateIdea `json:"preview"`
+	InstallCount   int                   `json:"installCount"`
+	CreatedAt      time.Time             `json:"createdAt"`
+}
+
+// newTemplateSummaryResponse builds a gallery listing entry with a short idea preview
+func newTemplateSummaryResponse(template models.BoardTemplate) TemplateSummaryResponse {
+	preview := template.Ideas
+	if len(preview) > templatePreviewSize {
+		preview = preview[:templatePreviewSize]
+	}
+	if preview == nil {
+		preview = []models.TemplateIdea{}
+	}
+
+	return TemplateSummaryResponse{
+		ID:             template.ID,
+		Name:           template.Name,
+		Description:    template.Description,
+		Category:       template.Category,
+		VisibleColumns: template.VisibleColumns,
+		IdeasCount:     len(template.Ideas),
+		Preview:        preview,
+		InstallCount:   template.InstallCount,
+		CreatedAt:      template.CreatedAt,
+	}
+}
+
+// PublishBoardTemplate handles POST /api/boards/:id/template
+func PublishBoardTemplate(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	// Get board ID from URL parameter
+	boardID := c.Param("id")
+	if boardID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_BOARD_ID",
+				"message": "Board ID is required",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req PublishTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	// Snapshot the board's ideas unless explicitly excluded (feedback and status are never copied)
+	templateIdeas := []models.TemplateIdea{}
+	if req.IncludeIdeas == nil || *req.IncludeIdeas {
+		opts := options.Find().
+			SetSort(bson.D{{Key: "column", Value: 1}, {Key: "position", Value: 1}}).
+			SetLimit(models.MaxTemplateIdeas)
+		cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, bson.M{"board_id": boardID}, opts)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch ideas",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		var ideas []models.Idea
+		if err := cursor.All(ctx, &ideas); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to decode ideas",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+
+		for _, idea := range ideas {
+			templateIdeas = append(templateIdeas, models.TemplateIdea{
+				OneLiner:       idea.OneLiner,
+				Description:    idea.Description,
+				ValueStatement: idea.ValueStatement,
+				RiceScore:      idea.RiceScore,
+				Column:         idea.Column,
+				Position:       idea.Position,
+			})
+		}
+	}
+
+	template := models.BoardTemplate{
+		ID:             utils.GenerateTemplateID(),
+		Name:           req.Name,
+		Description:    req.Description,
+		Category:       req.Category,
+		AuthorID:       userID,
+		SourceBoardID:  boardID,
+		VisibleColumns: board.VisibleColumns,
+		VisibleFields:  board.VisibleFields,
+		Ideas:          templateIdeas,
+		IsPublished:    true,
+		InstallCount:   0,
+	}
+
+	// Validate template
+	if validationErrors := models.ValidateTemplate(&template); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Template validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.TemplatesCollection).InsertOne(ctx, template); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to publish template",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] PublishBoardTemplate success - TemplateID: %s, BoardID: %s, UserID: %s, Ideas: %d, IP: %s",
+		template.ID, boardID, userID, len(templateIdeas), c.ClientIP())
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// ListTemplates handles GET /api/templates (public endpoint)
+func ListTemplates(c *gin.Context) {
+	// Parse query parameters
+	var req ListTemplatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if req.Category != "" && !models.IsValidTemplateCategory(req.Category) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_CATEGORY",
+				"message": "Invalid template category: " + req.Category,
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 50 {
+		req.PageSize = 20
+	}
+
+	filter := bson.M{"is_published": true}
+	if req.Category != "" {
+		filter["category"] = req.Category
+	}
+
+	sort := bson.D{{Key: "install_count", Value: -1}, {Key: "created_at", Value: -1}}
+	if req.SortBy == "recent" {
+		sort = bson.D{{Key: "created_at", Value: -1}}
+	}
+
+	ctx := c.Request.Context()
+
+	templatesCollection := models.GetCollection(ctx, models.TemplatesCollection)
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	cursor, err := templatesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch templates",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var templates []models.BoardTemplate
+	if err := cursor.All(ctx, &templates); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode templates",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := templatesCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count templates",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	responses := []TemplateSummaryResponse{}
+	for _, template := range templates {
+		responses = append(responses, newTemplateSummaryResponse(template))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates":  responses,
+		"categories": models.GetTemplateCategories(),
+		"count":      len(responses),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// GetTemplate handles GET /api/templates/:id (public endpoint)
+func GetTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	var template models.BoardTemplate
+	err := models.GetCollection(ctx, models.TemplatesCollection).FindOne(ctx, bson.M{"_id": templateID, "is_published": true}).Decode(&template)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "TEMPLATE_NOT_FOUND",
+					"message": "Template not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch template",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Author and source board are not exposed publicly
+	template.AuthorID = ""
+	template.SourceBoardID = ""
+
+	c.JSON(http.StatusOK, template)
+}
+
+// InstallTemplate handles POST /api/templates/:id/install
+func InstallTemplate(c *gin.Context) {
+	startTime := time.Now()
+
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	templateID := c.Param("id")
+
+	// Parse optional request body
+	var req InstallTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "VALIDATION_ERROR",
+					"message": "Invalid request data",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	templatesCollection := models.GetCollection(ctx, models.TemplatesCollection)
+	var template models.BoardTemplate
+	err = templatesCollection.FindOne(ctx, bson.M{"_id": templateID, "is_published": true}).Decode(&template)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "TEMPLATE_NOT_FOUND",
+					"message": "Template not found",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch template",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	name := req.Name
+	if name == "" {
+		name = template.Name
+	}
+
+	visibleColumns := template.VisibleColumns
+	if len(visibleColumns) == 0 {
+		visibleColumns = models.GetDefaultVisibleColumns()
+	}
+	visibleFields := template.VisibleFields
+	if len(visibleFields) == 0 {
+		visibleFields = models.GetDefaultVisibleFields()
+	}
+
+	// Create the board from the template (private by default, like any new board)
+	now := time.Now().UTC()
+	board := models.Board{
+		ID:             utils.GenerateBoardID(),
+		Name:           name,
+		Description:    template.Description,
+		PublicLink:     utils.GenerateShortUUID(),
+		IsPublic:       false,
+		UserID:         userID,
+		VisibleColumns: visibleColumns,
+		VisibleFields:  visibleFields,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if validationErrors := models.ValidateBoard(&board); len(validationErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Board validation failed",
+				"details": validationErrors.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := models.GetCollection(ctx, models.BoardsCollection).InsertOne(ctx, board); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create board from template",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Copy the template ideas onto the new board
+	if len(template.Ideas) > 0 {
+		ideas := make([]models.Idea, 0, len(template.Ideas))
+		for _, templateIdea := range template.Ideas {
+			ideas = append(ideas, models.Idea{
+				ID:             utils.GenerateIdeaID(),
+				BoardID:        board.ID,
+				OneLiner:       templateIdea.OneLiner,
+				Description:    templateIdea.Description,
+				ValueStatement: templateIdea.ValueStatement,
+				RiceScore:      templateIdea.RiceScore,
+				Column:         templateIdea.Column,
+				Position:       templateIdea.Position,
+				InProgress:     false,
+				Status:         string(models.StatusActive),
+				ThumbsUp:       0,
+				EmojiReactions: []models.EmojiReaction{},
+				CreatedBy:      userID,
+				UpdatedBy:      userID,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+
+		ideaDocs := make([]interface{}, 0, len(ideas))
+		for _, idea := range ideas {
+			ideaDocs = append(ideaDocs, idea)
+		}
+
+		if _, err := models.GetCollection(ctx, models.IdeasCollection).InsertMany(ctx, ideaDocs); err != nil {
+			log.Printf("[Handler] InstallTemplate - Failed to copy template ideas: %v, TemplateID: %s, BoardID: %s", err, templateID, board.ID)
+			// Don't fail the install if the ideas could not be copied, the board is usable
+		} else {
+			// Start each copied idea's score history, as creating it by hand would
+			go utils.RecordScoreSnapshots(ctx, ideas, userID)
+		}
+	}
+
+	// Track installs for gallery ranking
+	if _, err := templatesCollection.UpdateOne(ctx, bson.M{"_id": templateID}, bson.M{"$inc": bson.M{"install_count": 1}}); err != nil {
+		log.Printf("[Handler] InstallTemplate - Failed to increment install count: %v, TemplateID: %s", err, templateID)
+	}
+
+	log.Printf("[Handler] InstallTemplate success - TemplateID: %s, BoardID: %s, UserID: %s, Ideas: %d, Duration: %v, IP: %s",
+		templateID, board.ID, userID, len(template.Ideas), time.Since(startTime), c.ClientIP())
+
+	c.JSON(http.StatusCreated, BoardResponse{
+		ID:             board.ID,
+		Name:           board.Name,
+		Description:    board.Description,
+		PublicLink:     board.PublicLink,
+		IsPublic:       board.IsPublic,
+		UserID:         board.UserID,
+		IsAdmin:        true,
+		VisibleColumns: board.VisibleColumns,
+		VisibleFields:  board.VisibleFields,
+		IdeasCount:     len(template.Ideas),
+		CreatedAt:      board.CreatedAt,
+		UpdatedAt:      board.UpdatedAt,
+		CreatedBy:      board.CreatedBy,
+		UpdatedBy:      board.UpdatedBy,
+		Version:        board.Version,
+	})
+}
+
+// UnpublishTemplate handles DELETE /api/templates/:id
+func UnpublishTemplate(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	templateID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.TemplatesCollection).DeleteOne(ctx, bson.M{"_id": templateID, "author_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove template",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "TEMPLATE_NOT_FOUND",
+				"message": "Template not found or you are not its author",
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UnpublishTemplate success - TemplateID: %s, UserID: %s, IP: %s", templateID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Template removed from gallery",
+		"templateId": templateID,
+	})
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"context"
 	"log"
 	"net/http"
 
 	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 // GetUserInfo handles GET /api/user
@@ -27,10 +31,38 @@ func GetUserInfo(c *gin.Context) {
 	sessionID, _ := middleware.GetSessionID(c)
 	log.Printf("[API] GetUserInfo success - UserID: %s, SessionID: %s, IP: %s", userID, sessionID, c.ClientIP())
 
-	c.JSON(http.StatusOK, gin.H{
+	ctx := c.Request.Context()
+
+	// The profile is synced in the background on sign-in, so it may be missing on the very first request
+	response := gin.H{
 		"userID":    userID,
 		"sessionID": sessionID,
-	})
+	}
+	var profile models.User
+	if err := models.GetCollection(ctx, models.UsersCollection).FindOne(ctx, bson.M{"_id": userID}).Decode(&profile); err == nil {
+		response["profile"] = profile
+	}
+	// Lets the dashboard show a banner while an admin acts as this user
+	if impersonation := middleware.GetImpersonation(c); impersonation != nil {
+		response["impersonation"] = gin.H{
+			"id":        impersonation.ID,
+			"adminId":   impersonation.AdminID,
+			"expiresAt": impersonation.ExpiresAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// findUserProfiles returns the cached profiles of the given users for embedding in a response.
+// Lookup failures are logged and yield no profiles, since the IDs alone are still usable.
+func findUserProfiles(ctx context.Context, userIDs []string) map[string]models.UserProfile {
+	profiles, err := models.FindUserProfiles(ctx, userIDs)
+	if err != nil {
+		log.Printf("[Handler] findUserProfiles failed - Error: %v, Users: %d", err, len(userIDs))
+		return map[string]models.UserProfile{}
+	}
+	return profiles
 }
 
 // TestProtected handles GET /api/protected
@@ -42,3 +74,62 @@ func TestProtected(c *gin.Context) {
 		"userID":  userID,
 	})
 }
+
+// RevokeAllSessions handles POST /api/user/sessions/revoke, signing the user out everywhere after a
+// credential leak: every identity provider session is revoked and every personal access token deleted
+func RevokeAllSessions(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+	if rejectIfPersonalAccessToken(c) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Tokens are local, so drop them first; they stop working even if the provider call fails
+	result, err := models.GetCollection(ctx, models.PersonalTokensCollection).DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to revoke personal access tokens",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	sessions, supported, err := middleware.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		log.Printf("[Handler] RevokeAllSessions failed - Provider error: %v, Sessions: %d, UserID: %s, IP: %s", err, sessions, userID, c.ClientIP())
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"code":    "SESSION_REVOKE_FAILED",
+				"message": "Personal access tokens were revoked but some sessions could not be; try again",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Open realtime connections were authorized with the revoked sessions
+	utils.CloseUserConnections(userID)
+
+	log.Printf("[Handler] RevokeAllSessions success - Sessions: %d, Tokens: %d, UserID: %s, IP: %s",
+		sessions, result.DeletedCount, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"revokedSessions":   sessions,
+		"revokedTokens":     result.DeletedCount,
+		"sessionsSupported": supported,
+	})
+}
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ListUserNotificationsRequest represents query parameters for the notification center
+type ListUserNotificationsRequest struct {
+	Unread   bool   `form:"unread"` // Only unread notifications
+	BoardID  string `form:"boardId"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// ClearUserNotificationsRequest represents query parameters for clearing the notification center
+type ClearUserNotificationsRequest struct {
+	Read    bool   `form:"read"` // Only clear notifications already read
+	BoardID string `form:"boardId"`
+}
+
+// MarkAllNotificationsReadRequest represents the optional request body for marking notifications read
+type MarkAllNotificationsReadRequest struct {
+	BoardID string `json:"boardId"` // Only this board's notifications; empty for all
+}
+
+// pushUnreadCount sends the user's current unread count to their notification streams, so other
+// tabs update their badge
+func pushUnreadCount(ctx context.Context, userID string) {
+	unread, err := models.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		log.Printf("[Handler] Failed to count unread notifications - Error: %v, UserID: %s", err, userID)
+		return
+	}
+	utils.SendUnreadCount(userID, unread)
+}
+
+// ListUserNotifications handles GET /api/notifications, listing the caller's notifications newest first
+func ListUserNotifications(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	// Parse query parameters
+	var req ListUserNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	filter := bson.M{"user_id": userID}
+	if req.Unread {
+		filter["read"] = false
+	}
+	if req.BoardID != "" {
+		filter["board_id"] = req.BoardID
+	}
+
+	ctx := c.Request.Context()
+
+	collection := models.GetCollection(ctx, models.UserNotificationsCollection)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []models.UserNotification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	unread, err := models.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count unread notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"count":         len(notifications),
+		"totalCount":    totalCount,
+		"unreadCount":   unread,
+		"page":          req.Page,
+		"pageSize":      req.PageSize,
+		"totalPages":    (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// GetUnreadNotificationCount handles GET /api/notifications/unread-count, for the bell badge
+func GetUnreadNotificationCount(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	ctx := c.Request.Context()
+
+	unread, err := models.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count unread notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unreadCount": unread,
+	})
+}
+
+// MarkNotificationRead handles PUT /api/notifications/:notificationId/read
+func MarkNotificationRead(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	notificationID := c.Param("notificationId")
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	result, err := models.GetCollection(ctx, models.UserNotificationsCollection).UpdateOne(ctx,
+		bson.M{"_id": notificationID, "user_id": userID},
+		bson.M{"$set": bson.M{"read": true, "read_at": now}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update notification",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NOTIFICATION_NOT_FOUND",
+				"message": "Notification not found",
+			},
+		})
+		return
+	}
+
+	pushUnreadCount(ctx, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification marked as read",
+	})
+}
+
+// MarkAllNotificationsRead handles PUT /api/notifications/read-all, optionally limited to one board
+func MarkAllNotificationsRead(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	// The body is optional
+	var req MarkAllNotificationsReadRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "VALIDATION_ERROR",
+					"message": "Invalid request data",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	filter := bson.M{"user_id": userID, "read": false}
+	if req.BoardID != "" {
+		filter["board_id"] = req.BoardID
+	}
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	result, err := models.GetCollection(ctx, models.UserNotificationsCollection).UpdateMany(ctx, filter,
+		bson.M{"$set": bson.M{"read": true, "read_at": now}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] MarkAllNotificationsRead success - UserID: %s, BoardID: %s, Updated: %d, IP: %s",
+		userID, req.BoardID, result.ModifiedCount, c.ClientIP())
+
+	pushUnreadCount(ctx, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notifications marked as read",
+		"updated": result.ModifiedCount,
+	})
+}
+
+// DeleteUserNotification handles DELETE /api/notifications/:notificationId
+func DeleteUserNotification(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	notificationID := c.Param("notificationId")
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.UserNotificationsCollection).DeleteOne(ctx, bson.M{"_id": notificationID, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to delete notification",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "NOTIFICATION_NOT_FOUND",
+				"message": "Notification not found",
+			},
+		})
+		return
+	}
+
+	pushUnreadCount(ctx, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification deleted successfully",
+	})
+}
+
+// ClearUserNotifications handles DELETE /api/notifications, clearing the caller's notification
+// center; read=true keeps unread notifications and boardId limits it to one board
+func ClearUserNotifications(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	// Parse query parameters
+	var req ClearUserNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	filter := bson.M{"user_id": userID}
+	if req.Read {
+		filter["read"] = true
+	}
+	if req.BoardID != "" {
+		filter["board_id"] = req.BoardID
+	}
+
+	ctx := c.Request.Context()
+
+	result, err := models.GetCollection(ctx, models.UserNotificationsCollection).DeleteMany(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to clear notifications",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] ClearUserNotifications success - UserID: %s, BoardID: %s, ReadOnly: %t, Deleted: %d, IP: %s",
+		userID, req.BoardID, req.Read, result.DeletedCount, c.ClientIP())
+
+	pushUnreadCount(ctx, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notifications cleared",
+		"deleted": result.DeletedCount,
+	})
+}
+
+// NotificationWebSocket handles GET /api/ws/notifications, streaming the caller's new notifications
+// and unread count changes. The session token is offered with the auth subprotocol as on board sockets.
+func NotificationWebSocket(c *gin.Context) {
+	if err := middleware.AuthenticateSessionToken(c, utils.WebSocketToken(c.Request)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_TOKEN",
+				"message": "Invalid or expired token",
+			},
+		})
+		return
+	}
+	userID, _ := middleware.GetUserID(c)
+
+	utils.ServeUserSocket(c, userID, sessionStreamAccess(c))
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// expectedVersion returns the version the client last saw, from the request body or an If-Match
+// header such as "3" or W/"3". Returns nil when the client sent neither, so the edit always applies.
+// Responds with 400 and returns false when If-Match is not a version.
+func expectedVersion(c *gin.Context, bodyVersion *int64) (*int64, bool) {
+	if bodyVersion != nil {
+		return bodyVersion, true
+	}
+
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 64)
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_VERSION",
+				"message": "If-Match must be a version number",
+			},
+		})
+		return nil, false
+	}
+	return &version, true
+}
+
+// respondVersionConflict tells the client that someone else edited the document since it was loaded
+func respondVersionConflict(c *gin.Context, expected, current int64) {
+	conflict := versionConflictError(expected, current)
+	c.JSON(conflict.status, conflict.body())
+}
+
+// versionConflictError is the VERSION_CONFLICT error of an edit made from an outdated version
+func versionConflictError(expected, current int64) *ideaChangeError {
+	return &ideaChangeError{
+		status:  http.StatusConflict,
+		code:    "VERSION_CONFLICT",
+		message: "This was changed by someone else. Reload it and try again.",
+		details: fmt.Sprintf("expected version %d, current version %d", expected, current),
+	}
+}
+
+// setVersionHeader returns the version as an ETag for clients that edit with If-Match
+func setVersionHeader(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// currentVersion looks up the version of a document after a versioned update matched nothing, to
+// tell a conflicting edit from a deleted document
+func currentVersion(ctx context.Context, collection, id string) (int64, bool) {
+	var doc struct {
+		Version int64 `bson:"version"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"version": 1})
+	if err := models.GetCollection(ctx, collection).FindOne(ctx, bson.M{"_id": id}, opts).Decode(&doc); err != nil {
+		return 0, false
+	}
+	return doc.Version, true
+}
@@ -0,0 +1,658 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// recordVote stores the calling visitor's vote on an idea. A duplicate key error means the visitor already cast it.
+func recordVote(ctx context.Context, c *gin.Context, idea *models.Idea, strictPrivacy bool, voteType models.VoteType, emoji, note string) (*models.Vote, error) {
+	vote := models.Vote{
+		ID:          utils.GenerateFullUUID(),
+		BoardID:     idea.BoardID,
+		IdeaID:      idea.ID,
+		VisitorID:   utils.VisitorID(c, strictPrivacy),
+		Type:        string(voteType),
+		Emoji:       emoji,
+		Note:        strings.TrimSpace(note),
+		CreatedAt:   time.Now().UTC(),
+		Attribution: utils.FeedbackAttribution(c),
+	}
+
+	if _, err := models.GetCollection(ctx, models.VotesCollection).InsertOne(ctx, vote); err != nil {
+		return nil, err
+	}
+	return &vote, nil
+}
+
+// discardVote removes a vote whose counter update failed so the visitor can retry
+func discardVote(ctx context.Context, vote *models.Vote) {
+	if _, err := models.GetCollection(ctx, models.VotesCollection).DeleteOne(ctx, bson.M{"_id": vote.ID}); err != nil {
+		log.Printf("[Handler] discardVote failed - Error: %v, VoteID: %s, IdeaID: %s", err, vote.ID, vote.IdeaID)
+	}
+}
+
+// CastVoteRequest represents the public payload for choosing a board vote option
+type CastVoteRequest struct {
+	Option string `json:"option" binding:"required,max=30"`
+}
+
+// GetFeedbackNotesRequest represents query parameters for listing feedback notes
+type GetFeedbackNotesRequest struct {
+	IdeaID   string `form:"ideaId"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// FeedbackNoteResponse represents a visitor's note left with a thumbs up or reaction
+type FeedbackNoteResponse struct {
+	IdeaID    string    `json:"ideaId"`
+	Type      string    `json:"type"`
+	Emoji     string    `json:"emoji,omitempty"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RemoveEmojiReactionRequest represents query parameters for removing a reaction
+type RemoveEmojiReactionRequest struct {
+	Emoji string `form:"emoji" binding:"required,min=1,max=10"`
+}
+
+// findFeedbackIdea loads an idea targeted by public feedback, writing an error response if not found
+func findFeedbackIdea(ctx context.Context, c *gin.Context, ideaID string) (*models.Idea, bool) {
+	var idea models.Idea
+	err := models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found",
+				},
+			})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch idea",
+				"details": err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return &idea, true
+}
+
+// withdrawVote deletes the calling visitor's vote, writing VOTE_NOT_FOUND if they never cast it
+func withdrawVote(ctx context.Context, c *gin.Context, idea *models.Idea, voteType models.VoteType, emoji string) bool {
+	board, ok := feedbackBoardSettings(ctx, c, idea.BoardID)
+	if !ok || rejectBlockedVisitor(c, &board) {
+		return false
+	}
+	filter := bson.M{
+		"idea_id":    idea.ID,
+		"visitor_id": utils.VisitorID(c, board.StrictPrivacy),
+		"type":       string(voteType),
+		"emoji":      emoji,
+	}
+
+	result, err := models.GetCollection(ctx, models.VotesCollection).DeleteOne(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove vote",
+				"details": err.Error(),
+			},
+		})
+		return false
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": gin.H{
+				"code":    "VOTE_NOT_FOUND",
+				"message": "You have no such vote on this idea to remove",
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// RemoveThumbsUp handles DELETE /api/ideas/:id/thumbsup (public endpoint)
+func RemoveThumbsUp(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, ok := findFeedbackIdea(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	if !withdrawVote(ctx, c, idea, models.VoteThumbsUp, "") {
+		return
+	}
+
+	// Decrement without ever going below zero
+	updateDoc := bson.M{
+		"$inc": bson.M{"thumbs_up": -1},
+		"$set": bson.M{"updated_at": time.Now().UTC()},
+	}
+	thumbsUp := 0
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID, "thumbs_up": bson.M{"$gt": 0}}, updateDoc)
+	if err != nil && err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update thumbs up count",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if updatedIdea != nil {
+		thumbsUp = updatedIdea.ThumbsUp
+	}
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"thumbsUp": thumbsUp})
+
+	log.Printf("[Handler] RemoveThumbsUp success - IdeaID: %s, BoardID: %s, ThumbsUp: %d", ideaID, idea.BoardID, thumbsUp)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Thumbs up removed",
+		"thumbsUp": thumbsUp,
+	})
+}
+
+// RemoveEmojiReaction handles DELETE /api/ideas/:id/emoji?emoji=... (public endpoint)
+func RemoveEmojiReaction(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	// Parse query parameters
+	var req RemoveEmojiReactionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idea, ok := findFeedbackIdea(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	if !withdrawVote(ctx, c, idea, models.VoteEmoji, req.Emoji) {
+		return
+	}
+
+	// Decrement the matching reaction, then drop reactions that reached zero
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	filter := bson.M{
+		"_id":             ideaID,
+		"emoji_reactions": bson.M{"$elemMatch": bson.M{"emoji": req.Emoji, "count": bson.M{"$gt": 0}}},
+	}
+	update := bson.M{
+		"$inc": bson.M{"emoji_reactions.$.count": -1},
+		"$set": bson.M{"updated_at": time.Now().UTC()},
+	}
+	if _, err := ideasCollection.UpdateOne(ctx, filter, update); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update emoji reaction",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	cleanup := bson.M{"$pull": bson.M{"emoji_reactions": bson.M{"count": bson.M{"$lte": 0}}}}
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID}, cleanup)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update emoji reaction",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"emojiReactions": updatedIdea.EmojiReactions})
+
+	log.Printf("[Handler] RemoveEmojiReaction success - IdeaID: %s, BoardID: %s, Emoji: %s", ideaID, idea.BoardID, req.Emoji)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Emoji reaction removed",
+		"emoji":          req.Emoji,
+		"emojiReactions": updatedIdea.EmojiReactions,
+	})
+}
+
+// CastVote handles POST /api/ideas/:id/vote (public endpoint). Each visitor holds one option per idea;
+// voting again with a different option moves their vote.
+func CastVote(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	// Parse request body
+	var req CastVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idea, ok := findFeedbackIdea(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	board, ok := feedbackBoardSettings(ctx, c, idea.BoardID)
+	if !ok || rejectBlockedVisitor(c, &board) {
+		return
+	}
+	if !board.HasVoteOption(req.Option) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_VOTE_OPTION",
+				"message": "This vote option is not enabled for this board",
+			},
+		})
+		return
+	}
+
+	// Verify the CAPTCHA token when the board requires one
+	if rejectIfCaptchaFails(ctx, c, &board) {
+		return
+	}
+
+	// Upsert the visitor's single option vote, returning the previous choice if any
+	now := time.Now().UTC()
+	filter := bson.M{
+		"idea_id":    idea.ID,
+		"visitor_id": utils.VisitorID(c, board.StrictPrivacy),
+		"type":       string(models.VoteOption),
+		"emoji":      "",
+	}
+	setOnInsert := bson.M{
+		"_id":        utils.GenerateFullUUID(),
+		"board_id":   idea.BoardID,
+		"created_at": now,
+	}
+	attribution := utils.FeedbackAttribution(c)
+	for key, value := range map[string]string{"source": attribution.Source, "referrer": attribution.Referrer, "device": attribution.Device} {
+		if value != "" {
+			setOnInsert[key] = value
+		}
+	}
+	update := bson.M{
+		"$set":         bson.M{"option": req.Option},
+		"$setOnInsert": setOnInsert,
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var previous models.Vote
+	err := models.GetCollection(ctx, models.VotesCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err != nil && err != mongo.ErrNoDocuments {
+		if mongo.IsDuplicateKeyError(err) {
+			// A concurrent request from the same visitor won the upsert
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "ALREADY_VOTED",
+					"message": "Your vote is already being recorded",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to record vote",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	votes := idea.Votes
+	if err == nil && previous.Option == req.Option {
+		// Same choice again: nothing to count
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Vote unchanged",
+			"option":  req.Option,
+			"votes":   votes,
+		})
+		return
+	}
+
+	inc := bson.M{"votes." + req.Option: 1}
+	if err == nil && previous.Option != "" {
+		inc["votes."+previous.Option] = -1
+	}
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID}, bson.M{
+		"$inc": inc,
+		"$set": bson.M{"updated_at": now},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update vote counts",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	votes = updatedIdea.Votes
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"votes": votes})
+
+	// Record activity
+	go utils.RecordActivity(ctx, idea.BoardID, ideaID, "", models.ActivityFeedback, map[string]interface{}{
+		"feedbackType": "vote",
+		"option":       req.Option,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vote recorded",
+		"option":  req.Option,
+		"votes":   votes,
+	})
+}
+
+// RemoveVote handles DELETE /api/ideas/:id/vote (public endpoint)
+func RemoveVote(c *gin.Context) {
+	ideaID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	idea, ok := findFeedbackIdea(ctx, c, ideaID)
+	if !ok {
+		return
+	}
+
+	board, ok := feedbackBoardSettings(ctx, c, idea.BoardID)
+	if !ok || rejectBlockedVisitor(c, &board) {
+		return
+	}
+	filter := bson.M{
+		"idea_id":    idea.ID,
+		"visitor_id": utils.VisitorID(c, board.StrictPrivacy),
+		"type":       string(models.VoteOption),
+		"emoji":      "",
+	}
+
+	var vote models.Vote
+	err := models.GetCollection(ctx, models.VotesCollection).FindOneAndDelete(ctx, filter).Decode(&vote)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "VOTE_NOT_FOUND",
+					"message": "You have no vote on this idea to remove",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to remove vote",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Decrement without ever going below zero
+	counter := "votes." + vote.Option
+	votes := idea.Votes
+	updatedIdea, err := models.UpdateIdeaAndReturn(ctx, bson.M{"_id": ideaID, counter: bson.M{"$gt": 0}}, bson.M{
+		"$inc": bson.M{counter: -1},
+		"$set": bson.M{"updated_at": time.Now().UTC()},
+	})
+	if err != nil && err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update vote counts",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if updatedIdea != nil {
+		votes = updatedIdea.Votes
+	}
+
+	utils.BroadcastIdeaUpdate(ctx, idea.BoardID, ideaID, gin.H{"votes": votes})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vote removed",
+		"votes":   votes,
+	})
+}
+
+// GetVisitorVotes handles GET /api/boards/:id/votes/public
+func GetVisitorVotes(c *gin.Context) {
+	publicLink := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	// Verify board exists by public link and is public
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "BOARD_NOT_FOUND",
+					"message": "Board not found or is not publicly accessible",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch board",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if rejectBlockedVisitor(c, &board) {
+		return
+	}
+
+	utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+
+	filter := bson.M{"board_id": board.ID, "visitor_id": utils.VisitorID(c, board.StrictPrivacy)}
+	cursor, err := models.GetCollection(ctx, models.VotesCollection).Find(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch votes",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var votes []models.Vote
+	if err := cursor.All(ctx, &votes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode votes",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	thumbsUp := []string{}
+	emoji := make(map[string][]string)
+	voteOptions := make(map[string]string)
+	pollAnswers := make(map[string]string)
+	for _, vote := range votes {
+		switch vote.Type {
+		case string(models.VoteThumbsUp):
+			thumbsUp = append(thumbsUp, vote.IdeaID)
+		case string(models.VoteEmoji):
+			emoji[vote.IdeaID] = append(emoji[vote.IdeaID], vote.Emoji)
+		case string(models.VoteOption):
+			voteOptions[vote.IdeaID] = vote.Option
+		case string(models.VotePoll):
+			pollAnswers[vote.IdeaID] = vote.Option
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"thumbsUp": thumbsUp,
+		"emoji":    emoji,
+		"options":  voteOptions,
+		"polls":    pollAnswers,
+	})
+}
+
+// GetFeedbackNotes handles GET /api/boards/:id/feedback-notes
+func GetFeedbackNotes(c *gin.Context) {
+	boardID := c.Param("id")
+
+	// Parse query parameters
+	var req GetFeedbackNotesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid query parameters",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	// Set defaults
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 50
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	ctx := c.Request.Context()
+
+	filter := bson.M{"board_id": boardID, "note": bson.M{"$nin": []interface{}{nil, ""}}}
+	if req.IdeaID != "" {
+		filter["idea_id"] = req.IdeaID
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((req.Page - 1) * req.PageSize)).
+		SetLimit(int64(req.PageSize))
+
+	votesCollection := models.GetCollection(ctx, models.VotesCollection)
+	cursor, err := votesCollection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch feedback notes",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var votes []models.Vote
+	if err := cursor.All(ctx, &votes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode feedback notes",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	totalCount, err := votesCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count feedback notes",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	notes := []FeedbackNoteResponse{}
+	for _, vote := range votes {
+		notes = append(notes, FeedbackNoteResponse{
+			IdeaID:    vote.IdeaID,
+			Type:      vote.Type,
+			Emoji:     vote.Emoji,
+			Note:      vote.Note,
+			CreatedAt: vote.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notes":      notes,
+		"count":      len(notes),
+		"totalCount": totalCount,
+		"page":       req.Page,
+		"pageSize":   req.PageSize,
+		"totalPages": (int(totalCount) + req.PageSize - 1) / req.PageSize,
+	})
+}
+
+// voteOptionsOrEmpty keeps vote options serialized as a list even when a board has none
+func voteOptionsOrEmpty(options []string) []string {
+	if options == nil {
+		return []string{}
+	}
+	return options
+}
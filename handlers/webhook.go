@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBodySize bounds the webhook payloads read into memory
+const maxWebhookBodySize = 1 << 20
+
+// Clerk user lifecycle events handled by the webhook
+const (
+	clerkUserUpdated = "user.updated"
+	clerkUserDeleted = "user.deleted"
+)
+
+// ClerkWebhookEvent is the envelope of a Clerk webhook; data holds the event's object
+type ClerkWebhookEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// HandleClerkWebhook handles POST /api/webhooks/clerk. Events are verified against CLERK_WEBHOOK_SECRET;
+// deleted users have their data removed and updated users have their cached profile refreshed.
+// Other events are acknowledged and ignored.
+func HandleClerkWebhook(c *gin.Context) {
+	secret := os.Getenv("CLERK_WEBHOOK_SECRET")
+	if secret == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "WEBHOOK_NOT_CONFIGURED",
+				"message": "Clerk webhooks are not configured",
+			},
+		})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Failed to read webhook payload",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := utils.VerifyWebhookSignature(secret, c.Request.Header, body, time.Now()); err != nil {
+		log.Printf("[Handler] HandleClerkWebhook failed - Verification error: %v, IP: %s", err, c.ClientIP())
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"code":    "INVALID_SIGNATURE",
+				"message": "Webhook signature verification failed",
+			},
+		})
+		return
+	}
+
+	var event ClerkWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid webhook payload",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	if event.Type != clerkUserDeleted && event.Type != clerkUserUpdated {
+		log.Printf("[Handler] HandleClerkWebhook ignored - Type: %s", event.Type)
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
+	}
+
+	var clerkUser clerk.User
+	if err := json.Unmarshal(event.Data, &clerkUser); err != nil || clerkUser.ID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid webhook payload",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	switch event.Type {
+	case clerkUserDeleted:
+		// The user is already gone, so workspaces without another admin keep their boards without an owner
+		heirs, _, err := findWorkspaceHeirs(ctx, clerkUser.ID)
+		if err != nil {
+			log.Printf("[Handler] HandleClerkWebhook failed - Workspace admin lookup error: %v, UserID: %s", err, clerkUser.ID)
+			c.JSON(http.StatusBadGateway, gin.H{
+				"error": gin.H{
+					"code":    "WORKSPACE_ADMIN_LOOKUP_FAILED",
+					"message": "Failed to find who takes over the user's workspace boards",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+
+		report, err := deleteUserData(ctx, clerkUser.ID, heirs)
+		if err != nil {
+			// Clerk retries failed deliveries, and the cleanup picks up where it stopped
+			log.Printf("[Handler] HandleClerkWebhook failed - Deletion error: %v, Report: %+v, UserID: %s", err, *report, clerkUser.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to delete user data",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		log.Printf("[Handler] HandleClerkWebhook user deleted - Report: %+v, UserID: %s", *report, clerkUser.ID)
+
+	case clerkUserUpdated:
+		if err := models.UpsertUser(ctx, middleware.ClerkUserProfile(&clerkUser)); err != nil {
+			log.Printf("[Handler] HandleClerkWebhook failed - Profile sync error: %v, UserID: %s", err, clerkUser.ID)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to update user profile",
+					"details": err.Error(),
+				},
+			})
+			return
+		}
+		log.Printf("[Handler] HandleClerkWebhook profile synced - UserID: %s", clerkUser.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// BoardWebSocket handles GET /api/ws/boards/:boardId. Board members connect with the board ID and
+// offer their session token with the "disko-auth" subprotocol; they also receive private events.
+// Anyone else connects with the public link of a public board, which proves they may see it.
+func BoardWebSocket(c *gin.Context) {
+	boardID, userID, ok := authorizeBoardStream(c, utils.WebSocketToken(c.Request))
+	if !ok {
+		return
+	}
+
+	var operate utils.OperationHandler
+	if userID != "" {
+		operate = socketOperationHandler(c, boardID)
+	}
+	utils.ServeBoardSocket(c, boardID, userID, operate, boardStreamAccess(c, boardID, userID))
+}
+
+// socketOperationHandler applies a member's WebSocket operations as the connection's user, with the
+// validation, permission checks, audit entries and broadcasts of the matching REST endpoints
+func socketOperationHandler(c *gin.Context, boardID string) utils.OperationHandler {
+	who := requestActor(c) // Identity and impersonation as resolved at connect time
+	return func(op utils.ClientOperation) (int, interface{}) {
+		if (op.Type != utils.OperationMoveIdea && op.Type != utils.OperationUpdateStatus) || op.IdeaID == "" {
+			return http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_OPERATION",
+					"message": "Unknown operation or missing ideaId",
+				},
+			}
+		}
+
+		// Operation data is the body of the matching REST request
+		var apply func(ctx context.Context) (*models.Idea, *ideaChangeError)
+		switch op.Type {
+		case utils.OperationMoveIdea:
+			var req UpdateIdeaPositionRequest
+			if err := binding.JSON.BindBody(op.Data, &req); err != nil {
+				return invalidOperationData(err)
+			}
+			apply = func(ctx context.Context) (*models.Idea, *ideaChangeError) {
+				return moveIdea(ctx, who, op.IdeaID, req)
+			}
+		case utils.OperationUpdateStatus:
+			var req UpdateIdeaStatusRequest
+			if err := binding.JSON.BindBody(op.Data, &req); err != nil {
+				return invalidOperationData(err)
+			}
+			apply = func(ctx context.Context) (*models.Idea, *ideaChangeError) {
+				return changeIdeaStatus(ctx, who, op.IdeaID, req, req.Version)
+			}
+		}
+
+		// The socket follows one board; its operations may only touch that board's ideas
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		count, err := models.GetCollection(ctx, models.IdeasCollection).CountDocuments(ctx, bson.M{"_id": op.IdeaID, "board_id": boardID})
+		cancel()
+		if err != nil {
+			return http.StatusInternalServerError, databaseError("Failed to fetch idea", err).body()
+		}
+		if count == 0 {
+			return http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "IDEA_NOT_FOUND",
+					"message": "Idea not found on this board",
+				},
+			}
+		}
+
+		// Each operation gets the timeout of a REST request; the socket's own context has no deadline
+		ctx, cancel = context.WithTimeout(c.Request.Context(), middleware.DefaultRequestTimeout())
+		defer cancel()
+		updatedIdea, changeErr := apply(ctx)
+
+		status, body := http.StatusOK, interface{}(nil)
+		if changeErr != nil {
+			status, body = changeErr.status, changeErr.body()
+		} else {
+			body = newIdeaResponse(*updatedIdea)
+		}
+		log.Printf("[Handler] Socket operation - Type: %s, IdeaID: %s, BoardID: %s, Status: %d, IP: %s",
+			op.Type, op.IdeaID, boardID, status, c.ClientIP())
+		return status, body
+	}
+}
+
+// invalidOperationData is the result of an operation whose data does not match the REST request body
+func invalidOperationData(err error) (int, interface{}) {
+	return http.StatusBadRequest, gin.H{
+		"error": gin.H{
+			"code":    "VALIDATION_ERROR",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		},
+	}
+}
+
+// BoardEventStream handles GET /api/sse/boards/:boardId, streaming the WebSocket's events as
+// Server-Sent Events for clients behind proxies that block upgrades. Members send their session
+// token in the Authorization header; public visitors use the public link as with the WebSocket.
+func BoardEventStream(c *gin.Context) {
+	token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	boardID, userID, ok := authorizeBoardStream(c, token)
+	if !ok {
+		return
+	}
+
+	utils.ServeBoardEvents(c, boardID, userID, boardStreamAccess(c, boardID, userID))
+}
+
+// authorizeBoardStream resolves the board a realtime client may follow. With a session token the
+// caller must be a board member and userID is set; without one, :boardId is a public link. It writes
+// an error response and returns false when access is refused.
+func authorizeBoardStream(c *gin.Context, token string) (string, string, bool) {
+	ref := c.Param("boardId")
+
+	userID := ""
+	if token != "" {
+		if err := middleware.AuthenticateSessionToken(c, token); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_TOKEN",
+					"message": "Invalid or expired token",
+				},
+			})
+			return "", "", false
+		}
+		userID, _ = middleware.GetUserID(c)
+	}
+
+	// Authenticating may have scoped the request to the session's workspace
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var board models.Board
+	if userID != "" {
+		err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, middleware.BoardAccessFilter(c, ref, userID, models.RoleViewer)).Decode(&board)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				log.Printf("[Handler] Realtime connection failed - Board not found or access denied - BoardID: %s, UserID: %s, IP: %s", ref, userID, c.ClientIP())
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": gin.H{
+						"code":    "BOARD_NOT_FOUND",
+						"message": "Board not found or you don't have permission to access it",
+					},
+				})
+				return "", "", false
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch board",
+					"details": err.Error(),
+				},
+			})
+			return "", "", false
+		}
+	} else {
+		err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": ref, "is_public": true}).Decode(&board)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": gin.H{
+						"code":    "BOARD_NOT_FOUND",
+						"message": "Board not found or is not publicly accessible",
+					},
+				})
+				return "", "", false
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch board",
+					"details": err.Error(),
+				},
+			})
+			return "", "", false
+		}
+
+		if rejectBlockedVisitor(c, &board) {
+			return "", "", false
+		}
+	}
+
+	return board.ID, userID, true
+}
+
+// sessionStreamAccess returns the check a member's open realtime connection repeats: the session it
+// connected with must still be active with the identity provider
+func sessionStreamAccess(c *gin.Context) utils.StreamAuthorizer {
+	sessionID, _ := c.Get("sessionID")
+	userID, _ := c.Get("userID")
+	return func(ctx context.Context) bool {
+		id, _ := sessionID.(string)
+		active, err := middleware.SessionActive(ctx, id)
+		if err != nil {
+			log.Printf("[Handler] Realtime session check failed - Error: %v, UserID: %v", err, userID)
+			return true
+		}
+		return active
+	}
+}
+
+// boardStreamAccess returns the check an open realtime connection repeats to keep following the board,
+// matching authorizeBoardStream: members must keep an active session and their access to the board,
+// public visitors the board staying public under the link they used and open to their network
+func boardStreamAccess(c *gin.Context, boardID, userID string) utils.StreamAuthorizer {
+	snapshot := c.Copy() // Identity and workspace as resolved at connect time
+	ip := c.ClientIP()
+	publicLink := c.Param("boardId")
+	sessionActive := sessionStreamAccess(snapshot)
+
+	return func(ctx context.Context) bool {
+		if userID != "" {
+			if !sessionActive(ctx) {
+				return false
+			}
+			_, err := middleware.FindAccessibleBoard(ctx, snapshot, boardID, userID, models.RoleViewer)
+			if err != nil && err != mongo.ErrNoDocuments {
+				log.Printf("[Handler] Realtime access check failed - Error: %v, BoardID: %s, UserID: %s", err, boardID, userID)
+				return true
+			}
+			return err == nil
+		}
+
+		// Without the board its IP rules cannot be checked, so visitors are disconnected
+		board, err := models.FindBoardByID(ctx, boardID)
+		if err != nil {
+			if err != mongo.ErrNoDocuments {
+				log.Printf("[Handler] Realtime access check failed - Error: %v, BoardID: %s, IP: %s", err, boardID, ip)
+			}
+			return false
+		}
+		return board.IsPublic && board.PublicLink == publicLink && board.IPRules.Permits(ip)
+	}
+}
+
+// GetBoardPresence handles GET /api/boards/:id/presence, listing who is viewing the board live
+func GetBoardPresence(c *gin.Context) {
+	board, ok := contextBoard(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.GetBoardPresence(board.ID))
+}
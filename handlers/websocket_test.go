@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// socketAs returns the operation handler of a board socket opened by the signed-in user
+func socketAs(userID, boardID string) utils.OperationHandler {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/ws/boards/"+boardID, nil)
+	c.Set("userID", userID)
+	return socketOperationHandler(c, boardID)
+}
+
+// operationErrorCode returns the error code of an operation result
+func operationErrorCode(t *testing.T, body interface{}) string {
+	t.Helper()
+	encoded, err := json.Marshal(body)
+	require.NoError(t, err)
+	var result struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &result))
+	return result.Error.Code
+}
+
+func TestSocketOperationValidation(t *testing.T) {
+	operate := socketAs("user_1", "board_1")
+
+	t.Run("Unknown Operation", func(t *testing.T) {
+		status, body := operate(utils.ClientOperation{Type: "delete_idea", IdeaID: "idea_1"})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "INVALID_OPERATION", operationErrorCode(t, body))
+	})
+
+	t.Run("Missing Idea", func(t *testing.T) {
+		status, body := operate(utils.ClientOperation{Type: utils.OperationMoveIdea, Data: json.RawMessage(`{"column": "now"}`)})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "INVALID_OPERATION", operationErrorCode(t, body))
+	})
+
+	t.Run("Invalid Data", func(t *testing.T) {
+		for _, op := range []utils.ClientOperation{
+			{Type: utils.OperationMoveIdea, IdeaID: "idea_1", Data: json.RawMessage(`{"position": 1}`)},
+			{Type: utils.OperationMoveIdea, IdeaID: "idea_1", Data: json.RawMessage(`{"column": "now", "position": -1}`)},
+			{Type: utils.OperationUpdateStatus, IdeaID: "idea_1", Data: json.RawMessage(`{"inProgress": "yes"}`)},
+		} {
+			status, body := operate(op)
+			assert.Equal(t, http.StatusBadRequest, status, string(op.Data))
+			assert.Equal(t, "VALIDATION_ERROR", operationErrorCode(t, body), string(op.Data))
+		}
+	})
+}
+
+func TestSocketOperationsMoveIdeas(t *testing.T) {
+	connectTestDatabase(t)
+	userID := "user_" + utils.GenerateShortUUID()
+
+	t.Run("Move Idea", func(t *testing.T) {
+		boardID, ideaIDs := createTestBoard(t, userID, "now", "now", "next")
+
+		status, body := socketAs(userID, boardID)(utils.ClientOperation{
+			Type:   utils.OperationMoveIdea,
+			IdeaID: ideaIDs[0],
+			Data:   json.RawMessage(`{"column": "next", "position": 1}`),
+		})
+		require.Equal(t, http.StatusOK, status, body)
+
+		ids, positions := columnOrder(t, boardID, "next")
+		assert.Equal(t, []string{ideaIDs[0], ideaIDs[2]}, ids)
+		assert.Equal(t, []int{1, 2}, positions)
+	})
+
+	t.Run("Update Status", func(t *testing.T) {
+		boardID, ideaIDs := createTestBoard(t, userID, "now", "release")
+
+		status, body := socketAs(userID, boardID)(utils.ClientOperation{
+			Type:   utils.OperationUpdateStatus,
+			IdeaID: ideaIDs[0],
+			Data:   json.RawMessage(`{"status": "done"}`),
+		})
+		require.Equal(t, http.StatusOK, status, body)
+
+		ids, positions := columnOrder(t, boardID, "release")
+		assert.Equal(t, []string{ideaIDs[1], ideaIDs[0]}, ids)
+		assert.Equal(t, []int{1, 2}, positions)
+	})
+
+	t.Run("Other Users Are Refused", func(t *testing.T) {
+		boardID, ideaIDs := createTestBoard(t, userID, "now")
+
+		status, body := socketAs("user_"+utils.GenerateShortUUID(), boardID)(utils.ClientOperation{
+			Type:   utils.OperationMoveIdea,
+			IdeaID: ideaIDs[0],
+			Data:   json.RawMessage(`{"column": "next", "position": 1}`),
+		})
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Equal(t, "PERMISSION_DENIED", operationErrorCode(t, body))
+	})
+
+	t.Run("Ideas Of Other Boards", func(t *testing.T) {
+		boardID, _ := createTestBoard(t, userID, "now")
+		_, otherIdeas := createTestBoard(t, userID, "now")
+
+		status, body := socketAs(userID, boardID)(utils.ClientOperation{
+			Type:   utils.OperationMoveIdea,
+			IdeaID: otherIdeas[0],
+			Data:   json.RawMessage(`{"column": "next", "position": 1}`),
+		})
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, "IDEA_NOT_FOUND", operationErrorCode(t, body))
+	})
+}
@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/middleware"
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// WorkspaceRequest represents the request payload for creating or renaming a workspace
+type WorkspaceRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// WorkspaceResponse describes the caller's current workspace with its plan limits and usage
+type WorkspaceResponse struct {
+	models.Workspace
+	Role   models.WorkspaceRole `json:"role"`
+	Limits models.PlanLimits    `json:"limits"`
+	Usage  WorkspaceUsage       `json:"usage"`
+}
+
+// WorkspaceUsage counts what a workspace uses against its plan limits
+type WorkspaceUsage struct {
+	Boards int64 `json:"boards"`
+}
+
+// WorkspaceBoardSummary is a board listed in a workspace
+type WorkspaceBoardSummary struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	IsPublic    bool              `json:"isPublic"`
+	Archived    bool              `json:"archived"`
+	UserID      string            `json:"userId"`
+	Role        models.MemberRole `json:"role"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// boardAccessFilter checks board access for handlers whose board is not the :id route parameter
+// (ideas, comments, releases, ...); routes on /boards/:id use middleware.RequireBoardAccess instead
+func boardAccessFilter(c *gin.Context, boardID, userID string, required models.MemberRole) bson.M {
+	return middleware.BoardAccessFilter(c, boardID, userID, required)
+}
+
+// contextBoard returns the board loaded by middleware.RequireBoardAccess, writing INTERNAL_ERROR if the route lacks it
+func contextBoard(c *gin.Context) (*models.Board, bool) {
+	board, err := middleware.GetBoard(c)
+	if err != nil {
+		log.Printf("[Handler] contextBoard failed - %v, Path: %s", err, c.FullPath())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to load board",
+			},
+		})
+		return nil, false
+	}
+	return board, true
+}
+
+// callerBoardRole returns the caller's role on a board, including access through its workspace
+func callerBoardRole(c *gin.Context, board *models.Board, userID string) models.MemberRole {
+	return middleware.BoardRole(c, board, userID)
+}
+
+// currentWorkspace loads the workspace of the caller's active organization, writing an error response if there is none
+func currentWorkspace(ctx context.Context, c *gin.Context) (*models.Workspace, models.WorkspaceRole, bool) {
+	orgID, orgRole := middleware.GetOrganization(c)
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "ORGANIZATION_REQUIRED",
+				"message": "Select an organization to use workspaces",
+			},
+		})
+		return nil, "", false
+	}
+
+	var workspace models.Workspace
+	err := models.GetCollection(ctx, models.WorkspacesCollection).FindOne(ctx, bson.M{"_id": orgID}).Decode(&workspace)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "WORKSPACE_NOT_FOUND",
+					"message": "Your organization has no workspace yet",
+				},
+			})
+			return nil, "", false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch workspace",
+				"details": err.Error(),
+			},
+		})
+		return nil, "", false
+	}
+	return &workspace, models.WorkspaceRoleFromClerk(orgRole), true
+}
+
+// rejectIfNotWorkspaceAdmin writes WORKSPACE_ADMIN_REQUIRED unless the caller is an organization admin
+func rejectIfNotWorkspaceAdmin(c *gin.Context, role models.WorkspaceRole) bool {
+	if role == models.WorkspaceAdmin {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{
+		"error": gin.H{
+			"code":    "WORKSPACE_ADMIN_REQUIRED",
+			"message": "Only organization admins can manage the workspace",
+		},
+	})
+	return true
+}
+
+// rejectBoardWorkspace checks that a board may be placed in a workspace: it must be the caller's
+// active organization's workspace and stay within its plan's board limit. It writes the error and
+// returns true when the placement is rejected; an empty workspace ID is always accepted.
+func rejectBoardWorkspace(ctx context.Context, c *gin.Context, workspaceID, boardID string) bool {
+	if workspaceID == "" {
+		return false
+	}
+
+	orgID, _ := middleware.GetOrganization(c)
+	if workspaceID != orgID {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "WORKSPACE_NOT_ACTIVE",
+				"message": "Boards can only be added to the workspace of your active organization",
+			},
+		})
+		return true
+	}
+
+	workspace, _, ok := currentWorkspace(ctx, c)
+	if !ok {
+		return true
+	}
+
+	limits := workspace.Limits()
+	if limits.MaxBoards == 0 {
+		return false
+	}
+	count, err := models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, bson.M{
+		"workspace_id": workspace.ID,
+		"_id":          bson.M{"$ne": boardID},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count workspace boards",
+				"details": err.Error(),
+			},
+		})
+		return true
+	}
+	if count >= int64(limits.MaxBoards) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "PLAN_LIMIT_REACHED",
+				"message": fmt.Sprintf("The %s plan allows at most %d boards per workspace", workspace.Plan, limits.MaxBoards),
+			},
+		})
+		return true
+	}
+	return false
+}
+
+// CreateWorkspace handles POST /api/workspaces, creating the workspace of the caller's active organization
+func CreateWorkspace(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	orgID, orgRole := middleware.GetOrganization(c)
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "ORGANIZATION_REQUIRED",
+				"message": "Select an organization to use workspaces",
+			},
+		})
+		return
+	}
+	if rejectIfNotWorkspaceAdmin(c, models.WorkspaceRoleFromClerk(orgRole)) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	now := time.Now().UTC()
+	workspace := models.Workspace{
+		ID:        orgID,
+		Name:      strings.TrimSpace(req.Name),
+		Plan:      string(models.PlanFree),
+		CreatedBy: userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := models.GetCollection(ctx, models.WorkspacesCollection).InsertOne(ctx, workspace); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "WORKSPACE_EXISTS",
+					"message": "Your organization already has a workspace",
+				},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to create workspace",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] CreateWorkspace success - WorkspaceID: %s, UserID: %s, IP: %s", workspace.ID, userID, c.ClientIP())
+
+	c.JSON(http.StatusCreated, WorkspaceResponse{
+		Workspace: workspace,
+		Role:      models.WorkspaceAdmin,
+		Limits:    workspace.Limits(),
+	})
+}
+
+// GetCurrentWorkspace handles GET /api/workspaces/current
+func GetCurrentWorkspace(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	workspace, role, ok := currentWorkspace(ctx, c)
+	if !ok {
+		return
+	}
+
+	boards, err := models.GetCollection(ctx, models.BoardsCollection).CountDocuments(ctx, bson.M{"workspace_id": workspace.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to count workspace boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, WorkspaceResponse{
+		Workspace: *workspace,
+		Role:      role,
+		Limits:    workspace.Limits(),
+		Usage:     WorkspaceUsage{Boards: boards},
+	})
+}
+
+// UpdateCurrentWorkspace handles PUT /api/workspaces/current (organization admins only)
+func UpdateCurrentWorkspace(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	// Parse request body
+	var req WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid request data",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	workspace, role, ok := currentWorkspace(ctx, c)
+	if !ok {
+		return
+	}
+	if rejectIfNotWorkspaceAdmin(c, role) {
+		return
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated models.Workspace
+	err = models.GetCollection(ctx, models.WorkspacesCollection).FindOneAndUpdate(ctx, bson.M{"_id": workspace.ID}, bson.M{
+		"$set": bson.M{"name": strings.TrimSpace(req.Name), "updated_at": time.Now().UTC()},
+	}, opts).Decode(&updated)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to update workspace",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	log.Printf("[Handler] UpdateCurrentWorkspace success - WorkspaceID: %s, UserID: %s, IP: %s", workspace.ID, userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, WorkspaceResponse{
+		Workspace: updated,
+		Role:      role,
+		Limits:    updated.Limits(),
+	})
+}
+
+// GetWorkspaceBoards handles GET /api/workspaces/current/boards
+func GetWorkspaceBoards(c *gin.Context) {
+	// Get user ID from auth middleware
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user ID",
+			},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	workspace, _, ok := currentWorkspace(ctx, c)
+	if !ok {
+		return
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := models.GetCollection(ctx, models.BoardsCollection).Find(ctx, bson.M{"workspace_id": workspace.ID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to fetch workspace boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var boards []models.Board
+	if err := cursor.All(ctx, &boards); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to decode workspace boards",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+
+	summaries := []WorkspaceBoardSummary{}
+	for i := range boards {
+		board := &boards[i]
+		summaries = append(summaries, WorkspaceBoardSummary{
+			ID:          board.ID,
+			Name:        board.Name,
+			Description: board.Description,
+			IsPublic:    board.IsPublic,
+			Archived:    board.Archived,
+			UserID:      board.UserID,
+			Role:        callerBoardRole(c, board, userID),
+			UpdatedAt:   board.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workspace": workspace.ID,
+		"boards":    summaries,
+		"count":     len(summaries),
+	})
+}
@@ -0,0 +1,303 @@
+// Package jobs runs recurring background work, such as syncs, digests and purges, on cron-like
+// schedules. Every instance runs the scheduler; a lock in MongoDB makes sure each scheduled run
+// happens on only one of them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// defaultJobTimeout bounds a run when the job does not set a Timeout
+const defaultJobTimeout = 10 * time.Minute
+
+// Job is a recurring task
+type Job struct {
+	Name     string        // Unique; also names the job's lock
+	Schedule string        // See ParseSchedule
+	Timeout  time.Duration // Cancels the run's context; defaults to 10 minutes
+	Run      func(ctx context.Context) error
+	// PerTenant calls Run for the main database and then for each tenant, with ctx scoped to it,
+	// all within one run and its timeout
+	PerTenant bool
+}
+
+// JobStats describes a job's runs. The counters and last run are this instance's since it started;
+// Shared is the job's last run on any instance.
+type JobStats struct {
+	Name           string               `json:"name"`
+	Schedule       string               `json:"schedule"`
+	Running        bool                 `json:"running"`
+	NextRunAt      time.Time            `json:"nextRunAt"`
+	Runs           uint64               `json:"runs"`
+	Failures       uint64               `json:"failures"` // Runs that returned an error or panicked
+	Skipped        uint64               `json:"skipped"`  // Runs claimed by another instance
+	LastRunAt      *time.Time           `json:"lastRunAt,omitempty"`
+	LastDurationMs int64                `json:"lastDurationMs"`
+	LastError      string               `json:"lastError,omitempty"`
+	Shared         *models.ScheduledJob `json:"shared,omitempty"`
+}
+
+// scheduledJob is a registered job with its parsed schedule and stats
+type scheduledJob struct {
+	Job
+	schedule Schedule
+
+	mu    sync.Mutex
+	stats JobStats
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []*scheduledJob
+	started    bool
+
+	// instanceID identifies this instance as the owner of the jobs it runs
+	instanceID = utils.GenerateFullUUID()
+)
+
+// Register adds a job to the scheduler. Jobs registered after Start begin right away.
+func Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("job needs a name and a Run function")
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, existing := range registry {
+		if existing.Name == job.Name {
+			return fmt.Errorf("job %s is already registered", job.Name)
+		}
+	}
+
+	entry := &scheduledJob{
+		Job:      job,
+		schedule: schedule,
+		stats:    JobStats{Name: job.Name, Schedule: job.Schedule},
+	}
+	registry = append(registry, entry)
+	if started {
+		go entry.loop()
+	}
+	log.Printf("[Jobs] Job registered - Name: %s, Schedule: %s, Timeout: %v", job.Name, job.Schedule, job.Timeout)
+	return nil
+}
+
+// Start runs the registered jobs on their schedules. A run still going when its next slot comes
+// makes the job skip that slot rather than run twice.
+func Start() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if started {
+		return
+	}
+	started = true
+	for _, job := range registry {
+		go job.loop()
+	}
+	log.Printf("[Jobs] Scheduler started - Jobs: %d, InstanceID: %s", len(registry), instanceID)
+}
+
+// Stats returns the stats of every registered job in registration order, with their shared state
+func Stats(ctx context.Context) ([]JobStats, error) {
+	registryMu.Lock()
+	jobs := append([]*scheduledJob(nil), registry...)
+	registryMu.Unlock()
+
+	stats := make([]JobStats, 0, len(jobs))
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		job.mu.Lock()
+		stats = append(stats, job.stats)
+		job.mu.Unlock()
+		names = append(names, job.Name)
+	}
+	if models.DB == nil || len(names) == 0 {
+		return stats, nil
+	}
+
+	cursor, err := models.GetCollection(ctx, models.ScheduledJobsCollection).Find(ctx, bson.M{"_id": bson.M{"$in": names}})
+	if err != nil {
+		return nil, err
+	}
+	var shared []models.ScheduledJob
+	if err := cursor.All(ctx, &shared); err != nil {
+		return nil, err
+	}
+	for i := range shared {
+		for j := range stats {
+			if stats[j].Name == shared[i].Name {
+				stats[j].Shared = &shared[i]
+			}
+		}
+	}
+	return stats, nil
+}
+
+// InstanceID returns the ID this instance claims jobs with
+func InstanceID() string {
+	return instanceID
+}
+
+// loop waits for each slot of the job's schedule and runs it
+func (j *scheduledJob) loop() {
+	for {
+		slot := j.schedule.Next(time.Now())
+		if slot.IsZero() {
+			log.Printf("[Jobs] Job has no more runs - Name: %s", j.Name)
+			return
+		}
+		j.mu.Lock()
+		j.stats.NextRunAt = slot
+		j.mu.Unlock()
+
+		time.Sleep(time.Until(slot))
+		j.runSlot(slot)
+	}
+}
+
+// runSlot runs the job for a slot if this instance claims it first
+func (j *scheduledJob) runSlot(slot time.Time) {
+	if models.DB == nil {
+		return
+	}
+
+	claimed, err := claimSlot(j.Name, slot, j.Timeout)
+	if err != nil {
+		log.Printf("[Jobs] Failed to claim job - Name: %s, Slot: %v, Error: %v", j.Name, slot, err)
+		return
+	}
+	if !claimed {
+		j.mu.Lock()
+		j.stats.Skipped++
+		j.mu.Unlock()
+		return
+	}
+
+	j.mu.Lock()
+	j.stats.Running = true
+	j.mu.Unlock()
+
+	startedAt := time.Now().UTC()
+	log.Printf("[Jobs] Job started - Name: %s, Slot: %v", j.Name, slot)
+	runErr := j.runWithTimeout()
+	duration := time.Since(startedAt)
+
+	j.mu.Lock()
+	j.stats.Running = false
+	j.stats.Runs++
+	j.stats.LastRunAt = &startedAt
+	j.stats.LastDurationMs = duration.Milliseconds()
+	j.stats.LastError = ""
+	if runErr != nil {
+		j.stats.Failures++
+		j.stats.LastError = runErr.Error()
+	}
+	j.mu.Unlock()
+
+	if runErr != nil {
+		log.Printf("[Jobs] Job failed - Name: %s, Duration: %v, Error: %v", j.Name, duration, runErr)
+	} else {
+		log.Printf("[Jobs] Job finished - Name: %s, Duration: %v", j.Name, duration)
+	}
+
+	if err := releaseSlot(j.Name, duration, runErr); err != nil {
+		log.Printf("[Jobs] Failed to record job run - Name: %s, Error: %v", j.Name, err)
+	}
+}
+
+// runWithTimeout calls Run with the job's timeout, turning a panic into an error
+func (j *scheduledJob) runWithTimeout() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), j.Timeout)
+	defer cancel()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	if !j.PerTenant {
+		return j.Run(ctx)
+	}
+
+	var errs []error
+	models.ForEachTenant(ctx, func(ctx context.Context) {
+		if err := j.Run(ctx); err != nil {
+			if tenantID := models.TenantFromContext(ctx); tenantID != "" {
+				err = fmt.Errorf("tenant %s: %w", tenantID, err)
+			}
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// claimSlot locks the job for a slot no instance has claimed yet, holding the lock for the job's
+// timeout. The document is created the first time the job runs anywhere; while another instance
+// holds it, or has claimed the slot already, the upsert hits the existing document's ID and fails.
+func claimSlot(name string, slot time.Time, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	_, err := models.GetCollection(ctx, models.ScheduledJobsCollection).UpdateOne(ctx,
+		bson.M{
+			"_id":               name,
+			"locked_until":      bson.M{"$lte": now},
+			"last_scheduled_at": bson.M{"$lt": slot},
+		},
+		bson.M{"$set": bson.M{
+			"owner":             instanceID,
+			"locked_until":      now.Add(timeout),
+			"last_scheduled_at": slot,
+			"last_started_at":   now,
+		}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseSlot unlocks the job and records how its run went
+func releaseSlot(name string, duration time.Duration, runErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	lastError := ""
+	if runErr != nil {
+		lastError = runErr.Error()
+	}
+	_, err := models.GetCollection(ctx, models.ScheduledJobsCollection).UpdateOne(ctx,
+		bson.M{"_id": name, "owner": instanceID},
+		bson.M{"$set": bson.M{
+			"locked_until":     now,
+			"last_finished_at": now,
+			"last_duration_ms": duration.Milliseconds(),
+			"last_error":       lastError,
+		}},
+	)
+	return err
+}
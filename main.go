@@ -8,9 +8,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"disko-backend/handlers"
+	"disko-backend/jobs"
 	"disko-backend/middleware"
 	"disko-backend/models"
 	"disko-backend/utils"
@@ -20,28 +22,6 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
-// Simple in-memory rate limiting (for production, use Redis)
-var rateLimitStore = make(map[string]time.Time)
-
-func isRateLimited(key string, duration time.Duration) bool {
-	if lastRequest, exists := rateLimitStore[key]; exists {
-		if time.Since(lastRequest) < duration {
-			return true
-		}
-	}
-	return false
-}
-
-func setRateLimit(key string, duration time.Duration) {
-	rateLimitStore[key] = time.Now()
-
-	// Clean up old entries (simple cleanup)
-	go func() {
-		time.Sleep(duration * 2)
-		delete(rateLimitStore, key)
-	}()
-}
-
 func init() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
@@ -72,7 +52,17 @@ func getAppVersion() string {
 	return version
 }
 
-// getPublicStats returns public statistics for the landing page
+// publicStatsTTL is how long the landing page reuses its statistics
+const publicStatsTTL = 60 * time.Second
+
+// publicStatsCache holds the latest landing page statistics
+var (
+	publicStatsCache     gin.H
+	publicStatsExpiresAt time.Time
+	publicStatsMu        sync.Mutex
+)
+
+// getPublicStats returns public statistics for the landing page, recomputed at most once per publicStatsTTL
 func getPublicStats() gin.H {
 	// Get database connection
 	if models.DB == nil {
@@ -80,56 +70,54 @@ func getPublicStats() gin.H {
 		return gin.H{"boards": 0, "ideas": 0, "feedback": 0}
 	}
 
+	// Holding the lock while computing keeps concurrent renders from all querying at expiry
+	publicStatsMu.Lock()
+	defer publicStatsMu.Unlock()
+	if publicStatsCache != nil && time.Now().Before(publicStatsExpiresAt) {
+		return publicStatsCache
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// Count all boards
-	boardsCollection := models.GetCollection(models.BoardsCollection)
-	boardsCount, err := boardsCollection.CountDocuments(ctx, bson.M{})
+	boardsCount, err := models.GetCollection(ctx, models.BoardsCollection).EstimatedDocumentCount(ctx)
 	if err != nil {
 		log.Printf("[Stats] Error counting boards: %v", err)
-		boardsCount = 0
+		return gin.H{"boards": 0, "ideas": 0, "feedback": 0}
 	}
 
-	// Count all ideas
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	ideasCount, err := ideasCollection.CountDocuments(ctx, bson.M{})
+	// Count ideas and total their feedback (thumbs up and emoji reactions) in the database
+	var totals struct {
+		Ideas    int64 `bson:"ideas"`
+		ThumbsUp int64 `bson:"thumbs_up"`
+		Emoji    int64 `bson:"emoji"`
+	}
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Aggregate(ctx, []bson.M{
+		{"$group": bson.M{
+			"_id":       nil,
+			"ideas":     bson.M{"$sum": 1},
+			"thumbs_up": bson.M{"$sum": "$thumbs_up"},
+			"emoji":     bson.M{"$sum": bson.M{"$sum": "$emoji_reactions.count"}},
+		}},
+	})
 	if err != nil {
-		log.Printf("[Stats] Error counting ideas: %v", err)
-		ideasCount = 0
+		log.Printf("[Stats] Error aggregating ideas: %v", err)
+		return gin.H{"boards": boardsCount, "ideas": 0, "feedback": 0}
 	}
-
-	// Count feedback (thumbs up and emoji reactions)
-	feedbackCount := 0
-	cursor, err := ideasCollection.Find(ctx, bson.M{})
-	if err == nil {
-		defer cursor.Close(ctx)
-		var ideas []bson.M
-		if err := cursor.All(ctx, &ideas); err == nil {
-			for _, idea := range ideas {
-				// Count thumbs up
-				if thumbsUp, exists := idea["thumbsUp"]; exists {
-					if thumbsUpInt, ok := thumbsUp.(int32); ok {
-						feedbackCount += int(thumbsUpInt)
-					} else if thumbsUpInt, ok := thumbsUp.(int64); ok {
-						feedbackCount += int(thumbsUpInt)
-					} else if thumbsUpInt, ok := thumbsUp.(int); ok {
-						feedbackCount += thumbsUpInt
-					}
-				}
-
-				// Count emoji reactions
-				if emojiReactions, exists := idea["emojiReactions"]; exists {
-					if reactionsArray, ok := emojiReactions.([]interface{}); ok {
-						feedbackCount += len(reactionsArray)
-					}
-				}
-			}
+	defer cursor.Close(ctx)
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&totals); err != nil {
+			log.Printf("[Stats] Error decoding idea totals: %v", err)
+			return gin.H{"boards": boardsCount, "ideas": 0, "feedback": 0}
 		}
 	}
 
-	log.Printf("[Stats] Landing page stats - Boards: %d, Ideas: %d, Feedback: %d", boardsCount, ideasCount, feedbackCount)
-	return gin.H{"boards": boardsCount, "ideas": ideasCount, "feedback": feedbackCount}
+	publicStatsCache = gin.H{"boards": boardsCount, "ideas": totals.Ideas, "feedback": totals.ThumbsUp + totals.Emoji}
+	publicStatsExpiresAt = time.Now().Add(publicStatsTTL)
+
+	log.Printf("[Stats] Landing page stats - Boards: %d, Ideas: %d, Feedback: %d", boardsCount, totals.Ideas, totals.ThumbsUp+totals.Emoji)
+	return publicStatsCache
 }
 
 func main() {
@@ -143,21 +131,90 @@ func main() {
 		}
 	}()
 
-	// Initialize Clerk authentication
-	if err := middleware.InitializeClerk(); err != nil {
-		log.Fatal("Failed to initialize Clerk:", err)
+	// Select where logos, attachments and export files are stored (local disk unless STORAGE_BACKEND says otherwise)
+	if err := utils.InitStorage(); err != nil {
+		log.Fatal("Failed to configure asset storage:", err)
+	}
+
+	// Reset the boards of a fixtures file, for demo environments and integration tests
+	if path := os.Getenv("FIXTURES_FILE"); path != "" {
+		if err := handlers.LoadFixturesFile(path); err != nil {
+			log.Fatalf("Failed to load fixtures from %s: %v", path, err)
+		}
+	}
+
+	// Hosts dedicated to one workspace when workspaces keep their data apart (TENANCY_MODE)
+	if err := middleware.InitTenancy(); err != nil {
+		log.Fatal("Failed to configure tenancy:", err)
+	}
+
+	// Initialize the identity provider (Clerk unless AUTH_PROVIDER selects another one)
+	if err := middleware.InitializeAuth(); err != nil {
+		log.Fatal("Failed to initialize authentication:", err)
 	}
 
 	// Initialize notification service
 	utils.InitNotificationService()
 
+	// Count public rate limits in Redis when several instances share them
+	if err := utils.InitRateLimiter(); err != nil {
+		log.Fatal("Failed to configure rate limiting:", err)
+	}
+
 	// Initialize WebSocket manager
 	utils.InitWebSocketManager()
 
+	// Relay WebSocket events between instances when a broker is configured
+	broker, err := utils.NewBrokerFromEnv()
+	if err != nil {
+		log.Fatal("Failed to configure WebSocket broker:", err)
+	}
+	utils.StartWebSocketBroker(broker)
+
+	// Start scheduled analytics exports
+	utils.StartExportScheduler(time.Minute)
+
+	// Deliver queued email, Slack and webhook notifications
+	utils.StartNotificationQueue(5 * time.Second)
+
+	// Send digests of notifications batched during bursts
+	utils.StartNotificationBatcher(30 * time.Second)
+
+	// Recurring jobs, each run on one instance at a time
+	if err := jobs.Register(jobs.Job{
+		Name:      "jira-status-sync", // Release ideas whose Jira issue is done
+		Schedule:  "@every 5m",
+		Timeout:   5 * time.Minute,
+		Run:       handlers.SyncJiraStatuses,
+		PerTenant: true,
+	}); err != nil {
+		log.Fatal("Failed to register jobs:", err)
+	}
+	// Backups to BACKUP_DIR for self-hosted instances without managed database backups
+	if schedule := os.Getenv("BACKUP_SCHEDULE"); schedule != "" {
+		if err := jobs.Register(jobs.Job{
+			Name:      "database-backup",
+			Schedule:  schedule,
+			Timeout:   time.Hour,
+			Run:       utils.RunScheduledBackup,
+			PerTenant: true,
+		}); err != nil {
+			log.Fatal("Failed to register the backup job:", err)
+		}
+	}
+	jobs.Start()
+
 	// Initialize Gin router
 	gin.SetMode(gin.DebugMode)
 	router := gin.Default()
 
+	// Client IPs feed rate limits and board IP rules, so only trust forwarding headers from known proxies
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		if err := router.SetTrustedProxies(strings.Split(proxies, ",")); err != nil {
+			log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+		}
+	}
+
 	// Add custom request logging middleware
 	router.Use(func(c *gin.Context) {
 		start := time.Now()
@@ -189,6 +246,15 @@ func main() {
 		)
 	})
 
+	// Scope requests to the workspace of their host when it has one of its own (TENANCY_HOSTS)
+	router.Use(middleware.TenantMiddleware())
+
+	// Bound every request and stop its work when the client disconnects; slow routes set their own timeout
+	router.Use(middleware.RequestTimeoutMiddleware())
+
+	// Compress large JSON, CSV and page responses for clients that accept it
+	router.Use(middleware.CompressionMiddleware())
+
 	// Load HTML templates
 	router.LoadHTMLGlob("templates/*")
 
@@ -302,7 +368,7 @@ func main() {
 		// Log environment variables for debugging
 		clerkKey := os.Getenv("CLERK_PUBLISHABLE_KEY")
 		clerkApiUrl := os.Getenv("CLERK_FRONTEND_API_URL")
-		log.Printf("[Template] Dashboard environment - ClerkKey: %s, ClerkApiUrl: %s",
+		log.Printf("[Template] Dashboard environment - ClerkKey: %t, ClerkApiUrl: %t",
 			clerkKey != "", clerkApiUrl != "")
 
 		// Get app version
@@ -343,7 +409,7 @@ func main() {
 		// Log environment variables for debugging
 		clerkKey := os.Getenv("CLERK_PUBLISHABLE_KEY")
 		clerkApiUrl := os.Getenv("CLERK_FRONTEND_API_URL")
-		log.Printf("[Template] Board environment - ClerkKey: %s, ClerkApiUrl: %s",
+		log.Printf("[Template] Board environment - ClerkKey: %t, ClerkApiUrl: %t",
 			clerkKey != "", clerkApiUrl != "")
 
 		// Get app version
@@ -381,32 +447,20 @@ func main() {
 		acceptLanguage := c.GetHeader("Accept-Language")
 		clientIP := c.ClientIP()
 
-		log.Printf("[Template] Public Board route accessed - PublicLink: %s, IP: %s, UserAgent: %s, Referer: %s, AcceptLanguage: %s",
-			publicLink, clientIP, userAgent, referer, acceptLanguage)
-
-		// Rate limiting for public board access
-		rateLimitKey := "public_board_" + publicLink + "_" + clientIP
-		rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_PUBLIC_BOARD_SECONDS", 30)
-		if isRateLimited(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second) {
-			log.Printf("[Template] Public Board route - Rate limited: %s, IP: %s, Limit: %ds", publicLink, clientIP, rateLimitSeconds)
-			c.HTML(http.StatusTooManyRequests, "error.html", gin.H{
-				"title":   "Rate Limited - Disko",
-				"message": fmt.Sprintf("Too many requests. Please try again in %d seconds.", rateLimitSeconds),
-			})
-			return
-		}
-		setRateLimit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+		// Visitor details are logged once the board's privacy mode is known
+		log.Printf("[Template] Public Board route accessed - PublicLink: %s, Referer: %s, AcceptLanguage: %s",
+			publicLink, referer, acceptLanguage)
 
 		// Log environment variables for debugging
 		clerkKey := os.Getenv("CLERK_PUBLISHABLE_KEY")
 		clerkApiUrl := os.Getenv("CLERK_FRONTEND_API_URL")
-		log.Printf("[Template] Public Board environment - ClerkKey: %s, ClerkApiUrl: %s",
+		log.Printf("[Template] Public Board environment - ClerkKey: %t, ClerkApiUrl: %t",
 			clerkKey != "", clerkApiUrl != "")
 
 		// Check if board exists and is public
-		collection := models.GetCollection(models.BoardsCollection)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 		defer cancel()
+		collection := models.GetCollection(ctx, models.BoardsCollection)
 
 		filter := bson.M{"public_link": publicLink, "is_public": true}
 		var board models.Board
@@ -421,6 +475,35 @@ func main() {
 
 		log.Printf("[Template] Public Board route - Board is public: %s", publicLink)
 
+		if !board.IPRules.Permits(clientIP) {
+			log.Printf("[Template] Public Board route - Blocked by IP rules: %s", publicLink)
+			c.HTML(http.StatusForbidden, "error.html", gin.H{
+				"title":   "Access Restricted - Disko",
+				"message": "This board is not available from your network.",
+			})
+			return
+		}
+
+		// Strict privacy boards only ever see a coarse network prefix
+		visitorKey := utils.VisitorKey(clientIP, board.StrictPrivacy)
+		if !board.StrictPrivacy {
+			log.Printf("[Template] Public Board visitor - PublicLink: %s, IP: %s, UserAgent: %s", publicLink, clientIP, userAgent)
+		}
+
+		// Rate limiting for public board access
+		rateLimitKey := "public_board_" + publicLink + "_" + visitorKey
+		rateLimitSeconds := getRateLimitSeconds("RATE_LIMIT_PUBLIC_BOARD_SECONDS", 30)
+		if limited, _ := utils.RateLimited(rateLimitKey, 1, time.Duration(rateLimitSeconds)*time.Second); limited {
+			log.Printf("[Template] Public Board route - Rate limited: %s, IP: %s, Limit: %ds", publicLink, visitorKey, rateLimitSeconds)
+			c.HTML(http.StatusTooManyRequests, "error.html", gin.H{
+				"title":   "Rate Limited - Disko",
+				"message": fmt.Sprintf("Too many requests. Please try again in %d seconds.", rateLimitSeconds),
+			})
+			return
+		}
+		utils.RecordRateLimitHit(rateLimitKey, time.Duration(rateLimitSeconds)*time.Second)
+		utils.SetPrivacyHeaders(c, board.StrictPrivacy)
+
 		// Get app version
 		version := getAppVersion()
 
@@ -429,21 +512,22 @@ func main() {
 			appURL = "https://disko.nomadis.com"
 		}
 		c.HTML(http.StatusOK, "public.html", gin.H{
-			"title":       "Public Board - Disko",
-			"publicLink":  publicLink,
-			"boardID":     board.ID, // Use the actual board ID from database
-			"version":     version,
-			"siteName":    "Disko, a Service of Nomadis",
-			"description": "Explore a live public product board with ideas, progress and releases.",
-			"canonical":   appURL + "/public/" + publicLink,
-			"appURL":      appURL,
-			"ogImage":     appURL + "/static/images/disko-on-dark.png",
-			"robots":      "index,follow",
+			"title":         "Public Board - Disko",
+			"publicLink":    publicLink,
+			"boardID":       board.ID, // Use the actual board ID from database
+			"version":       version,
+			"siteName":      "Disko, a Service of Nomadis",
+			"description":   "Explore a live public product board with ideas, progress and releases.",
+			"canonical":     appURL + "/public/" + publicLink,
+			"appURL":        appURL,
+			"ogImage":       appURL + "/static/images/disko-on-dark.png",
+			"robots":        "index,follow",
+			"strictPrivacy": board.StrictPrivacy,
 		})
 
 		duration := time.Since(startTime)
 		log.Printf("[Template] Public Board rendered successfully - PublicLink: %s, Duration: %v, IP: %s",
-			publicLink, duration, clientIP)
+			publicLink, duration, visitorKey)
 	})
 
 	// Terms of Service route
@@ -517,6 +601,16 @@ func main() {
 		})
 	})
 
+	// Embeddable widget (CORS-enabled, cached, authorized by embed token)
+	embed := router.Group("/embed")
+	{
+		embed.GET("/widget.js", handlers.ServeEmbedWidget)
+		embed.GET("/v1/:token/released", handlers.GetEmbedFeed)
+		embed.OPTIONS("/v1/:token/released", handlers.EmbedFeedPreflight)
+		embed.GET("/v1/:token/roadmap", handlers.GetEmbedRoadmap)
+		embed.OPTIONS("/v1/:token/roadmap", handlers.EmbedFeedPreflight)
+	}
+
 	// API routes group
 	api := router.Group("/api")
 	{
@@ -530,20 +624,137 @@ func main() {
 		api.GET("/boards/:id/public", handlers.GetPublicBoard)
 		api.GET("/boards/:id/ideas/public", handlers.GetPublicBoardIdeas)
 		api.GET("/boards/:id/release/public", handlers.GetPublicReleasedIdeas)
+		api.GET("/boards/:id/leaderboard/public", handlers.GetPublicBoardLeaderboard)
+		api.GET("/boards/:id/logo/public", handlers.GetPublicBoardLogo)
+		api.GET("/public/:publicLink/changelog", handlers.GetPublicChangelog)
+		api.GET("/public/:publicLink/roadmap.ics", handlers.GetPublicRoadmapCalendar)
+
+		// Public template gallery endpoints
+		api.GET("/templates", handlers.ListTemplates)
+		api.GET("/templates/:id", handlers.GetTemplate)
+
+		// Public catalog of the events board webhooks deliver
+		api.GET("/webhooks/events", handlers.ListWebhookEventTypes)
 
 		// Public feedback endpoints
 		api.POST("/ideas/:id/thumbsup", handlers.AddThumbsUp)
 		api.POST("/ideas/:id/emoji", handlers.AddEmojiReaction)
+		api.DELETE("/ideas/:id/thumbsup", handlers.RemoveThumbsUp)
+		api.DELETE("/ideas/:id/emoji", handlers.RemoveEmojiReaction)
+		api.POST("/ideas/:id/vote", handlers.CastVote)
+		api.DELETE("/ideas/:id/vote", handlers.RemoveVote)
+		api.POST("/ideas/:id/poll/answer", handlers.AnswerPoll)
+		api.GET("/boards/:id/votes/public", handlers.GetVisitorVotes)
+		api.POST("/boards/:id/submissions/public", handlers.SubmitPublicIdea)
+		api.GET("/ideas/:id/comments", handlers.GetPublicComments)
+		api.POST("/ideas/:id/comments", handlers.AddComment)
+
+		// Public release announcement subscriptions (double opt-in)
+		api.POST("/boards/:id/subscribe", handlers.SubscribeToBoard)
+		api.GET("/subscriptions/confirm", handlers.ConfirmSubscription)
+		api.GET("/subscriptions/unsubscribe", handlers.Unsubscribe)
+
+		// Stored files behind signed download links
+		api.GET("/files/*key", middleware.RequestTimeout(10*time.Minute), handlers.DownloadFile)
+
+		// Identity provider webhooks (verified by signature)
+		api.POST("/webhooks/clerk", middleware.RequestTimeout(time.Minute), handlers.HandleClerkWebhook)
+		api.POST("/webhooks/linear/:configId", middleware.RequestTimeout(30*time.Second), handlers.HandleLinearWebhook)
+
+		// WebSocket endpoint for real-time updates (board members or public link holders)
+		api.GET("/ws/boards/:boardId", middleware.RequestTimeout(0), handlers.BoardWebSocket)
+		api.GET("/sse/boards/:boardId", middleware.RequestTimeout(0), handlers.BoardEventStream)
+
+		// WebSocket streaming the signed-in user's notification center
+		api.GET("/ws/notifications", handlers.NotificationWebSocket)
+
+		// Read-only board API (requires a board API token)
+		tokenAPI := api.Group("/v1/boards/:id")
+		tokenAPI.Use(middleware.APITokenMiddleware())
+		{
+			tokenAPI.GET("", handlers.GetAPIBoard)
+			tokenAPI.GET("/stats", handlers.GetAPIBoardStats)
+			tokenAPI.GET("/ideas", handlers.GetAPIBoardIdeas)
+		}
+
+		// Integration endpoints authenticated with a service account, checked against its board scopes
+		serviceAPI := api.Group("/service/boards/:id")
+		serviceAPI.Use(middleware.ServiceAccountMiddleware(), middleware.UserRateLimitMiddleware())
+		{
+			serviceAPI.GET("/ideas", middleware.RequireServicePermission(models.PermissionIdeasRead), handlers.GetAPIBoardIdeas)
+			serviceAPI.POST("/ideas", middleware.RequireServicePermission(models.PermissionIdeasCreate), handlers.ServiceCreateIdea)
+			serviceAPI.POST("/inbound", middleware.RequireServicePermission(models.PermissionFeedbackIngest), handlers.IngestInboundFeedback)
+		}
 
-		// WebSocket endpoint for real-time updates
-		api.GET("/ws/boards/:boardId", utils.HandleWebSocket)
+		// Platform admin endpoints (admins listed in ADMIN_USER_IDS or with the admin role in Clerk metadata)
+		admin := api.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+		{
+			admin.GET("/stats", handlers.AdminGetStats)
+			admin.GET("/realtime", handlers.AdminGetRealtimeMetrics)
+			admin.GET("/jobs", handlers.AdminListJobs)
+			admin.GET("/notification-jobs", handlers.AdminListNotificationJobs)
+			admin.POST("/notification-jobs/:jobId/retry", handlers.AdminRetryNotificationJob)
+			admin.GET("/boards", handlers.AdminListBoards)
+			admin.GET("/users", handlers.AdminListUsers)
+			admin.PUT("/boards/:id", handlers.AdminModerateBoard)
+			admin.DELETE("/comments/:id", handlers.AdminDeleteComment)
+			admin.GET("/audit", handlers.AdminGetAuditLog)
+			admin.POST("/impersonations", handlers.AdminStartImpersonation)
+			admin.GET("/impersonations", handlers.AdminListImpersonations)
+			admin.DELETE("/impersonations/:impersonationId", handlers.AdminEndImpersonation)
+			admin.GET("/backup", middleware.RequestTimeout(0), handlers.AdminDownloadBackup)
+			admin.POST("/restore", middleware.RequestTimeout(0), handlers.AdminRestoreBackup)
+
+			// Synthetic data and load tests for measuring performance; never enable in production
+			if os.Getenv("SEED_DATA_ENABLED") == "true" {
+				admin.POST("/seed", middleware.RequestTimeout(10*time.Minute), handlers.AdminSeedData)
+				admin.DELETE("/seed", middleware.RequestTimeout(5*time.Minute), handlers.AdminDeleteSeedData)
+				admin.POST("/seed/load", handlers.AdminStartSeedLoad)
+				admin.GET("/seed/load", handlers.AdminGetSeedLoad)
+				admin.POST("/fixtures", middleware.RequestTimeout(5*time.Minute), handlers.AdminLoadFixtures)
+				log.Println("[Seed] Synthetic data endpoints enabled")
+			}
+		}
 
 		// Protected endpoints (require authentication)
 		protected := api.Group("/")
-		protected.Use(middleware.AuthMiddleware())
+		protected.Use(middleware.AuthMiddleware(), middleware.UserRateLimitMiddleware())
 		{
+			// Board routes load the :id board once, checking the caller's role on it
+			viewerAccess := middleware.RequireBoardAccess(models.RoleViewer)
+			editorAccess := middleware.RequireBoardAccess(models.RoleEditor)
+			ownerAccess := middleware.RequireBoardAccess(models.RoleOwner)
+
 			// User info endpoint
 			protected.GET("/user", handlers.GetUserInfo)
+			protected.DELETE("/user", middleware.RequireRecentAuth(), handlers.DeleteAccount)
+			protected.POST("/user/sessions/revoke", middleware.RequestTimeout(30*time.Second), middleware.RequireRecentAuth(), handlers.RevokeAllSessions)
+
+			// Personal access tokens for scripts and CI
+			protected.POST("/user/tokens", middleware.RejectImpersonation(), handlers.CreatePersonalAccessToken)
+			protected.GET("/user/tokens", handlers.ListPersonalAccessTokens)
+			protected.DELETE("/user/tokens/:tokenId", handlers.RevokePersonalAccessToken)
+
+			// In-app notification center
+			protected.GET("/notifications", handlers.ListUserNotifications)
+			protected.GET("/notifications/unread-count", handlers.GetUnreadNotificationCount)
+			protected.PUT("/notifications/read-all", handlers.MarkAllNotificationsRead)
+			protected.PUT("/notifications/:notificationId/read", handlers.MarkNotificationRead)
+			protected.DELETE("/notifications/:notificationId", handlers.DeleteUserNotification)
+			protected.DELETE("/notifications", handlers.ClearUserNotifications)
+
+			// Web Push notifications
+			protected.GET("/push/config", handlers.GetPushConfig)
+			protected.POST("/push/subscriptions", handlers.CreatePushSubscription)
+			protected.GET("/push/subscriptions", handlers.ListPushSubscriptions)
+			protected.DELETE("/push/subscriptions/:subscriptionId", handlers.DeletePushSubscription)
+
+			// Service accounts for integrations
+			protected.POST("/service-accounts", middleware.RejectImpersonation(), handlers.CreateServiceAccount)
+			protected.GET("/service-accounts", handlers.ListServiceAccounts)
+			protected.PUT("/service-accounts/:accountId", handlers.UpdateServiceAccount)
+			protected.DELETE("/service-accounts/:accountId", handlers.DeleteServiceAccount)
 
 			// Test protected endpoint
 			protected.GET("/protected", handlers.TestProtected)
@@ -551,21 +762,131 @@ func main() {
 			// Board management endpoints
 			protected.POST("/boards", handlers.CreateBoard)
 			protected.GET("/boards", handlers.GetBoards)
-			protected.GET("/boards/:id", handlers.GetBoard)
+			protected.GET("/boards/:id", viewerAccess, handlers.GetBoard)
 			protected.PUT("/boards/:id", handlers.UpdateBoard)
-			protected.POST("/boards/:id/invite", handlers.SendBoardInvite)
-
-			protected.DELETE("/boards/:id", handlers.DeleteBoard)
+			protected.POST("/boards/:id/invite", ownerAccess, handlers.SendBoardInvite)
+			protected.GET("/boards/:id/activity", viewerAccess, handlers.GetBoardActivity)
+			protected.GET("/boards/:id/presence", viewerAccess, handlers.GetBoardPresence)
+			protected.GET("/boards/:id/notification-preferences", viewerAccess, handlers.GetNotificationPreferences)
+			protected.PUT("/boards/:id/notification-preferences", viewerAccess, handlers.UpdateNotificationPreferences)
+			protected.GET("/boards/:id/audit", ownerAccess, handlers.GetBoardAuditLog)
+			protected.POST("/boards/:id/template", ownerAccess, handlers.PublishBoardTemplate)
+			protected.PUT("/boards/:id/logo", ownerAccess, handlers.UploadBoardLogo)
+			protected.DELETE("/boards/:id/logo", ownerAccess, handlers.DeleteBoardLogo)
+
+			// Embed token endpoints
+			protected.POST("/boards/:id/embed-tokens", ownerAccess, handlers.CreateEmbedToken)
+			protected.GET("/boards/:id/embed-tokens", handlers.ListEmbedTokens)
+			protected.DELETE("/boards/:id/embed-tokens/:tokenId", handlers.DeleteEmbedToken)
+
+			// Board member endpoints
+			protected.GET("/boards/:id/members", viewerAccess, handlers.GetBoardMembers)
+			protected.POST("/boards/:id/members", ownerAccess, handlers.AddBoardMember)
+			protected.PUT("/boards/:id/members/:userId", ownerAccess, handlers.UpdateBoardMember)
+			protected.DELETE("/boards/:id/members/:userId", middleware.RequireMemberAccess(ownerAccess, viewerAccess), handlers.RemoveBoardMember)
+			protected.GET("/boards/:id/ip-rules", ownerAccess, handlers.GetBoardIPRules)
+			protected.PUT("/boards/:id/ip-rules", ownerAccess, handlers.UpdateBoardIPRules)
+			protected.GET("/boards/:id/invitations", ownerAccess, handlers.GetBoardInvitations)
+			protected.DELETE("/boards/:id/invitations/:invitationId", ownerAccess, handlers.RevokeBoardInvitation)
+			protected.POST("/invitations/accept", handlers.AcceptInvitation)
+			protected.POST("/workspaces", handlers.CreateWorkspace)
+			protected.GET("/workspaces/current", handlers.GetCurrentWorkspace)
+			protected.PUT("/workspaces/current", handlers.UpdateCurrentWorkspace)
+			protected.GET("/workspaces/current/boards", handlers.GetWorkspaceBoards)
+
+			// Board API token endpoints
+			protected.POST("/boards/:id/api-tokens", ownerAccess, middleware.RejectImpersonation(), handlers.CreateAPIToken)
+			protected.GET("/boards/:id/api-tokens", handlers.ListAPITokens)
+			protected.DELETE("/boards/:id/api-tokens/:tokenId", handlers.DeleteAPIToken)
+
+			// Analytics export endpoints
+			protected.GET("/boards/:id/export-config", ownerAccess, handlers.GetExportConfig)
+			protected.PUT("/boards/:id/export-config", ownerAccess, handlers.UpsertExportConfig)
+			protected.DELETE("/boards/:id/export-config", ownerAccess, handlers.DeleteExportConfig)
+			protected.POST("/boards/:id/export-config/run", middleware.RequestTimeout(2*time.Minute), ownerAccess, handlers.RunExportNow)
+			protected.GET("/boards/:id/export-config/downloads", ownerAccess, handlers.GetExportDownloads)
+
+			// Board webhook endpoints
+			protected.POST("/boards/:id/webhooks", ownerAccess, middleware.RejectImpersonation(), handlers.CreateBoardWebhook)
+			protected.GET("/boards/:id/webhooks", ownerAccess, handlers.ListBoardWebhooks)
+			protected.PUT("/boards/:id/webhooks/:webhookId", ownerAccess, middleware.RejectImpersonation(), handlers.UpdateBoardWebhook)
+			protected.DELETE("/boards/:id/webhooks/:webhookId", ownerAccess, handlers.DeleteBoardWebhook)
+			protected.GET("/boards/:id/webhooks/:webhookId/deliveries", ownerAccess, handlers.GetWebhookDeliveries)
+
+			// Board Telegram chat
+			protected.GET("/boards/:id/telegram", ownerAccess, handlers.GetTelegramConfig)
+			protected.PUT("/boards/:id/telegram", ownerAccess, middleware.RejectImpersonation(), handlers.UpsertTelegramConfig)
+			protected.DELETE("/boards/:id/telegram", ownerAccess, handlers.DeleteTelegramConfig)
+			protected.POST("/boards/:id/telegram/test", middleware.RequestTimeout(15*time.Second), ownerAccess, handlers.TestTelegramConfig)
+
+			// Board Jira integration
+			protected.GET("/boards/:id/jira", ownerAccess, handlers.GetJiraConfig)
+			protected.PUT("/boards/:id/jira", middleware.RequestTimeout(20*time.Second), ownerAccess, middleware.RejectImpersonation(), handlers.UpsertJiraConfig)
+			protected.DELETE("/boards/:id/jira", ownerAccess, handlers.DeleteJiraConfig)
+
+			// Board Linear integration
+			protected.GET("/boards/:id/linear", ownerAccess, handlers.GetLinearConfig)
+			protected.PUT("/boards/:id/linear", middleware.RequestTimeout(20*time.Second), ownerAccess, middleware.RejectImpersonation(), handlers.UpsertLinearConfig)
+			protected.DELETE("/boards/:id/linear", ownerAccess, handlers.DeleteLinearConfig)
+
+			// Board alert rule endpoints
+			protected.GET("/boards/:id/alerts", ownerAccess, handlers.ListAlertRules)
+			protected.POST("/boards/:id/alerts", ownerAccess, handlers.CreateAlertRule)
+			protected.PUT("/boards/:id/alerts/:alertId", ownerAccess, handlers.UpdateAlertRule)
+			protected.DELETE("/boards/:id/alerts/:alertId", ownerAccess, handlers.DeleteAlertRule)
+
+			// Template gallery endpoints
+			protected.POST("/templates/:id/install", middleware.RequestTimeout(15*time.Second), handlers.InstallTemplate)
+			protected.DELETE("/templates/:id", handlers.UnpublishTemplate)
+
+			protected.DELETE("/boards/:id", middleware.RequestTimeout(30*time.Second), middleware.RequireRecentAuth(), handlers.DeleteBoard)
 
 			// Idea management endpoints
-			protected.POST("/boards/:id/ideas", handlers.CreateIdea)
-			protected.GET("/boards/:id/ideas", handlers.GetBoardIdeas)
-			protected.GET("/boards/:id/search", handlers.SearchBoardIdeas)
+			protected.POST("/boards/:id/ideas", editorAccess, handlers.CreateIdea)
+			protected.GET("/boards/:id/ideas", viewerAccess, handlers.GetBoardIdeas)
+			protected.PUT("/boards/:id/ideas/reorder", editorAccess, handlers.ReorderIdeas)
+			protected.GET("/boards/:id/search", viewerAccess, handlers.SearchBoardIdeas)
 			protected.GET("/boards/:id/release", handlers.GetReleasedIdeas)
+			protected.GET("/boards/:id/leaderboard", viewerAccess, handlers.GetBoardLeaderboard)
 			protected.PUT("/ideas/:id", handlers.UpdateIdea)
 			protected.DELETE("/ideas/:id", handlers.DeleteIdea)
 			protected.PUT("/ideas/:id/position", handlers.UpdateIdeaPosition)
 			protected.PUT("/ideas/:id/status", handlers.UpdateIdeaStatus)
+			protected.PUT("/ideas/:id/poll", handlers.SetIdeaPoll)
+			protected.DELETE("/ideas/:id/poll", handlers.DeleteIdeaPoll)
+			protected.POST("/ideas/:id/jira", middleware.RequestTimeout(20*time.Second), handlers.CreateIdeaJiraIssue)
+			protected.DELETE("/ideas/:id/jira", handlers.UnlinkIdeaJiraIssue)
+			protected.POST("/ideas/:id/linear", middleware.RequestTimeout(20*time.Second), handlers.CreateIdeaLinearIssue)
+			protected.DELETE("/ideas/:id/linear", handlers.UnlinkIdeaLinearIssue)
+			protected.GET("/ideas/:id/attachments", handlers.ListIdeaAttachments)
+			protected.POST("/ideas/:id/attachments", middleware.RequestTimeout(2*time.Minute), handlers.UploadIdeaAttachment)
+			protected.DELETE("/ideas/:id/attachments/:attachmentId", handlers.DeleteIdeaAttachment)
+			protected.GET("/ideas/:id/score-history", handlers.GetIdeaScoreHistory)
+
+			// Release/milestone endpoints
+			protected.POST("/boards/:id/releases", editorAccess, handlers.CreateRelease)
+			protected.GET("/boards/:id/releases", viewerAccess, handlers.ListReleases)
+			protected.PUT("/releases/:id", handlers.UpdateRelease)
+			protected.DELETE("/releases/:id", handlers.DeleteRelease)
+
+			// Idea suggestion moderation endpoints
+			protected.GET("/boards/:id/submissions", viewerAccess, handlers.GetSubmissions)
+			protected.PUT("/submissions/:id", handlers.UpdateSubmission)
+			protected.POST("/submissions/:id/approve", handlers.ApproveSubmission)
+			protected.POST("/submissions/:id/reject", handlers.RejectSubmission)
+
+			// Visitor feedback notes and attribution
+			protected.GET("/boards/:id/feedback-notes", viewerAccess, handlers.GetFeedbackNotes)
+			protected.GET("/boards/:id/feedback-sources", viewerAccess, handlers.GetFeedbackSources)
+
+			// Comment moderation endpoints
+			protected.GET("/boards/:id/comments", viewerAccess, handlers.GetBoardComments)
+			protected.POST("/comments/:id/approve", handlers.ApproveComment)
+			protected.DELETE("/comments/:id", handlers.DeleteComment)
+
+			// Release announcement subscribers
+			protected.GET("/boards/:id/subscribers", editorAccess, handlers.GetSubscribers)
+			protected.DELETE("/subscribers/:id", handlers.DeleteSubscriber)
 		}
 	}
 
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2/user"
+	"github.com/gin-gonic/gin"
+)
+
+// adminRoleCacheTTL is how long a Clerk metadata admin lookup is reused
+const adminRoleCacheTTL = 5 * time.Minute
+
+// adminRoleCache remembers Clerk metadata admin lookups so admin requests don't each call Clerk
+var (
+	adminRoleCache   = make(map[string]adminRoleEntry)
+	adminRoleCacheMu sync.Mutex
+)
+
+type adminRoleEntry struct {
+	isAdmin   bool
+	expiresAt time.Time
+}
+
+// IsAdmin reports whether a user is a platform admin: listed in ADMIN_USER_IDS (comma-separated
+// user IDs) or, with the Clerk provider, carrying "role": "admin" in their Clerk public metadata
+func IsAdmin(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	for _, id := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if strings.TrimSpace(id) == userID {
+			return true
+		}
+	}
+
+	// Only Clerk carries the admin role in user metadata; other providers rely on the allowlist
+	if AuthProvider() != "clerk" {
+		return false
+	}
+
+	now := time.Now()
+	adminRoleCacheMu.Lock()
+	entry, found := adminRoleCache[userID]
+	adminRoleCacheMu.Unlock()
+	if found && now.Before(entry.expiresAt) {
+		return entry.isAdmin
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	clerkUser, err := user.Get(ctx, userID)
+	if err != nil {
+		// Don't cache failures so a Clerk outage doesn't lock admins out for the whole TTL
+		log.Printf("[Auth] IsAdmin - Clerk user lookup failed: %v, UserID: %s", err, userID)
+		return false
+	}
+
+	var metadata struct {
+		Role string `json:"role"`
+	}
+	isAdmin := len(clerkUser.PublicMetadata) > 0 &&
+		json.Unmarshal(clerkUser.PublicMetadata, &metadata) == nil &&
+		metadata.Role == "admin"
+
+	adminRoleCacheMu.Lock()
+	adminRoleCache[userID] = adminRoleEntry{isAdmin: isAdmin, expiresAt: now.Add(adminRoleCacheTTL)}
+	adminRoleCacheMu.Unlock()
+	return isAdmin
+}
+
+// AdminMiddleware restricts platform admin routes to admins signed in with a Clerk session.
+// It must run after AuthMiddleware; personal access tokens are not accepted.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := GetUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "Authentication required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		if rejectImpersonated(c) {
+			return
+		}
+
+		if IsPersonalAccessTokenAuth(c) || !IsAdmin(userID) {
+			log.Printf("[Auth] AdminMiddleware failed - Not an admin session, UserID: %s, Path: %s, IP: %s", userID, c.Request.URL.Path, c.ClientIP())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "ADMIN_REQUIRED",
+					"message": "This endpoint is restricted to platform admins",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		log.Printf("[Auth] AdminMiddleware success - UserID: %s, Path: %s, IP: %s", userID, c.Request.URL.Path, c.ClientIP())
+
+		c.Next()
+	}
+}
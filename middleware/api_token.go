@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// APITokenMiddleware authenticates read-only board API requests with a board API token
+// ("Authorization: Bearer dk_..."). The token must belong to the board in the :id route parameter.
+func APITokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log.Printf("[Auth] APITokenMiddleware called - Path: %s, Method: %s, IP: %s", c.Request.URL.Path, c.Request.Method, c.ClientIP())
+
+		// Tokens only ever grant read access
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "READ_ONLY_TOKEN",
+					"message": "API tokens only grant read access",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		secret, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || secret == "" {
+			log.Printf("[Auth] APITokenMiddleware failed - No bearer token, IP: %s", c.ClientIP())
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "A board API token is required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		var token models.APIToken
+		collection := models.GetCollection(ctx, models.APITokensCollection)
+		err := collection.FindOne(ctx, bson.M{"token_hash": models.HashAPIToken(secret)}).Decode(&token)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				log.Printf("[Auth] APITokenMiddleware failed - Unknown or revoked token, IP: %s", c.ClientIP())
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": gin.H{
+						"code":    "INVALID_TOKEN",
+						"message": "Invalid or revoked API token",
+					},
+				})
+				c.Abort()
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to verify API token",
+					"details": err.Error(),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		if boardID := c.Param("id"); boardID != token.BoardID {
+			log.Printf("[Auth] APITokenMiddleware failed - Token scoped to another board, TokenID: %s, BoardID: %s, IP: %s", token.ID, boardID, c.ClientIP())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "TOKEN_SCOPE_MISMATCH",
+					"message": "This API token does not grant access to this board",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		now := time.Now().UTC()
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": token.ID}, bson.M{"$set": bson.M{"last_used_at": now}}); err != nil {
+			log.Printf("[Auth] APITokenMiddleware - Failed to record token use: %v, TokenID: %s", err, token.ID)
+		}
+
+		c.Set("apiToken", &token)
+
+		log.Printf("[Auth] APITokenMiddleware success - TokenID: %s, BoardID: %s, IP: %s", token.ID, token.BoardID, c.ClientIP())
+
+		c.Next()
+	}
+}
+
+// GetAPIToken extracts the board API token authenticated by APITokenMiddleware
+func GetAPIToken(c *gin.Context) (*models.APIToken, error) {
+	value, exists := c.Get("apiToken")
+	if !exists {
+		return nil, fmt.Errorf("API token not found in context")
+	}
+
+	token, ok := value.(*models.APIToken)
+	if !ok {
+		return nil, fmt.Errorf("API token has an unexpected type")
+	}
+	return token, nil
+}
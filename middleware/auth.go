@@ -1,19 +1,19 @@
 package middleware
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"strings"
 
+	"disko-backend/models"
+
 	"github.com/clerk/clerk-sdk-go/v2"
-	"github.com/clerk/clerk-sdk-go/v2/jwt"
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates Clerk JWT tokens
+// AuthMiddleware validates session tokens of the configured identity provider (Clerk or OIDC), or personal access tokens ("Bearer dkp_...") for scripts and CI
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the authorization header
@@ -49,12 +49,62 @@ func AuthMiddleware() gin.HandlerFunc {
 		token := tokenParts[1]
 		log.Printf("[Auth] AuthMiddleware - Token received, length: %d, IP: %s", len(token), c.ClientIP())
 
-		// Verify the JWT token with Clerk
-		claims, err := jwt.Verify(context.Background(), &jwt.VerifyParams{
-			Token: token,
-		})
+		// Personal access tokens act as the user who created them
+		if strings.HasPrefix(token, models.PersonalAccessTokenPrefix) {
+			if c.GetHeader(models.ImpersonationHeader) != "" {
+				log.Printf("[Auth] AuthMiddleware failed - Impersonation with a personal access token, IP: %s", c.ClientIP())
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": gin.H{
+						"code":    "SESSION_REQUIRED",
+						"message": "Impersonation requires a signed-in admin session",
+					},
+				})
+				c.Abort()
+				return
+			}
+
+			pat, err := verifyPersonalAccessToken(token)
+			if err != nil {
+				log.Printf("[Auth] AuthMiddleware failed - Personal access token error: %v, IP: %s", err, c.ClientIP())
+				if err == errInvalidPersonalAccessToken {
+					c.JSON(http.StatusUnauthorized, gin.H{
+						"error": gin.H{
+							"code":    "INVALID_TOKEN",
+							"message": "Invalid, revoked or expired personal access token",
+						},
+					})
+				} else {
+					c.JSON(http.StatusInternalServerError, gin.H{
+						"error": gin.H{
+							"code":    "DATABASE_ERROR",
+							"message": "Failed to verify personal access token",
+							"details": err.Error(),
+						},
+					})
+				}
+				c.Abort()
+				return
+			}
+
+			c.Set("userID", pat.UserID)
+			c.Set("personalAccessToken", pat)
+
+			// Tokens work in the data of the workspace they were created in
+			if !applyTokenTenant(c, pat) {
+				c.Abort()
+				return
+			}
+
+			log.Printf("[Auth] AuthMiddleware success - UserID: %s, TokenID: %s, IP: %s", pat.UserID, pat.ID, c.ClientIP())
+
+			c.Next()
+			return
+		}
+
+		// Verify the session token with the configured identity provider
+		identity, err := tokenVerifier.Verify(c.Request.Context(), token)
 		if err != nil {
-			log.Printf("[Auth] AuthMiddleware failed - Token verification error: %v, IP: %s", err, c.ClientIP())
+			log.Printf("[Auth] AuthMiddleware failed - Token verification error: %v, Provider: %s, IP: %s", err, tokenVerifier.Name(), c.ClientIP())
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": gin.H{
 					"code":    "INVALID_TOKEN",
@@ -67,17 +117,28 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// Store user information in context
-		c.Set("userID", claims.Subject)
-		c.Set("sessionID", claims.SessionID)
-		c.Set("claims", claims)
+		setIdentity(c, identity)
+		go syncUserProfile(identity)
+
+		// Admins may act as a user under an active impersonation session
+		if !applyImpersonation(c, identity) {
+			c.Abort()
+			return
+		}
 
-		log.Printf("[Auth] AuthMiddleware success - UserID: %s, SessionID: %s, IP: %s", claims.Subject, claims.SessionID, c.ClientIP())
+		// Members of a workspace with isolated data work in its collections
+		if !applySessionTenant(c) {
+			c.Abort()
+			return
+		}
+
+		log.Printf("[Auth] AuthMiddleware success - UserID: %s, SessionID: %s, IP: %s", identity.UserID, identity.SessionID, c.ClientIP())
 
 		c.Next()
 	}
 }
 
-// OptionalAuthMiddleware validates Clerk JWT tokens but doesn't require them
+// OptionalAuthMiddleware validates session tokens but doesn't require them
 func OptionalAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -100,10 +161,34 @@ func OptionalAuthMiddleware() gin.HandlerFunc {
 
 		token := tokenParts[1]
 
-		// Try to verify the JWT token
-		claims, err := jwt.Verify(context.Background(), &jwt.VerifyParams{
-			Token: token,
-		})
+		if strings.HasPrefix(token, models.PersonalAccessTokenPrefix) {
+			pat, err := verifyPersonalAccessToken(token)
+			if err != nil {
+				log.Printf("[Auth] OptionalAuthMiddleware - Personal access token rejected: %v, continuing without auth, IP: %s", err, c.ClientIP())
+				c.Next()
+				return
+			}
+
+			// Tokens of another workspace than the host's are treated as anonymous, like sessions
+			if _, allowed := tokenTenant(c, pat); !allowed {
+				log.Printf("[Auth] OptionalAuthMiddleware - Token of another workspace, continuing without auth, IP: %s", c.ClientIP())
+				c.Next()
+				return
+			}
+
+			c.Set("userID", pat.UserID)
+			c.Set("personalAccessToken", pat)
+			if !applyTokenTenant(c, pat) {
+				c.Abort()
+				return
+			}
+			log.Printf("[Auth] OptionalAuthMiddleware success - UserID: %s, TokenID: %s, IP: %s", pat.UserID, pat.ID, c.ClientIP())
+			c.Next()
+			return
+		}
+
+		// Try to verify the session token
+		identity, err := tokenVerifier.Verify(c.Request.Context(), token)
 		if err != nil {
 			log.Printf("[Auth] OptionalAuthMiddleware - Token verification failed: %v, continuing without auth, IP: %s", err, c.ClientIP())
 			// Invalid token, continue without setting user context
@@ -111,17 +196,53 @@ func OptionalAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Sessions of another workspace than the host's are treated as anonymous
+		if _, allowed := sessionTenant(c, identity); !allowed {
+			log.Printf("[Auth] OptionalAuthMiddleware - Session of another workspace, continuing without auth, IP: %s", c.ClientIP())
+			c.Next()
+			return
+		}
+
 		// Store user information in context if valid
-		c.Set("userID", claims.Subject)
-		c.Set("sessionID", claims.SessionID)
-		c.Set("claims", claims)
+		setIdentity(c, identity)
+		if !applySessionTenant(c) {
+			c.Abort()
+			return
+		}
 
-		log.Printf("[Auth] OptionalAuthMiddleware success - UserID: %s, SessionID: %s, IP: %s", claims.Subject, claims.SessionID, c.ClientIP())
+		log.Printf("[Auth] OptionalAuthMiddleware success - UserID: %s, SessionID: %s, IP: %s", identity.UserID, identity.SessionID, c.ClientIP())
 
 		c.Next()
 	}
 }
 
+// AuthenticateSessionToken verifies a session token received outside the Authorization header, such
+// as on a WebSocket handshake, and stores the identity in the context like AuthMiddleware does.
+// Personal access tokens are not accepted.
+func AuthenticateSessionToken(c *gin.Context, token string) error {
+	if strings.HasPrefix(token, models.PersonalAccessTokenPrefix) {
+		return fmt.Errorf("personal access tokens are not accepted here")
+	}
+
+	identity, err := tokenVerifier.Verify(c.Request.Context(), token)
+	if err != nil {
+		log.Printf("[Auth] AuthenticateSessionToken failed - Token verification error: %v, Provider: %s, IP: %s", err, tokenVerifier.Name(), c.ClientIP())
+		return err
+	}
+	tenantID, allowed := sessionTenant(c, identity)
+	if !allowed {
+		log.Printf("[Auth] AuthenticateSessionToken failed - Session of another workspace, UserID: %s, IP: %s", identity.UserID, c.ClientIP())
+		return fmt.Errorf("this session belongs to another workspace")
+	}
+	if err := enterTenant(c, tenantID); err != nil {
+		return err
+	}
+
+	setIdentity(c, identity)
+	log.Printf("[Auth] AuthenticateSessionToken success - UserID: %s, SessionID: %s, IP: %s", identity.UserID, identity.SessionID, c.ClientIP())
+	return nil
+}
+
 // GetUserID extracts the user ID from the Gin context
 func GetUserID(c *gin.Context) (string, error) {
 	userID, exists := c.Get("userID")
@@ -178,3 +299,12 @@ func RequireAuth(c *gin.Context) bool {
 	log.Printf("[Auth] RequireAuth check - IsAuthenticated: %t, IP: %s", isAuthenticated, c.ClientIP())
 	return isAuthenticated
 }
+
+// GetOrganization returns the active organization ID and role of the session, or empty strings without one
+func GetOrganization(c *gin.Context) (string, string) {
+	identity, err := GetIdentity(c)
+	if err != nil {
+		return "", ""
+	}
+	return identity.OrgID, identity.OrgRole
+}
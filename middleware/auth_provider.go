@@ -0,0 +1,298 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+	"github.com/clerk/clerk-sdk-go/v2/organizationmembership"
+	"github.com/clerk/clerk-sdk-go/v2/session"
+	"github.com/clerk/clerk-sdk-go/v2/user"
+	"github.com/gin-gonic/gin"
+)
+
+// Identity is the principal a TokenVerifier extracts from a valid bearer token
+type Identity struct {
+	UserID    string
+	SessionID string
+	OrgID     string    // Active organization, mapped to a workspace; empty without one
+	OrgRole   string    // Role in the active organization ("org:admin", "org:member", ...)
+	AuthTime  time.Time // When the user last verified their credentials; zero if the provider doesn't say
+	Claims    interface{}
+}
+
+// TokenVerifier verifies the bearer tokens of the configured identity provider
+type TokenVerifier interface {
+	// Name identifies the provider ("clerk", "oidc") in logs and provider-specific features
+	Name() string
+	Verify(ctx context.Context, token string) (*Identity, error)
+}
+
+// tokenVerifier is the provider AuthMiddleware and OptionalAuthMiddleware verify tokens with
+var tokenVerifier TokenVerifier = clerkVerifier{}
+
+// SetTokenVerifier replaces the identity provider, e.g. in tests
+func SetTokenVerifier(verifier TokenVerifier) {
+	tokenVerifier = verifier
+}
+
+// AuthProvider returns the name of the configured identity provider
+func AuthProvider() string {
+	return tokenVerifier.Name()
+}
+
+// InitializeAuth sets up the identity provider selected by AUTH_PROVIDER: "clerk" (default) or
+// "oidc" for any OpenID Connect issuer publishing a JWKS (Auth0, Keycloak, self-hosted, ...)
+func InitializeAuth() error {
+	provider := strings.ToLower(strings.TrimSpace(os.Getenv("AUTH_PROVIDER")))
+	switch provider {
+	case "", "clerk":
+		if err := InitializeClerk(); err != nil {
+			return err
+		}
+		SetTokenVerifier(clerkVerifier{})
+	case "oidc":
+		verifier, err := NewOIDCVerifier(OIDCConfig{
+			Issuer:       os.Getenv("OIDC_ISSUER"),
+			Audience:     os.Getenv("OIDC_AUDIENCE"),
+			JWKSURL:      os.Getenv("OIDC_JWKS_URL"),
+			OrgClaim:     os.Getenv("OIDC_ORG_CLAIM"),
+			OrgRoleClaim: os.Getenv("OIDC_ORG_ROLE_CLAIM"),
+		})
+		if err != nil {
+			log.Printf("[Auth] InitializeAuth failed - OIDC provider error: %v", err)
+			return err
+		}
+		SetTokenVerifier(verifier)
+	default:
+		return fmt.Errorf("unknown AUTH_PROVIDER %q; supported providers are clerk and oidc", provider)
+	}
+
+	log.Printf("[Auth] InitializeAuth success - Provider: %s", tokenVerifier.Name())
+	return nil
+}
+
+// setIdentity stores the authenticated principal in the Gin context
+func setIdentity(c *gin.Context, identity *Identity) {
+	c.Set("userID", identity.UserID)
+	c.Set("sessionID", identity.SessionID)
+	c.Set("claims", identity.Claims)
+	c.Set("identity", identity)
+}
+
+// clerkVerifier verifies Clerk session tokens
+type clerkVerifier struct{}
+
+func (clerkVerifier) Name() string {
+	return "clerk"
+}
+
+func (clerkVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	claims, err := jwt.Verify(ctx, &jwt.VerifyParams{
+		Token: token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		UserID:    claims.Subject,
+		SessionID: claims.SessionID,
+		OrgID:     claims.ActiveOrganizationID,
+		OrgRole:   claims.ActiveOrganizationRole,
+		Claims:    claims,
+	}
+
+	// Version 2 session tokens carry the minutes since the first factor was verified ("fva"), -1 if never
+	if claims.Version >= 2 && claims.FactorVerificationAge[0] >= 0 {
+		issuedAt := time.Now()
+		if claims.IssuedAt != nil {
+			issuedAt = time.Unix(*claims.IssuedAt, 0)
+		}
+		identity.AuthTime = issuedAt.Add(-time.Duration(claims.FactorVerificationAge[0]) * time.Minute)
+	}
+	return identity, nil
+}
+
+// SessionRevoker is implemented by identity providers that can end a user's sessions server-side
+type SessionRevoker interface {
+	RevokeSessions(ctx context.Context, userID string) (int, error)
+}
+
+// RevokeUserSessions ends every active session of a user with the configured provider. It reports
+// false when the provider cannot revoke sessions (OIDC tokens stay valid until they expire).
+func RevokeUserSessions(ctx context.Context, userID string) (int, bool, error) {
+	revoker, ok := tokenVerifier.(SessionRevoker)
+	if !ok {
+		return 0, false, nil
+	}
+	revoked, err := revoker.RevokeSessions(ctx, userID)
+	return revoked, true, err
+}
+
+// clerkSessionPageSize is how many active sessions are listed per Clerk API call while revoking
+const clerkSessionPageSize = 100
+
+// RevokeSessions revokes all active Clerk sessions of the user
+func (clerkVerifier) RevokeSessions(ctx context.Context, userID string) (int, error) {
+	revoked := make(map[string]bool)
+	for {
+		// Revoked sessions drop out of the active list, so always read the first page
+		list, err := session.List(ctx, &session.ListParams{
+			ListParams: clerk.ListParams{Limit: clerk.Int64(clerkSessionPageSize)},
+			UserID:     clerk.String(userID),
+			Status:     clerk.String("active"),
+		})
+		if err != nil {
+			return len(revoked), err
+		}
+
+		progressed := false
+		for _, active := range list.Sessions {
+			if revoked[active.ID] {
+				continue
+			}
+			if _, err := session.Revoke(ctx, &session.RevokeParams{ID: active.ID}); err != nil {
+				return len(revoked), err
+			}
+			revoked[active.ID] = true
+			progressed = true
+		}
+		// Stop once a page has nothing left to revoke, even if the listing lags behind
+		if !progressed {
+			return len(revoked), nil
+		}
+	}
+}
+
+// SessionChecker is implemented by identity providers that can tell whether a session is still active
+type SessionChecker interface {
+	SessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
+// SessionActive reports whether a session is still active with the configured provider, so long-lived
+// connections can end once it is revoked. Providers that cannot tell report every session as active.
+func SessionActive(ctx context.Context, sessionID string) (bool, error) {
+	checker, ok := tokenVerifier.(SessionChecker)
+	if !ok || sessionID == "" {
+		return true, nil
+	}
+	return checker.SessionActive(ctx, sessionID)
+}
+
+// SessionActive looks the session up in Clerk; revoked, ended and expired sessions are not active
+func (clerkVerifier) SessionActive(ctx context.Context, sessionID string) (bool, error) {
+	current, err := session.Get(ctx, sessionID)
+	var apiErr *clerk.APIErrorResponse
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return current.Status == "active", nil
+}
+
+// WorkspaceAdminLister is implemented by identity providers that can list an organization's admins
+type WorkspaceAdminLister interface {
+	WorkspaceAdmins(ctx context.Context, orgID string) ([]string, error)
+}
+
+// WorkspaceAdmins returns the user IDs of a workspace's admins, longest-standing first. Providers that
+// cannot list organization members return none.
+func WorkspaceAdmins(ctx context.Context, orgID string) ([]string, error) {
+	lister, ok := tokenVerifier.(WorkspaceAdminLister)
+	if !ok || orgID == "" {
+		return nil, nil
+	}
+	return lister.WorkspaceAdmins(ctx, orgID)
+}
+
+// WorkspaceAdmins lists the admins of the Clerk organization backing a workspace
+func (clerkVerifier) WorkspaceAdmins(ctx context.Context, orgID string) ([]string, error) {
+	memberships, err := organizationmembership.List(ctx, &organizationmembership.ListParams{
+		ListParams:     clerk.ListParams{Limit: clerk.Int64(100)},
+		OrderBy:        clerk.String("+created_at"),
+		Roles:          []string{"org:admin"},
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	admins := make([]string, 0, len(memberships.OrganizationMemberships))
+	for _, membership := range memberships.OrganizationMemberships {
+		if membership.PublicUserData != nil && membership.PublicUserData.UserID != "" {
+			admins = append(admins, membership.PublicUserData.UserID)
+		}
+	}
+	return admins, nil
+}
+
+// ProfileProvider is implemented by identity providers that can describe a user for the cached profile
+type ProfileProvider interface {
+	Profile(ctx context.Context, identity *Identity) (*models.User, error)
+}
+
+// Profile loads the user's name, primary email and avatar from Clerk
+func (clerkVerifier) Profile(ctx context.Context, identity *Identity) (*models.User, error) {
+	clerkUser, err := user.Get(ctx, identity.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return ClerkUserProfile(clerkUser), nil
+}
+
+// ClerkUserProfile builds the cached profile of a Clerk user from their name, primary email and avatar
+func ClerkUserProfile(clerkUser *clerk.User) *models.User {
+	profile := &models.User{ID: clerkUser.ID}
+	var names []string
+	for _, name := range []*string{clerkUser.FirstName, clerkUser.LastName} {
+		if name != nil && strings.TrimSpace(*name) != "" {
+			names = append(names, strings.TrimSpace(*name))
+		}
+	}
+	profile.DisplayName = strings.Join(names, " ")
+	if profile.DisplayName == "" && clerkUser.Username != nil {
+		profile.DisplayName = *clerkUser.Username
+	}
+	for _, address := range clerkUser.EmailAddresses {
+		if clerkUser.PrimaryEmailAddressID != nil && address.ID == *clerkUser.PrimaryEmailAddressID {
+			profile.Email = address.EmailAddress
+		}
+	}
+	if clerkUser.ImageURL != nil {
+		profile.AvatarURL = *clerkUser.ImageURL
+	}
+	return profile
+}
+
+// UserDeleter is implemented by identity providers that can delete a user's account
+type UserDeleter interface {
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+// DeleteIdentity deletes the user from the configured identity provider. It reports false when
+// the provider cannot delete users, leaving the account to be removed at the provider.
+func DeleteIdentity(ctx context.Context, userID string) (bool, error) {
+	deleter, ok := tokenVerifier.(UserDeleter)
+	if !ok {
+		return false, nil
+	}
+	return true, deleter.DeleteUser(ctx, userID)
+}
+
+// DeleteUser deletes the Clerk user, ending all of their sessions
+func (clerkVerifier) DeleteUser(ctx context.Context, userID string) error {
+	_, err := user.Delete(ctx, userID)
+	return err
+}
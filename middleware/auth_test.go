@@ -96,6 +96,7 @@ func TestGetUserID(t *testing.T) {
 
 	t.Run("User ID Exists", func(t *testing.T) {
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Set("userID", "test_user_123")
 
 		userID, err := GetUserID(c)
@@ -106,6 +107,7 @@ func TestGetUserID(t *testing.T) {
 
 	t.Run("User ID Missing", func(t *testing.T) {
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
 		userID, err := GetUserID(c)
 
@@ -116,6 +118,7 @@ func TestGetUserID(t *testing.T) {
 
 	t.Run("User ID Wrong Type", func(t *testing.T) {
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Set("userID", 123) // Set as int instead of string
 
 		userID, err := GetUserID(c)
@@ -131,6 +134,7 @@ func TestRequireAuth(t *testing.T) {
 
 	t.Run("Authenticated User", func(t *testing.T) {
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 		c.Set("userID", "test_user_123")
 
 		result := RequireAuth(c)
@@ -140,6 +144,7 @@ func TestRequireAuth(t *testing.T) {
 
 	t.Run("Unauthenticated User", func(t *testing.T) {
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
 		result := RequireAuth(c)
 
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// BoardAccessFilter is models.BoardAccessFilter extended with the access the caller's active
+// Clerk organization grants on its workspace boards
+func BoardAccessFilter(c *gin.Context, boardID, userID string, required models.MemberRole) bson.M {
+	orgID, orgRole := GetOrganization(c)
+	return models.WithWorkspaceAccess(models.BoardAccessFilter(boardID, userID, required), orgID, models.WorkspaceRoleFromClerk(orgRole), required)
+}
+
+// BoardRole returns the caller's role on a board, including access through the workspace of their
+// active Clerk organization, or "" when they have none
+func BoardRole(c *gin.Context, board *models.Board, userID string) models.MemberRole {
+	orgID, orgRole := GetOrganization(c)
+	return OrganizationBoardRole(board, userID, orgID, orgRole)
+}
+
+// OrganizationBoardRole is BoardRole for a caller whose active organization was resolved earlier, such
+// as when a WebSocket connected
+func OrganizationBoardRole(board *models.Board, userID, orgID, orgRole string) models.MemberRole {
+	role := board.RoleOf(userID)
+	if board.WorkspaceID != "" && board.WorkspaceID == orgID {
+		if workspaceRole := models.WorkspaceRoleFromClerk(orgRole).BoardRole(); workspaceRole.Allows(role) {
+			role = workspaceRole
+		}
+	}
+	return role
+}
+
+// FindAccessibleBoard loads a board through the board cache and checks the caller has at least the
+// required role on it, matching BoardAccessFilter. Returns mongo.ErrNoDocuments when the board does
+// not exist or the caller lacks access.
+func FindAccessibleBoard(ctx context.Context, c *gin.Context, boardID, userID string, required models.MemberRole) (*models.Board, error) {
+	board, err := models.FindBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if !BoardRole(c, board, userID).Allows(required) {
+		return nil, mongo.ErrNoDocuments
+	}
+	return board, nil
+}
+
+// RequireBoardAccess loads the board in the :id route parameter once (see FindAccessibleBoard), checks that the authenticated
+// user has at least the required role on it, and stores it in the context for the handler (see GetBoard).
+// It must run after AuthMiddleware.
+func RequireBoardAccess(required models.MemberRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := GetUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "Authentication required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		boardID := c.Param("id")
+		if boardID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_BOARD_ID",
+					"message": "Board ID is required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		board, err := FindAccessibleBoard(ctx, c, boardID, userID, required)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				log.Printf("[Auth] RequireBoardAccess failed - BoardID: %s, Required: %s, UserID: %s, IP: %s", boardID, required, userID, c.ClientIP())
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": gin.H{
+						"code":    "BOARD_NOT_FOUND",
+						"message": "Board not found or you don't have permission to access it",
+					},
+				})
+				c.Abort()
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to fetch board",
+					"details": err.Error(),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("board", board)
+
+		c.Next()
+	}
+}
+
+// RequireMemberAccess guards routes on one board member in the :userId route parameter: callers
+// acting on themselves pass through self, such as members leaving a board, and on anyone else
+// through others. It must run after AuthMiddleware.
+func RequireMemberAccess(others, self gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID, err := GetUserID(c); err == nil && c.Param("userId") == userID {
+			self(c)
+			return
+		}
+		others(c)
+	}
+}
+
+// GetBoard extracts the board loaded by RequireBoardAccess
+func GetBoard(c *gin.Context) (*models.Board, error) {
+	value, exists := c.Get("board")
+	if !exists {
+		return nil, fmt.Errorf("board not found in context")
+	}
+
+	board, ok := value.(*models.Board)
+	if !ok {
+		return nil, fmt.Errorf("board has an unexpected type")
+	}
+	return board, nil
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireMemberAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// Stand-ins for the board access checks record which one ran
+	access := func(name string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Header("X-Access", name)
+			c.Next()
+		}
+	}
+	router.DELETE("/boards/:id/members/:userId", func(c *gin.Context) {
+		c.Set("userID", "user_caller")
+	}, RequireMemberAccess(access("owner"), access("viewer")), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"Leaving A Board", "/boards/board_1/members/user_caller", "viewer"},
+		{"Removing Another Member", "/boards/board_1/members/user_other", "owner"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, tt.want, w.Header().Get("X-Access"))
+		})
+	}
+}
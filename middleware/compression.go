@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCompressionMinBytes is the smallest response body worth compressing
+const defaultCompressionMinBytes = 1024
+
+// defaultCompressionTypes are the content types compressed unless COMPRESSION_CONTENT_TYPES lists others
+var defaultCompressionTypes = []string{
+	"application/json",
+	"application/javascript",
+	"text/javascript",
+	"text/css",
+	"text/csv",
+	"text/html",
+	"text/plain",
+	"text/calendar",
+}
+
+// CompressionMiddleware compresses responses with gzip or deflate, whichever the client prefers, when
+// their content type is one of COMPRESSION_CONTENT_TYPES (comma-separated) and their body reaches
+// COMPRESSION_MIN_BYTES. Smaller bodies are sent as they are. COMPRESSION_ENABLED=false turns it off.
+// WebSocket upgrades, range requests and event streams are left alone.
+func CompressionMiddleware() gin.HandlerFunc {
+	if enabled, err := strconv.ParseBool(os.Getenv("COMPRESSION_ENABLED")); err == nil && !enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	minBytes := defaultCompressionMinBytes
+	if value := os.Getenv("COMPRESSION_MIN_BYTES"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			minBytes = parsed
+		}
+	}
+
+	types := map[string]bool{}
+	for _, contentType := range strings.Split(os.Getenv("COMPRESSION_CONTENT_TYPES"), ",") {
+		if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+			types[contentType] = true
+		}
+	}
+	if len(types) == 0 {
+		for _, contentType := range defaultCompressionTypes {
+			types[contentType] = true
+		}
+	}
+	log.Printf("[Compression] Compressing %d content types from %d bytes", len(types), minBytes)
+
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead || c.GetHeader("Range") != "" ||
+			strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			minBytes:       minBytes,
+			types:          types,
+			status:         http.StatusOK,
+		}
+		c.Writer = writer
+		defer writer.finish()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, preferring gzip on ties,
+// or returns "" when the client accepts neither
+func negotiateEncoding(header string) string {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "gzip" && name != "deflate" && name != "*" {
+			continue
+		}
+		if name == "*" {
+			name = "gzip"
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > bestQuality || (quality == bestQuality && quality > 0 && name == "gzip") {
+			best, bestQuality = name, quality
+		}
+	}
+	if bestQuality == 0 {
+		return ""
+	}
+	return best
+}
+
+// compressWriter holds back the start of a response until it knows whether the body is large enough
+// to compress, then either streams it through the compressor or writes it as it is
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minBytes int
+	types    map[string]bool
+
+	status     int
+	buffer     bytes.Buffer
+	decided    bool
+	compressor io.WriteCloser // Set once compressing
+}
+
+// WriteHeader records the status until the response is committed
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+// WriteHeaderNow commits the response, uncompressed if nothing was buffered yet
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		w.decide(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Status returns the recorded status before the response is committed
+func (w *compressWriter) Status() int {
+	if !w.decided {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+// Written reports whether the handler has started the response
+func (w *compressWriter) Written() bool {
+	return w.decided || w.buffer.Len() > 0
+}
+
+// Write buffers the body until it reaches the threshold, then commits the response
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.compressor != nil {
+			return w.compressor.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buffer.Write(data)
+	if w.buffer.Len() >= w.minBytes {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// WriteString implements gin.ResponseWriter
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what was written so far, so streamed responses are never held back
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(false)
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack hands the connection over, as for WebSocket upgrades
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if !w.decided {
+		w.decide(false)
+	}
+	return w.ResponseWriter.Hijack()
+}
+
+// decide commits the status and headers, compressing the body when allowed and it qualifies, and
+// writes out the buffered start of the body
+func (w *compressWriter) decide(allowCompression bool) error {
+	w.decided = true
+	header := w.Header()
+
+	contentType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if allowCompression && w.types[contentType] && header.Get("Content-Encoding") == "" &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified {
+		if w.encoding == "gzip" {
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		} else {
+			w.compressor, _ = flate.NewWriter(w.ResponseWriter, flate.DefaultCompression)
+		}
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// A weak ETag still matches the compressed copy; a strong one would not
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+	}
+	header.Add("Vary", "Accept-Encoding")
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	data := w.buffer.Bytes()
+	w.buffer = bytes.Buffer{}
+	if w.compressor != nil {
+		_, err := w.compressor.Write(data)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(data)
+	return err
+}
+
+// finish commits a response still under the threshold and closes the compressor
+func (w *compressWriter) finish() {
+	if !w.decided {
+		w.decide(false)
+	}
+	if w.compressor != nil {
+		if err := w.compressor.Close(); err != nil {
+			log.Printf("[Compression] Failed to finish response - Error: %v", err)
+		}
+	}
+}
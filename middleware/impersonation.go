@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var errImpersonationNotActive = errors.New("impersonation not found, ended or expired")
+
+// findActiveImpersonation loads an admin's impersonation session that can still be used
+func findActiveImpersonation(impersonationID, adminID string) (*models.Impersonation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var impersonation models.Impersonation
+	err := models.GetCollection(ctx, models.ImpersonationsCollection).FindOne(ctx, bson.M{"_id": impersonationID, "admin_id": adminID}).Decode(&impersonation)
+	if err == mongo.ErrNoDocuments || (err == nil && !impersonation.IsActive(time.Now())) {
+		return nil, errImpersonationNotActive
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &impersonation, nil
+}
+
+// applyImpersonation switches the request to the impersonated user when an admin sends the
+// impersonation header. It writes an error response and returns false if the impersonation is refused.
+// The admin stays recorded in the context for audit logging and the UI banner.
+func applyImpersonation(c *gin.Context, admin *Identity) bool {
+	impersonationID := c.GetHeader(models.ImpersonationHeader)
+	if impersonationID == "" {
+		return true
+	}
+
+	if !IsAdmin(admin.UserID) {
+		log.Printf("[Auth] Impersonation refused - Not an admin, UserID: %s, IP: %s", admin.UserID, c.ClientIP())
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "ADMIN_REQUIRED",
+				"message": "Only platform admins can impersonate users",
+			},
+		})
+		return false
+	}
+
+	impersonation, err := findActiveImpersonation(impersonationID, admin.UserID)
+	if err != nil {
+		log.Printf("[Auth] Impersonation refused - Error: %v, ImpersonationID: %s, AdminID: %s, IP: %s", err, impersonationID, admin.UserID, c.ClientIP())
+		if err == errImpersonationNotActive {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "IMPERSONATION_NOT_ACTIVE",
+					"message": "This impersonation session was ended or has expired",
+				},
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to verify impersonation",
+					"details": err.Error(),
+				},
+			})
+		}
+		return false
+	}
+
+	// The admin's organization and claims don't carry over to the user
+	setIdentity(c, &Identity{
+		UserID:    impersonation.UserID,
+		SessionID: admin.SessionID,
+		AuthTime:  admin.AuthTime,
+	})
+	c.Set("impersonation", impersonation)
+	c.Header("X-Impersonated-By", impersonation.AdminID)
+
+	log.Printf("[Auth] Impersonation - AdminID: %s, UserID: %s, ImpersonationID: %s, Method: %s, Path: %s, IP: %s",
+		impersonation.AdminID, impersonation.UserID, impersonation.ID, c.Request.Method, c.Request.URL.Path, c.ClientIP())
+	return true
+}
+
+// GetImpersonation returns the impersonation session a request is made under, or nil when the
+// caller is acting as themselves
+func GetImpersonation(c *gin.Context) *models.Impersonation {
+	value, exists := c.Get("impersonation")
+	if !exists {
+		return nil
+	}
+	impersonation, _ := value.(*models.Impersonation)
+	return impersonation
+}
+
+// rejectImpersonated writes IMPERSONATION_FORBIDDEN and aborts if the request is made while impersonating
+func rejectImpersonated(c *gin.Context) bool {
+	impersonation := GetImpersonation(c)
+	if impersonation == nil {
+		return false
+	}
+	log.Printf("[Auth] Impersonation forbidden - AdminID: %s, UserID: %s, Path: %s, IP: %s",
+		impersonation.AdminID, impersonation.UserID, c.Request.URL.Path, c.ClientIP())
+	c.JSON(http.StatusForbidden, gin.H{
+		"error": gin.H{
+			"code":    "IMPERSONATION_FORBIDDEN",
+			"message": "This action is not available while impersonating a user",
+		},
+	})
+	c.Abort()
+	return true
+}
+
+// RejectImpersonation keeps actions that outlive an impersonation session, such as minting
+// credentials, with the real user. It must run after AuthMiddleware.
+func RejectImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rejectImpersonated(c) {
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/go-jose/go-jose/v3"
+	josejwt "github.com/go-jose/go-jose/v3/jwt"
+)
+
+const (
+	// oidcKeysTTL is how long a fetched JWKS is trusted before it is refreshed
+	oidcKeysTTL = time.Hour
+	// oidcRefreshInterval throttles JWKS refreshes triggered by unknown key IDs
+	oidcRefreshInterval = time.Minute
+	// oidcClockLeeway tolerates clock skew between the issuer and this server
+	oidcClockLeeway = time.Minute
+)
+
+// oidcSigningAlgorithms are the asymmetric algorithms accepted on OIDC tokens
+var oidcSigningAlgorithms = map[string]bool{
+	string(jose.RS256): true, string(jose.RS384): true, string(jose.RS512): true,
+	string(jose.PS256): true, string(jose.PS384): true, string(jose.PS512): true,
+	string(jose.ES256): true, string(jose.ES384): true, string(jose.ES512): true,
+	string(jose.EdDSA): true,
+}
+
+// OIDCConfig configures the generic OpenID Connect token verifier
+type OIDCConfig struct {
+	Issuer       string // Expected "iss" claim; also used for discovery when JWKSURL is empty
+	Audience     string // Expected "aud" claim; not checked when empty
+	JWKSURL      string // Signing keys; discovered from the issuer's openid-configuration when empty
+	OrgClaim     string // Optional claim holding the active organization (workspace) ID
+	OrgRoleClaim string // Optional claim holding the role in that organization
+}
+
+// OIDCVerifier verifies RS/PS/ES/EdDSA-signed JWTs against an issuer's JWKS
+type OIDCVerifier struct {
+	config OIDCConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        *jose.JSONWebKeySet
+	fetchedAt   time.Time
+	refreshedAt time.Time
+}
+
+// NewOIDCVerifier creates a verifier for an OIDC issuer, resolving its JWKS URL if not configured
+func NewOIDCVerifier(config OIDCConfig) (*OIDCVerifier, error) {
+	config.Issuer = strings.TrimSpace(config.Issuer)
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("OIDC_ISSUER environment variable is required")
+	}
+
+	verifier := &OIDCVerifier{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	if verifier.config.JWKSURL == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := verifier.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return nil, fmt.Errorf("failed to discover OIDC configuration: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("OIDC configuration at %s has no jwks_uri", discoveryURL)
+		}
+		verifier.config.JWKSURL = discovery.JWKSURI
+	}
+
+	log.Printf("[Auth] NewOIDCVerifier success - Issuer: %s, JWKS: %s", config.Issuer, verifier.config.JWKSURL)
+	return verifier, nil
+}
+
+func (v *OIDCVerifier) Name() string {
+	return "oidc"
+}
+
+// Verify checks the token's signature, issuer, audience and lifetime and maps its claims to an Identity
+func (v *OIDCVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	parsed, err := josejwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	if len(parsed.Headers) != 1 || !oidcSigningAlgorithms[parsed.Headers[0].Algorithm] {
+		return nil, fmt.Errorf("unsupported token signature")
+	}
+
+	key, err := v.signingKey(ctx, parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var standard josejwt.Claims
+	var extra map[string]interface{}
+	if err := parsed.Claims(key.Key, &standard, &extra); err != nil {
+		return nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	expected := josejwt.Expected{Issuer: v.config.Issuer, Time: time.Now()}
+	if v.config.Audience != "" {
+		expected.Audience = josejwt.Audience{v.config.Audience}
+	}
+	if err := standard.ValidateWithLeeway(expected, oidcClockLeeway); err != nil {
+		return nil, err
+	}
+	if standard.Expiry == nil {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+	if standard.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+
+	identity := &Identity{
+		UserID:    standard.Subject,
+		SessionID: stringClaim(extra, "sid"),
+		OrgID:     stringClaim(extra, v.config.OrgClaim),
+		OrgRole:   stringClaim(extra, v.config.OrgRoleClaim),
+		Claims:    extra,
+	}
+	if authTime, ok := extra["auth_time"].(float64); ok && authTime > 0 {
+		identity.AuthTime = time.Unix(int64(authTime), 0)
+	}
+	return identity, nil
+}
+
+// signingKey returns the JWKS key with the given ID, refreshing the key set when it is stale or
+// the ID is unknown (the issuer rotated its keys)
+func (v *OIDCVerifier) signingKey(ctx context.Context, keyID string) (*jose.JSONWebKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	if v.keys == nil || now.Sub(v.fetchedAt) > oidcKeysTTL {
+		if err := v.refreshKeys(ctx, now); err != nil && v.keys == nil {
+			return nil, err
+		}
+	}
+
+	key := findSigningKey(v.keys, keyID)
+	if key == nil && now.Sub(v.refreshedAt) > oidcRefreshInterval {
+		if err := v.refreshKeys(ctx, now); err != nil {
+			return nil, err
+		}
+		key = findSigningKey(v.keys, keyID)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+// refreshKeys fetches the JWKS; the caller must hold v.mu
+func (v *OIDCVerifier) refreshKeys(ctx context.Context, now time.Time) error {
+	v.refreshedAt = now
+
+	var keys jose.JSONWebKeySet
+	if err := v.getJSON(ctx, v.config.JWKSURL, &keys); err != nil {
+		log.Printf("[Auth] OIDCVerifier - JWKS refresh failed: %v, URL: %s", err, v.config.JWKSURL)
+		return fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+
+	v.keys = &keys
+	v.fetchedAt = now
+	log.Printf("[Auth] OIDCVerifier - JWKS refreshed, Keys: %d", len(keys.Keys))
+	return nil
+}
+
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+// findSigningKey picks the signing key with the given ID; tokens without a key ID match a lone key
+func findSigningKey(keys *jose.JSONWebKeySet, keyID string) *jose.JSONWebKey {
+	if keys == nil {
+		return nil
+	}
+	if keyID == "" {
+		if len(keys.Keys) == 1 {
+			return &keys.Keys[0]
+		}
+		return nil
+	}
+	for _, key := range keys.Key(keyID) {
+		if key.Use == "" || key.Use == "sig" {
+			return &key
+		}
+	}
+	return nil
+}
+
+// stringClaim reads a string claim, returning "" when the claim is unset or not a string
+func stringClaim(claims map[string]interface{}, name string) string {
+	if name == "" {
+		return ""
+	}
+	value, _ := claims[name].(string)
+	return value
+}
+
+// Profile builds the user's profile from the standard OIDC claims of their token
+func (v *OIDCVerifier) Profile(ctx context.Context, identity *Identity) (*models.User, error) {
+	claims, _ := identity.Claims.(map[string]interface{})
+	profile := &models.User{
+		ID:          identity.UserID,
+		DisplayName: stringClaim(claims, "name"),
+		Email:       stringClaim(claims, "email"),
+		AvatarURL:   stringClaim(claims, "picture"),
+	}
+	if profile.DisplayName == "" {
+		profile.DisplayName = stringClaim(claims, "preferred_username")
+	}
+	return profile, nil
+}
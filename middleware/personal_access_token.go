@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// errInvalidPersonalAccessToken is returned for unknown, revoked and expired personal access tokens
+var errInvalidPersonalAccessToken = errors.New("invalid, revoked or expired personal access token")
+
+// verifyPersonalAccessToken looks up a personal access token secret and records its use
+func verifyPersonalAccessToken(secret string) (*models.PersonalAccessToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var token models.PersonalAccessToken
+	collection := models.GetCollection(ctx, models.PersonalTokensCollection)
+	err := collection.FindOne(ctx, bson.M{"token_hash": models.HashAPIToken(secret)}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errInvalidPersonalAccessToken
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.IsExpired(now) {
+		return nil, errInvalidPersonalAccessToken
+	}
+
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": token.ID}, bson.M{"$set": bson.M{"last_used_at": now}}); err != nil {
+		log.Printf("[Auth] verifyPersonalAccessToken - Failed to record token use: %v, TokenID: %s", err, token.ID)
+	}
+	return &token, nil
+}
+
+// GetPersonalAccessToken extracts the personal access token that authenticated the request
+func GetPersonalAccessToken(c *gin.Context) (*models.PersonalAccessToken, error) {
+	value, exists := c.Get("personalAccessToken")
+	if !exists {
+		return nil, fmt.Errorf("personal access token not found in context")
+	}
+
+	token, ok := value.(*models.PersonalAccessToken)
+	if !ok {
+		return nil, fmt.Errorf("personal access token has an unexpected type")
+	}
+	return token, nil
+}
+
+// IsPersonalAccessTokenAuth reports whether the request was authenticated with a personal access token
+func IsPersonalAccessTokenAuth(c *gin.Context) bool {
+	_, err := GetPersonalAccessToken(c)
+	return err == nil
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"disko-backend/models"
+	"disko-backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// userRateWindow is how long an emptied per-user budget takes to refill
+const userRateWindow = time.Minute
+
+// workspacePlanCacheTTL is how long a workspace's plan is reused for rate limiting
+const workspacePlanCacheTTL = time.Minute
+
+type workspacePlanEntry struct {
+	limits    models.PlanLimits
+	found     bool
+	expiresAt time.Time
+}
+
+// workspacePlanCache remembers workspace plans so rate limiting doesn't query the workspace per request
+var (
+	workspacePlanCache   = make(map[string]workspacePlanEntry)
+	workspacePlanCacheMu sync.Mutex
+)
+
+// UserRateLimitMiddleware enforces per-user request budgets on authenticated routes: writes (POST, PUT,
+// PATCH, DELETE) and reads each have a token bucket refilled over a minute. Budgets come from the plan of the
+// caller's active workspace, or else RATE_LIMIT_USER_WRITES_PER_MINUTE and RATE_LIMIT_USER_READS_PER_MINUTE;
+// 0 means unlimited. It must run after AuthMiddleware or ServiceAccountMiddleware.
+func UserRateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := rateLimitPrincipal(c)
+		if principal == "" {
+			c.Next()
+			return
+		}
+
+		write := c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead && c.Request.Method != http.MethodOptions
+		limits := userRateLimits(c)
+		limit := limits.ReadsPerMinute
+		if write {
+			limit = limits.WritesPerMinute
+		}
+		if limit == 0 {
+			c.Next()
+			return
+		}
+
+		kind := "read"
+		if write {
+			kind = "write"
+		}
+		allowed, remaining, retryAfter := utils.TakeRateLimitToken("user_"+kind+"_"+principal, limit, userRateWindow)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		if !allowed {
+			log.Printf("[Auth] UserRateLimitMiddleware - Budget exhausted, Principal: %s, Kind: %s, Limit: %d, Path: %s, IP: %s",
+				principal, kind, limit, c.Request.URL.Path, c.ClientIP())
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": gin.H{
+					"code":    "RATE_LIMITED",
+					"message": fmt.Sprintf("Too many %s requests; please wait %d seconds", kind, retryAfter),
+					"details": fmt.Sprintf("limit is %d %s requests per minute", limit, kind),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitPrincipal returns who a request is counted against: the user, or the service account
+func rateLimitPrincipal(c *gin.Context) string {
+	if account, err := GetServiceAccount(c); err == nil {
+		return account.ActorID()
+	}
+	if userID, exists := c.Get("userID"); exists {
+		if id, ok := userID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// userRateLimits returns the budgets of the caller's active workspace plan, or the environment defaults
+func userRateLimits(c *gin.Context) models.PlanLimits {
+	limits := models.PlanLimits{
+		WritesPerMinute: rateLimitFromEnv("RATE_LIMIT_USER_WRITES_PER_MINUTE", 60),
+		ReadsPerMinute:  rateLimitFromEnv("RATE_LIMIT_USER_READS_PER_MINUTE", 600),
+	}
+
+	orgID, _ := GetOrganization(c)
+	if orgID == "" || models.DB == nil {
+		return limits
+	}
+
+	now := time.Now()
+	workspacePlanCacheMu.Lock()
+	entry, cached := workspacePlanCache[orgID]
+	workspacePlanCacheMu.Unlock()
+	if !cached || now.After(entry.expiresAt) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		var workspace models.Workspace
+		err := models.GetCollection(ctx, models.WorkspacesCollection).FindOne(ctx, bson.M{"_id": orgID}).Decode(&workspace)
+		entry = workspacePlanEntry{found: err == nil, expiresAt: now.Add(workspacePlanCacheTTL)}
+		if err == nil {
+			entry.limits = workspace.Limits()
+		}
+
+		workspacePlanCacheMu.Lock()
+		workspacePlanCache[orgID] = entry
+		workspacePlanCacheMu.Unlock()
+	}
+
+	if entry.found {
+		limits.WritesPerMinute = entry.limits.WritesPerMinute
+		limits.ReadsPerMinute = entry.limits.ReadsPerMinute
+	}
+	return limits
+}
+
+// rateLimitFromEnv reads a per-minute budget from the environment; 0 disables the limit
+func rateLimitFromEnv(envVar string, fallback int) int {
+	if value := os.Getenv(envVar); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+			return limit
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ServiceAccountMiddleware authenticates integration requests with a service account secret
+// ("Authorization: Bearer dks_..."). Routes check the account's board scopes with RequireServicePermission.
+func ServiceAccountMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log.Printf("[Auth] ServiceAccountMiddleware called - Path: %s, Method: %s, IP: %s", c.Request.URL.Path, c.Request.Method, c.ClientIP())
+
+		secret, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || !strings.HasPrefix(secret, models.ServiceAccountTokenPrefix) {
+			log.Printf("[Auth] ServiceAccountMiddleware failed - No service account token, IP: %s", c.ClientIP())
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": gin.H{
+					"code":    "UNAUTHORIZED",
+					"message": "A service account token is required",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		var account models.ServiceAccount
+		collection := models.GetCollection(ctx, models.ServiceAccountsCollection)
+		err := collection.FindOne(ctx, bson.M{"token_hash": models.HashAPIToken(secret)}).Decode(&account)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				log.Printf("[Auth] ServiceAccountMiddleware failed - Unknown or deleted service account, IP: %s", c.ClientIP())
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": gin.H{
+						"code":    "INVALID_TOKEN",
+						"message": "Invalid or revoked service account token",
+					},
+				})
+				c.Abort()
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "DATABASE_ERROR",
+					"message": "Failed to verify service account",
+					"details": err.Error(),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		now := time.Now().UTC()
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": account.ID}, bson.M{"$set": bson.M{"last_used_at": now}}); err != nil {
+			log.Printf("[Auth] ServiceAccountMiddleware - Failed to record account use: %v, AccountID: %s", err, account.ID)
+		}
+
+		c.Set("serviceAccount", &account)
+
+		log.Printf("[Auth] ServiceAccountMiddleware success - AccountID: %s, OwnerID: %s, IP: %s", account.ID, account.UserID, c.ClientIP())
+
+		c.Next()
+	}
+}
+
+// RequireServicePermission allows the request only if the service account holds the permission on the :id board
+func RequireServicePermission(permission models.ServicePermission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		account, err := GetServiceAccount(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Failed to get service account",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		boardID := c.Param("id")
+		if !account.Allows(boardID, permission) {
+			log.Printf("[Auth] RequireServicePermission failed - AccountID: %s, BoardID: %s, Permission: %s, IP: %s", account.ID, boardID, permission, c.ClientIP())
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "PERMISSION_DENIED",
+					"message": fmt.Sprintf("This service account lacks the %s permission on this board", permission),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetServiceAccount extracts the service account authenticated by ServiceAccountMiddleware
+func GetServiceAccount(c *gin.Context) (*models.ServiceAccount, error) {
+	value, exists := c.Get("serviceAccount")
+	if !exists {
+		return nil, fmt.Errorf("service account not found in context")
+	}
+
+	account, ok := value.(*models.ServiceAccount)
+	if !ok {
+		return nil, fmt.Errorf("service account has an unexpected type")
+	}
+	return account, nil
+}
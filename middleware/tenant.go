@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tenantHosts maps hosts dedicated to one workspace, from TENANCY_HOSTS, to the workspace ID
+var tenantHosts = map[string]string{}
+
+// InitTenancy reads TENANCY_HOSTS, a comma-separated list of host=workspace ID pairs. Requests to
+// such a host only ever reach that workspace's data, which lets its public boards, embeds and
+// tokens work without a session. Needs TENANCY_MODE to be database or prefix.
+func InitTenancy() error {
+	hosts := map[string]string{}
+	value := os.Getenv("TENANCY_HOSTS")
+	if value != "" && !models.TenancyEnabled() {
+		return fmt.Errorf("TENANCY_HOSTS needs TENANCY_MODE set to database or prefix")
+	}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		host, tenantID, ok := strings.Cut(pair, "=")
+		host = strings.ToLower(strings.TrimSpace(host))
+		tenantID = strings.TrimSpace(tenantID)
+		if !ok || host == "" || !models.IsValidTenantID(tenantID) {
+			return fmt.Errorf("invalid TENANCY_HOSTS entry %q: expected host=workspace ID", pair)
+		}
+		hosts[host] = tenantID
+	}
+
+	tenantHosts = hosts
+	if models.TenancyEnabled() {
+		log.Printf("[Tenancy] Workspace data isolation on - Dedicated hosts: %d", len(hosts))
+	}
+	return nil
+}
+
+// TenantMiddleware scopes requests made to a host of TENANCY_HOSTS to its workspace. Requests to
+// other hosts start in the main database; the auth middlewares move signed-in members of a
+// workspace to its data.
+func TenantMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tenantID := hostTenant(c); tenantID != "" && !scopeTenant(c, tenantID) {
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// hostTenant returns the workspace the request's host is dedicated to, or ""
+func hostTenant(c *gin.Context) string {
+	if len(tenantHosts) == 0 {
+		return ""
+	}
+	host := c.Request.Host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return tenantHosts[strings.ToLower(host)]
+}
+
+// sessionTenant returns the workspace whose data a session may use: the host's workspace, or the
+// session's active organization on shared hosts. It reports false for sessions of another
+// workspace on a dedicated host, so one workspace's data is never reached with another's session.
+func sessionTenant(c *gin.Context, identity *Identity) (string, bool) {
+	return workspaceTenant(c, identity.OrgID)
+}
+
+// tokenTenant returns the workspace whose data a personal access token may use, like sessionTenant
+// with the workspace the token was created in
+func tokenTenant(c *gin.Context, token *models.PersonalAccessToken) (string, bool) {
+	return workspaceTenant(c, token.TenantID)
+}
+
+// workspaceTenant returns the tenant of credentials issued for orgID: the host's workspace, which
+// they may only use when it is theirs, or orgID on shared hosts
+func workspaceTenant(c *gin.Context, orgID string) (string, bool) {
+	if !models.TenancyEnabled() {
+		return "", true
+	}
+	if tenantID := hostTenant(c); tenantID != "" {
+		return tenantID, orgID == tenantID
+	}
+	return orgID, true
+}
+
+// applySessionTenant moves a signed-in request to the data of the session's workspace, writing an
+// error response and returning false when the session may not be used here
+func applySessionTenant(c *gin.Context) bool {
+	identity, err := GetIdentity(c)
+	if err != nil {
+		return true
+	}
+	tenantID, allowed := sessionTenant(c, identity)
+	return applyTenant(c, tenantID, allowed, identity.UserID, identity.OrgID)
+}
+
+// applyTokenTenant moves a request authenticated with a personal access token to the data of the
+// token's workspace, writing an error response and returning false when it may not be used here
+func applyTokenTenant(c *gin.Context, token *models.PersonalAccessToken) bool {
+	tenantID, allowed := tokenTenant(c, token)
+	return applyTenant(c, tenantID, allowed, token.UserID, token.TenantID)
+}
+
+// applyTenant scopes the request to tenantID, or writes TENANT_FORBIDDEN when the credentials of
+// userID, issued for orgID, may not be used on this host
+func applyTenant(c *gin.Context, tenantID string, allowed bool, userID, orgID string) bool {
+	if !allowed {
+		log.Printf("[Auth] Tenant refused - UserID: %s, OrgID: %s, Host: %s, IP: %s", userID, orgID, c.Request.Host, c.ClientIP())
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "TENANT_FORBIDDEN",
+				"message": "Switch to the workspace this site belongs to",
+			},
+		})
+		return false
+	}
+	return scopeTenant(c, tenantID)
+}
+
+// scopeTenant scopes the request to a workspace like enterTenant, writing TENANT_UNAVAILABLE and
+// returning false when its collections cannot be set up
+func scopeTenant(c *gin.Context, tenantID string) bool {
+	if err := enterTenant(c, tenantID); err != nil {
+		log.Printf("[Tenancy] Failed to prepare tenant - TenantID: %s, Error: %v, IP: %s", tenantID, err, c.ClientIP())
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": gin.H{
+				"code":    "TENANT_UNAVAILABLE",
+				"message": "This workspace's data is not available right now",
+				"details": err.Error(),
+			},
+		})
+		return false
+	}
+	return true
+}
+
+// enterTenant prepares a workspace's collections and scopes the request to them; "" is the main
+// database
+func enterTenant(c *gin.Context, tenantID string) error {
+	ctx := c.Request.Context()
+	if tenantID == models.TenantFromContext(ctx) {
+		return nil
+	}
+	if err := models.PrepareTenant(ctx, tenantID); err != nil {
+		return err
+	}
+	c.Request = c.Request.WithContext(models.WithTenant(ctx, tenantID))
+	return nil
+}
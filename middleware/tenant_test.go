@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withTenancy turns tenancy on with one dedicated host for the test
+func withTenancy(t *testing.T, host, tenantID string) {
+	require.NoError(t, models.SetTenancyMode(models.TenancyPrefix))
+	previous := tenantHosts
+	tenantHosts = map[string]string{host: tenantID}
+	t.Cleanup(func() {
+		tenantHosts = previous
+		models.SetTenancyMode(models.TenancyOff)
+	})
+}
+
+func TestPersonalAccessTokenTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	withTenancy(t, "acme.example.com", "org_acme")
+
+	// serveToken applies a token's tenant on a request to host, as the auth middlewares do once the
+	// token is verified. Dedicated hosts start in their workspace, as TenantMiddleware leaves them.
+	serveToken := func(host string, token *models.PersonalAccessToken) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/api/boards", func(c *gin.Context) {
+			if tenantID := hostTenant(c); tenantID != "" {
+				c.Request = c.Request.WithContext(models.WithTenant(c.Request.Context(), tenantID))
+			}
+			if !applyTokenTenant(c, token) {
+				c.Abort()
+				return
+			}
+			c.String(http.StatusOK, models.TenantFromContext(c.Request.Context()))
+		})
+		req := httptest.NewRequest(http.MethodGet, "/api/boards", nil)
+		req.Host = host
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Token Of Another Workspace Is Refused", func(t *testing.T) {
+		w := serveToken("acme.example.com", &models.PersonalAccessToken{UserID: "user_1", TenantID: "org_other"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "TENANT_FORBIDDEN")
+	})
+
+	t.Run("Token Without Workspace Is Refused On Dedicated Hosts", func(t *testing.T) {
+		w := serveToken("acme.example.com:443", &models.PersonalAccessToken{UserID: "user_1"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Token Of The Host's Workspace", func(t *testing.T) {
+		w := serveToken("acme.example.com", &models.PersonalAccessToken{UserID: "user_1", TenantID: "org_acme"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "org_acme", w.Body.String())
+	})
+
+	t.Run("Shared Hosts Use The Token's Workspace", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/boards", nil)
+		c.Request.Host = "app.example.com"
+
+		tenantID, allowed := tokenTenant(c, &models.PersonalAccessToken{UserID: "user_1", TenantID: "org_other"})
+		assert.True(t, allowed)
+		assert.Equal(t, "org_other", tenantID)
+	})
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultRequestTimeout bounds a request unless REQUEST_TIMEOUT_SECONDS or its route says otherwise
+const defaultRequestTimeout = 10 * time.Second
+
+// requestBaseContextKey holds the request's context before any deadline was added, so a route can
+// replace the default deadline rather than only shorten it
+const requestBaseContextKey = "request_base_context"
+
+var (
+	requestTimeoutOnce sync.Once
+	requestTimeout     time.Duration
+)
+
+// DefaultRequestTimeout returns how long a request may take unless its route sets another timeout,
+// read once from REQUEST_TIMEOUT_SECONDS
+func DefaultRequestTimeout() time.Duration {
+	requestTimeoutOnce.Do(func() {
+		requestTimeout = defaultRequestTimeout
+		if value := os.Getenv("REQUEST_TIMEOUT_SECONDS"); value != "" {
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds <= 0 {
+				log.Printf("Invalid REQUEST_TIMEOUT_SECONDS %q, using %v", value, requestTimeout)
+				return
+			}
+			requestTimeout = time.Duration(seconds) * time.Second
+		}
+	})
+	return requestTimeout
+}
+
+// RequestTimeoutMiddleware gives every request a context that ends when the client disconnects or
+// DefaultRequestTimeout passes. Handlers pass c.Request.Context() to their database and HTTP calls
+// so abandoned or stuck requests stop working instead of running on.
+func RequestTimeoutMiddleware() gin.HandlerFunc {
+	timeout := DefaultRequestTimeout()
+	return func(c *gin.Context) {
+		c.Set(requestBaseContextKey, c.Request.Context())
+		runWithTimeout(c, timeout)
+	}
+}
+
+// RequestTimeout replaces the default timeout for a route, for slow operations such as exports and
+// third-party calls. 0 removes the deadline for long-lived connections, which still end when the
+// client disconnects.
+func RequestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runWithTimeout(c, timeout)
+	}
+}
+
+// runWithTimeout runs the rest of the chain with a deadline derived from the request's base context,
+// keeping the workspace the auth middlewares may have scoped the request to since
+func runWithTimeout(c *gin.Context, timeout time.Duration) {
+	base := c.Request.Context()
+	if value, ok := c.Get(requestBaseContextKey); ok {
+		base = models.WithTenant(value.(context.Context), models.TenantFromContext(base))
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(base, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(base)
+	}
+	defer cancel()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"disko-backend/models"
+)
+
+// userProfileSyncedAt remembers when each user's profile was last synced by this server instance
+var (
+	userProfileSyncedAt   = make(map[string]time.Time)
+	userProfileSyncedAtMu sync.Mutex
+)
+
+// syncUserProfile refreshes the cached profile of a signed-in user from the identity provider,
+// at most once per models.UserProfileSyncInterval. Failures are logged and retried on a later request.
+func syncUserProfile(identity *Identity) {
+	provider, ok := tokenVerifier.(ProfileProvider)
+	if !ok || models.DB == nil || identity.UserID == "" {
+		return
+	}
+
+	now := time.Now()
+	userProfileSyncedAtMu.Lock()
+	if syncedAt, found := userProfileSyncedAt[identity.UserID]; found && now.Sub(syncedAt) < models.UserProfileSyncInterval {
+		userProfileSyncedAtMu.Unlock()
+		return
+	}
+	// Claim the sync so concurrent requests don't all call the provider
+	userProfileSyncedAt[identity.UserID] = now
+	userProfileSyncedAtMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	profile, err := provider.Profile(ctx, identity)
+	if err == nil {
+		err = models.UpsertUser(ctx, profile)
+	}
+	if err != nil {
+		log.Printf("[Auth] syncUserProfile failed - Error: %v, UserID: %s", err, identity.UserID)
+		userProfileSyncedAtMu.Lock()
+		delete(userProfileSyncedAt, identity.UserID)
+		userProfileSyncedAtMu.Unlock()
+	}
+}
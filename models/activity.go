@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// Activity represents a board activity entry in MongoDB
+type Activity struct {
+	ID        string                 `bson:"_id,omitempty" json:"id"`
+	BoardID   string                 `bson:"board_id" json:"boardId"`
+	IdeaID    string                 `bson:"idea_id,omitempty" json:"ideaId,omitempty"`
+	ActorID   string                 `bson:"actor_id,omitempty" json:"actorId,omitempty"` // Empty for anonymous visitors
+	Type      string                 `bson:"type" json:"type"`
+	Details   map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt time.Time              `bson:"created_at" json:"createdAt"`
+}
+
+// ActivityType represents the different kinds of board activity
+type ActivityType string
+
+const (
+	ActivityIdeaCreated  ActivityType = "idea_created"
+	ActivityIdeaUpdated  ActivityType = "idea_updated"
+	ActivityIdeaMoved    ActivityType = "idea_moved"
+	ActivityIdeaDeleted  ActivityType = "idea_deleted"
+	ActivityFeedback     ActivityType = "feedback"
+	ActivityBoardUpdated ActivityType = "board_updated"
+)
+
+// IsValidActivityType checks if an activity type is valid
+func IsValidActivityType(activityType string) bool {
+	validTypes := []string{
+		string(ActivityIdeaCreated),
+		string(ActivityIdeaUpdated),
+		string(ActivityIdeaMoved),
+		string(ActivityIdeaDeleted),
+		string(ActivityFeedback),
+		string(ActivityBoardUpdated),
+	}
+
+	for _, valid := range validTypes {
+		if activityType == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AlertMetric is what an alert rule watches
+type AlertMetric string
+
+const (
+	AlertIdeaThumbsUp AlertMetric = "idea_thumbs_up" // An idea's thumbs up reach the threshold
+	AlertIdeaReaction AlertMetric = "idea_reactions" // An idea's thumbs up and emoji reactions together reach the threshold
+	AlertColumnSize   AlertMetric = "column_size"    // A column holds more ideas than the threshold
+)
+
+// MaxAlertRulesPerUser caps how many alert rules a user may have on one board
+const MaxAlertRulesPerUser = 20
+
+// AlertRule notifies the user who created it when a board metric crosses a threshold. Idea rules
+// fire once per idea; column rules fire when the column grows past the threshold and again after
+// it shrank back to it.
+type AlertRule struct {
+	ID              string      `bson:"_id,omitempty" json:"id"`
+	BoardID         string      `bson:"board_id" json:"boardId" validate:"required"`
+	UserID          string      `bson:"user_id" json:"userId" validate:"required"` // Who is notified
+	Metric          AlertMetric `bson:"metric" json:"metric"`
+	Column          string      `bson:"column,omitempty" json:"column,omitempty"` // Column rules only
+	Threshold       int         `bson:"threshold" json:"threshold"`
+	Enabled         bool        `bson:"enabled" json:"enabled"`
+	TriggerCount    int         `bson:"trigger_count" json:"triggerCount"`
+	LastTriggeredAt *time.Time  `bson:"last_triggered_at,omitempty" json:"lastTriggeredAt,omitempty"`
+	CreatedAt       time.Time   `bson:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time   `bson:"updated_at" json:"updatedAt"`
+}
+
+// AlertFiring records that a rule fired for an idea or column, so it does not fire again. The ID
+// is the rule ID and subject joined, which makes firing once atomic.
+type AlertFiring struct {
+	ID      string    `bson:"_id"`
+	RuleID  string    `bson:"rule_id"`
+	BoardID string    `bson:"board_id"`
+	UserID  string    `bson:"user_id"`
+	Subject string    `bson:"subject"` // Idea ID or column
+	Value   int       `bson:"value"`   // The metric when it fired
+	FiredAt time.Time `bson:"fired_at"`
+}
+
+// IsIdeaAlertMetric reports whether a metric is measured per idea
+func IsIdeaAlertMetric(metric AlertMetric) bool {
+	return metric == AlertIdeaThumbsUp || metric == AlertIdeaReaction
+}
+
+// IsValidAlertMetric checks if a metric exists
+func IsValidAlertMetric(metric string) bool {
+	switch AlertMetric(metric) {
+	case AlertIdeaThumbsUp, AlertIdeaReaction, AlertColumnSize:
+		return true
+	}
+	return false
+}
+
+// FindEnabledAlertRules returns a board's enabled rules watching any of the metrics
+func FindEnabledAlertRules(ctx context.Context, boardID string, metrics ...AlertMetric) ([]AlertRule, error) {
+	cursor, err := GetCollection(ctx, AlertRulesCollection).Find(ctx, bson.M{
+		"board_id": boardID,
+		"enabled":  true,
+		"metric":   bson.M{"$in": metrics},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rules []AlertRule
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// APIToken grants programmatic read-only access to a single board's data. Only a hash of the
+// secret is stored; the secret itself is shown once when the token is created.
+type APIToken struct {
+	ID         string     `bson:"_id,omitempty" json:"id"`
+	BoardID    string     `bson:"board_id" json:"boardId" validate:"required"`
+	UserID     string     `bson:"user_id" json:"userId" validate:"required"`
+	Label      string     `bson:"label,omitempty" json:"label,omitempty" validate:"max=100"`
+	TokenHash  string     `bson:"token_hash" json:"-"`
+	Prefix     string     `bson:"prefix" json:"prefix"` // First characters of the secret, to tell tokens apart
+	CreatedAt  time.Time  `bson:"created_at" json:"createdAt"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"lastUsedAt,omitempty"`
+}
+
+// MaxAPITokensPerBoard caps how many API tokens a board may have
+const MaxAPITokensPerBoard = 10
+
+// APITokenPrefixLength is how much of the secret is kept in clear for display
+const APITokenPrefixLength = 8
+
+// HashAPIToken returns the hash stored for an API token secret
+func HashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
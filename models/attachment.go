@@ -0,0 +1,51 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Attachment is a file uploaded to an idea; the content lives in the asset storage under Key
+type Attachment struct {
+	ID          string    `bson:"_id,omitempty" json:"id"`
+	BoardID     string    `bson:"board_id" json:"boardId"`
+	IdeaID      string    `bson:"idea_id" json:"ideaId"`
+	Key         string    `bson:"key" json:"-"`
+	Filename    string    `bson:"filename" json:"filename"`
+	ContentType string    `bson:"content_type" json:"contentType"` // Detected from the content, not the client
+	Size        int64     `bson:"size" json:"size"`
+	CreatedBy   string    `bson:"created_by" json:"createdBy"`
+	CreatedAt   time.Time `bson:"created_at" json:"createdAt"`
+}
+
+const (
+	// MaxAttachmentSize limits the size of one attachment
+	MaxAttachmentSize = 10 << 20
+	// MaxIdeaAttachments limits how many files one idea may carry
+	MaxIdeaAttachments = 20
+	// MaxBoardLogoSize limits the size of a board logo
+	MaxBoardLogoSize = 1 << 20
+)
+
+// BoardLogoContentTypes are the image types accepted as board logos, with the extension they are
+// stored under. SVG is left out because it can carry scripts.
+var BoardLogoContentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// BoardStoragePrefix is the storage key prefix of everything stored for a board, removed with it.
+// The files of a tenant's boards are kept under tenants/<tenant ID>/.
+func BoardStoragePrefix(ctx context.Context, boardID string) string {
+	if tenantID := TenantFromContext(ctx); tenantID != "" {
+		return "tenants/" + tenantID + "/boards/" + boardID + "/"
+	}
+	return "boards/" + boardID + "/"
+}
+
+// IdeaStoragePrefix is the storage key prefix of an idea's attachments
+func IdeaStoragePrefix(ctx context.Context, boardID, ideaID string) string {
+	return BoardStoragePrefix(ctx, boardID) + "ideas/" + ideaID + "/"
+}
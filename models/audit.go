@@ -0,0 +1,97 @@
+package models
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// AuditEntry is an immutable record of a board, idea or member mutation: who changed what, from where,
+// and how each changed field looked before and after. Entries are never updated, except to anonymize a
+// deleted account, and only expire with the configured retention.
+type AuditEntry struct {
+	ID             string                 `bson:"_id,omitempty" json:"id"`
+	BoardID        string                 `bson:"board_id" json:"boardId"`
+	TargetType     AuditTargetType        `bson:"target_type" json:"targetType"`
+	TargetID       string                 `bson:"target_id" json:"targetId"`
+	Action         AuditAction            `bson:"action" json:"action"`
+	ActorID        string                 `bson:"actor_id" json:"actorId"`                                   // User ID, or "service:<id>" for service accounts
+	ImpersonatorID string                 `bson:"impersonator_id,omitempty" json:"impersonatorId,omitempty"` // Admin acting as the actor
+	IP             string                 `bson:"ip,omitempty" json:"ip,omitempty"`
+	UserAgent      string                 `bson:"user_agent,omitempty" json:"userAgent,omitempty"`
+	Changes        map[string]AuditChange `bson:"changes,omitempty" json:"changes,omitempty"` // Keyed by API field name
+	CreatedAt      time.Time              `bson:"created_at" json:"createdAt"`
+}
+
+// AuditChange holds a field's value before and after a mutation; nil on creation or deletion
+type AuditChange struct {
+	Before interface{} `bson:"before" json:"before"`
+	After  interface{} `bson:"after" json:"after"`
+}
+
+// AuditTargetType is the kind of object an audit entry is about
+type AuditTargetType string
+
+const (
+	AuditTargetBoard  AuditTargetType = "board"
+	AuditTargetIdea   AuditTargetType = "idea"
+	AuditTargetMember AuditTargetType = "member"
+)
+
+// AuditAction names a recorded mutation
+type AuditAction string
+
+const (
+	AuditBoardCreated       AuditAction = "board.created"
+	AuditBoardUpdated       AuditAction = "board.updated"
+	AuditBoardDeleted       AuditAction = "board.deleted"
+	AuditBoardModerated     AuditAction = "board.moderated"
+	AuditIdeaCreated        AuditAction = "idea.created"
+	AuditIdeaUpdated        AuditAction = "idea.updated"
+	AuditIdeaMoved          AuditAction = "idea.moved"
+	AuditIdeaStatusChanged  AuditAction = "idea.status_changed"
+	AuditIdeaPollUpdated    AuditAction = "idea.poll_updated"
+	AuditIdeaPollDeleted    AuditAction = "idea.poll_deleted"
+	AuditIdeaJiraLinked     AuditAction = "idea.jira_linked"
+	AuditIdeaJiraUnlinked   AuditAction = "idea.jira_unlinked"
+	AuditIdeaLinearLinked   AuditAction = "idea.linear_linked"
+	AuditIdeaLinearUnlinked AuditAction = "idea.linear_unlinked"
+	AuditIdeaDeleted        AuditAction = "idea.deleted"
+	AuditMemberAdded        AuditAction = "member.added"
+	AuditMemberUpdated      AuditAction = "member.updated"
+	AuditMemberRemoved      AuditAction = "member.removed"
+)
+
+// IsValidAuditAction checks if an audit action is valid
+func IsValidAuditAction(action string) bool {
+	validActions := []AuditAction{
+		AuditBoardCreated, AuditBoardUpdated, AuditBoardDeleted, AuditBoardModerated,
+		AuditIdeaCreated, AuditIdeaUpdated, AuditIdeaMoved, AuditIdeaStatusChanged,
+		AuditIdeaPollUpdated, AuditIdeaPollDeleted, AuditIdeaJiraLinked, AuditIdeaJiraUnlinked,
+		AuditIdeaLinearLinked, AuditIdeaLinearUnlinked, AuditIdeaDeleted,
+		AuditMemberAdded, AuditMemberUpdated, AuditMemberRemoved,
+	}
+
+	for _, valid := range validActions {
+		if action == string(valid) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultAuditRetentionDays is how long audit entries are kept when AUDIT_RETENTION_DAYS is unset
+const DefaultAuditRetentionDays = 365
+
+// AuditRetentionDays returns how many days audit entries are kept (AUDIT_RETENTION_DAYS); 0 keeps them forever
+func AuditRetentionDays() int {
+	value := os.Getenv("AUDIT_RETENTION_DAYS")
+	if value == "" {
+		return DefaultAuditRetentionDays
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return DefaultAuditRetentionDays
+	}
+	return days
+}
@@ -1,21 +1,50 @@
 package models
 
 import (
+	"net/netip"
+	"strings"
 	"time"
 )
 
 // Board represents a board document in MongoDB
 type Board struct {
-	ID             string    `bson:"_id,omitempty" json:"id"`
-	Name           string    `bson:"name" json:"name" validate:"required,min=1,max=100"`
-	Description    string    `bson:"description,omitempty" json:"description,omitempty" validate:"max=500"`
-	PublicLink     string    `bson:"public_link" json:"publicLink" validate:"required"`
-	IsPublic       bool      `bson:"is_public" json:"isPublic"`
-	UserID         string    `bson:"user_id" json:"userId" validate:"required"`
-	VisibleColumns []string  `bson:"visible_columns" json:"visibleColumns"`
-	VisibleFields  []string  `bson:"visible_fields" json:"visibleFields"`
-	CreatedAt      time.Time `bson:"created_at" json:"createdAt"`
-	UpdatedAt      time.Time `bson:"updated_at" json:"updatedAt"`
+	ID                string             `bson:"_id,omitempty" json:"id"`
+	Name              string             `bson:"name" json:"name" validate:"required,min=1,max=100"`
+	Description       string             `bson:"description,omitempty" json:"description,omitempty" validate:"max=500"`
+	PublicLink        string             `bson:"public_link" json:"publicLink" validate:"required"`
+	IsPublic          bool               `bson:"is_public" json:"isPublic"`
+	UserID            string             `bson:"user_id" json:"userId" validate:"required"`
+	VisibleColumns    []string           `bson:"visible_columns" json:"visibleColumns"`
+	VisibleFields     []string           `bson:"visible_fields" json:"visibleFields"`
+	PublicRiceScore   bool               `bson:"public_rice_score" json:"publicRiceScore"` // Also requires the riceScore visible field
+	Archived          bool               `bson:"archived" json:"archived"`
+	Frozen            bool               `bson:"frozen" json:"frozen"`                                             // Blocks idea changes; reads and feedback still work
+	StrictPrivacy     bool               `bson:"strict_privacy" json:"strictPrivacy"`                              // No per-visitor identifiers on public endpoints
+	Reactions         []string           `bson:"reactions,omitempty" json:"reactions,omitempty"`                   // Empty uses the default reaction set
+	AcceptsIdeas      bool               `bson:"accepts_ideas" json:"acceptsIdeas"`                                // Public visitors may suggest ideas for moderation
+	VoteOptions       []string           `bson:"vote_options,omitempty" json:"voteOptions,omitempty"`              // Empty disables vote options
+	ModerateComments  bool               `bson:"moderate_comments" json:"moderateComments"`                        // Hold public comments until the owner approves them
+	CaptchaProvider   string             `bson:"captcha_provider,omitempty" json:"captchaProvider,omitempty"`      // Empty disables CAPTCHA on public writes
+	FeedbackRateLimit *FeedbackRateLimit `bson:"feedback_rate_limit,omitempty" json:"feedbackRateLimit,omitempty"` // Nil uses the server defaults
+	WorkspaceID       string             `bson:"workspace_id,omitempty" json:"workspaceId,omitempty"`              // Clerk organization whose members share the board
+	SeedRun           string             `bson:"seed_run,omitempty" json:"-"`                                      // Synthetic data run or "fixtures" that created the board, for load tests
+	IPRules           *BoardIPRules      `bson:"ip_rules,omitempty" json:"-"`                                      // Public access restrictions; managed via the IP rules API
+	Members           []BoardMember      `bson:"members,omitempty" json:"-"`                                       // Collaborators besides the owner; listed via the members API
+	LogoKey           string             `bson:"logo_key,omitempty" json:"-"`                                      // Storage key of the uploaded logo; served via logo URLs
+	CreatedBy         string             `bson:"created_by,omitempty" json:"createdBy,omitempty"`                  // User who created the board
+	UpdatedBy         string             `bson:"updated_by,omitempty" json:"updatedBy,omitempty"`                  // User behind the last settings or member change
+	CreatedAt         time.Time          `bson:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updatedAt"`
+	Version           int64              `bson:"version" json:"version"`        // Bumped by every settings edit; see BumpVersion
+	IdeaMoves         int64              `bson:"idea_moves,omitempty" json:"-"` // Bumped by every idea move so concurrent moves on the board conflict; see MoveIdea
+}
+
+// BoardStats holds aggregated counts for a board
+type BoardStats struct {
+	IdeasByColumn  map[string]int `json:"ideasByColumn"`
+	TotalIdeas     int            `json:"totalIdeas"`
+	TotalFeedback  int            `json:"totalFeedback"` // Thumbs up plus emoji reactions
+	LastActivityAt time.Time      `json:"lastActivityAt"`
 }
 
 // ColumnType represents the different columns available in a board
@@ -38,6 +67,7 @@ const (
 	FieldDescription    IdeaField = "description"
 	FieldValueStatement IdeaField = "valueStatement"
 	FieldRiceScore      IdeaField = "riceScore"
+	FieldTargetDate     IdeaField = "targetDate" // Not shown by default
 )
 
 // GetDefaultVisibleColumns returns the default visible columns for a new board
@@ -62,6 +92,82 @@ func GetDefaultVisibleFields() []string {
 	}
 }
 
+// ShowsRiceScorePublicly reports whether RICE scores may be included in public responses
+func (b *Board) ShowsRiceScorePublicly() bool {
+	if !b.PublicRiceScore {
+		return false
+	}
+	for _, field := range b.VisibleFields {
+		if field == string(FieldRiceScore) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxBoardReactions caps how many reactions a board may offer
+const MaxBoardReactions = 20
+
+// GetDefaultReactions returns the reaction set offered when a board has not configured its own
+func GetDefaultReactions() []string {
+	return []string{"🚀", "💡", "🎯", "🔥", "👍", "❤️", "😊", "🎉", "⭐", "💪"}
+}
+
+// AllowedReactions returns the board's configured reactions, falling back to the defaults
+func (b *Board) AllowedReactions() []string {
+	if len(b.Reactions) == 0 {
+		return GetDefaultReactions()
+	}
+	return b.Reactions
+}
+
+// AllowsReaction checks if a reaction is in the board's allowed set
+func (b *Board) AllowsReaction(reaction string) bool {
+	for _, allowed := range b.AllowedReactions() {
+		if reaction == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxBoardVoteOptions caps how many vote options a board may offer
+const MaxBoardVoteOptions = 5
+
+// MaxVoteOptionLength caps the length of a vote option label
+const MaxVoteOptionLength = 30
+
+// HasVoteOption reports whether option is one of the board's configured vote options
+func (b *Board) HasVoteOption(option string) bool {
+	for _, configured := range b.VoteOptions {
+		if option == configured {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidVoteOption checks that a vote option label is non-empty, short and usable as a document key
+func IsValidVoteOption(option string) bool {
+	if strings.TrimSpace(option) == "" || len(option) > MaxVoteOptionLength {
+		return false
+	}
+	return !strings.ContainsAny(option, ".$")
+}
+
+// IsValidReaction checks that a reaction is a short, non-ASCII symbol such as an emoji
+func IsValidReaction(reaction string) bool {
+	if len(reaction) == 0 || len(reaction) > 16 {
+		return false
+	}
+	for _, r := range reaction {
+		if r < 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
 // IsValidColumn checks if a column type is valid
 func IsValidColumn(column string) bool {
 	validColumns := []string{
@@ -88,6 +194,7 @@ func IsValidField(field string) bool {
 		string(FieldDescription),
 		string(FieldValueStatement),
 		string(FieldRiceScore),
+		string(FieldTargetDate),
 	}
 
 	for _, valid := range validFields {
@@ -97,3 +204,104 @@ func IsValidField(field string) bool {
 	}
 	return false
 }
+
+// FeedbackRateLimit is a board's abuse protection policy for public thumbs up and emoji reactions
+type FeedbackRateLimit struct {
+	WindowSeconds int    `bson:"window_seconds" json:"windowSeconds"` // Length of the window
+	Burst         int    `bson:"burst" json:"burst"`                  // Actions allowed per visitor within a window
+	Scope         string `bson:"scope" json:"scope"`                  // "idea" counts per idea, "board" across the whole board
+}
+
+// BoardIPRules restricts which client addresses may use a public board. Entries are CIDR ranges;
+// deny entries win over allow entries, and a non-empty allow list admits only the listed ranges.
+type BoardIPRules struct {
+	Allow []string `bson:"allow" json:"allow"`
+	Deny  []string `bson:"deny" json:"deny"`
+}
+
+// MaxBoardIPRules caps the entries of each board IP list
+const MaxBoardIPRules = 100
+
+// Permits reports whether a client address may access the board. Unparseable addresses are only
+// permitted when no allow list is set.
+func (r *BoardIPRules) Permits(ip string) bool {
+	if r == nil {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return len(r.Allow) == 0
+	}
+	addr = addr.Unmap()
+	if matchesIPRule(r.Deny, addr) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchesIPRule(r.Allow, addr)
+}
+
+// matchesIPRule reports whether an address is in any of the CIDR ranges
+func matchesIPRule(rules []string, addr netip.Addr) bool {
+	for _, rule := range rules {
+		if prefix, err := netip.ParsePrefix(rule); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeIPRule parses an IP address or CIDR range into canonical CIDR form; a single address
+// becomes a /32 or /128 range
+func NormalizeIPRule(rule string) (string, bool) {
+	rule = strings.TrimSpace(rule)
+	if prefix, err := netip.ParsePrefix(rule); err == nil {
+		if prefix.Addr().Is4In6() {
+			if prefix.Bits() < 96 {
+				return "", false
+			}
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked().String(), true
+	}
+	addr, err := netip.ParseAddr(rule)
+	if err != nil || addr.Zone() != "" {
+		return "", false
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()).String(), true
+}
+
+// RateLimitScope selects what a feedback rate limit is counted against
+type RateLimitScope string
+
+const (
+	RateLimitScopeIdea  RateLimitScope = "idea"
+	RateLimitScopeBoard RateLimitScope = "board"
+)
+
+// Bounds for board feedback rate limits
+const (
+	MaxRateLimitWindowSeconds = 3600
+	MaxRateLimitBurst         = 100
+)
+
+// IsValidRateLimitScope checks if a rate limit scope is valid
+func IsValidRateLimitScope(scope string) bool {
+	switch RateLimitScope(scope) {
+	case RateLimitScopeIdea, RateLimitScopeBoard:
+		return true
+	}
+	return false
+}
+
+// FeedbackPolicy returns the board's feedback rate limit, or one action per idea every
+// defaultSeconds when the board has not configured its own
+func (b *Board) FeedbackPolicy(defaultSeconds int) FeedbackRateLimit {
+	if b.FeedbackRateLimit == nil {
+		return FeedbackRateLimit{
+			WindowSeconds: defaultSeconds,
+			Burst:         1,
+			Scope:         string(RateLimitScopeIdea),
+		}
+	}
+	return *b.FeedbackRateLimit
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// defaultBoardCacheTTL is how long a loaded board is reused unless BOARD_CACHE_TTL_SECONDS says otherwise
+const defaultBoardCacheTTL = 15 * time.Second
+
+// cachedBoard is a board document kept by FindBoardByID
+type cachedBoard struct {
+	raw       bson.Raw // Decoded on every hit so callers never share a Board
+	tenantID  string   // Only served to the tenant it was loaded for
+	expiresAt time.Time
+}
+
+var (
+	boardCacheMu    sync.RWMutex
+	boardCache      = map[string]cachedBoard{}
+	boardCacheOnce  sync.Once
+	boardCacheTTL   time.Duration
+	boardCacheSwept time.Time
+	boardCacheGen   uint64 // Bumped by every invalidation
+)
+
+// loadBoardCacheTTL reads BOARD_CACHE_TTL_SECONDS once; 0 turns the cache off
+func loadBoardCacheTTL() time.Duration {
+	boardCacheOnce.Do(func() {
+		boardCacheTTL = defaultBoardCacheTTL
+		seconds, ok, err := mongoEnvInt("BOARD_CACHE_TTL_SECONDS")
+		if err != nil {
+			log.Printf("Board cache: %v, using %v", err, boardCacheTTL)
+			return
+		}
+		if ok {
+			boardCacheTTL = time.Duration(seconds) * time.Second
+		}
+	})
+	return boardCacheTTL
+}
+
+// FindBoardByID loads a board by ID, reusing a copy loaded in the last few seconds. Access checks
+// and idea operations load the same board over and over; every write to a board must call
+// InvalidateBoard so this instance never serves it stale. Other instances may for at most
+// BOARD_CACHE_TTL_SECONDS. Returns mongo.ErrNoDocuments if there is no such board.
+func FindBoardByID(ctx context.Context, boardID string) (*Board, error) {
+	ttl := loadBoardCacheTTL()
+	now := time.Now()
+	tenantID := TenantFromContext(ctx)
+	var generation uint64
+
+	if ttl > 0 {
+		boardCacheMu.RLock()
+		entry, ok := boardCache[boardID]
+		generation = boardCacheGen
+		boardCacheMu.RUnlock()
+		if ok && entry.tenantID == tenantID && now.Before(entry.expiresAt) {
+			var board Board
+			if err := bson.Unmarshal(entry.raw, &board); err == nil {
+				return &board, nil
+			}
+		}
+	}
+
+	raw, err := GetCollection(ctx, BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var board Board
+	if err := bson.Unmarshal(raw, &board); err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		boardCacheMu.Lock()
+		defer boardCacheMu.Unlock()
+		// A board invalidated while it was loading may be the old version
+		if boardCacheGen != generation {
+			return &board, nil
+		}
+		// Drop expired boards now and then so boards nobody opens again do not pile up
+		if now.Sub(boardCacheSwept) > time.Minute {
+			for id, entry := range boardCache {
+				if !now.Before(entry.expiresAt) {
+					delete(boardCache, id)
+				}
+			}
+			boardCacheSwept = now
+		}
+		boardCache[boardID] = cachedBoard{raw: raw, tenantID: tenantID, expiresAt: now.Add(ttl)}
+	}
+	return &board, nil
+}
+
+// InvalidateBoard drops a board from the cache after it was updated or deleted
+func InvalidateBoard(boardID string) {
+	boardCacheMu.Lock()
+	delete(boardCache, boardID)
+	boardCacheGen++
+	boardCacheMu.Unlock()
+}
+
+// InvalidateBoards empties the cache after a write to boards that are not known by ID
+func InvalidateBoards() {
+	boardCacheMu.Lock()
+	boardCache = map[string]cachedBoard{}
+	boardCacheGen++
+	boardCacheMu.Unlock()
+}
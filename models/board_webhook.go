@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// BoardWebhook is an outgoing webhook a board owner registered to receive the board's events
+type BoardWebhook struct {
+	ID        string             `bson:"_id,omitempty" json:"id"`
+	BoardID   string             `bson:"board_id" json:"boardId" validate:"required"`
+	UserID    string             `bson:"user_id" json:"userId" validate:"required"` // Owner who registered it
+	URL       string             `bson:"url" json:"url" validate:"required"`
+	Secret    string             `bson:"secret" json:"-"`      // Shared with the receiver; shown when created or rotated
+	Events    []WebhookEventType `bson:"events" json:"events"` // Catalog event types; empty subscribes to every event
+	Enabled   bool               `bson:"enabled" json:"enabled"`
+	CreatedAt time.Time          `bson:"created_at" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updatedAt"`
+}
+
+// MaxWebhooksPerBoard caps how many outgoing webhooks a board may have
+const MaxWebhooksPerBoard = 10
+
+// Subscribed reports whether the webhook receives an event type. Subscriptions saved with the
+// notification event names used before the catalog match their catalog types.
+func (w *BoardWebhook) Subscribed(event WebhookEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed.Canonical() == event {
+			return true
+		}
+	}
+	return false
+}
+
+// FindEnabledBoardWebhooks returns a board's enabled webhooks
+func FindEnabledBoardWebhooks(ctx context.Context, boardID string) ([]BoardWebhook, error) {
+	cursor, err := GetCollection(ctx, BoardWebhooksCollection).Find(ctx, bson.M{"board_id": boardID, "enabled": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []BoardWebhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
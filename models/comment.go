@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Comment is a public visitor's comment on an idea
+type Comment struct {
+	ID         string    `bson:"_id,omitempty" json:"id"`
+	BoardID    string    `bson:"board_id" json:"boardId" validate:"required"`
+	IdeaID     string    `bson:"idea_id" json:"ideaId" validate:"required"`
+	AuthorName string    `bson:"author_name,omitempty" json:"authorName,omitempty" validate:"max=50"`
+	Body       string    `bson:"body" json:"body" validate:"required,min=1,max=1000"`
+	VisitorKey string    `bson:"visitor_key,omitempty" json:"-"` // Rate limiting / abuse tracing only
+	Status     string    `bson:"status" json:"status"`
+	CreatedAt  time.Time `bson:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `bson:"updated_at" json:"updatedAt"`
+}
+
+// CommentStatus represents the moderation state of a comment
+type CommentStatus string
+
+const (
+	CommentPending  CommentStatus = "pending"
+	CommentApproved CommentStatus = "approved"
+)
+
+// IsValidCommentStatus checks if a comment status is valid
+func IsValidCommentStatus(status string) bool {
+	switch CommentStatus(status) {
+	case CommentPending, CommentApproved:
+		return true
+	}
+	return false
+}
@@ -2,14 +2,17 @@ package models
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
 )
 
 // Database holds the MongoDB client and database instance
@@ -28,13 +31,20 @@ func ConnectDatabase() error {
 		return fmt.Errorf("MONGODB_URI environment variable is not set")
 	}
 
+	if err := loadTenancyMode(); err != nil {
+		return err
+	}
+
 	dbName := os.Getenv("MONGODB_DATABASE")
 	if dbName == "" {
 		dbName = "disko" // default database name
 	}
 
 	// Set client options
-	clientOptions := options.Client().ApplyURI(mongoURI)
+	clientOptions, err := mongoClientOptions(mongoURI)
+	if err != nil {
+		return err
+	}
 
 	// Set connection timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -47,8 +57,7 @@ func ConnectDatabase() error {
 	}
 
 	// Test the connection
-	err = client.Ping(ctx, nil)
-	if err != nil {
+	if err := client.Ping(ctx, nil); err != nil {
 		return fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
@@ -60,14 +69,125 @@ func ConnectDatabase() error {
 
 	log.Printf("Successfully connected to MongoDB database: %s", dbName)
 
+	// Update documents from earlier schemas before indexing them
+	if err := runMigrations(context.Background()); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	// Set up indexes
-	if err := setupIndexes(); err != nil {
+	if err := setupIndexes(context.Background()); err != nil {
 		return fmt.Errorf("failed to setup database indexes: %w", err)
 	}
 
+	// Reject malformed boards and ideas at the database
+	setupValidators(context.Background())
+
+	// Bring the collections of isolated workspaces up to date as well
+	prepareKnownTenants()
+
 	return nil
 }
 
+// Client defaults, which options in MONGODB_URI and the MONGODB_* variables override
+const (
+	defaultMongoMaxPoolSize            = 100
+	defaultMongoConnectTimeout         = 10 * time.Second
+	defaultMongoServerSelectionTimeout = 10 * time.Second
+	defaultMongoMaxConnIdleTime        = 5 * time.Minute
+)
+
+// mongoClientOptions builds the client options from the URI and the tuning variables, each optional:
+// MONGODB_MAX_POOL_SIZE, MONGODB_MIN_POOL_SIZE, MONGODB_MAX_CONN_IDLE_SECONDS,
+// MONGODB_CONNECT_TIMEOUT_SECONDS, MONGODB_SERVER_SELECTION_TIMEOUT_SECONDS, MONGODB_TIMEOUT_SECONDS
+// (per operation, unlimited by default), MONGODB_RETRY_WRITES, MONGODB_RETRY_READS and
+// MONGODB_READ_PREFERENCE (primary, primaryPreferred, secondary, secondaryPreferred or nearest).
+// Atlas connection strings usually carry their own options; the variables suit self-hosted setups.
+func mongoClientOptions(mongoURI string) (*options.ClientOptions, error) {
+	clientOptions := options.Client().
+		SetMaxPoolSize(defaultMongoMaxPoolSize).
+		SetConnectTimeout(defaultMongoConnectTimeout).
+		SetServerSelectionTimeout(defaultMongoServerSelectionTimeout).
+		SetMaxConnIdleTime(defaultMongoMaxConnIdleTime).
+		SetRetryWrites(true).
+		SetRetryReads(true).
+		ApplyURI(mongoURI)
+
+	if value, ok, err := mongoEnvInt("MONGODB_MAX_POOL_SIZE"); err != nil {
+		return nil, err
+	} else if ok {
+		clientOptions.SetMaxPoolSize(uint64(value))
+	}
+	if value, ok, err := mongoEnvInt("MONGODB_MIN_POOL_SIZE"); err != nil {
+		return nil, err
+	} else if ok {
+		clientOptions.SetMinPoolSize(uint64(value))
+	}
+	if value, ok, err := mongoEnvInt("MONGODB_MAX_CONN_IDLE_SECONDS"); err != nil {
+		return nil, err
+	} else if ok {
+		clientOptions.SetMaxConnIdleTime(time.Duration(value) * time.Second)
+	}
+	if value, ok, err := mongoEnvInt("MONGODB_CONNECT_TIMEOUT_SECONDS"); err != nil {
+		return nil, err
+	} else if ok {
+		clientOptions.SetConnectTimeout(time.Duration(value) * time.Second)
+	}
+	if value, ok, err := mongoEnvInt("MONGODB_SERVER_SELECTION_TIMEOUT_SECONDS"); err != nil {
+		return nil, err
+	} else if ok {
+		clientOptions.SetServerSelectionTimeout(time.Duration(value) * time.Second)
+	}
+	if value, ok, err := mongoEnvInt("MONGODB_TIMEOUT_SECONDS"); err != nil {
+		return nil, err
+	} else if ok && value > 0 {
+		clientOptions.SetTimeout(time.Duration(value) * time.Second)
+	}
+
+	if value := os.Getenv("MONGODB_RETRY_WRITES"); value != "" {
+		retry, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGODB_RETRY_WRITES %q: must be true or false", value)
+		}
+		clientOptions.SetRetryWrites(retry)
+	}
+	if value := os.Getenv("MONGODB_RETRY_READS"); value != "" {
+		retry, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGODB_RETRY_READS %q: must be true or false", value)
+		}
+		clientOptions.SetRetryReads(retry)
+	}
+	if value := os.Getenv("MONGODB_READ_PREFERENCE"); value != "" {
+		mode, err := readpref.ModeFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGODB_READ_PREFERENCE %q: %w", value, err)
+		}
+		preference, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGODB_READ_PREFERENCE %q: %w", value, err)
+		}
+		clientOptions.SetReadPreference(preference)
+	}
+
+	if err := clientOptions.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid MongoDB client options: %w", err)
+	}
+	return clientOptions, nil
+}
+
+// mongoEnvInt reads a non-negative integer tuning variable, reporting whether it was set
+func mongoEnvInt(envVar string) (int, bool, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return 0, false, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, false, fmt.Errorf("invalid %s %q: must be a non-negative integer", envVar, value)
+	}
+	return parsed, true, nil
+}
+
 // DisconnectDatabase closes the MongoDB connection
 func DisconnectDatabase() error {
 	if DB == nil || DB.Client == nil {
@@ -86,27 +206,63 @@ func DisconnectDatabase() error {
 	return nil
 }
 
-// GetCollection returns a MongoDB collection
-func GetCollection(collectionName string) *mongo.Collection {
+// GetCollection returns a MongoDB collection of the workspace ctx is scoped to with WithTenant,
+// or of the main database with tenancy off
+func GetCollection(ctx context.Context, collectionName string) *mongo.Collection {
 	if DB == nil || DB.DB == nil {
 		log.Fatal("Database not initialized. Call ConnectDatabase() first.")
 	}
-	return DB.DB.Collection(collectionName)
+	return tenantCollection(TenantFromContext(ctx), collectionName)
 }
 
 // Collection names constants
 const (
-	BoardsCollection = "boards"
-	IdeasCollection  = "ideas"
+	BoardsCollection              = "boards"
+	IdeasCollection               = "ideas"
+	ActivitiesCollection          = "activities"
+	TemplatesCollection           = "board_templates"
+	ExportConfigsCollection       = "export_configs"
+	EmbedTokensCollection         = "embed_tokens"
+	ReleasesCollection            = "releases"
+	SubmissionsCollection         = "idea_submissions"
+	CommentsCollection            = "comments"
+	VotesCollection               = "votes"
+	SubscribersCollection         = "subscribers"
+	APITokensCollection           = "api_tokens"
+	InvitationsCollection         = "board_invitations"
+	WorkspacesCollection          = "workspaces"
+	PersonalTokensCollection      = "personal_access_tokens"
+	ServiceAccountsCollection     = "service_accounts"
+	AuditLogCollection            = "audit_log"
+	UsersCollection               = "users"
+	ImpersonationsCollection      = "impersonations"
+	NotificationPrefsCollection   = "notification_preferences"
+	BoardWebhooksCollection       = "board_webhooks"
+	WebhookDeliveriesCollection   = "webhook_deliveries"
+	NotificationJobsCollection    = "notification_jobs"
+	UserNotificationsCollection   = "user_notifications"
+	PushSubscriptionsCollection   = "push_subscriptions"
+	NotificationBatchesCollection = "notification_batches"
+	TelegramConfigsCollection     = "telegram_configs"
+	AlertRulesCollection          = "alert_rules"
+	AlertFiringsCollection        = "alert_firings"
+	JiraConfigsCollection         = "jira_configs"
+	LinearConfigsCollection       = "linear_configs"
+	ScheduledJobsCollection       = "scheduled_jobs"
+	PublicBoardsCollection        = "public_boards"
+	AttachmentsCollection         = "attachments"
+	ScoreHistoryCollection        = "score_history"
+	TenantsCollection             = "tenants"
 )
 
-// setupIndexes creates the necessary indexes for performance optimization
-func setupIndexes() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// setupIndexes creates the necessary indexes for performance optimization on the collections of the
+// tenant of ctx
+func setupIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Boards collection indexes
-	boardsCollection := GetCollection(BoardsCollection)
+	boardsCollection := GetCollection(ctx, BoardsCollection)
 
 	// Index on user_id for efficient board queries by user
 	_, err := boardsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -129,8 +285,29 @@ func setupIndexes() error {
 		return fmt.Errorf("failed to create public_link index on boards: %w", err)
 	}
 
+	// Multikey index on members.user_id for listing the boards a user collaborates on
+	_, err = boardsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "members.user_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create members.user_id index on boards: %w", err)
+	}
+
+	// Index on workspace_id for workspace-scoped board listing and plan limits
+	_, err = boardsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "workspace_id", Value: 1},
+		},
+		Options: options.Index().SetSparse(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create workspace_id index on boards: %w", err)
+	}
+
 	// Ideas collection indexes
-	ideasCollection := GetCollection(IdeasCollection)
+	ideasCollection := GetCollection(ctx, IdeasCollection)
 
 	// Compound index on board_id and position for efficient idea ordering
 	_, err = ideasCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -165,6 +342,19 @@ func setupIndexes() error {
 		return fmt.Errorf("failed to create board_id_status index on ideas: %w", err)
 	}
 
+	// Compound index matching the board order that idea listings page through
+	_, err = ideasCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "column", Value: 1},
+			{Key: "position", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_order index on ideas: %w", err)
+	}
+
 	// Text index for search functionality
 	_, err = ideasCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys: bson.D{
@@ -177,10 +367,652 @@ func setupIndexes() error {
 		return fmt.Errorf("failed to create text search index on ideas: %w", err)
 	}
 
+	// Activities collection indexes
+	activitiesCollection := GetCollection(ctx, ActivitiesCollection)
+
+	// Compound index on board_id and created_at for the paginated activity feed
+	_, err = activitiesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_created_at index on activities: %w", err)
+	}
+
+	// Templates collection indexes
+	templatesCollection := GetCollection(ctx, TemplatesCollection)
+
+	// Compound index on is_published, category and install_count for gallery browsing
+	_, err = templatesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "is_published", Value: 1},
+			{Key: "category", Value: 1},
+			{Key: "install_count", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create gallery index on board_templates: %w", err)
+	}
+
+	// Export configs collection indexes
+	exportConfigsCollection := GetCollection(ctx, ExportConfigsCollection)
+
+	// Unique index on board_id so each board has at most one export config
+	_, err = exportConfigsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on export_configs: %w", err)
+	}
+
+	// Compound index on enabled and next_run_at for the export scheduler
+	_, err = exportConfigsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "enabled", Value: 1},
+			{Key: "next_run_at", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create enabled_next_run_at index on export_configs: %w", err)
+	}
+
+	// Embed tokens collection indexes
+	embedTokensCollection := GetCollection(ctx, EmbedTokensCollection)
+
+	// Index on board_id for listing a board's embed tokens
+	_, err = embedTokensCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on embed_tokens: %w", err)
+	}
+
+	// Releases collection indexes
+	releasesCollection := GetCollection(ctx, ReleasesCollection)
+
+	// Compound index on board_id and date for listing a board's releases newest first
+	_, err = releasesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "date", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_date index on releases: %w", err)
+	}
+
+	// Idea submissions collection indexes
+	submissionsCollection := GetCollection(ctx, SubmissionsCollection)
+
+	// Compound index for the moderation queue: a board's submissions by status, newest first
+	_, err = submissionsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_status_created_at index on idea_submissions: %w", err)
+	}
+
+	// Comments collection indexes
+	commentsCollection := GetCollection(ctx, CommentsCollection)
+
+	// Compound index for an idea's approved comments in order
+	_, err = commentsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "idea_id", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create idea_id_status_created_at index on comments: %w", err)
+	}
+
+	// Compound index for the board moderation queue
+	_, err = commentsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_status_created_at index on comments: %w", err)
+	}
+
+	// Votes collection indexes
+	votesCollection := GetCollection(ctx, VotesCollection)
+
+	// Unique index so each visitor casts a given vote on an idea at most once
+	_, err = votesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "idea_id", Value: 1},
+			{Key: "visitor_id", Value: 1},
+			{Key: "type", Value: 1},
+			{Key: "emoji", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique idea_visitor_type index on votes: %w", err)
+	}
+
+	// Compound index for looking up a visitor's votes on a board
+	_, err = votesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "visitor_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_visitor_id index on votes: %w", err)
+	}
+
+	// Compound index for listing a board's feedback notes newest first
+	_, err = votesCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_created_at index on votes: %w", err)
+	}
+
+	// Subscribers collection indexes
+	subscribersCollection := GetCollection(ctx, SubscribersCollection)
+
+	// Unique index so an email subscribes to a board at most once
+	_, err = subscribersCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "email", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique board_id_email index on subscribers: %w", err)
+	}
+
+	// Sparse index for confirmation links (the token is cleared once confirmed)
+	_, err = subscribersCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "confirm_token", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create confirm_token index on subscribers: %w", err)
+	}
+
+	// Index for unsubscribe links
+	_, err = subscribersCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "unsubscribe_token", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unsubscribe_token index on subscribers: %w", err)
+	}
+
+	// API tokens collection indexes
+	apiTokensCollection := GetCollection(ctx, APITokensCollection)
+
+	// Unique index on token_hash for authenticating API requests
+	_, err = apiTokensCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "token_hash", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique token_hash index on api_tokens: %w", err)
+	}
+
+	// Index on board_id for listing a board's API tokens
+	_, err = apiTokensCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on api_tokens: %w", err)
+	}
+
+	// Personal access tokens collection indexes
+	personalTokensCollection := GetCollection(ctx, PersonalTokensCollection)
+
+	// Unique index on token_hash for authenticating API requests
+	_, err = personalTokensCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "token_hash", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique token_hash index on personal_access_tokens: %w", err)
+	}
+
+	// Index on user_id for listing a user's tokens
+	_, err = personalTokensCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user_id index on personal_access_tokens: %w", err)
+	}
+
+	// Service accounts collection indexes
+	serviceAccountsCollection := GetCollection(ctx, ServiceAccountsCollection)
+
+	// Unique index on token_hash for authenticating integration requests
+	_, err = serviceAccountsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "token_hash", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique token_hash index on service_accounts: %w", err)
+	}
+
+	// Index on user_id for listing a user's service accounts
+	_, err = serviceAccountsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user_id index on service_accounts: %w", err)
+	}
+
+	// Audit log collection indexes
+	auditCollection := GetCollection(ctx, AuditLogCollection)
+
+	// Compound index on board_id and created_at for a board's audit trail
+	_, err = auditCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_created_at index on audit_log: %w", err)
+	}
+
+	// Compound index on actor_id and created_at for tracing one user's changes
+	_, err = auditCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "actor_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create actor_id_created_at index on audit_log: %w", err)
+	}
+
+	if err := setupAuditRetention(ctx, auditCollection); err != nil {
+		return err
+	}
+
+	// Impersonations collection indexes
+	impersonationsCollection := GetCollection(ctx, ImpersonationsCollection)
+
+	// Indexes on admin_id and user_id for reviewing who impersonated whom
+	for _, field := range []string{"admin_id", "user_id"} {
+		_, err = impersonationsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: field, Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create %s_created_at index on impersonations: %w", field, err)
+		}
+	}
+
+	// Board invitations collection indexes
+	invitationsCollection := GetCollection(ctx, InvitationsCollection)
+
+	// Unique index on token for accepting invitations from the emailed link
+	_, err = invitationsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "token", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique token index on board_invitations: %w", err)
+	}
+
+	// Compound index for listing a board's invitations newest first
+	_, err = invitationsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_created_at index on board_invitations: %w", err)
+	}
+
+	// Notification preferences collection indexes
+
+	// Unique index on user_id and board_id: one preference per user and board
+	_, err = GetCollection(ctx, NotificationPrefsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "board_id", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique user_id_board_id index on notification_preferences: %w", err)
+	}
+
+	// Index on board_id for finding the preferences of a board's recipients
+	_, err = GetCollection(ctx, NotificationPrefsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on notification_preferences: %w", err)
+	}
+
+	// Index on board_id for listing a board's outgoing webhooks
+	_, err = GetCollection(ctx, BoardWebhooksCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on board_webhooks: %w", err)
+	}
+
+	// Webhook deliveries collection indexes
+
+	// Compound index for listing a webhook's deliveries newest first
+	_, err = GetCollection(ctx, WebhookDeliveriesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "webhook_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook_id_created_at index on webhook_deliveries: %w", err)
+	}
+
+	// Index on board_id for deleting a board's deliveries
+	_, err = GetCollection(ctx, WebhookDeliveriesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on webhook_deliveries: %w", err)
+	}
+
+	// TTL index expiring deliveries after WebhookDeliveryRetention
+	_, err = GetCollection(ctx, WebhookDeliveriesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(WebhookDeliveryRetention.Seconds())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create retention index on webhook_deliveries: %w", err)
+	}
+
+	// Notification jobs collection indexes
+	jobsCollection := GetCollection(ctx, NotificationJobsCollection)
+
+	// Unique index on idempotency_key: a delivery is queued once
+	_, err = jobsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "idempotency_key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique idempotency_key index on notification_jobs: %w", err)
+	}
+
+	// Compound index for claiming due jobs
+	_, err = jobsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "next_attempt_at", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create status_next_attempt_at index on notification_jobs: %w", err)
+	}
+
+	// Index on board_id for deleting a board's jobs
+	_, err = jobsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "board_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on notification_jobs: %w", err)
+	}
+
+	// TTL index expiring completed jobs; pending and dead jobs have no completed_at and are kept
+	_, err = jobsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "completed_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(NotificationJobRetention.Seconds())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create retention index on notification_jobs: %w", err)
+	}
+
+	// User notifications collection indexes
+	userNotifications := GetCollection(ctx, UserNotificationsCollection)
+
+	// Compound index for listing a user's notifications newest first
+	_, err = userNotifications.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user_id_created_at index on user_notifications: %w", err)
+	}
+
+	// Compound index for counting a user's unread notifications
+	_, err = userNotifications.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "read", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user_id_read index on user_notifications: %w", err)
+	}
+
+	// Index on board_id for deleting a board's notifications
+	_, err = userNotifications.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "board_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on user_notifications: %w", err)
+	}
+
+	// TTL index expiring notifications after UserNotificationRetention
+	_, err = userNotifications.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(UserNotificationRetention.Seconds())),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create retention index on user_notifications: %w", err)
+	}
+
+	pushSubscriptions := GetCollection(ctx, PushSubscriptionsCollection)
+
+	// Unique index on endpoint: a browser subscribes once, for the user last signed in on it
+	_, err = pushSubscriptions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "endpoint", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create unique endpoint index on push_subscriptions: %w", err)
+	}
+
+	// Index on user_id for finding a user's browsers
+	_, err = pushSubscriptions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user_id index on push_subscriptions: %w", err)
+	}
+
+	notificationBatches := GetCollection(ctx, NotificationBatchesCollection)
+
+	// Index on flush_at for finding batches whose window ended
+	_, err = notificationBatches.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "flush_at", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create flush_at index on notification_batches: %w", err)
+	}
+
+	// Index on board_id for deleting a board's batches
+	_, err = notificationBatches.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "board_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on notification_batches: %w", err)
+	}
+
+	// Unique index on board_id so each board has at most one Telegram chat
+	_, err = GetCollection(ctx, TelegramConfigsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "board_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on telegram_configs: %w", err)
+	}
+
+	// Compound index for evaluating a board's rules and listing a user's rules
+	_, err = GetCollection(ctx, AlertRulesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "board_id", Value: 1},
+			{Key: "user_id", Value: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id_user_id index on alert_rules: %w", err)
+	}
+
+	alertFirings := GetCollection(ctx, AlertFiringsCollection)
+
+	// Index on rule_id for deleting a rule's firings
+	_, err = alertFirings.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "rule_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rule_id index on alert_firings: %w", err)
+	}
+
+	// Index on board_id for deleting a board's firings
+	_, err = alertFirings.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "board_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on alert_firings: %w", err)
+	}
+
+	// Unique index on board_id so each board has at most one Jira project
+	_, err = GetCollection(ctx, JiraConfigsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "board_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on jira_configs: %w", err)
+	}
+
+	// Unique index on board_id so each board has at most one Linear team
+	_, err = GetCollection(ctx, LinearConfigsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "board_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create board_id index on linear_configs: %w", err)
+	}
+
+	// Index on public_link for serving public boards from their read model
+	_, err = GetCollection(ctx, PublicBoardsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "public_link", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create public_link index on public_boards: %w", err)
+	}
+
+	// Attachments collection indexes
+	_, err = GetCollection(ctx, AttachmentsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "idea_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create idea_id index on attachments: %w", err)
+	}
+
+	// Index on idea_id and created_at for reading an idea's score history in order
+	_, err = GetCollection(ctx, ScoreHistoryCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "idea_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create idea_id index on score_history: %w", err)
+	}
+
 	log.Println("Successfully created database indexes")
 	return nil
 }
 
+// auditRetentionIndex names the TTL index expiring audit entries
+const auditRetentionIndex = "audit_retention"
+
+// setupAuditRetention keeps the audit log TTL index in line with AUDIT_RETENTION_DAYS, updating the
+// expiry of an existing index in place and dropping it when entries should be kept forever
+func setupAuditRetention(ctx context.Context, collection *mongo.Collection) error {
+	days := AuditRetentionDays()
+	if days == 0 {
+		if err := collection.Indexes().DropOne(ctx, auditRetentionIndex); err != nil {
+			var cmdErr mongo.CommandError
+			if !errors.As(err, &cmdErr) || cmdErr.Name != "IndexNotFound" {
+				return fmt.Errorf("failed to drop retention index on audit_log: %w", err)
+			}
+		}
+		return nil
+	}
+
+	expireAfter := int32(days * 24 * 60 * 60)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetName(auditRetentionIndex).SetExpireAfterSeconds(expireAfter),
+	})
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Name == "IndexOptionsConflict" {
+		// The retention changed since the index was created
+		err = collection.Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collection.Name()},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: auditRetentionIndex},
+				{Key: "expireAfterSeconds", Value: expireAfter},
+			}},
+		}).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set up retention index on audit_log: %w", err)
+	}
+
+	log.Printf("Audit log retention set to %d days", days)
+	return nil
+}
+
 // DatabaseError represents a database operation error
 type DatabaseError struct {
 	Operation string
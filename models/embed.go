@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// EmbedToken grants read-only access to a board's released ideas from third-party sites
+type EmbedToken struct {
+	ID             string     `bson:"_id,omitempty" json:"id"` // The token itself
+	BoardID        string     `bson:"board_id" json:"boardId" validate:"required"`
+	UserID         string     `bson:"user_id" json:"userId" validate:"required"`
+	Label          string     `bson:"label,omitempty" json:"label,omitempty" validate:"max=100"`
+	AllowedOrigins []string   `bson:"allowed_origins" json:"allowedOrigins"` // Empty allows any origin
+	CreatedAt      time.Time  `bson:"created_at" json:"createdAt"`
+	LastUsedAt     *time.Time `bson:"last_used_at,omitempty" json:"lastUsedAt,omitempty"`
+}
+
+// MaxEmbedTokensPerBoard caps how many embed tokens a board may have
+const MaxEmbedTokensPerBoard = 20
+
+// AllowsOrigin reports whether a cross-origin request from origin may read the feed
+func (t *EmbedToken) AllowsOrigin(origin string) bool {
+	if len(t.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, allowed := range t.AllowedOrigins {
+		if allowed == origin {
+			return true
+		}
+	}
+	return false
+}
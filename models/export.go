@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// ExportConfig represents a scheduled analytics export for a board
+type ExportConfig struct {
+	ID              string     `bson:"_id,omitempty" json:"id"`
+	BoardID         string     `bson:"board_id" json:"boardId" validate:"required"`
+	UserID          string     `bson:"user_id" json:"userId" validate:"required"`
+	Provider        string     `bson:"provider" json:"provider" validate:"required"`
+	Bucket          string     `bson:"bucket" json:"bucket"` // Required except for the storage provider
+	Prefix          string     `bson:"prefix,omitempty" json:"prefix,omitempty"`
+	Region          string     `bson:"region,omitempty" json:"region,omitempty"`
+	Endpoint        string     `bson:"endpoint,omitempty" json:"endpoint,omitempty"` // Custom S3-compatible endpoint
+	AccessKeyID     string     `bson:"access_key_id" json:"-"`                       // Encrypted with ENCRYPTION_KEY
+	SecretAccessKey string     `bson:"secret_access_key" json:"-"`                   // Encrypted with ENCRYPTION_KEY
+	Format          string     `bson:"format" json:"format"`
+	IntervalHours   int        `bson:"interval_hours" json:"intervalHours" validate:"min=1,max=720"`
+	Enabled         bool       `bson:"enabled" json:"enabled"`
+	LastRunAt       *time.Time `bson:"last_run_at,omitempty" json:"lastRunAt,omitempty"`
+	LastStatus      string     `bson:"last_status,omitempty" json:"lastStatus,omitempty"`
+	LastError       string     `bson:"last_error,omitempty" json:"lastError,omitempty"`
+	LastFiles       []string   `bson:"last_files,omitempty" json:"lastFiles,omitempty"` // Object keys written by the last successful run
+	NextRunAt       time.Time  `bson:"next_run_at" json:"nextRunAt"`
+	CreatedAt       time.Time  `bson:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time  `bson:"updated_at" json:"updatedAt"`
+}
+
+// ExportProvider represents the supported cloud storage providers
+type ExportProvider string
+
+const (
+	ProviderS3  ExportProvider = "s3"
+	ProviderGCS ExportProvider = "gcs" // Uses GCS HMAC keys through the S3-compatible XML API
+	// ProviderStorage keeps the files in the server's own asset storage, downloaded via signed URLs
+	ProviderStorage ExportProvider = "storage"
+)
+
+// ExportFormat represents the supported export file formats
+type ExportFormat string
+
+const (
+	FormatCSV     ExportFormat = "csv"
+	FormatParquet ExportFormat = "parquet"
+)
+
+// Export run statuses
+const (
+	ExportStatusSuccess = "success"
+	ExportStatusFailed  = "failed"
+)
+
+// IsValidExportProvider checks if an export provider is valid
+func IsValidExportProvider(provider string) bool {
+	return provider == string(ProviderS3) || provider == string(ProviderGCS) || provider == string(ProviderStorage)
+}
+
+// IsValidExportFormat checks if an export format is valid
+func IsValidExportFormat(format string) bool {
+	return format == string(FormatCSV) || format == string(FormatParquet)
+}
@@ -1,25 +1,37 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
 // Idea represents an idea document in MongoDB
 type Idea struct {
-	ID             string          `bson:"_id,omitempty" json:"id"`
-	BoardID        string          `bson:"board_id" json:"boardId" validate:"required"`
-	OneLiner       string          `bson:"one_liner" json:"oneLiner" validate:"required,min=1,max=200"`
-	Description    string          `bson:"description" json:"description" validate:"omitempty,max=1000"`
-	ValueStatement string          `bson:"value_statement" json:"valueStatement" validate:"omitempty,max=500"`
-	RiceScore      RICEScore       `bson:"rice_score" json:"riceScore" validate:"omitempty"`
-	Column         string          `bson:"column" json:"column" validate:"required"`
-	Position       int             `bson:"position" json:"position" validate:"min=0"`
-	InProgress     bool            `bson:"in_progress" json:"inProgress"`
-	Status         string          `bson:"status" json:"status" validate:"required"`
-	ThumbsUp       int             `bson:"thumbs_up" json:"thumbsUp" validate:"min=0"`
-	EmojiReactions []EmojiReaction `bson:"emoji_reactions" json:"emojiReactions"`
-	CreatedAt      time.Time       `bson:"created_at" json:"createdAt"`
-	UpdatedAt      time.Time       `bson:"updated_at" json:"updatedAt"`
+	ID             string           `bson:"_id,omitempty" json:"id"`
+	BoardID        string           `bson:"board_id" json:"boardId" validate:"required"`
+	OneLiner       string           `bson:"one_liner" json:"oneLiner" validate:"required,min=1,max=200"`
+	Description    string           `bson:"description" json:"description" validate:"omitempty,max=1000"`
+	ValueStatement string           `bson:"value_statement" json:"valueStatement" validate:"omitempty,max=500"`
+	RiceScore      RICEScore        `bson:"rice_score" json:"riceScore" validate:"omitempty"`
+	Column         string           `bson:"column" json:"column" validate:"required"`
+	Position       int              `bson:"position" json:"position" validate:"min=0"`
+	InProgress     bool             `bson:"in_progress" json:"inProgress"`
+	Status         string           `bson:"status" json:"status" validate:"required"`
+	Tags           []string         `bson:"tags,omitempty" json:"tags"`
+	AssigneeID     string           `bson:"assignee_id,omitempty" json:"assigneeId,omitempty"`
+	ReleaseID      string           `bson:"release_id,omitempty" json:"releaseId,omitempty"`
+	ThumbsUp       int              `bson:"thumbs_up" json:"thumbsUp" validate:"min=0"`
+	EmojiReactions []EmojiReaction  `bson:"emoji_reactions" json:"emojiReactions"`
+	Votes          map[string]int   `bson:"votes,omitempty" json:"votes,omitempty"` // Counts per board vote option
+	Poll           *IdeaPoll        `bson:"poll,omitempty" json:"poll,omitempty"`
+	TargetDate     *time.Time       `bson:"target_date,omitempty" json:"targetDate,omitempty"` // Planned ship day (UTC midnight)
+	JiraIssue      *JiraIssueLink   `bson:"jira_issue,omitempty" json:"jiraIssue,omitempty"`
+	LinearIssue    *LinearIssueLink `bson:"linear_issue,omitempty" json:"linearIssue,omitempty"`
+	CreatedBy      string           `bson:"created_by,omitempty" json:"createdBy,omitempty"` // User or service account who added the idea
+	UpdatedBy      string           `bson:"updated_by,omitempty" json:"updatedBy,omitempty"` // User behind the last edit; feedback does not count
+	CreatedAt      time.Time        `bson:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time        `bson:"updated_at" json:"updatedAt"`
+	Version        int64            `bson:"version" json:"version"` // Bumped by every edit; see BumpVersion
 }
 
 // RICEScore represents the RICE scoring system for ideas
@@ -36,6 +48,55 @@ type EmojiReaction struct {
 	Count int    `bson:"count" json:"count" validate:"min=0"`
 }
 
+// TargetDateLayout is the day format accepted for idea target dates
+const TargetDateLayout = "2006-01-02"
+
+// ParseTargetDate parses a YYYY-MM-DD target date; an empty string clears the date and returns nil
+func ParseTargetDate(value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(TargetDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// Tag limits for ideas
+const (
+	MaxIdeaTags  = 10
+	MaxTagLength = 30
+)
+
+// NormalizeTags trims, lowercases and de-duplicates tags, dropping empty ones
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// IsValidTags checks tag count and length limits
+func IsValidTags(tags []string) bool {
+	if len(tags) > MaxIdeaTags {
+		return false
+	}
+	for _, tag := range tags {
+		if len(tag) > MaxTagLength {
+			return false
+		}
+	}
+	return true
+}
+
 // IdeaStatus represents the different statuses an idea can have
 type IdeaStatus string
 
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Impersonation lets a platform admin act as a user for a limited time to debug reported issues.
+// Every session is kept as a record of who impersonated whom, when and why.
+type Impersonation struct {
+	ID        string     `bson:"_id,omitempty" json:"id"`
+	AdminID   string     `bson:"admin_id" json:"adminId"`
+	UserID    string     `bson:"user_id" json:"userId"` // The impersonated user
+	Reason    string     `bson:"reason" json:"reason"`
+	IP        string     `bson:"ip,omitempty" json:"ip,omitempty"`
+	CreatedAt time.Time  `bson:"created_at" json:"createdAt"`
+	ExpiresAt time.Time  `bson:"expires_at" json:"expiresAt"`
+	EndedAt   *time.Time `bson:"ended_at,omitempty" json:"endedAt,omitempty"`
+}
+
+// ImpersonationHeader carries the impersonation ID on an admin's requests made as the user
+const ImpersonationHeader = "X-Impersonation-ID"
+
+// Bounds for impersonation sessions
+const (
+	DefaultImpersonationMinutes = 30
+	MaxImpersonationMinutes     = 60
+)
+
+// IsActive reports whether the session can still be used
+func (i *Impersonation) IsActive(now time.Time) bool {
+	return i.EndedAt == nil && now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// BoardInvitation is an emailed invitation to collaborate on a board. Accepting it with the
+// token from the email makes the signed-in Clerk user a member with the invited role.
+type BoardInvitation struct {
+	ID         string     `bson:"_id,omitempty" json:"id"`
+	BoardID    string     `bson:"board_id" json:"boardId" validate:"required"`
+	Email      string     `bson:"email" json:"email" validate:"required,email"`
+	Role       MemberRole `bson:"role" json:"role"`
+	Token      string     `bson:"token" json:"-"`
+	Status     string     `bson:"status" json:"status"`
+	InvitedBy  string     `bson:"invited_by" json:"invitedBy"`
+	AcceptedBy string     `bson:"accepted_by,omitempty" json:"acceptedBy,omitempty"`
+	CreatedAt  time.Time  `bson:"created_at" json:"createdAt"`
+	ExpiresAt  time.Time  `bson:"expires_at" json:"expiresAt"`
+	AcceptedAt *time.Time `bson:"accepted_at,omitempty" json:"acceptedAt,omitempty"`
+}
+
+// InvitationStatus represents the state of a board invitation
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+)
+
+// InvitationTTL is how long an invitation link stays valid
+const InvitationTTL = 7 * 24 * time.Hour
+
+// IsValidInvitationStatus checks if an invitation status is valid
+func IsValidInvitationStatus(status string) bool {
+	switch InvitationStatus(status) {
+	case InvitationPending, InvitationAccepted:
+		return true
+	}
+	return false
+}
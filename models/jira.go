@@ -0,0 +1,71 @@
+package models
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// JiraAuthType is how a board authenticates to Jira Cloud
+type JiraAuthType string
+
+const (
+	JiraAuthAPIToken JiraAuthType = "api_token" // Account email and API token, sent as basic auth
+	JiraAuthOAuth    JiraAuthType = "oauth"     // OAuth 2.0 (3LO) access token for the site's cloud ID
+)
+
+// JiraStatusDone is the status category key of Jira's done statuses
+const JiraStatusDone = "done"
+
+// JiraConfig connects a board to a Jira Cloud project. A board has at most one.
+type JiraConfig struct {
+	ID             string       `bson:"_id,omitempty" json:"id"`
+	BoardID        string       `bson:"board_id" json:"boardId" validate:"required"`
+	UserID         string       `bson:"user_id" json:"userId" validate:"required"` // Owner who configured it
+	SiteURL        string       `bson:"site_url" json:"siteUrl"`                   // https://your-domain.atlassian.net
+	AuthType       JiraAuthType `bson:"auth_type" json:"authType"`
+	Email          string       `bson:"email,omitempty" json:"email,omitempty"`      // API token auth only
+	APIToken       string       `bson:"api_token,omitempty" json:"-"`                // API token auth only; encrypted
+	CloudID        string       `bson:"cloud_id,omitempty" json:"cloudId,omitempty"` // OAuth only
+	AccessToken    string       `bson:"access_token,omitempty" json:"-"`             // OAuth only; encrypted
+	RefreshToken   string       `bson:"refresh_token,omitempty" json:"-"`            // OAuth only; encrypted, refreshed with JIRA_CLIENT_ID and JIRA_CLIENT_SECRET
+	TokenExpiresAt *time.Time   `bson:"token_expires_at,omitempty" json:"tokenExpiresAt,omitempty"`
+	ProjectKey     string       `bson:"project_key" json:"projectKey"`
+	IssueType      string       `bson:"issue_type" json:"issueType"`
+	SyncStatus     bool         `bson:"sync_status" json:"syncStatus"` // Release ideas whose issue is done
+	LastSyncedAt   *time.Time   `bson:"last_synced_at,omitempty" json:"lastSyncedAt,omitempty"`
+	LastError      string       `bson:"last_error,omitempty" json:"lastError,omitempty"` // Why the last call to Jira failed
+	CreatedAt      time.Time    `bson:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time    `bson:"updated_at" json:"updatedAt"`
+}
+
+// JiraIssueLink is the Jira issue created from an idea
+type JiraIssueLink struct {
+	IssueID   string     `bson:"issue_id" json:"issueId"`
+	Key       string     `bson:"key" json:"key"`
+	URL       string     `bson:"url" json:"url"`
+	Status    string     `bson:"status,omitempty" json:"status,omitempty"` // Status name as of the last sync
+	CreatedBy string     `bson:"created_by" json:"createdBy"`
+	CreatedAt time.Time  `bson:"created_at" json:"createdAt"`
+	SyncedAt  *time.Time `bson:"synced_at,omitempty" json:"syncedAt,omitempty"`
+}
+
+// IsValidJiraAuthType checks if a Jira auth type exists
+func IsValidJiraAuthType(authType string) bool {
+	return authType == string(JiraAuthAPIToken) || authType == string(JiraAuthOAuth)
+}
+
+// IsAllowedJiraSite reports whether a board may send Jira credentials to a site host: Jira Cloud sites
+// under atlassian.net, or hosts the operator lists in JIRA_ALLOWED_HOSTS (comma-separated)
+func IsAllowedJiraSite(host string) bool {
+	host = strings.ToLower(host)
+	if name, ok := strings.CutSuffix(host, ".atlassian.net"); ok && name != "" && !strings.Contains(name, ".") {
+		return true
+	}
+	for _, allowed := range strings.Split(os.Getenv("JIRA_ALLOWED_HOSTS"), ",") {
+		if allowed = strings.ToLower(strings.TrimSpace(allowed)); allowed != "" && allowed == host {
+			return true
+		}
+	}
+	return false
+}
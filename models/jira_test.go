@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJiraConfigSiteURL(t *testing.T) {
+	siteErrors := func(siteURL string) int {
+		config := &JiraConfig{
+			SiteURL:    siteURL,
+			AuthType:   JiraAuthAPIToken,
+			Email:      "owner@example.com",
+			APIToken:   "token",
+			ProjectKey: "DISKO",
+			IssueType:  "Task",
+		}
+		count := 0
+		for _, err := range ValidateJiraConfig(config) {
+			if err.Field == "siteUrl" {
+				count++
+			}
+		}
+		return count
+	}
+
+	t.Run("Jira Cloud Site", func(t *testing.T) {
+		assert.Zero(t, siteErrors("https://acme.atlassian.net"))
+		assert.Zero(t, siteErrors("https://ACME.atlassian.net/"))
+	})
+
+	t.Run("Other Hosts", func(t *testing.T) {
+		for _, siteURL := range []string{
+			"https://169.254.169.254",
+			"https://internal.example.com",
+			"https://atlassian.net",
+			"https://evil.com/.atlassian.net",
+			"https://acme.atlassian.net.evil.com",
+			"https://a.b.atlassian.net",
+			"https://user@acme.atlassian.net",
+			"http://acme.atlassian.net",
+		} {
+			assert.Equal(t, 1, siteErrors(siteURL), siteURL)
+		}
+	})
+
+	t.Run("Allowed Hosts", func(t *testing.T) {
+		t.Setenv("JIRA_ALLOWED_HOSTS", "jira.example.com, Tracker.Example.org")
+		assert.Zero(t, siteErrors("https://jira.example.com"))
+		assert.Zero(t, siteErrors("https://tracker.example.org"))
+		assert.Equal(t, 1, siteErrors("https://other.example.com"))
+	})
+}
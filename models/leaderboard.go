@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// LeaderboardMetric is what the idea leaderboard ranks by
+type LeaderboardMetric string
+
+const (
+	LeaderboardThumbsUp LeaderboardMetric = "thumbsup"
+	LeaderboardEmoji    LeaderboardMetric = "emoji"
+	LeaderboardScore    LeaderboardMetric = "score" // Weighted engagement score
+)
+
+// Engagement score weights: a thumbs up is an explicit vote, a comment takes the most effort
+const (
+	EngagementThumbsUpWeight = 2
+	EngagementEmojiWeight    = 1
+	EngagementCommentWeight  = 3
+)
+
+// LeaderboardWindow is the time range the leaderboard counts feedback over
+type LeaderboardWindow string
+
+const (
+	LeaderboardWindow7d  LeaderboardWindow = "7d"
+	LeaderboardWindow30d LeaderboardWindow = "30d"
+	LeaderboardWindow90d LeaderboardWindow = "90d"
+	LeaderboardAllTime   LeaderboardWindow = "all"
+)
+
+// Leaderboard size limits
+const (
+	DefaultLeaderboardLimit = 10
+	MaxLeaderboardLimit     = 50
+)
+
+// IsValidLeaderboardMetric checks if a leaderboard metric is valid
+func IsValidLeaderboardMetric(metric string) bool {
+	switch LeaderboardMetric(metric) {
+	case LeaderboardThumbsUp, LeaderboardEmoji, LeaderboardScore:
+		return true
+	}
+	return false
+}
+
+// IsValidLeaderboardWindow checks if a leaderboard window is valid
+func IsValidLeaderboardWindow(window string) bool {
+	switch LeaderboardWindow(window) {
+	case LeaderboardWindow7d, LeaderboardWindow30d, LeaderboardWindow90d, LeaderboardAllTime:
+		return true
+	}
+	return false
+}
+
+// Since returns the start of the window, or the zero time for all time
+func (w LeaderboardWindow) Since(now time.Time) time.Time {
+	switch w {
+	case LeaderboardWindow7d:
+		return now.AddDate(0, 0, -7)
+	case LeaderboardWindow30d:
+		return now.AddDate(0, 0, -30)
+	case LeaderboardWindow90d:
+		return now.AddDate(0, 0, -90)
+	}
+	return time.Time{}
+}
+
+// EngagementScore weighs an idea's feedback counts into a single engagement score
+func EngagementScore(thumbsUp, emoji, comments int) int {
+	return thumbsUp*EngagementThumbsUpWeight + emoji*EngagementEmojiWeight + comments*EngagementCommentWeight
+}
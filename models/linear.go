@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// Linear workflow state types, which every team's custom states belong to
+const (
+	LinearStateTriage    = "triage"
+	LinearStateBacklog   = "backlog"
+	LinearStateUnstarted = "unstarted"
+	LinearStateStarted   = "started"
+	LinearStateCompleted = "completed"
+	LinearStateCanceled  = "canceled"
+)
+
+// DefaultLinearStateColumns moves ideas when their issue is started, completed or canceled
+var DefaultLinearStateColumns = map[string]string{
+	LinearStateStarted:   string(ColumnNow),
+	LinearStateCompleted: string(ColumnRelease),
+	LinearStateCanceled:  string(ColumnWontDo),
+}
+
+// LinearConfig connects a board to a Linear team. The API key and webhook signing secret are
+// stored encrypted with ENCRYPTION_KEY. A board has at most one.
+type LinearConfig struct {
+	ID            string            `bson:"_id,omitempty" json:"id"`
+	BoardID       string            `bson:"board_id" json:"boardId" validate:"required"`
+	UserID        string            `bson:"user_id" json:"userId" validate:"required"` // Owner who configured it
+	APIKey        string            `bson:"api_key" json:"-"`
+	WebhookSecret string            `bson:"webhook_secret,omitempty" json:"-"`
+	TeamID        string            `bson:"team_id" json:"teamId"`
+	TeamKey       string            `bson:"team_key" json:"teamKey"`
+	TeamName      string            `bson:"team_name" json:"teamName"`
+	StateColumns  map[string]string `bson:"state_columns" json:"stateColumns"` // Linear state type to board column; other states leave ideas where they are
+	SyncStatus    bool              `bson:"sync_status" json:"syncStatus"`     // Move ideas when the webhook reports a state change
+	WebhookURL    string            `bson:"-" json:"webhookUrl"`               // Where to point the Linear webhook
+	LastEventAt   *time.Time        `bson:"last_event_at,omitempty" json:"lastEventAt,omitempty"`
+	LastError     string            `bson:"last_error,omitempty" json:"lastError,omitempty"` // Why the last call to Linear failed
+	CreatedAt     time.Time         `bson:"created_at" json:"createdAt"`
+	UpdatedAt     time.Time         `bson:"updated_at" json:"updatedAt"`
+}
+
+// LinearIssueLink is the Linear issue created from an idea
+type LinearIssueLink struct {
+	IssueID    string    `bson:"issue_id" json:"issueId"`
+	Identifier string    `bson:"identifier" json:"identifier"` // As in ENG-123
+	URL        string    `bson:"url" json:"url"`
+	State      string    `bson:"state,omitempty" json:"state,omitempty"`
+	StateType  string    `bson:"state_type,omitempty" json:"stateType,omitempty"`
+	CreatedBy  string    `bson:"created_by" json:"createdBy"`
+	CreatedAt  time.Time `bson:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `bson:"updated_at" json:"updatedAt"`
+}
+
+// IsValidLinearStateType checks if a Linear workflow state type exists
+func IsValidLinearStateType(stateType string) bool {
+	switch stateType {
+	case LinearStateTriage, LinearStateBacklog, LinearStateUnstarted, LinearStateStarted, LinearStateCompleted, LinearStateCanceled:
+		return true
+	}
+	return false
+}
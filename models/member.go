@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MemberRole is a user's role on a board. The board's creator (Board.UserID) is always the owner;
+// other Clerk users are added as members with a role.
+type MemberRole string
+
+const (
+	RoleOwner  MemberRole = "owner"  // Full control, including board settings, members and deletion
+	RoleEditor MemberRole = "editor" // Manages ideas, releases and moderation
+	RoleViewer MemberRole = "viewer" // Read-only access to the board and its feedback
+)
+
+// BoardMember is a collaborator on a board, stored in the board's members list
+type BoardMember struct {
+	UserID  string     `bson:"user_id" json:"userId" validate:"required"`
+	Email   string     `bson:"email,omitempty" json:"email,omitempty"` // For display only
+	Role    MemberRole `bson:"role" json:"role"`
+	AddedBy string     `bson:"added_by" json:"addedBy"`
+	AddedAt time.Time  `bson:"added_at" json:"addedAt"`
+}
+
+// MaxBoardMembers caps how many members a board may have besides its owner
+const MaxBoardMembers = 50
+
+// IsValidMemberRole checks if a role can be given to a member. Ownership is not assignable.
+func IsValidMemberRole(role string) bool {
+	switch MemberRole(role) {
+	case RoleEditor, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Allows reports whether the role grants at least the required role
+func (r MemberRole) Allows(required MemberRole) bool {
+	return r.rank() >= required.rank()
+}
+
+// rank orders roles from least to most privileged; unknown roles grant nothing
+func (r MemberRole) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleOwner:
+		return 3
+	}
+	return 0
+}
+
+// RoleOf returns the user's role on the board, or "" when they have no access
+func (b *Board) RoleOf(userID string) MemberRole {
+	if b.UserID == userID {
+		return RoleOwner
+	}
+	for _, member := range b.Members {
+		if member.UserID == userID {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+// Member returns the board member with the given user ID, or nil if they are not a member
+func (b *Board) Member(userID string) *BoardMember {
+	for i := range b.Members {
+		if b.Members[i].UserID == userID {
+			return &b.Members[i]
+		}
+	}
+	return nil
+}
+
+// BoardAccessFilter matches the board when the user owns it or is a member with at least the
+// required role. Use it wherever a handler checks that a user may act on a board.
+func BoardAccessFilter(boardID, userID string, required MemberRole) bson.M {
+	if required == RoleOwner {
+		return bson.M{"_id": boardID, "user_id": userID}
+	}
+
+	roles := []string{}
+	for _, role := range []MemberRole{RoleViewer, RoleEditor} {
+		if role.Allows(required) {
+			roles = append(roles, string(role))
+		}
+	}
+	return bson.M{
+		"_id": boardID,
+		"$or": []bson.M{
+			{"user_id": userID},
+			{"members": bson.M{"$elemMatch": bson.M{"user_id": userID, "role": bson.M{"$in": roles}}}},
+		},
+	}
+}
+
+// AccessibleBoardsFilter matches every board the user owns or is a member of, plus the boards of
+// the workspace of their active organization when they have one
+func AccessibleBoardsFilter(userID, orgID string) bson.M {
+	clauses := []bson.M{
+		{"user_id": userID},
+		{"members.user_id": userID},
+	}
+	if orgID != "" {
+		clauses = append(clauses, bson.M{"workspace_id": orgID})
+	}
+	return bson.M{"$or": clauses}
+}
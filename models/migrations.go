@@ -0,0 +1,52 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// runMigrations brings documents written by earlier versions up to the current schema. Every
+// migration is idempotent and cheap once applied, so they all run on each start, on the collections
+// of the tenant of ctx.
+func runMigrations(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if err := migrateBoardOwnerField(ctx); err != nil {
+		return fmt.Errorf("failed to migrate board owners: %w", err)
+	}
+	return nil
+}
+
+// migrateBoardOwnerField moves the owner of boards created by the first versions from admin_id to
+// user_id, which every query, index and access check uses. Boards with an admin_id were invisible
+// to their owners. Where both are set, user_id wins.
+func migrateBoardOwnerField(ctx context.Context) error {
+	boards := GetCollection(ctx, BoardsCollection)
+
+	moved, err := boards.UpdateMany(ctx,
+		bson.M{"admin_id": bson.M{"$exists": true}, "user_id": bson.M{"$in": bson.A{nil, ""}}},
+		bson.A{
+			bson.M{"$set": bson.M{"user_id": "$admin_id"}},
+			bson.M{"$unset": "admin_id"},
+		})
+	if err != nil {
+		return err
+	}
+
+	dropped, err := boards.UpdateMany(ctx,
+		bson.M{"admin_id": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"admin_id": ""}})
+	if err != nil {
+		return err
+	}
+
+	if moved.ModifiedCount > 0 || dropped.ModifiedCount > 0 {
+		log.Printf("Migrated board owners from admin_id to user_id - Moved: %d, Dropped: %d", moved.ModifiedCount, dropped.ModifiedCount)
+	}
+	return nil
+}
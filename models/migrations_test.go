@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMigrateBoardOwnerField(t *testing.T) {
+	connectTestDatabase(t)
+	ctx := context.Background()
+	boards := GetCollection(ctx, BoardsCollection)
+	now := time.Now().UTC()
+
+	// Boards as the first versions wrote them, bypassing the validator that now requires user_id
+	legacy := bson.M{"_id": "board_legacy", "name": "Legacy", "public_link": "link_legacy", "admin_id": "user_legacy", "is_public": false, "created_at": now, "updated_at": now}
+	both := bson.M{"_id": "board_both", "name": "Both", "public_link": "link_both", "admin_id": "user_stale", "user_id": "user_current", "is_public": false, "created_at": now, "updated_at": now}
+	_, err := DB.DB.RunCommand(ctx, bson.D{{Key: "insert", Value: BoardsCollection}, {Key: "documents", Value: bson.A{legacy, both}}, {Key: "bypassDocumentValidation", Value: true}}).Raw()
+	require.NoError(t, err)
+
+	require.NoError(t, runMigrations(ctx))
+	require.NoError(t, setupIndexes(ctx))
+
+	t.Run("Owner Moves To User ID", func(t *testing.T) {
+		var board bson.M
+		require.NoError(t, boards.FindOne(ctx, bson.M{"user_id": "user_legacy"}).Decode(&board))
+		assert.Equal(t, "board_legacy", board["_id"])
+		assert.NotContains(t, board, "admin_id")
+	})
+
+	t.Run("User ID Wins Over Admin ID", func(t *testing.T) {
+		var board bson.M
+		require.NoError(t, boards.FindOne(ctx, bson.M{"_id": "board_both"}).Decode(&board))
+		assert.Equal(t, "user_current", board["user_id"])
+		assert.NotContains(t, board, "admin_id")
+	})
+
+	t.Run("Owner Index Targets User ID", func(t *testing.T) {
+		cursor, err := boards.Indexes().List(ctx)
+		require.NoError(t, err)
+		var indexes []struct {
+			Key bson.D `bson:"key"`
+		}
+		require.NoError(t, cursor.All(ctx, &indexes))
+
+		var keys []string
+		for _, index := range indexes {
+			keys = append(keys, index.Key[0].Key)
+		}
+		assert.Contains(t, keys, "user_id")
+		assert.NotContains(t, keys, "admin_id")
+	})
+
+	t.Run("Running Again Changes Nothing", func(t *testing.T) {
+		require.NoError(t, runMigrations(ctx))
+		count, err := boards.CountDocuments(ctx, bson.M{"user_id": "user_legacy"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NotificationEvent is a board event that can notify the board's owner and members
+type NotificationEvent string
+
+const (
+	NotifyNewFeedback   NotificationEvent = "new_feedback"   // Thumbs up or emoji reaction on an idea
+	NotifyNewSubmission NotificationEvent = "new_submission" // Public idea submission awaiting review
+	NotifyComment       NotificationEvent = "comment"        // Comment on an idea, pending or published
+	NotifyStatusChange  NotificationEvent = "status_change"  // Idea moved to another column or status
+	NotifyAlert         NotificationEvent = "alert"          // One of the user's alert rules fired
+)
+
+// NotificationChannel is a way a notification reaches a user
+type NotificationChannel string
+
+const (
+	ChannelEmail   NotificationChannel = "email"
+	ChannelSlack   NotificationChannel = "slack"   // The instance's Slack webhook
+	ChannelWebhook NotificationChannel = "webhook" // The instance's outgoing webhook
+	ChannelInApp   NotificationChannel = "in_app"  // Live notification on the user's open board connections
+	ChannelPush    NotificationChannel = "push"    // Web Push message to the user's subscribed browsers
+)
+
+// NotificationEvents lists every event a preference can configure
+var NotificationEvents = []NotificationEvent{NotifyNewFeedback, NotifyNewSubmission, NotifyComment, NotifyStatusChange, NotifyAlert}
+
+// NotificationPreference is a user's choice of channels per event on one board. Events missing from
+// Channels use DefaultNotificationChannels; an empty list turns the event off.
+type NotificationPreference struct {
+	ID        string                                      `bson:"_id" json:"id"`
+	UserID    string                                      `bson:"user_id" json:"userId"`
+	BoardID   string                                      `bson:"board_id" json:"boardId"`
+	Channels  map[NotificationEvent][]NotificationChannel `bson:"channels" json:"channels"`
+	CreatedAt time.Time                                   `bson:"created_at" json:"createdAt"`
+	UpdatedAt time.Time                                   `bson:"updated_at" json:"updatedAt"`
+}
+
+// DefaultNotificationChannels are the channels of an event the user did not configure. Feedback
+// keeps notifying every channel as before preferences existed; submissions awaiting moderation
+// also push to the user's browsers, alerts the user set up also email and push, and other events
+// only notify in-app.
+func DefaultNotificationChannels(event NotificationEvent) []NotificationChannel {
+	switch event {
+	case NotifyNewFeedback:
+		return []NotificationChannel{ChannelEmail, ChannelSlack, ChannelWebhook, ChannelInApp}
+	case NotifyNewSubmission:
+		return []NotificationChannel{ChannelInApp, ChannelPush}
+	case NotifyAlert:
+		return []NotificationChannel{ChannelEmail, ChannelInApp, ChannelPush}
+	}
+	return []NotificationChannel{ChannelInApp}
+}
+
+// ChannelsFor returns the channels a preference selects for an event; a nil preference uses the defaults
+func (p *NotificationPreference) ChannelsFor(event NotificationEvent) []NotificationChannel {
+	if p != nil {
+		if channels, ok := p.Channels[event]; ok {
+			return channels
+		}
+	}
+	return DefaultNotificationChannels(event)
+}
+
+// Wants reports whether a preference selects the channel for an event
+func (p *NotificationPreference) Wants(event NotificationEvent, channel NotificationChannel) bool {
+	for _, selected := range p.ChannelsFor(event) {
+		if selected == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidNotificationEvent checks if an event can be configured
+func IsValidNotificationEvent(event string) bool {
+	for _, known := range NotificationEvents {
+		if NotificationEvent(event) == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidNotificationChannel checks if a channel exists
+func IsValidNotificationChannel(channel string) bool {
+	switch NotificationChannel(channel) {
+	case ChannelEmail, ChannelSlack, ChannelWebhook, ChannelInApp, ChannelPush:
+		return true
+	}
+	return false
+}
+
+// FindNotificationPreferences returns the preferences set on a board, keyed by user ID
+func FindNotificationPreferences(ctx context.Context, boardID string) (map[string]*NotificationPreference, error) {
+	cursor, err := GetCollection(ctx, NotificationPrefsCollection).Find(ctx, bson.M{"board_id": boardID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []NotificationPreference
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+	preferences := make(map[string]*NotificationPreference, len(found))
+	for i := range found {
+		preferences[found[i].UserID] = &found[i]
+	}
+	return preferences, nil
+}
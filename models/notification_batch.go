@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationBatch counts the notifications of one event, on one idea or on the board, held back
+// during a batching window. The first notification of a window is sent right away; when the window
+// ends, the rest are sent as a single digest. The ID is the batch's key, so a window has one batch.
+type NotificationBatch struct {
+	ID          string            `bson:"_id"` // board ID:idea ID:event
+	BoardID     string            `bson:"board_id"`
+	IdeaID      string            `bson:"idea_id,omitempty"`
+	Event       NotificationEvent `bson:"event"`
+	Count       int               `bson:"count"` // Notifications in the window, including the first
+	WindowStart time.Time         `bson:"window_start"`
+	FlushAt     time.Time         `bson:"flush_at"` // When the window ends
+}
+
+// IsBatchedNotificationEvent reports whether an event's notifications are batched. Visitors cause
+// these in bursts; status changes are made by members one at a time and always sent.
+func IsBatchedNotificationEvent(event NotificationEvent) bool {
+	switch event {
+	case NotifyNewFeedback, NotifyNewSubmission, NotifyComment:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationJobKind is the delivery a notification job performs
+type NotificationJobKind string
+
+const (
+	JobEmail        NotificationJobKind = "email"         // Email to the user in Target
+	JobSlack        NotificationJobKind = "slack"         // The instance's Slack webhook
+	JobWebhook      NotificationJobKind = "webhook"       // The instance's outgoing webhook
+	JobBoardWebhook NotificationJobKind = "board_webhook" // The board webhook in Target
+	JobPush         NotificationJobKind = "push"          // Web Push to the push subscription in Target
+	JobTelegram     NotificationJobKind = "telegram"      // The board's Telegram chat
+)
+
+const (
+	// MaxJobAttempts is how many times a job is tried before it is dead-lettered
+	MaxJobAttempts = 5
+	// NotificationJobRetention is how long completed jobs are kept
+	NotificationJobRetention = 7 * 24 * time.Hour
+)
+
+// NotificationJobStatus is where a notification job is in the queue
+type NotificationJobStatus string
+
+const (
+	JobPending    NotificationJobStatus = "pending"    // Waiting for NextAttemptAt
+	JobProcessing NotificationJobStatus = "processing" // Claimed by a worker until LockedUntil
+	JobDone       NotificationJobStatus = "done"
+	JobDead       NotificationJobStatus = "dead" // Gave up; kept until an admin retries it
+)
+
+// NotificationJob is one delivery of a board notification or webhook event, persisted so it survives restarts and
+// is retried with backoff. The idempotency key, built from the notification or event ID, kind
+// and target, keeps it from being queued twice for the same delivery.
+type NotificationJob struct {
+	ID             string                `bson:"_id" json:"id"`
+	IdempotencyKey string                `bson:"idempotency_key" json:"idempotencyKey"`
+	Kind           NotificationJobKind   `bson:"kind" json:"kind"`
+	Target         string                `bson:"target,omitempty" json:"target,omitempty"` // User, board webhook or push subscription ID
+	BoardID        string                `bson:"board_id" json:"boardId"`
+	Event          string                `bson:"event" json:"event"`     // Notification event, or webhook event type for board webhook jobs
+	Payload        string                `bson:"payload" json:"payload"` // The notification, or the webhook event envelope, as JSON
+	Status         NotificationJobStatus `bson:"status" json:"status"`
+	Attempts       int                   `bson:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time             `bson:"next_attempt_at" json:"nextAttemptAt"`
+	LockedUntil    *time.Time            `bson:"locked_until,omitempty" json:"lockedUntil,omitempty"`
+	LastError      string                `bson:"last_error,omitempty" json:"lastError,omitempty"`
+	CreatedAt      time.Time             `bson:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time             `bson:"updated_at" json:"updatedAt"`
+	CompletedAt    *time.Time            `bson:"completed_at,omitempty" json:"completedAt,omitempty"` // Set when done; expires the job
+}
+
+// IsValidNotificationJobStatus checks if a job status exists
+func IsValidNotificationJobStatus(status string) bool {
+	switch NotificationJobStatus(status) {
+	case JobPending, JobProcessing, JobDone, JobDead:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// PersonalAccessToken lets scripts and CI call the protected API on behalf of a user. Like board
+// API tokens, only a hash of the secret is stored and the secret is shown once at creation.
+type PersonalAccessToken struct {
+	ID         string     `bson:"_id,omitempty" json:"id"`
+	UserID     string     `bson:"user_id" json:"userId" validate:"required"`
+	Name       string     `bson:"name" json:"name" validate:"required,max=100"`
+	TokenHash  string     `bson:"token_hash" json:"-"`
+	Prefix     string     `bson:"prefix" json:"prefix"`                             // First characters of the secret, to tell tokens apart
+	TenantID   string     `bson:"tenant_id,omitempty" json:"workspaceId,omitempty"` // Workspace whose data the token reaches, from the session that created it
+	ExpiresAt  *time.Time `bson:"expires_at,omitempty" json:"expiresAt,omitempty"`
+	CreatedAt  time.Time  `bson:"created_at" json:"createdAt"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"lastUsedAt,omitempty"`
+}
+
+// PersonalAccessTokenPrefix starts every personal access token secret, telling them apart from Clerk JWTs
+const PersonalAccessTokenPrefix = "dkp_"
+
+// MaxPersonalAccessTokens caps how many personal access tokens a user may have
+const MaxPersonalAccessTokens = 20
+
+// MaxPersonalAccessTokenDays bounds the lifetime of an expiring personal access token
+const MaxPersonalAccessTokenDays = 365
+
+// IsExpired reports whether the token has passed its expiry date
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
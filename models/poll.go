@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// IdeaPoll is a one-question poll attached to an idea, answered anonymously by public visitors
+type IdeaPoll struct {
+	Question  string       `bson:"question" json:"question" validate:"required,min=1,max=200"`
+	Options   []PollOption `bson:"options" json:"options"`
+	CreatedAt time.Time    `bson:"created_at" json:"createdAt"`
+}
+
+// PollOption is one answer of a poll with its running count
+type PollOption struct {
+	ID    string `bson:"id" json:"id"`
+	Label string `bson:"label" json:"label" validate:"required,min=1,max=100"`
+	Count int    `bson:"count" json:"count" validate:"min=0"`
+}
+
+// Poll size limits
+const (
+	MinPollOptions = 2
+	MaxPollOptions = 6
+)
+
+// OptionIndex returns the index of the option with the given ID, or -1
+func (p *IdeaPoll) OptionIndex(optionID string) int {
+	for i, option := range p.Options {
+		if option.ID == optionID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+)
+
+// PublicBoardView is the read model of a public board: the board settings public pages use with its
+// ideas in visible columns, in board order, so public pages load with a single read. It is rebuilt
+// after writes to the board and its ideas and deleted once the board is no longer public.
+type PublicBoardView struct {
+	BoardID      string      `bson:"_id"`
+	PublicLink   string      `bson:"public_link"`
+	Board        PublicBoard `bson:"board"`
+	Ideas        []Idea      `bson:"ideas"`         // Only the fields public responses can show
+	LastModified time.Time   `bson:"last_modified"` // Latest updated_at of the ideas
+	RefreshedAt  time.Time   `bson:"refreshed_at"`
+}
+
+// PublicBoard holds the board settings public pages show or enforce. Owner-only settings such as
+// members, the owner and workspace, moderation and rate limits are left out of the read model.
+type PublicBoard struct {
+	ID              string        `bson:"_id"`
+	Name            string        `bson:"name"`
+	Description     string        `bson:"description,omitempty"`
+	PublicLink      string        `bson:"public_link"`
+	VisibleColumns  []string      `bson:"visible_columns"`
+	VisibleFields   []string      `bson:"visible_fields"`
+	PublicRiceScore bool          `bson:"public_rice_score"`
+	StrictPrivacy   bool          `bson:"strict_privacy"`
+	Reactions       []string      `bson:"reactions,omitempty"`
+	AcceptsIdeas    bool          `bson:"accepts_ideas"`
+	VoteOptions     []string      `bson:"vote_options,omitempty"`
+	CaptchaProvider string        `bson:"captcha_provider,omitempty"`
+	IPRules         *BoardIPRules `bson:"ip_rules,omitempty"` // Enforced on every read of the view
+	LogoKey         string        `bson:"logo_key,omitempty"`
+	CreatedAt       time.Time     `bson:"created_at"`
+	UpdatedAt       time.Time     `bson:"updated_at"`
+}
+
+// NewPublicBoard copies the settings public pages use from a board
+func NewPublicBoard(board *Board) PublicBoard {
+	return PublicBoard{
+		ID:              board.ID,
+		Name:            board.Name,
+		Description:     board.Description,
+		PublicLink:      board.PublicLink,
+		VisibleColumns:  board.VisibleColumns,
+		VisibleFields:   board.VisibleFields,
+		PublicRiceScore: board.PublicRiceScore,
+		StrictPrivacy:   board.StrictPrivacy,
+		Reactions:       board.Reactions,
+		AcceptsIdeas:    board.AcceptsIdeas,
+		VoteOptions:     board.VoteOptions,
+		CaptchaProvider: board.CaptchaProvider,
+		IPRules:         board.IPRules,
+		LogoKey:         board.LogoKey,
+		CreatedAt:       board.CreatedAt,
+		UpdatedAt:       board.UpdatedAt,
+	}
+}
+
+// AsBoard returns the settings as a public board, for the helpers shared with board handlers
+func (p PublicBoard) AsBoard() Board {
+	return Board{
+		ID:              p.ID,
+		Name:            p.Name,
+		Description:     p.Description,
+		PublicLink:      p.PublicLink,
+		IsPublic:        true,
+		VisibleColumns:  p.VisibleColumns,
+		VisibleFields:   p.VisibleFields,
+		PublicRiceScore: p.PublicRiceScore,
+		StrictPrivacy:   p.StrictPrivacy,
+		Reactions:       p.Reactions,
+		AcceptsIdeas:    p.AcceptsIdeas,
+		VoteOptions:     p.VoteOptions,
+		CaptchaProvider: p.CaptchaProvider,
+		IPRules:         p.IPRules,
+		LogoKey:         p.LogoKey,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
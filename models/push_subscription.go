@@ -0,0 +1,44 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MaxPushSubscriptionsPerUser caps how many browsers a user may receive push messages on
+const MaxPushSubscriptionsPerUser = 20
+
+// PushSubscriptionKeys are the keys a browser's push subscription encrypts messages with
+type PushSubscriptionKeys struct {
+	P256dh string `bson:"p256dh" json:"p256dh"` // The browser's P-256 public key, base64url
+	Auth   string `bson:"auth" json:"auth"`     // The 16-byte authentication secret, base64url
+}
+
+// PushSubscription is a browser a user subscribed to Web Push notifications on. The endpoint is
+// the push service URL messages are posted to; it is unique to the browser.
+type PushSubscription struct {
+	ID         string               `bson:"_id" json:"id"`
+	UserID     string               `bson:"user_id" json:"userId"`
+	Endpoint   string               `bson:"endpoint" json:"endpoint"`
+	Keys       PushSubscriptionKeys `bson:"keys" json:"-"`
+	UserAgent  string               `bson:"user_agent,omitempty" json:"userAgent,omitempty"`
+	CreatedAt  time.Time            `bson:"created_at" json:"createdAt"`
+	LastUsedAt *time.Time           `bson:"last_used_at,omitempty" json:"lastUsedAt,omitempty"` // Last successful push
+}
+
+// FindPushSubscriptions returns the browsers a user subscribed to push notifications on
+func FindPushSubscriptions(ctx context.Context, userID string) ([]PushSubscription, error) {
+	cursor, err := GetCollection(ctx, PushSubscriptionsCollection).Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []PushSubscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// Release represents a named milestone on a board that ideas can be attached to
+type Release struct {
+	ID        string    `bson:"_id,omitempty" json:"id"`
+	BoardID   string    `bson:"board_id" json:"boardId" validate:"required"`
+	Name      string    `bson:"name" json:"name" validate:"required,min=1,max=100"`
+	Date      time.Time `bson:"date" json:"date"`
+	Notes     string    `bson:"notes,omitempty" json:"notes,omitempty" validate:"max=5000"`
+	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updatedAt"`
+}
@@ -0,0 +1,242 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// BumpVersion adds an increment of the document's version to an update. Edits of an idea's content,
+// column or status and of a board's settings bump it, so clients can tell whether the copy they
+// edited is still current; feedback counters and renumbering around a move do not.
+func BumpVersion(update bson.M) bson.M {
+	inc, ok := update["$inc"].(bson.M)
+	if !ok {
+		inc = bson.M{}
+		update["$inc"] = inc
+	}
+	inc["version"] = 1
+	return update
+}
+
+// VersionCondition matches documents at the expected version, for update filters. Documents never
+// edited since versions were introduced have no version field and count as version 0.
+func VersionCondition(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return version
+}
+
+// UpdateIdeaAndReturn applies an update to the idea matching filter and returns the updated document
+// in a single round trip. Returns mongo.ErrNoDocuments if no idea matches.
+func UpdateIdeaAndReturn(ctx context.Context, filter bson.M, update bson.M) (*Idea, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var idea Idea
+	if err := GetCollection(ctx, IdeasCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&idea); err != nil {
+		return nil, err
+	}
+	return &idea, nil
+}
+
+// UpdateBoardAndReturn applies an update to the board matching filter and returns the updated document
+// in a single round trip, dropping the board from the board cache. Returns mongo.ErrNoDocuments if no board matches.
+func UpdateBoardAndReturn(ctx context.Context, filter bson.M, update bson.M) (*Board, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var board Board
+	if err := GetCollection(ctx, BoardsCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&board); err != nil {
+		return nil, err
+	}
+	InvalidateBoard(board.ID)
+	return &board, nil
+}
+
+// EndOfColumn is a MoveIdea position that puts the idea after every other idea of the column
+const EndOfColumn = math.MaxInt32
+
+// MoveIdea moves the idea matching filter to a column, at the 1-based position among the column's
+// other ideas (clamped to the column), and renumbers the column it left and the one it joined from 1,
+// all in a transaction. Every move also bumps the board's IdeaMoves counter, so concurrent moves on the
+// board conflict and are retried instead of leaving two ideas at one position. set holds other
+// fields to set on the moved idea. Returns the moved idea, or mongo.ErrNoDocuments if no idea
+// matches filter.
+func MoveIdea(ctx context.Context, filter bson.M, column string, position int, set bson.M) (*Idea, error) {
+	var moved Idea
+	err := RunInTransaction(ctx, func(tc context.Context) error {
+		ideas := GetCollection(ctx, IdeasCollection)
+
+		var idea Idea
+		if err := ideas.FindOne(tc, filter).Decode(&idea); err != nil {
+			return err
+		}
+		ideaID := idea.ID
+		if _, err := GetCollection(ctx, BoardsCollection).UpdateOne(tc, bson.M{"_id": idea.BoardID}, bson.M{"$inc": bson.M{"idea_moves": 1}}); err != nil {
+			return err
+		}
+
+		target, err := columnPositions(tc, idea.BoardID, column, ideaID)
+		if err != nil {
+			return err
+		}
+		index := min(max(position-1, 0), len(target))
+		writes := renumberWrites(target[:index], 1)
+		writes = append(writes, renumberWrites(target[index:], index+2)...)
+		if column != idea.Column {
+			source, err := columnPositions(tc, idea.BoardID, idea.Column, ideaID)
+			if err != nil {
+				return err
+			}
+			writes = append(writes, renumberWrites(source, 1)...)
+		}
+		if len(writes) > 0 {
+			if _, err := ideas.BulkWrite(tc, writes); err != nil {
+				return err
+			}
+		}
+
+		update := bson.M{}
+		for field, value := range set {
+			update[field] = value
+		}
+		update["column"] = column
+		update["position"] = index + 1
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		return ideas.FindOneAndUpdate(tc, bson.M{"_id": ideaID}, BumpVersion(bson.M{"$set": update}), opts).Decode(&moved)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+// ideaPosition is an idea's place in its column
+type ideaPosition struct {
+	ID       string `bson:"_id"`
+	Position int    `bson:"position"`
+}
+
+// columnPositions lists the ideas of a board column in order, leaving out the given idea
+func columnPositions(ctx context.Context, boardID, column, exceptID string) ([]ideaPosition, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "position", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"position": 1})
+	cursor, err := GetCollection(ctx, IdeasCollection).Find(ctx, bson.M{"board_id": boardID, "column": column, "_id": bson.M{"$ne": exceptID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []ideaPosition
+	if err := cursor.All(ctx, &positions); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// renumberWrites numbers ideas consecutively from first, updating only those not already in place
+func renumberWrites(ideas []ideaPosition, first int) []mongo.WriteModel {
+	writes := []mongo.WriteModel{}
+	for i, idea := range ideas {
+		if idea.Position == first+i {
+			continue
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": idea.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"position": first + i}}))
+	}
+	return writes
+}
+
+// RunInTransaction runs fn in a transaction, retrying it on transient errors such as write conflicts.
+// Standalone servers, as used in local development, do not support transactions; there fn runs
+// without one.
+func RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := DB.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc context.Context) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == illegalOperationCode {
+		return fn(ctx)
+	}
+	return err
+}
+
+// illegalOperationCode is the server error for transactions on a standalone server
+const illegalOperationCode = 20
+
+// NextIdeaPosition returns the position after the last idea in a board column (1 for an empty column)
+func NextIdeaPosition(ctx context.Context, boardID, column string) (int, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "position", Value: -1}})
+
+	var lastIdea Idea
+	err := GetCollection(ctx, IdeasCollection).FindOne(ctx, bson.M{"board_id": boardID, "column": column}, opts).Decode(&lastIdea)
+	if err == mongo.ErrNoDocuments {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return lastIdea.Position + 1, nil
+}
+
+// GetBoardStats computes per-column idea counts, total feedback and the latest idea change
+// for a board with a single aggregation over its ideas
+func GetBoardStats(ctx context.Context, board *Board) (*BoardStats, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"board_id": board.ID}},
+		{"$group": bson.M{
+			"_id":   "$column",
+			"count": bson.M{"$sum": 1},
+			"feedback": bson.M{"$sum": bson.M{"$add": []interface{}{
+				bson.M{"$ifNull": []interface{}{"$thumbs_up", 0}},
+				bson.M{"$sum": "$emoji_reactions.count"},
+			}}},
+			"last_updated": bson.M{"$max": "$updated_at"},
+		}},
+	}
+
+	cursor, err := GetCollection(ctx, IdeasCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Column      string    `bson:"_id"`
+		Count       int       `bson:"count"`
+		Feedback    int       `bson:"feedback"`
+		LastUpdated time.Time `bson:"last_updated"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	stats := &BoardStats{
+		IdeasByColumn:  make(map[string]int),
+		LastActivityAt: board.UpdatedAt,
+	}
+	for _, column := range GetDefaultVisibleColumns() {
+		stats.IdeasByColumn[column] = 0
+	}
+	for _, group := range groups {
+		stats.IdeasByColumn[group.Column] = group.Count
+		stats.TotalIdeas += group.Count
+		stats.TotalFeedback += group.Feedback
+		if group.LastUpdated.After(stats.LastActivityAt) {
+			stats.LastActivityAt = group.LastUpdated
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// ScheduledJob is the shared state of a recurring background job, one document per job. It doubles
+// as the job's lock: an instance runs the job only after claiming the document for a schedule slot,
+// so each slot runs once across all instances.
+type ScheduledJob struct {
+	Name            string     `bson:"_id" json:"name"`
+	Owner           string     `bson:"owner" json:"owner"`                       // Instance that claimed the last slot
+	LockedUntil     time.Time  `bson:"locked_until" json:"lockedUntil"`          // The job's timeout while it runs, then when it finished
+	LastScheduledAt time.Time  `bson:"last_scheduled_at" json:"lastScheduledAt"` // The last slot claimed
+	LastStartedAt   *time.Time `bson:"last_started_at,omitempty" json:"lastStartedAt,omitempty"`
+	LastFinishedAt  *time.Time `bson:"last_finished_at,omitempty" json:"lastFinishedAt,omitempty"`
+	LastDurationMs  int64      `bson:"last_duration_ms" json:"lastDurationMs"`
+	LastError       string     `bson:"last_error,omitempty" json:"lastError,omitempty"`
+}
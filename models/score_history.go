@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// ScoreSnapshot records an idea's RICE score from the moment it was set until the next snapshot
+type ScoreSnapshot struct {
+	ID        string    `bson:"_id,omitempty" json:"id"`
+	BoardID   string    `bson:"board_id" json:"boardId"`
+	IdeaID    string    `bson:"idea_id" json:"ideaId"`
+	RiceScore RICEScore `bson:"rice_score" json:"riceScore"`
+	Total     float64   `bson:"total" json:"total"` // CalculateRICEScore at the time, kept for charts
+	ChangedBy string    `bson:"changed_by,omitempty" json:"changedBy,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
+}
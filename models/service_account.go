@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ServicePermission is an action a service account may perform on a board
+type ServicePermission string
+
+const (
+	PermissionIdeasRead      ServicePermission = "ideas:read"      // List a board's ideas
+	PermissionIdeasCreate    ServicePermission = "ideas:create"    // Push new ideas to a board
+	PermissionFeedbackIngest ServicePermission = "feedback:ingest" // Forward suggestions and feedback from external tools
+)
+
+// ServiceAccountScope grants a service account permissions on one board
+type ServiceAccountScope struct {
+	BoardID     string              `bson:"board_id" json:"boardId" validate:"required"`
+	Permissions []ServicePermission `bson:"permissions" json:"permissions" validate:"required,min=1"`
+}
+
+// ServiceAccount is a non-human principal that integrations (support tools, CI, ...) use to call the
+// API without a user session. It acts with the scoped permissions its owner granted on their boards;
+// only a hash of its secret is stored.
+type ServiceAccount struct {
+	ID         string                `bson:"_id,omitempty" json:"id"`
+	UserID     string                `bson:"user_id" json:"userId" validate:"required"` // Owner of the account and its scoped boards
+	Name       string                `bson:"name" json:"name" validate:"required,max=100"`
+	Scopes     []ServiceAccountScope `bson:"scopes" json:"scopes"`
+	TokenHash  string                `bson:"token_hash" json:"-"`
+	Prefix     string                `bson:"prefix" json:"prefix"` // First characters of the secret, to tell accounts apart
+	CreatedAt  time.Time             `bson:"created_at" json:"createdAt"`
+	LastUsedAt *time.Time            `bson:"last_used_at,omitempty" json:"lastUsedAt,omitempty"`
+}
+
+// ServiceAccountTokenPrefix starts every service account secret
+const ServiceAccountTokenPrefix = "dks_"
+
+// MaxServiceAccounts caps how many service accounts a user may have
+const MaxServiceAccounts = 20
+
+// MaxServiceAccountScopes caps how many boards a service account may be scoped to
+const MaxServiceAccountScopes = 50
+
+// IsValidServicePermission checks if a service permission is valid
+func IsValidServicePermission(permission string) bool {
+	switch ServicePermission(permission) {
+	case PermissionIdeasRead, PermissionIdeasCreate, PermissionFeedbackIngest:
+		return true
+	}
+	return false
+}
+
+// ValidateServiceAccountScopes checks a scope list: known permissions, at least one per board, and no board listed twice
+func ValidateServiceAccountScopes(scopes []ServiceAccountScope) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one board scope is required")
+	}
+	if len(scopes) > MaxServiceAccountScopes {
+		return fmt.Errorf("at most %d board scopes are allowed", MaxServiceAccountScopes)
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if scope.BoardID == "" {
+			return fmt.Errorf("every scope needs a boardId")
+		}
+		if seen[scope.BoardID] {
+			return fmt.Errorf("board %s is listed more than once", scope.BoardID)
+		}
+		seen[scope.BoardID] = true
+
+		if len(scope.Permissions) == 0 {
+			return fmt.Errorf("board %s needs at least one permission", scope.BoardID)
+		}
+		for _, permission := range scope.Permissions {
+			if !IsValidServicePermission(string(permission)) {
+				return fmt.Errorf("unknown permission %q; supported permissions are ideas:read, ideas:create and feedback:ingest", permission)
+			}
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the account holds a permission on a board
+func (a *ServiceAccount) Allows(boardID string, permission ServicePermission) bool {
+	for _, scope := range a.Scopes {
+		if scope.BoardID != boardID {
+			continue
+		}
+		for _, granted := range scope.Permissions {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ActorID identifies the account in activity logs, distinct from Clerk user IDs
+func (a *ServiceAccount) ActorID() string {
+	return "service:" + a.ID
+}
+
+// BoardIDs returns the boards the account is scoped to
+func (a *ServiceAccount) BoardIDs() []string {
+	ids := make([]string, 0, len(a.Scopes))
+	for _, scope := range a.Scopes {
+		ids = append(ids, scope.BoardID)
+	}
+	return ids
+}
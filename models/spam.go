@@ -0,0 +1,50 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxPublicTextLinks caps how many URLs visitor-submitted text may contain before it is treated as spam
+const MaxPublicTextLinks = 2
+
+// spamRepeatRun is the length of a run of one character treated as spam
+const spamRepeatRun = 10
+
+var spamLinkPattern = regexp.MustCompile(`(?i)(https?://|www\.)`)
+
+// hasRepeatedRun reports whether text contains the same character spamRepeatRun times in a row
+func hasRepeatedRun(text string) bool {
+	run := 0
+	var previous rune
+	for i, r := range text {
+		if i > 0 && r == previous {
+			run++
+		} else {
+			run = 1
+		}
+		if run >= spamRepeatRun {
+			return true
+		}
+		previous = r
+	}
+	return false
+}
+
+// LooksLikeSpam applies cheap heuristics to visitor-submitted text: too many links,
+// long runs of one character, or shouting in all caps
+func LooksLikeSpam(text string) bool {
+	if len(spamLinkPattern.FindAllString(text, -1)) > MaxPublicTextLinks {
+		return true
+	}
+	if hasRepeatedRun(text) {
+		return true
+	}
+	letters := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, text)
+	return len(letters) >= 20 && letters == strings.ToUpper(letters)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLooksLikeSpam(t *testing.T) {
+	t.Run("Repeated Characters", func(t *testing.T) {
+		assert.True(t, LooksLikeSpam("Great idea!!!!!!!!!!"))
+		assert.True(t, LooksLikeSpam("heeeeeeeeeeeey"))
+		assert.True(t, LooksLikeSpam("😀😀😀😀😀😀😀😀😀😀"))
+	})
+
+	t.Run("Short Runs Are Allowed", func(t *testing.T) {
+		assert.False(t, LooksLikeSpam("Great idea!!!!!!!!!"))
+		assert.False(t, LooksLikeSpam("zzzzz ... zzzzz"))
+	})
+
+	t.Run("Link Heavy", func(t *testing.T) {
+		assert.True(t, LooksLikeSpam("Buy now https://a.example http://b.example www.c.example"))
+		assert.False(t, LooksLikeSpam("See https://a.example and www.b.example for details"))
+	})
+
+	t.Run("Shouting", func(t *testing.T) {
+		assert.True(t, LooksLikeSpam("PLEASE ADD THIS FEATURE RIGHT NOW"))
+		assert.False(t, LooksLikeSpam("Add SSO"))
+	})
+
+	t.Run("Clean Input", func(t *testing.T) {
+		assert.False(t, LooksLikeSpam("It would help to export the roadmap as a PDF for our quarterly review."))
+		assert.False(t, LooksLikeSpam(""))
+		assert.False(t, LooksLikeSpam(strings.Repeat("ab", 50)))
+	})
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// IdeaSubmission is an idea suggested by a public visitor, held for owner moderation
+type IdeaSubmission struct {
+	ID             string     `bson:"_id,omitempty" json:"id"`
+	BoardID        string     `bson:"board_id" json:"boardId" validate:"required"`
+	OneLiner       string     `bson:"one_liner" json:"oneLiner" validate:"required,min=1,max=200"`
+	Description    string     `bson:"description,omitempty" json:"description,omitempty" validate:"max=1000"`
+	ValueStatement string     `bson:"value_statement,omitempty" json:"valueStatement,omitempty" validate:"max=500"`
+	SubmitterName  string     `bson:"submitter_name,omitempty" json:"submitterName,omitempty" validate:"max=100"`
+	SubmitterEmail string     `bson:"submitter_email,omitempty" json:"submitterEmail,omitempty"`
+	VisitorKey     string     `bson:"visitor_key,omitempty" json:"-"`           // Rate limiting / abuse tracing only
+	Source         string     `bson:"source,omitempty" json:"source,omitempty"` // External tool that forwarded it, e.g. intercom
+	Status         string     `bson:"status" json:"status"`
+	IdeaID         string     `bson:"idea_id,omitempty" json:"ideaId,omitempty"` // Set once approved
+	ModeratedAt    *time.Time `bson:"moderated_at,omitempty" json:"moderatedAt,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time  `bson:"updated_at" json:"updatedAt"`
+}
+
+// SubmissionStatus represents the moderation state of a submission
+type SubmissionStatus string
+
+const (
+	SubmissionPending  SubmissionStatus = "pending"
+	SubmissionApproved SubmissionStatus = "approved"
+	SubmissionRejected SubmissionStatus = "rejected"
+)
+
+// IsValidSubmissionStatus checks if a submission status is valid
+func IsValidSubmissionStatus(status string) bool {
+	switch SubmissionStatus(status) {
+	case SubmissionPending, SubmissionApproved, SubmissionRejected:
+		return true
+	}
+	return false
+}
+
+// LooksLikeSpam applies the shared spam heuristics to all submitted text
+func (s *IdeaSubmission) LooksLikeSpam() bool {
+	return LooksLikeSpam(s.OneLiner + " " + s.Description + " " + s.ValueStatement)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Subscriber is a visitor who asked to receive release announcements for a board by email
+type Subscriber struct {
+	ID               string     `bson:"_id,omitempty" json:"id"`
+	BoardID          string     `bson:"board_id" json:"boardId" validate:"required"`
+	Email            string     `bson:"email" json:"email" validate:"required,email"`
+	Status           string     `bson:"status" json:"status"`
+	ConfirmToken     string     `bson:"confirm_token,omitempty" json:"-"`
+	UnsubscribeToken string     `bson:"unsubscribe_token" json:"-"`
+	ConfirmedAt      *time.Time `bson:"confirmed_at,omitempty" json:"confirmedAt,omitempty"`
+	CreatedAt        time.Time  `bson:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time  `bson:"updated_at" json:"updatedAt"`
+}
+
+// SubscriberStatus represents the opt-in state of a subscriber
+type SubscriberStatus string
+
+const (
+	SubscriberPending   SubscriberStatus = "pending"
+	SubscriberConfirmed SubscriberStatus = "confirmed"
+)
+
+// IsValidSubscriberStatus checks if a subscriber status is valid
+func IsValidSubscriberStatus(status string) bool {
+	switch SubscriberStatus(status) {
+	case SubscriberPending, SubscriberConfirmed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// TelegramConfig sends a board's events to a Telegram chat through a bot the owner created with
+// @BotFather and added to the chat. A board has at most one.
+type TelegramConfig struct {
+	ID        string              `bson:"_id,omitempty" json:"id"`
+	BoardID   string              `bson:"board_id" json:"boardId" validate:"required"`
+	UserID    string              `bson:"user_id" json:"userId" validate:"required"` // Owner who configured it
+	BotToken  string              `bson:"bot_token" json:"-"`
+	ChatID    string              `bson:"chat_id" json:"chatId"` // Numeric chat ID or @channelusername
+	Events    []NotificationEvent `bson:"events" json:"events"`  // Empty sends every event
+	Enabled   bool                `bson:"enabled" json:"enabled"`
+	LastError string              `bson:"last_error,omitempty" json:"lastError,omitempty"` // Why the last message failed permanently
+	CreatedAt time.Time           `bson:"created_at" json:"createdAt"`
+	UpdatedAt time.Time           `bson:"updated_at" json:"updatedAt"`
+}
+
+// Subscribed reports whether the chat receives an event
+func (t *TelegramConfig) Subscribed(event NotificationEvent) bool {
+	if len(t.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range t.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// BoardTemplate represents a shared board template in the community gallery
+type BoardTemplate struct {
+	ID             string         `bson:"_id,omitempty" json:"id"`
+	Name           string         `bson:"name" json:"name" validate:"required,min=1,max=100"`
+	Description    string         `bson:"description,omitempty" json:"description,omitempty" validate:"max=500"`
+	Category       string         `bson:"category" json:"category" validate:"required"`
+	AuthorID       string         `bson:"author_id" json:"authorId" validate:"required"`
+	SourceBoardID  string         `bson:"source_board_id" json:"sourceBoardId"`
+	VisibleColumns []string       `bson:"visible_columns" json:"visibleColumns"`
+	VisibleFields  []string       `bson:"visible_fields" json:"visibleFields"`
+	Ideas          []TemplateIdea `bson:"ideas" json:"ideas"`
+	IsPublished    bool           `bson:"is_published" json:"isPublished"`
+	InstallCount   int            `bson:"install_count" json:"installCount"`
+	CreatedAt      time.Time      `bson:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time      `bson:"updated_at" json:"updatedAt"`
+}
+
+// TemplateIdea represents an idea snapshot stored in a template (feedback is never copied)
+type TemplateIdea struct {
+	OneLiner       string    `bson:"one_liner" json:"oneLiner"`
+	Description    string    `bson:"description,omitempty" json:"description,omitempty"`
+	ValueStatement string    `bson:"value_statement,omitempty" json:"valueStatement,omitempty"`
+	RiceScore      RICEScore `bson:"rice_score" json:"riceScore"`
+	Column         string    `bson:"column" json:"column"`
+	Position       int       `bson:"position" json:"position"`
+}
+
+// TemplateCategory represents the gallery categories a template can be listed under
+type TemplateCategory string
+
+const (
+	CategoryProduct     TemplateCategory = "product"
+	CategoryEngineering TemplateCategory = "engineering"
+	CategoryMarketing   TemplateCategory = "marketing"
+	CategoryDesign      TemplateCategory = "design"
+	CategoryStartup     TemplateCategory = "startup"
+	CategoryOther       TemplateCategory = "other"
+)
+
+// MaxTemplateIdeas caps how many ideas a template snapshot may carry
+const MaxTemplateIdeas = 100
+
+// GetTemplateCategories returns all template categories
+func GetTemplateCategories() []string {
+	return []string{
+		string(CategoryProduct),
+		string(CategoryEngineering),
+		string(CategoryMarketing),
+		string(CategoryDesign),
+		string(CategoryStartup),
+		string(CategoryOther),
+	}
+}
+
+// IsValidTemplateCategory checks if a template category is valid
+func IsValidTemplateCategory(category string) bool {
+	for _, valid := range GetTemplateCategories() {
+		if category == valid {
+			return true
+		}
+	}
+	return false
+}
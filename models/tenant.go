@@ -0,0 +1,263 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Tenancy modes selectable with TENANCY_MODE. With tenancy on, every workspace (Clerk organization)
+// is a tenant whose boards, ideas and everything attached to them are kept apart from the other
+// workspaces; data outside any workspace stays in the main database.
+const (
+	TenancyOff      = "off"      // Every workspace shares the main database
+	TenancyDatabase = "database" // Each workspace has its own database, <MONGODB_DATABASE>_<workspace ID>
+	TenancyPrefix   = "prefix"   // Each workspace has its own collections, tenant_<workspace ID>.<collection>
+)
+
+// tenantCollectionPrefix starts the collection names of every tenant in prefix mode
+const tenantCollectionPrefix = "tenant_"
+
+// Tenant is a workspace with isolated data, recorded when an instance first prepares its collections
+type Tenant struct {
+	ID        string    `bson:"_id" json:"id"` // Workspace (Clerk organization) ID
+	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
+}
+
+// sharedCollections belong to users or to the deployment rather than to a workspace, so they stay in
+// the main database whatever the tenant
+var sharedCollections = map[string]bool{
+	UsersCollection:             true,
+	ImpersonationsCollection:    true,
+	PersonalTokensCollection:    true,
+	PushSubscriptionsCollection: true,
+	ScheduledJobsCollection:     true,
+	TenantsCollection:           true,
+}
+
+// tenantIDPattern accepts workspace IDs that are safe in database and collection names
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,40}$`)
+
+// tenantContextKey stores the tenant of a context
+type tenantContextKey struct{}
+
+var (
+	tenancyMode = TenancyOff
+
+	tenantSetupMu  sync.Mutex
+	preparedTenant sync.Map // Tenants whose indexes and validators this instance set up
+)
+
+// loadTenancyMode reads TENANCY_MODE (off by default)
+func loadTenancyMode() error {
+	return SetTenancyMode(os.Getenv("TENANCY_MODE"))
+}
+
+// SetTenancyMode selects how workspace data is kept apart, e.g. in tests
+func SetTenancyMode(mode string) error {
+	switch mode {
+	case "", TenancyOff:
+		tenancyMode = TenancyOff
+	case TenancyDatabase, TenancyPrefix:
+		tenancyMode = mode
+	default:
+		return fmt.Errorf("unsupported TENANCY_MODE %q (supported: off, database, prefix)", mode)
+	}
+	return nil
+}
+
+// TenancyEnabled reports whether workspaces keep their data apart
+func TenancyEnabled() bool {
+	return tenancyMode != TenancyOff
+}
+
+// IsValidTenantID reports whether a workspace ID can name a tenant
+func IsValidTenantID(tenantID string) bool {
+	return tenantIDPattern.MatchString(tenantID)
+}
+
+// WithTenant scopes the collections GetCollection returns for ctx to a workspace; "" is the main
+// database. Has no effect with tenancy off.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the workspace ctx is scoped to, or "" for the main database
+func TenantFromContext(ctx context.Context) string {
+	if tenancyMode == TenancyOff || ctx == nil {
+		return ""
+	}
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenantID
+}
+
+// DetachTenant returns a background context with the tenant of ctx, for work that outlives the
+// request that started it
+func DetachTenant(ctx context.Context) context.Context {
+	return WithTenant(context.Background(), TenantFromContext(ctx))
+}
+
+// tenantCollection returns the collection holding a tenant's documents of the given collection
+func tenantCollection(tenantID, collectionName string) *mongo.Collection {
+	if tenantID == "" || sharedCollections[collectionName] {
+		return DB.DB.Collection(collectionName)
+	}
+	switch tenancyMode {
+	case TenancyDatabase:
+		return DB.Client.Database(DB.DB.Name() + "_" + tenantID).Collection(collectionName)
+	case TenancyPrefix:
+		return DB.DB.Collection(tenantCollectionPrefix + tenantID + "." + collectionName)
+	}
+	return DB.DB.Collection(collectionName)
+}
+
+// TenantCollectionNames lists the collections holding documents of the tenant of ctx, by the names
+// GetCollection takes. The main database's list leaves out the collections of tenants.
+func TenantCollectionNames(ctx context.Context) ([]string, error) {
+	tenantID := TenantFromContext(ctx)
+	database := DB.DB
+	if tenantID != "" && tenancyMode == TenancyDatabase {
+		database = DB.Client.Database(DB.DB.Name() + "_" + tenantID)
+	}
+
+	names, err := database.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, err
+	}
+	collections := make([]string, 0, len(names))
+	for _, name := range names {
+		if tenancyMode == TenancyPrefix {
+			if tenantID != "" {
+				var ok bool
+				if name, ok = strings.CutPrefix(name, tenantCollectionPrefix+tenantID+"."); !ok {
+					continue
+				}
+			} else if strings.HasPrefix(name, tenantCollectionPrefix) {
+				continue
+			}
+		}
+		collections = append(collections, name)
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// PrepareTenant sets up the indexes and validators of a workspace's collections the first time this
+// instance meets it, and records the workspace so background work visits it. Does nothing with
+// tenancy off or for the main database.
+func PrepareTenant(ctx context.Context, tenantID string) error {
+	if tenancyMode == TenancyOff || tenantID == "" {
+		return nil
+	}
+	if !IsValidTenantID(tenantID) {
+		return fmt.Errorf("invalid tenant ID %q", tenantID)
+	}
+	if _, ok := preparedTenant.Load(tenantID); ok {
+		return nil
+	}
+
+	tenantSetupMu.Lock()
+	defer tenantSetupMu.Unlock()
+	if _, ok := preparedTenant.Load(tenantID); ok {
+		return nil
+	}
+
+	// Setting up must finish even if the request that triggered it gives up
+	tenantCtx := WithTenant(context.WithoutCancel(ctx), tenantID)
+	if err := setupTenant(tenantCtx); err != nil {
+		return err
+	}
+	_, err := GetCollection(tenantCtx, TenantsCollection).UpdateOne(tenantCtx,
+		bson.M{"_id": tenantID},
+		bson.M{"$setOnInsert": bson.M{"created_at": time.Now().UTC()}},
+		options.UpdateOne().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to record tenant %s: %w", tenantID, err)
+	}
+
+	preparedTenant.Store(tenantID, true)
+	log.Printf("Tenant prepared - TenantID: %s, Mode: %s", tenantID, tenancyMode)
+	return nil
+}
+
+// setupTenant migrates, indexes and validates the collections of the tenant of ctx
+func setupTenant(ctx context.Context) error {
+	if err := runMigrations(ctx); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	if err := setupIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to setup database indexes: %w", err)
+	}
+	setupValidators(ctx)
+	return nil
+}
+
+// prepareKnownTenants brings the collections of every recorded tenant up to date on start, so
+// migrations and new indexes reach them before their first request
+func prepareKnownTenants() {
+	if tenancyMode == TenancyOff {
+		return
+	}
+	tenantIDs, err := TenantIDs(context.Background())
+	if err != nil {
+		log.Printf("Warning: failed to list tenants, they are set up on their first request: %v", err)
+		return
+	}
+	for _, tenantID := range tenantIDs {
+		if err := PrepareTenant(context.Background(), tenantID); err != nil {
+			log.Printf("Warning: failed to set up tenant %s: %v", tenantID, err)
+		}
+	}
+	log.Printf("Tenancy mode %s - Tenants: %d", tenancyMode, len(tenantIDs))
+}
+
+// TenantIDs returns the recorded tenants, sorted
+func TenantIDs(ctx context.Context) ([]string, error) {
+	if tenancyMode == TenancyOff {
+		return nil, nil
+	}
+	cursor, err := GetCollection(ctx, TenantsCollection).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var tenants []Tenant
+	if err := cursor.All(ctx, &tenants); err != nil {
+		return nil, err
+	}
+	tenantIDs := make([]string, 0, len(tenants))
+	for _, tenant := range tenants {
+		tenantIDs = append(tenantIDs, tenant.ID)
+	}
+	return tenantIDs, nil
+}
+
+// ForEachTenant calls fn with ctx scoped to the main database, then to each recorded tenant, so
+// background work reaches every workspace. With tenancy off fn runs once.
+func ForEachTenant(ctx context.Context, fn func(ctx context.Context)) {
+	fn(WithTenant(ctx, ""))
+	if tenancyMode == TenancyOff {
+		return
+	}
+
+	tenantIDs, err := TenantIDs(ctx)
+	if err != nil {
+		log.Printf("[Tenancy] Failed to list tenants: %v", err)
+		return
+	}
+	for _, tenantID := range tenantIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		fn(WithTenant(ctx, tenantID))
+	}
+}
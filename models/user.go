@@ -0,0 +1,90 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// User is a signed-in user's profile, cached from the identity provider so responses can show
+// human-readable names and avatars without client-side provider lookups
+type User struct {
+	ID          string    `bson:"_id" json:"id"` // Identity provider user ID
+	DisplayName string    `bson:"display_name" json:"displayName"`
+	Email       string    `bson:"email,omitempty" json:"email,omitempty"`
+	AvatarURL   string    `bson:"avatar_url,omitempty" json:"avatarUrl,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"createdAt"`
+	SyncedAt    time.Time `bson:"synced_at" json:"syncedAt"`
+}
+
+// UserProfile is the part of a user's profile shown to other users; the email stays private
+type UserProfile struct {
+	ID          string `bson:"_id" json:"id"`
+	DisplayName string `bson:"display_name" json:"displayName"`
+	AvatarURL   string `bson:"avatar_url,omitempty" json:"avatarUrl,omitempty"`
+}
+
+// UserProfileSyncInterval is how often a user's cached profile is refreshed from the identity provider
+const UserProfileSyncInterval = time.Hour
+
+// UpsertUser stores the latest profile of a user, creating the user on first sight
+func UpsertUser(ctx context.Context, user *User) error {
+	now := time.Now().UTC()
+	_, err := GetCollection(ctx, UsersCollection).UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{
+		"$set": bson.M{
+			"display_name": user.DisplayName,
+			"email":        user.Email,
+			"avatar_url":   user.AvatarURL,
+			"synced_at":    now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+// FindUserProfiles returns the cached profiles of the given users keyed by user ID. Users without
+// a cached profile are left out; empty and repeated IDs are ignored.
+func FindUserProfiles(ctx context.Context, userIDs []string) (map[string]UserProfile, error) {
+	profiles := make(map[string]UserProfile)
+
+	seen := make(map[string]bool)
+	ids := []string{}
+	for _, id := range userIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+
+	cursor, err := GetCollection(ctx, UsersCollection).Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []UserProfile
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+	for _, profile := range found {
+		profiles[profile.ID] = profile
+	}
+	return profiles, nil
+}
+
+// DeletedUserID replaces the user ID in records kept after a user deleted their account
+const DeletedUserID = "deleted_user"
+
+// FindUserEmail returns a user's cached email address, empty if the user has none on file
+func FindUserEmail(ctx context.Context, userID string) (string, error) {
+	var user User
+	if err := GetCollection(ctx, UsersCollection).FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+		return "", err
+	}
+	return user.Email, nil
+}
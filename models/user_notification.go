@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// UserNotificationRetention is how long notifications stay in a user's notification center
+const UserNotificationRetention = 90 * 24 * time.Hour
+
+// UserNotification is an in-app notification kept in a user's notification center until it
+// expires or the user clears it
+type UserNotification struct {
+	ID        string            `bson:"_id" json:"id"`
+	UserID    string            `bson:"user_id" json:"userId"`
+	BoardID   string            `bson:"board_id" json:"boardId"`
+	BoardName string            `bson:"board_name" json:"boardName"`
+	Event     NotificationEvent `bson:"event" json:"event"`
+	IdeaID    string            `bson:"idea_id,omitempty" json:"ideaId,omitempty"`
+	IdeaTitle string            `bson:"idea_title,omitempty" json:"ideaTitle,omitempty"`
+	Summary   string            `bson:"summary" json:"summary"`
+	Read      bool              `bson:"read" json:"read"`
+	ReadAt    *time.Time        `bson:"read_at,omitempty" json:"readAt,omitempty"`
+	CreatedAt time.Time         `bson:"created_at" json:"createdAt"`
+}
+
+// CountUnreadNotifications counts the notifications a user has not read
+func CountUnreadNotifications(ctx context.Context, userID string) (int64, error) {
+	return GetCollection(ctx, UserNotificationsCollection).CountDocuments(ctx, bson.M{"user_id": userID, "read": false})
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -92,6 +93,177 @@ func ValidateBoard(board *Board) ValidationErrors {
 	return errors
 }
 
+// ValidateReactions validates a board's configured reaction set
+func ValidateReactions(reactions []string) ValidationErrors {
+	var errors ValidationErrors
+
+	if len(reactions) > MaxBoardReactions {
+		errors = append(errors, ValidationError{
+			Field:   "reactions",
+			Message: fmt.Sprintf("boards may offer at most %d reactions", MaxBoardReactions),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for i, reaction := range reactions {
+		if !IsValidReaction(reaction) {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("reactions[%d]", i),
+				Message: fmt.Sprintf("invalid reaction: %s", reaction),
+			})
+		} else if seen[reaction] {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("reactions[%d]", i),
+				Message: fmt.Sprintf("duplicate reaction: %s", reaction),
+			})
+		}
+		seen[reaction] = true
+	}
+
+	return errors
+}
+
+// ValidateVoteOptions validates a board's configured vote options
+func ValidateVoteOptions(options []string) ValidationErrors {
+	var errors ValidationErrors
+
+	if len(options) > MaxBoardVoteOptions {
+		errors = append(errors, ValidationError{
+			Field:   "voteOptions",
+			Message: fmt.Sprintf("boards may offer at most %d vote options", MaxBoardVoteOptions),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for i, option := range options {
+		if !IsValidVoteOption(option) {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("voteOptions[%d]", i),
+				Message: fmt.Sprintf("vote options must be 1-%d characters without '.' or '$': %s", MaxVoteOptionLength, option),
+			})
+		} else if seen[option] {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("voteOptions[%d]", i),
+				Message: fmt.Sprintf("duplicate vote option: %s", option),
+			})
+		}
+		seen[option] = true
+	}
+
+	return errors
+}
+
+// ValidateFeedbackRateLimit validates a board feedback rate limit policy, defaulting an empty scope to "idea"
+func ValidateFeedbackRateLimit(policy *FeedbackRateLimit) ValidationErrors {
+	var errors ValidationErrors
+
+	if policy.WindowSeconds < 1 || policy.WindowSeconds > MaxRateLimitWindowSeconds {
+		errors = append(errors, ValidationError{
+			Field:   "windowSeconds",
+			Message: fmt.Sprintf("window must be between 1 and %d seconds", MaxRateLimitWindowSeconds),
+		})
+	}
+	if policy.Burst < 1 || policy.Burst > MaxRateLimitBurst {
+		errors = append(errors, ValidationError{
+			Field:   "burst",
+			Message: fmt.Sprintf("burst must be between 1 and %d", MaxRateLimitBurst),
+		})
+	}
+
+	if policy.Scope == "" {
+		policy.Scope = string(RateLimitScopeIdea)
+	}
+	if !IsValidRateLimitScope(policy.Scope) {
+		errors = append(errors, ValidationError{
+			Field:   "scope",
+			Message: "scope must be idea or board",
+		})
+	}
+
+	return errors
+}
+
+// ValidateBoardIPRules validates a board's IP allow and deny lists, normalizing every entry to CIDR form
+func ValidateBoardIPRules(rules *BoardIPRules) ValidationErrors {
+	var errors ValidationErrors
+
+	for _, list := range []struct {
+		field   string
+		entries *[]string
+	}{{"allow", &rules.Allow}, {"deny", &rules.Deny}} {
+		if len(*list.entries) > MaxBoardIPRules {
+			errors = append(errors, ValidationError{
+				Field:   list.field,
+				Message: fmt.Sprintf("at most %d entries are allowed", MaxBoardIPRules),
+			})
+			continue
+		}
+
+		normalized := []string{}
+		seen := make(map[string]bool)
+		for _, entry := range *list.entries {
+			rule, ok := NormalizeIPRule(entry)
+			if !ok {
+				errors = append(errors, ValidationError{
+					Field:   list.field,
+					Message: fmt.Sprintf("%q is not an IP address or CIDR range", entry),
+				})
+				continue
+			}
+			if !seen[rule] {
+				seen[rule] = true
+				normalized = append(normalized, rule)
+			}
+		}
+		*list.entries = normalized
+	}
+
+	return errors
+}
+
+// ValidatePoll validates an idea poll
+func ValidatePoll(poll *IdeaPoll) ValidationErrors {
+	var errors ValidationErrors
+
+	if strings.TrimSpace(poll.Question) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "question",
+			Message: "question is required",
+		})
+	} else if len(poll.Question) > 200 {
+		errors = append(errors, ValidationError{
+			Field:   "question",
+			Message: "question must be 200 characters or less",
+		})
+	}
+
+	if len(poll.Options) < MinPollOptions || len(poll.Options) > MaxPollOptions {
+		errors = append(errors, ValidationError{
+			Field:   "options",
+			Message: fmt.Sprintf("polls need between %d and %d options", MinPollOptions, MaxPollOptions),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for i, option := range poll.Options {
+		label := strings.TrimSpace(option.Label)
+		if label == "" || len(label) > 100 {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("options[%d]", i),
+				Message: "option labels must be 1-100 characters",
+			})
+		} else if seen[strings.ToLower(label)] {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("options[%d]", i),
+				Message: fmt.Sprintf("duplicate option: %s", label),
+			})
+		}
+		seen[strings.ToLower(label)] = true
+	}
+
+	return errors
+}
+
 // ValidateIdea validates an Idea struct
 func ValidateIdea(idea *Idea) ValidationErrors {
 	var errors ValidationErrors
@@ -167,6 +339,14 @@ func ValidateIdea(idea *Idea) ValidationErrors {
 		})
 	}
 
+	// Validate tags
+	if !IsValidTags(idea.Tags) {
+		errors = append(errors, ValidationError{
+			Field:   "tags",
+			Message: fmt.Sprintf("at most %d tags of up to %d characters each", MaxIdeaTags, MaxTagLength),
+		})
+	}
+
 	// Validate thumbs up count
 	if idea.ThumbsUp < 0 {
 		errors = append(errors, ValidationError{
@@ -200,6 +380,526 @@ func ValidateIdea(idea *Idea) ValidationErrors {
 	return errors
 }
 
+// ValidateTemplate validates a BoardTemplate struct
+func ValidateTemplate(template *BoardTemplate) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate name
+	if strings.TrimSpace(template.Name) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name is required",
+		})
+	} else if len(template.Name) > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name must be 100 characters or less",
+		})
+	}
+
+	// Validate description length
+	if len(template.Description) > 500 {
+		errors = append(errors, ValidationError{
+			Field:   "description",
+			Message: "description must be 500 characters or less",
+		})
+	}
+
+	// Validate category
+	if !IsValidTemplateCategory(template.Category) {
+		errors = append(errors, ValidationError{
+			Field:   "category",
+			Message: fmt.Sprintf("invalid category: %s", template.Category),
+		})
+	}
+
+	// Validate author
+	if strings.TrimSpace(template.AuthorID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "authorId",
+			Message: "author ID is required",
+		})
+	}
+
+	// Validate idea snapshot
+	if len(template.Ideas) > MaxTemplateIdeas {
+		errors = append(errors, ValidationError{
+			Field:   "ideas",
+			Message: fmt.Sprintf("templates may contain at most %d ideas", MaxTemplateIdeas),
+		})
+	}
+
+	// Set timestamps if not set
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = time.Now().UTC()
+	}
+	template.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateExportConfig validates an export config model
+func ValidateExportConfig(config *ExportConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate provider
+	if !IsValidExportProvider(config.Provider) {
+		errors = append(errors, ValidationError{
+			Field:   "provider",
+			Message: fmt.Sprintf("invalid provider: %s", config.Provider),
+		})
+	}
+
+	// Validate bucket and credentials; the server's own storage needs neither
+	if config.Provider != string(ProviderStorage) {
+		if strings.TrimSpace(config.Bucket) == "" {
+			errors = append(errors, ValidationError{
+				Field:   "bucket",
+				Message: "bucket is required",
+			})
+		}
+
+		if strings.TrimSpace(config.AccessKeyID) == "" || strings.TrimSpace(config.SecretAccessKey) == "" {
+			errors = append(errors, ValidationError{
+				Field:   "credentials",
+				Message: "access key ID and secret access key are required",
+			})
+		}
+	}
+
+	// Validate format
+	if !IsValidExportFormat(config.Format) {
+		errors = append(errors, ValidationError{
+			Field:   "format",
+			Message: fmt.Sprintf("unsupported format: %s", config.Format),
+		})
+	}
+
+	// Validate interval
+	if config.IntervalHours < 1 || config.IntervalHours > 720 {
+		errors = append(errors, ValidationError{
+			Field:   "intervalHours",
+			Message: "interval must be between 1 and 720 hours",
+		})
+	}
+
+	// Set timestamps if not set
+	if config.CreatedAt.IsZero() {
+		config.CreatedAt = time.Now().UTC()
+	}
+	config.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateAlertRule validates an AlertRule struct
+func ValidateAlertRule(rule *AlertRule) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate metric
+	if !IsValidAlertMetric(string(rule.Metric)) {
+		errors = append(errors, ValidationError{
+			Field:   "metric",
+			Message: fmt.Sprintf("unknown metric %q (expected idea_thumbs_up, idea_reactions or column_size)", rule.Metric),
+		})
+	}
+
+	// Validate column; only column rules watch one
+	if rule.Metric == AlertColumnSize && !IsValidColumn(rule.Column) {
+		errors = append(errors, ValidationError{
+			Field:   "column",
+			Message: fmt.Sprintf("invalid column: %s", rule.Column),
+		})
+	}
+
+	// Validate threshold
+	if rule.Threshold < 1 || rule.Threshold > 1000000 {
+		errors = append(errors, ValidationError{
+			Field:   "threshold",
+			Message: "threshold must be between 1 and 1000000",
+		})
+	}
+
+	// Set timestamps if not set
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now().UTC()
+	}
+	rule.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// telegramBotTokenRegex matches the tokens @BotFather issues, "<bot ID>:<secret>"
+var telegramBotTokenRegex = regexp.MustCompile(`^[0-9]+:[A-Za-z0-9_-]{30,}$`)
+
+// telegramChatIDRegex matches a numeric chat ID, negative for groups and channels, or a public
+// channel's @username
+var telegramChatIDRegex = regexp.MustCompile(`^(-?[0-9]+|@[A-Za-z][A-Za-z0-9_]{4,31})$`)
+
+// ValidateTelegramConfig validates a TelegramConfig struct
+func ValidateTelegramConfig(config *TelegramConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate bot token
+	if !telegramBotTokenRegex.MatchString(config.BotToken) {
+		errors = append(errors, ValidationError{
+			Field:   "botToken",
+			Message: "bot token must be the token @BotFather issued, as in 123456:ABC-DEF...",
+		})
+	}
+
+	// Validate chat ID
+	if !telegramChatIDRegex.MatchString(config.ChatID) {
+		errors = append(errors, ValidationError{
+			Field:   "chatId",
+			Message: "chat ID must be a numeric chat ID or a channel @username",
+		})
+	}
+
+	// Validate events
+	for _, event := range config.Events {
+		if !IsValidNotificationEvent(string(event)) {
+			errors = append(errors, ValidationError{
+				Field:   "events",
+				Message: fmt.Sprintf("unknown event %q (expected new_feedback, new_submission, comment, status_change or alert)", event),
+			})
+		}
+	}
+
+	// Set timestamps if not set
+	if config.CreatedAt.IsZero() {
+		config.CreatedAt = time.Now().UTC()
+	}
+	config.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// jiraProjectKeyRegex matches Jira project keys, such as DISKO
+var jiraProjectKeyRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,9}$`)
+
+// ValidateJiraConfig validates a JiraConfig struct
+func ValidateJiraConfig(config *JiraConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate site URL; issues are linked from it and API tokens are sent to it
+	if parsed, err := url.Parse(config.SiteURL); err != nil || parsed.Scheme != "https" || parsed.User != nil || strings.Trim(parsed.Path, "/") != "" || parsed.RawQuery != "" {
+		errors = append(errors, ValidationError{
+			Field:   "siteUrl",
+			Message: "site URL must be the https URL of the Jira site, as in https://your-domain.atlassian.net",
+		})
+	} else if !IsAllowedJiraSite(parsed.Host) {
+		errors = append(errors, ValidationError{
+			Field:   "siteUrl",
+			Message: fmt.Sprintf("site %q is not a Jira Cloud site (*.atlassian.net) or listed in JIRA_ALLOWED_HOSTS", parsed.Host),
+		})
+	}
+
+	// Validate credentials for the auth type
+	switch config.AuthType {
+	case JiraAuthAPIToken:
+		if !strings.Contains(config.Email, "@") || config.APIToken == "" {
+			errors = append(errors, ValidationError{
+				Field:   "apiToken",
+				Message: "API token auth requires the account email and an API token",
+			})
+		}
+	case JiraAuthOAuth:
+		if strings.TrimSpace(config.CloudID) == "" || config.AccessToken == "" {
+			errors = append(errors, ValidationError{
+				Field:   "accessToken",
+				Message: "OAuth requires the site's cloud ID and an access token",
+			})
+		}
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "authType",
+			Message: fmt.Sprintf("unknown auth type %q (expected api_token or oauth)", config.AuthType),
+		})
+	}
+
+	// Validate project key
+	if !jiraProjectKeyRegex.MatchString(config.ProjectKey) {
+		errors = append(errors, ValidationError{
+			Field:   "projectKey",
+			Message: "project key must be the key of a Jira project, as in DISKO",
+		})
+	}
+
+	// Validate issue type
+	if strings.TrimSpace(config.IssueType) == "" || len(config.IssueType) > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "issueType",
+			Message: "issue type must be between 1 and 100 characters",
+		})
+	}
+
+	// Set timestamps if not set
+	if config.CreatedAt.IsZero() {
+		config.CreatedAt = time.Now().UTC()
+	}
+	config.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateLinearConfig validates a LinearConfig struct
+func ValidateLinearConfig(config *LinearConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate API key
+	if config.APIKey == "" {
+		errors = append(errors, ValidationError{
+			Field:   "apiKey",
+			Message: "API key is required",
+		})
+	}
+
+	// Validate team
+	if strings.TrimSpace(config.TeamID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "teamKey",
+			Message: "team is required",
+		})
+	}
+
+	// Validate state columns
+	for stateType, column := range config.StateColumns {
+		if !IsValidLinearStateType(stateType) {
+			errors = append(errors, ValidationError{
+				Field:   "stateColumns",
+				Message: fmt.Sprintf("unknown state type %q (expected triage, backlog, unstarted, started, completed or canceled)", stateType),
+			})
+		}
+		if !IsValidColumn(column) {
+			errors = append(errors, ValidationError{
+				Field:   "stateColumns",
+				Message: fmt.Sprintf("invalid column: %s", column),
+			})
+		}
+	}
+
+	// Set timestamps if not set
+	if config.CreatedAt.IsZero() {
+		config.CreatedAt = time.Now().UTC()
+	}
+	config.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateRelease validates a Release struct
+func ValidateRelease(release *Release) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate board ID
+	if strings.TrimSpace(release.BoardID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "boardId",
+			Message: "board ID is required",
+		})
+	}
+
+	// Validate name
+	if strings.TrimSpace(release.Name) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name is required",
+		})
+	} else if len(release.Name) > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: "name must be 100 characters or less",
+		})
+	}
+
+	// Validate notes length
+	if len(release.Notes) > 5000 {
+		errors = append(errors, ValidationError{
+			Field:   "notes",
+			Message: "notes must be 5000 characters or less",
+		})
+	}
+
+	// Set timestamps if not set
+	if release.CreatedAt.IsZero() {
+		release.CreatedAt = time.Now().UTC()
+	}
+	release.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateSubmission validates an IdeaSubmission struct
+func ValidateSubmission(submission *IdeaSubmission) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate board ID
+	if strings.TrimSpace(submission.BoardID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "boardId",
+			Message: "board ID is required",
+		})
+	}
+
+	// Validate one-liner
+	if strings.TrimSpace(submission.OneLiner) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "oneLiner",
+			Message: "one-liner is required",
+		})
+	} else if len(submission.OneLiner) > 200 {
+		errors = append(errors, ValidationError{
+			Field:   "oneLiner",
+			Message: "one-liner must be 200 characters or less",
+		})
+	}
+
+	// Validate description (optional)
+	if len(submission.Description) > 1000 {
+		errors = append(errors, ValidationError{
+			Field:   "description",
+			Message: "description must be 1000 characters or less",
+		})
+	}
+
+	// Validate value statement (optional)
+	if len(submission.ValueStatement) > 500 {
+		errors = append(errors, ValidationError{
+			Field:   "valueStatement",
+			Message: "value statement must be 500 characters or less",
+		})
+	}
+
+	// Validate submitter details (optional)
+	if len(submission.SubmitterName) > 100 {
+		errors = append(errors, ValidationError{
+			Field:   "submitterName",
+			Message: "submitter name must be 100 characters or less",
+		})
+	}
+	if submission.SubmitterEmail != "" && !IsValidEmail(submission.SubmitterEmail) {
+		errors = append(errors, ValidationError{
+			Field:   "submitterEmail",
+			Message: "submitter email is not a valid email address",
+		})
+	}
+
+	// Validate status
+	if !IsValidSubmissionStatus(submission.Status) {
+		errors = append(errors, ValidationError{
+			Field:   "status",
+			Message: "invalid submission status",
+		})
+	}
+
+	// Set timestamps if not set
+	if submission.CreatedAt.IsZero() {
+		submission.CreatedAt = time.Now().UTC()
+	}
+	submission.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateComment validates a Comment struct
+func ValidateComment(comment *Comment) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate board and idea IDs
+	if strings.TrimSpace(comment.BoardID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "boardId",
+			Message: "board ID is required",
+		})
+	}
+	if strings.TrimSpace(comment.IdeaID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "ideaId",
+			Message: "idea ID is required",
+		})
+	}
+
+	// Validate body
+	if strings.TrimSpace(comment.Body) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "body",
+			Message: "comment body is required",
+		})
+	} else if len(comment.Body) > 1000 {
+		errors = append(errors, ValidationError{
+			Field:   "body",
+			Message: "comment body must be 1000 characters or less",
+		})
+	}
+
+	// Validate author name (optional)
+	if len(comment.AuthorName) > 50 {
+		errors = append(errors, ValidationError{
+			Field:   "authorName",
+			Message: "author name must be 50 characters or less",
+		})
+	}
+
+	// Validate status
+	if !IsValidCommentStatus(comment.Status) {
+		errors = append(errors, ValidationError{
+			Field:   "status",
+			Message: "invalid comment status",
+		})
+	}
+
+	// Set timestamps if not set
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now().UTC()
+	}
+	comment.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
+// ValidateSubscriber validates a Subscriber struct
+func ValidateSubscriber(subscriber *Subscriber) ValidationErrors {
+	var errors ValidationErrors
+
+	// Validate board ID
+	if strings.TrimSpace(subscriber.BoardID) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "boardId",
+			Message: "board ID is required",
+		})
+	}
+
+	// Validate email
+	if strings.TrimSpace(subscriber.Email) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "email",
+			Message: "email is required",
+		})
+	} else if len(subscriber.Email) > 254 || !IsValidEmail(subscriber.Email) {
+		errors = append(errors, ValidationError{
+			Field:   "email",
+			Message: "email is not a valid email address",
+		})
+	}
+
+	// Validate status
+	if !IsValidSubscriberStatus(subscriber.Status) {
+		errors = append(errors, ValidationError{
+			Field:   "status",
+			Message: "invalid subscriber status",
+		})
+	}
+
+	// Set timestamps if not set
+	if subscriber.CreatedAt.IsZero() {
+		subscriber.CreatedAt = time.Now().UTC()
+	}
+	subscriber.UpdatedAt = time.Now().UTC()
+
+	return errors
+}
+
 // IsValidUUID checks if a string is a valid UUID format
 func IsValidUUID(uuid string) bool {
 	uuidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
@@ -211,3 +911,44 @@ func IsValidEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
 }
+
+// ValidateWebhookEvents validates the event types a webhook subscribes to
+func ValidateWebhookEvents(events []WebhookEventType) ValidationErrors {
+	var errors ValidationErrors
+
+	for _, event := range events {
+		if !IsValidWebhookEventType(string(event)) {
+			errors = append(errors, ValidationError{
+				Field:   "events",
+				Message: fmt.Sprintf("unknown event type %q (see GET /api/webhooks/events)", event),
+			})
+		}
+	}
+
+	return errors
+}
+
+// ValidateNotificationChannels validates the channels a notification preference selects per event
+func ValidateNotificationChannels(channels map[NotificationEvent][]NotificationChannel) ValidationErrors {
+	var errors ValidationErrors
+
+	for event, selected := range channels {
+		if !IsValidNotificationEvent(string(event)) {
+			errors = append(errors, ValidationError{
+				Field:   "channels",
+				Message: fmt.Sprintf("unknown event %q (expected new_feedback, new_submission, comment, status_change or alert)", event),
+			})
+			continue
+		}
+		for _, channel := range selected {
+			if !IsValidNotificationChannel(string(channel)) {
+				errors = append(errors, ValidationError{
+					Field:   "channels." + string(event),
+					Message: fmt.Sprintf("unknown channel %q (expected email, slack, webhook, in_app or push)", channel),
+				})
+			}
+		}
+	}
+
+	return errors
+}
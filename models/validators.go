@@ -0,0 +1,129 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// namespaceExistsCode is the server error for creating a collection that already exists
+const namespaceExistsCode = 48
+
+// setupValidators makes MongoDB reject board and idea documents of the wrong shape, so a bug or
+// script writing malformed data fails loudly instead of breaking the pages that read it. Validation
+// is moderate: documents that were already invalid can still be updated. Applying a validator needs
+// the collMod privilege; without it the server is left as it was and a warning is logged. Applies to
+// the collections of the tenant of ctx.
+func setupValidators(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	validators := []struct {
+		collection string
+		schema     bson.M
+	}{
+		{BoardsCollection, boardSchema()},
+		{IdeasCollection, ideaSchema()},
+	}
+	for _, validator := range validators {
+		if err := applyValidator(ctx, validator.collection, validator.schema); err != nil {
+			log.Printf("Warning: failed to apply the schema validator of %s, writes are not validated by the database: %v",
+				validator.collection, err)
+		}
+	}
+}
+
+// applyValidator creates the collection with a $jsonSchema validator, or replaces the validator of
+// the existing collection
+func applyValidator(ctx context.Context, collection string, schema bson.M) error {
+	validator := bson.M{"$jsonSchema": schema}
+	target := GetCollection(ctx, collection)
+	err := target.Database().CreateCollection(ctx, target.Name(), options.CreateCollection().
+		SetValidator(validator).
+		SetValidationLevel("moderate").
+		SetValidationAction("error"))
+
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Code == namespaceExistsCode {
+		err = target.Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: target.Name()},
+			{Key: "validator", Value: validator},
+			{Key: "validationLevel", Value: "moderate"},
+			{Key: "validationAction", Value: "error"},
+		}).Err()
+	}
+	return err
+}
+
+// boardSchema describes the fields of a Board the rest of the code relies on; others are unchecked
+func boardSchema() bson.M {
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{"_id", "name", "public_link", "user_id", "is_public", "created_at", "updated_at"},
+		"properties": bson.M{
+			"_id":             bson.M{"bsonType": "string", "minLength": 1},
+			"name":            bson.M{"bsonType": "string", "minLength": 1},
+			"description":     bson.M{"bsonType": "string"},
+			"public_link":     bson.M{"bsonType": "string", "minLength": 1},
+			"is_public":       bson.M{"bsonType": "bool"},
+			"user_id":         bson.M{"bsonType": "string", "minLength": 1},
+			"visible_columns": bson.M{"bsonType": bson.A{"array", "null"}, "items": bson.M{"enum": stringsToArray(GetDefaultVisibleColumns())}},
+			"visible_fields":  bson.M{"bsonType": bson.A{"array", "null"}, "items": bson.M{"bsonType": "string"}},
+			"archived":        bson.M{"bsonType": "bool"},
+			"frozen":          bson.M{"bsonType": "bool"},
+			"workspace_id":    bson.M{"bsonType": "string"},
+			"members":         bson.M{"bsonType": "array"},
+			"version":         bson.M{"bsonType": "number", "minimum": 0},
+			"idea_moves":      bson.M{"bsonType": "number", "minimum": 0}, // Move counter; see MoveIdea
+			"created_by":      bson.M{"bsonType": "string"},
+			"updated_by":      bson.M{"bsonType": "string"},
+			"created_at":      bson.M{"bsonType": "date"},
+			"updated_at":      bson.M{"bsonType": "date"},
+		},
+	}
+}
+
+// ideaSchema describes the fields of an Idea the rest of the code relies on; others are unchecked
+func ideaSchema() bson.M {
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{"_id", "board_id", "one_liner", "column", "position", "status", "created_at", "updated_at"},
+		"properties": bson.M{
+			"_id":             bson.M{"bsonType": "string", "minLength": 1},
+			"board_id":        bson.M{"bsonType": "string", "minLength": 1},
+			"one_liner":       bson.M{"bsonType": "string", "minLength": 1},
+			"description":     bson.M{"bsonType": "string"},
+			"value_statement": bson.M{"bsonType": "string"},
+			"rice_score":      bson.M{"bsonType": "object"},
+			"column":          bson.M{"enum": stringsToArray(GetDefaultVisibleColumns())},
+			"position":        bson.M{"bsonType": "number", "minimum": 0},
+			"in_progress":     bson.M{"bsonType": "bool"},
+			"status": bson.M{"enum": bson.A{
+				string(StatusDraft), string(StatusActive), string(StatusDone), string(StatusArchived),
+			}},
+			"tags":            bson.M{"bsonType": bson.A{"array", "null"}, "items": bson.M{"bsonType": "string"}},
+			"thumbs_up":       bson.M{"bsonType": "number", "minimum": 0},
+			"emoji_reactions": bson.M{"bsonType": bson.A{"array", "null"}},
+			"votes":           bson.M{"bsonType": "object"},
+			"version":         bson.M{"bsonType": "number", "minimum": 0},
+			"created_by":      bson.M{"bsonType": "string"},
+			"updated_by":      bson.M{"bsonType": "string"},
+			"created_at":      bson.M{"bsonType": "date"},
+			"updated_at":      bson.M{"bsonType": "date"},
+		},
+	}
+}
+
+// stringsToArray converts strings for use in a schema
+func stringsToArray(values []string) bson.A {
+	array := make(bson.A, 0, len(values))
+	for _, value := range values {
+		array = append(array, value)
+	}
+	return array
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// Vote records one anonymous visitor's feedback on an idea so it can be counted once
+type Vote struct {
+	ID        string    `bson:"_id,omitempty" json:"id"`
+	BoardID   string    `bson:"board_id" json:"boardId"`
+	IdeaID    string    `bson:"idea_id" json:"ideaId"`
+	VisitorID string    `bson:"visitor_id" json:"-"`
+	Type      string    `bson:"type" json:"type"`
+	Emoji     string    `bson:"emoji" json:"emoji,omitempty"`             // Always stored so the unique index treats "" consistently
+	Option    string    `bson:"option,omitempty" json:"option,omitempty"` // Chosen board vote option
+	Note      string    `bson:"note,omitempty" json:"note,omitempty"`     // Optional visitor context, owner-only
+	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
+
+	Attribution `bson:",inline"`
+}
+
+// Attribution records where a piece of feedback came from, owner-only
+type Attribution struct {
+	Source   string `bson:"source,omitempty" json:"source,omitempty"`     // Campaign or channel tag, e.g. from ?source= or utm_source
+	Referrer string `bson:"referrer,omitempty" json:"referrer,omitempty"` // Referring host only, never the full URL
+	Device   string `bson:"device,omitempty" json:"device,omitempty"`     // Coarse user agent class
+}
+
+// DeviceClass is the coarse user agent class stored with feedback
+type DeviceClass string
+
+const (
+	DeviceDesktop DeviceClass = "desktop"
+	DeviceMobile  DeviceClass = "mobile"
+	DeviceTablet  DeviceClass = "tablet"
+	DeviceBot     DeviceClass = "bot"
+	DeviceUnknown DeviceClass = "unknown"
+)
+
+// VoteType represents the kinds of per-visitor feedback that are deduplicated
+type VoteType string
+
+const (
+	VoteThumbsUp VoteType = "thumbsup"
+	VoteEmoji    VoteType = "emoji"
+	VoteOption   VoteType = "option" // One per visitor and idea; the choice can change
+	VotePoll     VoteType = "poll"   // One answer per visitor and idea poll
+)
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookDeliveryRetention is how long webhook delivery attempts are kept
+const WebhookDeliveryRetention = 30 * 24 * time.Hour
+
+// WebhookDelivery records one attempt to deliver an event to a board webhook. The attempts of an
+// event share its DeliveryID, which receivers see in the X-Disko-Webhook-Id header.
+type WebhookDelivery struct {
+	ID          string           `bson:"_id,omitempty" json:"id"`
+	DeliveryID  string           `bson:"delivery_id" json:"deliveryId"`
+	WebhookID   string           `bson:"webhook_id" json:"webhookId"`
+	BoardID     string           `bson:"board_id" json:"boardId"`
+	Event       WebhookEventType `bson:"event" json:"event"`
+	URL         string           `bson:"url" json:"url"`
+	Attempt     int              `bson:"attempt" json:"attempt"` // 1 for the first try
+	Success     bool             `bson:"success" json:"success"`
+	StatusCode  int              `bson:"status_code,omitempty" json:"statusCode,omitempty"`
+	Error       string           `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs  int64            `bson:"duration_ms" json:"durationMs"`
+	NextRetryAt *time.Time       `bson:"next_retry_at,omitempty" json:"nextRetryAt,omitempty"` // Unset once the event succeeded or gave up
+	CreatedAt   time.Time        `bson:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookEventType is an event in the catalog board webhooks deliver. The names are part of the
+// public API automations are built on: new types may be added, existing ones are never renamed.
+type WebhookEventType string
+
+const (
+	WebhookIdeaCreated       WebhookEventType = "idea.created"        // Idea added to the board, directly or from an approved submission
+	WebhookIdeaStatusChanged WebhookEventType = "idea.status_changed" // Idea moved to another column or status
+	WebhookIdeaReleased      WebhookEventType = "idea.released"       // Idea moved to the release column
+	WebhookFeedbackReceived  WebhookEventType = "feedback.received"   // Thumbs up or emoji reaction on an idea
+	WebhookCommentCreated    WebhookEventType = "comment.created"     // Comment on an idea, pending or published
+	WebhookSubmissionCreated WebhookEventType = "submission.created"  // Public idea submission awaiting review
+	WebhookBoardPublished    WebhookEventType = "board.published"     // Board made public
+)
+
+// WebhookAPIVersion is the version of the event envelope and data shapes, sent with every event
+const WebhookAPIVersion = "2024-10-01"
+
+// WebhookEventInfo describes an event type in the catalog
+type WebhookEventInfo struct {
+	Type        WebhookEventType `json:"type"`
+	Description string           `json:"description"`
+}
+
+// WebhookEventCatalog lists every event type board webhooks can subscribe to
+var WebhookEventCatalog = []WebhookEventInfo{
+	{WebhookIdeaCreated, "An idea was added to the board, directly or by approving a public submission"},
+	{WebhookIdeaStatusChanged, "An idea moved to another column or status"},
+	{WebhookIdeaReleased, "An idea moved to the release column"},
+	{WebhookFeedbackReceived, "A visitor reacted to an idea with a thumbs up or an emoji"},
+	{WebhookCommentCreated, "A visitor commented on an idea; the comment may await approval"},
+	{WebhookSubmissionCreated, "A visitor suggested an idea, which awaits review"},
+	{WebhookBoardPublished, "The board was made public"},
+}
+
+// legacyWebhookEvents maps the notification event names webhooks subscribed to before the catalog
+// existed to their catalog types
+var legacyWebhookEvents = map[WebhookEventType]WebhookEventType{
+	WebhookEventType(NotifyNewFeedback):   WebhookFeedbackReceived,
+	WebhookEventType(NotifyNewSubmission): WebhookSubmissionCreated,
+	WebhookEventType(NotifyComment):       WebhookCommentCreated,
+	WebhookEventType(NotifyStatusChange):  WebhookIdeaStatusChanged,
+}
+
+// Canonical returns the catalog type of a legacy notification event name, or the type unchanged
+func (t WebhookEventType) Canonical() WebhookEventType {
+	if canonical, ok := legacyWebhookEvents[t]; ok {
+		return canonical
+	}
+	return t
+}
+
+// IsValidWebhookEventType checks if an event type is in the catalog. Legacy notification event
+// names are accepted too.
+func IsValidWebhookEventType(event string) bool {
+	for _, info := range WebhookEventCatalog {
+		if WebhookEventType(event).Canonical() == info.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEventBoard identifies the board an event happened on
+type WebhookEventBoard struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebhookEvent is the envelope every board webhook receives. ID is unique per event, so receivers
+// can drop the duplicates retries may cause; Data's shape depends on Type.
+type WebhookEvent struct {
+	ID         string            `json:"id"`
+	Type       WebhookEventType  `json:"type"`
+	APIVersion string            `json:"apiVersion"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	Board      WebhookEventBoard `json:"board"`
+	Data       interface{}       `json:"data"`
+}
+
+// WebhookIdeaData is the idea sent with idea events
+type WebhookIdeaData struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Column      string `json:"column"`
+	Status      string `json:"status"`
+	InProgress  bool   `json:"inProgress"`
+}
+
+// NewWebhookIdeaData builds the idea sent with idea events
+func NewWebhookIdeaData(idea *Idea) WebhookIdeaData {
+	return WebhookIdeaData{
+		ID:          idea.ID,
+		Title:       idea.OneLiner,
+		Description: idea.Description,
+		Column:      idea.Column,
+		Status:      idea.Status,
+		InProgress:  idea.InProgress,
+	}
+}
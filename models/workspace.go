@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Workspace groups boards under a Clerk organization. Its ID is the Clerk organization ID, so
+// membership and roles come from the caller's active organization in their session.
+type Workspace struct {
+	ID        string    `bson:"_id" json:"id"` // Clerk organization ID (org_...)
+	Name      string    `bson:"name" json:"name" validate:"required,min=1,max=100"`
+	Plan      string    `bson:"plan" json:"plan"`
+	CreatedBy string    `bson:"created_by" json:"createdBy"`
+	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updatedAt"`
+}
+
+// WorkspaceRole is a user's role in a workspace, derived from their Clerk organization role
+type WorkspaceRole string
+
+const (
+	WorkspaceAdmin  WorkspaceRole = "admin"  // Manages the workspace; edits all of its boards
+	WorkspaceMember WorkspaceRole = "member" // Views all of the workspace's boards
+)
+
+// WorkspaceRoleFromClerk maps a Clerk organization role ("org:admin", "org:member", ...) to a workspace role
+func WorkspaceRoleFromClerk(orgRole string) WorkspaceRole {
+	switch strings.TrimPrefix(orgRole, "org:") {
+	case "":
+		return ""
+	case "admin":
+		return WorkspaceAdmin
+	}
+	return WorkspaceMember
+}
+
+// BoardRole returns the access a workspace role grants on the workspace's boards
+func (r WorkspaceRole) BoardRole() MemberRole {
+	switch r {
+	case WorkspaceAdmin:
+		return RoleEditor
+	case WorkspaceMember:
+		return RoleViewer
+	}
+	return ""
+}
+
+// WorkspacePlan is the billing plan of a workspace
+type WorkspacePlan string
+
+const (
+	PlanFree     WorkspacePlan = "free"
+	PlanTeam     WorkspacePlan = "team"
+	PlanBusiness WorkspacePlan = "business"
+)
+
+// PlanLimits are the quotas of a workspace plan; zero means unlimited
+type PlanLimits struct {
+	MaxBoards          int `json:"maxBoards"`
+	MaxMembersPerBoard int `json:"maxMembersPerBoard"`
+	WritesPerMinute    int `json:"writesPerMinute"` // API writes per user per minute
+	ReadsPerMinute     int `json:"readsPerMinute"`  // API reads per user per minute
+}
+
+// planLimits holds the quotas of each plan
+var planLimits = map[WorkspacePlan]PlanLimits{
+	PlanFree:     {MaxBoards: 3, MaxMembersPerBoard: 5, WritesPerMinute: 60, ReadsPerMinute: 600},
+	PlanTeam:     {MaxBoards: 25, MaxMembersPerBoard: 25, WritesPerMinute: 300, ReadsPerMinute: 3000},
+	PlanBusiness: {MaxBoards: 0, MaxMembersPerBoard: MaxBoardMembers, WritesPerMinute: 0, ReadsPerMinute: 0},
+}
+
+// IsValidWorkspacePlan checks if a workspace plan is valid
+func IsValidWorkspacePlan(plan string) bool {
+	_, ok := planLimits[WorkspacePlan(plan)]
+	return ok
+}
+
+// Limits returns the quotas of the workspace's plan, falling back to the free plan
+func (w *Workspace) Limits() PlanLimits {
+	if limits, ok := planLimits[WorkspacePlan(w.Plan)]; ok {
+		return limits
+	}
+	return planLimits[PlanFree]
+}
+
+// WithWorkspaceAccess extends a BoardAccessFilter so members of the caller's active organization
+// reach the boards of its workspace when their workspace role grants the required board role
+func WithWorkspaceAccess(filter bson.M, orgID string, role WorkspaceRole, required MemberRole) bson.M {
+	if orgID == "" || !role.BoardRole().Allows(required) {
+		return filter
+	}
+
+	// Owner-only filters never get here: workspace roles grant at most editor access
+	clauses, _ := filter["$or"].([]bson.M)
+	filter["$or"] = append(clauses, bson.M{"workspace_id": orgID})
+	return filter
+}
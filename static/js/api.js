@@ -1,4 +1,19 @@
 // API utility functions
+
+// feedbackAttributionHeaders returns the source tag and external referrer of the current page
+function feedbackAttributionHeaders() {
+    const headers = {};
+    const params = new URLSearchParams(window.location.search);
+    const source = params.get('source') || params.get('utm_source');
+    if (source) {
+        headers['X-Feedback-Source'] = source;
+    }
+    if (document.referrer) {
+        headers['X-Feedback-Referrer'] = document.referrer;
+    }
+    return headers;
+}
+
 class API {
     constructor() {
         this.baseURL = '/api';
@@ -93,6 +108,11 @@ class API {
             config.headers.Authorization = `Bearer ${token}`;
         }
 
+        // Tell the backend where public feedback came from
+        if (isPublicEndpoint) {
+            Object.assign(config.headers, feedbackAttributionHeaders());
+        }
+
         try {
             const response = await fetch(url, config);
             
@@ -143,9 +163,13 @@ class API {
         });
     }
 
-    // DELETE request
-    async delete(endpoint) {
-        return this.request(endpoint, { method: 'DELETE' });
+    // DELETE request (optional body, e.g. a typed confirmation)
+    async delete(endpoint, data) {
+        const options = { method: 'DELETE' };
+        if (data !== undefined) {
+            options.body = JSON.stringify(data);
+        }
+        return this.request(endpoint, options);
     }
 
     // Health check
@@ -184,7 +184,7 @@ class BoardView {
         // Initialize WebSocket connection for real-time updates (both admin and public)
         if (this.boardId && window.WebSocketManager) {
             console.log('[BoardView] WebSocket manager available, initializing connection');
-            this.wsManager = new WebSocketManager(this.boardId);
+            this.wsManager = new WebSocketManager(this.boardId, { getToken: () => this.getAuthToken() });
             // Expose globally for retry functionality
             window.wsManager = this.wsManager;
             this.wsManager.startKeepAlive();
@@ -198,6 +198,11 @@ class BoardView {
             document.addEventListener('ideaUpdated', (event) => {
                 this.handleIdeaUpdate(event.detail);
             });
+
+            // Show in-app notifications chosen in the notification preferences
+            document.addEventListener('boardNotification', (event) => {
+                this.showInfoMessage(event.detail.summary);
+            });
             console.log('[BoardView] WebSocket setup complete');
         } else {
             console.log('[BoardView] WebSocket setup skipped - BoardID:', this.boardId, 'WebSocketManager available:', !!window.WebSocketManager);
@@ -676,7 +681,7 @@ class BoardView {
         try {
             console.log('[BoardView] Confirming board deletion for board:', this.boardId);
             
-            const response = await window.api.delete(`/boards/${this.boardId}`);
+            const response = await window.api.delete(`/boards/${this.boardId}`, { confirmName: enteredName });
             
             if (response) {
                 this.showSuccessMessage('Board deleted successfully!');
@@ -719,6 +724,20 @@ class BoardView {
         }, 6000);
     }
 
+    showInfoMessage(message) {
+        // Notifications quote visitor content, so they are shown as text
+        const messageDiv = document.createElement('div');
+        messageDiv.className = 'message-toast success show';
+        messageDiv.textContent = message;
+        document.body.appendChild(messageDiv);
+
+        setTimeout(() => {
+            if (messageDiv.parentNode) {
+                messageDiv.parentNode.removeChild(messageDiv);
+            }
+        }, 6000);
+    }
+
     showErrorMessage(message) {
         // Create a temporary error message
         const messageDiv = document.createElement('div');
@@ -87,6 +87,25 @@ function updateDashboardStats(boardsCount = 0, ideasCount = 0) {
     }
 }
 
+// Accept a collaborator invitation from an emailed ?invite= link, then drop it from the URL
+async function acceptPendingInvitation() {
+    const params = new URLSearchParams(window.location.search);
+    const token = params.get('invite');
+    if (!token) return;
+
+    params.delete('invite');
+    const query = params.toString();
+    window.history.replaceState({}, '', window.location.pathname + (query ? `?${query}` : ''));
+
+    try {
+        const result = await window.api.post('/invitations/accept', { token });
+        showSuccessMessage(`You joined "${result.boardName}" as ${result.role}`);
+    } catch (error) {
+        console.error('[Dashboard] Failed to accept invitation:', error);
+        showErrorMessage('This invitation is invalid, has expired or was already used');
+    }
+}
+
 async function loadBoards() {
     const boardsList = document.getElementById('boards-list');
     
@@ -112,9 +131,10 @@ async function loadBoards() {
     try {
         console.log('[Dashboard] Starting loadBoards function... (attempt', window.boardLoadAttempts, ')');
         boardsList.innerHTML = '<div class="loading">Loading your boards...</div>';
+        await acceptPendingInvitation();
         console.log('[Dashboard] Making API call to /boards...');
 
-        const response = await window.api.get('/boards');
+        const response = await window.api.get('/boards?pageSize=100');
         console.log('[Dashboard] API response received:', response);
         
         // Reset attempts on success
@@ -139,7 +159,7 @@ async function loadBoards() {
         const totalIdeas = data.boards.reduce((total, board) => {
             return total + (board.ideasCount || 0);
         }, 0);
-        updateDashboardStats(data.boards.length, totalIdeas);
+        updateDashboardStats(data.totalCount || data.boards.length, totalIdeas);
         boardsList.innerHTML = data.boards.map(board => createBoardCard(board)).join('');
         console.log('[Dashboard] Boards rendered successfully');
     } catch (error) {
@@ -375,6 +395,7 @@ function confirmDeleteBoard(boardId, boardName) {
     const boardNameSpan = document.getElementById('delete-board-name');
     
     boardNameSpan.textContent = boardName;
+    document.getElementById('delete-board-confirm-name').value = '';
     modal.classList.add('show');
     
     // Close board menu
@@ -390,6 +411,12 @@ function closeDeleteBoardModal() {
 
 async function deleteBoard() {
     if (!boardToDelete) return;
+
+    const confirmName = document.getElementById('delete-board-confirm-name').value.trim();
+    if (confirmName !== document.getElementById('delete-board-name').textContent.trim()) {
+        showErrorMessage('Board name does not match. Please enter the exact board name to confirm deletion.');
+        return;
+    }
     
     try {
         const deleteBtn = document.getElementById('confirm-delete-btn');
@@ -397,7 +424,7 @@ async function deleteBoard() {
         deleteBtn.disabled = true;
         deleteBtn.textContent = 'Deleting...';
         
-        await window.api.delete(`/boards/${boardToDelete}`);
+        await window.api.delete(`/boards/${boardToDelete}`, { confirmName });
         
         closeDeleteBoardModal();
         await loadBoards();
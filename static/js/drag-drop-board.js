@@ -588,6 +588,13 @@ class DragDropBoard {
 
     async updateIdeaPosition(ideaId, column, position) {
         console.log('Updating idea position:', { ideaId, column, position });
+
+        // Members' board sockets apply moves with lower latency; fall back to REST without one
+        const ws = window.wsManager;
+        if (ws && ws.isConnected && ws.getToken) {
+            return ws.sendOperation('move_idea', ideaId, { column, position });
+        }
+
         const response = await window.api.put(`/ideas/${ideaId}/position`, {
             column: column,
             position: position
@@ -844,7 +851,10 @@ class DragDropBoard {
             existingPicker.remove();
         }
 
-        const emojis = ['🚀', '💡', '🎯', '🔥', '👍', '❤️', '😊', '🎉', '⭐', '💪'];
+        // Use the board's configured reaction set, falling back to the defaults
+        const emojis = (this.board && Array.isArray(this.board.reactions) && this.board.reactions.length > 0)
+            ? this.board.reactions
+            : ['🚀', '💡', '🎯', '🔥', '👍', '❤️', '😊', '🎉', '⭐', '💪'];
         
         const modal = document.createElement('div');
         modal.id = 'emoji-picker-modal';
@@ -0,0 +1,166 @@
+// Disko embeddable widgets: "recently shipped" feed and read-only roadmap
+// Usage:
+//   <script src="https://disko.nomadis.com/embed/widget.js" async></script>
+//   <disko-released-feed token="e..." limit="5"></disko-released-feed>
+//   <disko-roadmap token="e..." limit="5"></disko-roadmap>
+(function () {
+    if (window.customElements && window.customElements.get('disko-released-feed')) {
+        return;
+    }
+
+    // Resolve the API host from the script URL so the widget works on any site
+    const script = document.currentScript;
+    const defaultBase = script ? new URL(script.src).origin : '';
+
+    const styles = `
+        :host { display: block; font-family: system-ui, -apple-system, sans-serif; color: #1f2937; }
+        .feed { list-style: none; margin: 0; padding: 0; }
+        .item { padding: 10px 0; border-bottom: 1px solid #e5e7eb; }
+        .item:last-child { border-bottom: none; }
+        .title { font-weight: 600; margin: 0 0 4px; }
+        .description { margin: 0 0 6px; font-size: 0.9em; color: #4b5563; }
+        .votes { font-size: 0.85em; color: #6b7280; display: flex; gap: 8px; flex-wrap: wrap; }
+        .footer { margin-top: 8px; font-size: 0.8em; }
+        .footer a { color: #6b7280; text-decoration: none; }
+        .empty, .error { font-size: 0.9em; color: #6b7280; }
+        .roadmap { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 16px; }
+        .column h3 { margin: 0 0 4px; font-size: 0.95em; text-transform: uppercase; letter-spacing: 0.04em; color: #4b5563; }
+        .more { font-size: 0.8em; color: #6b7280; margin: 4px 0 0; }
+    `;
+
+    const columnLabels = {
+        parking: 'Parking',
+        now: 'Now',
+        next: 'Next',
+        later: 'Later',
+        release: 'Released',
+        'wont-do': "Won't do"
+    };
+
+    class DiskoReleasedFeed extends HTMLElement {
+        static get observedAttributes() {
+            return ['token', 'limit', 'api-base'];
+        }
+
+        constructor() {
+            super();
+            this.attachShadow({ mode: 'open' });
+        }
+
+        connectedCallback() {
+            this.load();
+        }
+
+        attributeChangedCallback(name, oldValue, newValue) {
+            if (this.isConnected && oldValue !== newValue) {
+                this.load();
+            }
+        }
+
+        async load() {
+            const token = this.getAttribute('token');
+            if (!token) {
+                this.renderMessage('error', 'Missing embed token');
+                return;
+            }
+
+            const base = this.getAttribute('api-base') || defaultBase;
+            const limit = parseInt(this.getAttribute('limit') || '10', 10);
+            const url = `${base}/embed/v1/${encodeURIComponent(token)}/${this.endpoint}?limit=${limit}`;
+
+            try {
+                const response = await fetch(url, { credentials: 'omit' });
+                if (!response.ok) {
+                    throw new Error(`HTTP ${response.status}`);
+                }
+                const data = await response.json();
+                this.render(data, base);
+            } catch (error) {
+                console.warn('[DiskoWidget] Failed to load feed:', error);
+                this.renderMessage('error', 'Unable to load the board');
+            }
+        }
+
+        renderMessage(className, message) {
+            this.shadowRoot.innerHTML = `<style>${styles}</style><p class="${className}">${this.escape(message)}</p>`;
+        }
+
+        get endpoint() {
+            return 'released';
+        }
+
+        render(data, base) {
+            const ideas = data.ideas || [];
+            if (ideas.length === 0) {
+                this.renderMessage('empty', 'Nothing shipped yet');
+                return;
+            }
+
+            const items = this.renderIdeas(ideas);
+            this.shadowRoot.innerHTML = `<style>${styles}</style><ul class="feed">${items}</ul>${this.renderFooter(data, base)}`;
+        }
+
+        renderIdeas(ideas) {
+            return ideas.map(idea => {
+                const reactions = (idea.emojiReactions || [])
+                    .filter(reaction => reaction.count > 0)
+                    .map(reaction => `<span>${this.escape(reaction.emoji)} ${reaction.count}</span>`)
+                    .join('');
+                const description = idea.description
+                    ? `<p class="description">${this.escape(idea.description)}</p>`
+                    : '';
+                return `
+                    <li class="item">
+                        <p class="title">${this.escape(idea.oneLiner)}</p>
+                        ${description}
+                        <div class="votes"><span>👍 ${idea.thumbsUp || 0}</span>${reactions}</div>
+                    </li>
+                `;
+            }).join('');
+        }
+
+        renderFooter(data, base) {
+            const board = data.board || {};
+            return board.publicLink
+                ? `<div class="footer"><a href="${base}/public/${encodeURIComponent(board.publicLink)}" target="_blank" rel="noopener">View ${this.escape(board.name || 'board')} on Disko</a></div>`
+                : '';
+        }
+
+        escape(value) {
+            const div = document.createElement('div');
+            div.textContent = value == null ? '' : String(value);
+            return div.innerHTML;
+        }
+    }
+
+    class DiskoRoadmap extends DiskoReleasedFeed {
+        get endpoint() {
+            return 'roadmap';
+        }
+
+        render(data, base) {
+            const columns = (data.columns || []).filter(column => column.count > 0);
+            if (columns.length === 0) {
+                this.renderMessage('empty', 'No roadmap items yet');
+                return;
+            }
+
+            const sections = columns.map(column => {
+                const hidden = column.count - (column.ideas || []).length;
+                const more = hidden > 0 ? `<p class="more">+${hidden} more</p>` : '';
+                return `
+                    <section class="column">
+                        <h3>${this.escape(columnLabels[column.column] || column.column)}</h3>
+                        <ul class="feed">${this.renderIdeas(column.ideas || [])}</ul>
+                        ${more}
+                    </section>
+                `;
+            }).join('');
+
+            this.shadowRoot.innerHTML = `<style>${styles}</style><div class="roadmap">${sections}</div>${this.renderFooter(data, base)}`;
+        }
+    }
+
+    window.customElements.define('disko-released-feed', DiskoReleasedFeed);
+    window.customElements.define('disko-roadmap', DiskoRoadmap);
+})();
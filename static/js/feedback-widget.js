@@ -82,7 +82,8 @@ class FeedbackWidget {
             const response = await fetch(`/api/ideas/${this.ideaId}/thumbsup`, {
                 method: 'POST',
                 headers: {
-                    'Content-Type': 'application/json'
+                    'Content-Type': 'application/json',
+                    ...(typeof feedbackAttributionHeaders === 'function' ? feedbackAttributionHeaders() : {})
                 }
             });
 
@@ -175,7 +176,8 @@ class FeedbackWidget {
             const response = await fetch(`/api/ideas/${this.ideaId}/emoji`, {
                 method: 'POST',
                 headers: {
-                    'Content-Type': 'application/json'
+                    'Content-Type': 'application/json',
+                    ...(typeof feedbackAttributionHeaders === 'function' ? feedbackAttributionHeaders() : {})
                 },
                 body: JSON.stringify({ emoji })
             });
@@ -212,6 +214,10 @@ class FeedbackWidget {
         
         if (errorData.error?.code === 'RATE_LIMITED') {
             this.showMessage('Please wait a moment before giving more feedback.', 'warning');
+        } else if (errorData.error?.code === 'ALREADY_VOTED') {
+            this.showMessage('You already gave this idea a thumbs up.', 'info');
+        } else if (errorData.error?.code === 'ALREADY_REACTED') {
+            this.showMessage('You already added this reaction.', 'info');
         } else {
             this.showMessage(message, 'error');
         }
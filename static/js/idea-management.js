@@ -397,6 +397,7 @@ class IdeaManager {
             
             console.log('[IdeaManager] Found idea for editing:', idea);
             this.editingIdeaId = ideaId;
+            this.announceEditing(ideaId, true);
             
             // Remove existing modal if any
             const existingModal = document.getElementById('edit-idea-modal');
@@ -430,9 +431,30 @@ class IdeaManager {
         if (modal) {
             modal.remove();
         }
+        if (this.editingIdeaId) {
+            this.announceEditing(this.editingIdeaId, false);
+        }
         this.editingIdeaId = null;
     }
 
+    // Tell collaborators on the board socket which idea is open for editing, repeating it until closed
+    announceEditing(ideaId, editing) {
+        clearInterval(this.editingAnnouncer);
+        const ws = window.wsManager;
+        if (!ws || !ws.isConnected) {
+            return;
+        }
+
+        ws.setEditing(ideaId, editing);
+        if (editing) {
+            this.editingAnnouncer = setInterval(() => {
+                if (ws.isConnected) {
+                    ws.setEditing(ideaId, true);
+                }
+            }, 20000);
+        }
+    }
+
     confirmDeleteIdea(ideaId, ideaTitle) {
         console.log('[IdeaManager] confirmDeleteIdea called with ideaId:', ideaId, 'ideaTitle:', ideaTitle);
         
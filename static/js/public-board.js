@@ -52,10 +52,11 @@ class PublicBoardView {
     }
 
     handleIdeaUpdate(detail) {
-        // Handle real-time idea updates (position, status changes)
+        // Handle real-time idea and board lifecycle events
         console.log('Idea updated:', detail);
         
-        if (detail.type === 'position_update' || detail.type === 'status_update') {
+        const reloadTypes = ['idea_created', 'idea_updated', 'idea_deleted', 'ideas_updated', 'board_updated', 'board_deleted', 'resync_required'];
+        if (reloadTypes.includes(detail.type)) {
             // Reload the board to reflect changes
             this.loadPublicBoard();
         }
@@ -1,13 +1,20 @@
 // WebSocket Manager for real-time updates
+// Board members pass options.getToken (resolving to a session token) and the board ID to also
+// receive private events; public visitors connect with the board's public link instead.
 class WebSocketManager {
-    constructor(boardId) {
+    constructor(boardId, options = {}) {
         this.boardId = boardId;
+        this.getToken = options.getToken || null;
         this.ws = null;
         this.reconnectAttempts = 0;
         this.maxReconnectAttempts = 5;
         this.reconnectDelay = 1000;
         this.isConnected = false;
         this.messageHandlers = new Map();
+        this.epoch = null; // Resume point for replaying missed events after a reconnect
+        this.lastSeq = 0;
+        this.pendingOperations = new Map();
+        this.operationCounter = 0;
         this.init();
     }
 
@@ -16,17 +23,21 @@ class WebSocketManager {
         this.setupMessageHandlers();
     }
 
-    connect() {
+    async connect() {
         try {
             const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-            const wsUrl = `${protocol}//${window.location.host}/api/ws/boards/${this.boardId}`;
-            
-            this.ws = new WebSocket(wsUrl);
+            let wsUrl = `${protocol}//${window.location.host}/api/ws/boards/${this.boardId}`;
+            if (this.epoch) {
+                wsUrl += `?epoch=${encodeURIComponent(this.epoch)}&since=${this.lastSeq}`;
+            }
+
+            // The session token travels as a subprotocol so it stays out of URLs and logs
+            const token = this.getToken ? await this.getToken() : null;
+            this.ws = token ? new WebSocket(wsUrl, ['disko-auth', token]) : new WebSocket(wsUrl);
             
             this.ws.onopen = () => {
                 console.log('WebSocket connected');
                 this.isConnected = true;
-                this.reconnectAttempts = 0;
                 this.onConnectionStatusChange(true);
             };
 
@@ -83,6 +94,56 @@ class WebSocketManager {
             this.handleIdeaUpdate(data);
         });
 
+        // Handle idea and board lifecycle events
+        ['idea_created', 'idea_updated', 'idea_deleted', 'ideas_updated', 'board_updated', 'board_deleted'].forEach((type) => {
+            this.onMessage(type, (data, message) => {
+                this.handleBoardEvent(message);
+            });
+        });
+
+        // Handle presence changes
+        ['presence', 'presence_joined', 'presence_left'].forEach((type) => {
+            this.onMessage(type, (data) => {
+                this.handlePresence(data);
+            });
+        });
+
+        // Events coalesced during a burst arrive together, in order
+        this.onMessage('batch', (data) => {
+            (data.messages || []).forEach((message) => this.handleMessage(message));
+        });
+
+        // Remember where to resume from; a new epoch means a new sequence
+        // The server only greets connections it admitted, so backoff resets here rather than on open
+        this.onMessage('connected', (data) => {
+            this.reconnectAttempts = 0;
+            if (data.epoch !== this.epoch) {
+                this.epoch = data.epoch;
+                this.lastSeq = data.seq;
+            }
+        });
+
+        // Missed events are gone; reload the board
+        this.onMessage('resync_required', (data, message) => {
+            this.handleBoardEvent(message);
+        });
+
+        // In-app notifications addressed to this member
+        this.onMessage('notification', (data) => {
+            const event = new CustomEvent('boardNotification', { detail: data });
+            document.dispatchEvent(event);
+        });
+
+        // Show who is editing which idea
+        this.onMessage('idea_editing', (data, message) => {
+            this.handleIdeaEditing(message.ideaId, data);
+        });
+
+        // Settle operations sent with sendOperation
+        this.onMessage('operation_result', (data) => {
+            this.handleOperationResult(data);
+        });
+
         // Handle pong responses
         this.onMessage('pong', () => {
             // Keep-alive response
@@ -90,6 +151,14 @@ class WebSocketManager {
     }
 
     handleMessage(message) {
+        // Replayed and live events may overlap after a reconnect
+        if (message.seq) {
+            if (message.seq <= this.lastSeq) {
+                return;
+            }
+            this.lastSeq = message.seq;
+        }
+
         const handler = this.messageHandlers.get(message.type);
         if (handler) {
             handler(message.data, message);
@@ -191,6 +260,32 @@ class WebSocketManager {
         document.dispatchEvent(event);
     }
 
+    handlePresence(data) {
+        const presence = (data && data.presence) || { total: 0 };
+
+        // Show "N viewing" when the page has a presence indicator
+        const indicator = document.getElementById('board-presence');
+        if (indicator) {
+            indicator.textContent = presence.total === 1 ? '1 person viewing' : `${presence.total} people viewing`;
+        }
+
+        const event = new CustomEvent('boardPresence', {
+            detail: presence
+        });
+        document.dispatchEvent(event);
+    }
+
+    handleBoardEvent(message) {
+        console.log('Board event received:', message.type, message.ideaId);
+
+        // Members receive the change details; public visitors only learn what changed
+        const details = (message.data && message.data.details) || {};
+        const event = new CustomEvent('ideaUpdated', {
+            detail: { type: message.type, ideaId: message.ideaId, ...details }
+        });
+        document.dispatchEvent(event);
+    }
+
     onMessage(type, handler) {
         this.messageHandlers.set(type, handler);
     }
@@ -203,6 +298,79 @@ class WebSocketManager {
         }
     }
 
+    // Announce that this member started or stopped editing an idea; repeat while editing
+    setEditing(ideaId, editing) {
+        this.send({ type: 'editing', ideaId, data: { editing } });
+    }
+
+    handleIdeaEditing(ideaId, data) {
+        const ideaCard = document.querySelector(`[data-idea-id="${ideaId}"]`);
+        if (ideaCard) {
+            clearTimeout(ideaCard.editingTimer);
+            if (data.editing) {
+                ideaCard.classList.add('being-edited');
+                ideaCard.title = 'Someone is editing this idea';
+                // The indicator lapses unless the editor repeats it
+                ideaCard.editingTimer = setTimeout(() => {
+                    ideaCard.classList.remove('being-edited');
+                    ideaCard.title = '';
+                }, (data.expiresIn || 30) * 1000);
+            } else {
+                ideaCard.classList.remove('being-edited');
+                ideaCard.title = '';
+            }
+        }
+
+        const event = new CustomEvent('ideaEditing', {
+            detail: { ideaId, ...data }
+        });
+        document.dispatchEvent(event);
+    }
+
+    // Send an idea operation ('move_idea' or 'update_status', with the REST endpoint's body) over the
+    // socket; resolves with the updated idea or rejects with the server's error
+    sendOperation(type, ideaId, data, timeoutMs = 10000) {
+        return new Promise((resolve, reject) => {
+            if (!this.isConnected || this.ws.readyState !== WebSocket.OPEN) {
+                reject(new Error('WebSocket not connected'));
+                return;
+            }
+
+            const id = `op-${Date.now()}-${++this.operationCounter}`;
+            const timer = setTimeout(() => {
+                this.pendingOperations.delete(id);
+                reject(new Error('Operation timed out'));
+            }, timeoutMs);
+            this.pendingOperations.set(id, { resolve, reject, timer });
+            this.send({ type, id, ideaId, data });
+        });
+    }
+
+    handleOperationResult(data) {
+        const pending = this.pendingOperations.get(data.id);
+        if (!pending) {
+            return;
+        }
+        this.pendingOperations.delete(data.id);
+        clearTimeout(pending.timer);
+
+        if (data.ok) {
+            pending.resolve(data.result);
+        } else {
+            const error = (data.result && data.result.error) || {};
+            pending.reject(new Error(error.message || `Operation failed (${data.status})`));
+        }
+    }
+
+    // Narrow the events this connection receives, e.g. { events: ['idea_updated'], ideaIds: ['I123'] }
+    subscribe(filter) {
+        this.send({ type: 'subscribe', data: filter });
+    }
+
+    unsubscribe(filter) {
+        this.send({ type: 'unsubscribe', data: filter });
+    }
+
     sendPing() {
         this.send({ type: 'ping' });
     }
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"disko-backend/models"
+)
+
+// RecordActivity stores an entry in the board activity feed.
+// Failures are logged and never propagated so the feed cannot break the operation being recorded.
+func RecordActivity(ctx context.Context, boardID, ideaID, actorID string, activityType models.ActivityType, details map[string]interface{}) {
+	if models.DB == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 5*time.Second)
+	defer cancel()
+
+	activity := models.Activity{
+		ID:        GenerateFullUUID(),
+		BoardID:   boardID,
+		IdeaID:    ideaID,
+		ActorID:   actorID,
+		Type:      string(activityType),
+		Details:   details,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	collection := models.GetCollection(ctx, models.ActivitiesCollection)
+	if _, err := collection.InsertOne(ctx, activity); err != nil {
+		log.Printf("[Activity] Failed to record activity - BoardID: %s, IdeaID: %s, Type: %s, Error: %v",
+			boardID, ideaID, activityType, err)
+	}
+}
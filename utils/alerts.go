@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// EvaluateIdeaAlerts checks the board's idea rules after an idea's reactions changed, notifying the
+// owners of rules whose threshold the idea reached for the first time
+func EvaluateIdeaAlerts(ctx context.Context, boardID, ideaID string) {
+	if models.DB == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 30*time.Second)
+	defer cancel()
+
+	rules, err := models.FindEnabledAlertRules(ctx, boardID, models.AlertIdeaThumbsUp, models.AlertIdeaReaction)
+	if err != nil {
+		log.Printf("Failed to load alert rules for board %s: %v", boardID, err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	var idea models.Idea
+	if err := models.GetCollection(ctx, models.IdeasCollection).FindOne(ctx, bson.M{"_id": ideaID, "board_id": boardID}).Decode(&idea); err != nil {
+		log.Printf("Failed to load idea %s for alert rules: %v", ideaID, err)
+		return
+	}
+
+	for _, rule := range rules {
+		value, noun := ideaAlertValue(rule.Metric, &idea)
+		if value < rule.Threshold {
+			continue
+		}
+		fireAlert(ctx, &rule, idea.ID, idea.ID, value,
+			fmt.Sprintf("\"%s\" reached %d %s", idea.OneLiner, value, noun))
+	}
+}
+
+// ideaAlertValue measures an idea for an idea metric, returning the value and what it counts
+func ideaAlertValue(metric models.AlertMetric, idea *models.Idea) (int, string) {
+	if metric == models.AlertIdeaReaction {
+		value := idea.ThumbsUp
+		for _, reaction := range idea.EmojiReactions {
+			value += reaction.Count
+		}
+		return value, "reactions"
+	}
+	return idea.ThumbsUp, "thumbs up"
+}
+
+// ArmAlertRule prepares a new or changed rule: the ideas or column already past its threshold are
+// recorded as fired, so the rule only notifies of thresholds crossed from now on
+func ArmAlertRule(ctx context.Context, rule *models.AlertRule) error {
+	firingsCollection := models.GetCollection(ctx, models.AlertFiringsCollection)
+	if _, err := firingsCollection.DeleteMany(ctx, bson.M{"rule_id": rule.ID}); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	firing := func(subject string, value int) interface{} {
+		return models.AlertFiring{
+			ID:      alertFiringID(rule.ID, subject),
+			RuleID:  rule.ID,
+			BoardID: rule.BoardID,
+			UserID:  rule.UserID,
+			Subject: subject,
+			Value:   value,
+			FiredAt: now,
+		}
+	}
+
+	var firings []interface{}
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+	if models.IsIdeaAlertMetric(rule.Metric) {
+		cursor, err := ideasCollection.Find(ctx, bson.M{"board_id": rule.BoardID},
+			options.Find().SetProjection(bson.M{"thumbs_up": 1, "emoji_reactions": 1}))
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+
+		var ideas []models.Idea
+		if err := cursor.All(ctx, &ideas); err != nil {
+			return err
+		}
+		for _, idea := range ideas {
+			if value, _ := ideaAlertValue(rule.Metric, &idea); value >= rule.Threshold {
+				firings = append(firings, firing(idea.ID, value))
+			}
+		}
+	} else {
+		count, err := ideasCollection.CountDocuments(ctx, bson.M{"board_id": rule.BoardID, "column": rule.Column})
+		if err != nil {
+			return err
+		}
+		if int(count) > rule.Threshold {
+			firings = append(firings, firing(rule.Column, int(count)))
+		}
+	}
+
+	if len(firings) == 0 {
+		return nil
+	}
+	_, err := firingsCollection.InsertMany(ctx, firings, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// EvaluateColumnAlerts checks the board's column rules after ideas were added, moved or removed.
+// Rules fire when their column holds more ideas than the threshold, and are re-armed once it no
+// longer does.
+func EvaluateColumnAlerts(ctx context.Context, boardID string) {
+	if models.DB == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 30*time.Second)
+	defer cancel()
+
+	rules, err := models.FindEnabledAlertRules(ctx, boardID, models.AlertColumnSize)
+	if err != nil {
+		log.Printf("Failed to load alert rules for board %s: %v", boardID, err)
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, rule := range rules {
+		count, ok := counts[rule.Column]
+		if !ok {
+			total, err := models.GetCollection(ctx, models.IdeasCollection).CountDocuments(ctx, bson.M{"board_id": boardID, "column": rule.Column})
+			if err != nil {
+				log.Printf("Failed to count ideas in column %s of board %s: %v", rule.Column, boardID, err)
+				return
+			}
+			count = int(total)
+			counts[rule.Column] = count
+		}
+
+		if count <= rule.Threshold {
+			// Re-arm the rule for the next time the column grows past the threshold
+			if _, err := models.GetCollection(ctx, models.AlertFiringsCollection).DeleteOne(ctx, bson.M{"_id": alertFiringID(rule.ID, rule.Column)}); err != nil {
+				log.Printf("Failed to re-arm alert rule %s: %v", rule.ID, err)
+			}
+			continue
+		}
+		fireAlert(ctx, &rule, rule.Column, "", count,
+			fmt.Sprintf("The %s column has %d ideas, more than your alert's %d", rule.Column, count, rule.Threshold))
+	}
+}
+
+// alertFiringID is the ID of a rule's firing for a subject
+func alertFiringID(ruleID, subject string) string {
+	return ruleID + ":" + subject
+}
+
+// fireAlert notifies a rule's owner unless the rule already fired for the subject. ideaID is the
+// idea the alert is about, or empty.
+func fireAlert(ctx context.Context, rule *models.AlertRule, subject, ideaID string, value int, summary string) {
+	now := time.Now().UTC()
+	firing := models.AlertFiring{
+		ID:      alertFiringID(rule.ID, subject),
+		RuleID:  rule.ID,
+		BoardID: rule.BoardID,
+		UserID:  rule.UserID,
+		Subject: subject,
+		Value:   value,
+		FiredAt: now,
+	}
+	if _, err := models.GetCollection(ctx, models.AlertFiringsCollection).InsertOne(ctx, firing); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("Failed to record alert firing for rule %s: %v", rule.ID, err)
+		}
+		return
+	}
+
+	_, err := models.GetCollection(ctx, models.AlertRulesCollection).UpdateOne(ctx, bson.M{"_id": rule.ID}, bson.M{
+		"$set": bson.M{"last_triggered_at": now},
+		"$inc": bson.M{"trigger_count": 1},
+	})
+	if err != nil {
+		log.Printf("Failed to update alert rule %s: %v", rule.ID, err)
+	}
+
+	if notificationService == nil {
+		InitNotificationService()
+	}
+	board, notification, err := notificationService.buildNotification(ctx, models.NotifyAlert, rule.BoardID, ideaID)
+	if err != nil {
+		log.Printf("Failed to build alert notification for rule %s: %v", rule.ID, err)
+		return
+	}
+	notification.Summary = summary
+	notificationService.dispatchToUser(ctx, board, notification, rule.UserID)
+
+	log.Printf("Alert fired: Board=%s, Rule=%s, Metric=%s, Subject=%s, Value=%d", rule.BoardID, rule.ID, rule.Metric, subject, value)
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"disko-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers the public board pages send to attribute feedback to where the visitor came from
+const (
+	FeedbackSourceHeader   = "X-Feedback-Source"
+	FeedbackReferrerHeader = "X-Feedback-Referrer"
+)
+
+// maxSourceLength caps stored source tags
+const maxSourceLength = 50
+
+// FeedbackAttribution captures the source tag, referring host and device class of a feedback request.
+// The source comes from the ?source= query, the X-Feedback-Source header or the source/utm_source
+// parameters of the page URL. The referrer is reduced to its host and dropped when it is this site.
+func FeedbackAttribution(c *gin.Context) models.Attribution {
+	pageURL, _ := url.Parse(c.GetHeader("Referer"))
+
+	source := c.Query("source")
+	if source == "" {
+		source = c.GetHeader(FeedbackSourceHeader)
+	}
+	if source == "" && pageURL != nil {
+		source = pageURL.Query().Get("source")
+		if source == "" {
+			source = pageURL.Query().Get("utm_source")
+		}
+	}
+
+	referrer := c.GetHeader(FeedbackReferrerHeader)
+	if referrer == "" && pageURL != nil {
+		// Without the page's own referrer, the Referer only helps when feedback comes from another site
+		referrer = pageURL.String()
+	}
+
+	return models.Attribution{
+		Source:   normalizeSource(source),
+		Referrer: referrerHost(referrer, c.Request.Host),
+		Device:   string(ClassifyUserAgent(c.GetHeader("User-Agent"))),
+	}
+}
+
+// normalizeSource lowercases a source tag and keeps only letters, digits, '.', '-' and '_'
+func normalizeSource(source string) string {
+	source = strings.ToLower(strings.TrimSpace(source))
+	var b strings.Builder
+	for _, r := range source {
+		if b.Len() >= maxSourceLength {
+			break
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// referrerHost returns the lowercase host of an http(s) referrer, or "" for invalid or same-site referrers
+func referrerHost(referrer, ownHost string) string {
+	parsed, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if own, _, err := net.SplitHostPort(ownHost); err == nil {
+		ownHost = own
+	}
+	if host == "" || host == strings.ToLower(ownHost) {
+		return ""
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// ClassifyUserAgent buckets a user agent string into a coarse device class
+func ClassifyUserAgent(userAgent string) models.DeviceClass {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return models.DeviceUnknown
+	case strings.Contains(ua, "bot"), strings.Contains(ua, "crawler"), strings.Contains(ua, "spider"),
+		strings.Contains(ua, "curl/"), strings.Contains(ua, "wget/"), strings.Contains(ua, "python-requests"):
+		return models.DeviceBot
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return models.DeviceTablet
+	case strings.Contains(ua, "mobi"), strings.Contains(ua, "iphone"), strings.Contains(ua, "android"):
+		return models.DeviceMobile
+	case strings.Contains(ua, "mozilla/"):
+		return models.DeviceDesktop
+	}
+	return models.DeviceUnknown
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"time"
+
+	"disko-backend/models"
+)
+
+// auditIgnoredFields change on every write and would only add noise to audit diffs
+var auditIgnoredFields = map[string]bool{
+	"updatedAt": true,
+}
+
+// AuditDiff compares two versions of an object by their JSON representation and returns the fields
+// that differ, keyed by API field name. Either side may be nil for creations and deletions.
+// Fields hidden from JSON (token hashes and other secrets) are never included.
+func AuditDiff(before, after interface{}) map[string]models.AuditChange {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+
+	changes := make(map[string]models.AuditChange)
+	for field, value := range beforeFields {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		if next, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, next) {
+			changes[field] = models.AuditChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok && !auditIgnoredFields[field] {
+			changes[field] = models.AuditChange{After: value}
+		}
+	}
+	return changes
+}
+
+// auditFields flattens an object to its top-level JSON fields
+func auditFields(value interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
+		return fields
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("[Audit] Failed to encode audit snapshot - Error: %v", err)
+		return fields
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		log.Printf("[Audit] Failed to decode audit snapshot - Error: %v", err)
+	}
+	return fields
+}
+
+// RecordAudit stores an entry in the audit log.
+// Failures are logged and never propagated so auditing cannot break the operation being recorded.
+func RecordAudit(ctx context.Context, entry models.AuditEntry) {
+	if models.DB == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 5*time.Second)
+	defer cancel()
+
+	entry.ID = GenerateFullUUID()
+	entry.CreatedAt = time.Now().UTC()
+
+	collection := models.GetCollection(ctx, models.AuditLogCollection)
+	if _, err := collection.InsertOne(ctx, entry); err != nil {
+		log.Printf("[Audit] Failed to record audit entry - BoardID: %s, TargetID: %s, Action: %s, ActorID: %s, Error: %v",
+			entry.BoardID, entry.TargetID, entry.Action, entry.ActorID, err)
+	}
+}
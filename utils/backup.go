@@ -0,0 +1,448 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Backup formats
+const (
+	BackupFormatJSON = "json" // One {"collection", "document"} object per line, documents in canonical Extended JSON
+	BackupFormatBSON = "bson" // Concatenated {collection, document} BSON documents
+)
+
+const (
+	// restoreBatchSize is how many documents are written per bulk write when restoring
+	restoreBatchSize = 500
+	// maxBackupEntrySize bounds one entry of a backup: the largest MongoDB document plus its wrapper
+	maxBackupEntrySize = 16*1024*1024 + 64*1024
+	// backupFilePrefix names the files written by scheduled backups
+	backupFilePrefix = "disko-backup-"
+	// defaultBackupKeep is how many scheduled backups are kept unless BACKUP_KEEP says otherwise
+	defaultBackupKeep = 7
+)
+
+// backupSkippedCollections hold state rebuilt on demand, so they are neither backed up nor restored
+var backupSkippedCollections = map[string]bool{
+	models.PublicBoardsCollection:  true, // Read models rebuilt from boards and ideas
+	models.ScheduledJobsCollection: true, // Job locks of the running instances
+}
+
+// BackupCollectionReport counts the documents of one collection in a backup or restore
+type BackupCollectionReport struct {
+	Documents int64 `json:"documents"`
+	Failed    int64 `json:"failed,omitempty"` // Restore only: documents the database refused, such as invalid ones
+}
+
+// BackupReport describes a finished backup or restore
+type BackupReport struct {
+	Collections map[string]*BackupCollectionReport `json:"collections"`
+	Skipped     int64                              `json:"skipped,omitempty"` // Restore only: documents of collections that are never restored
+	Errors      []string                           `json:"errors,omitempty"`  // Restore only: the first refused writes
+	Duration    time.Duration                      `json:"-"`
+	DurationMs  int64                              `json:"durationMs"`
+}
+
+// maxReportedRestoreErrors caps the write errors kept in a restore report
+const maxReportedRestoreErrors = 20
+
+// newBackupReport returns an empty report
+func newBackupReport() *BackupReport {
+	return &BackupReport{Collections: map[string]*BackupCollectionReport{}}
+}
+
+// collection returns the report of a collection, adding it on first use
+func (r *BackupReport) collection(name string) *BackupCollectionReport {
+	report, ok := r.Collections[name]
+	if !ok {
+		report = &BackupCollectionReport{}
+		r.Collections[name] = report
+	}
+	return report
+}
+
+// finish records how long the backup or restore took
+func (r *BackupReport) finish(startedAt time.Time) {
+	r.Duration = time.Since(startedAt)
+	r.DurationMs = r.Duration.Milliseconds()
+}
+
+// IsValidBackupFormat reports whether a backup format is known
+func IsValidBackupFormat(format string) bool {
+	return format == BackupFormatJSON || format == BackupFormatBSON
+}
+
+// BackupCollections returns the collections a backup of the tenant of ctx includes, sorted by name
+func BackupCollections(ctx context.Context) ([]string, error) {
+	names, err := models.TenantCollectionNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collections := make([]string, 0, len(names))
+	for _, name := range names {
+		if !strings.HasPrefix(name, "system.") && !backupSkippedCollections[name] {
+			collections = append(collections, name)
+		}
+	}
+	return collections, nil
+}
+
+// WriteBackup streams every document of the given collections to w in the given format. Documents
+// are read one collection at a time without a snapshot, so writes made meanwhile may or may not be
+// included.
+func WriteBackup(ctx context.Context, w io.Writer, format string, collections []string) (*BackupReport, error) {
+	if !IsValidBackupFormat(format) {
+		return nil, fmt.Errorf("unknown backup format %q", format)
+	}
+	startedAt := time.Now()
+	report := newBackupReport()
+	buffered := bufio.NewWriterSize(w, 64*1024)
+
+	for _, collection := range collections {
+		collectionReport := report.collection(collection)
+		cursor, err := models.GetCollection(ctx, collection).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+		if err != nil {
+			return report, fmt.Errorf("failed to read %s: %w", collection, err)
+		}
+		for cursor.Next(ctx) {
+			if err := writeBackupEntry(buffered, format, collection, cursor.Current); err != nil {
+				cursor.Close(ctx)
+				return report, fmt.Errorf("failed to write %s: %w", collection, err)
+			}
+			collectionReport.Documents++
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return report, fmt.Errorf("failed to read %s: %w", collection, err)
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return report, err
+	}
+	report.finish(startedAt)
+	return report, nil
+}
+
+// writeBackupEntry writes one document of a backup
+func writeBackupEntry(w io.Writer, format, collection string, document bson.Raw) error {
+	if format == BackupFormatBSON {
+		entry, err := bson.Marshal(bson.D{{Key: "collection", Value: collection}, {Key: "document", Value: document}})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(entry)
+		return err
+	}
+
+	name, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+	extJSON, err := bson.MarshalExtJSON(document, true, false)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "{\"collection\":%s,\"document\":%s}\n", name, extJSON)
+	return err
+}
+
+// RestoreBackup writes the documents of a backup back to their collections, replacing documents with
+// the same _id and keeping the others. With replace, each collection in the backup is emptied before
+// its first document is restored. Gzip-compressed backups are decompressed. Documents the database
+// refuses are counted and skipped; any other error stops the restore.
+func RestoreBackup(ctx context.Context, r io.Reader, format string, replace bool) (*BackupReport, error) {
+	if !IsValidBackupFormat(format) {
+		return nil, fmt.Errorf("unknown backup format %q", format)
+	}
+	startedAt := time.Now()
+	report := newBackupReport()
+
+	reader := bufio.NewReaderSize(r, 64*1024)
+	if magic, err := reader.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return report, fmt.Errorf("invalid gzip data: %w", err)
+		}
+		defer gz.Close()
+		reader = bufio.NewReaderSize(gz, 64*1024)
+	}
+
+	// Boards may have changed under the cache and the public read models, even if the restore stops
+	defer func() {
+		models.InvalidateBoards()
+		if _, err := models.GetCollection(ctx, models.PublicBoardsCollection).DeleteMany(context.WithoutCancel(ctx), bson.M{}); err != nil {
+			log.Printf("[Backup] Failed to clear public board read models after restore: %v", err)
+		}
+	}()
+
+	restorer := &backupRestorer{report: report, replace: replace, cleared: map[string]bool{}}
+	next := nextJSONBackupEntry(reader)
+	if format == BackupFormatBSON {
+		next = nextBSONBackupEntry(reader)
+	}
+	for entryNumber := 1; ; entryNumber++ {
+		collection, document, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return report, fmt.Errorf("invalid backup entry %d: %w", entryNumber, err)
+		}
+		if err := restorer.add(ctx, collection, document); err != nil {
+			return report, err
+		}
+	}
+	if err := restorer.flush(ctx); err != nil {
+		return report, err
+	}
+
+	report.finish(startedAt)
+	return report, nil
+}
+
+// nextJSONBackupEntry returns a function reading the entries of a JSON backup one line at a time
+func nextJSONBackupEntry(reader *bufio.Reader) func() (string, bson.D, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), maxBackupEntrySize)
+	return func() (string, bson.D, error) {
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var entry struct {
+				Collection string          `json:"collection"`
+				Document   json.RawMessage `json:"document"`
+			}
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return "", nil, err
+			}
+			var document bson.D
+			if err := bson.UnmarshalExtJSON(entry.Document, true, &document); err != nil {
+				return "", nil, err
+			}
+			return entry.Collection, document, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return "", nil, err
+		}
+		return "", nil, io.EOF
+	}
+}
+
+// nextBSONBackupEntry returns a function reading the entries of a BSON backup one document at a time
+func nextBSONBackupEntry(reader *bufio.Reader) func() (string, bson.D, error) {
+	return func() (string, bson.D, error) {
+		var header [4]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return "", nil, errors.New("truncated document")
+			}
+			return "", nil, err
+		}
+		size := int(binary.LittleEndian.Uint32(header[:]))
+		if size < 5 || size > maxBackupEntrySize {
+			return "", nil, fmt.Errorf("invalid document size %d", size)
+		}
+		raw := make([]byte, size)
+		copy(raw, header[:])
+		if _, err := io.ReadFull(reader, raw[4:]); err != nil {
+			return "", nil, errors.New("truncated document")
+		}
+
+		var entry struct {
+			Collection string `bson:"collection"`
+			Document   bson.D `bson:"document"`
+		}
+		if err := bson.Unmarshal(raw, &entry); err != nil {
+			return "", nil, err
+		}
+		return entry.Collection, entry.Document, nil
+	}
+}
+
+// backupRestorer batches the documents of a restore per collection
+type backupRestorer struct {
+	report     *BackupReport
+	replace    bool
+	cleared    map[string]bool // Collections emptied by a replacing restore
+	collection string
+	batch      []mongo.WriteModel
+}
+
+// add queues a document, writing the batch once it is full or the backup moves to another collection
+func (r *backupRestorer) add(ctx context.Context, collection string, document bson.D) error {
+	if collection == "" || strings.HasPrefix(collection, "system.") || strings.ContainsAny(collection, "$\x00") {
+		return fmt.Errorf("invalid collection name %q", collection)
+	}
+	if backupSkippedCollections[collection] {
+		r.report.Skipped++
+		return nil
+	}
+
+	if collection != r.collection || len(r.batch) >= restoreBatchSize {
+		if err := r.flush(ctx); err != nil {
+			return err
+		}
+		r.collection = collection
+	}
+	if r.replace && !r.cleared[collection] {
+		if _, err := models.GetCollection(ctx, collection).DeleteMany(ctx, bson.M{}); err != nil {
+			return fmt.Errorf("failed to empty %s: %w", collection, err)
+		}
+		r.cleared[collection] = true
+	}
+
+	var model mongo.WriteModel = mongo.NewInsertOneModel().SetDocument(document)
+	for _, element := range document {
+		if element.Key == "_id" {
+			model = mongo.NewReplaceOneModel().
+				SetFilter(bson.D{{Key: "_id", Value: element.Value}}).
+				SetReplacement(document).
+				SetUpsert(true)
+			break
+		}
+	}
+	r.batch = append(r.batch, model)
+	return nil
+}
+
+// flush writes the queued documents. Refused documents are counted; other errors are returned.
+func (r *backupRestorer) flush(ctx context.Context) error {
+	if len(r.batch) == 0 {
+		return nil
+	}
+	collectionReport := r.report.collection(r.collection)
+	written := int64(len(r.batch))
+
+	_, err := models.GetCollection(ctx, r.collection).BulkWrite(ctx, r.batch, options.BulkWrite().SetOrdered(false))
+	r.batch = r.batch[:0]
+
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil && len(bulkErr.WriteErrors) > 0 {
+		failed := int64(len(bulkErr.WriteErrors))
+		collectionReport.Failed += failed
+		written -= failed
+		for _, writeErr := range bulkErr.WriteErrors {
+			if len(r.report.Errors) >= maxReportedRestoreErrors {
+				break
+			}
+			r.report.Errors = append(r.report.Errors, fmt.Sprintf("%s: %s", r.collection, writeErr.Message))
+		}
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", r.collection, err)
+	}
+	collectionReport.Documents += written
+	return nil
+}
+
+// RunScheduledBackup writes a gzip-compressed JSON backup of every collection to BACKUP_DIR
+// (default "backups") and deletes the oldest scheduled backups beyond BACKUP_KEEP (default 7).
+// The file only appears under its final name once it is complete. Tenants are backed up to a
+// directory of their own in BACKUP_DIR.
+func RunScheduledBackup(ctx context.Context) error {
+	dir := os.Getenv("BACKUP_DIR")
+	if dir == "" {
+		dir = "backups"
+	}
+	if tenantID := models.TenantFromContext(ctx); tenantID != "" {
+		dir = filepath.Join(dir, tenantID)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	collections, err := BackupCollections(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	name := backupFilePrefix + time.Now().UTC().Format("20060102T150405Z") + ".jsonl.gz"
+	path := filepath.Join(dir, name)
+	file, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(file.Name()) // No-op once renamed
+
+	gz := gzip.NewWriter(file)
+	report, err := WriteBackup(ctx, gz, BackupFormatJSON, collections)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to save backup: %w", err)
+	}
+
+	var documents int64
+	for _, collection := range report.Collections {
+		documents += collection.Documents
+	}
+	log.Printf("[Backup] Scheduled backup written - File: %s, Collections: %d, Documents: %d, Duration: %v",
+		path, len(report.Collections), documents, report.Duration)
+
+	pruneScheduledBackups(dir)
+	return nil
+}
+
+// pruneScheduledBackups deletes the oldest scheduled backups in dir beyond BACKUP_KEEP
+func pruneScheduledBackups(dir string) {
+	keep := defaultBackupKeep
+	if value := os.Getenv("BACKUP_KEEP"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			keep = parsed
+		} else {
+			log.Printf("[Backup] Invalid BACKUP_KEEP %q, keeping %d backups", value, keep)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Printf("[Backup] Failed to list backups - Dir: %s, Error: %v", dir, err)
+		return
+	}
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), backupFilePrefix) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	// Names embed their UTC time, so they sort oldest first
+	sort.Strings(backups)
+	for len(backups) > keep {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			log.Printf("[Backup] Failed to delete old backup - File: %s, Error: %v", backups[0], err)
+		}
+		backups = backups[1:]
+	}
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Broker relays board events between server instances so clients connected to any replica
+// receive them. Without one, events only reach the connections of the instance that sent them.
+type Broker interface {
+	Publish(payload []byte) error
+	Subscribe(deliver func(payload []byte)) // Blocks until Close, reconnecting as needed
+	Close() error
+}
+
+// brokerOutboxSize bounds the events waiting to be published; more are dropped while the broker is slow
+const brokerOutboxSize = 1024
+
+// Presence heartbeat: instances republish their viewers so others can drop those of a replica that died
+const (
+	presenceHeartbeatInterval = 30 * time.Second
+	remoteViewersTTL          = 3 * presenceHeartbeatInterval
+)
+
+// brokerEnvelope is a board event as sent to the other instances
+type brokerEnvelope struct {
+	Origin  string            `json:"origin"` // Instance ID of the sender, which skips its own events
+	BoardID string            `json:"boardId"`
+	Member  *WebSocketMessage `json:"member,omitempty"`
+	Public  *WebSocketMessage `json:"public,omitempty"`
+
+	Transient bool `json:"transient,omitempty"` // Not sequenced for replay
+
+	// Presence changes carry the sender's own viewers, which the receivers merge with theirs
+	Viewers       *boardViewers `json:"viewers,omitempty"`
+	PresenceEvent string        `json:"presenceEvent,omitempty"`
+	UserID        string        `json:"userId,omitempty"`
+	Recipient     string        `json:"recipient,omitempty"`  // Member the event is addressed to, if only one
+	UserStream    bool          `json:"userStream,omitempty"` // For the recipient's notification streams, not a board
+}
+
+// NewBrokerFromEnv creates the broker selected by WEBSOCKET_BROKER, or nil when none is configured
+func NewBrokerFromEnv() (Broker, error) {
+	channel := os.Getenv("WEBSOCKET_BROKER_CHANNEL")
+	if channel == "" {
+		channel = "disko:board-events"
+	}
+
+	switch kind := os.Getenv("WEBSOCKET_BROKER"); kind {
+	case "", "none":
+		return nil, nil
+	case "redis":
+		redisURL := os.Getenv("REDIS_URL")
+		if redisURL == "" {
+			redisURL = "redis://localhost:6379"
+		}
+		return NewRedisBroker(redisURL, channel)
+	default:
+		return nil, fmt.Errorf("unsupported WEBSOCKET_BROKER %q (supported: redis)", kind)
+	}
+}
+
+// StartWebSocketBroker connects the WebSocket manager to a broker so broadcasts reach every instance
+func StartWebSocketBroker(broker Broker) {
+	if wsManager == nil || broker == nil {
+		return
+	}
+	outbox := make(chan []byte, brokerOutboxSize)
+	wsManager.mutex.Lock()
+	wsManager.broker = broker
+	wsManager.outbox = outbox
+	wsManager.mutex.Unlock()
+
+	go func() {
+		for payload := range outbox {
+			if err := broker.Publish(payload); err != nil {
+				log.Printf("[Broker] Failed to publish event - Error: %v", err)
+			}
+		}
+	}()
+	go broker.Subscribe(wsManager.receive)
+	go wsManager.presenceHeartbeat()
+
+	log.Printf("[Broker] WebSocket broker started - Instance: %s", wsManager.instanceID)
+}
+
+// relay queues an envelope for the other instances without waiting on the broker; failures only
+// cost them this event
+func (wsm *WebSocketManager) relay(envelope *brokerEnvelope) {
+	wsm.mutex.RLock()
+	outbox := wsm.outbox
+	wsm.mutex.RUnlock()
+	if outbox == nil {
+		return
+	}
+	envelope.Origin = wsm.instanceID
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		log.Printf("[Broker] Failed to encode event - Error: %v, BoardID: %s", err, envelope.BoardID)
+		return
+	}
+	select {
+	case outbox <- payload:
+	default:
+		log.Printf("[Broker] Outbox full, dropping event - BoardID: %s", envelope.BoardID)
+	}
+}
+
+// receive delivers an event published by another instance to the connections here
+func (wsm *WebSocketManager) receive(payload []byte) {
+	var envelope brokerEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		log.Printf("[Broker] Ignoring malformed event - Error: %v", err)
+		return
+	}
+	if envelope.Origin == wsm.instanceID {
+		return
+	}
+	if envelope.UserStream {
+		wsm.counters.eventsReceived.Add(1)
+		wsm.deliverToUser(envelope.Recipient, envelope.Member)
+		return
+	}
+	if envelope.BoardID == "" {
+		return
+	}
+
+	if envelope.Viewers != nil {
+		wsm.setRemoteViewers(envelope.BoardID, envelope.Origin, *envelope.Viewers)
+	}
+	if envelope.PresenceEvent != "" {
+		wsm.broadcastPresence(envelope.BoardID, envelope.PresenceEvent, envelope.UserID)
+		return
+	}
+	wsm.counters.eventsReceived.Add(1)
+	if envelope.Recipient != "" {
+		wsm.queueBroadcast(envelope.BoardID, pendingBroadcast{member: envelope.Member, recipient: envelope.Recipient})
+		return
+	}
+	memberMessage, publicMessage := envelope.Member, envelope.Public
+	if !envelope.Transient {
+		memberMessage, publicMessage = wsm.sequence(envelope.BoardID, memberMessage, publicMessage)
+	}
+	wsm.broadcast(envelope.BoardID, memberMessage, publicMessage)
+}
+
+// presenceHeartbeat periodically republishes this instance's viewers and forgets stale remote ones
+func (wsm *WebSocketManager) presenceHeartbeat() {
+	ticker := time.NewTicker(presenceHeartbeatInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		wsm.mutex.RLock()
+		boardIDs := make([]string, 0, len(wsm.connections))
+		for boardID := range wsm.connections {
+			boardIDs = append(boardIDs, boardID)
+		}
+		wsm.mutex.RUnlock()
+
+		for _, boardID := range boardIDs {
+			viewers := wsm.localViewers(boardID)
+			wsm.relay(&brokerEnvelope{BoardID: boardID, Viewers: &viewers})
+		}
+		wsm.pruneRemoteViewers()
+	}
+}
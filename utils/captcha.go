@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CaptchaTokenHeader carries the widget token on public write requests
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// Built-in CAPTCHA providers
+const (
+	CaptchaHCaptcha  = "hcaptcha"
+	CaptchaTurnstile = "turnstile"
+	CaptchaReCaptcha = "recaptcha"
+)
+
+// CaptchaVerifier checks a CAPTCHA widget token with its provider
+type CaptchaVerifier interface {
+	// SiteKey returns the public key the frontend renders the widget with
+	SiteKey() string
+	// Configured reports whether the server holds the credentials to verify tokens
+	Configured() bool
+	// Verify reports whether the token is valid. remoteIP may be empty.
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+var (
+	captchaProviders   = map[string]CaptchaVerifier{}
+	captchaProvidersMu sync.RWMutex
+	captchaHTTPClient  = &http.Client{Timeout: 5 * time.Second}
+)
+
+func init() {
+	RegisterCaptchaProvider(CaptchaHCaptcha, siteVerifyCaptcha{
+		verifyURL:  "https://api.hcaptcha.com/siteverify",
+		siteKeyEnv: "HCAPTCHA_SITE_KEY",
+		secretEnv:  "HCAPTCHA_SECRET",
+	})
+	RegisterCaptchaProvider(CaptchaTurnstile, siteVerifyCaptcha{
+		verifyURL:  "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+		siteKeyEnv: "TURNSTILE_SITE_KEY",
+		secretEnv:  "TURNSTILE_SECRET",
+	})
+	RegisterCaptchaProvider(CaptchaReCaptcha, siteVerifyCaptcha{
+		verifyURL:  "https://www.google.com/recaptcha/api/siteverify",
+		siteKeyEnv: "RECAPTCHA_SITE_KEY",
+		secretEnv:  "RECAPTCHA_SECRET",
+	})
+}
+
+// RegisterCaptchaProvider makes a CAPTCHA provider selectable by boards, replacing any provider with the same name
+func RegisterCaptchaProvider(name string, verifier CaptchaVerifier) {
+	captchaProvidersMu.Lock()
+	defer captchaProvidersMu.Unlock()
+	captchaProviders[name] = verifier
+}
+
+// GetCaptchaProvider returns a registered CAPTCHA provider
+func GetCaptchaProvider(name string) (CaptchaVerifier, bool) {
+	captchaProvidersMu.RLock()
+	defer captchaProvidersMu.RUnlock()
+	verifier, ok := captchaProviders[name]
+	return verifier, ok
+}
+
+// CaptchaSiteKey returns the public site key of a configured provider, or "" if it can't be used
+func CaptchaSiteKey(name string) string {
+	verifier, ok := GetCaptchaProvider(name)
+	if !ok || !verifier.Configured() {
+		return ""
+	}
+	return verifier.SiteKey()
+}
+
+// VerifyCaptcha checks a token against the named provider. A missing token is never valid.
+func VerifyCaptcha(ctx context.Context, name, token, remoteIP string) (bool, error) {
+	verifier, ok := GetCaptchaProvider(name)
+	if !ok {
+		return false, fmt.Errorf("unknown captcha provider %q", name)
+	}
+	if !verifier.Configured() {
+		return false, fmt.Errorf("captcha provider %q is not configured", name)
+	}
+	if strings.TrimSpace(token) == "" {
+		return false, nil
+	}
+	return verifier.Verify(ctx, token, remoteIP)
+}
+
+// siteVerifyCaptcha implements the form-encoded "siteverify" API shared by hCaptcha, Turnstile and reCAPTCHA
+type siteVerifyCaptcha struct {
+	verifyURL  string
+	siteKeyEnv string
+	secretEnv  string
+}
+
+func (p siteVerifyCaptcha) SiteKey() string {
+	return os.Getenv(p.siteKeyEnv)
+}
+
+func (p siteVerifyCaptcha) Configured() bool {
+	return os.Getenv(p.siteKeyEnv) != "" && os.Getenv(p.secretEnv) != ""
+}
+
+func (p siteVerifyCaptcha) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	form := url.Values{}
+	form.Set("secret", os.Getenv(p.secretEnv))
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("failed to create captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := captchaHTTPClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("captcha verification request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("captcha verification returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+	return result.Success, nil
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"log"
+	"time"
+)
+
+// EventBatch carries several board events in one WebSocket frame; data.messages holds them in order
+const EventBatch = "batch"
+
+// maxBatchMessages flushes a batch early so one frame stays reasonably small
+const maxBatchMessages = 100
+
+// pendingBroadcast is a queued event as sent to members and to public visitors
+type pendingBroadcast struct {
+	member    *WebSocketMessage
+	public    *WebSocketMessage
+	recipient string // When set, only this user's member connections receive the event
+}
+
+// boardBatch coalesces a board's broadcasts: the first event after a quiet interval goes out at
+// once, and events arriving within the interval are queued and sent together when it ends
+type boardBatch struct {
+	pending   []pendingBroadcast
+	timer     *time.Timer
+	lastFlush time.Time
+}
+
+// batchIntervalFromEnv reads how long a busy board's events are collected before they are sent
+func batchIntervalFromEnv() time.Duration {
+	return time.Duration(limitFromEnv("WEBSOCKET_BATCH_INTERVAL_MS", 100)) * time.Millisecond
+}
+
+// broadcast sends the board's local connections the member or the public message; nil skips that
+// audience. Bursts of events are coalesced per batch interval.
+func (wsm *WebSocketManager) broadcast(boardID string, memberMessage, publicMessage *WebSocketMessage) {
+	wsm.queueBroadcast(boardID, pendingBroadcast{member: memberMessage, public: publicMessage})
+}
+
+// queueBroadcast sends an event to the board's local connections now or with the board's next batch
+func (wsm *WebSocketManager) queueBroadcast(boardID string, event pendingBroadcast) {
+	if wsm.batchInterval <= 0 {
+		wsm.deliver(boardID, []pendingBroadcast{event})
+		return
+	}
+
+	wsm.batchMu.Lock()
+	batch := wsm.batches[boardID]
+	if batch == nil {
+		batch = &boardBatch{}
+		wsm.batches[boardID] = batch
+	}
+
+	// A quiet board gets its event right away
+	now := time.Now()
+	if batch.timer == nil && now.Sub(batch.lastFlush) >= wsm.batchInterval {
+		batch.lastFlush = now
+		wsm.batchMu.Unlock()
+		wsm.deliver(boardID, []pendingBroadcast{event})
+		return
+	}
+
+	batch.pending = append(batch.pending, event)
+	if len(batch.pending) >= maxBatchMessages {
+		if batch.timer != nil {
+			batch.timer.Stop()
+		}
+		events := wsm.takeBatchLocked(batch)
+		wsm.batchMu.Unlock()
+		wsm.deliver(boardID, events)
+		return
+	}
+	if batch.timer == nil {
+		batch.timer = time.AfterFunc(wsm.batchInterval-now.Sub(batch.lastFlush), func() {
+			wsm.flushBatch(boardID)
+		})
+	}
+	wsm.batchMu.Unlock()
+}
+
+// flushBatch sends a board's queued events when its interval ends
+func (wsm *WebSocketManager) flushBatch(boardID string) {
+	wsm.batchMu.Lock()
+	batch := wsm.batches[boardID]
+	if batch == nil {
+		wsm.batchMu.Unlock()
+		return
+	}
+	events := wsm.takeBatchLocked(batch)
+	wsm.batchMu.Unlock()
+
+	if len(events) > 0 {
+		wsm.deliver(boardID, events)
+	}
+}
+
+// takeBatchLocked empties a batch and starts its next interval; the caller holds batchMu
+func (wsm *WebSocketManager) takeBatchLocked(batch *boardBatch) []pendingBroadcast {
+	events := batch.pending
+	batch.pending = nil
+	batch.timer = nil
+	batch.lastFlush = time.Now()
+	return events
+}
+
+// pruneBatches forgets boards whose events stopped, so the batch map does not grow with every board seen
+func (wsm *WebSocketManager) pruneBatches() {
+	wsm.batchMu.Lock()
+	defer wsm.batchMu.Unlock()
+
+	cutoff := time.Now().Add(-wsm.batchInterval)
+	for boardID, batch := range wsm.batches {
+		if batch.timer == nil && batch.lastFlush.Before(cutoff) {
+			delete(wsm.batches, boardID)
+		}
+	}
+}
+
+// deliver queues events for the board's local connections: a single event as is, several as one batch
+func (wsm *WebSocketManager) deliver(boardID string, events []pendingBroadcast) {
+	// Create a copy of connections to avoid holding the lock during broadcast
+	wsm.mutex.RLock()
+	clients := make([]*boardClient, 0, len(wsm.connections[boardID]))
+	for client := range wsm.connections[boardID] {
+		clients = append(clients, client)
+	}
+	wsm.mutex.RUnlock()
+
+	for _, client := range clients {
+		messages := make([]*WebSocketMessage, 0, len(events))
+		for _, event := range events {
+			if event.recipient != "" && client.userID != event.recipient {
+				continue
+			}
+			message := event.public
+			if client.member() {
+				message = event.member
+			}
+			if message != nil && client.wants(message) {
+				messages = append(messages, message)
+			}
+		}
+		if len(messages) == 0 {
+			continue
+		}
+		if err := client.sendBatch(messages); err != nil {
+			wsm.counters.slowConsumers.Add(1)
+			log.Printf("WebSocket send error for board: %s, Member: %t: %v", boardID, client.member(), err)
+			// Closing ends the client's read loop, which removes it and announces the departure
+			client.close()
+			continue
+		}
+		wsm.counters.messagesQueued.Add(uint64(len(messages)))
+	}
+}
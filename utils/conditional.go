@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RespondNotModified sets the ETag, Last-Modified and Cache-Control validators of a public response
+// and answers 304 Not Modified when the client's cached copy is still current, in which case it
+// returns true and the caller must not write a body. The ETag hashes lastModified with parts, which
+// should cover everything else the response depends on, such as the query and an idea count that
+// changes when ideas are deleted. If-None-Match takes precedence over If-Modified-Since.
+// Responses of boards restricted by IP rules are only cacheable by the browser.
+func RespondNotModified(c *gin.Context, lastModified time.Time, restricted bool, parts ...string) bool {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d", lastModified.UnixNano())
+	for _, part := range parts {
+		fmt.Fprintf(hash, "|%s", part)
+	}
+	etag := `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+	visibility := "public"
+	if restricted {
+		visibility = "private"
+	}
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	// Caches may keep the response but must check it is current before reusing it
+	c.Header("Cache-Control", visibility+", no-cache")
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				c.Status(http.StatusNotModified)
+				return true
+			}
+		}
+		return false
+	}
+
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.Truncate(time.Second).After(since) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"time"
+)
+
+// Editing indicators: members announce which idea they are editing so others avoid clobbering it
+const (
+	ClientEditing    = "editing"      // data: {"editing": true|false}, with ideaId
+	EventIdeaEditing = "idea_editing" // Sent to members; not sequenced or replayed
+)
+
+// Clients drop an indicator that is not repeated within editingTTL, so a frozen tab does not hold an
+// idea forever; the server clears a member's indicators when they disconnect
+const (
+	editingTTL        = 30 * time.Second
+	maxEditingIdeas   = 20
+	maxEditingIDBytes = 64
+)
+
+// EditingUpdate is the data of an editing message
+type EditingUpdate struct {
+	Editing bool `json:"editing"`
+}
+
+// setEditing records and announces that a member started or stopped editing an idea. Repeating
+// "editing": true refreshes the indicator before it expires.
+func (wsm *WebSocketManager) setEditing(boardID string, client *boardClient, ideaID string, editing bool) bool {
+	if ideaID == "" || len(ideaID) > maxEditingIDBytes {
+		return false
+	}
+
+	client.filterMu.Lock()
+	if client.editing == nil {
+		client.editing = make(map[string]bool)
+	}
+	if editing && !client.editing[ideaID] && len(client.editing) >= maxEditingIdeas {
+		client.filterMu.Unlock()
+		return false
+	}
+	if editing {
+		client.editing[ideaID] = true
+	} else {
+		delete(client.editing, ideaID)
+	}
+	client.filterMu.Unlock()
+
+	wsm.publishTransient(boardID, editingMessage(boardID, ideaID, client.userID, editing), nil)
+	return true
+}
+
+// stopEditing announces that a leaving member stopped editing every idea they had open
+func (wsm *WebSocketManager) stopEditing(boardID string, client *boardClient) {
+	client.filterMu.Lock()
+	ideaIDs := make([]string, 0, len(client.editing))
+	for ideaID := range client.editing {
+		ideaIDs = append(ideaIDs, ideaID)
+	}
+	client.editing = nil
+	client.filterMu.Unlock()
+
+	for _, ideaID := range ideaIDs {
+		wsm.publishTransient(boardID, editingMessage(boardID, ideaID, client.userID, false), nil)
+	}
+}
+
+// editingMessage builds an idea_editing event
+func editingMessage(boardID, ideaID, userID string, editing bool) *WebSocketMessage {
+	return &WebSocketMessage{
+		Type:    EventIdeaEditing,
+		BoardID: boardID,
+		IdeaID:  ideaID,
+		Data: map[string]interface{}{
+			"userId":    userID,
+			"editing":   editing,
+			"expiresIn": int(editingTTL / time.Second),
+			"timestamp": getCurrentTimestamp(),
+		},
+	}
+}
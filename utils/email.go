@@ -19,7 +19,7 @@ import (
 )
 
 // SendBoardInviteEmail sends an HTML invitation email for a board
-func SendBoardInviteEmail(email, subject, message string, board models.Board, userID string) error {
+func SendBoardInviteEmail(ctx context.Context, email, subject, message string, board models.Board, userID string) error {
 	// Get email configuration from environment variables
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPortStr := os.Getenv("SMTP_PORT")
@@ -55,7 +55,7 @@ func SendBoardInviteEmail(email, subject, message string, board models.Board, us
 	m.SetHeader("From", fromEmail)
 	m.SetHeader("To", email)
 	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", generateInviteEmailHTML(board, message))
+	m.SetBody("text/html", generateInviteEmailHTML(ctx, board, message))
 
 	// Create dialer
 	d := gomail.NewDialer(smtpHost, smtpPort, smtpUser, smtpPass)
@@ -86,13 +86,13 @@ func getUserEmailFromClerk(userID string) (string, error) {
 }
 
 // generateInviteEmailHTML creates a compelling HTML email template with Disko branding
-func generateInviteEmailHTML(board models.Board, message string) string {
+func generateInviteEmailHTML(ctx context.Context, board models.Board, message string) string {
 	publicURL := fmt.Sprintf("%s/public/%s", os.Getenv("APP_URL"), board.PublicLink)
 
 	// Get board statistics
-	ideasCount := getBoardIdeasCount(board.ID)
-	reactionsCount := getBoardReactionsCount(board.ID)
-	recentIdeas := getRecentIdeas(board.ID, 5)
+	ideasCount := getBoardIdeasCount(ctx, board.ID)
+	reactionsCount := getBoardReactionsCount(ctx, board.ID)
+	recentIdeas := getRecentIdeas(ctx, board.ID, 5)
 
 	// Build the HTML template with proper escaping
 	htmlTemplate := `<!DOCTYPE html>
@@ -430,7 +430,7 @@ func generateInviteEmailHTML(board models.Board, message string) string {
 		IdeasCount:       ideasCount,
 		ReactionsCount:   reactionsCount,
 		UpdatedAgo:       formatTimeAgo(board.UpdatedAt),
-		EmojiRecaps:      generateEmojiRecaps(board),
+		EmojiRecaps:      generateEmojiRecaps(ctx, board),
 		RecentIdeasHTML:  generateRecentIdeasHTML(recentIdeas),
 		PublicURL:        publicURL,
 		AppURL:           os.Getenv("APP_URL"),
@@ -461,11 +461,11 @@ func generateInviteEmailHTML(board models.Board, message string) string {
 }
 
 // Helper functions for email generation
-func getBoardIdeasCount(boardID string) int {
+func getBoardIdeasCount(ctx context.Context, boardID string) int {
 	// Query the database for actual ideas count
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 
 	filter := bson.M{"board_id": boardID}
 	count, err := ideasCollection.CountDocuments(ctx, filter)
@@ -478,10 +478,10 @@ func getBoardIdeasCount(boardID string) int {
 }
 
 // getBoardReactionsCount gets the total reactions count for a board
-func getBoardReactionsCount(boardID string) int {
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func getBoardReactionsCount(ctx context.Context, boardID string) int {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 
 	pipeline := []bson.M{
 		{"$match": bson.M{"board_id": boardID}},
@@ -523,11 +523,11 @@ func getBoardReactionsCount(boardID string) int {
 }
 
 // generateEmojiRecaps creates emoji recaps for the board
-func generateEmojiRecaps(board models.Board) string {
+func generateEmojiRecaps(ctx context.Context, board models.Board) string {
 	// Query the database for real board statistics
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 
 	recaps := []string{}
 
@@ -603,11 +603,11 @@ func generateEmojiRecaps(board models.Board) string {
 	return strings.Join(recaps, " ")
 }
 
-func getRecentIdeas(boardID string, limit int) []models.Idea {
+func getRecentIdeas(ctx context.Context, boardID string, limit int) []models.Idea {
 	// Query the database for actual recent ideas
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+	ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
 
 	filter := bson.M{"board_id": boardID}
 	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(int64(limit))
@@ -0,0 +1,380 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"path"
+	"strconv"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/parquet-go/parquet-go"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// BuildIdeasCSV renders board ideas with their engagement counters as CSV
+func BuildIdeasCSV(ideas []models.Idea) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{
+		"idea_id", "board_id", "one_liner", "column", "status", "in_progress",
+		"thumbs_up", "emoji_reactions", "rice_reach", "rice_impact", "rice_confidence",
+		"rice_effort", "rice_score", "created_at", "updated_at",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, idea := range ideas {
+		emojiCount := 0
+		for _, reaction := range idea.EmojiReactions {
+			emojiCount += reaction.Count
+		}
+
+		record := []string{
+			idea.ID,
+			idea.BoardID,
+			idea.OneLiner,
+			idea.Column,
+			idea.Status,
+			strconv.FormatBool(idea.InProgress),
+			strconv.Itoa(idea.ThumbsUp),
+			strconv.Itoa(emojiCount),
+			strconv.Itoa(idea.RiceScore.Reach),
+			strconv.Itoa(idea.RiceScore.Impact),
+			strconv.Itoa(idea.RiceScore.Confidence),
+			strconv.Itoa(idea.RiceScore.Effort),
+			strconv.FormatFloat(idea.RiceScore.CalculateRICEScore(), 'f', 2, 64),
+			idea.CreatedAt.Format(time.RFC3339),
+			idea.UpdatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// BuildFeedbackCSV renders feedback activity entries as CSV
+func BuildFeedbackCSV(activities []models.Activity) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write([]string{"activity_id", "board_id", "idea_id", "feedback_type", "emoji", "created_at"}); err != nil {
+		return nil, err
+	}
+
+	for _, activity := range activities {
+		feedbackType, _ := activity.Details["feedbackType"].(string)
+		emoji, _ := activity.Details["emoji"].(string)
+
+		record := []string{
+			activity.ID,
+			activity.BoardID,
+			activity.IdeaID,
+			feedbackType,
+			emoji,
+			activity.CreatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// ideaParquetRow is an idea row of Parquet exports, with the columns of BuildIdeasCSV
+type ideaParquetRow struct {
+	IdeaID         string    `parquet:"idea_id"`
+	BoardID        string    `parquet:"board_id"`
+	OneLiner       string    `parquet:"one_liner"`
+	Column         string    `parquet:"column"`
+	Status         string    `parquet:"status"`
+	InProgress     bool      `parquet:"in_progress"`
+	ThumbsUp       int64     `parquet:"thumbs_up"`
+	EmojiReactions int64     `parquet:"emoji_reactions"`
+	RiceReach      int64     `parquet:"rice_reach"`
+	RiceImpact     int64     `parquet:"rice_impact"`
+	RiceConfidence int64     `parquet:"rice_confidence"`
+	RiceEffort     int64     `parquet:"rice_effort"`
+	RiceScore      float64   `parquet:"rice_score"`
+	CreatedAt      time.Time `parquet:"created_at,timestamp(millisecond)"`
+	UpdatedAt      time.Time `parquet:"updated_at,timestamp(millisecond)"`
+}
+
+// feedbackParquetRow is a feedback row of Parquet exports, with the columns of BuildFeedbackCSV
+type feedbackParquetRow struct {
+	ActivityID   string    `parquet:"activity_id"`
+	BoardID      string    `parquet:"board_id"`
+	IdeaID       string    `parquet:"idea_id"`
+	FeedbackType string    `parquet:"feedback_type"`
+	Emoji        string    `parquet:"emoji"`
+	CreatedAt    time.Time `parquet:"created_at,timestamp(millisecond)"`
+}
+
+// BuildIdeasParquet renders board ideas with their engagement counters as a Snappy-compressed
+// Parquet file
+func BuildIdeasParquet(ideas []models.Idea) ([]byte, error) {
+	rows := make([]ideaParquetRow, 0, len(ideas))
+	for _, idea := range ideas {
+		emojiCount := 0
+		for _, reaction := range idea.EmojiReactions {
+			emojiCount += reaction.Count
+		}
+
+		rows = append(rows, ideaParquetRow{
+			IdeaID:         idea.ID,
+			BoardID:        idea.BoardID,
+			OneLiner:       idea.OneLiner,
+			Column:         idea.Column,
+			Status:         idea.Status,
+			InProgress:     idea.InProgress,
+			ThumbsUp:       int64(idea.ThumbsUp),
+			EmojiReactions: int64(emojiCount),
+			RiceReach:      int64(idea.RiceScore.Reach),
+			RiceImpact:     int64(idea.RiceScore.Impact),
+			RiceConfidence: int64(idea.RiceScore.Confidence),
+			RiceEffort:     int64(idea.RiceScore.Effort),
+			RiceScore:      idea.RiceScore.CalculateRICEScore(),
+			CreatedAt:      idea.CreatedAt.UTC(),
+			UpdatedAt:      idea.UpdatedAt.UTC(),
+		})
+	}
+	return writeParquet(rows)
+}
+
+// BuildFeedbackParquet renders feedback activity entries as a Snappy-compressed Parquet file
+func BuildFeedbackParquet(activities []models.Activity) ([]byte, error) {
+	rows := make([]feedbackParquetRow, 0, len(activities))
+	for _, activity := range activities {
+		feedbackType, _ := activity.Details["feedbackType"].(string)
+		emoji, _ := activity.Details["emoji"].(string)
+
+		rows = append(rows, feedbackParquetRow{
+			ActivityID:   activity.ID,
+			BoardID:      activity.BoardID,
+			IdeaID:       activity.IdeaID,
+			FeedbackType: feedbackType,
+			Emoji:        emoji,
+			CreatedAt:    activity.CreatedAt.UTC(),
+		})
+	}
+	return writeParquet(rows)
+}
+
+// writeParquet writes rows as one Parquet file, with the schema of the row type
+func writeParquet[T any](rows []T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := parquet.Write(&buf, rows, parquet.Compression(&parquet.Snappy)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportFile is one file of an export run
+type exportFile struct {
+	key         string
+	body        []byte
+	contentType string
+}
+
+// buildExportFiles renders ideas and feedback in the config's format, named for the run's stamp
+func buildExportFiles(format, dir, stamp string, ideas []models.Idea, feedback []models.Activity) ([]exportFile, error) {
+	if format == string(models.FormatParquet) {
+		ideasParquet, err := BuildIdeasParquet(ideas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build ideas Parquet: %w", err)
+		}
+		feedbackParquet, err := BuildFeedbackParquet(feedback)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build feedback Parquet: %w", err)
+		}
+		return []exportFile{
+			{path.Join(dir, "ideas-"+stamp+".parquet"), ideasParquet, "application/vnd.apache.parquet"},
+			{path.Join(dir, "feedback-"+stamp+".parquet"), feedbackParquet, "application/vnd.apache.parquet"},
+		}, nil
+	}
+
+	ideasCSV, err := BuildIdeasCSV(ideas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ideas CSV: %w", err)
+	}
+	feedbackCSV, err := BuildFeedbackCSV(feedback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build feedback CSV: %w", err)
+	}
+	return []exportFile{
+		{path.Join(dir, "ideas-"+stamp+".csv"), ideasCSV, "text/csv"},
+		{path.Join(dir, "feedback-"+stamp+".csv"), feedbackCSV, "text/csv"},
+	}, nil
+}
+
+// RunBoardExport builds the analytics files for a board and uploads them to the configured bucket,
+// or to the asset storage for the storage provider. Feedback is exported incrementally since the previous successful run. Returns the uploaded object keys.
+func RunBoardExport(ctx context.Context, cfg models.ExportConfig) ([]string, error) {
+	// Load ideas
+	ideasCursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, bson.M{"board_id": cfg.BoardID},
+		options.Find().SetSort(bson.D{{Key: "column", Value: 1}, {Key: "position", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ideas: %w", err)
+	}
+	var ideas []models.Idea
+	if err := ideasCursor.All(ctx, &ideas); err != nil {
+		return nil, fmt.Errorf("failed to decode ideas: %w", err)
+	}
+
+	// Load feedback since the last run
+	feedbackFilter := bson.M{"board_id": cfg.BoardID, "type": string(models.ActivityFeedback)}
+	if cfg.LastRunAt != nil && cfg.LastStatus == models.ExportStatusSuccess {
+		feedbackFilter["created_at"] = bson.M{"$gt": *cfg.LastRunAt}
+	}
+	feedbackCursor, err := models.GetCollection(ctx, models.ActivitiesCollection).Find(ctx, feedbackFilter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feedback: %w", err)
+	}
+	var feedback []models.Activity
+	if err := feedbackCursor.All(ctx, &feedback); err != nil {
+		return nil, fmt.Errorf("failed to decode feedback: %w", err)
+	}
+
+	// Partition objects by board and day so warehouse loaders can pick up new files
+	now := time.Now().UTC()
+	dir := path.Join(cfg.Prefix, cfg.BoardID, now.Format("2006-01-02"))
+	if cfg.Provider == string(models.ProviderStorage) {
+		dir = path.Join(models.BoardStoragePrefix(ctx, cfg.BoardID), "exports", now.Format("2006-01-02"))
+	}
+	files, err := buildExportFiles(cfg.Format, dir, now.Format("20060102T150405Z"), ideas, feedback)
+	if err != nil {
+		return nil, err
+	}
+
+	put := GetStorage().Put
+	if cfg.Provider != string(models.ProviderStorage) {
+		accessKeyID, err := openStoredSecret(cfg.AccessKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt access key ID: %w", err)
+		}
+		secretAccessKey, err := openStoredSecret(cfg.SecretAccessKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret access key: %w", err)
+		}
+		s3Config := S3Config{
+			Endpoint:        cfg.Endpoint,
+			Region:          cfg.Region,
+			Bucket:          cfg.Bucket,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			PublicOnly:      true,
+		}
+		if cfg.Provider == string(models.ProviderGCS) && s3Config.Endpoint == "" {
+			s3Config.Endpoint = GCSEndpoint
+			if s3Config.Region == "" {
+				s3Config.Region = "auto"
+			}
+		}
+		put = func(ctx context.Context, key string, body []byte, contentType string) error {
+			return PutS3Object(ctx, s3Config, key, body, contentType)
+		}
+	}
+
+	var keys []string
+	for _, file := range files {
+		if err := put(ctx, file.key, file.body, file.contentType); err != nil {
+			return keys, err
+		}
+		keys = append(keys, file.key)
+	}
+
+	log.Printf("[Export] Board export uploaded - BoardID: %s, Provider: %s, Bucket: %s, Format: %s, Ideas: %d, Feedback: %d",
+		cfg.BoardID, cfg.Provider, cfg.Bucket, cfg.Format, len(ideas), len(feedback))
+	return keys, nil
+}
+
+// RecordExportResult stores the outcome of an export run, with the keys it wrote, and schedules the
+// next one
+func RecordExportResult(ctx context.Context, cfg models.ExportConfig, keys []string, runErr error) {
+	now := time.Now().UTC()
+	update := bson.M{
+		"last_run_at": now,
+		"next_run_at": now.Add(time.Duration(cfg.IntervalHours) * time.Hour),
+		"updated_at":  now,
+		"last_status": models.ExportStatusSuccess,
+		"last_error":  "",
+		"last_files":  keys,
+	}
+	if runErr != nil {
+		update["last_status"] = models.ExportStatusFailed
+		update["last_error"] = runErr.Error()
+		delete(update, "last_files") // Keep the files of the last successful run
+	}
+
+	// Encrypt credentials saved in plaintext before they were encrypted
+	if EncryptionEnabled() {
+		for field, value := range map[string]string{"access_key_id": cfg.AccessKeyID, "secret_access_key": cfg.SecretAccessKey} {
+			if value == "" || IsEncryptedSecret(value) {
+				continue
+			}
+			if sealed, err := EncryptSecret(value); err == nil {
+				update[field] = sealed
+			}
+		}
+	}
+
+	if _, err := models.GetCollection(ctx, models.ExportConfigsCollection).UpdateOne(ctx, bson.M{"_id": cfg.ID}, bson.M{"$set": update}); err != nil {
+		log.Printf("[Export] Failed to record export result - ConfigID: %s, Error: %v", cfg.ID, err)
+	}
+}
+
+// runDueExports runs every enabled export of the tenant of ctx whose next run time has passed
+func runDueExports(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	filter := bson.M{"enabled": true, "next_run_at": bson.M{"$lte": time.Now().UTC()}}
+	cursor, err := models.GetCollection(ctx, models.ExportConfigsCollection).Find(ctx, filter)
+	if err != nil {
+		log.Printf("[Export] Failed to load due exports: %v", err)
+		return
+	}
+
+	var configs []models.ExportConfig
+	if err := cursor.All(ctx, &configs); err != nil {
+		log.Printf("[Export] Failed to decode due exports: %v", err)
+		return
+	}
+
+	for _, cfg := range configs {
+		keys, runErr := RunBoardExport(ctx, cfg)
+		if runErr != nil {
+			log.Printf("[Export] Scheduled export failed - ConfigID: %s, BoardID: %s, Error: %v", cfg.ID, cfg.BoardID, runErr)
+		}
+		RecordExportResult(ctx, cfg, keys, runErr)
+	}
+}
+
+// StartExportScheduler checks for due exports on a fixed interval in the background
+func StartExportScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if models.DB == nil {
+				continue
+			}
+			models.ForEachTenant(context.Background(), runDueExports)
+		}
+	}()
+	log.Printf("[Export] Export scheduler started - Interval: %v", interval)
+}
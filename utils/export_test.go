@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildExportParquet(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	t.Run("Ideas", func(t *testing.T) {
+		ideas := []models.Idea{{
+			ID:             "idea_1",
+			BoardID:        "board_1",
+			OneLiner:       "Dark mode",
+			Column:         "now",
+			Status:         "active",
+			InProgress:     true,
+			ThumbsUp:       4,
+			EmojiReactions: []models.EmojiReaction{{Emoji: "🎉", Count: 2}, {Emoji: "❤️", Count: 1}},
+			RiceScore:      models.RICEScore{Reach: 5, Impact: 4, Confidence: 80, Effort: 2},
+			CreatedAt:      created,
+			UpdatedAt:      created.Add(time.Hour),
+		}}
+
+		body, err := BuildIdeasParquet(ideas)
+		require.NoError(t, err)
+
+		rows, err := parquet.Read[ideaParquetRow](bytes.NewReader(body), int64(len(body)))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "idea_1", rows[0].IdeaID)
+		assert.Equal(t, "Dark mode", rows[0].OneLiner)
+		assert.True(t, rows[0].InProgress)
+		assert.Equal(t, int64(3), rows[0].EmojiReactions)
+		assert.InDelta(t, ideas[0].RiceScore.CalculateRICEScore(), rows[0].RiceScore, 0.001)
+		assert.True(t, created.Equal(rows[0].CreatedAt))
+	})
+
+	t.Run("Feedback", func(t *testing.T) {
+		feedback := []models.Activity{{
+			ID:        "activity_1",
+			BoardID:   "board_1",
+			IdeaID:    "idea_1",
+			Details:   map[string]interface{}{"feedbackType": "emoji", "emoji": "🎉"},
+			CreatedAt: created,
+		}}
+
+		body, err := BuildFeedbackParquet(feedback)
+		require.NoError(t, err)
+
+		rows, err := parquet.Read[feedbackParquetRow](bytes.NewReader(body), int64(len(body)))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, feedbackParquetRow{
+			ActivityID:   "activity_1",
+			BoardID:      "board_1",
+			IdeaID:       "idea_1",
+			FeedbackType: "emoji",
+			Emoji:        "🎉",
+			CreatedAt:    rows[0].CreatedAt,
+		}, rows[0])
+		assert.True(t, created.Equal(rows[0].CreatedAt))
+	})
+
+	t.Run("Files Follow The Format", func(t *testing.T) {
+		files, err := buildExportFiles(string(models.FormatParquet), "exports", "20260301T093000Z", nil, nil)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Equal(t, "exports/ideas-20260301T093000Z.parquet", files[0].key)
+		assert.Equal(t, "application/vnd.apache.parquet", files[0].contentType)
+
+		files, err = buildExportFiles(string(models.FormatCSV), "exports", "20260301T093000Z", nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "exports/feedback-20260301T093000Z.csv", files[1].key)
+		assert.Equal(t, "text/csv", files[1].contentType)
+	})
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+// ICalEvent is an all-day calendar event
+type ICalEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	URL          string
+	Date         time.Time // Only the day is used
+	LastModified time.Time
+}
+
+// BuildICalendar renders events as an RFC 5545 calendar with CRLF line endings
+func BuildICalendar(name string, events []ICalEvent) string {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//Disko//Roadmap//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(name))
+
+	for _, event := range events {
+		day := event.Date.UTC()
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+event.LastModified.UTC().Format("20060102T150405Z"))
+		writeICalLine(&b, "LAST-MODIFIED:"+event.LastModified.UTC().Format("20060102T150405Z"))
+		writeICalLine(&b, "DTSTART;VALUE=DATE:"+day.Format("20060102"))
+		writeICalLine(&b, "DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format("20060102"))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.URL != "" {
+			writeICalLine(&b, "URL:"+event.URL)
+		}
+		writeICalLine(&b, "TRANSP:TRANSPARENT")
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// escapeICalText escapes TEXT values (backslash, semicolon, comma and newlines)
+func escapeICalText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(text)
+}
+
+// writeICalLine writes a content line, folding it at 75 octets without splitting UTF-8 characters
+func writeICalLine(b *strings.Builder, line string) {
+	const limit = 75
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		width = limit - 1 // Continuation lines start with a space
+	}
+	b.WriteString(line + "\r\n")
+}
+
+// isUTF8Start reports whether a byte begins a UTF-8 encoded character
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"net/url"
+	"os"
+
+	"disko-backend/models"
+)
+
+// invitationEmailTemplate invites a collaborator to join a board
+var invitationEmailTemplate = template.Must(template.New("invitation").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.BoardName}}</title>
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; line-height: 1.6; color: #333; background-color: #f9fafb; margin: 0; padding: 24px;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 12px; overflow: hidden;">
+        <div style="background: linear-gradient(135deg, #3b82f6 0%, #8b5cf6 100%); color: white; padding: 32px 30px; text-align: center;">
+            <h1 style="margin: 0; font-size: 24px;">You're invited to collaborate</h1>
+            <p style="margin: 8px 0 0 0; opacity: 0.9;">{{.BoardName}}</p>
+        </div>
+        <div style="padding: 32px 30px;">
+            <p style="margin: 0 0 16px 0;">You've been invited to join this board as {{if eq .Role "editor"}}an editor, so you can add and organize ideas{{else}}a viewer, so you can follow its ideas and feedback{{end}}.</p>
+            {{if .Message}}<div style="padding: 12px 16px; background-color: #f8fafc; border-radius: 6px; border-left: 3px solid #3b82f6; margin-bottom: 24px; white-space: pre-line;">{{.Message}}</div>{{end}}
+            <div style="text-align: center;">
+                <a href="{{.AcceptURL}}" style="display: inline-block; background: linear-gradient(135deg, #3b82f6 0%, #8b5cf6 100%); color: white; text-decoration: none; padding: 14px 28px; border-radius: 8px; font-weight: 600;">Accept invitation</a>
+            </div>
+        </div>
+        <div style="background-color: #f1f5f9; padding: 20px 30px; text-align: center; color: #64748b; font-size: 13px;">
+            <p style="margin: 0;">Sign in or create an account to accept. This invitation expires in {{.ExpiresInDays}} days and can only be used once.</p>
+        </div>
+    </div>
+</body>
+</html>`))
+
+// SendBoardInvitationEmail emails a collaborator invitation with its accept link
+func SendBoardInvitationEmail(invitation models.BoardInvitation, board models.Board, subject, message string) error {
+	var buf bytes.Buffer
+	err := invitationEmailTemplate.Execute(&buf, struct {
+		BoardName     string
+		Role          models.MemberRole
+		Message       string
+		AcceptURL     string
+		ExpiresInDays int
+	}{
+		BoardName:     board.Name,
+		Role:          invitation.Role,
+		Message:       message,
+		AcceptURL:     fmt.Sprintf("%s/dashboard?invite=%s", os.Getenv("APP_URL"), url.QueryEscape(invitation.Token)),
+		ExpiresInDays: int(models.InvitationTTL.Hours() / 24),
+	})
+	if err != nil {
+		log.Printf("[Email] Failed to render invitation email: %v", err)
+		return err
+	}
+
+	if err := sendHTMLEmail(invitation.Email, subject, buf.String()); err != nil {
+		log.Printf("[Email] Failed to send invitation email - Error: %v, InvitationID: %s, BoardID: %s", err, invitation.ID, board.ID)
+		return err
+	}
+
+	log.Printf("[Email] Invitation email sent - InvitationID: %s, BoardID: %s, Role: %s", invitation.ID, board.ID, invitation.Role)
+	return nil
+}
@@ -0,0 +1,275 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// jiraOAuthTokenURL is where Atlassian exchanges OAuth refresh tokens
+const jiraOAuthTokenURL = "https://auth.atlassian.com/oauth/token"
+
+// jiraClient calls Jira Cloud's REST API
+var jiraClient = &http.Client{Timeout: 15 * time.Second}
+
+// JiraError is a response from Jira other than a success
+type JiraError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *JiraError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Jira responded with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("Jira responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// jiraErrorReply is the error body of Jira's REST API
+type jiraErrorReply struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// message joins the reply's errors into one line
+func (r *jiraErrorReply) message() string {
+	messages := append([]string{}, r.ErrorMessages...)
+	for field, message := range r.Errors {
+		messages = append(messages, field+": "+message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// jiraAPIBase is the URL the config's REST calls go to; OAuth apps call the site through Atlassian's API gateway
+func jiraAPIBase(config *models.JiraConfig) string {
+	if config.AuthType == models.JiraAuthOAuth {
+		return "https://api.atlassian.com/ex/jira/" + url.PathEscape(config.CloudID)
+	}
+	return strings.TrimRight(config.SiteURL, "/")
+}
+
+// refreshJiraToken renews an OAuth access token about to expire, storing the new tokens encrypted on
+// the config. Tokens without a refresh token, or an instance without an OAuth app, are used as they are.
+func refreshJiraToken(ctx context.Context, config *models.JiraConfig) error {
+	clientID := os.Getenv("JIRA_CLIENT_ID")
+	clientSecret := os.Getenv("JIRA_CLIENT_SECRET")
+	if config.AuthType != models.JiraAuthOAuth || config.RefreshToken == "" || clientID == "" || clientSecret == "" {
+		return nil
+	}
+	if config.TokenExpiresAt != nil && time.Now().Add(time.Minute).Before(*config.TokenExpiresAt) {
+		return nil
+	}
+
+	refreshToken, err := openStoredSecret(config.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt refresh token: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+		"refresh_token": refreshToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, jiraOAuthTokenURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := jiraClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Atlassian: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		ErrorDescription string `json:"error_description"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&reply)
+	if resp.StatusCode != http.StatusOK || reply.AccessToken == "" {
+		return &JiraError{StatusCode: resp.StatusCode, Message: "failed to refresh OAuth token: " + reply.ErrorDescription}
+	}
+
+	expiresAt := time.Now().UTC().Add(time.Duration(reply.ExpiresIn) * time.Second)
+	if config.AccessToken, err = EncryptSecret(reply.AccessToken); err != nil {
+		return fmt.Errorf("failed to encrypt access token: %w", err)
+	}
+	if reply.RefreshToken != "" {
+		// Atlassian rotates refresh tokens; the old one stops working
+		refreshToken = reply.RefreshToken
+	}
+	if config.RefreshToken, err = EncryptSecret(refreshToken); err != nil {
+		return fmt.Errorf("failed to encrypt refresh token: %w", err)
+	}
+	config.TokenExpiresAt = &expiresAt
+
+	if config.ID == "" {
+		return nil
+	}
+	_, err = models.GetCollection(ctx, models.JiraConfigsCollection).UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{"$set": bson.M{
+		"access_token":     config.AccessToken,
+		"refresh_token":    config.RefreshToken,
+		"token_expires_at": config.TokenExpiresAt,
+	}})
+	return err
+}
+
+// jiraRequest calls the config's Jira site, decoding a successful response into out when it is not nil
+func jiraRequest(ctx context.Context, config *models.JiraConfig, method, path string, payload, out interface{}) error {
+	if err := refreshJiraToken(ctx, config); err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, jiraAPIBase(config)+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if config.AuthType == models.JiraAuthOAuth {
+		accessToken, err := openStoredSecret(config.AccessToken)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	} else {
+		apiToken, err := openStoredSecret(config.APIToken)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt API token: %w", err)
+		}
+		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(config.Email+":"+apiToken)))
+	}
+
+	resp, err := jiraClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Jira: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var reply jiraErrorReply
+		json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&reply)
+		return &JiraError{StatusCode: resp.StatusCode, Message: reply.message()}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Jira response: %v", err)
+	}
+	return nil
+}
+
+// CheckJiraProject verifies the config's credentials can see its project
+func CheckJiraProject(ctx context.Context, config *models.JiraConfig) error {
+	return jiraRequest(ctx, config, http.MethodGet, "/rest/api/3/project/"+url.PathEscape(config.ProjectKey), nil, nil)
+}
+
+// jiraDocument renders paragraphs of plain text in Atlassian Document Format, skipping empty ones
+func jiraDocument(paragraphs ...string) map[string]interface{} {
+	content := []interface{}{}
+	for _, paragraph := range paragraphs {
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		content = append(content, map[string]interface{}{
+			"type":    "paragraph",
+			"content": []interface{}{map[string]interface{}{"type": "text", "text": paragraph}},
+		})
+	}
+	return map[string]interface{}{"type": "doc", "version": 1, "content": content}
+}
+
+// CreateJiraIssue creates an issue in the config's project from an idea, returning the link to store on it
+func CreateJiraIssue(ctx context.Context, config *models.JiraConfig, idea *models.Idea, createdBy string) (*models.JiraIssueLink, error) {
+	valueStatement := ""
+	if idea.ValueStatement != "" {
+		valueStatement = "Value: " + idea.ValueStatement
+	}
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":   map[string]string{"key": config.ProjectKey},
+			"issuetype": map[string]string{"name": config.IssueType},
+			"summary":   idea.OneLiner,
+			"description": jiraDocument(
+				idea.Description,
+				valueStatement,
+				fmt.Sprintf("Created from Disko: %s/board/%s", os.Getenv("APP_URL"), idea.BoardID),
+			),
+		},
+	}
+
+	var created struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := jiraRequest(ctx, config, http.MethodPost, "/rest/api/3/issue", payload, &created); err != nil {
+		return nil, err
+	}
+
+	return &models.JiraIssueLink{
+		IssueID:   created.ID,
+		Key:       created.Key,
+		URL:       strings.TrimRight(config.SiteURL, "/") + "/browse/" + created.Key,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// JiraIssueStatus is where a Jira issue is in its workflow
+type JiraIssueStatus struct {
+	Name     string // As shown in Jira, such as "In Review"
+	Category string // new, indeterminate or done
+}
+
+// FetchJiraIssueStatus looks up the current status of an issue
+func FetchJiraIssueStatus(ctx context.Context, config *models.JiraConfig, issueID string) (*JiraIssueStatus, error) {
+	var issue struct {
+		Fields struct {
+			Status struct {
+				Name           string `json:"name"`
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := jiraRequest(ctx, config, http.MethodGet, "/rest/api/3/issue/"+url.PathEscape(issueID)+"?fields=status", nil, &issue); err != nil {
+		return nil, err
+	}
+	return &JiraIssueStatus{
+		Name:     issue.Fields.Status.Name,
+		Category: issue.Fields.Status.StatusCategory.Key,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"disko-backend/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraRequestDecryptsCredentials(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("owner@example.com:jira-token"))
+
+	t.Run("Encrypted Token", func(t *testing.T) {
+		sealed, err := EncryptSecret("jira-token")
+		require.NoError(t, err)
+
+		config := &models.JiraConfig{SiteURL: server.URL, AuthType: models.JiraAuthAPIToken, Email: "owner@example.com", APIToken: sealed, ProjectKey: "DISKO"}
+		require.NoError(t, CheckJiraProject(context.Background(), config))
+		assert.Equal(t, expected, authorization)
+	})
+
+	t.Run("Plaintext Token Saved Before Encryption", func(t *testing.T) {
+		config := &models.JiraConfig{SiteURL: server.URL, AuthType: models.JiraAuthAPIToken, Email: "owner@example.com", APIToken: "jira-token", ProjectKey: "DISKO"}
+		require.NoError(t, CheckJiraProject(context.Background(), config))
+		assert.Equal(t, expected, authorization)
+	})
+}
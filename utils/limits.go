@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Errors returned when a board stream is not admitted
+var (
+	ErrTooManyIPConnections    = errors.New("too many connections from this address")
+	ErrTooManyBoardConnections = errors.New("too many connections to this board")
+)
+
+// connectionLimits protect the server from clients opening many streams or flooding their socket.
+// A zero limit disables it.
+type connectionLimits struct {
+	perIP             int     // Concurrent WebSocket and SSE connections per client IP
+	perBoard          int     // Concurrent connections per board on this instance
+	messagesPerSecond float64 // Sustained client messages per WebSocket connection
+	messageBurst      int     // Messages a connection may send at once before the rate applies
+}
+
+// connectionLimitsFromEnv reads the limits from the environment
+func connectionLimitsFromEnv() connectionLimits {
+	rate := limitFromEnv("WEBSOCKET_MESSAGES_PER_SECOND", 10)
+	return connectionLimits{
+		perIP:             limitFromEnv("WEBSOCKET_MAX_CONNECTIONS_PER_IP", 50),
+		perBoard:          limitFromEnv("WEBSOCKET_MAX_CONNECTIONS_PER_BOARD", 2000),
+		messagesPerSecond: float64(rate),
+		messageBurst:      2 * rate,
+	}
+}
+
+// limitFromEnv reads a non-negative limit from the environment
+func limitFromEnv(envVar string, fallback int) int {
+	if value := os.Getenv(envVar); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+			return limit
+		}
+	}
+	return fallback
+}
+
+// admit reserves a connection slot for the client IP on the board; release frees it when the
+// connection ends
+func (wsm *WebSocketManager) admit(boardID, ip string) error {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+
+	if wsm.limits.perIP > 0 && wsm.ipConnections[ip] >= wsm.limits.perIP {
+		return ErrTooManyIPConnections
+	}
+	if wsm.limits.perBoard > 0 && wsm.boardConnections[boardID] >= wsm.limits.perBoard {
+		return ErrTooManyBoardConnections
+	}
+	wsm.ipConnections[ip]++
+	wsm.boardConnections[boardID]++
+	return nil
+}
+
+// release frees a slot reserved with admit
+func (wsm *WebSocketManager) release(boardID, ip string) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+
+	if wsm.ipConnections[ip]--; wsm.ipConnections[ip] <= 0 {
+		delete(wsm.ipConnections, ip)
+	}
+	if wsm.boardConnections[boardID]--; wsm.boardConnections[boardID] <= 0 {
+		delete(wsm.boardConnections, boardID)
+	}
+}
+
+// messageBudget is a token bucket limiting the messages one connection sends; only its read loop uses it
+type messageBudget struct {
+	tokens float64
+	last   time.Time
+}
+
+// take spends a token for a message, reporting false when the connection exceeded its rate
+func (b *messageBudget) take(limits connectionLimits) bool {
+	if limits.messagesPerSecond <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	if b.last.IsZero() {
+		b.tokens = float64(limits.messageBurst)
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * limits.messagesPerSecond
+		if b.tokens > float64(limits.messageBurst) {
+			b.tokens = float64(limits.messageBurst)
+		}
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// closeSocket ends a WebSocket with a close code and reason the client can act on, such as 1013
+// (try again later) when it was not admitted or 1008 (policy violation) when it sent too much
+func closeSocket(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteWait))
+	conn.Close()
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"disko-backend/models"
+)
+
+// linearAPI is Linear's GraphQL endpoint
+const linearAPI = "https://api.linear.app/graphql"
+
+// linearClient calls Linear's API
+var linearClient = &http.Client{Timeout: 15 * time.Second}
+
+// linearQuery runs a GraphQL query or mutation with a Linear API key, decoding its data into out
+func linearQuery(ctx context.Context, apiKey, query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, linearAPI, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := linearClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Linear: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&reply); err != nil && resp.StatusCode == http.StatusOK {
+		return fmt.Errorf("failed to decode Linear response: %v", err)
+	}
+	if len(reply.Errors) > 0 {
+		messages := make([]string, 0, len(reply.Errors))
+		for _, replyErr := range reply.Errors {
+			messages = append(messages, replyErr.Message)
+		}
+		return fmt.Errorf("Linear responded with status %d: %s", resp.StatusCode, strings.Join(messages, "; "))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Linear responded with status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(reply.Data, out)
+}
+
+// LinearTeam is a Linear team issues are created in
+type LinearTeam struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// FindLinearTeam looks up a team by its key, such as ENG, checking the API key on the way
+func FindLinearTeam(ctx context.Context, apiKey, key string) (*LinearTeam, error) {
+	var data struct {
+		Teams struct {
+			Nodes []LinearTeam `json:"nodes"`
+		} `json:"teams"`
+	}
+	err := linearQuery(ctx, apiKey,
+		`query Team($key: String!) { teams(filter: { key: { eq: $key } }) { nodes { id key name } } }`,
+		map[string]interface{}{"key": key}, &data)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Teams.Nodes) == 0 {
+		return nil, fmt.Errorf("no Linear team with key %s is visible to the API key", key)
+	}
+	return &data.Teams.Nodes[0], nil
+}
+
+// CreateLinearIssue creates an issue in a team from an idea, returning the link to store on it
+func CreateLinearIssue(ctx context.Context, apiKey, teamID string, idea *models.Idea, createdBy string) (*models.LinearIssueLink, error) {
+	var description strings.Builder
+	if idea.Description != "" {
+		description.WriteString(idea.Description + "\n\n")
+	}
+	if idea.ValueStatement != "" {
+		description.WriteString("**Value:** " + idea.ValueStatement + "\n\n")
+	}
+	fmt.Fprintf(&description, "Created from [Disko](%s/board/%s)", os.Getenv("APP_URL"), idea.BoardID)
+
+	var data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+				URL        string `json:"url"`
+				State      struct {
+					Name string `json:"name"`
+					Type string `json:"type"`
+				} `json:"state"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	err := linearQuery(ctx, apiKey,
+		`mutation IssueCreate($input: IssueCreateInput!) { issueCreate(input: $input) { success issue { id identifier url state { name type } } } }`,
+		map[string]interface{}{"input": map[string]interface{}{
+			"teamId":      teamID,
+			"title":       idea.OneLiner,
+			"description": description.String(),
+		}}, &data)
+	if err != nil {
+		return nil, err
+	}
+	if !data.IssueCreate.Success {
+		return nil, errors.New("Linear did not create the issue")
+	}
+
+	issue := data.IssueCreate.Issue
+	now := time.Now().UTC()
+	return &models.LinearIssueLink{
+		IssueID:    issue.ID,
+		Identifier: issue.Identifier,
+		URL:        issue.URL,
+		State:      issue.State.Name,
+		StateType:  issue.State.Type,
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// linearWebhookTolerance is how old a Linear webhook may be, against replays
+const linearWebhookTolerance = time.Minute
+
+// VerifyLinearWebhook checks a Linear webhook's Linear-Signature header, the hex HMAC-SHA256 of the
+// body with the webhook's signing secret, and that its webhookTimestamp (milliseconds) is recent
+func VerifyLinearWebhook(secret string, body []byte, signature string, now time.Time) error {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrWebhookSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrWebhookSignature
+	}
+
+	var envelope struct {
+		WebhookTimestamp int64 `json:"webhookTimestamp"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return err
+	}
+	sent := time.UnixMilli(envelope.WebhookTimestamp)
+	if now.Sub(sent) > linearWebhookTolerance || sent.Sub(now) > linearWebhookTolerance {
+		return ErrWebhookTimestamp
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	markdownBullet   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	markdownNumbered = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	markdownLink     = regexp.MustCompile(`\[([^\]]+)\]\(((?:https?://|mailto:)[^)\s]+)\)`)
+	markdownBold     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalic   = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// RenderMarkdown converts the small Markdown subset used in notes (headings, lists, paragraphs,
+// bold, italic, inline code and http(s)/mailto links) to HTML. Raw HTML in the source is escaped,
+// so the output is safe to embed in public pages.
+func RenderMarkdown(source string) string {
+	var out strings.Builder
+	var paragraph []string
+	listTag := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + renderMarkdownInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			out.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			out.WriteString("<" + tag + ">\n")
+			listTag = tag
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case markdownHeading.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			tag := "h" + string(rune('0'+len(match[1])))
+			out.WriteString("<" + tag + ">" + renderMarkdownInline(match[2]) + "</" + tag + ">\n")
+		case markdownBullet.MatchString(trimmed):
+			flushParagraph()
+			openList("ul")
+			out.WriteString("<li>" + renderMarkdownInline(markdownBullet.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		case markdownNumbered.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			out.WriteString("<li>" + renderMarkdownInline(markdownNumbered.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// renderMarkdownInline escapes a span of text and applies inline formatting outside code spans
+func renderMarkdownInline(text string) string {
+	parts := strings.Split(text, "`")
+	var out strings.Builder
+	for i, part := range parts {
+		// Odd parts sit between backticks; an unmatched trailing backtick is kept literally
+		if i%2 == 1 && i < len(parts)-1 {
+			out.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+		if i%2 == 1 {
+			out.WriteString("`")
+		}
+
+		escaped := html.EscapeString(part)
+		escaped = markdownLink.ReplaceAllString(escaped, `<a href="$2" rel="nofollow noopener" target="_blank">$1</a>`)
+		escaped = markdownBold.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+		escaped = markdownItalic.ReplaceAllString(escaped, "<em>$1$2</em>")
+		out.WriteString(escaped)
+	}
+	return out.String()
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// maxMetricsBoards bounds the busiest boards listed in the realtime metrics
+const maxMetricsBoards = 50
+
+// realtimeCounters count the realtime activity of this instance since it started
+type realtimeCounters struct {
+	eventsPublished     atomic.Uint64 // Board events published here
+	eventsReceived      atomic.Uint64 // Board events received from other instances through the broker
+	messagesQueued      atomic.Uint64 // Messages queued for connections
+	messagesWritten     atomic.Uint64 // Messages written to connections
+	writeErrors         atomic.Uint64
+	slowConsumers       atomic.Uint64 // Connections dropped for a full send queue
+	rejectedConnections atomic.Uint64 // Connections refused by the connection limits
+	rateLimited         atomic.Uint64 // Connections closed for their message rate
+	accessRevoked       atomic.Uint64 // Connections closed when a recheck refused their access
+}
+
+// BoardConnections is the number of connections to a board
+type BoardConnections struct {
+	BoardID     string `json:"boardId"`
+	Connections int    `json:"connections"`
+}
+
+// RealtimeMetrics describes this instance's WebSocket and SSE load. Counters are cumulative since
+// the instance started; with several instances, each reports its own.
+type RealtimeMetrics struct {
+	InstanceID          string             `json:"instanceId"`
+	Connections         int                `json:"connections"`
+	MemberConnections   int                `json:"memberConnections"`
+	PublicConnections   int                `json:"publicConnections"`
+	Boards              int                `json:"boards"`        // Boards with at least one connection
+	UserStreams         int                `json:"userStreams"`   // Notification stream connections, not counted in Connections
+	BusiestBoards       []BoardConnections `json:"busiestBoards"` // Up to 50, most connections first
+	EventsPublished     uint64             `json:"eventsPublished"`
+	EventsReceived      uint64             `json:"eventsReceived"`
+	MessagesQueued      uint64             `json:"messagesQueued"`
+	MessagesWritten     uint64             `json:"messagesWritten"`
+	WriteErrors         uint64             `json:"writeErrors"`
+	SlowConsumers       uint64             `json:"slowConsumers"`
+	RejectedConnections uint64             `json:"rejectedConnections"`
+	RateLimited         uint64             `json:"rateLimited"`
+	AccessRevoked       uint64             `json:"accessRevoked"`
+	BrokerEnabled       bool               `json:"brokerEnabled"`
+}
+
+// GetRealtimeMetrics returns this instance's realtime connection counts and counters
+func GetRealtimeMetrics() RealtimeMetrics {
+	if wsManager == nil {
+		return RealtimeMetrics{BusiestBoards: []BoardConnections{}}
+	}
+	return wsManager.metricsSnapshot()
+}
+
+// metricsSnapshot counts the current connections and reads the counters
+func (wsm *WebSocketManager) metricsSnapshot() RealtimeMetrics {
+	metrics := RealtimeMetrics{
+		InstanceID:          wsm.instanceID,
+		BusiestBoards:       []BoardConnections{},
+		EventsPublished:     wsm.counters.eventsPublished.Load(),
+		EventsReceived:      wsm.counters.eventsReceived.Load(),
+		MessagesQueued:      wsm.counters.messagesQueued.Load(),
+		MessagesWritten:     wsm.counters.messagesWritten.Load(),
+		WriteErrors:         wsm.counters.writeErrors.Load(),
+		SlowConsumers:       wsm.counters.slowConsumers.Load(),
+		RejectedConnections: wsm.counters.rejectedConnections.Load(),
+		RateLimited:         wsm.counters.rateLimited.Load(),
+		AccessRevoked:       wsm.counters.accessRevoked.Load(),
+	}
+
+	wsm.mutex.RLock()
+	metrics.BrokerEnabled = wsm.broker != nil
+	for _, clients := range wsm.userStreams {
+		metrics.UserStreams += len(clients)
+	}
+	for boardID, clients := range wsm.connections {
+		for client := range clients {
+			if client.member() {
+				metrics.MemberConnections++
+			} else {
+				metrics.PublicConnections++
+			}
+		}
+		metrics.BusiestBoards = append(metrics.BusiestBoards, BoardConnections{BoardID: boardID, Connections: len(clients)})
+	}
+	wsm.mutex.RUnlock()
+
+	metrics.Connections = metrics.MemberConnections + metrics.PublicConnections
+	metrics.Boards = len(metrics.BusiestBoards)
+	sort.Slice(metrics.BusiestBoards, func(i, j int) bool {
+		if metrics.BusiestBoards[i].Connections != metrics.BusiestBoards[j].Connections {
+			return metrics.BusiestBoards[i].Connections > metrics.BusiestBoards[j].Connections
+		}
+		return metrics.BusiestBoards[i].BoardID < metrics.BusiestBoards[j].BoardID
+	})
+	if len(metrics.BusiestBoards) > maxMetricsBoards {
+		metrics.BusiestBoards = metrics.BusiestBoards[:maxMetricsBoards]
+	}
+	return metrics
+}
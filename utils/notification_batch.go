@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// notificationBatchWindow returns NOTIFICATION_BATCH_MINUTES, how long notifications of a batched
+// event are held back after the first; zero sends every notification
+func notificationBatchWindow() time.Duration {
+	return time.Duration(limitFromEnv("NOTIFICATION_BATCH_MINUTES", 15)) * time.Minute
+}
+
+// batchNotification counts a notification in its event's batching window and reports whether to
+// send it now. The first notification opens the window and is sent; later ones in the window are
+// left for the digest. Notifications are sent when batching fails rather than dropped.
+func batchNotification(ctx context.Context, event models.NotificationEvent, boardID, ideaID string) bool {
+	window := notificationBatchWindow()
+	if window == 0 || !models.IsBatchedNotificationEvent(event) {
+		return true
+	}
+
+	now := time.Now().UTC()
+	key := fmt.Sprintf("%s:%s:%s", boardID, ideaID, event)
+	update := bson.M{
+		"$inc": bson.M{"count": 1},
+		"$setOnInsert": bson.M{
+			"board_id":     boardID,
+			"idea_id":      ideaID,
+			"event":        event,
+			"window_start": now,
+			"flush_at":     now.Add(window),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	// Concurrent upserts opening the same window race on the key; the loser retries and joins it
+	for attempt := 0; attempt < 2; attempt++ {
+		err := models.GetCollection(ctx, models.NotificationBatchesCollection).FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Err()
+		switch {
+		case err == mongo.ErrNoDocuments:
+			return true
+		case err == nil:
+			return false
+		case !mongo.IsDuplicateKeyError(err):
+			log.Printf("[Notifications] Failed to batch notification - Board: %s, Idea: %s, Event: %s, Error: %v", boardID, ideaID, event, err)
+			return true
+		}
+	}
+	return true
+}
+
+// StartNotificationBatcher sends the digests of batching windows that ended, checking at the interval
+func StartNotificationBatcher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if models.DB == nil {
+				continue
+			}
+			models.ForEachTenant(context.Background(), flushNotificationBatches)
+		}
+	}()
+	log.Printf("[Notifications] Notification batcher started - Window: %v, Interval: %v", notificationBatchWindow(), interval)
+}
+
+// flushNotificationBatches closes every batch whose window ended, sending a digest for those that
+// held back notifications, for the tenant of ctx. Each batch is removed as it is claimed, so one
+// instance sends its digest.
+func flushNotificationBatches(ctx context.Context) {
+	if notificationService == nil {
+		InitNotificationService()
+	}
+
+	for {
+		batchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		var batch models.NotificationBatch
+		err := models.GetCollection(batchCtx, models.NotificationBatchesCollection).FindOneAndDelete(batchCtx,
+			bson.M{"flush_at": bson.M{"$lte": time.Now().UTC()}},
+			options.FindOneAndDelete().SetSort(bson.D{{Key: "flush_at", Value: 1}}),
+		).Decode(&batch)
+		if err == mongo.ErrNoDocuments {
+			cancel()
+			return
+		}
+		if err != nil {
+			cancel()
+			log.Printf("[Notifications] Failed to claim notification batch: %v", err)
+			return
+		}
+
+		// A window with only its first notification has nothing left to send
+		if batch.Count > 1 {
+			notificationService.sendDigest(batchCtx, &batch)
+		}
+		cancel()
+	}
+}
+
+// sendDigest notifies the board's owner and members of the notifications a batch held back, on
+// the same channels as the event's individual notifications
+func (ns *NotificationService) sendDigest(ctx context.Context, batch *models.NotificationBatch) {
+	board, notification, err := ns.buildNotification(ctx, batch.Event, batch.BoardID, batch.IdeaID)
+	if err != nil {
+		log.Printf("[Notifications] Failed to build notification digest - Board: %s, Idea: %s, Event: %s, Error: %v",
+			batch.BoardID, batch.IdeaID, batch.Event, err)
+		return
+	}
+
+	window := formatBatchWindow(batch.FlushAt.Sub(batch.WindowStart))
+	notification.Count = batch.Count
+	switch batch.Event {
+	case models.NotifyNewFeedback:
+		notification.Summary = fmt.Sprintf("\"%s\" received %d reactions in the last %s", notification.IdeaTitle, batch.Count, window)
+	case models.NotifyComment:
+		notification.Summary = fmt.Sprintf("\"%s\" received %d comments in the last %s", notification.IdeaTitle, batch.Count, window)
+	case models.NotifyNewSubmission:
+		notification.Summary = fmt.Sprintf("%d ideas were suggested in the last %s", batch.Count, window)
+	}
+
+	ns.dispatch(ctx, board, notification, "")
+
+	log.Printf("[Notifications] Notification digest sent - Board: %s, Idea: %s, Event: %s, Count: %d",
+		batch.BoardID, batch.IdeaID, batch.Event, batch.Count)
+}
+
+// formatBatchWindow describes a batching window, as in "15 minutes" or "hour"
+func formatBatchWindow(window time.Duration) string {
+	switch {
+	case window == time.Hour:
+		return "hour"
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%d hours", int(window.Hours()))
+	case window == time.Minute:
+		return "minute"
+	}
+	return fmt.Sprintf("%d minutes", int(window.Minutes()))
+}
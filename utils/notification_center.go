@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"log"
+
+	"disko-backend/models"
+)
+
+// storeInAppNotification keeps an in-app notification in the user's notification center, then
+// pushes it with the new unread count to the user's notification streams and board connections
+func storeInAppNotification(ctx context.Context, userID string, notification *BoardNotification) {
+	stored := models.UserNotification{
+		ID:        GenerateFullUUID(),
+		UserID:    userID,
+		BoardID:   notification.BoardID,
+		BoardName: notification.BoardName,
+		Event:     notification.Event,
+		IdeaID:    notification.IdeaID,
+		IdeaTitle: notification.IdeaTitle,
+		Summary:   notification.Summary,
+		CreatedAt: notification.Timestamp,
+	}
+	if _, err := models.GetCollection(ctx, models.UserNotificationsCollection).InsertOne(ctx, stored); err != nil {
+		// Still show it live; it is only missing from the notification center
+		log.Printf("Failed to store notification for user %s on board %s: %v", userID, notification.BoardID, err)
+	}
+
+	SendUserNotification(notification.BoardID, userID, notification)
+
+	unread, err := models.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to count unread notifications for user %s: %v", userID, err)
+		return
+	}
+	SendToUserStream(userID, &WebSocketMessage{
+		Type:    EventNotification,
+		BoardID: notification.BoardID,
+		Data: map[string]interface{}{
+			"notification": stored,
+			"unreadCount":  unread,
+		},
+	})
+}
+
+// SendUnreadCount tells a user's notification streams their unread count changed
+func SendUnreadCount(userID string, unread int64) {
+	SendToUserStream(userID, &WebSocketMessage{
+		Type: EventUnreadCount,
+		Data: map[string]interface{}{"unreadCount": unread},
+	})
+}
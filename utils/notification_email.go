@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// notificationEmailTemplate tells a board's owner or member about an event on the board
+var notificationEmailTemplate = template.Must(template.New("notification").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.BoardName}}</title>
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; line-height: 1.6; color: #333; background-color: #f9fafb; margin: 0; padding: 24px;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 12px; overflow: hidden;">
+        <div style="background: linear-gradient(135deg, #3b82f6 0%, #8b5cf6 100%); color: white; padding: 32px 30px; text-align: center;">
+            <h1 style="margin: 0; font-size: 24px;">{{.Heading}}</h1>
+            <p style="margin: 8px 0 0 0; opacity: 0.9;">{{.BoardName}}</p>
+        </div>
+        <div style="padding: 32px 30px;">
+            <p style="margin: 0 0 16px 0;">{{.Summary}}</p>
+            {{if .IdeaTitle}}
+            <div style="padding: 12px 16px; background-color: #f8fafc; border-radius: 6px; border-left: 3px solid #10b981; margin-bottom: 24px;">
+                <div style="font-weight: 600; color: #1e293b;">{{.IdeaTitle}}</div>
+                {{if .FeedbackType}}<div style="font-size: 14px; color: #64748b;">Feedback: {{.FeedbackType}}</div>{{end}}
+            </div>
+            {{end}}
+            <div style="text-align: center;">
+                <a href="{{.BoardURL}}" style="display: inline-block; background: linear-gradient(135deg, #3b82f6 0%, #8b5cf6 100%); color: white; text-decoration: none; padding: 14px 28px; border-radius: 8px; font-weight: 600;">View board</a>
+            </div>
+        </div>
+        <div style="background-color: #f1f5f9; padding: 20px 30px; text-align: center; color: #64748b; font-size: 13px;">
+            <p style="margin: 0;">{{.Time}} · You're receiving this because of your notification preferences for this board. Change them from the board's settings.</p>
+        </div>
+    </div>
+</body>
+</html>`))
+
+// notificationEmailHeadings title the notification email of each event
+var notificationEmailHeadings = map[models.NotificationEvent]string{
+	models.NotifyNewFeedback:   "New feedback 🎉",
+	models.NotifyNewSubmission: "New idea submitted",
+	models.NotifyComment:       "New comment",
+	models.NotifyStatusChange:  "Idea updated",
+	models.NotifyAlert:         "Alert triggered",
+}
+
+// sendNotificationEmail emails a board notification to a user at the address cached from the
+// identity provider. Users without an email address on file are skipped.
+func sendNotificationEmail(ctx context.Context, notification *BoardNotification, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	email, err := models.FindUserEmail(ctx, userID)
+	if err == mongo.ErrNoDocuments {
+		return permanentError{fmt.Errorf("user %s has no profile", userID)}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user email: %v", err)
+	}
+	if email == "" {
+		log.Printf("[Email] Notification email skipped - No email on file, UserID: %s, BoardID: %s", userID, notification.BoardID)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	err = notificationEmailTemplate.Execute(&buf, struct {
+		Heading      string
+		BoardName    string
+		Summary      string
+		IdeaTitle    string
+		FeedbackType string
+		BoardURL     string
+		Time         string
+	}{
+		Heading:      notificationEmailHeadings[notification.Event],
+		BoardName:    notification.BoardName,
+		Summary:      notification.Summary,
+		IdeaTitle:    notification.IdeaTitle,
+		FeedbackType: notification.FeedbackType,
+		BoardURL:     fmt.Sprintf("%s/board/%s", os.Getenv("APP_URL"), notification.BoardID),
+		Time:         notification.Timestamp.Format("2006-01-02 15:04 UTC"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render notification email: %v", err)
+	}
+
+	subject := fmt.Sprintf("[%s] %s", notification.BoardName, notification.Summary)
+	if err := sendHTMLEmail(email, subject, buf.String()); err != nil {
+		return err
+	}
+
+	log.Printf("[Email] Notification email sent - UserID: %s, BoardID: %s, Event: %s", userID, notification.BoardID, notification.Event)
+	return nil
+}
@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	// notificationRetryBase is the wait before a job's first retry; each later retry waits twice as long
+	notificationRetryBase = 10 * time.Second
+	// notificationJobLease is how long a worker owns a claimed job. A job still processing after
+	// that, because its worker crashed, is claimed again.
+	notificationJobLease = 2 * time.Minute
+)
+
+// notificationWake wakes an idle worker when a job is queued
+var notificationWake = make(chan struct{}, 1)
+
+// permanentError marks a delivery failure that retrying cannot fix; the job is dead-lettered at once
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+// notificationRetryDelay is the wait after a job's failed attempt, counted from 1
+func notificationRetryDelay(attempt int) time.Duration {
+	return notificationRetryBase << (attempt - 1)
+}
+
+// WakeNotificationWorkers has an idle worker look for due jobs now rather than at its next poll
+func WakeNotificationWorkers() {
+	select {
+	case notificationWake <- struct{}{}:
+	default:
+	}
+}
+
+// enqueueNotificationJob persists a delivery of a notification. Queuing the same delivery twice,
+// with the same notification ID, kind and target, is a no-op.
+func enqueueNotificationJob(ctx context.Context, kind models.NotificationJobKind, target string, notification *BoardNotification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	job := newNotificationJob(fmt.Sprintf("%s:%s:%s", notification.ID, kind, target),
+		kind, target, notification.BoardID, string(notification.Event), payload)
+	return insertNotificationJob(ctx, job)
+}
+
+// newNotificationJob builds a pending job, due now
+func newNotificationJob(idempotencyKey string, kind models.NotificationJobKind, target, boardID, event string, payload []byte) models.NotificationJob {
+	now := time.Now().UTC()
+	return models.NotificationJob{
+		ID:             GenerateFullUUID(),
+		IdempotencyKey: idempotencyKey,
+		Kind:           kind,
+		Target:         target,
+		BoardID:        boardID,
+		Event:          event,
+		Payload:        string(payload),
+		Status:         models.JobPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// insertNotificationJob queues a job and wakes a worker. A job whose idempotency key is already
+// queued is dropped.
+func insertNotificationJob(ctx context.Context, job models.NotificationJob) error {
+	if _, err := models.GetCollection(ctx, models.NotificationJobsCollection).InsertOne(ctx, job); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return err
+	}
+
+	WakeNotificationWorkers()
+	return nil
+}
+
+// StartNotificationQueue starts the workers delivering queued notifications. Each polls for due
+// jobs at the interval, or sooner when a job is queued.
+func StartNotificationQueue(interval time.Duration) {
+	workers := limitFromEnv("NOTIFICATION_WORKERS", 4)
+	for i := 0; i < workers; i++ {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+				case <-notificationWake:
+				}
+				if models.DB == nil {
+					continue
+				}
+				models.ForEachTenant(context.Background(), runDueNotificationJobs)
+			}
+		}()
+	}
+	log.Printf("[Notifications] Notification queue started - Workers: %d, Interval: %v", workers, interval)
+}
+
+// runDueNotificationJobs processes the due jobs of the tenant of ctx until none are left
+func runDueNotificationJobs(ctx context.Context) {
+	for {
+		job, err := claimNotificationJob(ctx)
+		if err != nil {
+			log.Printf("[Notifications] Failed to claim notification job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		// Let another worker share the remaining jobs
+		WakeNotificationWorkers()
+		processNotificationJob(ctx, job)
+	}
+}
+
+// claimNotificationJob takes the oldest due job, or one whose worker's lease expired, counting the
+// attempt. It returns nil when no job is due.
+func claimNotificationJob(ctx context.Context) (*models.NotificationJob, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	var job models.NotificationJob
+	err := models.GetCollection(ctx, models.NotificationJobsCollection).FindOneAndUpdate(ctx,
+		bson.M{"$or": []bson.M{
+			{"status": models.JobPending, "next_attempt_at": bson.M{"$lte": now}},
+			{"status": models.JobProcessing, "locked_until": bson.M{"$lte": now}},
+		}},
+		bson.M{
+			"$set": bson.M{
+				"status":       models.JobProcessing,
+				"locked_until": now.Add(notificationJobLease),
+				"updated_at":   now,
+			},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// processNotificationJob makes one delivery attempt and records its outcome: done, pending again
+// with backoff, or dead once it failed permanently or ran out of attempts
+func processNotificationJob(ctx context.Context, job *models.NotificationJob) {
+	if notificationService == nil {
+		InitNotificationService()
+	}
+	err := notificationService.deliverJob(ctx, job)
+
+	now := time.Now().UTC()
+	update := bson.M{"updated_at": now}
+	var permanent permanentError
+	switch {
+	case err == nil:
+		update["status"] = models.JobDone
+		update["completed_at"] = now
+	case errors.As(err, &permanent) || job.Attempts >= models.MaxJobAttempts:
+		update["status"] = models.JobDead
+		update["last_error"] = err.Error()
+		log.Printf("[Notifications] Notification job dead-lettered - JobID: %s, Kind: %s, BoardID: %s, Attempts: %d, Error: %v",
+			job.ID, job.Kind, job.BoardID, job.Attempts, err)
+	default:
+		update["status"] = models.JobPending
+		update["next_attempt_at"] = now.Add(notificationRetryDelay(job.Attempts))
+		update["last_error"] = err.Error()
+		log.Printf("[Notifications] Notification job failed, retrying - JobID: %s, Kind: %s, BoardID: %s, Attempt: %d, Error: %v",
+			job.ID, job.Kind, job.BoardID, job.Attempts, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, updateErr := models.GetCollection(ctx, models.NotificationJobsCollection).UpdateOne(ctx,
+		bson.M{"_id": job.ID, "status": models.JobProcessing},
+		bson.M{"$set": update, "$unset": bson.M{"locked_until": ""}})
+	if updateErr != nil {
+		log.Printf("[Notifications] Failed to record notification job outcome - JobID: %s, Error: %v", job.ID, updateErr)
+	}
+}
+
+// deliverJob performs a job's delivery. Board webhook jobs carry the event envelope to post; the
+// other kinds carry the notification.
+func (ns *NotificationService) deliverJob(ctx context.Context, job *models.NotificationJob) error {
+	if job.Kind == models.JobBoardWebhook {
+		return deliverBoardWebhook(ctx, job)
+	}
+
+	var notification BoardNotification
+	if err := json.Unmarshal([]byte(job.Payload), &notification); err != nil {
+		return permanentError{fmt.Errorf("invalid payload: %v", err)}
+	}
+
+	switch job.Kind {
+	case models.JobEmail:
+		return sendNotificationEmail(ctx, &notification, job.Target)
+	case models.JobPush:
+		return sendPushNotification(ctx, &notification, job.Target)
+	case models.JobTelegram:
+		return sendTelegramNotification(ctx, &notification, job.Target)
+	case models.JobSlack:
+		return ns.sendSlackNotification(&notification)
+	case models.JobWebhook:
+		return ns.sendWebhookNotification(&notification)
+	}
+	return permanentError{fmt.Errorf("unknown job kind: %s", job.Kind)}
+}
@@ -13,27 +13,33 @@ import (
 	"disko-backend/models"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 // NotificationService handles multi-channel notifications
 type NotificationService struct {
 	emailEnabled    bool
+	pushEnabled     bool
 	slackEnabled    bool
 	webhookEnabled  bool
 	slackWebhookURL string
 	webhookURL      string
 }
 
-// FeedbackNotification represents a feedback notification
-type FeedbackNotification struct {
-	BoardID      string    `json:"boardId"`
-	BoardName    string    `json:"boardName"`
-	IdeaID       string    `json:"ideaId"`
-	IdeaTitle    string    `json:"ideaTitle"`
-	FeedbackType string    `json:"feedbackType"`
-	ClientIP     string    `json:"clientIp"`
-	Timestamp    time.Time `json:"timestamp"`
-	AdminEmail   string    `json:"adminEmail,omitempty"`
+// BoardNotification is a board event sent to the channels its recipients chose in their
+// notification preferences
+type BoardNotification struct {
+	ID           string                   `json:"id"` // Unique per notification; receivers can use it to drop duplicates
+	Event        models.NotificationEvent `json:"event"`
+	BoardID      string                   `json:"boardId"`
+	BoardName    string                   `json:"boardName"`
+	IdeaID       string                   `json:"ideaId,omitempty"`
+	IdeaTitle    string                   `json:"ideaTitle,omitempty"`
+	FeedbackType string                   `json:"feedbackType,omitempty"`
+	Summary      string                   `json:"summary"`
+	Count        int                      `json:"count,omitempty"` // Set on digests: how many notifications it stands for
+	ClientIP     string                   `json:"clientIp,omitempty"`
+	Timestamp    time.Time                `json:"timestamp"`
 }
 
 // SlackMessage represents a Slack webhook message
@@ -59,6 +65,7 @@ type SlackField struct {
 func NewNotificationService() *NotificationService {
 	return &NotificationService{
 		emailEnabled:    os.Getenv("EMAIL_ENABLED") == "true",
+		pushEnabled:     VAPIDPublicKey() != "",
 		slackEnabled:    os.Getenv("SLACK_WEBHOOK_URL") != "",
 		webhookEnabled:  os.Getenv("WEBHOOK_URL") != "",
 		slackWebhookURL: os.Getenv("SLACK_WEBHOOK_URL"),
@@ -66,157 +73,238 @@ func NewNotificationService() *NotificationService {
 	}
 }
 
-// SendFeedbackNotification sends notifications across all configured channels
-func (ns *NotificationService) SendFeedbackNotification(boardID, ideaID, feedbackType, clientIP string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// SendFeedbackNotification notifies the board's owner and members of feedback on an idea
+func (ns *NotificationService) SendFeedbackNotification(ctx context.Context, boardID, ideaID, feedbackType, clientIP string) {
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 30*time.Second)
 	defer cancel()
 
-	// Get board and idea information
-	notification, err := ns.buildNotification(ctx, boardID, ideaID, feedbackType, clientIP)
+	// Feedback arriving in bursts is left for the digest sent when the batching window ends
+	if batchNotification(ctx, models.NotifyNewFeedback, boardID, ideaID) {
+		// Get board and idea information
+		board, notification, err := ns.buildNotification(ctx, models.NotifyNewFeedback, boardID, ideaID)
+		if err != nil {
+			log.Printf("Failed to build notification: %v", err)
+			return
+		}
+		notification.FeedbackType = feedbackType
+		notification.ClientIP = clientIP
+		notification.Summary = fmt.Sprintf("New %s feedback on \"%s\"", feedbackType, notification.IdeaTitle)
+
+		ns.dispatch(ctx, board, notification, "")
+	}
+
+	// Trigger real-time feedback animation on admin board
+	emoji := ""
+	if len(feedbackType) > 6 && feedbackType[:6] == "emoji:" {
+		emoji = feedbackType[6:]
+		feedbackType = "emoji"
+	}
+	BroadcastFeedbackAnimation(ctx, boardID, ideaID, feedbackType, emoji)
+
+	log.Printf("Feedback notification sent: Board=%s, Idea=%s, Type=%s",
+		boardID, ideaID, feedbackType)
+}
+
+// SendBoardNotification notifies the board's owner and members of an event, except the user who
+// caused it. ideaID may be empty for events that are not about an idea. Events that come in bursts
+// are batched: see batchNotification.
+func (ns *NotificationService) SendBoardNotification(ctx context.Context, event models.NotificationEvent, boardID, ideaID, summary, actorID string) {
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 30*time.Second)
+	defer cancel()
+
+	if !batchNotification(ctx, event, boardID, ideaID) {
+		log.Printf("Board notification batched: Board=%s, Idea=%s, Event=%s", boardID, ideaID, event)
+		return
+	}
+
+	board, notification, err := ns.buildNotification(ctx, event, boardID, ideaID)
 	if err != nil {
 		log.Printf("Failed to build notification: %v", err)
 		return
 	}
+	notification.Summary = summary
+
+	ns.dispatch(ctx, board, notification, actorID)
+
+	log.Printf("Board notification sent: Board=%s, Idea=%s, Event=%s", boardID, ideaID, event)
+}
+
+// dispatch sends a notification to the channels the board's owner and members selected for its
+// event. In-app notifications are stored in each recipient's notification center and pushed to
+// their open connections right away; emails, Web Push messages to each of a recipient's browsers,
+// the instance's Slack and webhook channels, used once when any recipient selected them, and the
+// board's Telegram chat are queued for the notification workers. Board webhooks
+// receive catalog events instead; see EmitWebhookEvent.
+func (ns *NotificationService) dispatch(ctx context.Context, board *models.Board, notification *BoardNotification, actorID string) {
+	preferences, err := models.FindNotificationPreferences(ctx, board.ID)
+	if err != nil {
+		// Fall back to the defaults rather than dropping the notification
+		log.Printf("Failed to load notification preferences for board %s: %v", board.ID, err)
+	}
 
-	// Send notifications concurrently
-	if ns.emailEnabled {
-		go ns.sendEmailNotification(notification)
+	recipients := []string{board.UserID}
+	for _, member := range board.Members {
+		recipients = append(recipients, member.UserID)
 	}
 
-	if ns.slackEnabled {
-		go ns.sendSlackNotification(notification)
+	slack, webhook := false, false
+	for _, userID := range recipients {
+		if userID == actorID {
+			continue
+		}
+		preference := preferences[userID] // Nil uses the defaults
+		ns.notifyRecipient(ctx, userID, preference, notification)
+		slack = slack || preference.Wants(notification.Event, models.ChannelSlack)
+		webhook = webhook || preference.Wants(notification.Event, models.ChannelWebhook)
 	}
 
-	if ns.webhookEnabled {
-		go ns.sendWebhookNotification(notification)
+	if ns.slackEnabled && slack {
+		ns.enqueue(ctx, models.JobSlack, "", notification)
 	}
 
-	// Trigger real-time feedback animation on admin board
-	emoji := ""
-	if len(feedbackType) > 6 && feedbackType[:6] == "emoji:" {
-		emoji = feedbackType[6:]
-		feedbackType = "emoji"
+	if ns.webhookEnabled && webhook {
+		ns.enqueue(ctx, models.JobWebhook, "", notification)
 	}
-	BroadcastFeedbackAnimation(boardID, ideaID, feedbackType, emoji)
 
-	log.Printf("Feedback notification sent: Board=%s, Idea=%s, Type=%s",
-		boardID, ideaID, feedbackType)
+	// The board's Telegram chat follows its own event list, not the members' preferences
+	var telegram models.TelegramConfig
+	err = models.GetCollection(ctx, models.TelegramConfigsCollection).FindOne(ctx, bson.M{"board_id": board.ID, "enabled": true}).Decode(&telegram)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			log.Printf("Failed to load Telegram config for board %s: %v", board.ID, err)
+		}
+		return
+	}
+	if telegram.Subscribed(notification.Event) {
+		ns.enqueue(ctx, models.JobTelegram, telegram.ID, notification)
+	}
+}
+
+// dispatchToUser sends a notification to one user on the personal channels they selected for its
+// event: email, in-app and push
+func (ns *NotificationService) dispatchToUser(ctx context.Context, board *models.Board, notification *BoardNotification, userID string) {
+	preferences, err := models.FindNotificationPreferences(ctx, board.ID)
+	if err != nil {
+		// Fall back to the defaults rather than dropping the notification
+		log.Printf("Failed to load notification preferences for board %s: %v", board.ID, err)
+	}
+	ns.notifyRecipient(ctx, userID, preferences[userID], notification)
+}
+
+// notifyRecipient delivers a notification on a recipient's selected personal channels; a nil
+// preference uses the defaults
+func (ns *NotificationService) notifyRecipient(ctx context.Context, userID string, preference *models.NotificationPreference, notification *BoardNotification) {
+	if ns.emailEnabled && preference.Wants(notification.Event, models.ChannelEmail) {
+		ns.enqueue(ctx, models.JobEmail, userID, notification)
+	}
+	if preference.Wants(notification.Event, models.ChannelInApp) {
+		storeInAppNotification(ctx, userID, notification)
+	}
+	if ns.pushEnabled && preference.Wants(notification.Event, models.ChannelPush) {
+		ns.enqueuePush(ctx, userID, notification)
+	}
+}
+
+// enqueue queues a delivery of the notification, logging failures
+func (ns *NotificationService) enqueue(ctx context.Context, kind models.NotificationJobKind, target string, notification *BoardNotification) {
+	if err := enqueueNotificationJob(ctx, kind, target, notification); err != nil {
+		log.Printf("Failed to queue %s notification for board %s: %v", kind, notification.BoardID, err)
+	}
+}
+
+// enqueuePush queues a push of the notification to each browser the user subscribed on
+func (ns *NotificationService) enqueuePush(ctx context.Context, userID string, notification *BoardNotification) {
+	subscriptions, err := models.FindPushSubscriptions(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load push subscriptions of user %s: %v", userID, err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		ns.enqueue(ctx, models.JobPush, subscription.ID, notification)
+	}
 }
 
 // buildNotification creates a notification object with board and idea details
-func (ns *NotificationService) buildNotification(ctx context.Context, boardID, ideaID, feedbackType, clientIP string) (*FeedbackNotification, error) {
+func (ns *NotificationService) buildNotification(ctx context.Context, event models.NotificationEvent, boardID, ideaID string) (*models.Board, *BoardNotification, error) {
 	// Get board information
-	boardsCollection := models.GetCollection(models.BoardsCollection)
+	boardsCollection := models.GetCollection(ctx, models.BoardsCollection)
 	var board models.Board
 	err := boardsCollection.FindOne(ctx, bson.M{"_id": boardID}).Decode(&board)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board: %v", err)
+		return nil, nil, fmt.Errorf("failed to get board: %v", err)
 	}
 
-	// Get idea information
-	ideasCollection := models.GetCollection(models.IdeasCollection)
-	var idea models.Idea
-	err = ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get idea: %v", err)
-	}
-
-	// TODO: Get admin email from Clerk user info
-	// For now, we'll use a placeholder
-	adminEmail := "admin@example.com"
-
-	return &FeedbackNotification{
-		BoardID:      boardID,
-		BoardName:    board.Name,
-		IdeaID:       ideaID,
-		IdeaTitle:    idea.OneLiner,
-		FeedbackType: feedbackType,
-		ClientIP:     clientIP,
-		Timestamp:    time.Now().UTC(),
-		AdminEmail:   adminEmail,
-	}, nil
-}
+	notification := &BoardNotification{
+		ID:        GenerateFullUUID(),
+		Event:     event,
+		BoardID:   boardID,
+		BoardName: board.Name,
+		Timestamp: time.Now().UTC(),
+	}
 
-// sendEmailNotification sends an email notification
-func (ns *NotificationService) sendEmailNotification(notification *FeedbackNotification) {
-	// This is a placeholder for email notification
-	// In a real implementation, you would integrate with an email service like:
-	// - SendGrid
-	// - AWS SES
-	// - Mailgun
-	// - SMTP server
-
-	log.Printf("EMAIL NOTIFICATION: %s received feedback on '%s' in board '%s'",
-		notification.FeedbackType, notification.IdeaTitle, notification.BoardName)
-
-	// Example email content
-	subject := fmt.Sprintf("New feedback on your idea: %s", notification.IdeaTitle)
-	body := fmt.Sprintf(`
-Hello,
-
-You've received new feedback on your idea "%s" in board "%s".
-
-Feedback Type: %s
-Time: %s
-IP Address: %s
-
-View your board: %s
-
-Best regards,
-Disko Team
-`,
-		notification.IdeaTitle,
-		notification.BoardName,
-		notification.FeedbackType,
-		notification.Timestamp.Format("2006-01-02 15:04:05 UTC"),
-		notification.ClientIP,
-		fmt.Sprintf("https://yourdomain.com/board/%s", notification.BoardID),
-	)
+	// Get idea information
+	if ideaID != "" {
+		ideasCollection := models.GetCollection(ctx, models.IdeasCollection)
+		var idea models.Idea
+		err = ideasCollection.FindOne(ctx, bson.M{"_id": ideaID}).Decode(&idea)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get idea: %v", err)
+		}
+		notification.IdeaID = ideaID
+		notification.IdeaTitle = idea.OneLiner
+	}
 
-	// TODO: Implement actual email sending
-	log.Printf("Email would be sent to %s with subject: %s", notification.AdminEmail, subject)
-	log.Printf("Email body: %s", body)
+	return &board, notification, nil
 }
 
 // sendSlackNotification sends a Slack webhook notification
-func (ns *NotificationService) sendSlackNotification(notification *FeedbackNotification) {
+func (ns *NotificationService) sendSlackNotification(notification *BoardNotification) error {
 	if ns.slackWebhookURL == "" {
-		return
+		return nil
+	}
+
+	text := notification.Summary
+	eventField := SlackField{Title: "Event", Value: string(notification.Event), Short: true}
+	if notification.Event == models.NotifyNewFeedback && notification.Count == 0 {
+		text = "🎉 New feedback received on your Disko board!"
+		eventField = SlackField{Title: "Feedback Type", Value: notification.FeedbackType, Short: true}
 	}
 
+	fields := []SlackField{
+		{
+			Title: "Board",
+			Value: notification.BoardName,
+			Short: true,
+		},
+	}
+	if notification.IdeaTitle != "" {
+		fields = append(fields, SlackField{
+			Title: "Idea",
+			Value: notification.IdeaTitle,
+			Short: true,
+		})
+	}
+	fields = append(fields,
+		eventField,
+		SlackField{
+			Title: "Time",
+			Value: notification.Timestamp.Format("2006-01-02 15:04:05 UTC"),
+			Short: true,
+		},
+		SlackField{
+			Title: "Board Link",
+			Value: fmt.Sprintf("https://yourdomain.com/board/%s", notification.BoardID),
+			Short: false,
+		},
+	)
+
 	// Create Slack message
 	message := SlackMessage{
-		Text: "🎉 New feedback received on your Disko board!",
+		Text: text,
 		Attachments: []SlackAttachment{
 			{
-				Color: "#36a64f", // Green color
-				Fields: []SlackField{
-					{
-						Title: "Board",
-						Value: notification.BoardName,
-						Short: true,
-					},
-					{
-						Title: "Idea",
-						Value: notification.IdeaTitle,
-						Short: true,
-					},
-					{
-						Title: "Feedback Type",
-						Value: notification.FeedbackType,
-						Short: true,
-					},
-					{
-						Title: "Time",
-						Value: notification.Timestamp.Format("2006-01-02 15:04:05 UTC"),
-						Short: true,
-					},
-					{
-						Title: "Board Link",
-						Value: fmt.Sprintf("https://yourdomain.com/board/%s", notification.BoardID),
-						Short: false,
-					},
-				},
+				Color:  "#36a64f", // Green color
+				Fields: fields,
 			},
 		},
 	}
@@ -224,55 +312,46 @@ func (ns *NotificationService) sendSlackNotification(notification *FeedbackNotif
 	// Send to Slack
 	jsonData, err := json.Marshal(message)
 	if err != nil {
-		log.Printf("Failed to marshal Slack message: %v", err)
-		return
+		return permanentError{fmt.Errorf("failed to marshal Slack message: %v", err)}
 	}
 
-	resp, err := http.Post(ns.slackWebhookURL, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := webhookClient.Post(ns.slackWebhookURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
-		log.Printf("Failed to send Slack notification: %v", err)
-		return
+		return fmt.Errorf("failed to send Slack notification: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("Slack notification failed with status: %d", resp.StatusCode)
-		return
+		return fmt.Errorf("Slack notification failed with status: %d", resp.StatusCode)
 	}
 
 	log.Printf("Slack notification sent successfully")
+	return nil
 }
 
-// sendWebhookNotification sends a generic webhook notification
-func (ns *NotificationService) sendWebhookNotification(notification *FeedbackNotification) {
+// sendWebhookNotification posts the full notification object as JSON to the instance's webhook
+func (ns *NotificationService) sendWebhookNotification(notification *BoardNotification) error {
 	if ns.webhookURL == "" {
-		return
+		return nil
 	}
 
-	// Send the full notification object as JSON
 	jsonData, err := json.Marshal(notification)
 	if err != nil {
-		log.Printf("Failed to marshal webhook notification: %v", err)
-		return
-	}
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+		return permanentError{fmt.Errorf("failed to marshal webhook notification: %v", err)}
 	}
 
-	resp, err := client.Post(ns.webhookURL, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := webhookClient.Post(ns.webhookURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
-		log.Printf("Failed to send webhook notification: %v", err)
-		return
+		return fmt.Errorf("failed to send webhook notification: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		log.Printf("Webhook notification failed with status: %d", resp.StatusCode)
-		return
+		return fmt.Errorf("webhook notification failed with status: %d", resp.StatusCode)
 	}
 
 	log.Printf("Webhook notification sent successfully")
+	return nil
 }
 
 // Global notification service instance
@@ -284,9 +363,17 @@ func InitNotificationService() {
 }
 
 // SendFeedbackNotification is a convenience function to send notifications
-func SendFeedbackNotification(boardID, ideaID, feedbackType, clientIP string) {
+func SendFeedbackNotification(ctx context.Context, boardID, ideaID, feedbackType, clientIP string) {
+	if notificationService == nil {
+		InitNotificationService()
+	}
+	notificationService.SendFeedbackNotification(ctx, boardID, ideaID, feedbackType, clientIP)
+}
+
+// SendBoardNotification is a convenience function to notify a board's owner and members of an event
+func SendBoardNotification(ctx context.Context, event models.NotificationEvent, boardID, ideaID, summary, actorID string) {
 	if notificationService == nil {
 		InitNotificationService()
 	}
-	notificationService.SendFeedbackNotification(boardID, ideaID, feedbackType, clientIP)
+	notificationService.SendBoardNotification(ctx, event, boardID, ideaID, summary, actorID)
 }
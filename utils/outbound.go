@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Errors returned by ValidateOutboundURL and by clients of NewOutboundClient
+var (
+	ErrOutboundURL     = errors.New("URL must be an absolute https URL")
+	ErrOutboundAddress = errors.New("URL points to a private, loopback or link-local address")
+	ErrOutboundLookup  = errors.New("URL host could not be resolved")
+)
+
+// maxOutboundRedirects bounds how many redirects an outbound request follows
+const maxOutboundRedirects = 5
+
+// nonPublicPrefixes are ranges outside the public internet that netip does not classify itself
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach any IPv4 address
+}
+
+// isPublicAddress reports whether an address is on the public internet, rejecting loopback,
+// private (RFC 1918 and unique local), link-local (including cloud metadata at 169.254.169.254),
+// multicast, unspecified and reserved addresses
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkOutboundURL checks that a URL is an absolute https URL whose host, if an IP address, is public
+func checkOutboundURL(target *url.URL) error {
+	if target.Scheme != "https" || target.Hostname() == "" || target.User != nil {
+		return ErrOutboundURL
+	}
+	if addr, err := netip.ParseAddr(target.Hostname()); err == nil && !isPublicAddress(addr) {
+		return ErrOutboundAddress
+	}
+	return nil
+}
+
+// ValidateOutboundURL checks a URL users ask the server to send requests to: it must be an absolute
+// https URL whose host resolves only to public addresses. Clients of NewOutboundClient check the
+// addresses again when they connect, since DNS answers can change after the URL was saved.
+func ValidateOutboundURL(ctx context.Context, rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return ErrOutboundURL
+	}
+	if err := checkOutboundURL(target); err != nil {
+		return err
+	}
+	if _, err := netip.ParseAddr(target.Hostname()); err == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", target.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return ErrOutboundLookup
+	}
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return ErrOutboundAddress
+		}
+	}
+	return nil
+}
+
+// refuseNonPublicDial is a net.Dialer control function that refuses connections to addresses outside
+// the public internet. It runs after name resolution, right before each connection is made.
+func refuseNonPublicDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("refusing connection to %s: %w", address, err)
+	}
+	if !isPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("refusing connection to %s: %w", address, ErrOutboundAddress)
+	}
+	return nil
+}
+
+// NewOutboundClient returns an HTTP client for requests to URLs users provided, such as webhooks.
+// It only connects to public addresses, checked when each connection is made so DNS changes and
+// redirects cannot reach internal services, follows at most a few redirects and only to https, and
+// ignores proxy settings, which would hide the real destination from the check.
+func NewOutboundClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   refuseNonPublicDial,
+	}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxOutboundRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxOutboundRedirects)
+			}
+			return checkOutboundURL(req.URL)
+		},
+	}
+}
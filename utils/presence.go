@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// Presence events sent on the board channel
+const (
+	EventPresence       = "presence" // Snapshot sent to a user's additional tabs
+	EventPresenceJoined = "presence_joined"
+	EventPresenceLeft   = "presence_left"
+)
+
+// BoardPresence describes who is viewing a board. Members see which users are connected; public
+// visitors only see the counts.
+type BoardPresence struct {
+	UserIDs   []string `json:"userIds,omitempty"`
+	Users     int      `json:"users"`     // Distinct signed-in users
+	Anonymous int      `json:"anonymous"` // Public visitor connections
+	Total     int      `json:"total"`     // Users plus anonymous viewers
+}
+
+// boardViewers are the viewers connected to one instance
+type boardViewers struct {
+	UserIDs   []string `json:"userIds"`
+	Anonymous int      `json:"anonymous"`
+}
+
+// remoteViewers are another instance's viewers as last announced through the broker
+type remoteViewers struct {
+	viewers    boardViewers
+	receivedAt time.Time
+}
+
+// localViewers returns the viewers connected to this instance
+func (wsm *WebSocketManager) localViewers(boardID string) boardViewers {
+	wsm.mutex.RLock()
+	defer wsm.mutex.RUnlock()
+
+	users := make(map[string]bool)
+	viewers := boardViewers{UserIDs: []string{}}
+	for client := range wsm.connections[boardID] {
+		if client.member() {
+			users[client.userID] = true
+		} else {
+			viewers.Anonymous++
+		}
+	}
+	for userID := range users {
+		viewers.UserIDs = append(viewers.UserIDs, userID)
+	}
+	return viewers
+}
+
+// setRemoteViewers records another instance's viewers of a board
+func (wsm *WebSocketManager) setRemoteViewers(boardID, instanceID string, viewers boardViewers) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+
+	if len(viewers.UserIDs) == 0 && viewers.Anonymous == 0 {
+		delete(wsm.remoteViewers[boardID], instanceID)
+		if len(wsm.remoteViewers[boardID]) == 0 {
+			delete(wsm.remoteViewers, boardID)
+		}
+		return
+	}
+	if wsm.remoteViewers[boardID] == nil {
+		wsm.remoteViewers[boardID] = make(map[string]*remoteViewers)
+	}
+	wsm.remoteViewers[boardID][instanceID] = &remoteViewers{viewers: viewers, receivedAt: time.Now()}
+}
+
+// pruneRemoteViewers forgets instances that stopped announcing their viewers
+func (wsm *WebSocketManager) pruneRemoteViewers() {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+
+	cutoff := time.Now().Add(-remoteViewersTTL)
+	for boardID, instances := range wsm.remoteViewers {
+		for instanceID, remote := range instances {
+			if remote.receivedAt.Before(cutoff) {
+				delete(instances, instanceID)
+			}
+		}
+		if len(instances) == 0 {
+			delete(wsm.remoteViewers, boardID)
+		}
+	}
+}
+
+// presence returns the board's current viewers across all instances
+func (wsm *WebSocketManager) presence(boardID string) BoardPresence {
+	local := wsm.localViewers(boardID)
+
+	users := make(map[string]bool)
+	for _, userID := range local.UserIDs {
+		users[userID] = true
+	}
+	anonymous := local.Anonymous
+
+	wsm.mutex.RLock()
+	cutoff := time.Now().Add(-remoteViewersTTL)
+	for _, remote := range wsm.remoteViewers[boardID] {
+		if remote.receivedAt.Before(cutoff) {
+			continue
+		}
+		for _, userID := range remote.viewers.UserIDs {
+			users[userID] = true
+		}
+		anonymous += remote.viewers.Anonymous
+	}
+	wsm.mutex.RUnlock()
+
+	userIDs := make([]string, 0, len(users))
+	for userID := range users {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	return BoardPresence{
+		UserIDs:   userIDs,
+		Users:     len(userIDs),
+		Anonymous: anonymous,
+		Total:     len(userIDs) + anonymous,
+	}
+}
+
+// presenceMessage builds a presence event as seen by a member or a public visitor
+func presenceMessage(eventType, boardID, userID string, presence BoardPresence, member bool) *WebSocketMessage {
+	data := map[string]interface{}{
+		"timestamp": getCurrentTimestamp(),
+	}
+	if member {
+		data["presence"] = presence
+		if userID != "" {
+			data["userId"] = userID
+		}
+	} else {
+		presence.UserIDs = nil
+		data["presence"] = presence
+	}
+	return &WebSocketMessage{Type: eventType, BoardID: boardID, Data: data}
+}
+
+// broadcastPresence announces a join or leave to the board's local connections
+func (wsm *WebSocketManager) broadcastPresence(boardID, eventType, userID string) {
+	presence := wsm.presence(boardID)
+	wsm.broadcast(boardID,
+		presenceMessage(eventType, boardID, userID, presence, true),
+		presenceMessage(eventType, boardID, userID, presence, false))
+}
+
+// announcePresence announces a join or leave here and, with this instance's viewers, on the others
+func (wsm *WebSocketManager) announcePresence(boardID, eventType, userID string) {
+	wsm.broadcastPresence(boardID, eventType, userID)
+
+	viewers := wsm.localViewers(boardID)
+	wsm.relay(&brokerEnvelope{BoardID: boardID, Viewers: &viewers, PresenceEvent: eventType, UserID: userID})
+}
+
+// join registers a client, greets it with the events it missed since the resume point (if any) and
+// announces it to the board, the client included. A user's additional tabs are not announced again
+// and only receive the current presence.
+func (wsm *WebSocketManager) join(boardID string, client *boardClient, resume *ResumePoint) {
+	// Live events wait for the greeting so they follow the replayed ones
+	client.writeMu.Lock()
+	first := wsm.addConnection(boardID, client)
+	if err := wsm.greet(boardID, client, resume); err != nil {
+		log.Printf("WebSocket send error for board: %s: %v", boardID, err)
+		client.close()
+	}
+	client.writeMu.Unlock()
+
+	if !first {
+		presence := wsm.presence(boardID)
+		if err := client.send(presenceMessage(EventPresence, boardID, "", presence, client.member())); err != nil {
+			log.Printf("WebSocket send error for board: %s: %v", boardID, err)
+		}
+		return
+	}
+	wsm.announcePresence(boardID, EventPresenceJoined, client.userID)
+}
+
+// leave unregisters a client and announces the departure once the user's last connection is gone
+func (wsm *WebSocketManager) leave(boardID string, client *boardClient) {
+	if !wsm.removeConnection(boardID, client) {
+		return
+	}
+	wsm.announcePresence(boardID, EventPresenceLeft, client.userID)
+}
+
+// GetBoardPresence returns who is viewing a board
+func GetBoardPresence(boardID string) BoardPresence {
+	if wsManager == nil {
+		return BoardPresence{UserIDs: []string{}}
+	}
+	return wsManager.presence(boardID)
+}
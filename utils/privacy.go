@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"net"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyModeHeader tells the frontend which privacy mode applies to a public response
+const PrivacyModeHeader = "X-Privacy-Mode"
+
+// Privacy modes reported in PrivacyModeHeader
+const (
+	PrivacyModeStandard = "standard"
+	PrivacyModeStrict   = "strict"
+)
+
+// AnonymizeIP truncates an address to a coarse network prefix (/24 for IPv4, /48 for IPv6)
+func AnonymizeIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "unknown"
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// VisitorKey returns the client identifier used for public rate limiting, notifications and logs.
+// Boards in strict privacy mode only ever see a coarse network prefix, never the visitor's address.
+func VisitorKey(clientIP string, strictPrivacy bool) string {
+	if strictPrivacy {
+		return AnonymizeIP(clientIP)
+	}
+	return clientIP
+}
+
+// SetPrivacyHeaders annotates a public response with the board's privacy mode
+func SetPrivacyHeaders(c *gin.Context, strictPrivacy bool) {
+	if strictPrivacy {
+		c.Header(PrivacyModeHeader, PrivacyModeStrict)
+		return
+	}
+	c.Header(PrivacyModeHeader, PrivacyModeStandard)
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	// publicBoardRefreshDelay collects a burst of writes into one rebuild of the read model
+	publicBoardRefreshDelay = 500 * time.Millisecond
+	// publicBoardMaxAge rebuilds read models on read when no write refreshed them for this long,
+	// in case a change was never announced
+	publicBoardMaxAge = 5 * time.Minute
+)
+
+// publicIdeaProjection loads the idea fields public responses can show
+var publicIdeaProjection = bson.M{
+	"board_id":        1,
+	"one_liner":       1,
+	"description":     1,
+	"value_statement": 1,
+	"rice_score":      1,
+	"column":          1,
+	"position":        1,
+	"in_progress":     1,
+	"thumbs_up":       1,
+	"emoji_reactions": 1,
+	"votes":           1,
+	"poll":            1,
+	"target_date":     1,
+	"created_at":      1,
+	"updated_at":      1,
+}
+
+var (
+	publicBoardRefreshMu sync.Mutex
+	publicBoardRefreshes = map[string]bool{} // Boards with a rebuild pending
+)
+
+// LoadPublicBoard returns the read model of the public board with the given public link, building
+// it when it is missing or too old. Returns mongo.ErrNoDocuments if no public board has the link.
+func LoadPublicBoard(ctx context.Context, publicLink string) (*models.PublicBoardView, error) {
+	var view models.PublicBoardView
+	err := models.GetCollection(ctx, models.PublicBoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink}).Decode(&view)
+	if err == nil && time.Since(view.RefreshedAt) < publicBoardMaxAge {
+		// The read model may predate the board being made private or given a new link
+		board, err := models.FindBoardByID(ctx, view.BoardID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		if err == mongo.ErrNoDocuments || !board.IsPublic || board.PublicLink != publicLink {
+			if err := DeletePublicBoard(ctx, view.BoardID); err != nil {
+				log.Printf("[PublicBoard] Failed to delete read model - BoardID: %s, Error: %v", view.BoardID, err)
+			}
+			return nil, mongo.ErrNoDocuments
+		}
+		return &view, nil
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	var board models.Board
+	if err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"public_link": publicLink, "is_public": true}).Decode(&board); err != nil {
+		return nil, err
+	}
+	return buildPublicBoard(ctx, &board)
+}
+
+// SchedulePublicBoardRefresh rebuilds a board's read model shortly after a write, once for a burst
+// of writes
+func SchedulePublicBoardRefresh(ctx context.Context, boardID string) {
+	publicBoardRefreshMu.Lock()
+	defer publicBoardRefreshMu.Unlock()
+	if publicBoardRefreshes[boardID] {
+		return
+	}
+	publicBoardRefreshes[boardID] = true
+
+	time.AfterFunc(publicBoardRefreshDelay, func() {
+		publicBoardRefreshMu.Lock()
+		delete(publicBoardRefreshes, boardID)
+		publicBoardRefreshMu.Unlock()
+		RefreshPublicBoard(ctx, boardID)
+	})
+}
+
+// RefreshPublicBoard rebuilds a board's read model now, deleting it once the board is gone or no
+// longer public
+func RefreshPublicBoard(ctx context.Context, boardID string) {
+	if models.DB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 10*time.Second)
+	defer cancel()
+
+	var board models.Board
+	err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Decode(&board)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("[PublicBoard] Failed to load board - BoardID: %s, Error: %v", boardID, err)
+		return
+	}
+	if err == mongo.ErrNoDocuments || !board.IsPublic {
+		if err := DeletePublicBoard(ctx, boardID); err != nil {
+			log.Printf("[PublicBoard] Failed to delete read model - BoardID: %s, Error: %v", boardID, err)
+		}
+		return
+	}
+
+	if _, err := buildPublicBoard(ctx, &board); err != nil {
+		log.Printf("[PublicBoard] Failed to refresh read model - BoardID: %s, Error: %v", boardID, err)
+	}
+}
+
+// DeletePublicBoard removes a board's read model. Handlers call it before responding when a board
+// is made private or given a new link, so the old view is never served again.
+func DeletePublicBoard(ctx context.Context, boardID string) error {
+	_, err := models.GetCollection(ctx, models.PublicBoardsCollection).DeleteOne(ctx, bson.M{"_id": boardID})
+	return err
+}
+
+// buildPublicBoard loads the ideas of a public board's visible columns and saves the read model,
+// unless one built later was saved in the meantime. The built view is returned even when it could
+// not be saved, such as for boards too large for one document.
+func buildPublicBoard(ctx context.Context, board *models.Board) (*models.PublicBoardView, error) {
+	builtAt := time.Now().UTC()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "column", Value: 1}, {Key: "position", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(publicIdeaProjection)
+	cursor, err := models.GetCollection(ctx, models.IdeasCollection).Find(ctx, bson.M{
+		"board_id": board.ID,
+		"column":   bson.M{"$in": board.VisibleColumns},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	ideas := []models.Idea{}
+	if err := cursor.All(ctx, &ideas); err != nil {
+		return nil, err
+	}
+
+	view := &models.PublicBoardView{
+		BoardID:     board.ID,
+		PublicLink:  board.PublicLink,
+		Board:       models.NewPublicBoard(board),
+		Ideas:       ideas,
+		RefreshedAt: builtAt,
+	}
+	for _, idea := range ideas {
+		if idea.UpdatedAt.After(view.LastModified) {
+			view.LastModified = idea.UpdatedAt
+		}
+	}
+
+	_, err = models.GetCollection(ctx, models.PublicBoardsCollection).ReplaceOne(ctx,
+		bson.M{"_id": board.ID, "refreshed_at": bson.M{"$lt": builtAt}},
+		view,
+		options.Replace().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		log.Printf("[PublicBoard] Failed to save read model - BoardID: %s, Ideas: %d, Error: %v", board.ID, len(ideas), err)
+	}
+	return view, nil
+}
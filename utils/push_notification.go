@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// pushTTL is how long push services hold a notification for a browser that is offline
+const pushTTL = 24 * time.Hour
+
+// pushPayload is the JSON a service worker receives in its push event
+type pushPayload struct {
+	NotificationID string                   `json:"notificationId"`
+	Event          models.NotificationEvent `json:"event"`
+	Title          string                   `json:"title"`
+	Body           string                   `json:"body"`
+	URL            string                   `json:"url"`
+	Tag            string                   `json:"tag"` // Lets the browser replace an older notification of the board
+}
+
+// pushUrgency asks push services to wake the device for submissions awaiting moderation, and lets
+// them defer other events
+func pushUrgency(event models.NotificationEvent) string {
+	if event == models.NotifyNewSubmission {
+		return "high"
+	}
+	return "normal"
+}
+
+// sendPushNotification pushes a notification to one of the recipient's browsers. Subscriptions
+// the push service reports gone are removed; those deleted since the job was queued are skipped.
+func sendPushNotification(ctx context.Context, notification *BoardNotification, subscriptionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := models.GetCollection(ctx, models.PushSubscriptionsCollection)
+	var subscription models.PushSubscription
+	err := collection.FindOne(ctx, bson.M{"_id": subscriptionID}).Decode(&subscription)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find push subscription: %v", err)
+	}
+
+	payload, err := json.Marshal(pushPayload{
+		NotificationID: notification.ID,
+		Event:          notification.Event,
+		Title:          notification.BoardName,
+		Body:           notification.Summary,
+		URL:            fmt.Sprintf("%s/board/%s", os.Getenv("APP_URL"), notification.BoardID),
+		Tag:            fmt.Sprintf("%s:%s", notification.BoardID, notification.Event),
+	})
+	if err != nil {
+		return permanentError{fmt.Errorf("failed to marshal push payload: %v", err)}
+	}
+
+	statusCode, err := SendPushMessage(PushMessage{
+		Endpoint: subscription.Endpoint,
+		P256dh:   subscription.Keys.P256dh,
+		Auth:     subscription.Keys.Auth,
+		Payload:  payload,
+		TTL:      pushTTL,
+		Urgency:  pushUrgency(notification.Event),
+	})
+	switch {
+	case statusCode == http.StatusNotFound || statusCode == http.StatusGone:
+		if _, err := collection.DeleteOne(ctx, bson.M{"_id": subscription.ID}); err != nil {
+			log.Printf("Failed to remove expired push subscription %s: %v", subscription.ID, err)
+		}
+		log.Printf("Push subscription expired and removed - SubscriptionID: %s, UserID: %s", subscription.ID, subscription.UserID)
+		return nil
+	case errors.Is(err, ErrPushNotConfigured), errors.Is(err, ErrInvalidPushMessage),
+		errors.Is(err, ErrOutboundURL), errors.Is(err, ErrOutboundAddress):
+		return permanentError{err}
+	case err != nil && !retryableWebhookStatus(statusCode):
+		return permanentError{err}
+	case err != nil:
+		return err
+	}
+
+	now := time.Now().UTC()
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": subscription.ID}, bson.M{"$set": bson.M{"last_used_at": now}}); err != nil {
+		log.Printf("Failed to update push subscription %s: %v", subscription.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// clientQueueSize is how many sends may wait for a connection's writer. A client that lets its
+// queue fill up is disconnected rather than slowing the broadcasts; it resumes from the replay
+// buffer when it reconnects.
+const clientQueueSize = 64
+
+// errSlowConsumer is returned when a connection's send queue is full
+var errSlowConsumer = errors.New("send queue full, dropping slow connection")
+
+// enqueue hands messages to the connection's writer without blocking; the caller holds writeMu
+func (bc *boardClient) enqueue(messages []*WebSocketMessage) error {
+	select {
+	case bc.queue <- messages:
+		return nil
+	default:
+		return errSlowConsumer
+	}
+}
+
+// flush writes a queued send and counts the outcome. Only the connection's writer calls it.
+func (bc *boardClient) flush(messages []*WebSocketMessage) error {
+	if err := bc.writeQueued(messages); err != nil {
+		wsManager.counters.writeErrors.Add(1)
+		return err
+	}
+	wsManager.counters.messagesWritten.Add(uint64(len(messages)))
+	return nil
+}
+
+// writeQueued writes a single message as is, several with writeBatch or wrapped in one batch message
+func (bc *boardClient) writeQueued(messages []*WebSocketMessage) error {
+	if len(messages) == 1 {
+		return bc.write(messages[0])
+	}
+	if bc.writeBatch != nil {
+		return bc.writeBatch(messages)
+	}
+	return bc.write(&WebSocketMessage{
+		Type:    EventBatch,
+		BoardID: messages[0].BoardID,
+		Data:    map[string]interface{}{"messages": messages},
+	})
+}
+
+// writeSocket is a WebSocket's only writer: it writes the client's queued messages and the
+// heartbeat pings until stop is closed. A failed write closes the connection.
+func writeSocket(conn *websocket.Conn, client *boardClient, stop <-chan struct{}) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case messages := <-client.queue:
+			if err := client.flush(messages); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				conn.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}
+}
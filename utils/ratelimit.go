@@ -0,0 +1,328 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimiter is a store of rate limit state per key. Implementations are safe for concurrent use
+// and forget idle keys on their own once their window has passed.
+//
+// Two algorithms are offered: a sliding window, where keys are checked before an action and counted
+// once it succeeds so failed attempts don't use up a visitor's budget, and a token bucket, which
+// takes a token up front and suits per-request budgets.
+type RateLimiter interface {
+	// Limited reports whether key already has limit actions in the window, and how long until the oldest leaves it
+	Limited(key string, limit int, window time.Duration) (bool, time.Duration, error)
+	// Hit counts an action against key
+	Hit(key string, window time.Duration) error
+	// Take takes a token from key's bucket, which holds capacity tokens and refills completely over period.
+	// It returns whether a token was taken, the tokens left and, when none was, how long until one is.
+	Take(key string, capacity int, period time.Duration) (bool, int, time.Duration, error)
+}
+
+// rateLimiter is the limiter selected by RATE_LIMIT_BACKEND, and memoryLimiter the per-instance
+// fallback used without Redis or while Redis is unreachable
+var (
+	rateLimiter   RateLimiter
+	rateLimiterMu sync.RWMutex
+	memoryLimiter = NewMemoryRateLimiter()
+)
+
+// InitRateLimiter selects the rate limit backend from RATE_LIMIT_BACKEND: memory (the default)
+// counts per instance, redis shares the counts of every instance through REDIS_URL
+func InitRateLimiter() error {
+	switch backend := os.Getenv("RATE_LIMIT_BACKEND"); backend {
+	case "", "memory":
+		SetRateLimiter(memoryLimiter)
+	case "redis":
+		redisURL := os.Getenv("REDIS_URL")
+		if redisURL == "" {
+			redisURL = "redis://localhost:6379"
+		}
+		limiter, err := NewRedisRateLimiter(redisURL)
+		if err != nil {
+			return err
+		}
+		SetRateLimiter(limiter)
+	default:
+		return fmt.Errorf("unsupported RATE_LIMIT_BACKEND %q (supported: memory, redis)", backend)
+	}
+	log.Printf("[RateLimit] Using %T", currentRateLimiter())
+	return nil
+}
+
+// SetRateLimiter replaces the rate limit store, e.g. with another backend
+func SetRateLimiter(limiter RateLimiter) {
+	rateLimiterMu.Lock()
+	defer rateLimiterMu.Unlock()
+	rateLimiter = limiter
+}
+
+// currentRateLimiter returns the configured limiter, or memory before InitRateLimiter
+func currentRateLimiter() RateLimiter {
+	rateLimiterMu.RLock()
+	defer rateLimiterMu.RUnlock()
+	if rateLimiter == nil {
+		return memoryLimiter
+	}
+	return rateLimiter
+}
+
+// waitSeconds rounds a wait up to whole seconds, at least one
+func waitSeconds(wait time.Duration) int {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// RateLimited reports whether key has used up limit actions in the window, and the whole seconds
+// until it may act again. Redis errors fall back to this instance's counts.
+func RateLimited(key string, limit int, window time.Duration) (bool, int) {
+	limited, wait, err := currentRateLimiter().Limited(key, limit, window)
+	if err != nil {
+		log.Printf("[RateLimit] Falling back to memory - Key: %s, Error: %v", key, err)
+		limited, wait, _ = memoryLimiter.Limited(key, limit, window)
+	}
+	if !limited {
+		return false, 0
+	}
+	return true, waitSeconds(wait)
+}
+
+// RecordRateLimitHit counts a successful action against key for the window
+func RecordRateLimitHit(key string, window time.Duration) {
+	if err := currentRateLimiter().Hit(key, window); err != nil {
+		log.Printf("[RateLimit] Falling back to memory - Key: %s, Error: %v", key, err)
+		memoryLimiter.Hit(key, window)
+	}
+}
+
+// TakeRateLimitToken takes a token from key's bucket of capacity tokens per period, returning whether
+// it was allowed, the tokens left and, when refused, the whole seconds until a token is available
+func TakeRateLimitToken(key string, capacity int, period time.Duration) (bool, int, int) {
+	allowed, remaining, wait, err := currentRateLimiter().Take(key, capacity, period)
+	if err != nil {
+		log.Printf("[RateLimit] Falling back to memory - Key: %s, Error: %v", key, err)
+		allowed, remaining, wait, _ = memoryLimiter.Take(key, capacity, period)
+	}
+	if allowed {
+		return true, remaining, 0
+	}
+	return false, remaining, waitSeconds(wait)
+}
+
+// memoryRateSweepInterval is how often the memory limiter drops expired keys
+const memoryRateSweepInterval = time.Minute
+
+// memoryRateEntry is the state of one key: action times for sliding windows, or a token bucket
+type memoryRateEntry struct {
+	hits      []time.Time
+	tokens    float64
+	refilled  time.Time // When tokens was last brought up to date
+	expiresAt time.Time // Once passed, the key holds nothing worth keeping
+}
+
+// MemoryRateLimiter keeps rate limit state in this instance's memory
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryRateEntry
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimiter creates an empty in-memory limiter
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{entries: make(map[string]*memoryRateEntry)}
+}
+
+// entry returns key's live entry, or nil, first dropping expired keys at most once per sweep interval.
+// Callers hold mu.
+func (ml *MemoryRateLimiter) entry(key string, now time.Time) *memoryRateEntry {
+	if now.Sub(ml.lastSweep) > memoryRateSweepInterval {
+		for staleKey, entry := range ml.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(ml.entries, staleKey)
+			}
+		}
+		ml.lastSweep = now
+	}
+
+	entry, exists := ml.entries[key]
+	if !exists || !now.Before(entry.expiresAt) {
+		return nil
+	}
+	return entry
+}
+
+// recentHits returns the entry's actions still inside the window
+func recentHits(entry *memoryRateEntry, window time.Duration, now time.Time) []time.Time {
+	if entry == nil {
+		return nil
+	}
+	hits := entry.hits
+	cutoff := now.Add(-window)
+	for len(hits) > 0 && !hits[0].After(cutoff) {
+		hits = hits[1:]
+	}
+	return hits
+}
+
+// Limited implements RateLimiter
+func (ml *MemoryRateLimiter) Limited(key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	now := time.Now()
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	hits := recentHits(ml.entry(key, now), window, now)
+	if len(hits) < limit {
+		return false, 0, nil
+	}
+	return true, hits[len(hits)-limit].Add(window).Sub(now), nil
+}
+
+// Hit implements RateLimiter
+func (ml *MemoryRateLimiter) Hit(key string, window time.Duration) error {
+	now := time.Now()
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	entry := ml.entry(key, now)
+	hits := append(recentHits(entry, window, now), now)
+	if entry == nil {
+		entry = &memoryRateEntry{}
+		ml.entries[key] = entry
+	}
+	entry.hits = hits
+	if expiresAt := now.Add(window); expiresAt.After(entry.expiresAt) {
+		entry.expiresAt = expiresAt
+	}
+	return nil
+}
+
+// Take implements RateLimiter
+func (ml *MemoryRateLimiter) Take(key string, capacity int, period time.Duration) (bool, int, time.Duration, error) {
+	now := time.Now()
+	rate := float64(capacity) / period.Seconds() // Tokens per second
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	entry := ml.entry(key, now)
+	if entry == nil {
+		entry = &memoryRateEntry{tokens: float64(capacity), refilled: now}
+		ml.entries[key] = entry
+	}
+	entry.tokens = math.Min(float64(capacity), entry.tokens+now.Sub(entry.refilled).Seconds()*rate)
+	entry.refilled = now
+
+	if entry.tokens < 1 {
+		wait := time.Duration((1 - entry.tokens) / rate * float64(time.Second))
+		return false, 0, wait, nil
+	}
+	entry.tokens--
+	// A bucket left alone for a period is full again, the same as a new one
+	entry.expiresAt = now.Add(period)
+	return true, int(entry.tokens), 0, nil
+}
+
+// redisRateKeyPrefix namespaces rate limit keys in Redis
+const redisRateKeyPrefix = "disko:ratelimit:"
+
+// Lua scripts keep each operation atomic, and every key expires with its window or refill period so
+// Redis forgets idle visitors on its own. Sliding windows are sorted sets of action times in
+// milliseconds; token buckets are hashes of the tokens left and when they were last refilled.
+const (
+	// Returns 0 when under the limit, else the time of the action that must leave the window first
+	redisRateLimitedScript = `redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', tonumber(ARGV[1]) - tonumber(ARGV[2]))
+local count = redis.call('ZCARD', KEYS[1])
+local limit = tonumber(ARGV[3])
+if count < limit then return 0 end
+local oldest = redis.call('ZRANGE', KEYS[1], count - limit, count - limit, 'WITHSCORES')
+return tonumber(oldest[2])`
+	redisRateHitScript = `redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1`
+	// Returns {allowed, tokens left, milliseconds until a token}
+	redisRateTakeScript = `local now = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+local rate = capacity / period
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'refilled')
+local tokens = tonumber(state[1]) or capacity
+local refilled = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - refilled) * rate)
+if tokens < 1 then
+  redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'refilled', now)
+  return {0, 0, math.ceil((1 - tokens) / rate)}
+end
+tokens = tokens - 1
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'refilled', now)
+redis.call('PEXPIRE', KEYS[1], period)
+return {1, math.floor(tokens), 0}`
+)
+
+// Scripts are sent by hash and loaded into Redis on first use
+var (
+	redisRateLimited = redis.NewScript(redisRateLimitedScript)
+	redisRateHit     = redis.NewScript(redisRateHitScript)
+	redisRateTake    = redis.NewScript(redisRateTakeScript)
+)
+
+// RedisRateLimiter keeps rate limit state in Redis so every instance shares the same counts
+type RedisRateLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisRateLimiter creates a limiter from a redis:// or rediss:// URL
+func NewRedisRateLimiter(rawURL string) (*RedisRateLimiter, error) {
+	client, err := newRedisClient(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisRateLimiter{client: client}, nil
+}
+
+// Limited implements RateLimiter
+func (rl *RedisRateLimiter) Limited(key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	now := time.Now()
+	oldest, err := redisRateLimited.Run(context.Background(), rl.client, []string{redisRateKeyPrefix + key},
+		now.UnixMilli(), window.Milliseconds(), limit).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+	if oldest == 0 {
+		return false, 0, nil
+	}
+	return true, time.UnixMilli(oldest).Add(window).Sub(now), nil
+}
+
+// Hit implements RateLimiter
+func (rl *RedisRateLimiter) Hit(key string, window time.Duration) error {
+	// The member only needs to be unique; the score carries the time
+	return redisRateHit.Run(context.Background(), rl.client, []string{redisRateKeyPrefix + key},
+		time.Now().UnixMilli(), GenerateFullUUID(), window.Milliseconds()).Err()
+}
+
+// Take implements RateLimiter
+func (rl *RedisRateLimiter) Take(key string, capacity int, period time.Duration) (bool, int, time.Duration, error) {
+	reply, err := redisRateTake.Run(context.Background(), rl.client, []string{redisRateKeyPrefix + "bucket:" + key},
+		time.Now().UnixMilli(), capacity, period.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if len(reply) != 3 {
+		return false, 0, 0, fmt.Errorf("unexpected Redis reply: %v", reply)
+	}
+	return reply[0] == 1, int(reply[1]), time.Duration(reply[2]) * time.Millisecond, nil
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisRateLimiter returns a limiter backed by an in-process Redis server
+func newTestRedisRateLimiter(t *testing.T) (*RedisRateLimiter, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	limiter, err := NewRedisRateLimiter("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { limiter.client.Close() })
+	return limiter, server
+}
+
+// testRateLimiter runs the behaviour every RateLimiter shares
+func testRateLimiter(t *testing.T, limiter RateLimiter) {
+	t.Run("Limited After Hits", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			limited, _, err := limiter.Limited("visitor_hits", 3, time.Hour)
+			require.NoError(t, err)
+			assert.False(t, limited, "hit %d", i)
+			require.NoError(t, limiter.Hit("visitor_hits", time.Hour))
+		}
+
+		limited, wait, err := limiter.Limited("visitor_hits", 3, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, limited)
+		assert.InDelta(t, time.Hour.Seconds(), wait.Seconds(), 5)
+
+		limited, _, err = limiter.Limited("visitor_hits", 4, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, limited, "a higher limit leaves room")
+
+		limited, _, err = limiter.Limited("visitor_other", 3, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, limited, "keys are counted separately")
+	})
+
+	t.Run("Hits Leave The Window", func(t *testing.T) {
+		window := 200 * time.Millisecond
+		require.NoError(t, limiter.Hit("visitor_window", window))
+
+		limited, _, err := limiter.Limited("visitor_window", 1, window)
+		require.NoError(t, err)
+		assert.True(t, limited)
+
+		time.Sleep(window + 50*time.Millisecond)
+		limited, _, err = limiter.Limited("visitor_window", 1, window)
+		require.NoError(t, err)
+		assert.False(t, limited)
+	})
+
+	t.Run("Take Empties The Bucket", func(t *testing.T) {
+		for remaining := 1; remaining >= 0; remaining-- {
+			allowed, left, _, err := limiter.Take("visitor_bucket", 2, time.Hour)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+			assert.Equal(t, remaining, left)
+		}
+
+		allowed, left, wait, err := limiter.Take("visitor_bucket", 2, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Zero(t, left)
+		// Two tokens an hour refill one every half hour
+		assert.InDelta(t, (30 * time.Minute).Seconds(), wait.Seconds(), 5)
+
+		allowed, _, _, err = limiter.Take("visitor_bucket_other", 2, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, allowed, "buckets are kept per key")
+	})
+
+	t.Run("Take Refills Over The Period", func(t *testing.T) {
+		period := 200 * time.Millisecond
+		allowed, _, _, err := limiter.Take("visitor_refill", 1, period)
+		require.NoError(t, err)
+		require.True(t, allowed)
+
+		allowed, _, _, err = limiter.Take("visitor_refill", 1, period)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+
+		time.Sleep(period + 50*time.Millisecond)
+		allowed, _, _, err = limiter.Take("visitor_refill", 1, period)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
+
+func TestMemoryRateLimiter(t *testing.T) {
+	testRateLimiter(t, NewMemoryRateLimiter())
+}
+
+func TestRedisRateLimiter(t *testing.T) {
+	limiter, server := newTestRedisRateLimiter(t)
+	testRateLimiter(t, limiter)
+
+	t.Run("Keys Expire With Their Window", func(t *testing.T) {
+		require.NoError(t, limiter.Hit("visitor_expiry", time.Minute))
+		assert.Equal(t, time.Minute, server.TTL(redisRateKeyPrefix+"visitor_expiry"))
+	})
+
+	t.Run("Falls Back To Memory While Unreachable", func(t *testing.T) {
+		SetRateLimiter(limiter)
+		t.Cleanup(func() { SetRateLimiter(nil) })
+		server.Close()
+
+		RecordRateLimitHit("visitor_fallback", time.Hour)
+		limited, wait := RateLimited("visitor_fallback", 1, time.Hour)
+		assert.True(t, limited)
+		assert.Positive(t, wait)
+	})
+}
+
+func TestRedisBroker(t *testing.T) {
+	server := miniredis.RunT(t)
+	broker, err := NewRedisBroker("redis://"+server.Addr(), "disko:test-events")
+	require.NoError(t, err)
+
+	delivered := make(chan string, 8)
+	stopped := make(chan struct{})
+	go func() {
+		broker.Subscribe(func(payload []byte) { delivered <- string(payload) })
+		close(stopped)
+	}()
+
+	// Publish until the subscription is in place, since earlier messages are not delivered
+	require.Eventually(t, func() bool {
+		require.NoError(t, broker.Publish([]byte("hello")))
+		select {
+		case payload := <-delivered:
+			return payload == "hello"
+		case <-time.After(20 * time.Millisecond):
+			return false
+		}
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, broker.Close())
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscribe did not return after Close")
+	}
+}
+
+func TestNewRedisClientRejectsOtherSchemes(t *testing.T) {
+	for _, rawURL := range []string{"http://localhost:6379", "unix:///tmp/redis.sock", "localhost:6379"} {
+		_, err := newRedisClient(rawURL)
+		assert.Error(t, err, rawURL)
+	}
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTimeout bounds connecting to Redis and each command
+const redisTimeout = 5 * time.Second
+
+// newRedisClient creates a pooled client from a redis:// or rediss:// URL, e.g. redis://:password@host:6379
+func newRedisClient(rawURL string) (*redis.Client, error) {
+	if !strings.HasPrefix(rawURL, "redis://") && !strings.HasPrefix(rawURL, "rediss://") {
+		return nil, fmt.Errorf("invalid Redis URL: scheme must be redis or rediss")
+	}
+	options, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	options.DialTimeout = redisTimeout
+	options.ReadTimeout = redisTimeout
+	options.WriteTimeout = redisTimeout
+	return redis.NewClient(options), nil
+}
+
+// RedisBroker relays board events between instances over a Redis pub/sub channel
+type RedisBroker struct {
+	client  *redis.Client
+	channel string
+
+	ctx    context.Context // Cancelled by Close
+	cancel context.CancelFunc
+}
+
+// NewRedisBroker creates a broker from a redis:// or rediss:// URL, e.g. redis://:password@host:6379
+func NewRedisBroker(rawURL, channel string) (*RedisBroker, error) {
+	client, err := newRedisClient(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RedisBroker{client: client, channel: channel, ctx: ctx, cancel: cancel}, nil
+}
+
+// Publish sends a payload to the other instances
+func (rb *RedisBroker) Publish(payload []byte) error {
+	return rb.client.Publish(rb.ctx, rb.channel, payload).Err()
+}
+
+// Subscribe delivers payloads published on the channel until Close, reconnecting with backoff
+func (rb *RedisBroker) Subscribe(deliver func(payload []byte)) {
+	pubsub := rb.client.Subscribe(rb.ctx, rb.channel)
+	go func() {
+		// Closing the subscription interrupts a blocked receive
+		<-rb.ctx.Done()
+		pubsub.Close()
+	}()
+
+	// The subscription redials and resubscribes after a failed receive; back off while it cannot
+	backoff := time.Second
+	for {
+		received, err := pubsub.Receive(rb.ctx)
+		if rb.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("[Broker] Redis subscription lost - Error: %v, Retrying in %v", err, backoff)
+			select {
+			case <-rb.ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff < 30*time.Second {
+				backoff *= 2
+			}
+			continue
+		}
+
+		switch message := received.(type) {
+		case *redis.Subscription:
+			if message.Kind == "subscribe" {
+				log.Printf("[Broker] Subscribed to Redis channel %s", rb.channel)
+				backoff = time.Second
+			}
+		case *redis.Message:
+			deliver([]byte(message.Payload))
+		}
+	}
+}
+
+// Close stops the subscription and closes the connections
+func (rb *RedisBroker) Close() error {
+	rb.cancel()
+	return rb.client.Close()
+}
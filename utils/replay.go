@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Replay buffer: the recent events of each board kept for reconnecting clients
+const (
+	replayBufferSize = 256
+	replayWindow     = 5 * time.Minute
+)
+
+// Replay messages sent to a connecting client
+const (
+	EventConnected      = "connected"       // First message: the epoch and latest sequence number to resume from
+	EventResyncRequired = "resync_required" // Missed events are no longer buffered; refetch the board
+)
+
+// ResumePoint is the last event a reconnecting client received. Sequence numbers are per board and
+// instance, so the epoch (the instance ID) must match for the buffered events to apply.
+type ResumePoint struct {
+	Epoch string
+	Seq   uint64
+}
+
+// ParseResumePoint reads a resume point from its epoch and sequence number; it returns nil when
+// either is missing or invalid
+func ParseResumePoint(epoch, seq string) *ResumePoint {
+	if epoch == "" || seq == "" {
+		return nil
+	}
+	value, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &ResumePoint{Epoch: epoch, Seq: value}
+}
+
+// ParseEventID reads a resume point from an SSE event ID ("<epoch>:<seq>", e.g. a Last-Event-ID header)
+func ParseEventID(eventID string) *ResumePoint {
+	epoch, seq, found := strings.Cut(eventID, ":")
+	if !found {
+		return nil
+	}
+	return ParseResumePoint(epoch, seq)
+}
+
+// bufferedEvent is a sequenced event as sent to members and to public visitors
+type bufferedEvent struct {
+	member *WebSocketMessage
+	public *WebSocketMessage
+	at     time.Time
+}
+
+// boardEventLog numbers a board's events and keeps the most recent ones
+type boardEventLog struct {
+	seq    uint64
+	events []bufferedEvent
+}
+
+// sequence numbers an event for the board and buffers it. It returns copies of the messages
+// carrying the sequence number.
+func (wsm *WebSocketManager) sequence(boardID string, memberMessage, publicMessage *WebSocketMessage) (*WebSocketMessage, *WebSocketMessage) {
+	wsm.logMu.Lock()
+	defer wsm.logMu.Unlock()
+
+	eventLog := wsm.eventLogs[boardID]
+	if eventLog == nil {
+		eventLog = &boardEventLog{}
+		wsm.eventLogs[boardID] = eventLog
+	}
+	eventLog.seq++
+
+	stamp := func(message *WebSocketMessage) *WebSocketMessage {
+		if message == nil {
+			return nil
+		}
+		stamped := *message
+		stamped.Seq = eventLog.seq
+		return &stamped
+	}
+	event := bufferedEvent{member: stamp(memberMessage), public: stamp(publicMessage), at: time.Now()}
+
+	eventLog.events = append(eventLog.events, event)
+	if len(eventLog.events) > replayBufferSize {
+		eventLog.events = eventLog.events[len(eventLog.events)-replayBufferSize:]
+	}
+	return event.member, event.public
+}
+
+// latestSeq returns the sequence number of the board's last event
+func (wsm *WebSocketManager) latestSeq(boardID string) uint64 {
+	wsm.logMu.Lock()
+	defer wsm.logMu.Unlock()
+
+	if eventLog := wsm.eventLogs[boardID]; eventLog != nil {
+		return eventLog.seq
+	}
+	return 0
+}
+
+// missedEvents returns the board's events after the resume point. It returns false when they
+// cannot be replayed: another epoch, or events that are no longer buffered.
+func (wsm *WebSocketManager) missedEvents(boardID string, resume *ResumePoint) ([]bufferedEvent, bool) {
+	if resume.Epoch != wsm.instanceID {
+		return nil, false
+	}
+
+	wsm.logMu.Lock()
+	defer wsm.logMu.Unlock()
+
+	eventLog := wsm.eventLogs[boardID]
+	if eventLog == nil {
+		return nil, resume.Seq == 0
+	}
+	if resume.Seq > eventLog.seq {
+		return nil, false
+	}
+
+	oldest := eventLog.seq - uint64(len(eventLog.events)) + 1
+	if resume.Seq+1 < oldest {
+		return nil, false
+	}
+	return append([]bufferedEvent{}, eventLog.events[resume.Seq+1-oldest:]...), true
+}
+
+// pruneEventLogs drops buffered events past the replay window. Sequence counters are kept so a
+// board's numbering never restarts while clients may still resume from it.
+func (wsm *WebSocketManager) pruneEventLogs() {
+	wsm.logMu.Lock()
+	defer wsm.logMu.Unlock()
+
+	cutoff := time.Now().Add(-replayWindow)
+	for _, eventLog := range wsm.eventLogs {
+		expired := 0
+		for expired < len(eventLog.events) && eventLog.events[expired].at.Before(cutoff) {
+			expired++
+		}
+		eventLog.events = eventLog.events[expired:]
+	}
+}
+
+// greet queues the connected message for a joining client and, when it resumes, the events it
+// missed in batches. The caller holds the client's write lock, so live events are queued after the replay.
+func (wsm *WebSocketManager) greet(boardID string, client *boardClient, resume *ResumePoint) error {
+	connected := &WebSocketMessage{
+		Type:    EventConnected,
+		BoardID: boardID,
+		Data: map[string]interface{}{
+			"epoch": wsm.instanceID,
+			"seq":   wsm.latestSeq(boardID),
+		},
+	}
+	if err := client.enqueue([]*WebSocketMessage{connected}); err != nil {
+		return err
+	}
+	if resume == nil {
+		return nil
+	}
+
+	missed, ok := wsm.missedEvents(boardID, resume)
+	if !ok {
+		return client.enqueue([]*WebSocketMessage{{Type: EventResyncRequired, BoardID: boardID}})
+	}
+
+	var messages []*WebSocketMessage
+	for _, event := range missed {
+		message := event.public
+		if client.member() {
+			message = event.member
+		}
+		if message == nil || !client.wants(message) {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	for start := 0; start < len(messages); start += maxBatchMessages {
+		end := min(start+maxBatchMessages, len(messages))
+		if err := client.enqueue(messages[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config holds the credentials and location for an S3-compatible bucket
+type S3Config struct {
+	Endpoint        string // Defaults to the regional AWS endpoint
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PublicOnly      bool // Set for buckets users configure: requests only reach public https endpoints
+}
+
+// GCSEndpoint is the S3-compatible XML API endpoint for Google Cloud Storage (HMAC keys)
+const GCSEndpoint = "https://storage.googleapis.com"
+
+// PutS3Object uploads an object to an S3-compatible bucket using AWS Signature Version 4
+func PutS3Object(ctx context.Context, cfg S3Config, key string, body []byte, contentType string) error {
+	req, err := newS3Request(ctx, cfg, http.MethodPut, key, nil, body)
+	if err != nil {
+		return fmt.Errorf("failed to build upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	signS3Request(req, cfg, sha256Hex(body))
+
+	resp, err := s3HTTPClient(cfg).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("upload failed with %s", s3ErrorBody(resp))
+	}
+
+	return nil
+}
+
+// GetS3Object downloads an object, returning ErrObjectNotFound when the key does not exist. The
+// caller closes the returned body.
+func GetS3Object(ctx context.Context, cfg S3Config, key string) (io.ReadCloser, *StoredObject, error) {
+	req, err := newS3Request(ctx, cfg, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build download request: %w", err)
+	}
+	signS3Request(req, cfg, sha256Hex(nil))
+
+	resp, err := s3HTTPClient(cfg).Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, nil, ErrObjectNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, nil, fmt.Errorf("download failed with %s", s3ErrorBody(resp))
+	}
+
+	object := &StoredObject{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		object.ModifiedAt = modified
+	}
+	return resp.Body, object, nil
+}
+
+// DeleteS3Object removes an object; deleting a missing key succeeds
+func DeleteS3Object(ctx context.Context, cfg S3Config, key string) error {
+	req, err := newS3Request(ctx, cfg, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build delete request: %w", err)
+	}
+	signS3Request(req, cfg, sha256Hex(nil))
+
+	resp, err := s3HTTPClient(cfg).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete failed with %s", s3ErrorBody(resp))
+	}
+	return nil
+}
+
+// ListS3Objects returns the keys of the objects whose key starts with prefix
+func ListS3Objects(ctx context.Context, cfg S3Config, prefix string) ([]string, error) {
+	var keys []string
+	continuation := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if continuation != "" {
+			query.Set("continuation-token", continuation)
+		}
+		req, err := newS3Request(ctx, cfg, http.MethodGet, "", query, nil)
+		if err != nil {
+			return keys, fmt.Errorf("failed to build list request: %w", err)
+		}
+		signS3Request(req, cfg, sha256Hex(nil))
+
+		resp, err := s3HTTPClient(cfg).Do(req)
+		if err != nil {
+			return keys, fmt.Errorf("failed to list objects: %w", err)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err := fmt.Errorf("list failed with %s", s3ErrorBody(resp))
+			resp.Body.Close()
+			return keys, err
+		}
+
+		var page struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return keys, fmt.Errorf("failed to decode object list: %w", err)
+		}
+		for _, object := range page.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return keys, nil
+		}
+		continuation = page.NextContinuationToken
+	}
+}
+
+// PresignS3GetURL returns a URL that downloads an object without credentials until expires (at most
+// 7 days away). A filename makes browsers save the download under that name.
+func PresignS3GetURL(cfg S3Config, key string, expires time.Time, filename string) (string, error) {
+	region := s3Region(cfg)
+	endpoint, canonicalURI := s3ObjectLocation(cfg, key)
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	now := time.Now().UTC()
+	lifetime := int64(expires.Sub(now).Seconds())
+	if lifetime < 1 {
+		lifetime = 1
+	}
+	if lifetime > 7*24*60*60 {
+		lifetime = 7 * 24 * 60 * 60
+	}
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + region + "/s3/aws4_request"
+
+	query := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {cfg.AccessKeyID + "/" + scope},
+		"X-Amz-Date":          {amzDate},
+		"X-Amz-Expires":       {strconv.FormatInt(lifetime, 10)},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+	if filename != "" {
+		query.Set("response-content-disposition", ContentDisposition("attachment", filename))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		canonicalURI,
+		s3CanonicalQuery(query),
+		"host:" + parsed.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	signature := s3Signature(cfg, now, region, canonicalRequest)
+
+	return endpoint + canonicalURI + "?" + s3CanonicalQuery(query) + "&X-Amz-Signature=" + signature, nil
+}
+
+// s3Client sends bucket requests; transfers of large objects are bounded by the caller's context
+var s3Client = &http.Client{Timeout: 5 * time.Minute}
+
+// publicS3Client sends requests to buckets users configure, which must not reach internal services
+var publicS3Client = NewOutboundClient(5 * time.Minute)
+
+// s3HTTPClient returns the client for a bucket's requests
+func s3HTTPClient(cfg S3Config) *http.Client {
+	if cfg.PublicOnly {
+		return publicS3Client
+	}
+	return s3Client
+}
+
+// s3Region returns the signing region, us-east-1 unless configured
+func s3Region(cfg S3Config) string {
+	if cfg.Region == "" {
+		return "us-east-1"
+	}
+	return cfg.Region
+}
+
+// s3ObjectLocation returns the endpoint and the path of an object, or of the bucket for an empty key.
+// Path-style addressing works across AWS, GCS and most S3-compatible stores.
+func s3ObjectLocation(cfg S3Config, key string) (string, string) {
+	endpoint := strings.TrimRight(cfg.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s3Region(cfg))
+	}
+	canonicalURI := "/" + s3URIEncode(cfg.Bucket)
+	if key != "" {
+		canonicalURI += "/" + s3URIEncodePath(key)
+	} else {
+		canonicalURI += "/"
+	}
+	return endpoint, canonicalURI
+}
+
+// newS3Request builds an unsigned request for an object, or for the bucket when key is empty
+func newS3Request(ctx context.Context, cfg S3Config, method, key string, query url.Values, body []byte) (*http.Request, error) {
+	endpoint, canonicalURI := s3ObjectLocation(cfg, key)
+	target := endpoint + canonicalURI
+	if len(query) > 0 {
+		target += "?" + s3CanonicalQuery(query)
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.PublicOnly {
+		if err := checkOutboundURL(req.URL); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// signS3Request adds the Signature Version 4 headers to a request built by newS3Request
+func signS3Request(req *http.Request, cfg S3Config, payloadHash string) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		signedHeaders = "content-type;" + signedHeaders
+		canonicalHeaders = "content-type:" + contentType + "\n" + canonicalHeaders
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	region := s3Region(cfg)
+	scope := now.Format("20060102") + "/" + region + "/s3/aws4_request"
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		cfg.AccessKeyID, scope, signedHeaders, s3Signature(cfg, now, region, canonicalRequest)))
+}
+
+// s3Signature signs a canonical request with a key derived from the secret, date and region
+func s3Signature(cfg S3Config, now time.Time, region, canonicalRequest string) string {
+	dateStamp := now.Format("20060102")
+	scope := dateStamp + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + now.Format("20060102T150405Z") + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+cfg.SecretAccessKey), dateStamp)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+// s3CanonicalQuery encodes query parameters sorted by name, as SigV4 expects
+func s3CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			pairs = append(pairs, s3URIEncode(name)+"="+s3URIEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3ErrorBody describes a failed response with its status and the start of the error document
+func s3ErrorBody(resp *http.Response) string {
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
+
+// hmacSHA256 computes an HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 digest of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// s3URIEncodePath encodes each segment of an object key, keeping the slashes
+func s3URIEncodePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = s3URIEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3URIEncode percent-encodes everything except the SigV4 unreserved characters
+func s3URIEncode(value string) string {
+	var builder strings.Builder
+	for _, b := range []byte(value) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
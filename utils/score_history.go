@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"disko-backend/models"
+)
+
+// scoreSnapshot captures the current RICE score of an idea
+func scoreSnapshot(idea *models.Idea, actorID string, now time.Time) models.ScoreSnapshot {
+	return models.ScoreSnapshot{
+		ID:        GenerateFullUUID(),
+		BoardID:   idea.BoardID,
+		IdeaID:    idea.ID,
+		RiceScore: idea.RiceScore,
+		Total:     idea.RiceScore.CalculateRICEScore(),
+		ChangedBy: actorID,
+		CreatedAt: now,
+	}
+}
+
+// RecordScoreSnapshot stores the current RICE score of an idea in its score history.
+// Failures are logged and never propagated so the history cannot break the edit being recorded.
+func RecordScoreSnapshot(ctx context.Context, idea *models.Idea, actorID string) {
+	if models.DB == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 5*time.Second)
+	defer cancel()
+
+	snapshot := scoreSnapshot(idea, actorID, time.Now().UTC())
+	if _, err := models.GetCollection(ctx, models.ScoreHistoryCollection).InsertOne(ctx, snapshot); err != nil {
+		log.Printf("[ScoreHistory] Failed to record score snapshot - BoardID: %s, IdeaID: %s, Error: %v",
+			idea.BoardID, idea.ID, err)
+	}
+}
+
+// RecordScoreSnapshots stores the initial scores of ideas created together, such as those copied
+// from a template, in one write. Failures are logged like RecordScoreSnapshot's.
+func RecordScoreSnapshots(ctx context.Context, ideas []models.Idea, actorID string) {
+	if models.DB == nil || len(ideas) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	snapshots := make([]interface{}, 0, len(ideas))
+	for i := range ideas {
+		snapshots = append(snapshots, scoreSnapshot(&ideas[i], actorID, now))
+	}
+	if _, err := models.GetCollection(ctx, models.ScoreHistoryCollection).InsertMany(ctx, snapshots); err != nil {
+		log.Printf("[ScoreHistory] Failed to record score snapshots - BoardID: %s, Ideas: %d, Error: %v",
+			ideas[0].BoardID, len(ideas), err)
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// encryptedSecretPrefix marks values sealed by EncryptSecret, versioned so the scheme can change
+const encryptedSecretPrefix = "v1."
+
+// ErrEncryptionNotConfigured is returned when ENCRYPTION_KEY is not set
+var ErrEncryptionNotConfigured = errors.New("ENCRYPTION_KEY is not set")
+
+// secretsCipher builds the AES-256-GCM cipher from ENCRYPTION_KEY, 32 bytes encoded as base64
+func secretsCipher() (cipher.AEAD, error) {
+	encoded := os.Getenv("ENCRYPTION_KEY")
+	if encoded == "" {
+		return nil, ErrEncryptionNotConfigured
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("ENCRYPTION_KEY must be 32 bytes encoded as base64")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptionEnabled reports whether secrets can be encrypted
+func EncryptionEnabled() bool {
+	_, err := secretsCipher()
+	return err == nil
+}
+
+// EncryptSecret seals a credential for storage with ENCRYPTION_KEY
+func EncryptSecret(plaintext string) (string, error) {
+	aead, err := secretsCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedSecretPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret opens a credential sealed by EncryptSecret
+func DecryptSecret(ciphertext string) (string, error) {
+	aead, err := secretsCipher()
+	if err != nil {
+		return "", err
+	}
+	encoded, ok := strings.CutPrefix(ciphertext, encryptedSecretPrefix)
+	if !ok {
+		return "", errors.New("unknown secret encoding")
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed secret")
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", errors.New("secret cannot be decrypted; was ENCRYPTION_KEY changed?")
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedSecret reports whether a stored credential was sealed by EncryptSecret rather than saved
+// in plaintext before it was encrypted
+func IsEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, encryptedSecretPrefix)
+}
+
+// openStoredSecret decrypts a stored credential; credentials saved in plaintext before they were
+// encrypted are returned as they are until they are saved again
+func openStoredSecret(stored string) (string, error) {
+	if !IsEncryptedSecret(stored) {
+		return stored, nil
+	}
+	return DecryptSecret(stored)
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseKeepAliveInterval keeps idle event streams open through proxies that close quiet connections
+const sseKeepAliveInterval = 25 * time.Second
+
+// ServeBoardEvents streams a board's events as Server-Sent Events until the client disconnects, for
+// clients behind proxies that block WebSocket upgrades. Each event is named after the message type
+// and carries the same JSON message as the WebSocket; the events and ideaIds query parameters
+// (comma-separated) filter them like a WebSocket subscription. Streams over the connection limits are
+// refused with 429. The caller authorizes the connection first and passes authorize to repeat the
+// check; streams refused later end.
+func ServeBoardEvents(c *gin.Context, boardID, userID string, authorize StreamAuthorizer) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok || wsManager == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "STREAMING_UNSUPPORTED",
+				"message": "Event streaming is not available",
+			},
+		})
+		return
+	}
+
+	ip := c.ClientIP()
+	if err := wsManager.admit(boardID, ip); err != nil {
+		wsManager.counters.rejectedConnections.Add(1)
+		log.Printf("SSE rejected for board: %s, IP: %s, Reason: %v", boardID, ip, err)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    "TOO_MANY_CONNECTIONS",
+				"message": "Too many open event streams, try again later",
+				"details": err.Error(),
+			},
+		})
+		return
+	}
+	defer wsManager.release(boardID, ip)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable nginx response buffering
+	c.Status(http.StatusOK)
+	flusher.Flush()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	// Only this handler writes the response: queued events and keep-alives alike
+	writeEvent := func(message *WebSocketMessage) error {
+		payload, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		if message.Seq > 0 {
+			if _, err := fmt.Fprintf(c.Writer, "id: %s:%d\n", wsManager.instanceID, message.Seq); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", message.Type, payload)
+		return err
+	}
+	client := &boardClient{
+		userID: userID,
+		queue:  make(chan []*WebSocketMessage, clientQueueSize),
+		write: func(message *WebSocketMessage) error {
+			if err := writeEvent(message); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		},
+		// Coalesced events keep their own SSE events and IDs and share one flush
+		writeBatch: func(messages []*WebSocketMessage) error {
+			for _, message := range messages {
+				if err := writeEvent(message); err != nil {
+					return err
+				}
+			}
+			flusher.Flush()
+			return nil
+		},
+		close:   cancel,
+		recheck: make(chan struct{}, 1),
+	}
+	client.touch()
+	client.updateFilter(SubscriptionFilter{
+		Events:  splitFilterList(c.Query("events")),
+		IdeaIDs: splitFilterList(c.Query("ideaIds")),
+	}, true)
+	// Browsers send the last event ID when they reconnect an EventSource
+	resume := ParseEventID(c.GetHeader("Last-Event-ID"))
+	if resume == nil {
+		resume = ParseResumePoint(c.Query("epoch"), c.Query("since"))
+	}
+	wsManager.join(boardID, client, resume)
+	defer wsManager.leave(boardID, client)
+
+	go watchAccess(ctx, client, authorize, func() {
+		log.Printf("SSE closed for revoked access for board: %s, IP: %s", boardID, ip)
+		cancel()
+	})
+
+	log.Printf("SSE connected for board: %s, Member: %t", boardID, client.member())
+
+	ticker := time.NewTicker(sseKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case messages := <-client.queue:
+			if err := client.flush(messages); err != nil {
+				log.Printf("SSE write error: %v", err)
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+			client.touch()
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Storage keeps binary assets such as board logos, idea attachments and export files under
+// slash-separated keys like "boards/<boardID>/logo.png"
+type Storage interface {
+	// Put writes an object, replacing any object with the same key
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Open reads an object, returning ErrObjectNotFound when the key does not exist. The caller
+	// closes the reader.
+	Open(ctx context.Context, key string) (io.ReadCloser, *StoredObject, error)
+	// Delete removes an object; deleting a missing key succeeds
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix removes every object whose key starts with prefix and returns how many it removed
+	DeletePrefix(ctx context.Context, prefix string) (int, error)
+}
+
+// URLSigner is implemented by backends that serve downloads themselves, such as S3 with presigned
+// URLs. Other backends are downloaded through GET /api/files/*key.
+type URLSigner interface {
+	SignedURL(key string, expires time.Time, filename string) (string, error)
+}
+
+// StoredObject describes an object read from storage
+type StoredObject struct {
+	Key         string
+	Size        int64 // -1 when unknown
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// ErrObjectNotFound is returned when a storage key does not exist
+var ErrObjectNotFound = errors.New("stored object not found")
+
+// ErrInvalidStorageKey is returned for keys that are empty, absolute or contain "." or ".." segments
+var ErrInvalidStorageKey = errors.New("invalid storage key")
+
+// Storage backends selectable with STORAGE_BACKEND
+const (
+	StorageBackendLocal  = "local"
+	StorageBackendS3     = "s3"
+	StorageBackendGridFS = "gridfs"
+)
+
+// storageDownloadPath is where the server serves objects of backends without their own URLs
+const storageDownloadPath = "/api/files/"
+
+var (
+	storageMu      sync.RWMutex
+	currentStorage Storage
+
+	storageSecret     []byte
+	storageSecretOnce sync.Once
+)
+
+// InitStorage selects the asset storage from STORAGE_BACKEND: local (the default) writes under
+// STORAGE_DIR, s3 uses the STORAGE_S3_* bucket, gridfs keeps files in the database. The database
+// must be connected first for gridfs.
+func InitStorage() error {
+	var store Storage
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", StorageBackendLocal:
+		dir := os.Getenv("STORAGE_DIR")
+		if dir == "" {
+			dir = "storage"
+		}
+		store = NewLocalStorage(dir)
+	case StorageBackendS3:
+		cfg := S3Config{
+			Endpoint:        os.Getenv("STORAGE_S3_ENDPOINT"),
+			Region:          os.Getenv("STORAGE_S3_REGION"),
+			Bucket:          os.Getenv("STORAGE_S3_BUCKET"),
+			AccessKeyID:     os.Getenv("STORAGE_S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("STORAGE_S3_SECRET_ACCESS_KEY"),
+		}
+		if cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+			return fmt.Errorf("STORAGE_S3_BUCKET, STORAGE_S3_ACCESS_KEY_ID and STORAGE_S3_SECRET_ACCESS_KEY are required for the s3 storage backend")
+		}
+		store = NewS3Storage(cfg)
+	case StorageBackendGridFS:
+		bucket := os.Getenv("STORAGE_GRIDFS_BUCKET")
+		if bucket == "" {
+			bucket = "assets"
+		}
+		var err error
+		if store, err = NewGridFSStorage(bucket); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported STORAGE_BACKEND %q (supported: local, s3, gridfs)", backend)
+	}
+
+	SetStorage(store)
+	log.Printf("[Storage] Using %T", store)
+	return nil
+}
+
+// SetStorage replaces the asset storage, e.g. with another backend
+func SetStorage(store Storage) {
+	storageMu.Lock()
+	defer storageMu.Unlock()
+	currentStorage = store
+}
+
+// GetStorage returns the configured asset storage, local disk under "storage" before InitStorage
+func GetStorage() Storage {
+	storageMu.RLock()
+	store := currentStorage
+	storageMu.RUnlock()
+	if store != nil {
+		return store
+	}
+
+	storageMu.Lock()
+	defer storageMu.Unlock()
+	if currentStorage == nil {
+		currentStorage = NewLocalStorage("storage")
+	}
+	return currentStorage
+}
+
+// ValidateStorageKey rejects keys that could escape the storage root of the local backend
+func ValidateStorageKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return ErrInvalidStorageKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return ErrInvalidStorageKey
+		}
+	}
+	return nil
+}
+
+// StorageURLTTL returns how long download links stay valid: STORAGE_URL_TTL (a duration such as
+// 30m), one hour by default
+func StorageURLTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("STORAGE_URL_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return time.Hour
+}
+
+// storageSigningSecret returns the HMAC key for download links. Without STORAGE_SIGNING_SECRET a
+// random key is used, so links stop working on restart and differ between instances.
+func storageSigningSecret() []byte {
+	storageSecretOnce.Do(func() {
+		if secret := os.Getenv("STORAGE_SIGNING_SECRET"); secret != "" {
+			storageSecret = []byte(secret)
+			return
+		}
+		log.Println("Warning: STORAGE_SIGNING_SECRET not set, download links will stop working on restart")
+		storageSecret = make([]byte, 32)
+		if _, err := rand.Read(storageSecret); err != nil {
+			log.Fatalf("Failed to generate storage signing secret: %v", err)
+		}
+	})
+	return storageSecret
+}
+
+// storageSignature signs a download of key until expires, saved as filename when set
+func storageSignature(key string, expires int64, filename string) string {
+	mac := hmac.New(sha256.New, storageSigningSecret())
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10) + "\n" + filename))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignedStorageURL returns a link that downloads key without signing in until ttl has passed. A
+// filename makes browsers save the download under that name. The link points at the bucket for
+// backends that implement URLSigner and at APP_URL/api/files otherwise.
+func SignedStorageURL(key string, ttl time.Duration, filename string) (string, time.Time, error) {
+	if err := ValidateStorageKey(key); err != nil {
+		return "", time.Time{}, err
+	}
+	expires := time.Now().Add(ttl).UTC().Truncate(time.Second)
+
+	if signer, ok := GetStorage().(URLSigner); ok {
+		signed, err := signer.SignedURL(key, expires, filename)
+		return signed, expires, err
+	}
+
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expires.Unix(), 10)},
+		"signature": {storageSignature(key, expires.Unix(), filename)},
+	}
+	if filename != "" {
+		query.Set("filename", filename)
+	}
+	return os.Getenv("APP_URL") + storageDownloadPath + s3URIEncodePath(key) + "?" + query.Encode(), expires, nil
+}
+
+// VerifyStorageSignature checks the expiry and signature of a link made by SignedStorageURL
+func VerifyStorageSignature(key, expires, filename, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(storageSignature(key, expiresAt, filename)), []byte(signature))
+}
+
+// ContentDisposition formats a Content-Disposition header, encoding non-ASCII filenames
+func ContentDisposition(disposition, filename string) string {
+	if filename == "" {
+		return disposition
+	}
+	if formatted := mime.FormatMediaType(disposition, map[string]string{"filename": filename}); formatted != "" {
+		return formatted
+	}
+	return disposition
+}
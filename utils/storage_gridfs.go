@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// GridFSStorage keeps objects in MongoDB GridFS, so assets are replicated and backed up with the
+// database. Objects are GridFS files named after their key; a Put adds a revision and removes the
+// older ones.
+type GridFSStorage struct {
+	bucket *mongo.GridFSBucket
+}
+
+// gridFSMetadata is stored with every file
+type gridFSMetadata struct {
+	ContentType string `bson:"content_type"`
+}
+
+// NewGridFSStorage creates a storage in the GridFS bucket of the connected database
+func NewGridFSStorage(bucket string) (*GridFSStorage, error) {
+	if models.DB == nil {
+		return nil, fmt.Errorf("the gridfs storage backend needs a database connection")
+	}
+	return &GridFSStorage{bucket: models.DB.DB.GridFSBucket(options.GridFSBucket().SetName(bucket))}, nil
+}
+
+// Put uploads a new revision of the object and deletes the previous ones
+func (s *GridFSStorage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	if err := ValidateStorageKey(key); err != nil {
+		return err
+	}
+	previous, err := s.fileIDs(ctx, bson.M{"filename": key})
+	if err != nil {
+		return err
+	}
+
+	opts := options.GridFSUpload().SetMetadata(gridFSMetadata{ContentType: contentType})
+	if _, err := s.bucket.UploadFromStream(ctx, key, bytes.NewReader(body), opts); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	for _, id := range previous {
+		if err := s.bucket.Delete(ctx, id); err != nil && !errors.Is(err, mongo.ErrFileNotFound) {
+			return fmt.Errorf("failed to delete the previous revision: %w", err)
+		}
+	}
+	return nil
+}
+
+// Open streams the latest revision of the object
+func (s *GridFSStorage) Open(ctx context.Context, key string) (io.ReadCloser, *StoredObject, error) {
+	if err := ValidateStorageKey(key); err != nil {
+		return nil, nil, err
+	}
+	stream, err := s.bucket.OpenDownloadStreamByName(ctx, key)
+	if errors.Is(err, mongo.ErrFileNotFound) {
+		return nil, nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file := stream.GetFile()
+	var metadata gridFSMetadata
+	if len(file.Metadata) > 0 {
+		_ = bson.Unmarshal(file.Metadata, &metadata)
+	}
+	if metadata.ContentType == "" {
+		metadata.ContentType = "application/octet-stream"
+	}
+	return stream, &StoredObject{Key: key, Size: file.Length, ContentType: metadata.ContentType, ModifiedAt: file.UploadDate}, nil
+}
+
+// Delete removes every revision of the object
+func (s *GridFSStorage) Delete(ctx context.Context, key string) error {
+	if err := ValidateStorageKey(key); err != nil {
+		return err
+	}
+	_, err := s.deleteFiles(ctx, bson.M{"filename": key})
+	return err
+}
+
+// DeletePrefix removes the files whose name starts with prefix
+func (s *GridFSStorage) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	return s.deleteFiles(ctx, bson.M{"filename": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}})
+}
+
+// fileIDs returns the IDs of the files matching filter
+func (s *GridFSStorage) fileIDs(ctx context.Context, filter bson.M) ([]interface{}, error) {
+	cursor, err := s.bucket.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []struct {
+		ID interface{} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	ids := make([]interface{}, 0, len(files))
+	for _, file := range files {
+		ids = append(ids, file.ID)
+	}
+	return ids, nil
+}
+
+// deleteFiles removes the files matching filter with their chunks
+func (s *GridFSStorage) deleteFiles(ctx context.Context, filter bson.M) (int, error) {
+	ids, err := s.fileIDs(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, id := range ids {
+		if err := s.bucket.Delete(ctx, id); err != nil && !errors.Is(err, mongo.ErrFileNotFound) {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
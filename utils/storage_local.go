@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage keeps objects as files under a directory, for single-instance deployments. The
+// content type is derived from the key's extension.
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage creates a storage writing under dir, which is created on first write
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+// path returns the file of a key, rejecting keys that would leave the storage directory
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := ValidateStorageKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the object through a temporary file so readers never see a partial object
+func (s *LocalStorage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object file: %w", err)
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+	return nil
+}
+
+// Open reads the object's file
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, *StoredObject, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		file.Close()
+		return nil, nil, ErrObjectNotFound
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return file, &StoredObject{Key: key, Size: info.Size(), ContentType: contentType, ModifiedAt: info.ModTime()}, nil
+}
+
+// Delete removes the object's file
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeletePrefix removes the files whose key starts with prefix, with the directories left empty
+func (s *LocalStorage) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	// Walk the deepest directory the prefix names completely
+	dir := path.Dir(prefix + "x")
+	root := s.dir
+	if dir != "." {
+		var err error
+		if root, err = s.path(dir); err != nil {
+			return 0, err
+		}
+	}
+
+	deleted := 0
+	var emptied []string
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, file)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if strings.HasPrefix(key+"/", prefix) && file != s.dir {
+				emptied = append(emptied, file)
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+		deleted++
+		return ctx.Err()
+	})
+
+	// Deepest directories first; directories that still hold files stay
+	for i := len(emptied) - 1; i >= 0; i-- {
+		os.Remove(emptied[i])
+	}
+	return deleted, err
+}
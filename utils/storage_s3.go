@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// S3Storage keeps objects in an S3-compatible bucket and hands out presigned download URLs, so
+// downloads do not go through the server
+type S3Storage struct {
+	cfg S3Config
+}
+
+// NewS3Storage creates a storage for a bucket
+func NewS3Storage(cfg S3Config) *S3Storage {
+	return &S3Storage{cfg: cfg}
+}
+
+// Put uploads the object
+func (s *S3Storage) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	if err := ValidateStorageKey(key); err != nil {
+		return err
+	}
+	return PutS3Object(ctx, s.cfg, key, body, contentType)
+}
+
+// Open downloads the object
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadCloser, *StoredObject, error) {
+	if err := ValidateStorageKey(key); err != nil {
+		return nil, nil, err
+	}
+	return GetS3Object(ctx, s.cfg, key)
+}
+
+// Delete removes the object
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := ValidateStorageKey(key); err != nil {
+		return err
+	}
+	return DeleteS3Object(ctx, s.cfg, key)
+}
+
+// DeletePrefix lists the objects under prefix and removes them one by one
+func (s *S3Storage) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	keys, err := ListS3Objects(ctx, s.cfg, prefix)
+	deleted := 0
+	for _, key := range keys {
+		if deleteErr := DeleteS3Object(ctx, s.cfg, key); deleteErr != nil {
+			return deleted, deleteErr
+		}
+		deleted++
+	}
+	return deleted, err
+}
+
+// SignedURL presigns a download from the bucket
+func (s *S3Storage) SignedURL(key string, expires time.Time, filename string) (string, error) {
+	return PresignS3GetURL(s.cfg, key, expires, filename)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// StreamAuthorizer reports whether a realtime client may still follow its board or notifications. It
+// returns false only once access is definitely gone, such as a revoked session or a removed member,
+// and true when the check itself fails so a database hiccup does not drop every connection. Public
+// visitors are the exception: a board whose IP rules cannot be read no longer admits them.
+type StreamAuthorizer func(ctx context.Context) bool
+
+// Open connections check their access again on this interval, and right away when RecheckBoardAccess
+// asks them to on this instance
+const (
+	streamRecheckInterval = time.Minute
+	streamRecheckTimeout  = 5 * time.Second
+)
+
+// watchAccess repeats a connection's authorization until ctx ends, calling revoke once it is refused
+func watchAccess(ctx context.Context, client *boardClient, authorize StreamAuthorizer, revoke func()) {
+	if authorize == nil {
+		return
+	}
+
+	ticker := time.NewTicker(streamRecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-client.recheck:
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, streamRecheckTimeout)
+		allowed := authorize(checkCtx)
+		cancel()
+		if !allowed && ctx.Err() == nil {
+			wsManager.counters.accessRevoked.Add(1)
+			revoke()
+			return
+		}
+	}
+}
+
+// requestRecheck asks a connection to check its access on its next turn
+func (bc *boardClient) requestRecheck() {
+	select {
+	case bc.recheck <- struct{}{}:
+	default: // A check is already pending
+	}
+}
+
+// RecheckBoardAccess makes the board's open connections on this instance check their access now,
+// such as after a member was removed or the board stopped being public. Connections on other
+// instances notice on their next periodic check.
+func RecheckBoardAccess(boardID string) {
+	if wsManager == nil {
+		return
+	}
+
+	wsManager.mutex.RLock()
+	defer wsManager.mutex.RUnlock()
+	for client := range wsManager.connections[boardID] {
+		client.requestRecheck()
+	}
+}
+
+// CloseUserConnections ends every board and notification connection of a user on this instance, such
+// as after their sessions were revoked. Connections on other instances end on their next periodic
+// check when the identity provider reports the session revoked.
+func CloseUserConnections(userID string) {
+	if wsManager == nil || userID == "" {
+		return
+	}
+
+	wsManager.mutex.RLock()
+	var clients []*boardClient
+	for _, boardClients := range wsManager.connections {
+		for client := range boardClients {
+			if client.userID == userID {
+				clients = append(clients, client)
+			}
+		}
+	}
+	for client := range wsManager.userStreams[userID] {
+		clients = append(clients, client)
+	}
+	wsManager.mutex.RUnlock()
+
+	for _, client := range clients {
+		client.close()
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchAccess(t *testing.T) {
+	if wsManager == nil {
+		InitWebSocketManager()
+	}
+
+	watch := func(t *testing.T, boardID string, allowed *atomic.Bool) (*atomic.Int32, chan struct{}) {
+		client := &boardClient{userID: "user_1", recheck: make(chan struct{}, 1)}
+		wsManager.addConnection(boardID, client)
+		t.Cleanup(func() { wsManager.removeConnection(boardID, client) })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		var checks atomic.Int32
+		revoked := make(chan struct{})
+		go watchAccess(ctx, client, func(context.Context) bool {
+			checks.Add(1)
+			return allowed.Load()
+		}, func() { close(revoked) })
+		return &checks, revoked
+	}
+
+	t.Run("Revoked On Recheck", func(t *testing.T) {
+		var allowed atomic.Bool
+		_, revoked := watch(t, "board_revoked", &allowed)
+
+		RecheckBoardAccess("board_revoked")
+		select {
+		case <-revoked:
+		case <-time.After(2 * time.Second):
+			t.Fatal("connection was not revoked")
+		}
+	})
+
+	t.Run("Kept While Allowed", func(t *testing.T) {
+		var allowed atomic.Bool
+		allowed.Store(true)
+		checks, revoked := watch(t, "board_allowed", &allowed)
+
+		RecheckBoardAccess("board_allowed")
+		assert.Eventually(t, func() bool { return checks.Load() == 1 }, 2*time.Second, 10*time.Millisecond)
+		select {
+		case <-revoked:
+			t.Fatal("allowed connection was revoked")
+		default:
+		}
+	})
+
+	t.Run("Other Boards Untouched", func(t *testing.T) {
+		var allowed atomic.Bool
+		checks, _ := watch(t, "board_other", &allowed)
+
+		RecheckBoardAccess("board_elsewhere")
+		time.Sleep(50 * time.Millisecond)
+		assert.Zero(t, checks.Load())
+	})
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// Subscription messages a WebSocket client sends to narrow the events it receives
+const (
+	ClientSubscribe   = "subscribe"
+	ClientUnsubscribe = "unsubscribe"
+	EventSubscribed   = "subscribed" // Reply with the connection's current filter
+)
+
+// maxSubscriptionItems bounds the event types and idea IDs a connection can filter on
+const maxSubscriptionItems = 200
+
+// SubscriptionFilter selects the events a connection receives. Empty lists mean no restriction;
+// messages that are not about a single idea (board events, presence) pass the idea filter.
+type SubscriptionFilter struct {
+	Events  []string `json:"events"`
+	IdeaIDs []string `json:"ideaIds"`
+}
+
+// subscription is a connection's current filter
+type subscription struct {
+	events  map[string]bool
+	ideaIDs map[string]bool
+}
+
+// wants reports whether the connection receives a message
+func (bc *boardClient) wants(message *WebSocketMessage) bool {
+	bc.filterMu.RLock()
+	defer bc.filterMu.RUnlock()
+
+	if len(bc.filter.events) > 0 && !bc.filter.events[message.Type] {
+		return false
+	}
+	if len(bc.filter.ideaIDs) > 0 && message.IdeaID != "" && !bc.filter.ideaIDs[message.IdeaID] {
+		return false
+	}
+	return true
+}
+
+// updateFilter adds (subscribe) or removes (unsubscribe) filter entries and returns the resulting filter
+func (bc *boardClient) updateFilter(change SubscriptionFilter, subscribe bool) SubscriptionFilter {
+	bc.filterMu.Lock()
+	defer bc.filterMu.Unlock()
+
+	if bc.filter.events == nil {
+		bc.filter.events = make(map[string]bool)
+		bc.filter.ideaIDs = make(map[string]bool)
+	}
+	apply := func(set map[string]bool, values []string) {
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if !subscribe {
+				delete(set, value)
+			} else if len(set) < maxSubscriptionItems {
+				set[value] = true
+			}
+		}
+	}
+	apply(bc.filter.events, change.Events)
+	apply(bc.filter.ideaIDs, change.IdeaIDs)
+
+	current := SubscriptionFilter{Events: []string{}, IdeaIDs: []string{}}
+	for event := range bc.filter.events {
+		current.Events = append(current.Events, event)
+	}
+	for ideaID := range bc.filter.ideaIDs {
+		current.IdeaIDs = append(current.IdeaIDs, ideaID)
+	}
+	sort.Strings(current.Events)
+	sort.Strings(current.IdeaIDs)
+	return current
+}
+
+// splitFilterList parses a comma-separated filter list, as used by the event stream query parameters
+func splitFilterList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
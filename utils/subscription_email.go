@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gopkg.in/gomail.v2"
+)
+
+// subscriptionEmailTemplate is shared by the confirmation and release announcement emails
+var subscriptionEmailTemplate = template.Must(template.New("subscription").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.BoardName}}</title>
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; line-height: 1.6; color: #333; background-color: #f9fafb; margin: 0; padding: 24px;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 12px; overflow: hidden;">
+        <div style="background: linear-gradient(135deg, #3b82f6 0%, #8b5cf6 100%); color: white; padding: 32px 30px; text-align: center;">
+            <h1 style="margin: 0; font-size: 24px;">{{.Heading}}</h1>
+            <p style="margin: 8px 0 0 0; opacity: 0.9;">{{.BoardName}}</p>
+        </div>
+        <div style="padding: 32px 30px;">
+            <p style="margin: 0 0 16px 0;">{{.Intro}}</p>
+            {{if .Idea}}
+            <div style="padding: 12px 16px; background-color: #f8fafc; border-radius: 6px; border-left: 3px solid #10b981; margin-bottom: 24px;">
+                <div style="font-weight: 600; color: #1e293b;">{{.Idea.OneLiner}}</div>
+                {{if .Idea.ValueStatement}}<div style="font-size: 14px; color: #64748b;">{{.Idea.ValueStatement}}</div>{{end}}
+            </div>
+            {{end}}
+            <div style="text-align: center;">
+                <a href="{{.ActionURL}}" style="display: inline-block; background: linear-gradient(135deg, #3b82f6 0%, #8b5cf6 100%); color: white; text-decoration: none; padding: 14px 28px; border-radius: 8px; font-weight: 600;">{{.ActionLabel}}</a>
+            </div>
+        </div>
+        <div style="background-color: #f1f5f9; padding: 20px 30px; text-align: center; color: #64748b; font-size: 13px;">
+            <p style="margin: 0 0 8px 0;">{{.Footer}}</p>
+            <p style="margin: 0;"><a href="{{.UnsubscribeURL}}" style="color: #64748b;">Unsubscribe</a></p>
+        </div>
+    </div>
+</body>
+</html>`))
+
+// subscriptionEmailData fills subscriptionEmailTemplate
+type subscriptionEmailData struct {
+	Heading        string
+	BoardName      string
+	Intro          string
+	Idea           *models.Idea
+	ActionURL      string
+	ActionLabel    string
+	Footer         string
+	UnsubscribeURL string
+}
+
+// sendHTMLEmail sends a single HTML email using the SMTP environment configuration
+func sendHTMLEmail(to, subject, body string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPortStr := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("FROM_EMAIL")
+
+	if smtpHost == "" || smtpPortStr == "" || smtpUser == "" || smtpPass == "" || fromEmail == "" {
+		return fmt.Errorf("email configuration incomplete - check SMTP_HOST, SMTP_PORT, SMTP_USER, SMTP_PASS, FROM_EMAIL environment variables")
+	}
+
+	smtpPort, _ := strconv.Atoi(smtpPortStr)
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", fromEmail)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", body)
+
+	d := gomail.NewDialer(smtpHost, smtpPort, smtpUser, smtpPass)
+	if err := d.DialAndSend(m); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	return nil
+}
+
+// renderSubscriptionEmail executes the subscription template
+func renderSubscriptionEmail(data subscriptionEmailData) (string, error) {
+	var buf bytes.Buffer
+	if err := subscriptionEmailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// subscriptionURL builds an absolute API link for a subscription token
+func subscriptionURL(action, token string) string {
+	return fmt.Sprintf("%s/api/subscriptions/%s?token=%s", os.Getenv("APP_URL"), action, token)
+}
+
+// SendSubscriptionConfirmEmail asks a new subscriber to confirm their address (double opt-in)
+func SendSubscriptionConfirmEmail(subscriber models.Subscriber, board models.Board) error {
+	body, err := renderSubscriptionEmail(subscriptionEmailData{
+		Heading:        "Confirm your subscription",
+		BoardName:      board.Name,
+		Intro:          "Someone (hopefully you) asked to receive release announcements for this board. Confirm your email address to start receiving them.",
+		ActionURL:      subscriptionURL("confirm", subscriber.ConfirmToken),
+		ActionLabel:    "Confirm subscription",
+		Footer:         "If you didn't ask for this, you can safely ignore this email and you won't hear from us again.",
+		UnsubscribeURL: subscriptionURL("unsubscribe", subscriber.UnsubscribeToken),
+	})
+	if err != nil {
+		log.Printf("[Email] Failed to render confirmation email: %v", err)
+		return err
+	}
+
+	if err := sendHTMLEmail(subscriber.Email, fmt.Sprintf("Confirm your subscription to %s", board.Name), body); err != nil {
+		log.Printf("[Email] Failed to send confirmation email - Error: %v, SubscriberID: %s, BoardID: %s", err, subscriber.ID, board.ID)
+		return err
+	}
+
+	log.Printf("[Email] Confirmation email sent - SubscriberID: %s, BoardID: %s", subscriber.ID, board.ID)
+	return nil
+}
+
+// NotifySubscribersOfRelease emails a board's confirmed subscribers that an idea was released.
+// It runs in the background; private boards are skipped since subscribers can't open them.
+func NotifySubscribersOfRelease(ctx context.Context, boardID string, idea models.Idea) {
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 5*time.Minute)
+	defer cancel()
+
+	var board models.Board
+	if err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Decode(&board); err != nil {
+		log.Printf("[Email] Release announcement skipped - Board lookup error: %v, BoardID: %s", err, boardID)
+		return
+	}
+	if !board.IsPublic {
+		return
+	}
+
+	cursor, err := models.GetCollection(ctx, models.SubscribersCollection).Find(ctx, bson.M{
+		"board_id": boardID,
+		"status":   string(models.SubscriberConfirmed),
+	})
+	if err != nil {
+		log.Printf("[Email] Release announcement skipped - Subscriber lookup error: %v, BoardID: %s", err, boardID)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	publicURL := fmt.Sprintf("%s/public/%s", os.Getenv("APP_URL"), board.PublicLink)
+	subject := fmt.Sprintf("Just released on %s: %s", board.Name, idea.OneLiner)
+	sent, failed := 0, 0
+	for cursor.Next(ctx) {
+		var subscriber models.Subscriber
+		if err := cursor.Decode(&subscriber); err != nil {
+			failed++
+			continue
+		}
+
+		body, err := renderSubscriptionEmail(subscriptionEmailData{
+			Heading:        "Just released 🎉",
+			BoardName:      board.Name,
+			Intro:          "A new item has shipped on a board you follow:",
+			Idea:           &idea,
+			ActionURL:      publicURL,
+			ActionLabel:    "View board",
+			Footer:         "You're receiving this because you subscribed to release announcements for this board.",
+			UnsubscribeURL: subscriptionURL("unsubscribe", subscriber.UnsubscribeToken),
+		})
+		if err == nil {
+			err = sendHTMLEmail(subscriber.Email, subject, body)
+		}
+		if err != nil {
+			failed++
+			log.Printf("[Email] Release announcement failed - Error: %v, SubscriberID: %s, BoardID: %s", err, subscriber.ID, boardID)
+			continue
+		}
+		sent++
+	}
+
+	log.Printf("[Email] Release announcement done - BoardID: %s, IdeaID: %s, Sent: %d, Failed: %d", boardID, idea.ID, sent, failed)
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// telegramAPI is the Telegram Bot API's base URL
+const telegramAPI = "https://api.telegram.org"
+
+// telegramReply is the Bot API's response envelope
+type telegramReply struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// SendTelegramMessage posts an HTML formatted message to a chat with a bot, returning the
+// response status if there was one
+func SendTelegramMessage(botToken, chatID, text string) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := webhookClient.Post(telegramAPI+"/bot"+botToken+"/sendMessage", "application/json", bytes.NewReader(body))
+	if err != nil {
+		// The request URL holds the bot token; keep it out of errors and logs
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("failed to reach Telegram: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var reply telegramReply
+	json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&reply)
+	if resp.StatusCode != http.StatusOK || !reply.OK {
+		return resp.StatusCode, fmt.Errorf("Telegram responded with status %d: %s", resp.StatusCode, reply.Description)
+	}
+	return resp.StatusCode, nil
+}
+
+// formatTelegramMessage renders a notification as a short HTML message linking to the board
+func formatTelegramMessage(notification *BoardNotification) string {
+	return fmt.Sprintf("<b>%s</b>\n%s\n<a href=\"%s\">Open board</a>",
+		html.EscapeString(notification.BoardName),
+		html.EscapeString(notification.Summary),
+		html.EscapeString(fmt.Sprintf("%s/board/%s", os.Getenv("APP_URL"), notification.BoardID)))
+}
+
+// sendTelegramNotification sends a notification to the board's Telegram chat. Chats removed or
+// disabled since the job was queued are skipped. Failures retrying cannot fix, such as a revoked
+// token or a bot removed from the chat, are recorded on the config for the owner to see.
+func sendTelegramNotification(ctx context.Context, notification *BoardNotification, configID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	collection := models.GetCollection(ctx, models.TelegramConfigsCollection)
+	var config models.TelegramConfig
+	err := collection.FindOne(ctx, bson.M{"_id": configID, "board_id": notification.BoardID}).Decode(&config)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find Telegram config: %v", err)
+	}
+	if !config.Enabled {
+		return nil
+	}
+
+	statusCode, err := SendTelegramMessage(config.BotToken, config.ChatID, formatTelegramMessage(notification))
+	if err != nil {
+		if !retryableWebhookStatus(statusCode) {
+			if _, updateErr := collection.UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{"$set": bson.M{"last_error": err.Error()}}); updateErr != nil {
+				log.Printf("Failed to record Telegram error for board %s: %v", config.BoardID, updateErr)
+			}
+			return permanentError{err}
+		}
+		return err
+	}
+
+	if config.LastError != "" {
+		collection.UpdateOne(ctx, bson.M{"_id": config.ID}, bson.M{"$unset": bson.M{"last_error": ""}})
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Events sent on a user's notification stream
+const (
+	EventUnreadCount = "unread_count" // The user read or cleared notifications, possibly in another tab
+)
+
+// userStreamKey is the connection limit key of a user's notification streams, capped like a board's
+func userStreamKey(userID string) string {
+	return "user:" + userID
+}
+
+// ServeUserSocket upgrades the request and streams the user's notification center events, new
+// notifications and unread count changes, until the client disconnects. The caller authenticates
+// the user first and passes authorize to check the session again while the socket stays open.
+// Unlike board sockets, nothing is replayed on reconnect: clients refetch the notification list instead.
+func ServeUserSocket(c *gin.Context, userID string, authorize StreamAuthorizer) {
+	conn, err := wsManager.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ip := c.ClientIP()
+	if err := wsManager.admit(userStreamKey(userID), ip); err != nil {
+		wsManager.counters.rejectedConnections.Add(1)
+		log.Printf("WebSocket rejected for user stream: %s, IP: %s, Reason: %v", userID, ip, err)
+		closeSocket(conn, websocket.CloseTryAgainLater, err.Error())
+		return
+	}
+	defer wsManager.release(userStreamKey(userID), ip)
+
+	client := &boardClient{
+		userID: userID,
+		queue:  make(chan []*WebSocketMessage, clientQueueSize),
+		write: func(message *WebSocketMessage) error {
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			return conn.WriteJSON(message)
+		},
+		close:   func() { conn.Close() },
+		recheck: make(chan struct{}, 1),
+	}
+	client.touch()
+
+	stopWriter := make(chan struct{})
+	defer close(stopWriter)
+	go writeSocket(conn, client, stopWriter)
+
+	wsManager.addUserStream(userID, client)
+	defer wsManager.removeUserStream(userID, client)
+
+	watchCtx, stopWatch := context.WithCancel(c.Request.Context())
+	defer stopWatch()
+	go watchAccess(watchCtx, client, authorize, func() {
+		log.Printf("WebSocket closed for revoked session for user stream: %s, IP: %s", userID, ip)
+		closeSocket(conn, websocket.ClosePolicyViolation, "session revoked")
+	})
+	client.send(&WebSocketMessage{Type: "connected"})
+
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		client.touch()
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	// The stream only answers pings; everything else flows from the server
+	var budget messageBudget
+	for {
+		var msg clientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("WebSocket error: %v", err)
+			}
+			break
+		}
+		client.touch()
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		if !budget.take(wsManager.limits) {
+			wsManager.counters.rateLimited.Add(1)
+			closeSocket(conn, websocket.ClosePolicyViolation, "message rate limit exceeded")
+			break
+		}
+		if msg.Type == "ping" {
+			client.send(&WebSocketMessage{Type: "pong"})
+		}
+	}
+}
+
+// addUserStream registers a user's notification stream
+func (wsm *WebSocketManager) addUserStream(userID string, client *boardClient) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+
+	if wsm.userStreams[userID] == nil {
+		wsm.userStreams[userID] = make(map[*boardClient]bool)
+	}
+	wsm.userStreams[userID][client] = true
+}
+
+// removeUserStream unregisters a user's notification stream
+func (wsm *WebSocketManager) removeUserStream(userID string, client *boardClient) {
+	wsm.mutex.Lock()
+	defer wsm.mutex.Unlock()
+
+	delete(wsm.userStreams[userID], client)
+	if len(wsm.userStreams[userID]) == 0 {
+		delete(wsm.userStreams, userID)
+	}
+}
+
+// SendToUserStream delivers a message to a user's notification streams on every instance
+func SendToUserStream(userID string, message *WebSocketMessage) {
+	if wsManager == nil {
+		return
+	}
+
+	wsManager.counters.eventsPublished.Add(1)
+	wsManager.relay(&brokerEnvelope{Member: message, Transient: true, Recipient: userID, UserStream: true})
+	wsManager.deliverToUser(userID, message)
+}
+
+// deliverToUser queues a message for a user's local notification streams
+func (wsm *WebSocketManager) deliverToUser(userID string, message *WebSocketMessage) {
+	wsm.mutex.RLock()
+	clients := make([]*boardClient, 0, len(wsm.userStreams[userID]))
+	for client := range wsm.userStreams[userID] {
+		clients = append(clients, client)
+	}
+	wsm.mutex.RUnlock()
+
+	for _, client := range clients {
+		if err := client.send(message); err != nil {
+			wsm.counters.slowConsumers.Add(1)
+			log.Printf("WebSocket send error for user stream: %s: %v", userID, err)
+			client.close()
+			continue
+		}
+		wsm.counters.messagesQueued.Add(1)
+	}
+}
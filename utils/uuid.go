@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 )
 
@@ -22,6 +24,61 @@ func GenerateIdeaID() string {
 	return "i" + uuid.New().String()[:8]
 }
 
+// GenerateTemplateID generates a board template ID with "t" prefix and 8-character UUID
+func GenerateTemplateID() string {
+	return "t" + uuid.New().String()[:8]
+}
+
+// GenerateReleaseID generates a release ID with "r" prefix and 8-character UUID
+func GenerateReleaseID() string {
+	return "r" + uuid.New().String()[:8]
+}
+
+// GenerateSubmissionID generates an idea submission ID with "s" prefix and 8-character UUID
+func GenerateSubmissionID() string {
+	return "s" + uuid.New().String()[:8]
+}
+
+// GenerateCommentID generates a comment ID with "c" prefix and 8-character UUID
+func GenerateCommentID() string {
+	return "c" + uuid.New().String()[:8]
+}
+
+// GenerateEmbedToken generates an embed token with "e" prefix and a dash-free full UUID
+func GenerateEmbedToken() string {
+	return "e" + strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// GenerateSubscriberToken generates an unguessable subscription link token with "st" prefix and a dash-free full UUID
+func GenerateSubscriberToken() string {
+	return "st" + strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// GenerateInvitationToken generates an unguessable collaborator invitation token with "iv" prefix and a dash-free full UUID
+func GenerateInvitationToken() string {
+	return "iv" + strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// GenerateAPITokenSecret generates a board API token secret with "dk_" prefix and two dash-free UUIDs
+func GenerateAPITokenSecret() string {
+	return "dk_" + strings.ReplaceAll(uuid.New().String()+uuid.New().String(), "-", "")
+}
+
+// GeneratePersonalAccessToken generates a personal access token secret with "dkp_" prefix and two dash-free UUIDs
+func GeneratePersonalAccessToken() string {
+	return "dkp_" + strings.ReplaceAll(uuid.New().String()+uuid.New().String(), "-", "")
+}
+
+// GenerateWebhookSecret generates an outgoing webhook secret with "whsec_" prefix and two dash-free UUIDs
+func GenerateWebhookSecret() string {
+	return "whsec_" + strings.ReplaceAll(uuid.New().String()+uuid.New().String(), "-", "")
+}
+
+// GenerateServiceAccountSecret generates a service account secret with "dks_" prefix and two dash-free UUIDs
+func GenerateServiceAccountSecret() string {
+	return "dks_" + strings.ReplaceAll(uuid.New().String()+uuid.New().String(), "-", "")
+}
+
 // GenerateFullUUID generates a full UUID string for cases where maximum uniqueness is needed
 func GenerateFullUUID() string {
 	return uuid.New().String()
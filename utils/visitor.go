@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VisitorCookieName is the cookie carrying the signed anonymous visitor token
+const VisitorCookieName = "disko_visitor"
+
+// visitorCookieMaxAge keeps the visitor token for a year
+const visitorCookieMaxAge = 365 * 24 * 60 * 60
+
+var (
+	visitorSecret     []byte
+	visitorSecretOnce sync.Once
+)
+
+// visitorTokenSecret returns the HMAC key for visitor tokens. Without VISITOR_TOKEN_SECRET a random
+// key is used, so tokens (and vote deduplication) only survive until the next restart.
+func visitorTokenSecret() []byte {
+	visitorSecretOnce.Do(func() {
+		if secret := os.Getenv("VISITOR_TOKEN_SECRET"); secret != "" {
+			visitorSecret = []byte(secret)
+			return
+		}
+		log.Println("Warning: VISITOR_TOKEN_SECRET not set, visitor tokens will reset on restart")
+		visitorSecret = make([]byte, 32)
+		if _, err := rand.Read(visitorSecret); err != nil {
+			log.Fatalf("Failed to generate visitor token secret: %v", err)
+		}
+	})
+	return visitorSecret
+}
+
+// signVisitorID returns the token for a visitor ID in the form "<id>.<signature>"
+func signVisitorID(id string) string {
+	mac := hmac.New(sha256.New, visitorTokenSecret())
+	mac.Write([]byte(id))
+	return id + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseVisitorToken returns the visitor ID of a correctly signed token
+func parseVisitorToken(token string) (string, bool) {
+	id, _, found := strings.Cut(token, ".")
+	if !found || id == "" {
+		return "", false
+	}
+	if !hmac.Equal([]byte(signVisitorID(id)), []byte(token)) {
+		return "", false
+	}
+	return id, true
+}
+
+// VisitorID identifies an anonymous public visitor for vote deduplication. Standard boards use a
+// signed cookie, issuing one on first contact. Strict privacy boards never set cookies and fall back
+// to the coarse network prefix, so visitors sharing a network share one vote.
+func VisitorID(c *gin.Context, strictPrivacy bool) string {
+	if strictPrivacy {
+		return "net:" + AnonymizeIP(c.ClientIP())
+	}
+
+	if token, err := c.Cookie(VisitorCookieName); err == nil {
+		if id, ok := parseVisitorToken(token); ok {
+			return "v:" + id
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		// Extremely unlikely; degrade to IP-based identification rather than failing the request
+		return "ip:" + c.ClientIP()
+	}
+	visitorID := base64.RawURLEncoding.EncodeToString(id)
+
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(VisitorCookieName, signVisitorID(visitorID), visitorCookieMaxAge, "/", "", secure, true)
+	return "v:" + visitorID
+}
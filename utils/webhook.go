@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// webhookTolerance bounds how far a webhook's timestamp may be from now, limiting replays
+const webhookTolerance = 5 * time.Minute
+
+// Errors returned by VerifyWebhookSignature
+var (
+	ErrWebhookSecret    = errors.New("invalid webhook signing secret")
+	ErrWebhookHeaders   = errors.New("missing webhook signature headers")
+	ErrWebhookTimestamp = errors.New("webhook timestamp is too old or too new")
+	ErrWebhookSignature = errors.New("no matching webhook signature")
+)
+
+// VerifyWebhookSignature checks a Svix-signed webhook, as sent by Clerk. The secret is the endpoint's
+// "whsec_" signing secret; the signature covers the message ID, timestamp and raw body.
+func VerifyWebhookSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil || len(key) == 0 {
+		return ErrWebhookSecret
+	}
+
+	id := header.Get("svix-id")
+	timestamp := header.Get("svix-timestamp")
+	signatures := header.Get("svix-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return ErrWebhookHeaders
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookTimestamp
+	}
+	if sent := time.Unix(seconds, 0); now.Sub(sent) > webhookTolerance || sent.Sub(now) > webhookTolerance {
+		return ErrWebhookTimestamp
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	// The header lists space-separated "v1,<signature>" entries, one per active secret
+	for _, entry := range strings.Fields(signatures) {
+		version, signature, found := strings.Cut(entry, ",")
+		if found && version == "v1" && hmac.Equal([]byte(signature), expected) {
+			return nil
+		}
+	}
+	return ErrWebhookSignature
+}
+
+// SignWebhookPayload signs an outgoing board webhook the way Svix signs incoming ones: an HMAC-SHA256,
+// keyed with the webhook's secret, over the delivery ID, timestamp and raw body, sent as "v1,<base64>"
+func SignWebhookPayload(secret, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
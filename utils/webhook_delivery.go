@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// webhookClient sends the instance's Slack, Telegram and webhook notifications
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// boardWebhookClient sends board webhooks, whose URLs board owners choose, only to public addresses
+var boardWebhookClient = NewOutboundClient(10 * time.Second)
+
+// deliverBoardWebhook makes one attempt of a board webhook job, posting its event signed with the
+// webhook's secret, and records it in the webhook's delivery log. The job's ID is the delivery ID
+// receivers see on every attempt. Webhooks deleted or disabled since the job was queued are skipped.
+func deliverBoardWebhook(ctx context.Context, job *models.NotificationJob) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var webhook models.BoardWebhook
+	err := models.GetCollection(ctx, models.BoardWebhooksCollection).FindOne(ctx, bson.M{"_id": job.Target, "board_id": job.BoardID}).Decode(&webhook)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !webhook.Enabled {
+		return nil
+	}
+
+	delivery, err := attemptBoardWebhook(webhook, job)
+	recordWebhookDelivery(ctx, delivery)
+	return err
+}
+
+// attemptBoardWebhook posts a job's event to its webhook and describes the attempt for the delivery
+// log. Failures that retrying cannot fix are returned as permanent errors; others get the time of
+// their next attempt while the job has attempts left.
+func attemptBoardWebhook(webhook models.BoardWebhook, job *models.NotificationJob) (models.WebhookDelivery, error) {
+	delivery := models.WebhookDelivery{
+		ID:         GenerateFullUUID(),
+		DeliveryID: job.ID,
+		WebhookID:  webhook.ID,
+		BoardID:    webhook.BoardID,
+		Event:      models.WebhookEventType(job.Event),
+		URL:        webhook.URL,
+		Attempt:    job.Attempts,
+	}
+
+	started := time.Now()
+	statusCode, err := postSignedWebhook(webhook, job.ID, job.Event, []byte(job.Payload))
+	delivery.DurationMs = time.Since(started).Milliseconds()
+	delivery.StatusCode = statusCode
+	delivery.Success = err == nil
+	if err != nil {
+		delivery.Error = err.Error()
+		if !retryableWebhookStatus(statusCode) || errors.Is(err, ErrOutboundURL) || errors.Is(err, ErrOutboundAddress) {
+			err = permanentError{err}
+		} else if job.Attempts < models.MaxJobAttempts {
+			nextRetryAt := time.Now().UTC().Add(notificationRetryDelay(job.Attempts))
+			delivery.NextRetryAt = &nextRetryAt
+		}
+	}
+	delivery.CreatedAt = time.Now().UTC()
+	return delivery, err
+}
+
+// postSignedWebhook makes one delivery attempt, returning the response status if there was one
+func postSignedWebhook(webhook models.BoardWebhook, deliveryID, event string, body []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	// Webhooks saved before URLs were restricted may still use http or an IP address
+	if err := checkOutboundURL(req.URL); err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Disko-Webhooks/1.0")
+	req.Header.Set("X-Disko-Webhook-Id", deliveryID)
+	req.Header.Set("X-Disko-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Disko-Webhook-Event", event)
+	req.Header.Set("X-Disko-Webhook-Signature", SignWebhookPayload(webhook.Secret, deliveryID, timestamp, body))
+
+	resp, err := boardWebhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Let the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryableWebhookStatus reports whether a failed attempt may succeed later: network errors,
+// timeouts, rate limiting and server errors are retried, other client errors are not
+func retryableWebhookStatus(statusCode int) bool {
+	switch {
+	case statusCode == 0, statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode >= 500:
+		return true
+	}
+	return false
+}
+
+// recordWebhookDelivery stores a delivery attempt in the webhook's delivery log
+func recordWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 10*time.Second)
+	defer cancel()
+
+	if _, err := models.GetCollection(ctx, models.WebhookDeliveriesCollection).InsertOne(ctx, delivery); err != nil {
+		log.Printf("Failed to record webhook delivery: %v", err)
+	}
+}
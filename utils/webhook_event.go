@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"disko-backend/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EmitWebhookEvent sends an event from the catalog to the board's enabled webhooks subscribed to
+// its type, queuing one delivery per webhook. data is the event's data object; see the catalog in
+// the README for each type's shape.
+func EmitWebhookEvent(ctx context.Context, boardID string, eventType models.WebhookEventType, data interface{}) {
+	if models.DB == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(models.DetachTenant(ctx), 10*time.Second)
+	defer cancel()
+
+	webhooks, err := models.FindEnabledBoardWebhooks(ctx, boardID)
+	if err != nil {
+		log.Printf("Failed to load webhooks for board %s: %v", boardID, err)
+		return
+	}
+	subscribed := []models.BoardWebhook{}
+	for _, webhook := range webhooks {
+		if webhook.Subscribed(eventType) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	var board models.Board
+	if err := models.GetCollection(ctx, models.BoardsCollection).FindOne(ctx, bson.M{"_id": boardID}).Decode(&board); err != nil {
+		log.Printf("Failed to load board %s for webhook event %s: %v", boardID, eventType, err)
+		return
+	}
+
+	event := &models.WebhookEvent{
+		ID:         GenerateFullUUID(),
+		Type:       eventType,
+		APIVersion: models.WebhookAPIVersion,
+		CreatedAt:  time.Now().UTC(),
+		Board:      models.WebhookEventBoard{ID: board.ID, Name: board.Name},
+		Data:       data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal webhook event %s for board %s: %v", eventType, boardID, err)
+		return
+	}
+
+	for _, webhook := range subscribed {
+		job := newNotificationJob(fmt.Sprintf("%s:%s:%s", event.ID, models.JobBoardWebhook, webhook.ID),
+			models.JobBoardWebhook, webhook.ID, boardID, string(eventType), payload)
+		if err := insertNotificationJob(ctx, job); err != nil {
+			log.Printf("Failed to queue webhook event %s for webhook %s: %v", eventType, webhook.ID, err)
+		}
+	}
+}
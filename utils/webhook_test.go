@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"disko-backend/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"idea.created","data":{"id":"idea_1"}}`)
+
+	// Computed independently: base64(HMAC-SHA256("whsec_test_secret", "delivery_123.1700000000." + body))
+	assert.Equal(t, "v1,CQYnAuLFiOXY/JYDSjQXDm2bqTCwXwSEYxY73QQGVLQ=",
+		SignWebhookPayload("whsec_test_secret", "delivery_123", "1700000000", body))
+
+	assert.NotEqual(t, SignWebhookPayload("whsec_test_secret", "delivery_123", "1700000000", body),
+		SignWebhookPayload("whsec_test_secret", "delivery_123", "1700000001", body), "the timestamp is signed")
+	assert.NotEqual(t, SignWebhookPayload("whsec_test_secret", "delivery_123", "1700000000", body),
+		SignWebhookPayload("whsec_test_secret", "delivery_124", "1700000000", body), "the delivery ID is signed")
+}
+
+// webhookReceiver is a TLS endpoint answering each delivery attempt with the next queued status
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   []string
+}
+
+// startWebhookReceiver serves the receiver at https://hooks.example.com and routes board webhooks
+// to it, since the outbound client refuses the loopback address it really listens on
+func startWebhookReceiver(t *testing.T, statuses ...int) (*webhookReceiver, string) {
+	receiver := &webhookReceiver{statuses: statuses}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		receiver.requests = append(receiver.requests, r)
+		receiver.bodies = append(receiver.bodies, string(body))
+		status := http.StatusOK
+		if len(receiver.statuses) > 0 {
+			status, receiver.statuses = receiver.statuses[0], receiver.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	client := server.Client()
+	transport := client.Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	client.Transport = transport
+
+	previous := boardWebhookClient
+	boardWebhookClient = client
+	t.Cleanup(func() { boardWebhookClient = previous })
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	return receiver, "https://hooks.example.com:" + port + "/disko"
+}
+
+func TestAttemptBoardWebhook(t *testing.T) {
+	payload := `{"event":"idea.created","data":{"id":"idea_1"}}`
+	newJob := func(attempt int) *models.NotificationJob {
+		return &models.NotificationJob{ID: "delivery_123", BoardID: "board_1", Event: "idea.created", Payload: payload, Attempts: attempt}
+	}
+
+	t.Run("Signed Delivery", func(t *testing.T) {
+		receiver, endpoint := startWebhookReceiver(t)
+		webhook := models.BoardWebhook{ID: "webhook_1", BoardID: "board_1", URL: endpoint, Secret: "whsec_test_secret"}
+
+		delivery, err := attemptBoardWebhook(webhook, newJob(1))
+		require.NoError(t, err)
+		assert.True(t, delivery.Success)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+		assert.Equal(t, "delivery_123", delivery.DeliveryID)
+		assert.Nil(t, delivery.NextRetryAt)
+
+		require.Len(t, receiver.requests, 1)
+		header := receiver.requests[0].Header
+		assert.Equal(t, payload, receiver.bodies[0])
+		assert.Equal(t, "delivery_123", header.Get("X-Disko-Webhook-Id"))
+		assert.Equal(t, "idea.created", header.Get("X-Disko-Webhook-Event"))
+		assert.Equal(t, SignWebhookPayload("whsec_test_secret", "delivery_123", header.Get("X-Disko-Webhook-Timestamp"), []byte(payload)),
+			header.Get("X-Disko-Webhook-Signature"))
+	})
+
+	t.Run("Retries With Backoff", func(t *testing.T) {
+		receiver, endpoint := startWebhookReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusInternalServerError)
+		webhook := models.BoardWebhook{ID: "webhook_1", BoardID: "board_1", URL: endpoint, Secret: "whsec_test_secret"}
+
+		for attempt, wait := range map[int]time.Duration{1: 10 * time.Second, 2: 20 * time.Second, 3: 40 * time.Second} {
+			before := time.Now().UTC()
+			delivery, err := attemptBoardWebhook(webhook, newJob(attempt))
+			require.Error(t, err)
+			assert.NotErrorAs(t, err, new(permanentError), "attempt %d", attempt)
+			assert.False(t, delivery.Success)
+			require.NotNil(t, delivery.NextRetryAt, "attempt %d", attempt)
+			assert.WithinDuration(t, before.Add(wait), *delivery.NextRetryAt, time.Second, "attempt %d", attempt)
+		}
+
+		// The last attempt is not scheduled again; the queue dead-letters the job
+		receiver.mu.Lock()
+		receiver.statuses = []int{http.StatusBadGateway}
+		receiver.mu.Unlock()
+		delivery, err := attemptBoardWebhook(webhook, newJob(models.MaxJobAttempts))
+		require.Error(t, err)
+		assert.Nil(t, delivery.NextRetryAt)
+
+		// A later attempt that succeeds ends the retries
+		delivery, err = attemptBoardWebhook(webhook, newJob(2))
+		require.NoError(t, err)
+		assert.True(t, delivery.Success)
+		assert.Nil(t, delivery.NextRetryAt)
+
+		// Every attempt carries the same delivery ID
+		for _, request := range receiver.requests {
+			assert.Equal(t, "delivery_123", request.Header.Get("X-Disko-Webhook-Id"))
+		}
+	})
+
+	t.Run("Client Errors Are Permanent", func(t *testing.T) {
+		_, endpoint := startWebhookReceiver(t, http.StatusGone)
+		webhook := models.BoardWebhook{ID: "webhook_1", BoardID: "board_1", URL: endpoint, Secret: "whsec_test_secret"}
+
+		delivery, err := attemptBoardWebhook(webhook, newJob(1))
+		assert.ErrorAs(t, err, new(permanentError))
+		assert.Equal(t, http.StatusGone, delivery.StatusCode)
+		assert.Nil(t, delivery.NextRetryAt)
+	})
+
+	t.Run("Local Addresses Are Permanent", func(t *testing.T) {
+		webhook := models.BoardWebhook{ID: "webhook_1", BoardID: "board_1", URL: "https://127.0.0.1/disko", Secret: "whsec_test_secret"}
+
+		delivery, err := attemptBoardWebhook(webhook, newJob(1))
+		assert.ErrorAs(t, err, new(permanentError))
+		assert.Contains(t, delivery.Error, ErrOutboundAddress.Error())
+	})
+}
@@ -0,0 +1,280 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// pushRecordSize is the aes128gcm record size; messages are sent as a single record
+	pushRecordSize = 4096
+	// pushMaxPayload bounds a push message's plaintext so it fits a record with its padding delimiter
+	// and authentication tag
+	pushMaxPayload = pushRecordSize - 17
+	// vapidTokenLifetime is how long a VAPID token is valid; push services reject more than 24 hours
+	vapidTokenLifetime = 12 * time.Hour
+)
+
+// Errors returned by SendPushMessage before contacting the push service; retrying cannot fix them
+var (
+	ErrPushNotConfigured  = errors.New("web push is not configured")
+	ErrInvalidPushMessage = errors.New("invalid push message")
+)
+
+// pushClient sends Web Push messages. Browsers report their push service endpoint, so like other
+// URLs users provide it only reaches public addresses.
+var pushClient = NewOutboundClient(10 * time.Second)
+
+// PushMessage is a Web Push message to one browser
+type PushMessage struct {
+	Endpoint string
+	P256dh   string // The browser's public key, base64url
+	Auth     string // The browser's authentication secret, base64url
+	Payload  []byte
+	TTL      time.Duration // How long the push service keeps the message while the browser is offline
+	Urgency  string        // very-low, low, normal or high
+}
+
+// VAPIDPublicKey returns the application server key browsers subscribe with, base64url encoded,
+// or "" when Web Push is not configured
+func VAPIDPublicKey() string {
+	if os.Getenv("VAPID_PRIVATE_KEY") == "" {
+		return ""
+	}
+	return os.Getenv("VAPID_PUBLIC_KEY")
+}
+
+// vapidKey parses VAPID_PRIVATE_KEY, the raw P-256 private scalar base64url encoded as web-push
+// tools generate it
+func vapidKey() (*ecdsa.PrivateKey, error) {
+	if os.Getenv("VAPID_PUBLIC_KEY") == "" || os.Getenv("VAPID_PRIVATE_KEY") == "" {
+		return nil, ErrPushNotConfigured
+	}
+	raw, err := decodePushKey(os.Getenv("VAPID_PRIVATE_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID_PRIVATE_KEY: %v", err)
+	}
+	private, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID_PRIVATE_KEY: %v", err)
+	}
+
+	public := private.PublicKey().Bytes() // 0x04 || X || Y
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}, nil
+}
+
+// decodePushKey decodes a base64url key, padded or not
+func decodePushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
+
+// ValidatePushKeys checks that a browser's subscription keys can encrypt messages
+func ValidatePushKeys(p256dh, auth string) error {
+	raw, err := decodePushKey(p256dh)
+	if err != nil {
+		return errors.New("p256dh is not base64url encoded")
+	}
+	if _, err := ecdh.P256().NewPublicKey(raw); err != nil {
+		return errors.New("p256dh is not an uncompressed P-256 public key")
+	}
+	if secret, err := decodePushKey(auth); err != nil || len(secret) != 16 {
+		return errors.New("auth is not a base64url encoded 16-byte secret")
+	}
+	return nil
+}
+
+// vapidAuthorization builds the Authorization header identifying this server to the push service
+// of an endpoint (RFC 8292)
+func vapidAuthorization(endpoint string, now time.Time) (string, error) {
+	key, err := vapidKey()
+	if err != nil {
+		return "", err
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	subject := os.Getenv("VAPID_SUBJECT")
+	if subject == "" {
+		subject = "mailto:" + os.Getenv("FROM_EMAIL")
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": parsed.Scheme + "://" + parsed.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return fmt.Sprintf("vapid t=%s, k=%s", token, strings.TrimRight(os.Getenv("VAPID_PUBLIC_KEY"), "=")), nil
+}
+
+// encryptPushPayload encrypts a payload for a browser with the aes128gcm content encoding (RFC 8291)
+func encryptPushPayload(payload []byte, p256dh, auth string) ([]byte, error) {
+	if len(payload) > pushMaxPayload {
+		return nil, fmt.Errorf("push payload is %d bytes, above the %d byte limit", len(payload), pushMaxPayload)
+	}
+	userAgentRaw, err := decodePushKey(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %v", err)
+	}
+	userAgentKey, err := ecdh.P256().NewPublicKey(userAgentRaw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %v", err)
+	}
+	authSecret, err := decodePushKey(auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, errors.New("invalid auth secret")
+	}
+
+	// A fresh key pair and salt per message
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	return sealPushPayload(payload, userAgentKey, authSecret, serverKey, salt)
+}
+
+// sealPushPayload encrypts a payload as a single aes128gcm record with the given server key and salt
+func sealPushPayload(payload []byte, userAgentKey *ecdh.PublicKey, authSecret []byte, serverKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgentRaw := userAgentKey.Bytes()
+	serverPublic := serverKey.PublicKey().Bytes()
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentRaw...)
+	keyInfo = append(keyInfo, serverPublic...)
+	ikm := hkdfExpand(hkdfExtract(authSecret, sharedSecret), keyInfo, 32)
+
+	prk := hkdfExtract(salt, ikm)
+	contentKey := hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, key ID length and the server's public key as the key ID
+	var body bytes.Buffer
+	body.Write(salt)
+	binary.Write(&body, binary.BigEndian, uint32(pushRecordSize))
+	body.WriteByte(byte(len(serverPublic)))
+	body.Write(serverPublic)
+
+	// The single, last record ends with the 0x02 padding delimiter
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	body.Write(gcm.Seal(nil, nonce, plaintext, nil))
+	return body.Bytes(), nil
+}
+
+// hkdfExtract is the HKDF-SHA256 extract step (RFC 5869)
+func hkdfExtract(salt, ikm []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
+
+// hkdfExpand is the HKDF-SHA256 expand step for outputs of at most one hash length
+func hkdfExpand(prk, info []byte, length int) []byte {
+	mac := hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+// SendPushMessage encrypts a message and posts it to the browser's push service, returning the
+// response status if there was one. Push services answer 404 or 410 for subscriptions that
+// expired or were revoked.
+func SendPushMessage(message PushMessage) (int, error) {
+	body, err := encryptPushPayload(message.Payload, message.P256dh, message.Auth)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidPushMessage, err)
+	}
+	authorization, err := vapidAuthorization(message.Endpoint, time.Now())
+	if err != nil {
+		if errors.Is(err, ErrPushNotConfigured) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%w: %v", ErrInvalidPushMessage, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, message.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidPushMessage, err)
+	}
+	// Subscriptions saved before endpoints were restricted may point anywhere
+	if err := checkOutboundURL(req.URL); err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(message.TTL.Seconds())))
+	if message.Urgency != "" {
+		req.Header.Set("Urgency", message.Urgency)
+	}
+
+	resp, err := pushClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Let the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
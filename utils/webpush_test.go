@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The example of RFC 8291 section 5 and its appendix A
+const (
+	rfc8291Plaintext       = "When I grow up, I want to be a watermelon"
+	rfc8291ServerPrivate   = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfc8291UserAgentPublic = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfc8291UserAgentSecret = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	rfc8291Salt            = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfc8291AuthSecret      = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfc8291Message         = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+// mustDecodePushKey decodes a base64url test vector
+func mustDecodePushKey(t *testing.T, key string) []byte {
+	t.Helper()
+	raw, err := decodePushKey(key)
+	require.NoError(t, err)
+	return raw
+}
+
+// openPushPayload decrypts a single-record aes128gcm message as the browser does
+func openPushPayload(t *testing.T, message []byte, userAgentKey *ecdh.PrivateKey, authSecret []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(message), 86)
+	salt := message[:16]
+	assert.Equal(t, uint32(pushRecordSize), binary.BigEndian.Uint32(message[16:20]))
+	keyIDLength := int(message[20])
+	serverPublic, err := ecdh.P256().NewPublicKey(message[21 : 21+keyIDLength])
+	require.NoError(t, err)
+
+	sharedSecret, err := userAgentKey.ECDH(serverPublic)
+	require.NoError(t, err)
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentKey.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, serverPublic.Bytes()...)
+	ikm := hkdfExpand(hkdfExtract(authSecret, sharedSecret), keyInfo, 32)
+
+	prk := hkdfExtract(salt, ikm)
+	block, err := aes.NewCipher(hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16))
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12), message[21+keyIDLength:], nil)
+	require.NoError(t, err)
+
+	// Strip the padding up to and including the last record's delimiter
+	end := strings.LastIndexByte(string(plaintext), 0x02)
+	require.GreaterOrEqual(t, end, 0)
+	return plaintext[:end]
+}
+
+func TestEncryptPushPayload(t *testing.T) {
+	userAgentPublic, err := ecdh.P256().NewPublicKey(mustDecodePushKey(t, rfc8291UserAgentPublic))
+	require.NoError(t, err)
+	authSecret := mustDecodePushKey(t, rfc8291AuthSecret)
+
+	t.Run("RFC 8291 Example", func(t *testing.T) {
+		serverKey, err := ecdh.P256().NewPrivateKey(mustDecodePushKey(t, rfc8291ServerPrivate))
+		require.NoError(t, err)
+
+		message, err := sealPushPayload([]byte(rfc8291Plaintext), userAgentPublic, authSecret, serverKey, mustDecodePushKey(t, rfc8291Salt))
+		require.NoError(t, err)
+		assert.Equal(t, rfc8291Message, base64.RawURLEncoding.EncodeToString(message))
+	})
+
+	t.Run("Browser Decrypts Message", func(t *testing.T) {
+		userAgentKey, err := ecdh.P256().NewPrivateKey(mustDecodePushKey(t, rfc8291UserAgentSecret))
+		require.NoError(t, err)
+
+		message, err := encryptPushPayload([]byte(`{"title":"Idea released"}`), rfc8291UserAgentPublic, rfc8291AuthSecret)
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"Idea released"}`, string(openPushPayload(t, message, userAgentKey, authSecret)))
+
+		again, err := encryptPushPayload([]byte(`{"title":"Idea released"}`), rfc8291UserAgentPublic, rfc8291AuthSecret)
+		require.NoError(t, err)
+		assert.NotEqual(t, message, again, "each message uses a fresh key and salt")
+	})
+
+	t.Run("Rejects Invalid Input", func(t *testing.T) {
+		_, err := encryptPushPayload(make([]byte, pushMaxPayload+1), rfc8291UserAgentPublic, rfc8291AuthSecret)
+		assert.Error(t, err)
+		_, err = encryptPushPayload([]byte("hi"), rfc8291AuthSecret, rfc8291AuthSecret)
+		assert.Error(t, err)
+		_, err = encryptPushPayload([]byte("hi"), rfc8291UserAgentPublic, rfc8291Salt+"AA")
+		assert.Error(t, err)
+	})
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	private, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKey := base64.RawURLEncoding.EncodeToString(private.PublicKey().Bytes())
+	t.Setenv("VAPID_PRIVATE_KEY", base64.RawURLEncoding.EncodeToString(private.Bytes()))
+	t.Setenv("VAPID_PUBLIC_KEY", publicKey)
+	t.Setenv("VAPID_SUBJECT", "mailto:push@example.com")
+
+	now := time.Unix(1700000000, 0)
+	header, err := vapidAuthorization("https://push.example.net/send/abc?x=1", now)
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(header, "vapid t="))
+	token, key, found := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	require.True(t, found)
+	assert.Equal(t, publicKey, key)
+
+	// Verify the token with an independent JWS implementation against the advertised key
+	raw := private.PublicKey().Bytes()
+	verifier := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(raw[1:33]), Y: new(big.Int).SetBytes(raw[33:])}
+	signed, err := jose.ParseSigned(token)
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures, 1)
+	assert.Equal(t, "ES256", signed.Signatures[0].Header.Algorithm)
+	payload, err := signed.Verify(verifier)
+	require.NoError(t, err)
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "https://push.example.net", claims.Aud)
+	assert.Equal(t, now.Add(vapidTokenLifetime).Unix(), claims.Exp)
+	assert.Equal(t, "mailto:push@example.com", claims.Sub)
+
+	t.Run("Not Configured", func(t *testing.T) {
+		t.Setenv("VAPID_PRIVATE_KEY", "")
+		_, err := vapidAuthorization("https://push.example.net/send/abc", now)
+		assert.ErrorIs(t, err, ErrPushNotConfigured)
+	})
+}
+
+func TestSendPushMessageRefusesPrivateEndpoints(t *testing.T) {
+	t.Setenv("VAPID_PUBLIC_KEY", rfc8291UserAgentPublic)
+	t.Setenv("VAPID_PRIVATE_KEY", rfc8291UserAgentSecret)
+
+	message := PushMessage{P256dh: rfc8291UserAgentPublic, Auth: rfc8291AuthSecret, Payload: []byte("{}"), TTL: time.Hour}
+
+	message.Endpoint = "https://127.0.0.1/push/abc"
+	_, err := SendPushMessage(message)
+	assert.ErrorIs(t, err, ErrOutboundAddress)
+
+	message.Endpoint = "http://93.184.216.34/push/abc"
+	_, err = SendPushMessage(message)
+	assert.ErrorIs(t, err, ErrOutboundURL)
+}
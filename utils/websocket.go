@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"context"
+	"encoding/json"
 	"log"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,9 +15,78 @@ import (
 
 // WebSocketManager manages WebSocket connections
 type WebSocketManager struct {
-	connections map[string]map[*websocket.Conn]bool // boardID -> connections
-	mutex       sync.RWMutex
-	upgrader    websocket.Upgrader
+	connections      map[string]map[*boardClient]bool     // boardID -> connections
+	userStreams      map[string]map[*boardClient]bool     // userID -> notification streams
+	remoteViewers    map[string]map[string]*remoteViewers // boardID -> instance ID -> viewers there
+	mutex            sync.RWMutex
+	upgrader         websocket.Upgrader
+	broker           Broker                    // Nil when running a single instance
+	outbox           chan []byte               // Events waiting to be published to the broker
+	eventLogs        map[string]*boardEventLog // boardID -> sequenced recent events
+	logMu            sync.Mutex
+	instanceID       string
+	limits           connectionLimits
+	ipConnections    map[string]int // Client IP -> admitted connections, guarded by mutex
+	boardConnections map[string]int // boardID -> admitted connections, guarded by mutex
+	batchInterval    time.Duration  // Zero sends every event on its own
+	batches          map[string]*boardBatch
+	batchMu          sync.Mutex
+	counters         realtimeCounters
+}
+
+// Heartbeat timing: the server pings every connection and drops those that stop answering
+const (
+	wsPongWait           = 60 * time.Second // Time allowed between messages or pongs from the client
+	wsPingPeriod         = (wsPongWait * 9) / 10
+	wsWriteWait          = 10 * time.Second
+	wsMaxMessageSize     = 4096 // Clients only send small control messages
+	staleSweepInterval   = time.Minute
+	staleConnectionAfter = 2 * wsPongWait
+)
+
+// boardClient is a connection streaming a board's events, over WebSocket or Server-Sent Events
+type boardClient struct {
+	userID  string                   // Empty for public visitors
+	writeMu sync.Mutex               // Orders enqueued messages, so a greeting's replay precedes live events
+	queue   chan []*WebSocketMessage // Outbound messages, written by the connection's writer
+	write   func(message *WebSocketMessage) error
+	// writeBatch writes several messages at once; when nil they are wrapped in one batch message
+	writeBatch func(messages []*WebSocketMessage) error
+	close      func()        // Ends the connection; its serve loop then leaves the board
+	recheck    chan struct{} // Asks the connection to check its access now
+	lastSeen   atomic.Int64
+	filterMu   sync.RWMutex // Guards filter and editing
+	filter     subscription
+	editing    map[string]bool // Ideas the member announced editing
+}
+
+// touch records that the connection is alive
+func (bc *boardClient) touch() {
+	bc.lastSeen.Store(time.Now().UnixNano())
+}
+
+// stale reports whether the connection has not shown signs of life since the cutoff
+func (bc *boardClient) stale(cutoff time.Time) bool {
+	return bc.lastSeen.Load() < cutoff.UnixNano()
+}
+
+// member reports whether the client connected as a board member
+func (bc *boardClient) member() bool {
+	return bc.userID != ""
+}
+
+// send queues a message for the client
+func (bc *boardClient) send(message *WebSocketMessage) error {
+	bc.writeMu.Lock()
+	defer bc.writeMu.Unlock()
+	return bc.enqueue([]*WebSocketMessage{message})
+}
+
+// sendBatch queues coalesced messages for the client, written as one frame when there are several
+func (bc *boardClient) sendBatch(messages []*WebSocketMessage) error {
+	bc.writeMu.Lock()
+	defer bc.writeMu.Unlock()
+	return bc.enqueue(messages)
 }
 
 // WebSocketMessage represents a WebSocket message
@@ -23,8 +95,35 @@ type WebSocketMessage struct {
 	BoardID string      `json:"boardId,omitempty"`
 	IdeaID  string      `json:"ideaId,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Seq     uint64      `json:"seq,omitempty"` // Per-board sequence number of replayable events
 }
 
+// clientMessage is a message received from a WebSocket client; data depends on the type
+type clientMessage struct {
+	Type   string          `json:"type"`
+	ID     string          `json:"id,omitempty"`     // Client-chosen ID of an operation, echoed in its result
+	IdeaID string          `json:"ideaId,omitempty"` // Idea an operation applies to
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+// Operations board members can send over the WebSocket instead of calling the REST API
+const (
+	OperationMoveIdea     = "move_idea"     // data as PUT /api/ideas/:id/position
+	OperationUpdateStatus = "update_status" // data as PUT /api/ideas/:id/status
+	EventOperationResult  = "operation_result"
+)
+
+// ClientOperation is a change a board member requested over the WebSocket
+type ClientOperation struct {
+	Type   string
+	IdeaID string
+	Data   json.RawMessage
+}
+
+// OperationHandler validates and applies a client operation like the matching REST endpoint,
+// returning its HTTP status and response body
+type OperationHandler func(op ClientOperation) (int, interface{})
+
 // FeedbackAnimation represents feedback animation data
 type FeedbackAnimation struct {
 	IdeaID       string `json:"ideaId"`
@@ -33,28 +132,58 @@ type FeedbackAnimation struct {
 	Timestamp    int64  `json:"timestamp"`
 }
 
+// WebSocketAuthProtocol is the subprotocol a client offers, followed by its session token, to
+// connect as a board member. Browsers cannot set headers on the handshake, and a subprotocol keeps
+// the token out of URLs and access logs.
+const WebSocketAuthProtocol = "disko-auth"
+
 var wsManager *WebSocketManager
 
 // InitWebSocketManager initializes the WebSocket manager
 func InitWebSocketManager() {
 	wsManager = &WebSocketManager{
-		connections: make(map[string]map[*websocket.Conn]bool),
+		connections:      make(map[string]map[*boardClient]bool),
+		userStreams:      make(map[string]map[*boardClient]bool),
+		remoteViewers:    make(map[string]map[string]*remoteViewers),
+		eventLogs:        make(map[string]*boardEventLog),
+		instanceID:       GenerateFullUUID(),
+		limits:           connectionLimitsFromEnv(),
+		ipConnections:    make(map[string]int),
+		boardConnections: make(map[string]int),
+		batchInterval:    batchIntervalFromEnv(),
+		batches:          make(map[string]*boardBatch),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				// In production, implement proper origin checking
 				return true
 			},
+			Subprotocols: []string{WebSocketAuthProtocol},
 		},
 	}
+
+	go wsManager.sweepStaleConnections()
 }
 
-// HandleWebSocket handles WebSocket connections
-func HandleWebSocket(c *gin.Context) {
-	boardID := c.Param("boardId")
-	if boardID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Board ID required"})
-		return
+// WebSocketToken returns the session token offered with the auth subprotocol, if any
+func WebSocketToken(r *http.Request) string {
+	protocols := websocket.Subprotocols(r)
+	for i, protocol := range protocols {
+		if protocol == WebSocketAuthProtocol && i+1 < len(protocols) {
+			return protocols[i+1]
+		}
 	}
+	return ""
+}
+
+// ServeBoardSocket upgrades the request and streams the board's events until the client disconnects.
+// The caller authorizes the connection first and passes authorize to repeat the check while it stays
+// open; sockets refused later are closed with 1008. userID is empty for public visitors, and member
+// sockets also receive private board events and may send operations, which operate applies.
+// Reconnecting clients pass the epoch and since query parameters to receive the events they missed.
+// Connections over the limits are closed with 1013 (try again later), and clients sending messages
+// faster than allowed with 1008 (policy violation).
+func ServeBoardSocket(c *gin.Context, boardID, userID string, operate OperationHandler, authorize StreamAuthorizer) {
+	resume := ParseResumePoint(c.Query("epoch"), c.Query("since"))
 
 	conn, err := wsManager.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -63,15 +192,58 @@ func HandleWebSocket(c *gin.Context) {
 	}
 	defer conn.Close()
 
+	// The socket is upgraded first so a rejected client gets a close code rather than a failed handshake
+	ip := c.ClientIP()
+	if err := wsManager.admit(boardID, ip); err != nil {
+		wsManager.counters.rejectedConnections.Add(1)
+		log.Printf("WebSocket rejected for board: %s, IP: %s, Reason: %v", boardID, ip, err)
+		closeSocket(conn, websocket.CloseTryAgainLater, err.Error())
+		return
+	}
+	defer wsManager.release(boardID, ip)
+
 	// Add connection to manager
-	wsManager.addConnection(boardID, conn)
-	defer wsManager.removeConnection(boardID, conn)
+	client := &boardClient{
+		userID: userID,
+		queue:  make(chan []*WebSocketMessage, clientQueueSize),
+		write: func(message *WebSocketMessage) error {
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			return conn.WriteJSON(message)
+		},
+		close:   func() { conn.Close() },
+		recheck: make(chan struct{}, 1),
+	}
+	client.touch()
+
+	stopWriter := make(chan struct{})
+	defer close(stopWriter)
+	go writeSocket(conn, client, stopWriter)
+
+	wsManager.join(boardID, client, resume)
+	defer wsManager.leave(boardID, client)
+	defer wsManager.stopEditing(boardID, client)
+
+	watchCtx, stopWatch := context.WithCancel(c.Request.Context())
+	defer stopWatch()
+	go watchAccess(watchCtx, client, authorize, func() {
+		log.Printf("WebSocket closed for revoked access for board: %s, IP: %s", boardID, ip)
+		closeSocket(conn, websocket.ClosePolicyViolation, "access revoked")
+	})
 
-	log.Printf("WebSocket connected for board: %s", boardID)
+	log.Printf("WebSocket connected for board: %s, Member: %t", boardID, client.member())
+
+	// Any message or pong keeps the connection alive; silence past the deadline ends the read loop
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		client.touch()
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
 
 	// Handle incoming messages (ping/pong, etc.)
+	var budget messageBudget
 	for {
-		var msg WebSocketMessage
+		var msg clientMessage
 		err := conn.ReadJSON(&msg)
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
@@ -79,69 +251,164 @@ func HandleWebSocket(c *gin.Context) {
 			}
 			break
 		}
+		client.touch()
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		if !budget.take(wsManager.limits) {
+			wsManager.counters.rateLimited.Add(1)
+			log.Printf("WebSocket closed for message rate for board: %s, IP: %s", boardID, ip)
+			closeSocket(conn, websocket.ClosePolicyViolation, "message rate limit exceeded")
+			break
+		}
 
 		// Handle different message types
 		switch msg.Type {
 		case "ping":
-			conn.WriteJSON(WebSocketMessage{Type: "pong"})
+			client.send(&WebSocketMessage{Type: "pong"})
+		case ClientSubscribe, ClientUnsubscribe:
+			var change SubscriptionFilter
+			if err := json.Unmarshal(msg.Data, &change); err != nil {
+				client.send(&WebSocketMessage{Type: "error", Data: map[string]interface{}{"message": "Invalid subscription filter"}})
+				continue
+			}
+			current := client.updateFilter(change, msg.Type == ClientSubscribe)
+			client.send(&WebSocketMessage{Type: EventSubscribed, BoardID: boardID, Data: current})
+		case ClientEditing:
+			var update EditingUpdate
+			if !client.member() || json.Unmarshal(msg.Data, &update) != nil || !wsManager.setEditing(boardID, client, msg.IdeaID, update.Editing) {
+				client.send(&WebSocketMessage{Type: "error", Data: map[string]interface{}{"message": "Invalid editing indicator"}})
+			}
+		case OperationMoveIdea, OperationUpdateStatus:
+			status, body := http.StatusForbidden, interface{}(map[string]interface{}{
+				"error": map[string]interface{}{
+					"code":    "OPERATION_FORBIDDEN",
+					"message": "Only board members can change ideas",
+				},
+			})
+			if client.member() && operate != nil {
+				status, body = operate(ClientOperation{Type: msg.Type, IdeaID: msg.IdeaID, Data: msg.Data})
+			}
+			client.send(&WebSocketMessage{
+				Type:    EventOperationResult,
+				BoardID: boardID,
+				IdeaID:  msg.IdeaID,
+				Data: map[string]interface{}{
+					"id":     msg.ID,
+					"ok":     status < http.StatusBadRequest,
+					"status": status,
+					"result": body,
+				},
+			})
 		}
 	}
 }
 
-// addConnection adds a WebSocket connection for a board
-func (wsm *WebSocketManager) addConnection(boardID string, conn *websocket.Conn) {
+// sweepStaleConnections periodically closes connections that stopped answering, such as tabs
+// that vanished without closing their socket. Closing ends their serve loops, which leave the board.
+// Expired replay events and idle batches are dropped on the same schedule.
+func (wsm *WebSocketManager) sweepStaleConnections() {
+	ticker := time.NewTicker(staleSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-staleConnectionAfter)
+
+		wsm.mutex.RLock()
+		var stale []*boardClient
+		for _, clients := range wsm.connections {
+			for client := range clients {
+				if client.stale(cutoff) {
+					stale = append(stale, client)
+				}
+			}
+		}
+		for _, clients := range wsm.userStreams {
+			for client := range clients {
+				if client.stale(cutoff) {
+					stale = append(stale, client)
+				}
+			}
+		}
+		wsm.mutex.RUnlock()
+
+		for _, client := range stale {
+			client.close()
+		}
+		wsm.pruneEventLogs()
+		wsm.pruneBatches()
+		if len(stale) > 0 {
+			log.Printf("WebSocket sweep closed %d stale connections", len(stale))
+		}
+	}
+}
+
+// addConnection adds a client to a board and reports whether it is the user's first connection
+func (wsm *WebSocketManager) addConnection(boardID string, client *boardClient) bool {
 	wsm.mutex.Lock()
 	defer wsm.mutex.Unlock()
 
 	if wsm.connections[boardID] == nil {
-		wsm.connections[boardID] = make(map[*websocket.Conn]bool)
+		wsm.connections[boardID] = make(map[*boardClient]bool)
 	}
-	wsm.connections[boardID][conn] = true
+	first := !client.member() || !wsm.hasUserLocked(boardID, client.userID)
+	wsm.connections[boardID][client] = true
+	return first
 }
 
-// removeConnection removes a WebSocket connection
-func (wsm *WebSocketManager) removeConnection(boardID string, conn *websocket.Conn) {
+// removeConnection removes a client from a board and reports whether it was the user's last connection
+func (wsm *WebSocketManager) removeConnection(boardID string, client *boardClient) bool {
 	wsm.mutex.Lock()
 	defer wsm.mutex.Unlock()
 
-	if wsm.connections[boardID] != nil {
-		delete(wsm.connections[boardID], conn)
-		if len(wsm.connections[boardID]) == 0 {
-			delete(wsm.connections, boardID)
+	if !wsm.connections[boardID][client] {
+		return false
+	}
+	delete(wsm.connections[boardID], client)
+	if len(wsm.connections[boardID]) == 0 {
+		delete(wsm.connections, boardID)
+	}
+	return !client.member() || !wsm.hasUserLocked(boardID, client.userID)
+}
+
+// hasUserLocked reports whether a user has a connection to the board; the caller holds the mutex
+func (wsm *WebSocketManager) hasUserLocked(boardID, userID string) bool {
+	for client := range wsm.connections[boardID] {
+		if client.userID == userID {
+			return true
 		}
 	}
+	return false
 }
 
 // BroadcastToBoard sends a message to all connections for a specific board
 func (wsm *WebSocketManager) BroadcastToBoard(boardID string, message WebSocketMessage) {
-	wsm.mutex.RLock()
-	connections := wsm.connections[boardID]
-	wsm.mutex.RUnlock()
+	wsm.publish(boardID, &message, &message)
+}
 
-	if connections == nil {
-		return
-	}
+// BroadcastToMembers sends a message only to the board members' connections
+func (wsm *WebSocketManager) BroadcastToMembers(boardID string, message WebSocketMessage) {
+	wsm.publish(boardID, &message, nil)
+}
 
-	// Create a copy of connections to avoid holding the lock during broadcast
-	connList := make([]*websocket.Conn, 0, len(connections))
-	for conn := range connections {
-		connList = append(connList, conn)
-	}
+// publish delivers a board's messages to the connections here and, through the broker, on the
+// other instances. A nil message skips that audience.
+func (wsm *WebSocketManager) publish(boardID string, memberMessage, publicMessage *WebSocketMessage) {
+	wsm.counters.eventsPublished.Add(1)
+	wsm.relay(&brokerEnvelope{BoardID: boardID, Member: memberMessage, Public: publicMessage})
+	memberMessage, publicMessage = wsm.sequence(boardID, memberMessage, publicMessage)
+	wsm.broadcast(boardID, memberMessage, publicMessage)
+}
 
-	// Broadcast to all connections
-	for _, conn := range connList {
-		err := conn.WriteJSON(message)
-		if err != nil {
-			log.Printf("WebSocket write error: %v", err)
-			// Remove failed connection
-			wsm.removeConnection(boardID, conn)
-			conn.Close()
-		}
-	}
+// publishTransient delivers messages like publish, without sequencing them for replay. It suits
+// momentary state such as editing indicators, which would be stale by the time a client resumes.
+func (wsm *WebSocketManager) publishTransient(boardID string, memberMessage, publicMessage *WebSocketMessage) {
+	wsm.counters.eventsPublished.Add(1)
+	wsm.relay(&brokerEnvelope{BoardID: boardID, Member: memberMessage, Public: publicMessage, Transient: true})
+	wsm.broadcast(boardID, memberMessage, publicMessage)
 }
 
 // BroadcastFeedbackAnimation broadcasts feedback animation to admin board
-func BroadcastFeedbackAnimation(boardID, ideaID, feedbackType string, emoji string) {
+func BroadcastFeedbackAnimation(ctx context.Context, boardID, ideaID, feedbackType string, emoji string) {
+	SchedulePublicBoardRefresh(ctx, boardID)
 	if wsManager == nil {
 		return
 	}
@@ -166,7 +433,8 @@ func BroadcastFeedbackAnimation(boardID, ideaID, feedbackType string, emoji stri
 }
 
 // BroadcastIdeaUpdate broadcasts idea updates to all board connections
-func BroadcastIdeaUpdate(boardID, ideaID string, updateData interface{}) {
+func BroadcastIdeaUpdate(ctx context.Context, boardID, ideaID string, updateData interface{}) {
+	SchedulePublicBoardRefresh(ctx, boardID)
 	if wsManager == nil {
 		return
 	}
@@ -181,6 +449,101 @@ func BroadcastIdeaUpdate(boardID, ideaID string, updateData interface{}) {
 	wsManager.BroadcastToBoard(boardID, message)
 }
 
+// BroadcastCommentEvent notifies board connections about a new comment. Only IDs are sent, and
+// pending comments are only announced to board members until approved.
+func BroadcastCommentEvent(boardID, ideaID, commentID, status string) {
+	if wsManager == nil {
+		return
+	}
+
+	messageType := "comment_added"
+	if status == "pending" {
+		messageType = "comment_pending"
+	}
+
+	message := WebSocketMessage{
+		Type:    messageType,
+		BoardID: boardID,
+		IdeaID:  ideaID,
+		Data: map[string]interface{}{
+			"commentId": commentID,
+			"timestamp": getCurrentTimestamp(),
+		},
+	}
+
+	if status == "pending" {
+		wsManager.BroadcastToMembers(boardID, message)
+		return
+	}
+	wsManager.BroadcastToBoard(boardID, message)
+}
+
+// EventNotification is an in-app notification addressed to one member
+const EventNotification = "notification"
+
+// SendUserNotification delivers an in-app notification to a member's open connections to the board,
+// on every instance. It is not sequenced or replayed.
+func SendUserNotification(boardID, userID string, data interface{}) {
+	if wsManager == nil {
+		return
+	}
+
+	message := &WebSocketMessage{
+		Type:    EventNotification,
+		BoardID: boardID,
+		Data:    data,
+	}
+	wsManager.counters.eventsPublished.Add(1)
+	wsManager.relay(&brokerEnvelope{BoardID: boardID, Member: message, Transient: true, Recipient: userID})
+	wsManager.queueBroadcast(boardID, pendingBroadcast{member: message, recipient: userID})
+}
+
+// Idea and board lifecycle events sent with BroadcastBoardEvent
+const (
+	EventIdeaCreated  = "idea_created"
+	EventIdeaUpdated  = "idea_updated"
+	EventIdeaDeleted  = "idea_deleted"
+	EventIdeasUpdated = "ideas_updated" // Bulk changes; clients reload the board's ideas
+	EventBoardUpdated = "board_updated"
+	EventBoardDeleted = "board_deleted"
+)
+
+// BroadcastBoardEvent announces an idea or board lifecycle event. Members receive the details,
+// while public connections only learn what changed and refetch the public view, which applies the
+// board's visibility settings. Every event also refreshes the board's public read model, right away
+// when the board itself changed so a board made private stops being served.
+func BroadcastBoardEvent(ctx context.Context, boardID, eventType, ideaID string, details interface{}) {
+	if eventType == EventBoardUpdated || eventType == EventBoardDeleted {
+		go RefreshPublicBoard(ctx, boardID)
+	} else {
+		SchedulePublicBoardRefresh(ctx, boardID)
+	}
+	if wsManager == nil {
+		return
+	}
+
+	timestamp := getCurrentTimestamp()
+	memberMessage := WebSocketMessage{
+		Type:    eventType,
+		BoardID: boardID,
+		IdeaID:  ideaID,
+		Data: map[string]interface{}{
+			"details":   details,
+			"timestamp": timestamp,
+		},
+	}
+	publicMessage := WebSocketMessage{
+		Type:    eventType,
+		BoardID: boardID,
+		IdeaID:  ideaID,
+		Data: map[string]interface{}{
+			"timestamp": timestamp,
+		},
+	}
+
+	wsManager.publish(boardID, &memberMessage, &publicMessage)
+}
+
 // getCurrentTimestamp returns current timestamp in milliseconds
 func getCurrentTimestamp() int64 {
 	return time.Now().UnixNano() / int64(time.Millisecond)